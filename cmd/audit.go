@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/audit"
+	"github.com/supabase/cli/internal/audit/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+var (
+	auditCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "audit",
+		Short:   "Inspect the audit log of CLI commands",
+	}
+
+	auditListParams list.RunParams
+	auditListOutput = utils.EnumFlag{
+		Allowed: utils.OutputDefaultAllowed,
+		Value:   utils.OutputPretty,
+	}
+
+	auditListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List mutating commands run by this machine",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return list.Run(cmd.Context(), auditListParams, auditListOutput.Value, afero.NewOsFs())
+		},
+	}
+)
+
+// Opts a command that changes remote projects or databases into the audit log. Commands
+// that only change state when some flags are set, ie. inspect db locks --kill, pass those
+// flag names instead.
+func auditAnnotations(flagNames ...string) map[string]string {
+	value := "true"
+	if len(flagNames) > 0 {
+		value = strings.Join(flagNames, ",")
+	}
+	return map[string]string{audit.AnnotationRecord: value}
+}
+
+// Failures to record are reported as warnings so that they never fail the command itself.
+func recordAudit(cmd *cobra.Command, cmdErr error) {
+	if !audit.ShouldRecord(cmd.Annotations, cmd.Flags()) {
+		return
+	}
+	// Skip commands that were not run, ie. with --help
+	if help, _ := cmd.Flags().GetBool("help"); help {
+		return
+	}
+	command := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	entry := audit.NewEntry(command, flags.ProjectRef, cmd.Flags().Args(), cmd.Annotations, cmd.Flags(), cmdErr)
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := audit.Record(ctx, entry, afero.NewOsFs()); err != nil {
+		fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "failed to record audit log:", err)
+	}
+}
+
+func init() {
+	listFlags := auditListCmd.Flags()
+	listFlags.StringVar(&auditListParams.ProjectRef, "project-ref", "", "Only list commands run against this project.")
+	listFlags.StringVar(&auditListParams.Command, "command", "", "Only list this command and its subcommands, ie. \"functions deploy\".")
+	listFlags.DurationVar(&auditListParams.Since, "since", 0, "Only list commands run within this duration, ie. 24h.")
+	listFlags.UintVar(&auditListParams.Limit, "limit", 50, "Maximum number of recent commands to list. Set to 0 to list all.")
+	listFlags.VarP(&auditListOutput, "output", "o", "Output format of audit log.")
+	auditCmd.AddCommand(auditListCmd)
+	rootCmd.AddCommand(auditCmd)
+}
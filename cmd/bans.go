@@ -21,8 +21,9 @@ The subcommands help you view the current bans, and unblock IPs if desired.`,
 	dbIpsToUnban []string
 
 	bansRemoveCmd = &cobra.Command{
-		Use:   "remove",
-		Short: "Remove a network ban",
+		Use:         "remove",
+		Short:       "Remove a network ban",
+		Annotations: auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return update.Run(cmd.Context(), flags.ProjectRef, dbIpsToUnban, afero.NewOsFs())
 		},
@@ -33,10 +33,11 @@ var (
 	}
 
 	branchCreateCmd = &cobra.Command{
-		Use:   "create [name]",
-		Short: "Create a preview branch",
-		Long:  "Create a preview branch for the linked project.",
-		Args:  cobra.MaximumNArgs(1),
+		Use:         "create [name]",
+		Short:       "Create a preview branch",
+		Long:        "Create a preview branch for the linked project.",
+		Args:        cobra.MaximumNArgs(1),
+		Annotations: auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var name string
 			if len(args) > 0 {
@@ -81,10 +82,11 @@ var (
 	resetOnPush bool
 
 	branchUpdateCmd = &cobra.Command{
-		Use:   "update [branch-id]",
-		Short: "Update a preview branch",
-		Long:  "Update a preview branch by its ID.",
-		Args:  cobra.ExactArgs(1),
+		Use:         "update [branch-id]",
+		Short:       "Update a preview branch",
+		Long:        "Update a preview branch by its ID.",
+		Args:        cobra.ExactArgs(1),
+		Annotations: auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var body api.UpdateBranchBody
 			if cmd.Flags().Changed("name") {
@@ -109,10 +111,11 @@ var (
 	}
 
 	branchDeleteCmd = &cobra.Command{
-		Use:   "delete [branch-id]",
-		Short: "Delete a preview branch",
-		Long:  "Delete a preview branch by its ID.",
-		Args:  cobra.MaximumNArgs(1),
+		Use:         "delete [branch-id]",
+		Short:       "Delete a preview branch",
+		Long:        "Delete a preview branch by its ID.",
+		Args:        cobra.MaximumNArgs(1),
+		Annotations: auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			if len(args) == 0 {
@@ -122,14 +125,18 @@ var (
 			} else {
 				branchId = args[0]
 			}
+			if err := utils.ConfirmProtectedRef(ctx, flags.ProjectRef, "delete a preview branch", iKnowWhatImDoing, afero.NewOsFs()); err != nil {
+				return err
+			}
 			return delete.Run(ctx, branchId)
 		},
 	}
 
 	branchDisableCmd = &cobra.Command{
-		Use:   "disable",
-		Short: "Disable preview branching",
-		Long:  "Disable preview branching for the linked project.",
+		Use:         "disable",
+		Short:       "Disable preview branching",
+		Long:        "Disable preview branching for the linked project.",
+		Annotations: auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return disable.Run(cmd.Context(), afero.NewOsFs())
 		},
@@ -156,6 +163,7 @@ func init() {
 	updateFlags.StringVar(&gitBranch, "git-branch", "", "Change the associated git branch.")
 	updateFlags.BoolVar(&resetOnPush, "reset-on-push", false, "Reset the preview branch on git push.")
 	branchesCmd.AddCommand(branchUpdateCmd)
+	branchDeleteCmd.Flags().BoolVar(&iKnowWhatImDoing, "i-know-what-im-doing", false, "Skip typing the project ref to confirm on protected projects.")
 	branchesCmd.AddCommand(branchDeleteCmd)
 	branchesCmd.AddCommand(branchDisableCmd)
 	rootCmd.AddCommand(branchesCmd)
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	ciInit "github.com/supabase/cli/internal/ci/init"
+	"github.com/supabase/cli/internal/ci/verify"
+	"github.com/supabase/cli/internal/db/lint"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+var (
+	ciCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "ci",
+		Short:   "Run Supabase checks in continuous integration",
+	}
+
+	ciProvider = utils.EnumFlag{
+		Allowed: []string{ciInit.ProviderGithub, ciInit.ProviderGitlab},
+		Value:   ciInit.ProviderGithub,
+	}
+	ciBranch string
+	ciForce  bool
+
+	ciInitCmd = &cobra.Command{
+		Use:   "init",
+		Short: "Generate a CI workflow that deploys to the linked project",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			return ciInit.Run(ctx, ciProvider.Value, ciBranch, ciForce, afero.NewOsFs())
+		},
+		Example: `  supabase ci init --provider gitlab --branch develop`,
+	}
+
+	typesFile  string
+	reportFile string
+	ciLevel    = utils.EnumFlag{
+		Allowed: lint.AllowedLevels,
+		Value:   "error",
+	}
+	reportFormat = utils.EnumFlag{
+		Allowed: []string{utils.OutputPretty, utils.OutputJson, verify.OutputJunit},
+		Value:   utils.OutputPretty,
+	}
+
+	ciVerifyCmd = &cobra.Command{
+		Use:   "verify",
+		Short: "Validate config, migrations, functions and generated types",
+		Long: `Validate config, migrations, functions and generated types.
+
+Applies all local migrations to a shadow database and lints the resulting schema,
+bundles every function under supabase/functions, and optionally compares generated
+types against a checked in file.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			return verify.Run(ctx, typesFile, ciLevel.Value, reportFormat.Value, reportFile, afero.NewOsFs())
+		},
+		Example: `  supabase ci verify --types-file src/types/supabase.ts
+  supabase ci verify --report junit --report-file report.xml`,
+	}
+)
+
+func init() {
+	initFlags := ciInitCmd.Flags()
+	initFlags.Var(&ciProvider, "provider", "CI provider to generate workflow for.")
+	initFlags.StringVar(&ciBranch, "branch", "main", "Branch that triggers a deployment on push.")
+	initFlags.BoolVar(&ciForce, "force", false, "Overwrite existing workflow file.")
+	initFlags.StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	ciCmd.AddCommand(ciInitCmd)
+	verifyFlags := ciVerifyCmd.Flags()
+	verifyFlags.StringVar(&typesFile, "types-file", "", "Path to generated TypeScript types to check for drift.")
+	verifyFlags.Var(&ciLevel, "level", "Minimum lint level that fails the check.")
+	verifyFlags.Var(&reportFormat, "report", "Format of the verification report.")
+	verifyFlags.StringVar(&reportFile, "report-file", "", "Path to write the junit or json report instead of stdout.")
+	ciCmd.AddCommand(ciVerifyCmd)
+	rootCmd.AddCommand(ciCmd)
+}
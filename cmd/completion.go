@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/completion"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+func completeFunctionSlugs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	slugs, err := completion.FunctionSlugs(afero.NewOsFs())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	// Skip slugs already given as previous arguments
+	return slices.DeleteFunc(slugs, func(s string) bool {
+		return slices.Contains(args, s)
+	}), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeMigrationVersions(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	versions, err := completion.MigrationVersions(afero.NewOsFs())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return slices.DeleteFunc(versions, func(v string) bool {
+		return slices.Contains(args, v)
+	}), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeProjectRefs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	refs, err := completion.ProjectRefs(cmd.Context(), afero.NewOsFs())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return refs, cobra.ShellCompDirectiveNoFileComp
+}
+
+// Completes bucket names of the linked project for storage paths, falling back to local files.
+func completeStoragePaths(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if !strings.HasPrefix(toComplete, client.STORAGE_SCHEME+":") {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	prefix := client.STORAGE_SCHEME + ":///"
+	// Objects within a bucket are not completed to avoid listing large buckets
+	if bucket, found := strings.CutPrefix(toComplete, prefix); found && strings.Contains(bucket, "/") {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	if local, _ := cmd.Flags().GetBool("local"); local {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	fsys := afero.NewOsFs()
+	projectRef, err := flags.LoadProjectRef(fsys)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	buckets, err := completion.BucketNames(cmd.Context(), projectRef, fsys)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	result := make([]string, len(buckets))
+	for i, b := range buckets {
+		result[i] = prefix + b + "/"
+	}
+	return result, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+// Registers project ref completion on every command that accepts the flag.
+func registerProjectRefCompletion(cmd *cobra.Command) {
+	if cmd.Flags().Lookup("project-ref") != nil {
+		_ = cmd.RegisterFlagCompletionFunc("project-ref", completeProjectRefs)
+	}
+	for _, child := range cmd.Commands() {
+		registerProjectRefCompletion(child)
+	}
+}
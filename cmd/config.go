@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/config/export"
+	"github.com/supabase/cli/internal/config/migrate"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+var (
+	configCmd = &cobra.Command{
+		GroupID: groupManagementAPI,
+		Use:     "config",
+		Short:   "Manage Supabase project configurations",
+	}
+
+	exportFormat = utils.EnumFlag{
+		Allowed: []string{export.FormatHcl, export.FormatJson},
+		Value:   export.FormatHcl,
+	}
+
+	configExportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "Export remote project config for infrastructure-as-code tools",
+		Long: `Export remote project config for infrastructure-as-code tools.
+
+Reads auth settings, functions, secret names and network restrictions of the linked
+project, and emits them as Terraform HCL or a generic JSON state. Secret values and
+auth provider credentials are never exported.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return export.Run(cmd.Context(), flags.ProjectRef, exportFormat.Value, os.Stdout)
+		},
+		Example: `  supabase config export --format hcl > supabase.tf`,
+	}
+
+	migrateDryRun bool
+
+	configMigrateCmd = &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate local config to the current schema",
+		Long: `Migrate local config to the current schema.
+
+Rewrites sections and keys of supabase/config.toml that were renamed in newer CLI
+versions. Comments and formatting are preserved, and the original file is saved
+to supabase/config.toml.bak.`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			cmd.GroupID = groupLocalDev
+			return cmd.Root().PersistentPreRunE(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return migrate.Run(cmd.Context(), migrateDryRun, afero.NewOsFs())
+		},
+	}
+)
+
+func init() {
+	configCmd.PersistentFlags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	configExportCmd.Flags().Var(&exportFormat, "format", "Output format of the exported config.")
+	configCmd.AddCommand(configExportCmd)
+	configMigrateCmd.Flags().BoolVar(&migrateDryRun, "dry-run", false, "Print the changes without writing to config.toml.")
+	configCmd.AddCommand(configMigrateCmd)
+	rootCmd.AddCommand(configCmd)
+}
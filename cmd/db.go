@@ -5,6 +5,8 @@ import (
 	"os"
 	"os/signal"
 
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -15,13 +17,20 @@ import (
 	"github.com/supabase/cli/internal/db/diff"
 	"github.com/supabase/cli/internal/db/dump"
 	"github.com/supabase/cli/internal/db/lint"
+	"github.com/supabase/cli/internal/db/parity"
+	"github.com/supabase/cli/internal/db/pooler"
 	"github.com/supabase/cli/internal/db/pull"
 	"github.com/supabase/cli/internal/db/push"
+	"github.com/supabase/cli/internal/db/query"
 	"github.com/supabase/cli/internal/db/remote/changes"
 	"github.com/supabase/cli/internal/db/remote/commit"
 	"github.com/supabase/cli/internal/db/reset"
+	"github.com/supabase/cli/internal/db/seed/generate"
+	"github.com/supabase/cli/internal/db/shell"
 	"github.com/supabase/cli/internal/db/start"
 	"github.com/supabase/cli/internal/db/test"
+	"github.com/supabase/cli/internal/db/url"
+	"github.com/supabase/cli/internal/db/verify"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
 )
@@ -87,27 +96,49 @@ var (
 	useMigra    bool
 	usePgAdmin  bool
 	usePgSchema bool
+	diffEngine  = utils.EnumFlag{
+		Allowed: diff.EngineAllowed,
+		Value:   diff.EngineMigra,
+	}
+	shadowDbUrl string
 	schema      []string
 	file        string
+	diffInclude []string
 
 	dbDiffCmd = &cobra.Command{
 		Use:   "diff",
 		Short: "Diffs the local database for schema changes",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			// Legacy flags take precedence for backwards compatibility
 			if usePgAdmin {
-				return diff.RunPgAdmin(cmd.Context(), schema, file, flags.DbConfig, afero.NewOsFs())
+				diffEngine.Value = diff.EnginePgAdmin
+			} else if usePgSchema {
+				diffEngine.Value = diff.EnginePgSchema
+			}
+			if diffEngine.Value == diff.EnginePgSchema {
+				fmt.Fprintln(os.Stderr, "WARNING: pg-schema engine is experimental and may not include all entities, such as enums.")
 			}
-			differ := diff.DiffSchemaMigra
-			if usePgSchema {
-				differ = diff.DiffPgSchema
-				fmt.Fprintln(os.Stderr, "WARNING: --use-pg-schema flag is experimental and may not include all entities, such as RLS policies, enums, and grants.")
+			for _, c := range diffInclude {
+				if !utils.SliceContains(diff.CategoryAllowed, c) {
+					return errors.Errorf("Invalid --include category: %s. Must be one of: %v", c, diff.CategoryAllowed)
+				}
 			}
-			return diff.Run(cmd.Context(), schema, file, flags.DbConfig, differ, afero.NewOsFs())
+			var shadow pgconn.Config
+			if len(shadowDbUrl) > 0 {
+				config, err := pgconn.ParseConfig(shadowDbUrl)
+				if err != nil {
+					return errors.Errorf("failed to parse shadow connection string: %w", err)
+				}
+				shadow = *config
+			}
+			differ := diff.WithCatalog(diff.GetDiffer(diffEngine.Value), diffInclude)
+			return diff.Run(cmd.Context(), schema, file, flags.DbConfig, shadow, differ, afero.NewOsFs())
 		},
 	}
 
 	dataOnly     bool
 	useCopy      bool
+	largeObjects bool
 	roleOnly     bool
 	keepComments bool
 	excludeTable []string
@@ -116,12 +147,12 @@ var (
 		Use:   "dump",
 		Short: "Dumps data or schemas from the remote database",
 		PreRun: func(cmd *cobra.Command, args []string) {
-			if useCopy || len(excludeTable) > 0 {
+			if useCopy || largeObjects || len(excludeTable) > 0 {
 				cobra.CheckErr(cmd.MarkFlagRequired("data-only"))
 			}
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return dump.Run(cmd.Context(), file, flags.DbConfig, schema, excludeTable, dataOnly, roleOnly, keepComments, useCopy, dryRun, afero.NewOsFs())
+			return dump.Run(cmd.Context(), file, flags.DbConfig, schema, excludeTable, dataOnly, roleOnly, keepComments, useCopy, largeObjects, dryRun, afero.NewOsFs())
 		},
 		PostRun: func(cmd *cobra.Command, args []string) {
 			if len(file) > 0 {
@@ -134,12 +165,27 @@ var (
 	includeAll   bool
 	includeRoles bool
 	includeSeed  bool
+	pushOrder    []string
+	pushAtomic   bool
+
+	allowTransactionMode bool
+	checkFunctions       bool
 
 	dbPushCmd = &cobra.Command{
-		Use:   "push",
-		Short: "Push new migrations to the remote database",
+		Use:         "push",
+		Short:       "Push new migrations to the remote database",
+		Annotations: auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return push.Run(cmd.Context(), dryRun, includeAll, includeRoles, includeSeed, flags.DbConfig, afero.NewOsFs())
+			config, options := push.ResolvePoolerMode(flags.DbConfig, allowTransactionMode, os.Stderr)
+			// Functions edited on remote are only reported, because pending migrations may redefine them
+			if checkFunctions && !utils.IsLocalDatabase(config) {
+				if err := verify.CheckFunctions(cmd.Context(), nil, config, afero.NewOsFs(), options...); errors.Is(err, parity.ErrDrift) {
+					fmt.Fprintf(os.Stderr, "%s these functions were edited outside of migrations. Pending migrations that redefine them will overwrite those edits. Run %s first to keep them.\n", utils.Yellow("WARNING:"), utils.Aqua("supabase db pull"))
+				} else if err != nil {
+					return err
+				}
+			}
+			return push.Run(cmd.Context(), dryRun, includeAll, includeRoles, includeSeed, pushOrder, pushAtomic, config, afero.NewOsFs(), options...)
 		},
 	}
 
@@ -184,10 +230,15 @@ var (
 	}
 
 	dbResetCmd = &cobra.Command{
-		Use:   "reset",
-		Short: "Resets the local database to current migrations",
+		Use:         "reset",
+		Short:       "Resets the local database to current migrations",
+		Annotations: auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return reset.Run(cmd.Context(), migrationVersion, flags.DbConfig, afero.NewOsFs())
+			var projectRef string
+			if linked, _ := cmd.Flags().GetBool("linked"); linked {
+				projectRef = flags.ProjectRef
+			}
+			return reset.Run(cmd.Context(), migrationVersion, projectRef, iKnowWhatImDoing, flags.DbConfig, afero.NewOsFs())
 		},
 	}
 
@@ -204,6 +255,125 @@ var (
 		},
 	}
 
+	queryParams []string
+	queryOutput = utils.EnumFlag{
+		Allowed: []string{
+			utils.OutputTable,
+			utils.OutputCsv,
+			utils.OutputJson,
+		},
+		Value: utils.OutputTable,
+	}
+
+	dbPoolerCmd = &cobra.Command{
+		Use:   "pooler",
+		Short: "Manage connection pooler of the linked project",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			cmd.GroupID = groupManagementAPI
+			return cmd.Root().PersistentPreRunE(cmd, args)
+		},
+	}
+
+	poolerOutput = utils.EnumFlag{
+		Allowed: utils.OutputDefaultAllowed,
+		Value:   utils.OutputPretty,
+	}
+
+	dbPoolerGetCmd = &cobra.Command{
+		Use:   "get",
+		Short: "Show pool mode, pool size and client connection limit",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return pooler.RunGet(cmd.Context(), flags.ProjectRef, poolerOutput.Value, afero.NewOsFs())
+		},
+	}
+
+	defaultPoolSize int
+
+	dbPoolerUpdateCmd = &cobra.Command{
+		Use:         "update",
+		Short:       "Update the default pool size of the pooler",
+		Annotations: auditAnnotations(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return pooler.RunUpdate(cmd.Context(), flags.ProjectRef, defaultPoolSize, afero.NewOsFs())
+		},
+		Example: `  supabase db pooler update --default-pool-size 20`,
+	}
+
+	usePooler bool
+	poolMode  = utils.EnumFlag{
+		Allowed: []string{string(utils.SessionMode), string(utils.TransactionMode)},
+		Value:   string(utils.SessionMode),
+	}
+	urlParams url.RunParams
+
+	dbUrlCmd = &cobra.Command{
+		Use:   "url",
+		Short: "Prints the connection string of the database",
+		Long:  "Prints the connection string of the local or linked database. The password of the linked project is read from the --password flag, the SUPABASE_DB_PASSWORD environment variable, or the credential store saved by supabase link, and prompted for otherwise.",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// Printed connection strings must outlive the command, unlike temporary login roles
+			flags.RequirePassword = true
+			return cmd.Parent().PersistentPreRunE(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if usePooler || cmd.Flags().Changed("pool-mode") {
+				urlParams.PoolMode = poolMode.Value
+			}
+			return url.Run(cmd.Context(), flags.DbConfig, urlParams, os.Stdout, afero.NewOsFs())
+		},
+		Example: `  supabase db url --linked --pooler --role service_role
+  supabase db url --env >> .env.local`,
+	}
+
+	dbQueryCmd = &cobra.Command{
+		Use:   "query [sql]",
+		Short: "Executes a SQL query against the database",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var sql string
+			if len(args) > 0 {
+				sql = args[0]
+			}
+			return query.Run(cmd.Context(), sql, file, queryParams, queryOutput.Value, flags.DbConfig, afero.NewOsFs())
+		},
+		Example: `  supabase db query "select * from auth.users where email = $1" --param alice@example.com
+  supabase db query --file report.sql -o csv > report.csv`,
+	}
+
+	shellCommand string
+
+	dbShellCmd = &cobra.Command{
+		Use:   "shell",
+		Short: "Opens an interactive psql session",
+		Long:  "Opens an interactive psql session to the local or linked database. Falls back to the psql client bundled in the local database container if psql is not installed.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return shell.Run(cmd.Context(), shellCommand, file, flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	dbSeedCmd = &cobra.Command{
+		Use:   "seed",
+		Short: "Manage seed data",
+	}
+
+	seedRows uint
+	seedSpec string
+
+	dbSeedGenerateCmd = &cobra.Command{
+		Use:   "generate",
+		Short: "Generates synthetic seed data from the database schema",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return generate.Run(cmd.Context(), schema, seedRows, seedSpec, file, flags.DbConfig, afero.NewOsFs())
+		},
+		PostRun: func(cmd *cobra.Command, args []string) {
+			if len(file) > 0 {
+				fmt.Fprintln(os.Stderr, "Generated seed data to "+utils.Bold(file)+".")
+			}
+		},
+		Example: `  supabase db seed generate --rows 50 > supabase/seed.sql
+  supabase db seed generate --spec seed.yaml -f supabase/seed.sql`,
+	}
+
 	dbStartCmd = &cobra.Command{
 		Use:   "start",
 		Short: "Starts local Postgres database",
@@ -217,7 +387,15 @@ var (
 		Use:    "test [path] ...",
 		Short:  "Tests local database with pgTAP",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return test.Run(cmd.Context(), args, flags.DbConfig, afero.NewOsFs())
+			return test.Run(cmd.Context(), args, flags.DbConfig, testCoverage, afero.NewOsFs())
+		},
+	}
+
+	dbVerifyCmd = &cobra.Command{
+		Use:   "verify",
+		Short: "Checks the remote database schema for drift from migrations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return verify.Run(cmd.Context(), schema, checkFunctions, flags.DbConfig, afero.NewOsFs())
 		},
 	}
 )
@@ -234,7 +412,13 @@ func init() {
 	diffFlags.BoolVar(&useMigra, "use-migra", true, "Use migra to generate schema diff.")
 	diffFlags.BoolVar(&usePgAdmin, "use-pgadmin", false, "Use pgAdmin to generate schema diff.")
 	diffFlags.BoolVar(&usePgSchema, "use-pg-schema", false, "Use pg-schema-diff to generate schema diff.")
-	dbDiffCmd.MarkFlagsMutuallyExclusive("use-migra", "use-pgadmin")
+	cobra.CheckErr(diffFlags.MarkDeprecated("use-migra", "use --engine migra instead."))
+	cobra.CheckErr(diffFlags.MarkDeprecated("use-pgadmin", "use --engine pgadmin instead."))
+	cobra.CheckErr(diffFlags.MarkDeprecated("use-pg-schema", "use --engine pg-schema instead."))
+	diffFlags.Var(&diffEngine, "engine", "Engine used to generate schema diff.")
+	diffFlags.StringSliceVar(&diffInclude, "include", diff.CategoryAllowed, "Catalog objects to include in schema diff.")
+	dbDiffCmd.MarkFlagsMutuallyExclusive("use-migra", "use-pgadmin", "use-pg-schema", "engine")
+	diffFlags.StringVar(&shadowDbUrl, "shadow-db-url", "", "Migrates an existing empty database as shadow instead of starting a container.")
 	diffFlags.String("db-url", "", "Diffs against the database specified by the connection string (must be percent-encoded).")
 	diffFlags.Bool("linked", false, "Diffs local migration files against the linked project.")
 	diffFlags.Bool("local", true, "Diffs local migration files against the local database.")
@@ -247,6 +431,7 @@ func init() {
 	dumpFlags.BoolVar(&dryRun, "dry-run", false, "Prints the pg_dump script that would be executed.")
 	dumpFlags.BoolVar(&dataOnly, "data-only", false, "Dumps only data records.")
 	dumpFlags.BoolVar(&useCopy, "use-copy", false, "Uses copy statements in place of inserts.")
+	dumpFlags.BoolVar(&largeObjects, "include-large-objects", false, "Includes large objects in data-only dump.")
 	dumpFlags.StringSliceVarP(&excludeTable, "exclude", "x", []string{}, "List of schema.tables to exclude from data-only dump.")
 	dumpFlags.BoolVar(&roleOnly, "role-only", false, "Dumps only cluster roles.")
 	dbDumpCmd.MarkFlagsMutuallyExclusive("role-only", "data-only")
@@ -267,7 +452,11 @@ func init() {
 	pushFlags.BoolVar(&includeAll, "include-all", false, "Include all migrations not found on remote history table.")
 	pushFlags.BoolVar(&includeRoles, "include-roles", false, "Include custom roles from "+utils.CustomRolesPath+".")
 	pushFlags.BoolVar(&includeSeed, "include-seed", false, "Include seed data from "+utils.SeedDataPath+".")
+	pushFlags.StringSliceVar(&pushOrder, "order", push.DefaultOrder, "Order in which roles, migrations, and seed are applied.")
+	pushFlags.BoolVar(&pushAtomic, "atomic", false, "Apply roles, migrations, and seed in a single transaction.")
 	pushFlags.BoolVar(&dryRun, "dry-run", false, "Print the migrations that would be applied, but don't actually apply them.")
+	pushFlags.BoolVar(&allowTransactionMode, "allow-transaction-mode", false, "Push through a transaction mode pooler instead of switching to session mode.")
+	pushFlags.BoolVar(&checkFunctions, "check-functions", false, "Warn about functions in exposed schemas that were edited outside of migrations.")
 	pushFlags.String("db-url", "", "Pushes to the database specified by the connection string (must be percent-encoded).")
 	pushFlags.Bool("linked", true, "Pushes to the linked project.")
 	pushFlags.Bool("local", false, "Pushes to the local database.")
@@ -301,6 +490,7 @@ func init() {
 	resetFlags.Bool("local", true, "Resets the local database with local migrations.")
 	dbResetCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
 	resetFlags.StringVar(&migrationVersion, "version", "", "Reset up to the specified version.")
+	resetFlags.BoolVar(&iKnowWhatImDoing, "i-know-what-im-doing", false, "Skip typing the project ref to confirm resetting a linked project.")
 	dbCmd.AddCommand(dbResetCmd)
 	// Build lint command
 	lintFlags := dbLintCmd.Flags()
@@ -311,6 +501,66 @@ func init() {
 	lintFlags.StringSliceVarP(&schema, "schema", "s", []string{}, "Comma separated list of schema to include.")
 	lintFlags.Var(&level, "level", "Error level to emit.")
 	dbCmd.AddCommand(dbLintCmd)
+	// Build query command
+	queryFlags := dbQueryCmd.Flags()
+	queryFlags.StringVarP(&file, "file", "f", "", "Reads the SQL query from a file.")
+	queryFlags.StringArrayVar(&queryParams, "param", []string{}, "Binds a value to the next positional parameter, ie. $1, $2.")
+	queryFlags.VarP(&queryOutput, "output", "o", "Output format of query results.")
+	queryFlags.String("db-url", "", "Queries the database specified by the connection string (must be percent-encoded).")
+	queryFlags.Bool("linked", false, "Queries the linked project.")
+	queryFlags.Bool("local", true, "Queries the local database.")
+	dbQueryCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	queryFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", queryFlags.Lookup("password")))
+	dbCmd.AddCommand(dbQueryCmd)
+	// Build pooler command
+	dbPoolerCmd.PersistentFlags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	dbPoolerGetCmd.Flags().VarP(&poolerOutput, "output", "o", "Output format of pooler config.")
+	dbPoolerUpdateCmd.Flags().IntVar(&defaultPoolSize, "default-pool-size", 0, "Number of server connections to allow per user and database pair.")
+	cobra.CheckErr(dbPoolerUpdateCmd.MarkFlagRequired("default-pool-size"))
+	dbPoolerCmd.AddCommand(dbPoolerGetCmd)
+	dbPoolerCmd.AddCommand(dbPoolerUpdateCmd)
+	dbCmd.AddCommand(dbPoolerCmd)
+	// Build url command
+	urlFlags := dbUrlCmd.Flags()
+	urlFlags.Bool("linked", false, "Prints the connection string of the linked project.")
+	urlFlags.Bool("local", true, "Prints the connection string of the local database.")
+	dbUrlCmd.MarkFlagsMutuallyExclusive("linked", "local")
+	urlFlags.BoolVar(&usePooler, "pooler", false, "Connect through the connection pooler.")
+	urlFlags.Var(&poolMode, "pool-mode", "Pool mode of the pooler connection.")
+	urlFlags.StringVar(&urlParams.Role, "role", "", "Database role to assume after connecting.")
+	urlFlags.StringVar(&urlParams.EnvName, "env", "", "Print as an environment variable assignment with this name.")
+	urlFlags.Lookup("env").NoOptDefVal = "DATABASE_URL"
+	urlFlags.BoolVar(&urlParams.Copy, "copy", false, "Copy to clipboard instead of printing.")
+	urlFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", urlFlags.Lookup("password")))
+	dbCmd.AddCommand(dbUrlCmd)
+	// Build shell command
+	shellFlags := dbShellCmd.Flags()
+	shellFlags.StringVarP(&shellCommand, "command", "c", "", "Runs a single SQL command and exits.")
+	shellFlags.StringVarP(&file, "file", "f", "", "Runs SQL commands from a file and exits.")
+	dbShellCmd.MarkFlagsMutuallyExclusive("command", "file")
+	shellFlags.String("db-url", "", "Connects to the database specified by the connection string (must be percent-encoded).")
+	shellFlags.Bool("linked", false, "Connects to the linked project.")
+	shellFlags.Bool("local", true, "Connects to the local database.")
+	dbShellCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	shellFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", shellFlags.Lookup("password")))
+	dbCmd.AddCommand(dbShellCmd)
+	// Build seed command
+	seedFlags := dbSeedGenerateCmd.Flags()
+	seedFlags.UintVar(&seedRows, "rows", 10, "Number of rows to generate per table.")
+	seedFlags.StringVar(&seedSpec, "spec", "", "Path to a YAML file overriding rows and column generators.")
+	seedFlags.StringVarP(&file, "file", "f", "", "Writes the generated SQL to a file instead of stdout.")
+	seedFlags.StringSliceVarP(&schema, "schema", "s", []string{}, "Comma separated list of schema to include.")
+	seedFlags.String("db-url", "", "Generates seed data for the database specified by the connection string (must be percent-encoded).")
+	seedFlags.Bool("linked", false, "Generates seed data for the linked project.")
+	seedFlags.Bool("local", true, "Generates seed data for the local database.")
+	dbSeedGenerateCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	seedFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", seedFlags.Lookup("password")))
+	dbSeedCmd.AddCommand(dbSeedGenerateCmd)
+	dbCmd.AddCommand(dbSeedCmd)
 	// Build start command
 	dbCmd.AddCommand(dbStartCmd)
 	// Build test command
@@ -319,6 +569,18 @@ func init() {
 	testFlags.String("db-url", "", "Tests the database specified by the connection string (must be percent-encoded).")
 	testFlags.Bool("linked", false, "Runs pgTAP tests on the linked project.")
 	testFlags.Bool("local", true, "Runs pgTAP tests on the local database.")
+	testFlags.BoolVar(&testCoverage, "coverage", false, "Summarise tables and policies referenced by tests.")
 	dbTestCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	// Build verify command
+	verifyFlags := dbVerifyCmd.Flags()
+	verifyFlags.String("db-url", "", "Verifies the database specified by the connection string (must be percent-encoded).")
+	verifyFlags.Bool("linked", true, "Verifies the linked project.")
+	verifyFlags.Bool("local", false, "Verifies the local database.")
+	dbVerifyCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	verifyFlags.StringSliceVarP(&schema, "schema", "s", []string{}, "Comma separated list of schema to include.")
+	verifyFlags.BoolVar(&checkFunctions, "check-functions", false, "Report functions that differ from migrations before comparing the full schema.")
+	verifyFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", verifyFlags.Lookup("password")))
+	dbCmd.AddCommand(dbVerifyCmd)
 	rootCmd.AddCommand(dbCmd)
 }
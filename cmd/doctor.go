@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/doctor"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var (
+	doctorOutput = utils.EnumFlag{
+		Allowed: utils.OutputDefaultAllowed,
+		Value:   utils.OutputPretty,
+	}
+
+	doctorCmd = &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose common problems with your environment",
+		Long:  "Checks docker, disk space, port conflicts, access token, linked project and clock skew. Attach the report when filing a bug report.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			return doctor.Run(ctx, doctorOutput.Value, afero.NewOsFs())
+		},
+		Example: `  supabase doctor
+  supabase doctor -o json > report.json`,
+	}
+)
+
+func init() {
+	doctorCmd.Flags().VarP(&doctorOutput, "output", "o", "Output format of diagnostics report.")
+	rootCmd.AddCommand(doctorCmd)
+}
@@ -31,6 +31,7 @@ Use of custom domains and vanity subdomains is mutually exclusive.
 		Long: `Create a custom hostname for your Supabase project.
 
 Expects your custom hostname to have a CNAME record to your Supabase project's subdomain.`,
+		Annotations: auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return create.Run(cmd.Context(), flags.ProjectRef, customHostname, rawOutput, afero.NewOsFs())
 		},
@@ -46,8 +47,9 @@ Expects your custom hostname to have a CNAME record to your Supabase project's s
 	}
 
 	customHostnamesReverifyCmd = &cobra.Command{
-		Use:   "reverify",
-		Short: "Re-verify the custom hostname config for your project",
+		Use:         "reverify",
+		Short:       "Re-verify the custom hostname config for your project",
+		Annotations: auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return reverify.Run(cmd.Context(), flags.ProjectRef, rawOutput, afero.NewOsFs())
 		},
@@ -60,14 +62,16 @@ Expects your custom hostname to have a CNAME record to your Supabase project's s
 
 This reconfigures your Supabase project to respond to requests on your custom hostname.
 After the custom hostname is activated, your project's auth services will no longer function on the Supabase-provisioned subdomain.`,
+		Annotations: auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return activate.Run(cmd.Context(), flags.ProjectRef, rawOutput, afero.NewOsFs())
 		},
 	}
 
 	customHostnamesDeleteCmd = &cobra.Command{
-		Use:   "delete",
-		Short: "Deletes the custom hostname config for your project",
+		Use:         "delete",
+		Short:       "Deletes the custom hostname config for your project",
+		Annotations: auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return delete.Run(cmd.Context(), flags.ProjectRef, afero.NewOsFs())
 		},
@@ -25,8 +25,9 @@ var (
 	}
 
 	rootKeyUpdateCmd = &cobra.Command{
-		Use:   "update-root-key",
-		Short: "Update root encryption key of a Supabase project",
+		Use:         "update-root-key",
+		Short:       "Update root encryption key of a Supabase project",
+		Annotations: auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return update.Run(cmd.Context(), flags.ProjectRef, os.Stdin)
 		},
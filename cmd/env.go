@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/env/diff"
+	"github.com/supabase/cli/internal/env/pull"
+	"github.com/supabase/cli/internal/env/push"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+var (
+	envCmd = &cobra.Command{
+		GroupID: groupManagementAPI,
+		Use:     "env",
+		Short:   "Manage encrypted environment variables",
+		Long:    "Manage environment variables of the linked project in an encrypted env file, which can be committed to git and used by functions serve.",
+	}
+
+	encryptedEnvPath string
+	pruneSecrets     bool
+
+	envPushCmd = &cobra.Command{
+		Use:         "push",
+		Short:       "Push local env file to secrets of the linked project",
+		Annotations: auditAnnotations(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if pruneSecrets {
+				if err := utils.ConfirmProtectedRef(cmd.Context(), flags.ProjectRef, "prune secrets", iKnowWhatImDoing, afero.NewOsFs()); err != nil {
+					return err
+				}
+			}
+			return push.Run(cmd.Context(), flags.ProjectRef, encryptedEnvPath, pruneSecrets, afero.NewOsFs())
+		},
+	}
+
+	envPullCmd = &cobra.Command{
+		Use:   "pull",
+		Short: "Pull secrets of the linked project to local env file",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return pull.Run(cmd.Context(), flags.ProjectRef, encryptedEnvPath, afero.NewOsFs())
+		},
+	}
+
+	envDiffCmd = &cobra.Command{
+		Use:   "diff",
+		Short: "Diff local env file against secrets of the linked project",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return diff.Run(cmd.Context(), flags.ProjectRef, encryptedEnvPath, afero.NewOsFs())
+		},
+	}
+)
+
+func init() {
+	envFlags := envCmd.PersistentFlags()
+	envFlags.StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	envFlags.StringVar(&encryptedEnvPath, "env-file", utils.EncryptedEnvPath, "Path to the encrypted env file.")
+	envPushCmd.Flags().BoolVar(&pruneSecrets, "prune", false, "Delete remote secrets not found in the env file, after confirmation.")
+	envPushCmd.Flags().BoolVar(&iKnowWhatImDoing, "i-know-what-im-doing", false, "Skip typing the project ref to confirm pruning on protected projects.")
+	envCmd.AddCommand(envPushCmd)
+	envCmd.AddCommand(envPullCmd)
+	envCmd.AddCommand(envDiffCmd)
+	rootCmd.AddCommand(envCmd)
+}
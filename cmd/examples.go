@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/help"
+)
+
+var examplesCmd = &cobra.Command{
+	GroupID: groupQuickStart,
+	Use:     "examples [recipe]",
+	Short:   "Show recipes for common workflows",
+	Long:    "Lists recipes for common workflows, or prints the named recipe as a shell script. Recipes are checked against the flags of the installed CLI version.",
+	Args:    cobra.MaximumNArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		names := make([]string, len(help.Recipes))
+		for i, r := range help.Recipes {
+			names[i] = r.Name + "\t" + r.Title
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var name string
+		if len(args) > 0 {
+			name = args[0]
+		}
+		return help.RunExamples(cmd.Root(), name, os.Stdout)
+	},
+	Example: `  supabase examples
+  supabase examples functions-deploy-ci > deploy.sh`,
+}
+
+func init() {
+	rootCmd.AddCommand(examplesCmd)
+}
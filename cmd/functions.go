@@ -2,14 +2,23 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/andybalholm/brotli"
+	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/functions/audit"
 	"github.com/supabase/cli/internal/functions/delete"
 	"github.com/supabase/cli/internal/functions/deploy"
 	"github.com/supabase/cli/internal/functions/download"
+	"github.com/supabase/cli/internal/functions/editor"
+	"github.com/supabase/cli/internal/functions/invoke"
 	"github.com/supabase/cli/internal/functions/list"
 	new_ "github.com/supabase/cli/internal/functions/new"
+	"github.com/supabase/cli/internal/functions/ping"
+	"github.com/supabase/cli/internal/functions/replay"
 	"github.com/supabase/cli/internal/functions/serve"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
@@ -27,25 +36,51 @@ var (
 		Short: "List all Functions in Supabase",
 		Long:  "List all Functions in the linked Supabase project.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return list.Run(cmd.Context(), flags.ProjectRef, afero.NewOsFs())
+			return list.Run(cmd.Context(), flags.ProjectRef, listOutput.Value, listColumns, afero.NewOsFs())
 		},
 	}
 
+	listOutput = utils.EnumFlag{
+		Allowed: utils.OutputDefaultAllowed,
+		Value:   utils.OutputPretty,
+	}
+	listColumns []string
+
+	deleteAll    bool
+	manifestPath string
+
 	functionsDeleteCmd = &cobra.Command{
-		Use:   "delete <Function name>",
-		Short: "Delete a Function from Supabase",
-		Long:  "Delete a Function from the linked Supabase project. This does NOT remove the Function locally.",
-		Args:  cobra.ExactArgs(1),
+		Use:   "delete <Function name> ...",
+		Short: "Delete Functions from Supabase",
+		Long:  "Delete Functions from the linked Supabase project. This does NOT remove the Functions locally.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if deleteAll {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.MinimumNArgs(1)(cmd, args)
+		},
+		ValidArgsFunction: completeFunctionSlugs,
+		Annotations:       auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return delete.Run(cmd.Context(), args[0], flags.ProjectRef, afero.NewOsFs())
+			if err := utils.ConfirmProtectedRef(cmd.Context(), flags.ProjectRef, "delete functions", iKnowWhatImDoing, afero.NewOsFs()); err != nil {
+				return err
+			}
+			if !deleteAll && len(args) == 1 && !strings.ContainsAny(args[0], "*?[") {
+				return delete.Run(cmd.Context(), args[0], flags.ProjectRef, afero.NewOsFs())
+			}
+			return delete.RunBulk(cmd.Context(), args, deleteAll, flags.ProjectRef, afero.NewOsFs())
 		},
+		Example: `  supabase functions delete hello-world
+  supabase functions delete "payments-*" webhooks
+  supabase functions delete --all`,
 	}
 
 	functionsDownloadCmd = &cobra.Command{
-		Use:   "download <Function name>",
-		Short: "Download a Function from Supabase",
-		Long:  "Download the source code for a Function from the linked Supabase project.",
-		Args:  cobra.ExactArgs(1),
+		Use:               "download <Function name>",
+		Short:             "Download a Function from Supabase",
+		Long:              "Download the source code for a Function from the linked Supabase project.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFunctionSlugs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return download.Run(cmd.Context(), args[0], flags.ProjectRef, useLegacyBundle, afero.NewOsFs())
 		},
@@ -54,17 +89,52 @@ var (
 	noVerifyJWT     = new(bool)
 	useLegacyBundle bool
 	importMapPath   string
+	noCompress      bool
+	compressLevel   int
+	fromEszip       string
+	eszipParams     deploy.EszipParams
+	deployFailOn    = utils.EnumFlag{
+		Allowed: audit.FailOnAllowed,
+		Value:   audit.FailOnErrors,
+	}
+	mountStrategy = utils.EnumFlag{
+		Allowed: []string{utils.MountStrategyBind, utils.MountStrategyCopy},
+		Value:   utils.MountStrategyBind,
+	}
 
 	functionsDeployCmd = &cobra.Command{
-		Use:   "deploy [Function name]",
-		Short: "Deploy a Function to Supabase",
-		Long:  "Deploy a Function to the linked Supabase project.",
+		Use:               "deploy [Function name]",
+		Short:             "Deploy a Function to Supabase",
+		Long:              "Deploy a Function to the linked Supabase project.",
+		ValidArgsFunction: completeFunctionSlugs,
+		Annotations:       auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Fallback to config if user did not set the flag.
 			if !cmd.Flags().Changed("no-verify-jwt") {
 				noVerifyJWT = nil
 			}
-			return deploy.Run(cmd.Context(), args, flags.ProjectRef, noVerifyJWT, importMapPath, afero.NewOsFs())
+			setMountStrategy(cmd)
+			// Unreachable advisory databases only fail the deploy if user set the flag.
+			failOn := deployFailOn.Value
+			if !cmd.Flags().Changed("fail-on") {
+				failOn = ""
+			}
+			if len(fromEszip) == 0 && (len(eszipParams.EntrypointPath) > 0 || len(eszipParams.ImportMapPath) > 0) {
+				return errors.New("--entrypoint-path and --import-map-path must be used together with --from-eszip.")
+			}
+			if len(manifestPath) > 0 {
+				if len(args) > 0 {
+					return errors.New("Function names cannot be specified with --manifest.")
+				}
+				return deploy.RunManifest(cmd.Context(), manifestPath, flags.ProjectRef, failOn, afero.NewOsFs(), compressOptions()...)
+			}
+			if len(fromEszip) > 0 {
+				if len(args) != 1 {
+					return errors.New("Exactly one Function name must be specified with --from-eszip.")
+				}
+				return deploy.RunEszip(cmd.Context(), args[0], fromEszip, flags.ProjectRef, eszipParams, noVerifyJWT, failOn, afero.NewOsFs(), compressOptions()...)
+			}
+			return deploy.Run(cmd.Context(), args, flags.ProjectRef, noVerifyJWT, importMapPath, failOn, afero.NewOsFs(), compressOptions()...)
 		},
 	}
 
@@ -81,9 +151,108 @@ var (
 		},
 	}
 
-	envFilePath string
-	inspectBrk  bool
-	inspectMode = utils.EnumFlag{
+	functionsSetupEditorCmd = &cobra.Command{
+		Use:               "setup-editor [Function name] ...",
+		Short:             "Generate editor settings for Functions",
+		Long:              "Write Deno settings for each Function so that editor IntelliSense resolves modules with the same import map used when deploying.",
+		ValidArgsFunction: completeFunctionSlugs,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			cmd.GroupID = groupLocalDev
+			return cmd.Root().PersistentPreRunE(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return editor.Run(cmd.Context(), args, importMapPath, afero.NewOsFs())
+		},
+	}
+
+	invokeLocal   bool
+	invokeRequest invoke.Request
+	invokeRole    = utils.EnumFlag{
+		Allowed: []string{invoke.RoleAnon, invoke.RoleServiceRole, invoke.RoleNone},
+		Value:   invoke.RoleAnon,
+	}
+
+	functionsInvokeCmd = &cobra.Command{
+		Use:               "invoke <Function name>",
+		Short:             "Invoke a Function locally or on Supabase",
+		Long:              "Invoke a Function served locally, or deployed to the linked Supabase project, with the project API key injected.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFunctionSlugs,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if invokeLocal {
+				cmd.GroupID = groupLocalDev
+			}
+			return cmd.Root().PersistentPreRunE(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectRef := flags.ProjectRef
+			if invokeLocal {
+				projectRef = ""
+			}
+			invokeRequest.Role = invokeRole.Value
+			return invoke.Run(cmd.Context(), args[0], projectRef, invokeRequest, afero.NewOsFs())
+		},
+		Example: `  supabase functions invoke hello-world --local --body '{"name":"Functions"}'
+  supabase functions invoke hello-world --method GET --header "x-region: us-east-1"
+  supabase functions invoke hello-world --body @payload.json --role service_role`,
+	}
+
+	pingRegions []string
+	pingCount   uint
+	pingRequest invoke.Request
+	pingRole    = utils.EnumFlag{
+		Allowed: []string{invoke.RoleAnon, invoke.RoleServiceRole, invoke.RoleNone},
+		Value:   invoke.RoleAnon,
+	}
+
+	functionsPingCmd = &cobra.Command{
+		Use:               "ping <Function name>",
+		Short:             "Measure latency of a deployed Function from multiple regions",
+		Long:              "Invoke a Function deployed to the linked Supabase project in each region, and report the latency of the first request against warm requests to estimate cold start duration.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFunctionSlugs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pingRequest.Role = pingRole.Value
+			return ping.Run(cmd.Context(), args[0], flags.ProjectRef, pingRegions, pingCount, pingRequest, afero.NewOsFs())
+		},
+		Example: `  supabase functions ping hello-world --regions all
+  supabase functions ping hello-world --regions us-east-1,eu-central-1 --count 5`,
+	}
+
+	replayLocal    bool
+	replayFunction string
+	replayRole     = utils.EnumFlag{
+		Allowed: []string{invoke.RoleAnon, invoke.RoleServiceRole, invoke.RoleNone},
+		Value:   invoke.RoleAnon,
+	}
+
+	functionsReplayCmd = &cobra.Command{
+		Use:   "replay <requests.ndjson>",
+		Short: "Replay recorded requests against Functions",
+		Long:  "Re-send requests recorded by functions serve --record to Functions served locally, or deployed to the linked Supabase project, and compare response statuses.",
+		Args:  cobra.ExactArgs(1),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if replayLocal {
+				cmd.GroupID = groupLocalDev
+			}
+			return cmd.Root().PersistentPreRunE(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectRef := flags.ProjectRef
+			if replayLocal {
+				projectRef = ""
+			}
+			return replay.Run(cmd.Context(), args[0], replayFunction, projectRef, replayRole.Value, afero.NewOsFs())
+		},
+		Example: `  supabase functions replay requests.ndjson --local
+  supabase functions replay requests.ndjson --function hello-world --role service_role`,
+	}
+
+	envFilePath  string
+	envFilePaths []string
+	inspectRun   serve.InspectFlag
+	inspectBrk   serve.InspectFlag
+	inspectMode  = utils.EnumFlag{
 		Allowed: []string{
 			string(serve.InspectModeRun),
 			string(serve.InspectModeBrk),
@@ -104,38 +273,91 @@ var (
 			if !cmd.Flags().Changed("no-verify-jwt") {
 				noVerifyJWT = nil
 			}
+			setMountStrategy(cmd)
 
 			if len(inspectMode.Value) > 0 {
 				runtimeOption.InspectMode = utils.Ptr(serve.InspectMode(inspectMode.Value))
-			} else if inspectBrk {
+			} else if inspectBrk.Enabled {
 				runtimeOption.InspectMode = utils.Ptr(serve.InspectModeBrk)
+				runtimeOption.InspectAddr = inspectBrk.Addr
+			} else if inspectRun.Enabled {
+				runtimeOption.InspectMode = utils.Ptr(serve.InspectModeRun)
+				runtimeOption.InspectAddr = inspectRun.Addr
 			}
 			if runtimeOption.InspectMode == nil && runtimeOption.InspectMain {
-				return fmt.Errorf("--inspect-main must be used together with one of these flags: [inspect inspect-mode]")
+				return fmt.Errorf("--inspect-main must be used together with one of these flags: [inspect inspect-brk inspect-mode]")
 			}
 
-			return serve.Run(cmd.Context(), envFilePath, noVerifyJWT, importMapPath, runtimeOption, afero.NewOsFs())
+			return serve.Run(cmd.Context(), envFilePaths, noVerifyJWT, importMapPath, runtimeOption, afero.NewOsFs())
 		},
 	}
 )
 
 func init() {
 	functionsListCmd.Flags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	functionsListCmd.Flags().VarP(&listOutput, "output", "o", "Output format of functions list.")
+	functionsListCmd.Flags().StringSliceVar(&listColumns, "columns", nil, "Comma separated list of columns to display: "+strings.Join(list.AllColumns, ", ")+".")
 	functionsDeleteCmd.Flags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	functionsDeleteCmd.Flags().BoolVar(&deleteAll, "all", false, "Delete all Functions from the project.")
+	functionsDeleteCmd.Flags().BoolVar(&iKnowWhatImDoing, "i-know-what-im-doing", false, "Skip typing the project ref to confirm on protected projects.")
 	functionsDeployCmd.Flags().BoolVar(noVerifyJWT, "no-verify-jwt", false, "Disable JWT verification for the Function.")
 	functionsDeployCmd.Flags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
 	functionsDeployCmd.Flags().BoolVar(&useLegacyBundle, "legacy-bundle", false, "Use legacy bundling mechanism.")
 	functionsDeployCmd.Flags().StringVar(&importMapPath, "import-map", "", "Path to import map file.")
+	functionsDeployCmd.Flags().StringVar(&manifestPath, "manifest", "", "Path to a deploy manifest listing Functions and their overrides.")
+	functionsDeployCmd.Flags().Var(&deployFailOn, "fail-on", "Minimum severity of import audit findings and missing secrets that fails the deploy.")
+	functionsDeployCmd.Flags().Bool("skip-audit", false, "Skip auditing Function imports for unpinned and vulnerable packages.")
+	cobra.CheckErr(viper.BindPFlag("FUNCTIONS_SKIP_AUDIT", functionsDeployCmd.Flags().Lookup("skip-audit")))
+	functionsDeployCmd.Flags().IntVar(&compressLevel, "compress-level", brotli.DefaultCompression, "Brotli quality level between 0 and 11 used to compress the bundle.")
+	functionsDeployCmd.Flags().BoolVar(&noCompress, "no-compress", false, "Upload the bundle without compression.")
+	functionsDeployCmd.MarkFlagsMutuallyExclusive("compress-level", "no-compress")
+	functionsDeployCmd.Flags().Var(&mountStrategy, "mount-strategy", "How Function sources are made available to the bundler container.")
+	functionsDeployCmd.Flags().StringVar(&fromEszip, "from-eszip", "", "Path to a prebuilt eszip artifact to deploy without bundling.")
+	functionsDeployCmd.Flags().StringVar(&eszipParams.EntrypointPath, "entrypoint-path", "", "Entrypoint path recorded in the eszip artifact.")
+	functionsDeployCmd.Flags().StringVar(&eszipParams.ImportMapPath, "import-map-path", "", "Import map path recorded in the eszip artifact.")
+	functionsDeployCmd.MarkFlagsMutuallyExclusive("from-eszip", "manifest")
+	functionsDeployCmd.MarkFlagsMutuallyExclusive("from-eszip", "import-map")
+	functionsDeployCmd.MarkFlagsMutuallyExclusive("manifest", "import-map")
+	functionsDeployCmd.MarkFlagsMutuallyExclusive("manifest", "no-verify-jwt")
 	cobra.CheckErr(functionsDeployCmd.Flags().MarkHidden("legacy-bundle"))
-	functionsServeCmd.Flags().BoolVar(noVerifyJWT, "no-verify-jwt", false, "Disable JWT verification for the Function.")
-	functionsServeCmd.Flags().StringVar(&envFilePath, "env-file", "", "Path to an env file to be populated to the Function environment.")
+	functionsServeCmd.Flags().BoolVar(noVerifyJWT, "no-verify-jwt", false, "Disable JWT verification for all Functions, overriding config.toml.")
+	functionsServeCmd.Flags().StringArrayVar(&envFilePaths, "env-file", []string{}, "Path to an env file to be populated to the Function environment. Repeat to override values from earlier files.")
+	functionsServeCmd.Flags().BoolVar(&runtimeOption.PrintEnv, "print-env", false, "Print the resolved environment passed to the edge runtime.")
 	functionsServeCmd.Flags().StringVar(&importMapPath, "import-map", "", "Path to import map file.")
-	functionsServeCmd.Flags().BoolVar(&inspectBrk, "inspect", false, "Alias of --inspect-mode brk.")
-	functionsServeCmd.Flags().Var(&inspectMode, "inspect-mode", "Activate inspector capability for debugging.")
-	functionsServeCmd.Flags().BoolVar(&runtimeOption.InspectMain, "inspect-main", false, "Allow inspecting the main worker.")
-	functionsServeCmd.MarkFlagsMutuallyExclusive("inspect", "inspect-mode")
+	functionsServeCmd.Flags().StringVar(&runtimeOption.RecordPath, "record", "", "Append served requests and responses to this NDJSON file.")
+	serveFlags := functionsServeCmd.Flags()
+	serveFlags.Var(&inspectRun, "inspect", "Activate inspector on [host:]port, defaults to edge_runtime.inspector_port.")
+	serveFlags.Lookup("inspect").NoOptDefVal = "true"
+	serveFlags.Var(&inspectBrk, "inspect-brk", "Same as --inspect, but pauses on the first line of user code.")
+	serveFlags.Lookup("inspect-brk").NoOptDefVal = "true"
+	serveFlags.Var(&inspectMode, "inspect-mode", "Activate inspector capability for debugging.")
+	serveFlags.BoolVar(&runtimeOption.InspectMain, "inspect-main", false, "Allow inspecting the main worker.")
+	functionsServeCmd.MarkFlagsMutuallyExclusive("inspect", "inspect-brk", "inspect-mode")
+	serveFlags.Var(&mountStrategy, "mount-strategy", "How Function sources are made available to the edge runtime container.")
 	functionsServeCmd.Flags().Bool("all", true, "Serve all Functions.")
 	cobra.CheckErr(functionsServeCmd.Flags().MarkHidden("all"))
+	invokeFlags := functionsInvokeCmd.Flags()
+	invokeFlags.StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	invokeFlags.BoolVar(&invokeLocal, "local", false, "Invokes the Function served by the local development stack.")
+	invokeFlags.StringVarP(&invokeRequest.Method, "method", "X", "POST", "HTTP method of the request.")
+	invokeFlags.StringVarP(&invokeRequest.Body, "body", "d", "", "Request body, or @path to read it from a file.")
+	invokeFlags.StringArrayVarP(&invokeRequest.Headers, "header", "H", nil, "Additional request header of the form \"Name: Value\".")
+	invokeFlags.Var(&invokeRole, "role", "API key used to authorise the request.")
+	functionsInvokeCmd.MarkFlagsMutuallyExclusive("local", "project-ref")
+	functionsSetupEditorCmd.Flags().StringVar(&importMapPath, "import-map", "", "Path to import map file.")
+	pingFlags := functionsPingCmd.Flags()
+	pingFlags.StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	pingFlags.StringSliceVar(&pingRegions, "regions", []string{}, "Regions to invoke the Function in, or all. Defaults to the region nearest to you.")
+	pingFlags.UintVar(&pingCount, "count", 3, "Number of requests to send to each region, including the first.")
+	pingFlags.StringVarP(&pingRequest.Method, "method", "X", "POST", "HTTP method of the requests.")
+	pingFlags.StringVarP(&pingRequest.Body, "body", "d", "", "Request body, or @path to read it from a file.")
+	pingFlags.Var(&pingRole, "role", "API key used to authorise the requests.")
+	replayFlags := functionsReplayCmd.Flags()
+	replayFlags.StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	replayFlags.BoolVar(&replayLocal, "local", false, "Replays against Functions served by the local development stack.")
+	replayFlags.StringVar(&replayFunction, "function", "", "Only replay requests to this Function.")
+	replayFlags.Var(&replayRole, "role", "API key used to authorise the requests.")
+	functionsReplayCmd.MarkFlagsMutuallyExclusive("local", "project-ref")
 	functionsDownloadCmd.Flags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
 	functionsDownloadCmd.Flags().BoolVar(&useLegacyBundle, "legacy-bundle", false, "Use legacy bundling mechanism.")
 	functionsCmd.AddCommand(functionsListCmd)
@@ -144,5 +366,23 @@ func init() {
 	functionsCmd.AddCommand(functionsNewCmd)
 	functionsCmd.AddCommand(functionsServeCmd)
 	functionsCmd.AddCommand(functionsDownloadCmd)
+	functionsCmd.AddCommand(functionsInvokeCmd)
+	functionsCmd.AddCommand(functionsPingCmd)
+	functionsCmd.AddCommand(functionsReplayCmd)
+	functionsCmd.AddCommand(functionsSetupEditorCmd)
 	rootCmd.AddCommand(functionsCmd)
 }
+
+func compressOptions() []func(*deploy.CompressOptions) {
+	if noCompress {
+		return []func(*deploy.CompressOptions){deploy.WithoutCompression()}
+	}
+	return []func(*deploy.CompressOptions){deploy.WithCompressLevel(compressLevel)}
+}
+
+// The flag takes precedence over SUPABASE_MOUNT_STRATEGY env var.
+func setMountStrategy(cmd *cobra.Command) {
+	if cmd.Flags().Changed("mount-strategy") {
+		viper.Set("MOUNT_STRATEGY", mountStrategy.Value)
+	}
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/gen/graphql"
 	"github.com/supabase/cli/internal/gen/keys"
+	"github.com/supabase/cli/internal/gen/openapi"
 	"github.com/supabase/cli/internal/gen/types/typescript"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
@@ -51,12 +53,56 @@ var (
 		},
 	}
 
+	failOnBreaking bool
+
+	genGraphqlSchemaCmd = &cobra.Command{
+		Use:   "graphql-schema",
+		Short: "Generate GraphQL schema from pg_graphql",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if failOnBreaking && len(file) == 0 {
+				return errors.New("--fail-on-breaking can only be used together with --file.")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			var projectRef string
+			if linked, _ := cmd.Flags().GetBool("linked"); linked {
+				projectRef = flags.ProjectRef
+			}
+			return graphql.Run(ctx, projectRef, file, failOnBreaking, afero.NewOsFs())
+		},
+		Example: `  supabase gen graphql-schema --local > schema.graphql
+  supabase gen graphql-schema --linked --file schema.graphql --fail-on-breaking`,
+	}
+
+	openapiOutput string
+	openapiDiff   string
+
+	genOpenapiCmd = &cobra.Command{
+		Use:   "openapi",
+		Short: "Generate OpenAPI spec from PostgREST",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			var projectRef string
+			if linked, _ := cmd.Flags().GetBool("linked"); linked {
+				projectRef = flags.ProjectRef
+			}
+			return openapi.Run(ctx, projectRef, openapiOutput, openapiDiff, afero.NewOsFs())
+		},
+		Example: `  supabase gen openapi --local -o openapi.json
+  supabase gen openapi --linked --diff openapi.json`,
+	}
+
 	genTypesCmd = &cobra.Command{
 		Use:   "types",
 		Short: "Generate types from Postgres schema",
 	}
 
 	postgrestV9Compat bool
+	postgrestV12      bool
+	supabaseJsVersion string
+	checkPath         string
 
 	genTypesTypescriptCmd = &cobra.Command{
 		Use:   "typescript",
@@ -77,12 +123,24 @@ var (
 					return err
 				}
 			}
-			return typescript.Run(ctx, flags.ProjectRef, flags.DbConfig, schema, postgrestV9Compat, afero.NewOsFs())
+			opts := typescript.Options{
+				PostgrestV9Compat: postgrestV9Compat,
+				PostgrestV12:      postgrestV12,
+				Check:             checkPath,
+			}
+			if len(supabaseJsVersion) > 0 {
+				if err := opts.TargetSupabaseJs(supabaseJsVersion); err != nil {
+					return err
+				}
+			}
+			return typescript.Run(ctx, flags.ProjectRef, flags.DbConfig, schema, opts, afero.NewOsFs())
 		},
 		Example: `  supabase gen types typescript --local
   supabase gen types typescript --linked
   supabase gen types typescript --project-id abc-def-123 --schema public --schema private
-  supabase gen types typescript --db-url 'postgresql://...' --schema public --schema auth`,
+  supabase gen types typescript --db-url 'postgresql://...' --schema public --schema auth
+  supabase gen types typescript --local --supabase-js 2.50.0
+  supabase gen types typescript --linked --check src/database.types.ts`,
 	}
 )
 
@@ -95,6 +153,10 @@ func init() {
 	genTypesTypescriptCmd.MarkFlagsMutuallyExclusive("local", "linked", "project-id", "db-url")
 	genFlags.StringSliceVarP(&schema, "schema", "s", []string{}, "Comma separated list of schema to include.")
 	genFlags.BoolVar(&postgrestV9Compat, "postgrest-v9-compat", false, "Generate types compatible with PostgREST v9 and below. Only use together with --db-url.")
+	genFlags.BoolVar(&postgrestV12, "postgrest-v12", false, "Generate types for PostgREST v12 type helpers in newer versions of supabase-js.")
+	genFlags.StringVar(&supabaseJsVersion, "supabase-js", "", "Generate types supported by this version of supabase-js, ie. 2.50.0.")
+	genTypesTypescriptCmd.MarkFlagsMutuallyExclusive("postgrest-v9-compat", "postgrest-v12", "supabase-js")
+	genFlags.StringVar(&checkPath, "check", "", "Fails with a diff if the types differ from this file, without writing it.")
 	genTypesCmd.AddCommand(genTypesTypescriptCmd)
 	genCmd.AddCommand(genTypesCmd)
 	keyFlags := genKeysCmd.Flags()
@@ -102,5 +164,19 @@ func init() {
 	keyFlags.VarP(&keyOutput, "output", "o", "Output format of key variables.")
 	keyFlags.StringSliceVar(&override, "override-name", []string{}, "Override specific variable names.")
 	genCmd.AddCommand(genKeysCmd)
+	graphqlFlags := genGraphqlSchemaCmd.Flags()
+	graphqlFlags.Bool("local", true, "Generate schema from the local GraphQL endpoint.")
+	graphqlFlags.Bool("linked", false, "Generate schema from the linked project.")
+	genGraphqlSchemaCmd.MarkFlagsMutuallyExclusive("local", "linked")
+	graphqlFlags.StringVarP(&file, "file", "f", "", "Writes the schema to a file instead of stdout.")
+	graphqlFlags.BoolVar(&failOnBreaking, "fail-on-breaking", false, "Fails without writing if the schema has breaking changes compared to the existing file.")
+	genCmd.AddCommand(genGraphqlSchemaCmd)
+	openapiFlags := genOpenapiCmd.Flags()
+	openapiFlags.Bool("local", true, "Generate spec from the local REST endpoint.")
+	openapiFlags.Bool("linked", false, "Generate spec from the linked project.")
+	genOpenapiCmd.MarkFlagsMutuallyExclusive("local", "linked")
+	openapiFlags.StringVarP(&openapiOutput, "output", "o", "", "Writes the spec to a file instead of stdout.")
+	openapiFlags.StringVar(&openapiDiff, "diff", "", "Fails if the spec has breaking changes compared to a previous snapshot.")
+	genCmd.AddCommand(genOpenapiCmd)
 	rootCmd.AddCommand(genCmd)
 }
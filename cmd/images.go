@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/images/export"
+	"github.com/supabase/cli/internal/images/load"
+)
+
+var (
+	imagesCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "images",
+		Short:   "Manage local Supabase docker images",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			cmd.SetContext(ctx)
+			return cmd.Root().PersistentPreRunE(cmd, args)
+		},
+	}
+
+	imageArchive string
+
+	imagesExportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "Export all required images to a tar archive",
+		Long:  "Pulls all images required by the local development stack and writes them to a tar archive on stdout, for loading on an air-gapped machine.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return export.Run(cmd.Context(), imageArchive, afero.NewOsFs())
+		},
+	}
+
+	imagesImportCmd = &cobra.Command{
+		Use:   "import",
+		Short: "Import images from a tar archive",
+		Long:  "Loads images previously saved by images export from stdin into the local docker daemon.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return load.Run(cmd.Context(), imageArchive, afero.NewOsFs())
+		},
+	}
+)
+
+func init() {
+	imagesExportCmd.Flags().StringVarP(&imageArchive, "output", "o", "", "Path to write the image archive instead of stdout.")
+	imagesCmd.AddCommand(imagesExportCmd)
+	imagesImportCmd.Flags().StringVarP(&imageArchive, "input", "i", "", "Path to read the image archive instead of stdin.")
+	imagesCmd.AddCommand(imagesImportCmd)
+	rootCmd.AddCommand(imagesCmd)
+}
@@ -22,7 +22,7 @@ var (
 		Use:     "init",
 		Short:   "Initialize a local project",
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-			if !viper.IsSet("WORKDIR") {
+			if !viper.IsSet("WORKDIR") && !viper.IsSet("WORKSPACE") && !viper.IsSet("APP") {
 				// Prevents recursing to parent directory
 				viper.Set("WORKDIR", ".")
 			}
@@ -6,6 +6,7 @@ import (
 	"os/signal"
 	"path/filepath"
 
+	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/supabase/cli/internal/inspect/bloat"
@@ -74,10 +75,17 @@ var (
 		},
 	}
 
+	killLocks     bool
+	terminatePids []int
+
 	inspectLocksCmd = &cobra.Command{
-		Use:   "locks",
-		Short: "Show queries which have taken out an exclusive lock on a relation",
+		Use:         "locks",
+		Short:       "Show queries which have taken out an exclusive lock on a relation",
+		Annotations: auditAnnotations("kill", "terminate-pid"),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if killLocks || len(terminatePids) > 0 {
+				return locks.Kill(cmd.Context(), flags.DbConfig, terminatePids, afero.NewOsFs())
+			}
 			return locks.Run(cmd.Context(), flags.DbConfig, afero.NewOsFs())
 		},
 	}
@@ -90,10 +98,24 @@ var (
 		},
 	}
 
+	outliersBaseline  string
+	compareBaseline   bool
+	baselineThreshold float64
+	baselineMinCalls  uint
+
 	inspectOutliersCmd = &cobra.Command{
 		Use:   "outliers",
 		Short: "Show queries from pg_stat_statements ordered by total execution time",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if compareBaseline {
+				if len(outliersBaseline) == 0 {
+					return errors.New("--compare requires a --baseline file.")
+				}
+				return outliers.CompareBaseline(cmd.Context(), flags.DbConfig, outliersBaseline, baselineThreshold, baselineMinCalls, afero.NewOsFs())
+			}
+			if len(outliersBaseline) > 0 {
+				return outliers.SaveBaseline(cmd.Context(), flags.DbConfig, outliersBaseline, baselineMinCalls, afero.NewOsFs())
+			}
 			return outliers.Run(cmd.Context(), flags.DbConfig, afero.NewOsFs())
 		},
 	}
@@ -202,11 +224,13 @@ var (
 		},
 	}
 
-	outputDir string
+	outputDir    string
+	reportBundle bool
 
 	reportCmd = &cobra.Command{
 		Use:   "report",
 		Short: "Generate a CSV output for all inspect commands",
+		Long:  "Generate a CSV output for all inspect commands. Use --bundle to also collect local config, service versions, container states, and recent logs into a tarball for support tickets.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			if len(outputDir) == 0 {
@@ -218,6 +242,9 @@ var (
 					outputDir = defaultPath
 				}
 			}
+			if reportBundle {
+				return inspect.Bundle(ctx, outputDir, flags.DbConfig, afero.NewOsFs())
+			}
 			return inspect.Report(ctx, outputDir, flags.DbConfig, afero.NewOsFs())
 		},
 	}
@@ -232,8 +259,17 @@ func init() {
 	inspectDBCmd.AddCommand(inspectCacheHitCmd)
 	inspectDBCmd.AddCommand(inspectReplicationSlotsCmd)
 	inspectDBCmd.AddCommand(inspectIndexUsageCmd)
+	locksFlags := inspectLocksCmd.Flags()
+	locksFlags.BoolVar(&killLocks, "kill", false, "Prompt to terminate each backend at the root of a blocking tree.")
+	locksFlags.IntSliceVar(&terminatePids, "terminate-pid", []int{}, "Terminate the backends with these pids after confirmation.")
+	inspectLocksCmd.MarkFlagsMutuallyExclusive("kill", "terminate-pid")
 	inspectDBCmd.AddCommand(inspectLocksCmd)
 	inspectDBCmd.AddCommand(inspectBlockingCmd)
+	outliersFlags := inspectOutliersCmd.Flags()
+	outliersFlags.StringVar(&outliersBaseline, "baseline", "", "Path to a JSON file for saving the top queries, or comparing against with --compare.")
+	outliersFlags.BoolVar(&compareBaseline, "compare", false, "Fail if any query in the baseline became slower than the threshold.")
+	outliersFlags.Float64Var(&baselineThreshold, "threshold", 20, "Percentage increase in mean execution time allowed by --compare.")
+	outliersFlags.UintVar(&baselineMinCalls, "min-calls", 10, "Skip queries called fewer times than this when saving or comparing a baseline.")
 	inspectDBCmd.AddCommand(inspectOutliersCmd)
 	inspectDBCmd.AddCommand(inspectCallsCmd)
 	inspectDBCmd.AddCommand(inspectTotalIndexSizeCmd)
@@ -250,6 +286,7 @@ func init() {
 	inspectDBCmd.AddCommand(inspectRoleConnectionsCmd)
 	inspectCmd.AddCommand(inspectDBCmd)
 	reportCmd.Flags().StringVar(&outputDir, "output-dir", "", "Path to save CSV files in")
+	reportCmd.Flags().BoolVar(&reportBundle, "bundle", false, "Package all reports, config, and container logs into a redacted tarball.")
 	inspectCmd.AddCommand(reportCmd)
 	rootCmd.AddCommand(inspectCmd)
 }
@@ -10,16 +10,17 @@ import (
 	"github.com/supabase/cli/internal/link"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
-	"golang.org/x/term"
 )
 
 var (
+	skipServices bool
+
 	linkCmd = &cobra.Command{
 		GroupID: groupLocalDev,
 		Use:     "link",
 		Short:   "Link to a Supabase project",
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			if !term.IsTerminal(int(os.Stdin.Fd())) && !viper.IsSet("PROJECT_ID") {
+			if !utils.IsInteractive() && !viper.IsSet("PROJECT_ID") {
 				return cmd.MarkFlagRequired("project-ref")
 			}
 			return nil
@@ -34,7 +35,7 @@ var (
 			if err := utils.LoadConfigFS(fsys); err != nil {
 				return err
 			}
-			return link.Run(ctx, flags.ProjectRef, fsys)
+			return link.Run(ctx, flags.ProjectRef, skipServices, fsys)
 		},
 		PostRunE: func(cmd *cobra.Command, args []string) error {
 			return link.PostRun(flags.ProjectRef, os.Stdout, afero.NewOsFs())
@@ -46,6 +47,7 @@ func init() {
 	linkFlags := linkCmd.Flags()
 	linkFlags.StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
 	linkFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	linkFlags.BoolVar(&skipServices, "skip-services", false, "Link without reading service configs and versions from the management API.")
 	// For some reason, BindPFlag only works for StringVarP instead of StringP
 	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", linkFlags.Lookup("password")))
 	rootCmd.AddCommand(linkCmd)
@@ -21,12 +21,16 @@ var (
 		OpenBrowser: term.IsTerminal(int(os.Stdin.Fd())),
 		Fsys:        afero.NewOsFs(),
 	}
+	useOidc bool
 
 	loginCmd = &cobra.Command{
 		GroupID: groupLocalDev,
 		Use:     "login",
 		Short:   "Authenticate using an access token",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if useOidc {
+				return login.RunOidc(cmd.Context(), os.Stdout, params.Fsys)
+			}
 			if params.Token == "" {
 				params.Token = login.ParseAccessToken(os.Stdin)
 			}
@@ -47,5 +51,9 @@ func init() {
 	loginFlags.StringVar(&params.TokenName, "name", "", "Name that will be used to store token in your settings")
 	loginFlags.Lookup("name").DefValue = "built-in token name generator"
 	loginFlags.Bool("no-browser", false, "Do not open browser automatically")
+	loginFlags.BoolVar(&useOidc, "oidc", false, "Exchange the OIDC token of the current CI job for a short-lived access token")
+	// The token exchange endpoint is not generally available yet
+	cobra.CheckErr(loginFlags.MarkHidden("oidc"))
+	loginCmd.MarkFlagsMutuallyExclusive("oidc", "token")
 	rootCmd.AddCommand(loginCmd)
 }
@@ -8,6 +8,8 @@ import (
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/migration/capture"
+	"github.com/supabase/cli/internal/migration/down"
 	"github.com/supabase/cli/internal/migration/fetch"
 	"github.com/supabase/cli/internal/migration/list"
 	"github.com/supabase/cli/internal/migration/new"
@@ -35,10 +37,15 @@ var (
 		Use:   "list",
 		Short: "List local and remote migrations",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return list.Run(cmd.Context(), flags.DbConfig, afero.NewOsFs())
+			return list.Run(cmd.Context(), flags.DbConfig, migrationListOutput.Value, afero.NewOsFs())
 		},
 	}
 
+	migrationListOutput = utils.EnumFlag{
+		Allowed: utils.OutputDefaultAllowed,
+		Value:   utils.OutputPretty,
+	}
+
 	migrationNewCmd = &cobra.Command{
 		Use:   "new <migration name>",
 		Short: "Create an empty migration script",
@@ -56,8 +63,10 @@ var (
 	}
 
 	migrationRepairCmd = &cobra.Command{
-		Use:   "repair [version] ...",
-		Short: "Repair the migration history table",
+		Use:               "repair [version] ...",
+		Short:             "Repair the migration history table",
+		ValidArgsFunction: completeMigrationVersions,
+		Annotations:       auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return repair.Run(cmd.Context(), flags.DbConfig, args, targetStatus.Value, afero.NewOsFs())
 		},
@@ -80,8 +89,9 @@ var (
 	}
 
 	migrationUpCmd = &cobra.Command{
-		Use:   "up",
-		Short: "Apply pending migrations to local database",
+		Use:         "up",
+		Short:       "Apply pending migrations to local database",
+		Annotations: auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return up.Run(cmd.Context(), includeAll, flags.DbConfig, afero.NewOsFs())
 		},
@@ -90,6 +100,51 @@ var (
 		},
 	}
 
+	applyVersion string
+	applyTarget  string
+	applyDryRun  bool
+
+	migrationApplyCmd = &cobra.Command{
+		Use:   "apply",
+		Short: "Apply selected pending migrations to linked database",
+		Example: `  supabase migration apply --single 20240101000000
+  supabase migration apply --to 20240102000000 --dry-run`,
+		Annotations: auditAnnotations(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return up.RunTargeted(cmd.Context(), applyVersion, applyTarget, includeAll, applyDryRun, flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	downCount  uint
+	downTarget string
+
+	migrationDownCmd = &cobra.Command{
+		Use:         "down",
+		Short:       "Revert applied migrations",
+		Annotations: auditAnnotations(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return down.Run(cmd.Context(), downCount, downTarget, flags.DbConfig, afero.NewOsFs())
+		},
+		PostRun: func(cmd *cobra.Command, args []string) {
+			fmt.Println("Finished " + utils.Aqua("supabase migration down") + ".")
+		},
+	}
+
+	captureDryRun bool
+
+	migrationCaptureCmd = &cobra.Command{
+		Use:   "capture [migration name]",
+		Short: "Save schema changes made on local database to a migration script",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var name string
+			if len(args) > 0 {
+				name = args[0]
+			}
+			return capture.Run(cmd.Context(), name, captureDryRun, afero.NewOsFs())
+		},
+	}
+
 	migrationFetchCmd = &cobra.Command{
 		Use:   "fetch",
 		Short: "Fetch migration files from history table",
@@ -109,6 +164,7 @@ func init() {
 	listFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
 	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", listFlags.Lookup("password")))
 	migrationListCmd.MarkFlagsMutuallyExclusive("db-url", "password")
+	listFlags.VarP(&migrationListOutput, "output", "o", "Output format of migration list.")
 	migrationCmd.AddCommand(migrationListCmd)
 	// Build repair command
 	repairFlags := migrationRepairCmd.Flags()
@@ -125,6 +181,7 @@ func init() {
 	// Build squash command
 	squashFlags := migrationSquashCmd.Flags()
 	squashFlags.StringVar(&migrationVersion, "version", "", "Squash up to the specified version.")
+	cobra.CheckErr(migrationSquashCmd.RegisterFlagCompletionFunc("version", completeMigrationVersions))
 	squashFlags.String("db-url", "", "Squashes migrations of the database specified by the connection string (must be percent-encoded).")
 	squashFlags.Bool("linked", false, "Squashes the migration history of the linked project.")
 	squashFlags.Bool("local", true, "Squashes the migration history of the local database.")
@@ -141,7 +198,37 @@ func init() {
 	upFlags.Bool("local", true, "Applies pending migrations to the local database.")
 	migrationUpCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
 	migrationCmd.AddCommand(migrationUpCmd)
-	// Build up command
+	// Build apply command
+	applyFlags := migrationApplyCmd.Flags()
+	applyFlags.StringVar(&applyVersion, "single", "", "Apply only the pending migration with this version.")
+	applyFlags.StringVar(&applyTarget, "to", "", "Apply all pending migrations up to and including this version.")
+	migrationApplyCmd.MarkFlagsMutuallyExclusive("single", "to")
+	migrationApplyCmd.MarkFlagsOneRequired("single", "to")
+	applyFlags.BoolVar(&includeAll, "include-all", false, "Include all migrations not found on remote history table.")
+	applyFlags.BoolVar(&applyDryRun, "dry-run", false, "Print the migrations that would be applied, but don't apply them.")
+	applyFlags.String("db-url", "", "Applies migrations to the database specified by the connection string (must be percent-encoded).")
+	applyFlags.Bool("linked", true, "Applies migrations to the linked project.")
+	applyFlags.Bool("local", false, "Applies migrations to the local database.")
+	migrationApplyCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	applyFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", applyFlags.Lookup("password")))
+	migrationApplyCmd.MarkFlagsMutuallyExclusive("db-url", "password")
+	migrationCmd.AddCommand(migrationApplyCmd)
+	// Build down command
+	downFlags := migrationDownCmd.Flags()
+	downFlags.UintVar(&downCount, "count", 1, "Number of latest migrations to revert.")
+	downFlags.StringVar(&downTarget, "to", "", "Revert all migrations applied after the specified version.")
+	cobra.CheckErr(migrationDownCmd.RegisterFlagCompletionFunc("to", completeMigrationVersions))
+	migrationDownCmd.MarkFlagsMutuallyExclusive("count", "to")
+	downFlags.String("db-url", "", "Reverts migrations of the database specified by the connection string (must be percent-encoded).")
+	downFlags.Bool("linked", false, "Reverts migrations applied to the linked project.")
+	downFlags.Bool("local", true, "Reverts migrations applied to the local database.")
+	migrationDownCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	downFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", downFlags.Lookup("password")))
+	migrationDownCmd.MarkFlagsMutuallyExclusive("db-url", "password")
+	migrationCmd.AddCommand(migrationDownCmd)
+	// Build fetch command
 	fetchFlags := migrationFetchCmd.Flags()
 	fetchFlags.String("db-url", "", "Fetches migrations from the database specified by the connection string (must be percent-encoded).")
 	fetchFlags.Bool("linked", true, "Fetches migration history from the linked project.")
@@ -150,5 +237,8 @@ func init() {
 	migrationCmd.AddCommand(migrationFetchCmd)
 	// Build new command
 	migrationCmd.AddCommand(migrationNewCmd)
+	// Build capture command
+	migrationCaptureCmd.Flags().BoolVar(&captureDryRun, "dry-run", false, "Print the captured changes without writing a migration file.")
+	migrationCmd.AddCommand(migrationCaptureCmd)
 	rootCmd.AddCommand(migrationCmd)
 }
@@ -23,10 +23,11 @@ var (
 	}
 
 	orgsCreateCmd = &cobra.Command{
-		Use:   "create",
-		Short: "Create an organization",
-		Long:  "Create an organization for the logged-in user.",
-		Args:  cobra.ExactArgs(1),
+		Use:         "create",
+		Short:       "Create an organization",
+		Long:        "Create an organization for the logged-in user.",
+		Args:        cobra.ExactArgs(1),
+		Annotations: auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return create.Run(cmd.Context(), args[0])
 		},
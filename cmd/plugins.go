@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/plugins"
+	"github.com/supabase/cli/internal/plugins/install"
+	"github.com/supabase/cli/internal/plugins/list"
+)
+
+var (
+	pluginsCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "plugins",
+		Short:   "Manage supabase-<name> plugins",
+		Long: `Manage supabase-<name> plugins.
+
+Any executable named supabase-<name> in ~/.supabase/plugins or $PATH can be run as
+supabase <name>, with all remaining arguments forwarded to the plugin. On Windows,
+plugins must be named supabase-<name>.exe instead.`,
+	}
+
+	pluginsListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List all installed plugins",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return list.Run(afero.NewOsFs())
+		},
+	}
+
+	pluginName     string
+	pluginChecksum string
+
+	pluginsInstallCmd = &cobra.Command{
+		Use:   "install <path or url>",
+		Short: "Install a plugin from a local file or URL",
+		Long:  "Install a plugin from a local file or https URL. Downloaded plugins must match the sha256 checksum passed in --sha256 flag.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			return install.Run(ctx, args[0], pluginName, pluginChecksum, afero.NewOsFs())
+		},
+		Example: `  supabase plugins install ./bin/supabase-mask
+  supabase plugins install https://example.com/supabase-tenant --name tenant --sha256 <checksum>`,
+	}
+)
+
+func init() {
+	pluginsInstallCmd.Flags().StringVar(&pluginName, "name", "", "Name of the plugin command. Defaults to the file name without supabase- prefix.")
+	pluginsInstallCmd.Flags().StringVar(&pluginChecksum, "sha256", "", "Expected sha256 checksum of the plugin. Required for URLs.")
+	pluginsCmd.AddCommand(pluginsListCmd)
+	pluginsCmd.AddCommand(pluginsInstallCmd)
+	rootCmd.AddCommand(pluginsCmd)
+}
+
+// Runs a supabase-<name> plugin if the first argument is not a built-in command.
+func maybeRunPlugin(args []string) (bool, error) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return false, nil
+	}
+	if _, _, err := rootCmd.Find(args); err == nil {
+		return false, nil
+	}
+	path, ok := plugins.LookupPlugin(args[0], afero.NewOsFs())
+	if !ok {
+		return false, nil
+	}
+	ctx, _ := signal.NotifyContext(context.Background(), os.Interrupt)
+	return true, plugins.Exec(ctx, path, args[1:])
+}
@@ -11,6 +11,9 @@ import (
 	"github.com/supabase/cli/internal/projects/create"
 	"github.com/supabase/cli/internal/projects/delete"
 	"github.com/supabase/cli/internal/projects/list"
+	"github.com/supabase/cli/internal/projects/pause"
+	"github.com/supabase/cli/internal/projects/resize"
+	"github.com/supabase/cli/internal/projects/restore"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
 	"github.com/supabase/cli/pkg/api"
@@ -51,6 +54,7 @@ var (
 			}
 			return nil
 		},
+		Annotations: auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
 				projectName = args[0]
@@ -73,14 +77,121 @@ var (
 		},
 	}
 
+	apiKeyName   string
+	apiKeyOutput = utils.EnumFlag{
+		Allowed: []string{utils.OutputPretty, utils.OutputJson, utils.OutputToml, utils.OutputYaml, utils.OutputRaw},
+		Value:   utils.OutputPretty,
+	}
+
 	projectsApiKeysCmd = &cobra.Command{
 		Use:   "api-keys",
 		Short: "List all API keys for a Supabase project",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return apiKeys.Run(cmd.Context(), flags.ProjectRef, afero.NewOsFs())
+			return apiKeys.Run(cmd.Context(), flags.ProjectRef, apiKeyName, apiKeyOutput.Value, afero.NewOsFs())
+		},
+		Example: `  supabase projects api-keys --name anon -o raw`,
+	}
+
+	apiKeyType = utils.EnumFlag{
+		Allowed: []string{
+			string(api.CreateApiKeyBodyTypePublishable),
+			string(api.CreateApiKeyBodyTypeSecret),
+		},
+	}
+	apiKeyDescription string
+	revokeOldKey      bool
+
+	projectsApiKeysCreateCmd = &cobra.Command{
+		Use:         "create",
+		Short:       "Create a publishable or secret API key",
+		Annotations: auditAnnotations(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return apiKeys.RunCreate(cmd.Context(), flags.ProjectRef, apiKeyType.Value, apiKeyDescription)
+		},
+	}
+
+	projectsApiKeysRotateCmd = &cobra.Command{
+		Use:         "rotate <name>",
+		Short:       "Replace a publishable or secret API key with a new one",
+		Args:        cobra.ExactArgs(1),
+		Annotations: auditAnnotations(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ConfirmProtectedRef(cmd.Context(), flags.ProjectRef, "rotate API keys", iKnowWhatImDoing, afero.NewOsFs()); err != nil {
+				return err
+			}
+			return apiKeys.RunRotate(cmd.Context(), flags.ProjectRef, args[0], revokeOldKey)
+		},
+	}
+
+	waitStatus bool
+
+	projectsPauseCmd = &cobra.Command{
+		Use:   "pause <ref>",
+		Short: "Pause a Supabase project",
+		Args:  cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !term.IsTerminal(int(os.Stdin.Fd())) {
+				return cobra.ExactArgs(1)(cmd, args)
+			}
+			return nil
+		},
+		Annotations: auditAnnotations(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			if len(args) == 0 {
+				title := "Which project do you want to pause?"
+				cobra.CheckErr(flags.PromptProjectRef(ctx, title))
+			} else {
+				flags.ProjectRef = args[0]
+			}
+			if err := utils.ConfirmProtectedRef(ctx, flags.ProjectRef, "pause the project", iKnowWhatImDoing, afero.NewOsFs()); err != nil {
+				return err
+			}
+			return pause.Run(ctx, flags.ProjectRef, waitStatus, afero.NewOsFs())
+		},
+	}
+
+	projectsRestoreCmd = &cobra.Command{
+		Use:   "restore <ref>",
+		Short: "Restore a paused Supabase project",
+		Args:  cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !term.IsTerminal(int(os.Stdin.Fd())) {
+				return cobra.ExactArgs(1)(cmd, args)
+			}
+			return nil
+		},
+		Annotations: auditAnnotations(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			if len(args) == 0 {
+				title := "Which project do you want to restore?"
+				cobra.CheckErr(flags.PromptProjectRef(ctx, title))
+			} else {
+				flags.ProjectRef = args[0]
+			}
+			return restore.Run(ctx, flags.ProjectRef, waitStatus, afero.NewOsFs())
 		},
 	}
 
+	computeSize string
+	diskType    = utils.EnumFlag{
+		Allowed: []string{string(api.Gp3), string(api.Io2)},
+	}
+	diskParams resize.DiskParams
+	waitResize bool
+
+	projectsResizeCmd = &cobra.Command{
+		Use:         "resize",
+		Short:       "Change the compute size or disk of a Supabase project",
+		Annotations: auditAnnotations(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			diskParams.Type = diskType.Value
+			return resize.Run(cmd.Context(), flags.ProjectRef, computeSize, diskParams, waitResize, afero.NewOsFs())
+		},
+		Example: `  supabase projects resize --compute small --disk-size 16 --wait`,
+	}
+
 	projectsDeleteCmd = &cobra.Command{
 		Use:   "delete <ref>",
 		Short: "Delete a Supabase project",
@@ -91,6 +202,7 @@ var (
 			}
 			return nil
 		},
+		Annotations: auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			if len(args) == 0 {
@@ -126,13 +238,39 @@ func init() {
 	cobra.CheckErr(createFlags.MarkHidden("plan"))
 	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", createFlags.Lookup("db-password")))
 
+	projectsApiKeysCmd.PersistentFlags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
 	apiKeysFlags := projectsApiKeysCmd.Flags()
-	apiKeysFlags.StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	apiKeysFlags.StringVar(&apiKeyName, "name", "", "Only print the API key with this name or id.")
+	apiKeysFlags.VarP(&apiKeyOutput, "output", "o", "Output format of API keys.")
+	createKeyFlags := projectsApiKeysCreateCmd.Flags()
+	createKeyFlags.Var(&apiKeyType, "type", "Type of the API key to create.")
+	createKeyFlags.StringVar(&apiKeyDescription, "description", "", "Description of the API key.")
+	cobra.CheckErr(projectsApiKeysCreateCmd.MarkFlagRequired("type"))
+	projectsApiKeysCmd.AddCommand(projectsApiKeysCreateCmd)
+	projectsApiKeysRotateCmd.Flags().BoolVar(&revokeOldKey, "revoke-old", false, "Revoke the old key after confirmation.")
+	projectsApiKeysRotateCmd.Flags().BoolVar(&iKnowWhatImDoing, "i-know-what-im-doing", false, "Skip typing the project ref to confirm on protected projects.")
+	projectsApiKeysCmd.AddCommand(projectsApiKeysRotateCmd)
+
+	resizeFlags := projectsResizeCmd.Flags()
+	resizeFlags.StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	resizeFlags.StringVar(&computeSize, "compute", "", "Compute add-on to switch to, by id or name.")
+	resizeFlags.Var(&diskType, "disk-type", "Type of the disk volume.")
+	resizeFlags.IntVar(&diskParams.SizeGb, "disk-size", 0, "Size of the disk in GB. Disks cannot be shrunk.")
+	resizeFlags.IntVar(&diskParams.Iops, "disk-iops", 0, "Provisioned IOPS of the disk.")
+	resizeFlags.IntVar(&diskParams.ThroughputMibps, "disk-throughput", 0, "Provisioned throughput of the disk in MiB/s.")
+	resizeFlags.BoolVar(&waitResize, "wait", false, "Wait for the project to become healthy after restarting.")
+
+	projectsPauseCmd.Flags().BoolVar(&waitStatus, "wait", false, "Wait for the project to become inactive.")
+	projectsPauseCmd.Flags().BoolVar(&iKnowWhatImDoing, "i-know-what-im-doing", false, "Skip typing the project ref to confirm on protected projects.")
+	projectsRestoreCmd.Flags().BoolVar(&waitStatus, "wait", false, "Wait for the project to become healthy.")
 
 	// Add commands to root
 	projectsCmd.AddCommand(projectsCreateCmd)
 	projectsCmd.AddCommand(projectsDeleteCmd)
 	projectsCmd.AddCommand(projectsListCmd)
 	projectsCmd.AddCommand(projectsApiKeysCmd)
+	projectsCmd.AddCommand(projectsResizeCmd)
+	projectsCmd.AddCommand(projectsPauseCmd)
+	projectsCmd.AddCommand(projectsRestoreCmd)
 	rootCmd.AddCommand(projectsCmd)
 }
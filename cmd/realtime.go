@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/realtime/get"
+	"github.com/supabase/cli/internal/realtime/push"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+var (
+	realtimeCmd = &cobra.Command{
+		GroupID: groupManagementAPI,
+		Use:     "realtime",
+		Short:   "Manage Supabase Realtime of your project",
+	}
+
+	realtimeConfigCmd = &cobra.Command{
+		Use:   "config",
+		Short: "Manage realtime settings of the linked project",
+	}
+
+	realtimeConfigGetCmd = &cobra.Command{
+		Use:   "get",
+		Short: "Get the current realtime settings",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return get.Run(cmd.Context(), flags.ProjectRef, afero.NewOsFs())
+		},
+	}
+
+	realtimeConfigPushCmd = &cobra.Command{
+		Use:   "push",
+		Short: "Push realtime settings from config.toml to the linked project",
+		Long: `Push realtime settings from config.toml to the linked project.
+
+Compares max_concurrent_users and private_only under the [realtime] section with the
+remote project, and only updates settings that differ after confirmation. These settings
+are not applied to the local realtime container.`,
+		Annotations: auditAnnotations(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return push.Run(cmd.Context(), flags.ProjectRef, afero.NewOsFs())
+		},
+	}
+)
+
+func init() {
+	realtimeCmd.PersistentFlags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	realtimeConfigCmd.AddCommand(realtimeConfigGetCmd)
+	realtimeConfigCmd.AddCommand(realtimeConfigPushCmd)
+	realtimeCmd.AddCommand(realtimeConfigCmd)
+	rootCmd.AddCommand(realtimeCmd)
+}
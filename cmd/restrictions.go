@@ -18,8 +18,9 @@ var (
 	bypassCidrChecks bool
 
 	restrictionsUpdateCmd = &cobra.Command{
-		Use:   "update",
-		Short: "Update network restrictions",
+		Use:         "update",
+		Short:       "Update network restrictions",
+		Annotations: auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return update.Run(cmd.Context(), flags.ProjectRef, dbCidrsToAllow, bypassCidrChecks)
 		},
@@ -16,6 +16,7 @@ import (
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/help"
 	"github.com/supabase/cli/internal/services"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
@@ -83,16 +84,33 @@ var (
 	}
 
 	createTicket bool
+	// Skips typed confirmation of destructive commands on protected projects
+	iKnowWhatImDoing bool
+	// Commands with --output json also report errors as json objects
+	errorFormat string
+	// Stops execution after printing command metadata
+	errHelpJson = errors.New("help json printed")
 
 	rootCmd = &cobra.Command{
 		Use:     "supabase",
 		Short:   "Supabase CLI " + utils.Version,
 		Version: utils.Version,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if isHelpJson(cmd) {
+				cmd.SilenceUsage = true
+				if err := help.WriteJson(cmd, os.Stdout); err != nil {
+					return err
+				}
+				return errors.New(errHelpJson)
+			}
 			if IsExperimental(cmd) && !viper.GetBool("EXPERIMENTAL") {
 				return errors.New("must set the --experimental flag to run this command")
 			}
 			cmd.SilenceUsage = true
+			removeOldBinary()
+			if f := cmd.Flags().Lookup("output"); f != nil {
+				errorFormat = f.Value.String()
+			}
 			// Change workdir
 			fsys := afero.NewOsFs()
 			if err := utils.ChangeWorkDir(fsys); err != nil {
@@ -111,9 +129,12 @@ var (
 					}
 				}
 			}
-			if err := flags.ParseDatabaseConfig(cmd.Flags(), fsys); err != nil {
+			if err := flags.ParseDatabaseConfig(ctx, cmd.Flags(), fsys); err != nil {
 				return err
 			}
+			if len(viper.GetString("TRACE")) > 0 {
+				utils.EnableTrace()
+			}
 			// Prepare context
 			if viper.GetBool("DEBUG") {
 				ctx = utils.WithTraceContext(ctx)
@@ -134,22 +155,84 @@ var (
 
 func Execute() {
 	defer recoverAndExit()
-	if err := rootCmd.Execute(); err != nil {
+	defer writeTrace()
+	defer removeRunTempDir()
+	if ok, err := maybeRunPlugin(os.Args[1:]); err != nil {
 		panic(err)
+	} else if ok {
+		return
+	}
+	registerProjectRefCompletion(rootCmd)
+	skipArgsOnHelpJson(rootCmd)
+	cmd, err := rootCmd.ExecuteC()
+	if errors.Is(err, errHelpJson) {
+		return
+	}
+	if cmd != nil {
+		recordAudit(cmd, err)
 	}
-	// Check upgrade last because --version flag is initialised after execute
-	version, err := checkUpgrade(rootCmd.Context(), afero.NewOsFs())
 	if err != nil {
-		fmt.Fprintln(utils.GetDebugLogger(), err)
+		panic(err)
 	}
-	if semver.Compare(version, "v"+utils.Version) > 0 {
-		fmt.Fprintln(os.Stderr, suggestUpgrade(version))
+	// Check upgrade last because --version flag is initialised after execute
+	if shouldNotifyUpgrade() {
+		version, err := checkUpgrade(rootCmd.Context(), afero.NewOsFs())
+		if err != nil {
+			fmt.Fprintln(utils.GetDebugLogger(), err)
+		}
+		if semver.Compare(version, "v"+utils.Version) > 0 {
+			fmt.Fprintln(os.Stderr, suggestUpgrade(version))
+		}
 	}
 	if len(utils.CmdSuggestion) > 0 {
 		fmt.Fprintln(os.Stderr, utils.CmdSuggestion)
 	}
 }
 
+func isHelpJson(cmd *cobra.Command) bool {
+	showJson, err := cmd.Flags().GetBool("help-json")
+	return err == nil && showJson
+}
+
+// Metadata is printed before running a command, so its positional args need not be valid.
+func skipArgsOnHelpJson(cmd *cobra.Command) {
+	if validate := cmd.Args; validate != nil {
+		cmd.Args = func(cmd *cobra.Command, args []string) error {
+			if isHelpJson(cmd) {
+				return nil
+			}
+			return validate(cmd, args)
+		}
+	}
+	for _, child := range cmd.Commands() {
+		skipArgsOnHelpJson(child)
+	}
+}
+
+func writeTrace() {
+	if path := viper.GetString("TRACE"); len(path) > 0 {
+		if err := utils.WriteTrace(path, utils.TraceFormat.Value, afero.NewOsFs()); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}
+}
+
+func removeRunTempDir() {
+	if err := utils.RemoveRunTempDir(afero.NewOsFs()); err != nil {
+		fmt.Fprintln(utils.GetDebugLogger(), err)
+	}
+}
+
+// Users can opt out of the notice by setting SUPABASE_NO_UPDATE_NOTIFIER.
+func shouldNotifyUpgrade() bool {
+	if viper.GetBool("NO_UPDATE_NOTIFIER") || viper.GetBool("QUIET") {
+		return false
+	}
+	// The upgrade command already reports the version it installed
+	cmd, _, err := rootCmd.Find(os.Args[1:])
+	return err != nil || cmd != upgradeCmd
+}
+
 func checkUpgrade(ctx context.Context, fsys afero.Fs) (string, error) {
 	if shouldFetchRelease(fsys) {
 		version, err := utils.GetLatestRelease(ctx)
@@ -181,7 +264,8 @@ func shouldFetchRelease(fsys afero.Fs) bool {
 func suggestUpgrade(version string) string {
 	const guide = "https://supabase.com/docs/guides/cli/getting-started#updating-the-supabase-cli"
 	return fmt.Sprintf(`A new version of Supabase CLI is available: %s (currently installed v%s)
-We recommend updating regularly for new features and bug fixes: %s`, utils.Yellow(version), utils.Version, utils.Bold(guide))
+We recommend updating regularly for new features and bug fixes: %s
+Run %s to update, or set SUPABASE_NO_UPDATE_NOTIFIER=1 to hide this notice.`, utils.Yellow(version), utils.Version, utils.Bold(guide), utils.Aqua("supabase upgrade"))
 }
 
 func recoverAndExit() {
@@ -189,11 +273,15 @@ func recoverAndExit() {
 	if err == nil {
 		return
 	}
-	var msg string
+	var msg, code, hint string
 	switch err := err.(type) {
 	case string:
 		msg = err
 	case error:
+		code, hint = utils.GetErrorCode(err)
+		if len(utils.CmdSuggestion) == 0 {
+			utils.CmdSuggestion = hint
+		}
 		if !errors.Is(err, context.Canceled) &&
 			len(utils.CmdSuggestion) == 0 &&
 			!viper.GetBool("DEBUG") {
@@ -203,10 +291,19 @@ func recoverAndExit() {
 	default:
 		msg = fmt.Sprintf("%#v", err)
 	}
-	// Log error to console
-	fmt.Fprintln(os.Stderr, utils.Red(msg))
-	if len(utils.CmdSuggestion) > 0 {
-		fmt.Fprintln(os.Stderr, utils.CmdSuggestion)
+	// Log error to console, keeping stdout free for the command's own output
+	if errorFormat == utils.OutputJson {
+		if err := utils.EncodeOutput(errorFormat, os.Stderr, newErrorOutput(code, msg)); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	} else {
+		fmt.Fprintln(os.Stderr, utils.Red(msg))
+		if len(code) > 0 {
+			fmt.Fprintln(os.Stderr, "Error code:", utils.Bold(code))
+		}
+		if len(utils.CmdSuggestion) > 0 {
+			fmt.Fprintln(os.Stderr, utils.CmdSuggestion)
+		}
 	}
 	// Report error to sentry
 	if createTicket && len(utils.SentryDsn) > 0 {
@@ -220,6 +317,24 @@ func recoverAndExit() {
 	os.Exit(1)
 }
 
+type errorOutput struct {
+	Error errorDetail `json:"error"`
+}
+
+type errorDetail struct {
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+func newErrorOutput(code, msg string) errorOutput {
+	return errorOutput{Error: errorDetail{
+		Code:    code,
+		Message: msg,
+		Hint:    utils.CmdSuggestion,
+	}}
+}
+
 func init() {
 	cobra.OnInitialize(func() {
 		// Allow overriding config object with automatic env
@@ -240,12 +355,36 @@ func init() {
 	flags := rootCmd.PersistentFlags()
 	flags.Bool("debug", false, "output debug logs to stderr")
 	flags.String("workdir", "", "path to a Supabase project directory")
+	flags.String("workspace", "", "name or path of a project listed in "+utils.WorkspacesManifestPath)
+	flags.String("app", "", "alias of --workspace")
 	flags.Bool("experimental", false, "enable experimental features")
 	flags.String("network-id", "", "use the specified docker network instead of a generated one")
+	flags.Bool("offline", false, "use only locally cached docker images without pulling from registry")
 	flags.Var(&utils.DNSResolver, "dns-resolver", "lookup domain names using the specified resolver")
+	flags.Var(&utils.NetworkPreference, "network", "connect to databases using the specified IP family")
+	flags.Int("max-api-concurrency", 0, "maximum number of concurrent management API requests")
+	flags.Bool("non-interactive", false, "disable prompts, such as the project selector, for use in CI")
+	flags.Bool("quiet", false, "only print the final result or errors")
+	flags.Bool("verbose", false, "print detailed progress, such as function bundler output")
 	flags.BoolVar(&createTicket, "create-ticket", false, "create a support ticket for any CLI error")
+	flags.String("trace", "", "write a trace of internal steps to the specified file")
+	flags.Var(&utils.TraceFormat, "trace-format", "format of the trace file")
+	flags.Bool("help-json", false, "print command metadata as JSON for documentation tooling")
+	cobra.CheckErr(flags.MarkHidden("app"))
+	rootCmd.MarkFlagsMutuallyExclusive("workdir", "workspace", "app")
+	rootCmd.MarkFlagsMutuallyExclusive("quiet", "verbose")
+	rootCmd.MarkFlagsMutuallyExclusive("quiet", "debug")
 	cobra.CheckErr(viper.BindPFlags(flags))
 
+	// Commands without RunE never reach PersistentPreRunE, so print their metadata as help instead
+	defaultHelp := rootCmd.HelpFunc()
+	rootCmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+		if !isHelpJson(cmd) {
+			defaultHelp(cmd, args)
+		} else if err := help.WriteJson(cmd, os.Stdout); err != nil {
+			cmd.PrintErrln(err)
+		}
+	})
 	rootCmd.SetVersionTemplate("{{.Version}}\n")
 	rootCmd.AddGroup(&cobra.Group{ID: groupQuickStart, Title: "Quick Start:"})
 	rootCmd.AddGroup(&cobra.Group{ID: groupLocalDev, Title: "Local Development:"})
@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"os"
+
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/audit"
+	"github.com/supabase/cli/internal/secrets/download"
 	"github.com/supabase/cli/internal/secrets/list"
 	"github.com/supabase/cli/internal/secrets/set"
 	"github.com/supabase/cli/internal/secrets/unset"
+	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
 )
 
@@ -25,20 +30,42 @@ var (
 		},
 	}
 
+	secretsEnvFile string
+	revealSecrets  bool
+
+	secretsDownloadCmd = &cobra.Command{
+		Use:   "download",
+		Short: "Download secrets of the linked project to an env file",
+		Long:  "Download secrets of the linked project in dotenv format. Values are redacted unless --reveal is specified.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return download.Run(cmd.Context(), flags.ProjectRef, secretsEnvFile, revealSecrets, afero.NewOsFs())
+		},
+		Example: `  supabase secrets download --env-file .env.production`,
+	}
+
 	secretsSetCmd = &cobra.Command{
 		Use:   "set <NAME=VALUE> ...",
 		Short: "Set a secret(s) on Supabase",
 		Long:  "Set a secret(s) to the linked Supabase project.",
+		// Values of NAME=VALUE args are redacted, keeping only the secret names
+		Annotations: map[string]string{
+			audit.AnnotationRecord:     "true",
+			audit.AnnotationRedactArgs: "*",
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return set.Run(cmd.Context(), flags.ProjectRef, envFilePath, args, afero.NewOsFs())
+			return set.Run(cmd.Context(), flags.ProjectRef, envFilePath, args, os.Stdin, afero.NewOsFs())
 		},
 	}
 
 	secretsUnsetCmd = &cobra.Command{
-		Use:   "unset [NAME] ...",
-		Short: "Unset a secret(s) on Supabase",
-		Long:  "Unset a secret(s) from the linked Supabase project.",
+		Use:         "unset [NAME] ...",
+		Short:       "Unset a secret(s) on Supabase",
+		Long:        "Unset a secret(s) from the linked Supabase project.",
+		Annotations: auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ConfirmProtectedRef(cmd.Context(), flags.ProjectRef, "unset secrets", iKnowWhatImDoing, afero.NewOsFs()); err != nil {
+				return err
+			}
 			return unset.Run(cmd.Context(), flags.ProjectRef, args, afero.NewOsFs())
 		},
 	}
@@ -47,7 +74,11 @@ var (
 func init() {
 	secretsCmd.PersistentFlags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
 	secretsSetCmd.Flags().StringVar(&envFilePath, "env-file", "", "Read secrets from a .env file.")
+	secretsDownloadCmd.Flags().StringVar(&secretsEnvFile, "env-file", "", "Path to write secrets to instead of stdout.")
+	secretsUnsetCmd.Flags().BoolVar(&iKnowWhatImDoing, "i-know-what-im-doing", false, "Skip typing the project ref to confirm on protected projects.")
+	secretsDownloadCmd.Flags().BoolVar(&revealSecrets, "reveal", false, "Include secret values or digests after confirmation.")
 	secretsCmd.AddCommand(secretsListCmd)
+	secretsCmd.AddCommand(secretsDownloadCmd)
 	secretsCmd.AddCommand(secretsSetCmd)
 	secretsCmd.AddCommand(secretsUnsetCmd)
 	rootCmd.AddCommand(secretsCmd)
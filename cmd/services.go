@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"os"
+
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/supabase/cli/internal/services"
+	"github.com/supabase/cli/internal/start"
 )
 
 var (
@@ -15,8 +18,21 @@ var (
 			return services.Run(cmd.Context(), afero.NewOsFs())
 		},
 	}
+
+	servicesGraphCmd = &cobra.Command{
+		Use:   "graph",
+		Short: "Show dependencies between local Supabase containers",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			cmd.GroupID = groupLocalDev
+			return cmd.Root().PersistentPreRunE(cmd, args)
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			start.PrintGraph(os.Stdout)
+		},
+	}
 )
 
 func init() {
+	servicesCmd.AddCommand(servicesGraphCmd)
 	rootCmd.AddCommand(servicesCmd)
 }
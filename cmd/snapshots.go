@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/snapshots/create"
+	"github.com/supabase/cli/internal/snapshots/delete"
+	"github.com/supabase/cli/internal/snapshots/list"
+	"github.com/supabase/cli/internal/snapshots/restore"
+)
+
+var (
+	snapshotsCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "snapshots",
+		Short:   "Manage named snapshots of your local database",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			cmd.SetContext(ctx)
+			return cmd.Root().PersistentPreRunE(cmd, args)
+		},
+	}
+
+	snapshotsCreateCmd = &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a snapshot of your local database",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return create.Run(cmd.Context(), args[0], afero.NewOsFs())
+		},
+	}
+
+	snapshotsRestoreCmd = &cobra.Command{
+		Use:   "restore <name>",
+		Short: "Restore your local database from a snapshot",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return restore.Run(cmd.Context(), args[0], afero.NewOsFs())
+		},
+	}
+
+	snapshotsListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List snapshots of your local database",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return list.Run(cmd.Context(), afero.NewOsFs())
+		},
+	}
+
+	snapshotsDeleteCmd = &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a snapshot of your local database",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return delete.Run(cmd.Context(), args[0], afero.NewOsFs())
+		},
+	}
+)
+
+func init() {
+	snapshotsCmd.AddCommand(snapshotsCreateCmd)
+	snapshotsCmd.AddCommand(snapshotsRestoreCmd)
+	snapshotsCmd.AddCommand(snapshotsListCmd)
+	snapshotsCmd.AddCommand(snapshotsDeleteCmd)
+	rootCmd.AddCommand(snapshotsCmd)
+}
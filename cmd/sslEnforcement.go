@@ -20,8 +20,9 @@ var (
 	dbDisableSsl bool
 
 	sslEnforcementUpdateCmd = &cobra.Command{
-		Use:   "update",
-		Short: "Update SSL enforcement configuration",
+		Use:         "update",
+		Short:       "Update SSL enforcement configuration",
+		Annotations: auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !dbEnforceSsl && !dbDisableSsl {
 				return errors.New("enable/disable not specified")
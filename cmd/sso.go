@@ -39,10 +39,11 @@ var (
 	}
 
 	ssoAddCmd = &cobra.Command{
-		Use:     "add",
-		Short:   "Add a new SSO identity provider",
-		Long:    "Add and configure a new connection to a SSO identity provider to your Supabase project.",
-		Example: `  supabase sso add --type saml --project-ref mwjylndxudmiehsxhmmz --metadata-url 'https://...' --domains example.com`,
+		Use:         "add",
+		Short:       "Add a new SSO identity provider",
+		Long:        "Add and configure a new connection to a SSO identity provider to your Supabase project.",
+		Example:     `  supabase sso add --type saml --project-ref mwjylndxudmiehsxhmmz --metadata-url 'https://...' --domains example.com`,
+		Annotations: auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return create.Run(cmd.Context(), create.RunParams{
 				ProjectRef:        flags.ProjectRef,
@@ -58,11 +59,12 @@ var (
 	}
 
 	ssoRemoveCmd = &cobra.Command{
-		Use:     "remove <provider-id>",
-		Short:   "Remove an existing SSO identity provider",
-		Long:    "Remove a connection to an already added SSO identity provider. Removing the provider will prevent existing users from logging in. Please treat this command with care.",
-		Args:    cobra.ExactArgs(1),
-		Example: `  supabase sso remove b5ae62f9-ef1d-4f11-a02b-731c8bbb11e8 --project-ref mwjylndxudmiehsxhmmz`,
+		Use:         "remove <provider-id>",
+		Short:       "Remove an existing SSO identity provider",
+		Long:        "Remove a connection to an already added SSO identity provider. Removing the provider will prevent existing users from logging in. Please treat this command with care.",
+		Args:        cobra.ExactArgs(1),
+		Example:     `  supabase sso remove b5ae62f9-ef1d-4f11-a02b-731c8bbb11e8 --project-ref mwjylndxudmiehsxhmmz`,
+		Annotations: auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !utils.UUIDPattern.MatchString(args[0]) {
 				return errors.Errorf("identity provider ID %q is not a UUID", args[0])
@@ -73,11 +75,12 @@ var (
 	}
 
 	ssoUpdateCmd = &cobra.Command{
-		Use:     "update <provider-id>",
-		Short:   "Update information about an SSO identity provider",
-		Long:    "Update the configuration settings of a already added SSO identity provider.",
-		Args:    cobra.ExactArgs(1),
-		Example: `  supabase sso update b5ae62f9-ef1d-4f11-a02b-731c8bbb11e8 --project-ref mwjylndxudmiehsxhmmz --add-domains example.com`,
+		Use:         "update <provider-id>",
+		Short:       "Update information about an SSO identity provider",
+		Long:        "Update the configuration settings of a already added SSO identity provider.",
+		Args:        cobra.ExactArgs(1),
+		Example:     `  supabase sso update b5ae62f9-ef1d-4f11-a02b-731c8bbb11e8 --project-ref mwjylndxudmiehsxhmmz --add-domains example.com`,
+		Annotations: auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !utils.UUIDPattern.MatchString(args[0]) {
 				return errors.Errorf("identity provider ID %q is not a UUID", args[0])
@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/config/migrate"
 	"github.com/supabase/cli/internal/start"
 )
 
@@ -12,6 +13,8 @@ var (
 	allowedContainers  = start.ExcludableContainers()
 	excludedContainers []string
 	ignoreHealthCheck  bool
+	autoPorts          bool
+	isolate            bool
 	preview            bool
 
 	startCmd = &cobra.Command{
@@ -19,7 +22,11 @@ var (
 		Use:     "start",
 		Short:   "Start containers for Supabase local development",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return start.Run(cmd.Context(), afero.NewOsFs(), excludedContainers, ignoreHealthCheck)
+			fsys := afero.NewOsFs()
+			if err := migrate.PromptMigrate(cmd.Context(), fsys); err != nil {
+				return err
+			}
+			return start.Run(cmd.Context(), fsys, excludedContainers, ignoreHealthCheck, autoPorts, isolate)
 		},
 	}
 )
@@ -27,8 +34,10 @@ var (
 func init() {
 	flags := startCmd.Flags()
 	names := strings.Join(allowedContainers, ",")
-	flags.StringSliceVarP(&excludedContainers, "exclude", "x", []string{}, "Names of containers to not start. ["+names+"]")
+	flags.StringSliceVarP(&excludedContainers, "exclude", "x", []string{}, "Names of containers to not start, or analytics to exclude both logflare and vector. ["+names+"]")
 	flags.BoolVar(&ignoreHealthCheck, "ignore-health-check", false, "Ignore unhealthy services and exit 0")
+	flags.BoolVar(&autoPorts, "auto-ports", false, "Select free host ports when configured ports are in use")
+	flags.BoolVar(&isolate, "isolate", false, "Start a separate stack when another workspace is running the same project_id")
 	flags.BoolVar(&preview, "preview", false, "Connect to feature preview branch")
 	cobra.CheckErr(flags.MarkHidden("preview"))
 	rootCmd.AddCommand(startCmd)
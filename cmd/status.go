@@ -12,9 +12,11 @@ import (
 )
 
 var (
-	override []string
-	names    status.CustomName
-	output   = utils.EnumFlag{
+	override   []string
+	names      status.CustomName
+	prefix     string
+	outputFile string
+	output     = utils.EnumFlag{
 		Allowed: append([]string{utils.OutputEnv}, utils.OutputDefaultAllowed...),
 		Value:   utils.OutputPretty,
 	}
@@ -27,13 +29,18 @@ var (
 			if err != nil {
 				return err
 			}
-			return env.Unmarshal(es, &names)
+			if err := env.Unmarshal(es, &names); err != nil {
+				return err
+			}
+			names.AddPrefix(prefix, es)
+			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
-			return status.Run(ctx, names, output.Value, afero.NewOsFs())
+			return status.Run(ctx, names, output.Value, outputFile, afero.NewOsFs())
 		},
 		Example: `  supabase status -o env --override-name api.url=NEXT_PUBLIC_SUPABASE_URL
+  supabase status -o env --prefix NEXT_PUBLIC_ --output-file .env.local
   supabase status -o json`,
 	}
 )
@@ -42,5 +49,7 @@ func init() {
 	flags := statusCmd.Flags()
 	flags.VarP(&output, "output", "o", "Output format of status variables.")
 	flags.StringSliceVar(&override, "override-name", []string{}, "Override specific variable names.")
+	flags.StringVar(&prefix, "prefix", "", "Prefix to prepend to all variable names.")
+	flags.StringVar(&outputFile, "output-file", "", "Path to write status variables instead of stdout.")
 	rootCmd.AddCommand(statusCmd)
 }
@@ -21,10 +21,11 @@ var (
 	recursive bool
 
 	lsCmd = &cobra.Command{
-		Use:     "ls [path]",
-		Example: "ls ss:///bucket/docs",
-		Short:   "List objects by path prefix",
-		Args:    cobra.MaximumNArgs(1),
+		Use:               "ls [path]",
+		Example:           "ls ss:///bucket/docs",
+		Short:             "List objects by path prefix",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeStoragePaths,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			objectPath := client.STORAGE_SCHEME + ":///"
 			if len(args) > 0 {
@@ -43,8 +44,10 @@ var (
 cp -r docs ss:///bucket/docs
 cp -r ss:///bucket/docs .
 `,
-		Short: "Copy objects from src to dst path",
-		Args:  cobra.ExactArgs(2),
+		Short:             "Copy objects from src to dst path",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeStoragePaths,
+		Annotations:       auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts := func(fo *storage.FileOptions) {
 				fo.CacheControl = options.CacheControl
@@ -55,10 +58,12 @@ cp -r ss:///bucket/docs .
 	}
 
 	mvCmd = &cobra.Command{
-		Use:     "mv <src> <dst>",
-		Short:   "Move objects from src to dst path",
-		Example: "mv -r ss:///bucket/docs ss:///bucket/www/docs",
-		Args:    cobra.ExactArgs(2),
+		Use:               "mv <src> <dst>",
+		Short:             "Move objects from src to dst path",
+		Example:           "mv -r ss:///bucket/docs ss:///bucket/www/docs",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeStoragePaths,
+		Annotations:       auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return mv.Run(cmd.Context(), args[0], args[1], recursive, afero.NewOsFs())
 		},
@@ -70,7 +75,9 @@ cp -r ss:///bucket/docs .
 		Example: `rm -r ss:///bucket/docs
 rm ss:///bucket/docs/example.md ss:///bucket/readme.md
 `,
-		Args: cobra.MinimumNArgs(1),
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeStoragePaths,
+		Annotations:       auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return rm.Run(cmd.Context(), args, recursive, afero.NewOsFs())
 		},
@@ -24,17 +24,20 @@ var (
 	}
 
 	template = utils.EnumFlag{
-		Allowed: []string{new.TemplatePgTAP},
+		Allowed: new.TemplateAllowed,
 		Value:   new.TemplatePgTAP,
 	}
 
+	testSchema   string
+	testCoverage bool
+
 	testNewCmd = &cobra.Command{
 		Use:   "new <name>",
 		Short: "Create a new test file",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
-			return new.Run(ctx, args[0], template.Value, afero.NewOsFs())
+			return new.Run(ctx, args[0], template.Value, testSchema, afero.NewOsFs())
 		},
 	}
 )
@@ -45,11 +48,13 @@ func init() {
 	dbFlags.String("db-url", "", "Tests the database specified by the connection string (must be percent-encoded).")
 	dbFlags.Bool("linked", false, "Runs pgTAP tests on the linked project.")
 	dbFlags.Bool("local", true, "Runs pgTAP tests on the local database.")
+	dbFlags.BoolVar(&testCoverage, "coverage", false, "Summarise tables and policies referenced by tests.")
 	testDbCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
 	testCmd.AddCommand(testDbCmd)
 	// Build new command
 	newFlags := testNewCmd.Flags()
 	newFlags.VarP(&template, "template", "t", "Template framework to generate.")
+	newFlags.StringVar(&testSchema, "schema", "", "Schema of the table or function under test.")
 	testCmd.AddCommand(testNewCmd)
 	// Build test command
 	rootCmd.AddCommand(testCmd)
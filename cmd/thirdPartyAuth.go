@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/third_party_auth/add"
+	"github.com/supabase/cli/internal/third_party_auth/list"
+	"github.com/supabase/cli/internal/third_party_auth/remove"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+var (
+	thirdPartyAuthCmd = &cobra.Command{
+		GroupID: groupManagementAPI,
+		Use:     "third-party-auth",
+		Short:   "Manage third-party auth integrations of a project",
+	}
+
+	tpaIssuerUrl string
+	tpaJwksUrl   string
+	tpaOutput    = utils.EnumFlag{
+		Allowed: utils.OutputDefaultAllowed,
+		Value:   utils.OutputPretty,
+	}
+
+	thirdPartyAuthAddCmd = &cobra.Command{
+		Use:   "add",
+		Short: "Accept JWTs issued by a third-party auth provider",
+		Long:  "Accept JWTs issued by a third-party auth provider, such as Firebase, Auth0 or AWS Cognito. Defaults to the provider enabled under [auth.third_party] in config.toml.",
+		Example: `  supabase third-party-auth add --issuer-url https://securetoken.google.com/my-firebase-project
+  supabase third-party-auth add --project-ref mwjylndxudmiehsxhmmz`,
+		Annotations: auditAnnotations(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return add.Run(cmd.Context(), flags.ProjectRef, tpaIssuerUrl, tpaJwksUrl, tpaOutput.Value, afero.NewOsFs())
+		},
+	}
+
+	thirdPartyAuthListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List all third-party auth integrations of a project",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return list.Run(cmd.Context(), flags.ProjectRef, tpaOutput.Value)
+		},
+	}
+
+	thirdPartyAuthRemoveCmd = &cobra.Command{
+		Use:         "remove <id>",
+		Short:       "Remove a third-party auth integration",
+		Args:        cobra.ExactArgs(1),
+		Annotations: auditAnnotations(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !utils.UUIDPattern.MatchString(args[0]) {
+				return errors.Errorf("third-party auth ID %q is not a UUID", args[0])
+			}
+			return remove.Run(cmd.Context(), flags.ProjectRef, args[0])
+		},
+	}
+)
+
+func init() {
+	persistentFlags := thirdPartyAuthCmd.PersistentFlags()
+	persistentFlags.StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	persistentFlags.VarP(&tpaOutput, "output", "o", "Output format")
+	addFlags := thirdPartyAuthAddCmd.Flags()
+	addFlags.StringVar(&tpaIssuerUrl, "issuer-url", "", "OIDC issuer URL of the third-party auth provider.")
+	addFlags.StringVar(&tpaJwksUrl, "jwks-url", "", "URL of the JSON Web Key Set used to verify JWTs, if not discoverable from the issuer.")
+	thirdPartyAuthCmd.AddCommand(thirdPartyAuthAddCmd)
+	thirdPartyAuthCmd.AddCommand(thirdPartyAuthListCmd)
+	thirdPartyAuthCmd.AddCommand(thirdPartyAuthRemoveCmd)
+	rootCmd.AddCommand(thirdPartyAuthCmd)
+}
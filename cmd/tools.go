@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/tools"
+	"github.com/supabase/cli/internal/tools/install"
+)
+
+var (
+	toolsCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "tools",
+		Short:   "Manage standalone tools used without docker",
+		Long: `Manage standalone tools used without docker.
+
+Tools are downloaded at pinned versions, verified against known checksums, and cached
+in ~/.supabase/tools for all projects. Set SUPABASE_TOOLS_DIR to use a different cache.`,
+	}
+
+	toolsInstallCmd = &cobra.Command{
+		Use:       "install <deno|psql|pg_dump>...",
+		Short:     "Install pinned versions of deno, psql, or pg_dump",
+		Args:      cobra.MatchAll(cobra.MinimumNArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: tools.Names(),
+		Example:   `  supabase tools install deno pg_dump`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			return install.Run(ctx, args, afero.NewOsFs())
+		},
+	}
+)
+
+func init() {
+	toolsCmd.AddCommand(toolsInstallCmd)
+	rootCmd.AddCommand(toolsCmd)
+}
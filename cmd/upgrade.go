@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/upgrade"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var (
+	upgradeChannel = utils.EnumFlag{
+		Allowed: upgrade.ChannelAllowed,
+		Value:   upgrade.ChannelStable,
+	}
+
+	upgradeCmd = &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrade Supabase CLI to the latest release",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			exePath, err := getExecutable()
+			if err != nil {
+				return err
+			}
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			return upgrade.Run(ctx, upgradeChannel.Value, exePath, afero.NewOsFs())
+		},
+		Example: `  supabase upgrade
+  supabase upgrade --channel beta`,
+	}
+)
+
+func getExecutable() (string, error) {
+	exePath, err := os.Executable()
+	if err != nil {
+		return "", errors.Errorf("failed to find executable: %w", err)
+	}
+	if exePath, err = filepath.EvalSymlinks(exePath); err != nil {
+		return "", errors.Errorf("failed to resolve executable: %w", err)
+	}
+	return exePath, nil
+}
+
+// Cleans up after an upgrade on Windows, where the replaced binary is still running.
+func removeOldBinary() {
+	if runtime.GOOS != "windows" {
+		return
+	}
+	exePath, err := getExecutable()
+	if err != nil {
+		fmt.Fprintln(utils.GetDebugLogger(), err)
+		return
+	}
+	upgrade.RemoveOldBinary(exePath, afero.NewOsFs())
+}
+
+func init() {
+	upgradeCmd.Flags().Var(&upgradeChannel, "channel", "Release channel to upgrade from.")
+	rootCmd.AddCommand(upgradeCmd)
+}
@@ -30,6 +30,7 @@ Usage of vanity subdomains and custom domains is mutually exclusive.`,
 This reconfigures your Supabase project to respond to requests on your vanity subdomain.
 After the vanity subdomain is activated, your project's auth services will no longer function on the {project-ref}.{supabase-domain} hostname.
 `,
+		Annotations: auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return activate.Run(cmd.Context(), flags.ProjectRef, desiredSubdomain, afero.NewOsFs())
 		},
@@ -52,9 +53,10 @@ After the vanity subdomain is activated, your project's auth services will no lo
 	}
 
 	vanityDeleteCmd = &cobra.Command{
-		Use:   "delete",
-		Short: "Deletes a project's vanity subdomain",
-		Long:  `Deletes the vanity subdomain for a project, and reverts to using the project ref for routing.`,
+		Use:         "delete",
+		Short:       "Deletes a project's vanity subdomain",
+		Long:        `Deletes the vanity subdomain for a project, and reverts to using the project ref for routing.`,
+		Annotations: auditAnnotations(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return delete.Run(cmd.Context(), flags.ProjectRef, afero.NewOsFs())
 		},
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/utils/credentials"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/internal/vault/create"
+	"github.com/supabase/cli/internal/vault/delete"
+	"github.com/supabase/cli/internal/vault/list"
+	"github.com/supabase/cli/internal/vault/update"
+)
+
+var (
+	vaultCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "vault",
+		Short:   "Manage Supabase Vault",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			cmd.SetContext(ctx)
+			return cmd.Root().PersistentPreRunE(cmd, args)
+		},
+	}
+
+	vaultSecretsCmd = &cobra.Command{
+		Use:   "secrets",
+		Short: "Manage secrets stored in Vault",
+	}
+
+	vaultSecretsListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List all secrets in Vault",
+		Long:  "List all secrets in Vault. Decrypted values are never printed.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return list.Run(cmd.Context(), flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	secretDescription string
+
+	vaultSecretsCreateCmd = &cobra.Command{
+		Use:         "create <name>",
+		Short:       "Create a secret in Vault",
+		Long:        "Create a secret in Vault. The secret value is read from stdin, or prompted for without echo, so that it never appears in shell history.",
+		Args:        cobra.ExactArgs(1),
+		Annotations: auditAnnotations(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			secret, err := promptVaultSecret(os.Stdin)
+			if err != nil {
+				return err
+			}
+			return create.Run(cmd.Context(), args[0], secret, secretDescription, flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	updateSecretValue bool
+
+	vaultSecretsUpdateCmd = &cobra.Command{
+		Use:         "update <name>",
+		Short:       "Update a secret in Vault",
+		Long:        "Update a secret in Vault. The new secret value is read from stdin, or prompted for without echo. Pass only --description to leave the value unchanged.",
+		Args:        cobra.ExactArgs(1),
+		Annotations: auditAnnotations(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var secret, description *string
+			if cmd.Flags().Changed("description") {
+				description = &secretDescription
+			}
+			if description == nil || updateSecretValue {
+				value, err := promptVaultSecret(os.Stdin)
+				if err != nil {
+					return err
+				}
+				secret = &value
+			}
+			return update.Run(cmd.Context(), args[0], secret, description, flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	vaultSecretsDeleteCmd = &cobra.Command{
+		Use:         "delete <name> ...",
+		Short:       "Delete secrets from Vault",
+		Args:        cobra.MinimumNArgs(1),
+		Annotations: auditAnnotations(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return delete.Run(cmd.Context(), args, flags.DbConfig, afero.NewOsFs())
+		},
+	}
+)
+
+func init() {
+	vaultFlags := vaultCmd.PersistentFlags()
+	vaultFlags.String("db-url", "", "Manages Vault in the database specified by the connection string (must be percent-encoded).")
+	vaultFlags.Bool("linked", false, "Manages Vault in the linked project.")
+	vaultFlags.Bool("local", true, "Manages Vault in the local database.")
+	vaultCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	vaultFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", vaultFlags.Lookup("password")))
+	vaultSecretsCreateCmd.Flags().StringVar(&secretDescription, "description", "", "Description of the secret.")
+	vaultSecretsUpdateCmd.Flags().StringVar(&secretDescription, "description", "", "New description of the secret.")
+	vaultSecretsUpdateCmd.Flags().BoolVar(&updateSecretValue, "value", false, "Also update the secret value when --description is set.")
+	vaultSecretsCmd.AddCommand(vaultSecretsListCmd)
+	vaultSecretsCmd.AddCommand(vaultSecretsCreateCmd)
+	vaultSecretsCmd.AddCommand(vaultSecretsUpdateCmd)
+	vaultSecretsCmd.AddCommand(vaultSecretsDeleteCmd)
+	vaultCmd.AddCommand(vaultSecretsCmd)
+	rootCmd.AddCommand(vaultCmd)
+}
+
+func promptVaultSecret(stdin *os.File) (string, error) {
+	fmt.Fprintf(os.Stderr, "Enter the secret value: ")
+	secret := strings.TrimRight(credentials.PromptMasked(stdin), "\r\n")
+	if len(secret) == 0 {
+		return "", errors.New("Secret value must not be empty.")
+	}
+	return secret, nil
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/workspaces/list"
+)
+
+var (
+	workspacesCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "workspaces",
+		Short:   "Manage local workspaces",
+	}
+
+	workspacesListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List local Supabase stacks across all workspaces",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return list.Run(cmd.Context())
+		},
+	}
+)
+
+func init() {
+	workspacesCmd.AddCommand(workspacesListCmd)
+	rootCmd.AddCommand(workspacesCmd)
+}
@@ -0,0 +1,228 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const (
+	StatusSuccess = "success"
+	StatusFailure = "failure"
+
+	// Opts a command into the audit log. The value is "true" to always record the command,
+	// or a comma separated list of flags that only make it mutating when set.
+	AnnotationRecord = "audit.record"
+	// Comma separated positions of args holding credentials, or "*" for all args.
+	AnnotationRedactArgs = "audit.redact_args"
+
+	redacted = "[REDACTED]"
+)
+
+// Flags holding credentials are never written to the log
+var sensitiveFlag = regexp.MustCompile(`(?i)password|secret|token|key`)
+
+type Entry struct {
+	Timestamp  time.Time         `json:"timestamp"`
+	User       string            `json:"user"`
+	Command    string            `json:"command"`
+	ProjectRef string            `json:"project_ref,omitempty"`
+	Args       []string          `json:"args,omitempty"`
+	Flags      map[string]string `json:"flags,omitempty"`
+	Status     string            `json:"status"`
+	Error      string            `json:"error,omitempty"`
+	Version    string            `json:"cli_version,omitempty"`
+}
+
+// ShouldRecord checks the annotations of a command that was run with flags.
+func ShouldRecord(annotations map[string]string, flags *pflag.FlagSet) bool {
+	value, ok := annotations[AnnotationRecord]
+	if !ok {
+		return false
+	} else if value == "true" {
+		return true
+	}
+	for _, name := range strings.Split(value, ",") {
+		if flags != nil && flags.Changed(strings.TrimSpace(name)) {
+			return true
+		}
+	}
+	return false
+}
+
+func NewEntry(command, projectRef string, args []string, annotations map[string]string, flags *pflag.FlagSet, err error) Entry {
+	entry := Entry{
+		Timestamp:  time.Now().UTC(),
+		User:       currentUser(),
+		Command:    command,
+		ProjectRef: projectRef,
+		Args:       RedactArgs(args, annotations[AnnotationRedactArgs]),
+		Flags:      RedactFlags(flags),
+		Status:     StatusSuccess,
+		Version:    utils.Version,
+	}
+	if err != nil {
+		entry.Status = StatusFailure
+		entry.Error = err.Error()
+	}
+	return entry
+}
+
+func currentUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+// RedactArgs replaces args at the positions in spec. Args in NAME=VALUE form, ie. secrets,
+// keep their names.
+func RedactArgs(args []string, spec string) []string {
+	sensitive := map[int]bool{}
+	for _, pos := range strings.Split(spec, ",") {
+		if i, err := strconv.Atoi(strings.TrimSpace(pos)); err == nil {
+			sensitive[i] = true
+		}
+	}
+	result := make([]string, len(args))
+	for i, arg := range args {
+		if spec == "*" || sensitive[i] {
+			if name, _, found := strings.Cut(arg, "="); found {
+				arg = name + "=" + redacted
+			} else {
+				arg = redacted
+			}
+		}
+		result[i] = arg
+	}
+	return result
+}
+
+// Returns the flags set on the command line, with credentials redacted.
+func RedactFlags(flags *pflag.FlagSet) map[string]string {
+	if flags == nil {
+		return nil
+	}
+	result := map[string]string{}
+	flags.Visit(func(f *pflag.Flag) {
+		value := f.Value.String()
+		if f.Value.Type() != "bool" && sensitiveFlag.MatchString(f.Name) {
+			value = redacted
+		} else if u, err := url.Parse(value); err == nil && u.User != nil {
+			value = u.Redacted()
+		}
+		result[f.Name] = value
+	})
+	return result
+}
+
+// GetLogPath defaults to a file in the user's home directory, so that commands run
+// against all projects are logged in one place.
+func GetLogPath() (string, error) {
+	if path := viper.GetString("AUDIT_LOG_PATH"); len(path) > 0 {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.Errorf("failed to get $HOME directory: %w", err)
+	}
+	return filepath.Join(home, ".supabase", "audit.log"), nil
+}
+
+// Record appends the entry to the local audit log, and submits it to the remote endpoint
+// in SUPABASE_AUDIT_LOG_URL if set.
+func Record(ctx context.Context, entry Entry, fsys afero.Fs) error {
+	logPath, err := GetLogPath()
+	if err != nil {
+		return err
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Errorf("failed to encode audit entry: %w", err)
+	}
+	if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(logPath)); err != nil {
+		return err
+	}
+	f, err := fsys.OpenFile(logPath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return errors.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return errors.Errorf("failed to write audit log: %w", err)
+	}
+	if remoteUrl := viper.GetString("AUDIT_LOG_URL"); len(remoteUrl) > 0 {
+		return submit(ctx, remoteUrl, line)
+	}
+	return nil
+}
+
+func submit(ctx context.Context, remoteUrl string, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, remoteUrl, bytes.NewReader(body))
+	if err != nil {
+		return errors.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Errorf("failed to submit audit entry: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errors.Errorf("failed to submit audit entry: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// ReadEntries returns all entries in the local audit log, oldest first.
+func ReadEntries(fsys afero.Fs) ([]Entry, error) {
+	logPath, err := GetLogPath()
+	if err != nil {
+		return nil, err
+	}
+	f, err := fsys.Open(logPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+	var result []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1024*1024)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			fmt.Fprintf(os.Stderr, "Skipping malformed audit entry on line %d: %v\n", lineNum, err)
+			continue
+		}
+		result = append(result, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Errorf("failed to read audit log: %w", err)
+	}
+	return result, nil
+}
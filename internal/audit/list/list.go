@@ -0,0 +1,105 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/audit"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+)
+
+type RunParams struct {
+	ProjectRef string
+	// Matches the command or any of its parents, ie. functions matches functions deploy.
+	Command string
+	Since   time.Duration
+	// Shows the most recent entries only. Zero shows all entries.
+	Limit uint
+}
+
+func Run(ctx context.Context, params RunParams, format string, fsys afero.Fs) error {
+	entries, err := audit.ReadEntries(fsys)
+	if err != nil {
+		return err
+	}
+	entries = filterEntries(entries, params, time.Now())
+	if format == utils.OutputPretty {
+		if len(entries) == 0 {
+			fmt.Fprintln(os.Stderr, "No audit entries found.")
+			return nil
+		}
+		return list.RenderTable(ToMarkdown(entries))
+	}
+	if entries == nil {
+		entries = []audit.Entry{}
+	}
+	return utils.EncodeOutput(format, os.Stdout, entries)
+}
+
+func filterEntries(entries []audit.Entry, params RunParams, now time.Time) []audit.Entry {
+	var result []audit.Entry
+	for _, e := range entries {
+		if len(params.ProjectRef) > 0 && e.ProjectRef != params.ProjectRef {
+			continue
+		}
+		if len(params.Command) > 0 && e.Command != params.Command && !strings.HasPrefix(e.Command, params.Command+" ") {
+			continue
+		}
+		if params.Since > 0 && e.Timestamp.Before(now.Add(-params.Since)) {
+			continue
+		}
+		result = append(result, e)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Timestamp.Before(result[j].Timestamp)
+	})
+	if params.Limit > 0 && uint(len(result)) > params.Limit {
+		result = result[uint(len(result))-params.Limit:]
+	}
+	return result
+}
+
+func ToMarkdown(entries []audit.Entry) string {
+	table := `|TIMESTAMP (UTC)|USER|COMMAND|PROJECT REF|STATUS|ARGUMENTS|
+|-|-|-|-|-|-|
+`
+	for _, e := range entries {
+		table += fmt.Sprintf(
+			"|`%s`|`%s`|`%s`|`%s`|`%s`|`%s`|\n",
+			e.Timestamp.UTC().Format(time.DateTime),
+			e.User,
+			e.Command,
+			valueOrDash(e.ProjectRef),
+			e.Status,
+			valueOrDash(formatArgs(e)),
+		)
+	}
+	return table
+}
+
+func formatArgs(e audit.Entry) string {
+	args := append([]string{}, e.Args...)
+	names := make([]string, 0, len(e.Flags))
+	for name := range e.Flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		args = append(args, fmt.Sprintf("--%s=%s", name, e.Flags[name]))
+	}
+	// Pipes would break the markdown table
+	return strings.ReplaceAll(strings.Join(args, " "), "|", "\\|")
+}
+
+func valueOrDash(value string) string {
+	if len(value) == 0 {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,76 @@
+package list
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/audit"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestAuditListCommand(t *testing.T) {
+	viper.Set("AUDIT_LOG_PATH", "/tmp/audit.log")
+	t.Cleanup(func() { viper.Set("AUDIT_LOG_PATH", "") })
+
+	t.Run("lists audit entries", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		entry := audit.Entry{
+			Timestamp: time.Now(),
+			Command:   "functions deploy",
+			Args:      []string{"hello"},
+			Flags:     map[string]string{"project-ref": "test"},
+			Status:    audit.StatusSuccess,
+		}
+		require.NoError(t, audit.Record(context.Background(), entry, fsys))
+		// Run test
+		err := Run(context.Background(), RunParams{}, utils.OutputPretty, fsys)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("encodes empty log", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), RunParams{}, utils.OutputJson, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+	})
+}
+
+func TestFilterEntries(t *testing.T) {
+	now := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	entries := []audit.Entry{
+		{Timestamp: now.Add(-48 * time.Hour), Command: "db push", ProjectRef: "test"},
+		{Timestamp: now.Add(-time.Hour), Command: "functions deploy", ProjectRef: "test"},
+		{Timestamp: now.Add(-2 * time.Hour), Command: "functions delete", ProjectRef: "other"},
+		{Timestamp: now.Add(-time.Minute), Command: "functionsx", ProjectRef: "test"},
+	}
+
+	t.Run("filters by project and command", func(t *testing.T) {
+		result := filterEntries(entries, RunParams{ProjectRef: "test", Command: "functions"}, now)
+		assert.Equal(t, []audit.Entry{entries[1]}, result)
+	})
+
+	t.Run("filters by time and sorts oldest first", func(t *testing.T) {
+		result := filterEntries(entries, RunParams{Since: 24 * time.Hour}, now)
+		assert.Equal(t, []audit.Entry{entries[2], entries[1], entries[3]}, result)
+	})
+
+	t.Run("keeps most recent entries within limit", func(t *testing.T) {
+		result := filterEntries(entries, RunParams{Limit: 2}, now)
+		assert.Equal(t, []audit.Entry{entries[1], entries[3]}, result)
+	})
+}
+
+func TestFormatArgs(t *testing.T) {
+	entry := audit.Entry{
+		Args:  []string{"a|b"},
+		Flags: map[string]string{"project-ref": "test", "debug": "true"},
+	}
+	assert.Equal(t, "a\\|b --debug=true --project-ref=test", formatArgs(entry))
+}
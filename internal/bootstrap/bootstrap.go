@@ -88,7 +88,7 @@ func Run(ctx context.Context, starter StarterTemplate, fsys afero.Fs, options ..
 	policy := newBackoffPolicy(ctx)
 	if err := backoff.RetryNotify(func() error {
 		fmt.Fprintln(os.Stderr, "Linking project...")
-		keys, err = apiKeys.RunGetApiKeys(ctx, flags.ProjectRef)
+		keys, err = apiKeys.RunGetApiKeys(ctx, flags.ProjectRef, false)
 		return err
 	}, policy, newErrorCallback()); err != nil {
 		return err
@@ -116,7 +116,7 @@ func Run(ctx context.Context, starter StarterTemplate, fsys afero.Fs, options ..
 	}
 	policy.Reset()
 	if err := backoff.RetryNotify(func() error {
-		return push.Run(ctx, false, false, true, true, config, fsys)
+		return push.Run(ctx, false, false, true, true, nil, false, config, fsys)
 	}, policy, newErrorCallback()); err != nil {
 		return err
 	}
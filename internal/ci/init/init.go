@@ -0,0 +1,94 @@
+package init
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+const (
+	ProviderGithub = "github"
+	ProviderGitlab = "gitlab"
+)
+
+var (
+	//go:embed templates/github.yml
+	githubWorkflow string
+	//go:embed templates/gitlab.yml
+	gitlabPipeline string
+
+	// Custom delimiters avoid clashing with GitHub's ${{ }} expressions.
+	workflowTemplates = map[string]*template.Template{
+		ProviderGithub: template.Must(template.New(ProviderGithub).Delims("[[", "]]").Parse(githubWorkflow)),
+		ProviderGitlab: template.Must(template.New(ProviderGitlab).Delims("[[", "]]").Parse(gitlabPipeline)),
+	}
+	workflowPaths = map[string]string{
+		ProviderGithub: filepath.Join(".github", "workflows", "supabase.yml"),
+		ProviderGitlab: ".gitlab-ci.yml",
+	}
+)
+
+type WorkflowParams struct {
+	ProjectRef string
+	Branch     string
+	Version    string
+}
+
+func Run(ctx context.Context, provider, branch string, overwrite bool, fsys afero.Fs) error {
+	if err := flags.ParseProjectRef(ctx, fsys); err != nil {
+		return err
+	}
+	params := WorkflowParams{
+		ProjectRef: flags.ProjectRef,
+		Branch:     branch,
+		Version:    "latest",
+	}
+	// Pin the workflow to this CLI version so that generated flags stay valid
+	if len(utils.Version) > 0 {
+		params.Version = utils.Version
+	}
+	path, err := WriteWorkflow(provider, params, overwrite, fsys)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			utils.CmdSuggestion = fmt.Sprintf("Run %s to overwrite existing workflow.", utils.Aqua("supabase ci init --force"))
+		}
+		return err
+	}
+	fmt.Println("Generated " + provider + " workflow in " + utils.Bold(path) + ".")
+	fmt.Println("Add " + utils.Aqua("SUPABASE_ACCESS_TOKEN") + " and " + utils.Aqua("SUPABASE_DB_PASSWORD") + " to your CI secrets before merging.")
+	return nil
+}
+
+func WriteWorkflow(provider string, params WorkflowParams, overwrite bool, fsys afero.Fs) (string, error) {
+	tmpl, ok := workflowTemplates[provider]
+	if !ok {
+		return "", errors.Errorf("Unsupported CI provider: %s", provider)
+	}
+	path := workflowPaths[provider]
+	if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(path)); err != nil {
+		return "", err
+	}
+	flag := os.O_WRONLY | os.O_CREATE
+	if overwrite {
+		flag |= os.O_TRUNC
+	} else {
+		flag |= os.O_EXCL
+	}
+	f, err := fsys.OpenFile(path, flag, 0644)
+	if err != nil {
+		return "", errors.Errorf("failed to create workflow file: %w", err)
+	}
+	defer f.Close()
+	if err := tmpl.Execute(f, params); err != nil {
+		return "", errors.Errorf("failed to render workflow: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,84 @@
+package init
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+func TestWriteWorkflow(t *testing.T) {
+	params := WorkflowParams{
+		ProjectRef: apitest.RandomProjectRef(),
+		Branch:     "main",
+		Version:    "1.0.0",
+	}
+
+	t.Run("generates github workflow", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		path, err := WriteWorkflow(ProviderGithub, params, false, fsys)
+		// Check error
+		assert.NoError(t, err)
+		contents, err := afero.ReadFile(fsys, path)
+		require.NoError(t, err)
+		assert.Contains(t, string(contents), "SUPABASE_PROJECT_ID: "+params.ProjectRef)
+		assert.Contains(t, string(contents), "${{ secrets.SUPABASE_ACCESS_TOKEN }}")
+		assert.Contains(t, string(contents), "version: 1.0.0")
+	})
+
+	t.Run("generates gitlab pipeline", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		path, err := WriteWorkflow(ProviderGitlab, params, false, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, ".gitlab-ci.yml", path)
+		contents, err := afero.ReadFile(fsys, path)
+		require.NoError(t, err)
+		assert.Contains(t, string(contents), `$CI_COMMIT_BRANCH == "main"`)
+		assert.Contains(t, string(contents), "supabase@1.0.0")
+	})
+
+	t.Run("throws error on existing workflow", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, workflowPaths[ProviderGitlab], []byte{}, 0644))
+		// Run test
+		_, err := WriteWorkflow(ProviderGitlab, params, false, fsys)
+		// Check error
+		assert.ErrorIs(t, err, os.ErrExist)
+	})
+
+	t.Run("throws error on unknown provider", func(t *testing.T) {
+		// Run test
+		_, err := WriteWorkflow("jenkins", params, false, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Unsupported CI provider: jenkins")
+	})
+}
+
+func TestInitCommand(t *testing.T) {
+	t.Run("uses linked project ref", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		project := apitest.RandomProjectRef()
+		require.NoError(t, afero.WriteFile(fsys, utils.ProjectRefPath, []byte(project), 0644))
+		t.Cleanup(func() { flags.ProjectRef = "" })
+		// Run test
+		err := Run(context.Background(), ProviderGithub, "main", false, fsys)
+		// Check error
+		assert.NoError(t, err)
+		contents, err := afero.ReadFile(fsys, workflowPaths[ProviderGithub])
+		require.NoError(t, err)
+		assert.Contains(t, string(contents), project)
+	})
+}
@@ -0,0 +1,280 @@
+package verify
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/diff"
+	"github.com/supabase/cli/internal/db/lint"
+	"github.com/supabase/cli/internal/db/start"
+	"github.com/supabase/cli/internal/functions/deploy"
+	"github.com/supabase/cli/internal/gen/types/typescript"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const (
+	StatusPassed  = "passed"
+	StatusFailed  = "failed"
+	StatusSkipped = "skipped"
+
+	OutputJunit = "junit"
+)
+
+type Check struct {
+	Name     string  `json:"name"`
+	Status   string  `json:"status"`
+	Duration float64 `json:"duration"`
+	Message  string  `json:"message,omitempty"`
+}
+
+type Report struct {
+	Checks []Check `json:"checks"`
+}
+
+func Run(ctx context.Context, typesFile, lintLevel, format, reportFile string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	// Tables are rendered for terminals, so they are never written to a file
+	if len(reportFile) > 0 && format != OutputJunit && format != utils.OutputJson {
+		return errors.Errorf("--report-file requires --report %s or %s.", OutputJunit, utils.OutputJson)
+	}
+	var report Report
+	// 1. Validate config
+	configErr := report.Add("config", func() (string, error) {
+		return "", utils.LoadConfigFS(fsys)
+	})
+	// 2. Apply migrations and lint shadow database
+	var shadow string
+	defer func() {
+		if len(shadow) > 0 {
+			utils.DockerRemove(shadow)
+		}
+	}()
+	migrationErr := configErr
+	if configErr != nil {
+		report.Skip("migrations")
+	} else {
+		migrationErr = report.Add("migrations", func() (msg string, err error) {
+			if shadow, err = diff.CreateShadowDatabase(ctx, utils.Config.Db.ShadowPort); err != nil {
+				return "", err
+			}
+			if err := start.WaitForHealthyService(ctx, start.HealthTimeout, shadow); err != nil {
+				return "", err
+			}
+			if err := diff.MigrateShadowDatabase(ctx, shadow, fsys, options...); err != nil {
+				return "", err
+			}
+			return lintShadowDatabase(ctx, lintLevel, options...)
+		})
+	}
+	// 3. Bundle all functions
+	if configErr != nil {
+		report.Skip("functions")
+	} else {
+		_ = report.Add("functions", func() (string, error) {
+			return bundleFunctions(ctx, fsys)
+		})
+	}
+	// 4. Check generated types for drift
+	if migrationErr != nil || len(typesFile) == 0 {
+		report.Skip("types")
+	} else {
+		_ = report.Add("types", func() (string, error) {
+			return checkTypesDrift(ctx, typesFile, fsys)
+		})
+	}
+	// Write report
+	if err := writeReport(report, format, reportFile, fsys); err != nil {
+		return err
+	}
+	if failed := report.Failed(); failed > 0 {
+		return errors.Errorf("%d of %d checks failed.", failed, len(report.Checks))
+	}
+	return nil
+}
+
+func (r *Report) Add(name string, check func() (string, error)) error {
+	fmt.Fprintln(os.Stderr, "Checking", utils.Aqua(name)+"...")
+	started := time.Now()
+	msg, err := check()
+	result := Check{
+		Name:     name,
+		Status:   StatusPassed,
+		Duration: time.Since(started).Seconds(),
+		Message:  msg,
+	}
+	if err != nil {
+		result.Status = StatusFailed
+		result.Message = err.Error()
+	}
+	r.Checks = append(r.Checks, result)
+	return err
+}
+
+func (r *Report) Skip(name string) {
+	r.Checks = append(r.Checks, Check{Name: name, Status: StatusSkipped})
+}
+
+func (r Report) Failed() (count int) {
+	for _, c := range r.Checks {
+		if c.Status == StatusFailed {
+			count++
+		}
+	}
+	return count
+}
+
+func lintShadowDatabase(ctx context.Context, level string, options ...func(*pgx.ConnConfig)) (string, error) {
+	conn, err := diff.ConnectShadowDatabase(ctx, 10*time.Second, options...)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close(context.Background())
+	result, err := lint.LintDatabase(ctx, conn, nil)
+	if err != nil {
+		return "", err
+	}
+	var issues []string
+	for _, r := range lint.FilterResult(result, level) {
+		for _, issue := range r.Issues {
+			issues = append(issues, fmt.Sprintf("%s: %s %s", r.Function, issue.Level, issue.Message))
+		}
+	}
+	if len(issues) > 0 {
+		return "", errors.Errorf("found %d lint issues:\n%s", len(issues), strings.Join(issues, "\n"))
+	}
+	return "", nil
+}
+
+func bundleFunctions(ctx context.Context, fsys afero.Fs) (string, error) {
+	slugs, err := deploy.GetFunctionSlugs(fsys)
+	if err != nil {
+		return "", err
+	}
+	var failed []string
+	for _, slug := range slugs {
+		if err := deploy.Bundle(ctx, slug, "", fsys); err != nil {
+			failed = append(failed, slug+": "+err.Error())
+		}
+	}
+	if len(failed) > 0 {
+		return "", errors.Errorf("failed to bundle %d functions:\n%s", len(failed), strings.Join(failed, "\n"))
+	}
+	return fmt.Sprintf("bundled %d functions", len(slugs)), nil
+}
+
+func checkTypesDrift(ctx context.Context, typesFile string, fsys afero.Fs) (string, error) {
+	expected, err := afero.ReadFile(fsys, typesFile)
+	if err != nil {
+		return "", errors.Errorf("failed to read types file: %w", err)
+	}
+	config := pgconn.Config{
+		Host:     utils.Config.Hostname,
+		Port:     utils.Config.Db.ShadowPort,
+		User:     "postgres",
+		Password: utils.Config.Db.Password,
+		Database: "postgres",
+	}
+	schemas := utils.RemoveDuplicates(append([]string{"public"}, utils.Config.Api.Schemas...))
+	var actual bytes.Buffer
+	if err := typescript.GenerateFromHost(ctx, config, schemas, &actual); err != nil {
+		return "", err
+	}
+	if !bytes.Equal(bytes.TrimSpace(expected), bytes.TrimSpace(actual.Bytes())) {
+		return "", errors.Errorf("%s is out of date. Run %s to regenerate it.", typesFile, utils.Aqua("supabase gen types typescript --local"))
+	}
+	return "", nil
+}
+
+func writeReport(report Report, format, reportFile string, fsys afero.Fs) error {
+	var buf bytes.Buffer
+	switch format {
+	case OutputJunit:
+		if err := encodeJunit(report, &buf); err != nil {
+			return err
+		}
+	case utils.OutputJson:
+		if err := utils.EncodeOutput(format, &buf, report); err != nil {
+			return err
+		}
+	default:
+		table := "|CHECK|STATUS|DURATION|\n|-|-|-|\n"
+		for _, c := range report.Checks {
+			table += fmt.Sprintf("|`%s`|`%s`|`%.1fs`|\n", c.Name, c.Status, c.Duration)
+		}
+		if err := list.RenderTable(table); err != nil {
+			return err
+		}
+		for _, c := range report.Checks {
+			if c.Status == StatusFailed {
+				fmt.Fprintln(os.Stderr, utils.Red(c.Name+": ")+c.Message)
+			}
+		}
+		return nil
+	}
+	if len(reportFile) > 0 {
+		return utils.WriteFile(reportFile, buf.Bytes(), fsys)
+	}
+	_, err := io.Copy(os.Stdout, &buf)
+	return err
+}
+
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name    string        `xml:"name,attr"`
+	Time    string        `xml:"time,attr"`
+	Failure *junitMessage `xml:"failure,omitempty"`
+	Skipped *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+func encodeJunit(report Report, w io.Writer) error {
+	suite := junitTestSuite{
+		Name:     "supabase ci verify",
+		Tests:    len(report.Checks),
+		Failures: report.Failed(),
+	}
+	for _, c := range report.Checks {
+		tc := junitTestCase{Name: c.Name, Time: fmt.Sprintf("%.3f", c.Duration)}
+		switch c.Status {
+		case StatusFailed:
+			summary, _, _ := strings.Cut(c.Message, "\n")
+			tc.Failure = &junitMessage{Message: summary, Text: c.Message}
+		case StatusSkipped:
+			suite.Skipped++
+			tc.Skipped = &junitMessage{}
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return errors.Errorf("failed to write xml header: %w", err)
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suite); err != nil {
+		return errors.Errorf("failed to encode junit report: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
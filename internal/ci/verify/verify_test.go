@@ -0,0 +1,81 @@
+package verify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var report = Report{Checks: []Check{
+	{Name: "config", Status: StatusPassed, Duration: 0.1},
+	{Name: "migrations", Status: StatusFailed, Duration: 2, Message: "found 1 lint issues:\npublic.f: error invalid"},
+	{Name: "types", Status: StatusSkipped},
+}}
+
+func TestReportEncoding(t *testing.T) {
+	t.Run("encodes junit report", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := encodeJunit(report, &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), `<testsuite name="supabase ci verify" tests="3" failures="1" skipped="1">`)
+		assert.Contains(t, out.String(), `<testcase name="config" time="0.100"></testcase>`)
+		assert.Contains(t, out.String(), `<failure message="found 1 lint issues:">`)
+		assert.Contains(t, out.String(), `<skipped></skipped>`)
+	})
+
+	t.Run("writes json report to file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := writeReport(report, utils.OutputJson, "report.json", fsys)
+		// Check error
+		assert.NoError(t, err)
+		contents, err := afero.ReadFile(fsys, "report.json")
+		assert.NoError(t, err)
+		assert.Contains(t, string(contents), `"status": "failed"`)
+	})
+}
+
+func TestVerifyCommand(t *testing.T) {
+	t.Run("throws error on report file with table format", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Run(context.Background(), "", "error", utils.OutputPretty, "report.txt", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "--report-file requires --report junit or json.")
+		exists, err := afero.Exists(fsys, "report.txt")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("skips remaining checks on invalid config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Run(context.Background(), "", "error", utils.OutputJson, "report.json", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "1 of 4 checks failed.")
+		contents, err := afero.ReadFile(fsys, "report.json")
+		assert.NoError(t, err)
+		assert.Contains(t, string(contents), `"status": "skipped"`)
+	})
+}
+
+func TestReportAdd(t *testing.T) {
+	var r Report
+	// Run test
+	err := r.Add("functions", func() (string, error) {
+		return "", errors.New("bundle failed")
+	})
+	// Check error
+	assert.ErrorContains(t, err, "bundle failed")
+	assert.Equal(t, 1, r.Failed())
+}
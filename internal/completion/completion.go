@@ -0,0 +1,107 @@
+package completion
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/functions/deploy"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Shells invoke completion on every tab press, so remote values are cached briefly.
+const cacheTTL = 5 * time.Minute
+
+func FunctionSlugs(fsys afero.Fs) ([]string, error) {
+	return deploy.GetFunctionSlugs(fsys)
+}
+
+func MigrationVersions(fsys afero.Fs) ([]string, error) {
+	return list.LoadLocalVersions(fsys)
+}
+
+// ProjectRefs returns project refs described by their names, ie. `ref\tname`.
+func ProjectRefs(ctx context.Context, fsys afero.Fs) ([]string, error) {
+	return cached("projects", fsys, func() ([]string, error) {
+		if err := assertLoggedIn(fsys); err != nil {
+			return nil, err
+		}
+		resp, err := utils.GetSupabase().V1ListAllProjectsWithResponse(ctx)
+		if err != nil {
+			return nil, errors.Errorf("failed to list projects: %w", err)
+		}
+		if resp.JSON200 == nil {
+			return nil, errors.New("Unexpected error retrieving projects: " + string(resp.Body))
+		}
+		result := make([]string, len(*resp.JSON200))
+		for i, project := range *resp.JSON200 {
+			result[i] = project.Id + "\t" + project.Name
+		}
+		return result, nil
+	})
+}
+
+func BucketNames(ctx context.Context, projectRef string, fsys afero.Fs) ([]string, error) {
+	return cached("buckets_"+projectRef, fsys, func() ([]string, error) {
+		if err := assertLoggedIn(fsys); err != nil {
+			return nil, err
+		}
+		api, err := client.NewStorageAPI(ctx, projectRef)
+		if err != nil {
+			return nil, err
+		}
+		buckets, err := api.ListBuckets(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]string, len(buckets))
+		for i, b := range buckets {
+			result[i] = b.Name
+		}
+		return result, nil
+	})
+}
+
+// The management api client exits on missing token, which would break the user's shell.
+func assertLoggedIn(fsys afero.Fs) error {
+	_, err := utils.LoadAccessTokenFS(fsys)
+	return err
+}
+
+func getCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "supabase", "completion")
+}
+
+func cached(key string, fsys afero.Fs, fetch func() ([]string, error)) ([]string, error) {
+	path := filepath.Join(getCacheDir(), key+".json")
+	if info, err := fsys.Stat(path); err == nil && time.Since(info.ModTime()) < cacheTTL {
+		if contents, err := afero.ReadFile(fsys, path); err == nil {
+			var result []string
+			if err := json.Unmarshal(contents, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+	result, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	contents, err := json.Marshal(result)
+	if err != nil {
+		return nil, errors.Errorf("failed to encode completion cache: %w", err)
+	}
+	if err := utils.WriteFile(path, contents, fsys); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,130 @@
+package completion
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+func TestLocalCompletion(t *testing.T) {
+	t.Run("completes function slugs", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "hello", "index.ts"), nil, 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "_shared", "index.ts"), nil, 0644))
+		// Run test
+		slugs, err := FunctionSlugs(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"hello"}, slugs)
+	})
+
+	t.Run("completes migration versions", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.MigrationsDir, "20240101000000_init.sql"), nil, 0644))
+		// Run test
+		versions, err := MigrationVersions(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"20240101000000"}, versions)
+	})
+}
+
+func TestProjectRefs(t *testing.T) {
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	project := apitest.RandomProjectRef()
+
+	t.Run("caches project refs", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects").
+			Reply(http.StatusOK).
+			JSON([]api.V1ProjectResponse{{Id: project, Name: "test"}})
+		// Run test
+		refs, err := ProjectRefs(context.Background(), fsys)
+		assert.NoError(t, err)
+		cached, err := ProjectRefs(context.Background(), fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{project + "\ttest"}, refs)
+		assert.Equal(t, refs, cached)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("refreshes expired cache", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(getCacheDir(), "projects.json")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(`["stale"]`), 0644))
+		expired := time.Now().Add(-cacheTTL - time.Minute)
+		require.NoError(t, fsys.Chtimes(path, expired, expired))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects").
+			Reply(http.StatusOK).
+			JSON([]api.V1ProjectResponse{{Id: project, Name: "test"}})
+		// Run test
+		refs, err := ProjectRefs(context.Background(), fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{project + "\ttest"}, refs)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on server unavailable", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		_, err := ProjectRefs(context.Background(), afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Unexpected error retrieving projects:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestBucketNames(t *testing.T) {
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	project := apitest.RandomProjectRef()
+
+	t.Run("completes bucket names", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{Name: "service_role", ApiKey: "service-key"}})
+		gock.New("https://" + utils.GetSupabaseHost(project)).
+			Get("/storage/v1/bucket").
+			Reply(http.StatusOK).
+			JSON([]storage.BucketResponse{{Name: "avatars"}})
+		// Run test
+		buckets, err := BucketNames(context.Background(), project, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"avatars"}, buckets)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
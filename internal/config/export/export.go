@@ -0,0 +1,176 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const (
+	FormatHcl  = "hcl"
+	FormatJson = "json"
+)
+
+type Function struct {
+	Slug           string  `json:"slug"`
+	Name           string  `json:"name"`
+	VerifyJwt      *bool   `json:"verify_jwt,omitempty"`
+	ImportMapPath  *string `json:"import_map_path,omitempty"`
+	EntrypointPath *string `json:"entrypoint_path,omitempty"`
+}
+
+type NetworkRestrictions struct {
+	DbAllowedCidrs   []string `json:"db_allowed_cidrs"`
+	DbAllowedCidrsV6 []string `json:"db_allowed_cidrs_v6"`
+}
+
+// State is a provider agnostic snapshot of a project's configuration. Secret values
+// are never exported, only their names.
+type State struct {
+	ProjectRef          string              `json:"project_ref"`
+	Auth                map[string]any      `json:"auth"`
+	Functions           []Function          `json:"functions"`
+	SecretNames         []string            `json:"secret_names"`
+	NetworkRestrictions NetworkRestrictions `json:"network_restrictions"`
+}
+
+func Run(ctx context.Context, projectRef, format string, w io.Writer) error {
+	state, err := GetState(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	if format == FormatJson {
+		return utils.EncodeOutput(utils.OutputJson, w, state)
+	}
+	return EncodeHcl(*state, w)
+}
+
+func GetState(ctx context.Context, projectRef string) (*State, error) {
+	state := State{ProjectRef: projectRef}
+	// 1. Auth settings
+	auth, err := utils.GetSupabase().V1GetAuthServiceConfigWithResponse(ctx, projectRef)
+	if err != nil {
+		return nil, errors.Errorf("failed to retrieve auth config: %w", err)
+	} else if auth.JSON200 == nil {
+		return nil, errors.New("Unexpected error retrieving auth config: " + string(auth.Body))
+	}
+	if state.Auth, err = redactAuthConfig(auth.Body); err != nil {
+		return nil, err
+	}
+	// 2. Functions
+	functions, err := utils.GetSupabase().V1ListAllFunctionsWithResponse(ctx, projectRef)
+	if err != nil {
+		return nil, errors.Errorf("failed to list functions: %w", err)
+	} else if functions.JSON200 == nil {
+		return nil, errors.New("Unexpected error listing functions: " + string(functions.Body))
+	}
+	for _, f := range *functions.JSON200 {
+		state.Functions = append(state.Functions, Function{
+			Slug:           f.Slug,
+			Name:           f.Name,
+			VerifyJwt:      f.VerifyJwt,
+			ImportMapPath:  f.ImportMapPath,
+			EntrypointPath: f.EntrypointPath,
+		})
+	}
+	// 3. Secret names
+	secrets, err := utils.GetSupabase().V1ListAllSecretsWithResponse(ctx, projectRef)
+	if err != nil {
+		return nil, errors.Errorf("failed to list secrets: %w", err)
+	} else if secrets.JSON200 == nil {
+		return nil, errors.New("Unexpected error listing secrets: " + string(secrets.Body))
+	}
+	for _, s := range *secrets.JSON200 {
+		state.SecretNames = append(state.SecretNames, s.Name)
+	}
+	sort.Strings(state.SecretNames)
+	// 4. Network restrictions
+	network, err := utils.GetSupabase().V1GetNetworkRestrictionsWithResponse(ctx, projectRef)
+	if err != nil {
+		return nil, errors.Errorf("failed to retrieve network restrictions: %w", err)
+	} else if network.JSON200 == nil {
+		return nil, errors.New("Unexpected error retrieving network restrictions: " + string(network.Body))
+	}
+	if cidrs := network.JSON200.Config.DbAllowedCidrs; cidrs != nil {
+		state.NetworkRestrictions.DbAllowedCidrs = *cidrs
+	}
+	if cidrs := network.JSON200.Config.DbAllowedCidrsV6; cidrs != nil {
+		state.NetworkRestrictions.DbAllowedCidrsV6 = *cidrs
+	}
+	return &state, nil
+}
+
+var sensitiveKeys = []string{"secret", "pass", "token", "_key"}
+
+// Drops null values and any credentials from the auth config.
+func redactAuthConfig(body []byte) (map[string]any, error) {
+	var config map[string]any
+	if err := json.Unmarshal(body, &config); err != nil {
+		return nil, errors.Errorf("failed to parse auth config: %w", err)
+	}
+	for k, v := range config {
+		if v == nil || isSensitive(k) {
+			delete(config, k)
+		}
+	}
+	return config, nil
+}
+
+func isSensitive(key string) bool {
+	for _, s := range sensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Encodes state as resources of the supabase/supabase Terraform provider. Functions and
+// secrets have no equivalent resource, so they are exported as locals for reference.
+func EncodeHcl(state State, w io.Writer) error {
+	auth, err := json.MarshalIndent(state.Auth, "  ", "  ")
+	if err != nil {
+		return errors.Errorf("failed to encode auth config: %w", err)
+	}
+	restrictions := append([]string{}, state.NetworkRestrictions.DbAllowedCidrs...)
+	restrictions = append(restrictions, state.NetworkRestrictions.DbAllowedCidrsV6...)
+	network, err := json.MarshalIndent(map[string]any{"restrictions": restrictions}, "  ", "  ")
+	if err != nil {
+		return errors.Errorf("failed to encode network restrictions: %w", err)
+	}
+	functions, err := json.MarshalIndent(append([]Function{}, state.Functions...), "  ", "  ")
+	if err != nil {
+		return errors.Errorf("failed to encode functions: %w", err)
+	}
+	secrets, err := json.Marshal(append([]string{}, state.SecretNames...))
+	if err != nil {
+		return errors.Errorf("failed to encode secret names: %w", err)
+	}
+	name := "project_" + state.ProjectRef
+	if _, err := fmt.Fprintf(w, `# Generated by supabase config export. Import existing settings with:
+#   terraform import supabase_settings.%[1]s %[2]s
+resource "supabase_settings" "%[1]s" {
+  project_ref = %[3]q
+
+  auth = jsonencode(%[4]s)
+
+  network = jsonencode(%[5]s)
+}
+
+locals {
+  functions = %[6]s
+
+  # Secret values are not exported. Set them with supabase secrets set.
+  secret_names = %[7]s
+}
+`, name, state.ProjectRef, state.ProjectRef, auth, network, functions, secrets); err != nil {
+		return errors.Errorf("failed to write hcl: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestExportCommand(t *testing.T) {
+	// Setup valid project ref
+	project := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	mockApi := func() {
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/config/auth").
+			Reply(http.StatusOK).
+			JSON(map[string]any{
+				"site_url":              "http://localhost:3000",
+				"external_apple_secret": "dummy",
+				"smtp_pass":             "dummy",
+				"mailer_otp_exp":        3600,
+				"sms_provider":          nil,
+			})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{{Slug: "hello", Name: "hello", VerifyJwt: utils.Ptr(true)}})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{{Name: "STRIPE_KEY", Value: "dummy"}})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/network-restrictions").
+			Reply(http.StatusOK).
+			JSON(api.NetworkRestrictionsResponse{Config: api.NetworkRestrictionsRequest{
+				DbAllowedCidrs: &[]string{"0.0.0.0/0"},
+			}})
+	}
+
+	t.Run("exports json state", func(t *testing.T) {
+		defer gock.OffAll()
+		mockApi()
+		var out bytes.Buffer
+		// Run test
+		err := Run(context.Background(), project, FormatJson, &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{
+			"project_ref": "`+project+`",
+			"auth": {"site_url": "http://localhost:3000", "mailer_otp_exp": 3600},
+			"functions": [{"slug": "hello", "name": "hello", "verify_jwt": true}],
+			"secret_names": ["STRIPE_KEY"],
+			"network_restrictions": {"db_allowed_cidrs": ["0.0.0.0/0"], "db_allowed_cidrs_v6": null}
+		}`, out.String())
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("exports terraform hcl", func(t *testing.T) {
+		defer gock.OffAll()
+		mockApi()
+		var out bytes.Buffer
+		// Run test
+		err := Run(context.Background(), project, FormatHcl, &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), `resource "supabase_settings" "project_`+project+`" {`)
+		assert.Contains(t, out.String(), `"site_url": "http://localhost:3000"`)
+		assert.Contains(t, out.String(), `secret_names = ["STRIPE_KEY"]`)
+		assert.NotContains(t, out.String(), "dummy")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on auth config failure", func(t *testing.T) {
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/config/auth").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		err := Run(context.Background(), project, FormatHcl, &bytes.Buffer{})
+		// Check error
+		assert.ErrorContains(t, err, "Unexpected error retrieving auth config:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -0,0 +1,75 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var backupPath = utils.ConfigPath + ".bak"
+
+// Run rewrites deprecated fields in config.toml, keeping a backup of the original file.
+func Run(ctx context.Context, dryRun bool, fsys afero.Fs) error {
+	data, err := afero.ReadFile(fsys, utils.ConfigPath)
+	if err != nil {
+		return errors.Errorf("failed to read config: %w", err)
+	}
+	migrated, changes, err := utils.MigrateConfig(data)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		fmt.Fprintln(os.Stderr, utils.Bold(utils.ConfigPath)+" is up to date.")
+		return nil
+	}
+	printChanges(changes)
+	if dryRun {
+		return nil
+	}
+	return writeMigrated(data, migrated, fsys)
+}
+
+// PromptMigrate offers to migrate config.toml when it contains deprecated fields.
+func PromptMigrate(ctx context.Context, fsys afero.Fs) error {
+	data, err := afero.ReadFile(fsys, utils.ConfigPath)
+	if err != nil {
+		// Missing config is reported when loading it
+		return nil
+	}
+	migrated, changes, err := utils.MigrateConfig(data)
+	if err != nil || len(changes) == 0 {
+		return nil
+	}
+	printChanges(changes)
+	if !utils.IsInteractive() {
+		fmt.Fprintf(os.Stderr, "Run %s to update %s.\n", utils.Aqua("supabase config migrate"), utils.Bold(utils.ConfigPath))
+		return nil
+	}
+	console := utils.NewConsole()
+	if shouldMigrate, err := console.PromptYesNo(ctx, "Migrate "+utils.Bold(utils.ConfigPath)+" to the current schema?", false); err != nil || !shouldMigrate {
+		return err
+	}
+	return writeMigrated(data, migrated, fsys)
+}
+
+func printChanges(changes []utils.ConfigChange) {
+	fmt.Fprintln(os.Stderr, "Found deprecated fields in "+utils.Bold(utils.ConfigPath)+":")
+	for _, c := range changes {
+		fmt.Fprintf(os.Stderr, "  line %d: %s -> %s\n", c.Line, c.From, c.To)
+	}
+}
+
+func writeMigrated(original, migrated []byte, fsys afero.Fs) error {
+	if err := utils.WriteFile(backupPath, original, fsys); err != nil {
+		return err
+	}
+	if err := utils.WriteFile(utils.ConfigPath, migrated, fsys); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Migrated "+utils.Bold(utils.ConfigPath)+". Backup saved to "+utils.Bold(backupPath)+".")
+	return nil
+}
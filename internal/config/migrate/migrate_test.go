@@ -0,0 +1,58 @@
+package migrate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const testConfig = `project_id = "test"
+
+[db]
+port = 54322
+`
+
+func TestMigrateCommand(t *testing.T) {
+	t.Run("skips up to date config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(testConfig), 0644))
+		// Run test
+		err := Run(context.Background(), false, fsys)
+		// Check error
+		assert.NoError(t, err)
+		contents, err := afero.ReadFile(fsys, utils.ConfigPath)
+		assert.NoError(t, err)
+		assert.Equal(t, testConfig, string(contents))
+		exists, err := afero.Exists(fsys, backupPath)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("writes migrated config with backup", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		migrated := []byte("project_id = \"migrated\"\n")
+		// Run test
+		err := writeMigrated([]byte(testConfig), migrated, fsys)
+		// Check error
+		assert.NoError(t, err)
+		contents, err := afero.ReadFile(fsys, utils.ConfigPath)
+		assert.NoError(t, err)
+		assert.Equal(t, migrated, contents)
+		backup, err := afero.ReadFile(fsys, backupPath)
+		assert.NoError(t, err)
+		assert.Equal(t, testConfig, string(backup))
+	})
+
+	t.Run("throws error on missing config", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), false, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "failed to read config:")
+	})
+}
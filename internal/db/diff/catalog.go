@@ -0,0 +1,231 @@
+package diff
+
+import (
+	"context"
+	_ "embed"
+	"regexp"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/parser"
+)
+
+const (
+	CategoryComments     = "comments"
+	CategoryGrants       = "grants"
+	CategoryPolicies     = "policies"
+	CategoryPublications = "publications"
+)
+
+var CategoryAllowed = []string{CategoryComments, CategoryGrants, CategoryPolicies, CategoryPublications}
+
+var (
+	//go:embed templates/catalog/objects.sql
+	objectsQuery string
+	//go:embed templates/catalog/comments.sql
+	commentsQuery string
+	//go:embed templates/catalog/grants.sql
+	grantsQuery string
+	//go:embed templates/catalog/policies.sql
+	policiesQuery string
+	//go:embed templates/catalog/publications.sql
+	publicationsQuery string
+)
+
+type category struct {
+	name    string
+	query   string
+	pattern *regexp.Regexp
+	// Drops the existing entry before recreating it when its definition changed
+	replace bool
+}
+
+var categories = []category{
+	{CategoryPolicies, policiesQuery, regexp.MustCompile(`(?i)^(create|alter|drop)\s+policy\s`), true},
+	{CategoryGrants, grantsQuery, regexp.MustCompile(`(?i)^((grant|revoke)\s|alter\s.+\sowner\s+to\s)`), false},
+	{CategoryPublications, publicationsQuery, regexp.MustCompile(`(?i)^(create|alter|drop)\s+publication\s`), false},
+	{CategoryComments, commentsQuery, regexp.MustCompile(`(?i)^comment\s+on\s`), false},
+}
+
+type catalogEntry struct {
+	Key    string
+	Parent string
+	Create string
+	Drop   string
+}
+
+// WithCatalog diffs policies, grants and ownership, publications, and comments from
+// the system catalogs instead of relying on each engine's partial coverage. Statements
+// of these categories are removed from the engine output, and only those in include
+// are generated in their place.
+func WithCatalog(differ DiffFunc, include []string, options ...func(*pgx.ConnConfig)) DiffFunc {
+	return func(ctx context.Context, source, target string, schema []string) (string, error) {
+		out, err := differ(ctx, source, target, schema)
+		if err != nil {
+			return "", err
+		}
+		out, err = removeCatalogStatements(out)
+		if err != nil {
+			return "", err
+		}
+		catalog, err := DiffCatalog(ctx, source, target, schema, include, options...)
+		if err != nil {
+			return "", err
+		}
+		if len(catalog) == 0 {
+			return out, nil
+		}
+		if trimmed := strings.TrimRight(out, "\n"); len(trimmed) > 0 {
+			out = trimmed + "\n\n"
+		}
+		return out + strings.Join(catalog, "\n\n") + "\n", nil
+	}
+}
+
+func removeCatalogStatements(out string) (string, error) {
+	stats, err := parser.SplitAndTrim(strings.NewReader(out))
+	if err != nil {
+		return "", errors.Errorf("failed to parse schema diff: %w", err)
+	}
+	var kept []string
+	for _, s := range stats {
+		if findCategory(s) == nil {
+			kept = append(kept, s+";")
+		}
+	}
+	// Preserves the original formatting when nothing is removed
+	if len(kept) == len(stats) {
+		return out, nil
+	}
+	if len(kept) == 0 {
+		return "", nil
+	}
+	return strings.Join(kept, "\n\n") + "\n\n", nil
+}
+
+func findCategory(stat string) *category {
+	// Skips leading comments, such as hazards reported by pg-schema-diff
+	lines := strings.Split(stat, "\n")
+	for len(lines) > 0 && strings.HasPrefix(strings.TrimSpace(lines[0]), "--") {
+		lines = lines[1:]
+	}
+	body := strings.TrimSpace(strings.Join(lines, "\n"))
+	for i, c := range categories {
+		if c.pattern.MatchString(body) {
+			return &categories[i]
+		}
+	}
+	return nil
+}
+
+// DiffCatalog returns the statements that migrate source to match target for each
+// included category. Drops are ordered before creates.
+func DiffCatalog(ctx context.Context, source, target string, schema, include []string, options ...func(*pgx.ConnConfig)) ([]string, error) {
+	if len(include) == 0 {
+		return nil, nil
+	}
+	srcConn, err := utils.ConnectByUrl(ctx, source, options...)
+	if err != nil {
+		return nil, err
+	}
+	defer srcConn.Close(context.Background())
+	dstConn, err := utils.ConnectByUrl(ctx, target, options...)
+	if err != nil {
+		return nil, err
+	}
+	defer dstConn.Close(context.Background())
+	exists, err := loadObjects(ctx, dstConn, schema)
+	if err != nil {
+		return nil, err
+	}
+	var drops, creates []string
+	for _, c := range categories {
+		if !utils.SliceContains(include, c.name) {
+			continue
+		}
+		before, err := loadCatalog(ctx, srcConn, c, schema)
+		if err != nil {
+			return nil, err
+		}
+		after, err := loadCatalog(ctx, dstConn, c, schema)
+		if err != nil {
+			return nil, err
+		}
+		d, cr := compareCatalog(c, before, after, exists)
+		drops = append(drops, d...)
+		creates = append(creates, cr...)
+	}
+	return append(drops, creates...), nil
+}
+
+func compareCatalog(c category, before, after []catalogEntry, exists map[string]bool) (drops, creates []string) {
+	old := make(map[string]catalogEntry, len(before))
+	for _, e := range before {
+		old[e.Key] = e
+	}
+	seen := make(map[string]bool, len(after))
+	for _, e := range after {
+		seen[e.Key] = true
+		prev, found := old[e.Key]
+		if found && prev.Create == e.Create {
+			continue
+		}
+		if found && c.replace && len(prev.Drop) > 0 {
+			drops = append(drops, prev.Drop)
+		}
+		creates = append(creates, e.Create)
+	}
+	for _, e := range before {
+		if seen[e.Key] || len(e.Drop) == 0 {
+			continue
+		}
+		// Entries are removed implicitly when their parent object is dropped
+		if len(e.Parent) > 0 && !exists[e.Parent] {
+			continue
+		}
+		drops = append(drops, e.Drop)
+	}
+	return drops, creates
+}
+
+func loadObjects(ctx context.Context, conn *pgx.Conn, schema []string) (map[string]bool, error) {
+	rows, err := conn.Query(ctx, objectsQuery, schema)
+	if err != nil {
+		return nil, errors.Errorf("failed to query objects: %w", err)
+	}
+	defer rows.Close()
+	result := map[string]bool{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, errors.Errorf("failed to scan objects: %w", err)
+		}
+		result[name] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Errorf("failed to read objects: %w", err)
+	}
+	return result, nil
+}
+
+func loadCatalog(ctx context.Context, conn *pgx.Conn, c category, schema []string) ([]catalogEntry, error) {
+	rows, err := conn.Query(ctx, c.query, schema)
+	if err != nil {
+		return nil, errors.Errorf("failed to query %s: %w", c.name, err)
+	}
+	defer rows.Close()
+	var result []catalogEntry
+	for rows.Next() {
+		var e catalogEntry
+		if err := rows.Scan(&e.Key, &e.Parent, &e.Create, &e.Drop); err != nil {
+			return nil, errors.Errorf("failed to scan %s: %w", c.name, err)
+		}
+		result = append(result, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Errorf("failed to read %s: %w", c.name, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,114 @@
+package diff
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoveCatalogStatements(t *testing.T) {
+	t.Run("removes catalog statements", func(t *testing.T) {
+		out := `create table "public"."test" ("id" bigint);
+
+alter table "public"."test" enable row level security;
+
+grant select on table "public"."test" to "anon";
+
+alter table "public"."test" owner to "postgres";
+
+-- Hazard: drops policy
+create policy "read" on "public"."test" for select using (true);
+
+comment on table "public"."test" is 'test';
+
+alter publication "realtime" add table "public"."test";
+`
+		// Run test
+		result, err := removeCatalogStatements(out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, `create table "public"."test" ("id" bigint);
+
+alter table "public"."test" enable row level security;
+
+`, result)
+	})
+
+	t.Run("preserves output without catalog statements", func(t *testing.T) {
+		out := "create table test();\n"
+		// Run test
+		result, err := removeCatalogStatements(out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, out, result)
+	})
+}
+
+func TestCompareCatalog(t *testing.T) {
+	policies := categories[0]
+	grants := categories[1]
+
+	t.Run("drops before recreating changed policy", func(t *testing.T) {
+		before := []catalogEntry{
+			{Key: "public.test.read", Parent: "public.test", Create: "create policy read using (true);", Drop: "drop policy read;"},
+		}
+		after := []catalogEntry{
+			{Key: "public.test.read", Parent: "public.test", Create: "create policy read using (false);", Drop: "drop policy read;"},
+		}
+		// Run test
+		drops, creates := compareCatalog(policies, before, after, map[string]bool{"public.test": true})
+		// Check output
+		assert.Equal(t, []string{"drop policy read;"}, drops)
+		assert.Equal(t, []string{"create policy read using (false);"}, creates)
+	})
+
+	t.Run("skips unchanged entries", func(t *testing.T) {
+		entries := []catalogEntry{
+			{Key: "public.test.anon", Parent: "public.test", Create: "grant select;", Drop: "revoke select;"},
+		}
+		// Run test
+		drops, creates := compareCatalog(grants, entries, entries, map[string]bool{"public.test": true})
+		// Check output
+		assert.Empty(t, drops)
+		assert.Empty(t, creates)
+	})
+
+	t.Run("skips drops on removed parent", func(t *testing.T) {
+		before := []catalogEntry{
+			{Key: "public.test.anon", Parent: "public.test", Create: "grant select on test;", Drop: "revoke select on test;"},
+			{Key: "public.other.anon", Parent: "public.other", Create: "grant select on other;", Drop: "revoke select on other;"},
+		}
+		// Run test
+		drops, creates := compareCatalog(grants, before, nil, map[string]bool{"public.other": true})
+		// Check output
+		assert.Equal(t, []string{"revoke select on other;"}, drops)
+		assert.Empty(t, creates)
+	})
+}
+
+func TestWithCatalog(t *testing.T) {
+	t.Run("removes catalog statements without include", func(t *testing.T) {
+		differ := func(context.Context, string, string, []string) (string, error) {
+			return "create table test();\n\ngrant all on table test to anon;\n", nil
+		}
+		// Run test
+		out, err := WithCatalog(differ, nil)(context.Background(), "", "", []string{"public"})
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "create table test();\n\n", out)
+	})
+
+	t.Run("throws error on diff failure", func(t *testing.T) {
+		errDiff := errors.New("network error")
+		differ := func(context.Context, string, string, []string) (string, error) {
+			return "", errDiff
+		}
+		// Run test
+		out, err := WithCatalog(differ, []string{CategoryGrants})(context.Background(), "", "", []string{"public"})
+		// Check error
+		assert.ErrorIs(t, err, errDiff)
+		assert.Empty(t, out)
+	})
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/push"
 	"github.com/supabase/cli/internal/db/reset"
 	"github.com/supabase/cli/internal/db/start"
 	"github.com/supabase/cli/internal/gen/keys"
@@ -33,7 +34,7 @@ import (
 
 type DiffFunc func(context.Context, string, string, []string) (string, error)
 
-func Run(ctx context.Context, schema []string, file string, config pgconn.Config, differ DiffFunc, fsys afero.Fs, options ...func(*pgx.ConnConfig)) (err error) {
+func Run(ctx context.Context, schema []string, file string, config, shadow pgconn.Config, differ DiffFunc, fsys afero.Fs, options ...func(*pgx.ConnConfig)) (err error) {
 	// Sanity checks.
 	if err := utils.LoadConfigFS(fsys); err != nil {
 		return err
@@ -59,7 +60,12 @@ func Run(ctx context.Context, schema []string, file string, config pgconn.Config
 		}
 	}
 	// 3. Run migra to diff schema
-	out, err := DiffDatabase(ctx, schema, config, os.Stderr, fsys, differ, options...)
+	var out string
+	if len(shadow.Host) > 0 {
+		out, err = DiffShadowDatabase(ctx, schema, config, shadow, os.Stderr, fsys, differ, options...)
+	} else {
+		out, err = DiffDatabase(ctx, schema, config, os.Stderr, fsys, differ, options...)
+	}
 	if err != nil {
 		return err
 	}
@@ -170,7 +176,7 @@ func MigrateShadowDatabase(ctx context.Context, container string, fsys afero.Fs,
 	if err := start.SetupDatabase(ctx, conn, container[:12], os.Stderr, fsys); err != nil {
 		return err
 	}
-	return apply.MigrateUp(ctx, conn, migrations, fsys)
+	return apply.MigrateUp(ctx, conn, list.SkipDataMigrations(migrations), fsys)
 }
 
 func migrateBaseDatabase(ctx context.Context, container string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
@@ -223,3 +229,26 @@ func DiffDatabase(ctx context.Context, schema []string, config pgconn.Config, w
 	target := utils.ToPostgresURL(config)
 	return differ(ctx, source, target, schema)
 }
+
+// Migrates an existing empty database in place of a shadow container, so that diffing
+// with an in-process engine does not require docker.
+func DiffShadowDatabase(ctx context.Context, schema []string, config, shadow pgconn.Config, w io.Writer, fsys afero.Fs, differ DiffFunc, options ...func(*pgx.ConnConfig)) (string, error) {
+	migrations, err := list.LoadLocalMigrations(fsys)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintln(w, "Migrating shadow database...")
+	conn, err := utils.ConnectByConfig(ctx, shadow, options...)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close(context.Background())
+	if err := push.CreateCustomRoles(ctx, conn, w, fsys); err != nil {
+		return "", err
+	}
+	if err := apply.MigrateUp(ctx, conn, list.SkipDataMigrations(migrations), fsys); err != nil {
+		return "", err
+	}
+	fmt.Fprintln(w, "Diffing schemas:", strings.Join(schema, ","))
+	return differ(ctx, utils.ToPostgresURL(shadow), utils.ToPostgresURL(config), schema)
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 
@@ -83,7 +84,7 @@ func TestRun(t *testing.T) {
 		conn := pgtest.NewConn()
 		defer conn.Close(t)
 		// Run test
-		err := Run(context.Background(), []string{"public"}, "file", dbConfig, DiffSchemaMigra, fsys, conn.Intercept)
+		err := Run(context.Background(), []string{"public"}, "file", dbConfig, pgconn.Config{}, DiffSchemaMigra, fsys, conn.Intercept)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -101,7 +102,7 @@ func TestRun(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := Run(context.Background(), []string{"public"}, "", pgconn.Config{}, DiffSchemaMigra, fsys)
+		err := Run(context.Background(), []string{"public"}, "", pgconn.Config{}, pgconn.Config{}, DiffSchemaMigra, fsys)
 		// Check error
 		assert.ErrorIs(t, err, os.ErrNotExist)
 	})
@@ -118,7 +119,7 @@ func TestRun(t *testing.T) {
 		conn.Query(reset.ListSchemas, escapedSchemas).
 			ReplyError(pgerrcode.DuplicateTable, `relation "test" already exists`)
 		// Run test
-		err := Run(context.Background(), []string{}, "", dbConfig, DiffSchemaMigra, fsys, conn.Intercept)
+		err := Run(context.Background(), []string{}, "", dbConfig, pgconn.Config{}, DiffSchemaMigra, fsys, conn.Intercept)
 		// Check error
 		assert.ErrorContains(t, err, `ERROR: relation "test" already exists (SQLSTATE 42P07)`)
 	})
@@ -136,7 +137,7 @@ func TestRun(t *testing.T) {
 			Get("/v" + utils.Docker.ClientVersion() + "/images/" + utils.GetRegistryImageUrl(utils.Pg15Image) + "/json").
 			ReplyError(errors.New("network error"))
 		// Run test
-		err := Run(context.Background(), []string{"public"}, "file", dbConfig, DiffSchemaMigra, fsys)
+		err := Run(context.Background(), []string{"public"}, "file", dbConfig, pgconn.Config{}, DiffSchemaMigra, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "network error")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -165,7 +166,7 @@ func TestMigrateShadow(t *testing.T) {
 		pgtest.MockMigrationHistory(conn)
 		conn.Query(sql).
 			Reply("CREATE SCHEMA").
-			Query(history.INSERT_MIGRATION_VERSION, "0", "test", []string{sql}).
+			Query(history.INSERT_MIGRATION_VERSION, "0", "test", []string{sql}, history.Checksum([]string{sql})).
 			Reply("INSERT 0 1")
 		// Run test
 		err := MigrateShadowDatabase(context.Background(), "test-shadow-db", fsys, conn.Intercept)
@@ -339,7 +340,7 @@ At statement 0: create schema public`)
 		pgtest.MockMigrationHistory(conn)
 		conn.Query(sql).
 			Reply("CREATE SCHEMA").
-			Query(history.INSERT_MIGRATION_VERSION, "0", "test", []string{sql}).
+			Query(history.INSERT_MIGRATION_VERSION, "0", "test", []string{sql}, history.Checksum([]string{sql})).
 			Reply("INSERT 0 1")
 		// Run test
 		diff, err := DiffDatabase(context.Background(), []string{"public"}, dbConfig, io.Discard, fsys, DiffSchemaMigra, conn.Intercept)
@@ -354,3 +355,74 @@ func TestDropStatements(t *testing.T) {
 	drops := findDropStatements("create table t(); drop table t; alter table t drop column c")
 	assert.Equal(t, []string{"drop table t", "alter table t drop column c"}, drops)
 }
+
+func TestDiffShadowDatabase(t *testing.T) {
+	shadow := pgconn.Config{
+		Host:     "127.0.0.1",
+		Port:     5432,
+		User:     "postgres",
+		Password: "postgres",
+		Database: "shadow",
+	}
+
+	t.Run("diffs against existing shadow", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "0_test.sql")
+		sql := "create schema test"
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(sql), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		pgtest.MockMigrationHistory(conn)
+		conn.Query(sql).
+			Reply("CREATE SCHEMA").
+			Query(history.INSERT_MIGRATION_VERSION, "0", "test", []string{sql}, history.Checksum([]string{sql})).
+			Reply("INSERT 0 1")
+		// Setup mock differ
+		differ := func(_ context.Context, source, target string, schema []string) (string, error) {
+			assert.Equal(t, utils.ToPostgresURL(shadow), source)
+			assert.Equal(t, utils.ToPostgresURL(dbConfig), target)
+			assert.Equal(t, []string{"public"}, schema)
+			return "create table test();", nil
+		}
+		// Run test
+		diff, err := DiffShadowDatabase(context.Background(), []string{"public"}, dbConfig, shadow, io.Discard, fsys, differ, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "create table test();", diff)
+	})
+
+	t.Run("throws error on failure to migrate shadow", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "0_test.sql")
+		sql := "create schema test"
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(sql), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		pgtest.MockMigrationHistory(conn)
+		conn.Query(sql).
+			ReplyError(pgerrcode.DuplicateSchema, `schema "test" already exists`).
+			Query(history.INSERT_MIGRATION_VERSION, "0", "test", []string{sql}, history.Checksum([]string{sql})).
+			Reply("INSERT 0 1")
+		// Run test
+		diff, err := DiffShadowDatabase(context.Background(), []string{"public"}, dbConfig, shadow, io.Discard, fsys, DiffSchemaMigra, conn.Intercept)
+		// Check error
+		assert.Empty(t, diff)
+		assert.ErrorContains(t, err, `ERROR: schema "test" already exists (SQLSTATE 42P06)`)
+	})
+}
+
+func TestGetDiffer(t *testing.T) {
+	t.Run("defaults to migra", func(t *testing.T) {
+		differ := GetDiffer("")
+		assert.Equal(t, reflect.ValueOf(DiffSchemaMigra).Pointer(), reflect.ValueOf(differ).Pointer())
+	})
+
+	t.Run("selects pg-schema engine", func(t *testing.T) {
+		differ := GetDiffer(EnginePgSchema)
+		assert.Equal(t, reflect.ValueOf(DiffPgSchema).Pointer(), reflect.ValueOf(differ).Pointer())
+	})
+}
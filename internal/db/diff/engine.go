@@ -0,0 +1,22 @@
+package diff
+
+const (
+	EngineMigra    = "migra"
+	EnginePgAdmin  = "pgadmin"
+	EnginePgSchema = "pg-schema"
+)
+
+var EngineAllowed = []string{EngineMigra, EnginePgAdmin, EnginePgSchema}
+
+// GetDiffer returns the diff backend for an engine. Both migra and pgAdmin run in a
+// container, while pg-schema introspects the databases in process without docker.
+func GetDiffer(engine string) DiffFunc {
+	switch engine {
+	case EnginePgAdmin:
+		return DiffPgAdmin
+	case EnginePgSchema:
+		return DiffPgSchema
+	default:
+		return DiffSchemaMigra
+	}
+}
@@ -0,0 +1,36 @@
+WITH comments AS (
+  SELECT
+    CASE c.relkind WHEN 'v' THEN 'VIEW' WHEN 'm' THEN 'MATERIALIZED VIEW' WHEN 'f' THEN 'FOREIGN TABLE' WHEN 'S' THEN 'SEQUENCE' ELSE 'TABLE' END AS kind,
+    format('%I.%I', n.nspname, c.relname) AS name,
+    d.description
+  FROM pg_description d
+  JOIN pg_class c ON d.classoid = 'pg_class'::regclass AND d.objoid = c.oid AND d.objsubid = 0
+  JOIN pg_namespace n ON n.oid = c.relnamespace
+  WHERE n.nspname = ANY($1)
+  UNION ALL
+  SELECT
+    'COLUMN',
+    format('%I.%I.%I', n.nspname, c.relname, a.attname),
+    d.description
+  FROM pg_description d
+  JOIN pg_class c ON d.classoid = 'pg_class'::regclass AND d.objoid = c.oid
+  JOIN pg_attribute a ON a.attrelid = c.oid AND a.attnum = d.objsubid
+  JOIN pg_namespace n ON n.oid = c.relnamespace
+  WHERE n.nspname = ANY($1) AND d.objsubid > 0
+  UNION ALL
+  SELECT
+    CASE p.prokind WHEN 'p' THEN 'PROCEDURE' ELSE 'FUNCTION' END,
+    format('%I.%I(%s)', n.nspname, p.proname, pg_get_function_identity_arguments(p.oid)),
+    d.description
+  FROM pg_description d
+  JOIN pg_proc p ON d.classoid = 'pg_proc'::regclass AND d.objoid = p.oid
+  JOIN pg_namespace n ON n.oid = p.pronamespace
+  WHERE n.nspname = ANY($1)
+)
+SELECT
+  format('%s %s', kind, name) AS key,
+  name AS parent,
+  format('COMMENT ON %s %s IS %L;', kind, name, description) AS create_sql,
+  format('COMMENT ON %s %s IS NULL;', kind, name) AS drop_sql
+FROM comments
+ORDER BY 1
@@ -0,0 +1,49 @@
+WITH objects AS (
+  SELECT
+    CASE c.relkind WHEN 'S' THEN 'SEQUENCE' ELSE 'TABLE' END AS kind,
+    CASE c.relkind WHEN 'S' THEN 'SEQUENCE' WHEN 'v' THEN 'VIEW' WHEN 'm' THEN 'MATERIALIZED VIEW' WHEN 'f' THEN 'FOREIGN TABLE' ELSE 'TABLE' END AS alter_kind,
+    format('%I.%I', n.nspname, c.relname) AS name,
+    coalesce(c.relacl, acldefault(CASE c.relkind WHEN 'S' THEN 's' ELSE 'r' END::"char", c.relowner)) AS acl,
+    c.relowner AS owner
+  FROM pg_class c
+  JOIN pg_namespace n ON n.oid = c.relnamespace
+  WHERE n.nspname = ANY($1) AND c.relkind IN ('r', 'p', 'v', 'm', 'f', 'S')
+    AND NOT EXISTS (SELECT 1 FROM pg_depend d WHERE d.classid = 'pg_class'::regclass AND d.objid = c.oid AND d.deptype = 'e')
+  UNION ALL
+  SELECT
+    CASE p.prokind WHEN 'p' THEN 'PROCEDURE' ELSE 'FUNCTION' END,
+    CASE p.prokind WHEN 'p' THEN 'PROCEDURE' ELSE 'FUNCTION' END,
+    format('%I.%I(%s)', n.nspname, p.proname, pg_get_function_identity_arguments(p.oid)),
+    coalesce(p.proacl, acldefault('f', p.proowner)),
+    p.proowner
+  FROM pg_proc p
+  JOIN pg_namespace n ON n.oid = p.pronamespace
+  WHERE n.nspname = ANY($1)
+    AND NOT EXISTS (SELECT 1 FROM pg_depend d WHERE d.classid = 'pg_proc'::regclass AND d.objid = p.oid AND d.deptype = 'e')
+), privileges AS (
+  SELECT
+    o.kind,
+    o.name,
+    a.privilege_type,
+    a.is_grantable,
+    CASE a.grantee WHEN 0 THEN 'PUBLIC' ELSE quote_ident(pg_get_userbyid(a.grantee)) END AS grantee
+  FROM objects o, aclexplode(o.acl) a
+  -- Privileges of the owner are implicit
+  WHERE a.grantee <> o.owner
+)
+SELECT
+  format('%s %s %s %s', kind, name, privilege_type, grantee) AS key,
+  name AS parent,
+  format('GRANT %s ON %s %s TO %s%s;', privilege_type, kind, name, grantee, CASE WHEN is_grantable THEN ' WITH GRANT OPTION' ELSE '' END) AS create_sql,
+  format('REVOKE %s ON %s %s FROM %s;', privilege_type, kind, name, grantee) AS drop_sql
+FROM privileges
+UNION ALL
+SELECT
+  format('OWNER %s', name),
+  name,
+  format('ALTER %s %s OWNER TO %I;', alter_kind, name, pg_get_userbyid(owner)),
+  format('ALTER %s %s OWNER TO %I;', alter_kind, name, current_user)
+FROM objects
+-- Objects are owned by the migration role by default
+WHERE pg_get_userbyid(owner) <> current_user
+ORDER BY 1
@@ -0,0 +1,14 @@
+SELECT
+  format('%I.%I.%I', schemaname, tablename, policyname) AS key,
+  format('%I.%I', schemaname, tablename) AS parent,
+  format(
+    'CREATE POLICY %I ON %I.%I AS %s FOR %s TO %s%s%s;',
+    policyname, schemaname, tablename, permissive, cmd,
+    (SELECT string_agg(CASE WHEN r = 'public' THEN r ELSE quote_ident(r) END, ', ') FROM unnest(roles) AS r),
+    CASE WHEN qual IS NOT NULL THEN format(' USING (%s)', qual) ELSE '' END,
+    CASE WHEN with_check IS NOT NULL THEN format(' WITH CHECK (%s)', with_check) ELSE '' END
+  ) AS create_sql,
+  format('DROP POLICY %I ON %I.%I;', policyname, schemaname, tablename) AS drop_sql
+FROM pg_policies
+WHERE schemaname = ANY($1)
+ORDER BY 1
@@ -0,0 +1,31 @@
+SELECT
+  format('publication %I%s', p.pubname, CASE WHEN p.puballtables THEN ' for all tables' ELSE '' END) AS key,
+  '' AS parent,
+  format('CREATE PUBLICATION %I%s;', p.pubname, CASE WHEN p.puballtables THEN ' FOR ALL TABLES' ELSE '' END) AS create_sql,
+  format('DROP PUBLICATION %I;', p.pubname) AS drop_sql
+FROM pg_publication p
+UNION ALL
+SELECT
+  format('publication %I options', p.pubname),
+  '',
+  format(
+    'ALTER PUBLICATION %I SET (publish = %L);',
+    p.pubname,
+    concat_ws(', ',
+      CASE WHEN p.pubinsert THEN 'insert' END,
+      CASE WHEN p.pubupdate THEN 'update' END,
+      CASE WHEN p.pubdelete THEN 'delete' END,
+      CASE WHEN p.pubtruncate THEN 'truncate' END)
+  ),
+  ''
+FROM pg_publication p
+UNION ALL
+SELECT
+  format('publication %I table %I.%I', t.pubname, t.schemaname, t.tablename),
+  format('%I.%I', t.schemaname, t.tablename),
+  format('ALTER PUBLICATION %I ADD TABLE %I.%I;', t.pubname, t.schemaname, t.tablename),
+  format('ALTER PUBLICATION %I DROP TABLE %I.%I;', t.pubname, t.schemaname, t.tablename)
+FROM pg_publication_tables t
+JOIN pg_publication p ON p.pubname = t.pubname
+WHERE t.schemaname = ANY($1) AND NOT p.puballtables
+ORDER BY 1
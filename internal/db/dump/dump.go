@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
 	"strings"
 
 	"github.com/docker/docker/api/types/container"
@@ -13,6 +16,7 @@ import (
 	"github.com/go-errors/errors"
 	"github.com/jackc/pgconn"
 	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/tools"
 	"github.com/supabase/cli/internal/utils"
 )
 
@@ -25,7 +29,15 @@ var (
 	dumpRoleScript string
 )
 
-func Run(ctx context.Context, path string, config pgconn.Config, schema, excludeTable []string, dataOnly, roleOnly, keepComments, useCopy, dryRun bool, fsys afero.Fs) error {
+func Run(ctx context.Context, path string, config pgconn.Config, schema, excludeTable []string, dataOnly, roleOnly, keepComments, useCopy, largeObjects, dryRun bool, fsys afero.Fs) error {
+	// Dumping with --db-url may run outside of a local project directory
+	if exists, err := afero.Exists(fsys, utils.ConfigPath); err != nil {
+		return errors.Errorf("failed to check config file: %w", err)
+	} else if exists {
+		if err := utils.LoadConfigFS(fsys); err != nil {
+			return err
+		}
+	}
 	// Initialize output stream
 	var outStream afero.File
 	if len(path) > 0 {
@@ -48,7 +60,7 @@ func Run(ctx context.Context, path string, config pgconn.Config, schema, exclude
 	}
 	if dataOnly {
 		fmt.Fprintf(os.Stderr, "Dumping data from %s database...\n", db)
-		return dumpData(ctx, config, schema, excludeTable, useCopy, dryRun, outStream)
+		return dumpData(ctx, config, schema, excludeTable, useCopy, largeObjects, dryRun, outStream)
 	} else if roleOnly {
 		fmt.Fprintf(os.Stderr, "Dumping roles from %s database...\n", db)
 		return dumpRole(ctx, config, keepComments, dryRun, outStream)
@@ -71,7 +83,7 @@ func DumpSchema(ctx context.Context, config pgconn.Config, schema []string, keep
 	return dump(ctx, config, dumpSchemaScript, env, dryRun, stdout)
 }
 
-func dumpData(ctx context.Context, config pgconn.Config, schema, excludeTable []string, useCopy, dryRun bool, stdout io.Writer) error {
+func dumpData(ctx context.Context, config pgconn.Config, schema, excludeTable []string, useCopy, largeObjects, dryRun bool, stdout io.Writer) error {
 	// We want to dump user data in auth, storage, etc. for migrating to new project
 	excludedSchemas := []string{
 		"information_schema",
@@ -113,6 +125,10 @@ func dumpData(ctx context.Context, config pgconn.Config, schema, excludeTable []
 	if !useCopy {
 		extraFlags = append(extraFlags, "--column-inserts", "--rows-per-insert 100000")
 	}
+	if largeObjects {
+		// Large objects are not dumped by default when schemas are selected
+		extraFlags = append(extraFlags, "--blobs")
+	}
 	for _, table := range excludeTable {
 		escaped := quoteUpperCase(table)
 		// Use separate flags to avoid error: too many dotted names
@@ -167,10 +183,20 @@ func dump(ctx context.Context, config pgconn.Config, script string, env []string
 		fmt.Println(expanded)
 		return nil
 	}
-	return utils.DockerRunOnceWithConfig(
+	if utils.Config.Db.Tools.Managed {
+		pgDump, err := tools.Ensure(ctx, "pg_dump", afero.NewOsFs())
+		if err != nil {
+			return err
+		}
+		return runLocal(ctx, filepath.Dir(pgDump), script, allEnvs, stdout)
+	}
+	if binDir := utils.Config.Db.Tools.BinDir; len(binDir) > 0 {
+		return runLocal(ctx, binDir, script, allEnvs, stdout)
+	}
+	if err := utils.DockerRunOnceWithConfig(
 		ctx,
 		container.Config{
-			Image: utils.Pg15Image,
+			Image: utils.Config.Db.Tools.Image,
 			Env:   allEnvs,
 			Cmd:   []string{"bash", "-c", script, "--"},
 		},
@@ -181,5 +207,35 @@ func dump(ctx context.Context, config pgconn.Config, script string, env []string
 		"",
 		stdout,
 		os.Stderr,
-	)
+	); err != nil {
+		utils.CmdSuggestion = fmt.Sprintf("If pg_dump reported a server version mismatch, set a newer version under %s in %s.", utils.Aqua("[db.tools]"), utils.Bold(utils.ConfigPath))
+		return err
+	}
+	return nil
+}
+
+// Runs the dump script on the host, preferring the Postgres binaries in binDir over those on PATH.
+func runLocal(ctx context.Context, binDir, script string, env []string, stdout io.Writer) error {
+	pgDump := "pg_dump"
+	if runtime.GOOS == "windows" {
+		pgDump += ".exe"
+	}
+	if _, err := os.Stat(filepath.Join(binDir, pgDump)); err != nil {
+		return errors.Errorf("failed to find pg_dump in db.tools.bin_dir: %w", err)
+	}
+	// The dump scripts pipe through sed, so Windows users need a bash from Git for Windows or MSYS2
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		utils.CmdSuggestion = fmt.Sprintf("Install Git for Windows to provide bash, or set %s to false in %s to dump with Docker.", utils.Aqua("db.tools.managed"), utils.Bold(utils.ConfigPath))
+		return errors.Errorf("failed to find bash: %w", err)
+	}
+	cmd := exec.CommandContext(ctx, bash, "-c", script, "--")
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Env = append(cmd.Env, "PATH="+binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	cmd.Stdout = stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return errors.Errorf("failed to run pg_dump: %w", err)
+	}
+	return nil
 }
@@ -3,6 +3,8 @@ package dump
 import (
 	"context"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/h2non/gock"
@@ -35,7 +37,7 @@ func TestPullCommand(t *testing.T) {
 		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
 		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "hello world"))
 		// Run test
-		err := Run(context.Background(), "schema.sql", dbConfig, nil, nil, false, false, false, false, false, fsys)
+		err := Run(context.Background(), "schema.sql", dbConfig, nil, nil, false, false, false, false, false, false, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -54,7 +56,7 @@ func TestPullCommand(t *testing.T) {
 		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
 		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "hello world"))
 		// Run test
-		err := Run(context.Background(), "", dbConfig, []string{"public"}, nil, false, false, false, false, false, fsys)
+		err := Run(context.Background(), "", dbConfig, []string{"public"}, nil, false, false, false, false, false, false, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -70,7 +72,7 @@ func TestPullCommand(t *testing.T) {
 			Get("/v" + utils.Docker.ClientVersion() + "/images").
 			Reply(http.StatusServiceUnavailable)
 		// Run test
-		err := Run(context.Background(), "", dbConfig, nil, nil, false, false, false, false, false, fsys)
+		err := Run(context.Background(), "", dbConfig, nil, nil, false, false, false, false, false, false, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "request returned Service Unavailable for API route and version")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -85,9 +87,57 @@ func TestPullCommand(t *testing.T) {
 		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
 		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "hello world"))
 		// Run test
-		err := Run(context.Background(), "schema.sql", dbConfig, nil, nil, false, false, false, false, false, fsys)
+		err := Run(context.Background(), "schema.sql", dbConfig, nil, nil, false, false, false, false, false, false, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "operation not permitted")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
+
+	t.Run("uses pinned tools image", func(t *testing.T) {
+		utils.Config.Db.Tools.Image = "supabase/postgres:15.6.1.120"
+		defer func() {
+			utils.Config.Db.Tools.Image = utils.Pg15Image
+		}()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		apitest.MockDockerStart(utils.Docker, utils.GetRegistryImageUrl("supabase/postgres:15.6.1.120"), containerId)
+		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "hello world"))
+		// Run test
+		err := Run(context.Background(), "schema.sql", dbConfig, nil, nil, false, false, false, false, false, false, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("runs host binaries", func(t *testing.T) {
+		binDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(binDir, "pg_dump"), []byte("#!/usr/bin/env bash\necho 'create schema private;'\n"), 0755))
+		utils.Config.Db.Tools.BinDir = binDir
+		defer func() {
+			utils.Config.Db.Tools.BinDir = ""
+		}()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Run(context.Background(), "schema.sql", dbConfig, nil, nil, false, false, false, false, false, false, fsys)
+		// Check error
+		assert.NoError(t, err)
+		contents, err := afero.ReadFile(fsys, "schema.sql")
+		assert.NoError(t, err)
+		assert.Contains(t, string(contents), "create schema private;")
+	})
+
+	t.Run("throws error on missing host binaries", func(t *testing.T) {
+		utils.Config.Db.Tools.BinDir = t.TempDir()
+		defer func() {
+			utils.Config.Db.Tools.BinDir = ""
+		}()
+		// Run test
+		err := Run(context.Background(), "", dbConfig, nil, nil, false, false, false, false, false, false, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "failed to find pg_dump in db.tools.bin_dir")
+	})
 }
@@ -58,6 +58,11 @@ func Run(ctx context.Context, schema []string, level string, config pgconn.Confi
 	return printResultJSON(result, toEnum(level), os.Stdout)
 }
 
+// Returns only issues at or above the given level, ie. warning or error.
+func FilterResult(result []Result, level string) []Result {
+	return filterResult(result, toEnum(level))
+}
+
 func filterResult(result []Result, minLevel LintLevel) (filtered []Result) {
 	for _, r := range result {
 		out := Result{Function: r.Function}
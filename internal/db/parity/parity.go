@@ -0,0 +1,88 @@
+package parity
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"io"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var (
+	//go:embed templates/functions.sql
+	FunctionsQuery string
+
+	ErrDrift = errors.New("Remote functions do not match migrations.")
+)
+
+type Function struct {
+	Name string
+	Hash string
+}
+
+// Internal schemas are managed by Supabase services, so their functions may differ between
+// versions of the local stack and the hosted platform.
+func GetExposedSchemas() []string {
+	var result []string
+	for _, s := range utils.Config.Api.Schemas {
+		if !utils.SliceContains(utils.InternalSchemas, s) {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// LoadFunctions lists the definition hash of each function in schema, ordered by name.
+func LoadFunctions(ctx context.Context, conn *pgx.Conn, schema []string) ([]Function, error) {
+	rows, err := conn.Query(ctx, FunctionsQuery, schema)
+	if err != nil {
+		return nil, errors.Errorf("failed to query functions: %w", err)
+	}
+	defer rows.Close()
+	var result []Function
+	for rows.Next() {
+		var fn Function
+		if err := rows.Scan(&fn.Name, &fn.Hash); err != nil {
+			return nil, errors.Errorf("failed to scan functions: %w", err)
+		}
+		result = append(result, fn)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Errorf("failed to parse functions: %w", err)
+	}
+	return result, nil
+}
+
+// PrintDrift reports functions in actual that differ from expected, returning false if there
+// are none.
+func PrintDrift(w io.Writer, expected, actual []Function) bool {
+	hashes := make(map[string]string, len(expected))
+	for _, fn := range expected {
+		hashes[fn.Name] = fn.Hash
+	}
+	var lines []string
+	for _, fn := range actual {
+		if hash, ok := hashes[fn.Name]; !ok {
+			lines = append(lines, utils.Aqua(" + "+fn.Name+" (not in migrations)"))
+		} else if hash != fn.Hash {
+			lines = append(lines, utils.Yellow(" ~ "+fn.Name))
+		}
+		delete(hashes, fn.Name)
+	}
+	for _, fn := range expected {
+		if _, ok := hashes[fn.Name]; ok {
+			lines = append(lines, utils.Red(" - "+fn.Name+" (missing on remote)"))
+		}
+	}
+	if len(lines) == 0 {
+		return false
+	}
+	fmt.Fprintln(w, "Found functions that differ from migrations:")
+	for _, l := range lines {
+		fmt.Fprintln(w, l)
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+package parity
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/pgtest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestLoadFunctions(t *testing.T) {
+	t.Run("loads function hashes", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(FunctionsQuery, []string{"public"}).
+			Reply("SELECT 2",
+				[]interface{}{"public.add(integer, integer)", "5d41402abc4b2a76b9719d911017c592"},
+				[]interface{}{"public.greet()", "7d793037a0760186574b0282f2f435e7"},
+			)
+		// Connect to mock
+		ctx := context.Background()
+		mock, err := utils.ConnectLocalPostgres(ctx, pgconn.Config{Port: 5432}, conn.Intercept)
+		require.NoError(t, err)
+		defer mock.Close(ctx)
+		// Run test
+		functions, err := LoadFunctions(ctx, mock, []string{"public"})
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []Function{
+			{Name: "public.add(integer, integer)", Hash: "5d41402abc4b2a76b9719d911017c592"},
+			{Name: "public.greet()", Hash: "7d793037a0760186574b0282f2f435e7"},
+		}, functions)
+	})
+
+	t.Run("throws error on query failure", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(FunctionsQuery, []string{"public"}).
+			ReplyError(pgerrcode.InsufficientPrivilege, "permission denied for schema public")
+		// Connect to mock
+		ctx := context.Background()
+		mock, err := utils.ConnectLocalPostgres(ctx, pgconn.Config{Port: 5432}, conn.Intercept)
+		require.NoError(t, err)
+		defer mock.Close(ctx)
+		// Run test
+		_, err = LoadFunctions(ctx, mock, []string{"public"})
+		// Check error
+		assert.ErrorContains(t, err, "permission denied for schema public")
+	})
+}
+
+func TestPrintDrift(t *testing.T) {
+	expected := []Function{
+		{Name: "public.add(integer, integer)", Hash: "a"},
+		{Name: "public.greet()", Hash: "b"},
+		{Name: "public.dropped()", Hash: "c"},
+	}
+
+	t.Run("reports changed functions", func(t *testing.T) {
+		actual := []Function{
+			{Name: "public.add(integer, integer)", Hash: "a"},
+			{Name: "public.greet()", Hash: "edited"},
+			{Name: "public.hotfix()", Hash: "d"},
+		}
+		var out bytes.Buffer
+		// Run test
+		found := PrintDrift(&out, expected, actual)
+		// Check output
+		assert.True(t, found)
+		assert.Equal(t, `Found functions that differ from migrations:
+ ~ public.greet()
+ + public.hotfix() (not in migrations)
+ - public.dropped() (missing on remote)
+`, out.String())
+	})
+
+	t.Run("prints nothing on match", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		found := PrintDrift(&out, expected, expected)
+		// Check output
+		assert.False(t, found)
+		assert.Empty(t, out.String())
+	})
+}
+
+func TestExposedSchemas(t *testing.T) {
+	utils.Config.Api.Schemas = []string{"public", "storage", "graphql_public", "api"}
+	t.Cleanup(func() { utils.Config.Api.Schemas = nil })
+	// Run test
+	assert.Equal(t, []string{"public", "api"}, GetExposedSchemas())
+}
@@ -0,0 +1,89 @@
+package pooler
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func RunGet(ctx context.Context, projectRef, format string, fsys afero.Fs) error {
+	config, err := getPoolerConfig(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	if format == utils.OutputPretty {
+		return list.RenderTable(toMarkdown(config))
+	}
+	return utils.EncodeOutput(format, os.Stdout, config)
+}
+
+func RunUpdate(ctx context.Context, projectRef string, defaultPoolSize int, fsys afero.Fs) error {
+	config, err := getPoolerConfig(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	if err := validatePoolSize(defaultPoolSize, config.MaxClientConn); err != nil {
+		return err
+	}
+	resp, err := utils.GetSupabase().V1UpdateSupavisorConfigWithResponse(ctx, projectRef, api.UpdateSupavisorConfigBody{
+		DefaultPoolSize: &defaultPoolSize,
+	})
+	if err != nil {
+		return errors.Errorf("failed to update pooler config: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return errors.New("Unexpected error updating pooler config: " + string(resp.Body))
+	}
+	fmt.Fprintln(os.Stderr, "Updated pooler config of project "+utils.Aqua(projectRef)+".")
+	config.DefaultPoolSize = resp.JSON200.DefaultPoolSize
+	return list.RenderTable(toMarkdown(config))
+}
+
+func validatePoolSize(size int, maxClientConn *float32) error {
+	if size < 1 {
+		return errors.Errorf("Invalid default pool size: %d. Must be at least 1.", size)
+	}
+	// Pooled server connections are only useful up to the number of client connections
+	if maxClientConn != nil && float32(size) > *maxClientConn {
+		return errors.Errorf("Invalid default pool size: %d. Must not exceed max client connections of %.0f.", size, *maxClientConn)
+	}
+	return nil
+}
+
+func getPoolerConfig(ctx context.Context, projectRef string) (api.SupavisorConfigResponse, error) {
+	resp, err := utils.GetSupabase().V1GetSupavisorConfigWithResponse(ctx, projectRef)
+	if err != nil {
+		return api.SupavisorConfigResponse{}, errors.Errorf("failed to get pooler config: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return api.SupavisorConfigResponse{}, errors.New("Unexpected error retrieving pooler config: " + string(resp.Body))
+	}
+	for _, config := range *resp.JSON200 {
+		if config.DatabaseType == api.PRIMARY {
+			return config, nil
+		}
+	}
+	return api.SupavisorConfigResponse{}, errors.New("Pooler is not enabled for project: " + utils.Aqua(projectRef))
+}
+
+func toMarkdown(config api.SupavisorConfigResponse) string {
+	return fmt.Sprintf(`|SETTING|VALUE|
+|-|-|
+|pool_mode|%s|
+|default_pool_size|%s|
+|max_client_conn|%s|
+`, config.PoolMode, formatSize(config.DefaultPoolSize), formatSize(config.MaxClientConn))
+}
+
+func formatSize(value *float32) string {
+	if value == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f", *value)
+}
@@ -0,0 +1,107 @@
+package pooler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestPoolerCommand(t *testing.T) {
+	// Setup valid project ref
+	project := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	config := []api.SupavisorConfigResponse{{
+		DatabaseType:    api.PRIMARY,
+		PoolMode:        api.SupavisorConfigResponsePoolModeTransaction,
+		DefaultPoolSize: utils.Ptr(float32(15)),
+		MaxClientConn:   utils.Ptr(float32(200)),
+	}}
+
+	t.Run("shows pooler config", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/config/database/pooler").
+			Reply(http.StatusOK).
+			JSON(config)
+		// Run test
+		err := RunGet(context.Background(), project, utils.OutputPretty, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("updates default pool size", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/config/database/pooler").
+			Reply(http.StatusOK).
+			JSON(config)
+		gock.New(utils.DefaultApiHost).
+			Patch("/v1/projects/" + project + "/config/database/pooler").
+			MatchType("json").
+			JSON(api.UpdateSupavisorConfigBody{DefaultPoolSize: utils.Ptr(20)}).
+			Reply(http.StatusOK).
+			JSON(api.UpdateSupavisorConfigResponse{
+				DefaultPoolSize: utils.Ptr(float32(20)),
+				PoolMode:        api.UpdateSupavisorConfigResponsePoolModeTransaction,
+			})
+		// Run test
+		err := RunUpdate(context.Background(), project, 20, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on pool size above client limit", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/config/database/pooler").
+			Reply(http.StatusOK).
+			JSON(config)
+		// Run test
+		err := RunUpdate(context.Background(), project, 500, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Must not exceed max client connections of 200.")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on zero pool size", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/config/database/pooler").
+			Reply(http.StatusOK).
+			JSON(config)
+		// Run test
+		err := RunUpdate(context.Background(), project, 0, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Must be at least 1.")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing pooler", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/config/database/pooler").
+			Reply(http.StatusOK).
+			JSON([]api.SupavisorConfigResponse{})
+		// Run test
+		err := RunGet(context.Background(), project, utils.OutputJson, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Pooler is not enabled for project:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
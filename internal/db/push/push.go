@@ -5,18 +5,51 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/go-errors/errors"
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/hooks"
 	"github.com/supabase/cli/internal/migration/apply"
+	"github.com/supabase/cli/internal/migration/list"
 	"github.com/supabase/cli/internal/migration/up"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
 )
 
-func Run(ctx context.Context, dryRun, ignoreVersionMismatch bool, includeRoles, includeSeed bool, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+const (
+	StepRoles      = "roles"
+	StepMigrations = "migrations"
+	StepSeed       = "seed"
+)
+
+var DefaultOrder = []string{StepRoles, StepMigrations, StepSeed}
+
+// Switches to session mode when pushing through a transaction mode pooler, unless explicitly
+// allowed, in which case statements are sent without preparing them first.
+func ResolvePoolerMode(config pgconn.Config, allowTransactionMode bool, w io.Writer) (pgconn.Config, []func(*pgx.ConnConfig)) {
+	if !utils.IsTransactionPooler(config) {
+		return config, nil
+	}
+	if allowTransactionMode {
+		fmt.Fprintln(w, utils.Yellow("WARNING:"), "Pushing through a transaction mode pooler. Migrations that rely on session state, such as LISTEN or SET, may not behave as expected.")
+		return config, []func(*pgx.ConnConfig){func(cc *pgx.ConnConfig) {
+			cc.PreferSimpleProtocol = true
+		}}
+	}
+	session := utils.GetSessionConfig(config)
+	fmt.Fprintf(w, "Connecting on port %d instead of transaction mode pooler because prepared statements are not supported. Pass %s to override.\n", session.Port, utils.Aqua("--allow-transaction-mode"))
+	return session, nil
+}
+
+func Run(ctx context.Context, dryRun, ignoreVersionMismatch bool, includeRoles, includeSeed bool, order []string, atomic bool, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if err := validateOrder(order); err != nil {
+		return err
+	}
 	if dryRun {
 		fmt.Fprintln(os.Stderr, "DRY RUN: migrations will *not* be pushed to the database.")
 	}
@@ -29,43 +62,200 @@ func Run(ctx context.Context, dryRun, ignoreVersionMismatch bool, includeRoles,
 	if err != nil {
 		return err
 	}
+	if err := warnChangedMigrations(ctx, conn, os.Stderr, fsys); err != nil {
+		return err
+	}
 	if len(pending) == 0 {
 		fmt.Println("Remote database is up to date.")
 		return nil
 	}
-	// Push pending migrations
-	if dryRun {
-		if includeRoles {
-			fmt.Fprintln(os.Stderr, "Would create custom roles "+utils.Bold(utils.CustomRolesPath)+"...")
+	if failed, err := loadFailure(config, pending[0], fsys); err != nil {
+		return err
+	} else if failed != nil {
+		if pending, err = resolveFailure(ctx, conn, failed, pending, dryRun, config, fsys); err != nil {
+			return err
+		} else if len(pending) == 0 && !dryRun {
+			fmt.Println("Finished " + utils.Aqua("supabase db push") + ".")
+			return nil
 		}
-		for _, filename := range pending {
-			fmt.Fprintln(os.Stderr, "Would push migration "+utils.Bold(filename)+"...")
+	}
+	if atomic {
+		if err := checkDataMigrations(pending); err != nil {
+			return err
 		}
-		if includeSeed {
-			fmt.Fprintln(os.Stderr, "Would seed data "+utils.Bold(utils.SeedDataPath)+"...")
+	}
+	plan := NewPlan(pending, includeRoles, includeSeed, order)
+	// Push pending migrations
+	if dryRun {
+		for _, s := range plan {
+			switch s.Name {
+			case StepRoles:
+				fmt.Fprintln(os.Stderr, "Would create custom roles "+utils.Bold(utils.CustomRolesPath)+"...")
+			case StepSeed:
+				fmt.Fprintln(os.Stderr, "Would seed data "+utils.Bold(utils.SeedDataPath)+"...")
+			default:
+				for _, filename := range s.Files {
+					fmt.Fprintln(os.Stderr, "Would push migration "+utils.Bold(filename)+"...")
+				}
+			}
 		}
 	} else {
-		msg := fmt.Sprintf("Do you want to push these migrations to the remote database?\n • %s\n\n", strings.Join(pending, "\n • "))
+		msg := "Do you want to push these migrations to the remote database?\n" + plan.String(atomic) + "\n"
 		if shouldPush, err := utils.NewConsole().PromptYesNo(ctx, msg, true); err != nil {
 			return err
 		} else if !shouldPush {
 			return errors.New(context.Canceled)
 		}
-		if includeRoles {
-			if err := CreateCustomRoles(ctx, conn, os.Stderr, fsys); err != nil {
-				return err
+		started := time.Now()
+		err := plan.Apply(ctx, conn, atomic, fsys)
+		payload := hooks.NewPayload(utils.HookDbPush, flags.ProjectRef, started, err)
+		payload.Migrations = getVersions(pending)
+		hooks.Notify(ctx, payload)
+		if err != nil {
+			if !atomic {
+				return handleFailure(err, pending, 0, config, fsys)
 			}
-		}
-		if err := apply.MigrateUp(ctx, conn, pending, fsys); err != nil {
 			return err
 		}
-		if includeSeed {
-			if err := apply.SeedDatabase(ctx, conn, fsys); err != nil {
-				return err
+	}
+	fmt.Println("Finished " + utils.Aqua("supabase db push") + ".")
+	return nil
+}
+
+// Applied migrations are never pushed again, so local edits to them silently diverge from remote.
+func warnChangedMigrations(ctx context.Context, conn *pgx.Conn, w io.Writer, fsys afero.Fs) error {
+	changed, err := list.FindChangedMigrations(ctx, conn, fsys)
+	if err != nil || len(changed) == 0 {
+		return err
+	}
+	fmt.Fprintln(w, utils.Yellow("WARNING:"), "The following migrations were edited after being applied to the remote database:")
+	for _, m := range changed {
+		fmt.Fprintln(w, " •", utils.Bold(filepath.Join(utils.MigrationsDir, m.Version+"_"+m.Name+".sql")))
+	}
+	fmt.Fprintf(w, "These edits will not be pushed. Revert them, or move the changes to a new migration with %s.\n", utils.Aqua("supabase migration new"))
+	return nil
+}
+
+// Data migrations connect separately, so they cannot join the transaction of an atomic push.
+func checkDataMigrations(pending []string) error {
+	for _, filename := range pending {
+		if utils.DataFilePattern.MatchString(filepath.Base(filename)) {
+			return errors.Errorf("Cannot push data migration %s with %s flag.", utils.Bold(filename), utils.Aqua("--atomic"))
+		}
+	}
+	return nil
+}
+
+func getVersions(pending []string) []string {
+	var versions []string
+	for _, filename := range pending {
+		if matches := utils.MatchMigrationFile(filepath.Base(filename)); len(matches) > 1 {
+			versions = append(versions, matches[1])
+		}
+	}
+	return versions
+}
+
+func validateOrder(order []string) error {
+	seen := make(map[string]struct{}, len(order))
+	for _, name := range order {
+		if !utils.SliceContains(DefaultOrder, name) {
+			return errors.Errorf("Invalid push step %q. Must be one of: %v", name, DefaultOrder)
+		}
+		if _, ok := seen[name]; ok {
+			return errors.Errorf("Duplicate push step %q.", name)
+		}
+		seen[name] = struct{}{}
+	}
+	return nil
+}
+
+type Step struct {
+	Name  string
+	Files []string
+}
+
+type Plan []Step
+
+// Creates an execution plan from pending migrations, honouring the user defined order
+// of steps. Steps omitted from order are appended in their default position.
+func NewPlan(pending []string, includeRoles, includeSeed bool, order []string) Plan {
+	var plan Plan
+	steps := append(append([]string{}, order...), DefaultOrder...)
+	for _, name := range steps {
+		if utils.SliceContains(plan.names(), name) {
+			continue
+		}
+		switch name {
+		case StepRoles:
+			if includeRoles {
+				plan = append(plan, Step{Name: StepRoles})
+			}
+		case StepMigrations:
+			plan = append(plan, Step{Name: StepMigrations, Files: pending})
+		case StepSeed:
+			if includeSeed {
+				plan = append(plan, Step{Name: StepSeed})
 			}
 		}
 	}
-	fmt.Println("Finished " + utils.Aqua("supabase db push") + ".")
+	return plan
+}
+
+func (p Plan) names() []string {
+	result := make([]string, len(p))
+	for i, s := range p {
+		result[i] = s.Name
+	}
+	return result
+}
+
+func (p Plan) String(atomic bool) string {
+	var lines []string
+	for i, s := range p {
+		switch s.Name {
+		case StepRoles:
+			lines = append(lines, fmt.Sprintf("%d. Create custom roles: %s", i+1, utils.CustomRolesPath))
+		case StepSeed:
+			lines = append(lines, fmt.Sprintf("%d. Seed data: %s", i+1, utils.SeedDataPath))
+		default:
+			lines = append(lines, fmt.Sprintf("%d. Apply migrations:\n • %s", i+1, strings.Join(s.Files, "\n • ")))
+		}
+	}
+	if atomic {
+		lines = append(lines, "All steps will be applied in a single transaction.")
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func (p Plan) Apply(ctx context.Context, conn *pgx.Conn, atomic bool, fsys afero.Fs) (err error) {
+	if atomic {
+		if _, err := conn.Exec(ctx, "BEGIN"); err != nil {
+			return errors.Errorf("failed to begin transaction: %w", err)
+		}
+		defer func() {
+			if err != nil {
+				if _, rbErr := conn.Exec(context.Background(), "ROLLBACK"); rbErr != nil {
+					fmt.Fprintln(os.Stderr, "Failed to rollback transaction:", rbErr)
+				}
+			} else if _, err = conn.Exec(ctx, "COMMIT"); err != nil {
+				err = errors.Errorf("failed to commit transaction: %w", err)
+			}
+		}()
+	}
+	for _, s := range p {
+		switch s.Name {
+		case StepRoles:
+			err = CreateCustomRoles(ctx, conn, utils.GetStatusWriter(), fsys)
+		case StepSeed:
+			err = apply.SeedDatabase(ctx, conn, fsys)
+		default:
+			err = apply.MigrateUp(ctx, conn, s.Files, fsys)
+		}
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
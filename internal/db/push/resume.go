@@ -0,0 +1,211 @@
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/apply"
+	"github.com/supabase/cli/internal/migration/history"
+	"github.com/supabase/cli/internal/migration/repair"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var FailedMigrationPath = filepath.Join(utils.TempDir, "failed-migration.json")
+
+// Records a migration that failed after some of its statements were committed, so that
+// the next push can resume from the failing statement instead of running them again.
+type failedMigration struct {
+	Filename  string `json:"filename"`
+	Checksum  string `json:"checksum"`
+	Host      string `json:"host"`
+	Database  string `json:"database"`
+	Index     int    `json:"index"`
+	Committed int    `json:"committed"`
+}
+
+// Loads the failure recorded against the same database, if it is for the next pending
+// migration. Failures for migrations that have since been applied or edited are discarded.
+func loadFailure(config pgconn.Config, next string, fsys afero.Fs) (*failedMigration, error) {
+	data, err := afero.ReadFile(fsys, FailedMigrationPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Errorf("failed to read failed migration: %w", err)
+	}
+	var failed failedMigration
+	if err := json.Unmarshal(data, &failed); err != nil {
+		return nil, errors.Errorf("failed to parse failed migration: %w", err)
+	}
+	if failed.Host != config.Host || failed.Database != config.Database {
+		return nil, nil
+	}
+	if failed.Filename == next {
+		migration, err := repair.NewMigrationFromFile(filepath.Join(utils.MigrationsDir, next), fsys)
+		if err != nil {
+			return nil, err
+		}
+		if failed.Checksum == history.Checksum(migration.Lines) {
+			return &failed, nil
+		}
+		fmt.Fprintf(os.Stderr, "%s Migration %s was edited after it was partially applied. Statements before %d may run again.\n", utils.Yellow("WARNING:"), utils.Bold(next), failed.Committed)
+	}
+	return nil, clearFailure(fsys)
+}
+
+func clearFailure(fsys afero.Fs) error {
+	if err := fsys.Remove(FailedMigrationPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Errorf("failed to remove failed migration: %w", err)
+	}
+	return nil
+}
+
+// Resumes or skips a partially applied migration, returning the remaining pending migrations.
+func resolveFailure(ctx context.Context, conn *pgx.Conn, failed *failedMigration, pending []string, dryRun bool, config pgconn.Config, fsys afero.Fs) ([]string, error) {
+	if dryRun {
+		fmt.Fprintf(os.Stderr, "Would resume migration %s from statement %d...\n", utils.Bold(failed.Filename), failed.Committed)
+		return pending[1:], nil
+	}
+	console := utils.NewConsole()
+	msg := fmt.Sprintf("Migration %s was partially applied by a previous push, which failed at statement %d. Resume from statement %d?", utils.Bold(failed.Filename), failed.Index, failed.Committed)
+	if shouldResume, err := console.PromptYesNo(ctx, msg, true); err != nil {
+		return nil, err
+	} else if shouldResume {
+		if err := apply.ResumeMigration(ctx, conn, failed.Filename, failed.Committed, fsys); err != nil {
+			return nil, handleFailure(err, pending[:1], failed.Committed, config, fsys)
+		}
+	} else if shouldSkip, err := console.PromptYesNo(ctx, "Mark "+utils.Bold(failed.Filename)+" as applied and skip its remaining statements?", false); err != nil {
+		return nil, err
+	} else if !shouldSkip {
+		return nil, errors.New(context.Canceled)
+	} else if err := skipMigration(ctx, conn, failed.Filename, fsys); err != nil {
+		return nil, err
+	}
+	return pending[1:], clearFailure(fsys)
+}
+
+func skipMigration(ctx context.Context, conn *pgx.Conn, filename string, fsys afero.Fs) error {
+	migration, err := repair.NewMigrationFromFile(filepath.Join(utils.MigrationsDir, filename), fsys)
+	if err != nil {
+		return err
+	}
+	if err := migration.InsertVersion(ctx, conn); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Marked migration "+utils.Bold(filename)+" as applied.")
+	return nil
+}
+
+// Prints the statement that failed with its line numbers, and records the failure if any
+// statements of the migration were committed. Statements before start were committed by
+// a previous push. Always returns the original error.
+func handleFailure(err error, pending []string, start int, config pgconn.Config, fsys afero.Fs) error {
+	var stmtErr *repair.StatementError
+	if !errors.As(err, &stmtErr) || len(stmtErr.Version) == 0 {
+		return err
+	}
+	var filename string
+	for _, name := range pending {
+		if strings.HasPrefix(name, stmtErr.Version+"_") {
+			filename = name
+			break
+		}
+	}
+	if len(filename) == 0 {
+		return err
+	}
+	path := filepath.Join(utils.MigrationsDir, filename)
+	contents, readErr := repair.ReadMigrationFile(path, fsys)
+	if readErr != nil {
+		return err
+	}
+	migration, readErr := repair.NewMigrationFromFile(path, fsys)
+	if readErr != nil {
+		return err
+	}
+	printStatement(os.Stderr, path, string(contents), migration.Lines, stmtErr.Index)
+	committed := max(start, countCommitted(migration.Lines, stmtErr.Index))
+	if committed == 0 {
+		return err
+	}
+	failed := failedMigration{
+		Filename:  filename,
+		Checksum:  history.Checksum(migration.Lines),
+		Host:      config.Host,
+		Database:  config.Database,
+		Index:     stmtErr.Index,
+		Committed: committed,
+	}
+	if writeErr := saveFailure(failed, fsys); writeErr != nil {
+		fmt.Fprintln(os.Stderr, writeErr)
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Statements before %d were committed. Run %s again to resume from statement %d or skip this migration.\n", committed, utils.Aqua("supabase db push"), committed)
+	return err
+}
+
+func saveFailure(failed failedMigration, fsys afero.Fs) error {
+	data, err := json.Marshal(failed)
+	if err != nil {
+		return errors.Errorf("failed to encode failed migration: %w", err)
+	}
+	if err := utils.WriteFile(FailedMigrationPath, data, fsys); err != nil {
+		return errors.Errorf("failed to save failed migration: %w", err)
+	}
+	return nil
+}
+
+// Statements are batched in an implicit transaction, so only those up to the last explicit
+// COMMIT before the failing statement persist.
+func countCommitted(lines []string, failed int) int {
+	for i := min(failed, len(lines)) - 1; i >= 0; i-- {
+		if isCommit(lines[i]) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func isCommit(stat string) bool {
+	var body []string
+	for _, line := range strings.Split(stat, "\n") {
+		if trimmed := strings.TrimSpace(line); len(trimmed) > 0 && !strings.HasPrefix(trimmed, "--") {
+			body = append(body, trimmed)
+		}
+	}
+	keyword, _, _ := strings.Cut(strings.Join(body, " "), " ")
+	keyword = strings.ToUpper(strings.TrimSuffix(keyword, ";"))
+	return keyword == "COMMIT" || keyword == "END"
+}
+
+func printStatement(w io.Writer, path, contents string, lines []string, index int) {
+	if index >= len(lines) {
+		return
+	}
+	// Statements are trimmed from the file in order, so each is found after the previous one
+	offset := 0
+	for i := 0; i <= index; i++ {
+		pos := strings.Index(contents[offset:], lines[i])
+		if pos < 0 {
+			return
+		}
+		if i < index {
+			offset += pos + len(lines[i])
+			continue
+		}
+		offset += pos
+	}
+	first := strings.Count(contents[:offset], "\n") + 1
+	fmt.Fprintf(w, "Failed statement in %s:\n", utils.Bold(path))
+	for i, line := range strings.Split(lines[index], "\n") {
+		fmt.Fprintf(w, "%5d | %s\n", first+i, line)
+	}
+}
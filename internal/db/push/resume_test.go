@@ -0,0 +1,217 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgerrcode"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/migration/history"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/migration/repair"
+	"github.com/supabase/cli/internal/testing/fstest"
+	"github.com/supabase/cli/internal/testing/pgtest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const partialMigration = `create schema private;
+commit;
+
+-- fails on missing schema
+create table
+  missing.todos ();
+`
+
+var partialLines = []string{
+	"create schema private",
+	"commit",
+	"-- fails on missing schema\ncreate table\n  missing.todos ()",
+}
+
+func writeFailure(t *testing.T, fsys afero.Fs) {
+	failed := failedMigration{
+		Filename:  "1_test.sql",
+		Checksum:  history.Checksum(partialLines),
+		Host:      dbConfig.Host,
+		Database:  dbConfig.Database,
+		Index:     2,
+		Committed: 2,
+	}
+	data, err := json.Marshal(failed)
+	require.NoError(t, err)
+	require.NoError(t, afero.WriteFile(fsys, FailedMigrationPath, data, 0644))
+}
+
+func TestHandleFailure(t *testing.T) {
+	path := filepath.Join(utils.MigrationsDir, "1_test.sql")
+
+	t.Run("records partially applied migration", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(partialMigration), 0644))
+		stmtErr := errors.New(&repair.StatementError{Version: "1", Index: 2, Statement: partialLines[2], Err: errors.New("schema does not exist")})
+		// Run test
+		err := handleFailure(stmtErr, []string{"0_init.sql", "1_test.sql"}, 0, dbConfig, fsys)
+		// Check error
+		assert.ErrorIs(t, err, stmtErr)
+		data, err := afero.ReadFile(fsys, FailedMigrationPath)
+		require.NoError(t, err)
+		var failed failedMigration
+		require.NoError(t, json.Unmarshal(data, &failed))
+		assert.Equal(t, "1_test.sql", failed.Filename)
+		assert.Equal(t, 2, failed.Index)
+		assert.Equal(t, 2, failed.Committed)
+	})
+
+	t.Run("ignores uncommitted failure", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(partialMigration), 0644))
+		stmtErr := errors.New(&repair.StatementError{Version: "1", Index: 1, Statement: partialLines[1], Err: errors.New("failed")})
+		// Run test
+		err := handleFailure(stmtErr, []string{"1_test.sql"}, 0, dbConfig, fsys)
+		// Check error
+		assert.ErrorIs(t, err, stmtErr)
+		exists, err := afero.Exists(fsys, FailedMigrationPath)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestPrintStatement(t *testing.T) {
+	var out bytes.Buffer
+	// Run test
+	printStatement(&out, "1_test.sql", partialMigration, partialLines, 2)
+	// Check output
+	assert.Contains(t, out.String(), "    4 | -- fails on missing schema\n")
+	assert.Contains(t, out.String(), "    6 |   missing.todos ()\n")
+}
+
+func TestCountCommitted(t *testing.T) {
+	assert.Equal(t, 2, countCommitted(partialLines, 2))
+	assert.Equal(t, 0, countCommitted(partialLines, 1))
+	assert.Equal(t, 1, countCommitted([]string{"-- done\nEND", "select 1"}, 2))
+}
+
+func TestResumePush(t *testing.T) {
+	path := filepath.Join(utils.MigrationsDir, "1_test.sql")
+
+	t.Run("resumes from failed statement", func(t *testing.T) {
+		defer fstest.MockStdin(t, "y")()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(partialMigration), 0644))
+		writeFailure(t, fsys)
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(list.LIST_MIGRATION_VERSION).
+			Reply("SELECT 0")
+		conn.Query(list.LIST_MIGRATION_HISTORY).
+			Reply("SELECT 0")
+		conn.Query(partialLines[2]).
+			Reply("CREATE TABLE").
+			Query(history.INSERT_MIGRATION_VERSION, "1", "test", partialLines, history.Checksum(partialLines)).
+			Reply("INSERT 0 1")
+		// Run test
+		err := Run(context.Background(), false, false, false, false, nil, false, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		exists, err := afero.Exists(fsys, FailedMigrationPath)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("marks failed migration as applied", func(t *testing.T) {
+		defer fstest.MockStdin(t, "n\ny\n")()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(partialMigration), 0644))
+		writeFailure(t, fsys)
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(list.LIST_MIGRATION_VERSION).
+			Reply("SELECT 0")
+		conn.Query(list.LIST_MIGRATION_HISTORY).
+			Reply("SELECT 0")
+		conn.Query(history.INSERT_MIGRATION_VERSION, "1", "test", partialLines, history.Checksum(partialLines)).
+			Reply("INSERT 0 1")
+		// Run test
+		err := Run(context.Background(), false, false, false, false, nil, false, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		exists, err := afero.Exists(fsys, FailedMigrationPath)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("throws error on cancel", func(t *testing.T) {
+		defer fstest.MockStdin(t, "n\nn\n")()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(partialMigration), 0644))
+		writeFailure(t, fsys)
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(list.LIST_MIGRATION_VERSION).
+			Reply("SELECT 0")
+		conn.Query(list.LIST_MIGRATION_HISTORY).
+			Reply("SELECT 0")
+		// Run test
+		err := Run(context.Background(), false, false, false, false, nil, false, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorIs(t, err, context.Canceled)
+		exists, err := afero.Exists(fsys, FailedMigrationPath)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("records failure on resume", func(t *testing.T) {
+		defer fstest.MockStdin(t, "y")()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(partialMigration), 0644))
+		writeFailure(t, fsys)
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(list.LIST_MIGRATION_VERSION).
+			Reply("SELECT 0")
+		conn.Query(list.LIST_MIGRATION_HISTORY).
+			Reply("SELECT 0")
+		conn.Query(partialLines[2]).
+			ReplyError(pgerrcode.InvalidSchemaName, `schema "missing" does not exist`).
+			Query(history.INSERT_MIGRATION_VERSION, "1", "test", partialLines, history.Checksum(partialLines)).
+			Reply("INSERT 0 1")
+		// Run test
+		err := Run(context.Background(), false, false, false, false, nil, false, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, `schema "missing" does not exist`)
+		exists, err := afero.Exists(fsys, FailedMigrationPath)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("discards failure of edited migration", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, path, []byte("create schema private;"), 0644))
+		writeFailure(t, fsys)
+		// Run test
+		failed, err := loadFailure(dbConfig, "1_test.sql", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Nil(t, failed)
+		exists, err := afero.Exists(fsys, FailedMigrationPath)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
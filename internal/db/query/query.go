@@ -0,0 +1,168 @@
+package query
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+)
+
+type Result struct {
+	Columns []string
+	Rows    [][]any
+}
+
+func Run(ctx context.Context, sql, file string, params []string, format string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if len(file) > 0 {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(utils.CurrentDirAbs, file)
+		}
+		contents, err := afero.ReadFile(fsys, file)
+		if err != nil {
+			return errors.Errorf("failed to read query file: %w", err)
+		}
+		sql = string(contents)
+	}
+	if len(strings.TrimSpace(sql)) == 0 {
+		return errors.New("No query specified. Pass a SQL statement as argument or use --file.")
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	result, err := ExecQuery(ctx, conn, sql, params...)
+	if err != nil {
+		return err
+	}
+	return result.Encode(format, os.Stdout)
+}
+
+func ExecQuery(ctx context.Context, conn *pgx.Conn, sql string, params ...string) (*Result, error) {
+	args := make([]any, len(params))
+	for i, p := range params {
+		args[i] = p
+	}
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errors.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+	var result Result
+	for _, field := range rows.FieldDescriptions() {
+		result.Columns = append(result.Columns, string(field.Name))
+	}
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return nil, errors.Errorf("failed to read row values: %w", err)
+		}
+		result.Rows = append(result.Rows, values)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Errorf("failed to execute query: %w", err)
+	}
+	return &result, nil
+}
+
+func (r Result) Encode(format string, w io.Writer) error {
+	switch format {
+	case utils.OutputCsv:
+		return r.writeCsv(w)
+	case utils.OutputJson, utils.OutputYaml, utils.OutputToml:
+		return utils.EncodeOutput(format, w, r.toMaps())
+	}
+	return list.RenderTable(r.toMarkdown())
+}
+
+func (r Result) writeCsv(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(r.Columns); err != nil {
+		return errors.Errorf("failed to write csv header: %w", err)
+	}
+	for _, row := range r.Rows {
+		record := make([]string, len(row))
+		for i, v := range row {
+			if v != nil {
+				record[i] = toString(v)
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return errors.Errorf("failed to write csv row: %w", err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return errors.Errorf("failed to flush csv: %w", err)
+	}
+	return nil
+}
+
+func (r Result) toMaps() []map[string]any {
+	result := make([]map[string]any, len(r.Rows))
+	for i, row := range r.Rows {
+		result[i] = make(map[string]any, len(r.Columns))
+		for j, v := range row {
+			if v != nil {
+				v = toValue(v)
+			}
+			result[i][r.Columns[j]] = v
+		}
+	}
+	return result
+}
+
+func (r Result) toMarkdown() string {
+	var table strings.Builder
+	table.WriteString("|" + strings.Join(r.Columns, "|") + "|\n|")
+	table.WriteString(strings.Repeat("-|", len(r.Columns)) + "\n")
+	for _, row := range r.Rows {
+		table.WriteString("|")
+		for _, v := range row {
+			value := "NULL"
+			if v != nil {
+				value = strings.ReplaceAll(toString(v), "|", `\|`)
+			}
+			table.WriteString("`" + value + "`|")
+		}
+		table.WriteString("\n")
+	}
+	return table.String()
+}
+
+// Unwraps pgtype values, such as numeric and uuid, into their driver representation.
+func toValue(v any) any {
+	if valuer, ok := v.(driver.Valuer); ok {
+		if value, err := valuer.Value(); err == nil {
+			return value
+		}
+	}
+	if b, ok := v.([16]byte); ok {
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+	}
+	return v
+}
+
+func toString(v any) string {
+	switch value := toValue(v).(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case []byte:
+		return string(value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
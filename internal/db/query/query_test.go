@@ -0,0 +1,112 @@
+package query
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/pgtest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+type employee struct {
+	Id   int
+	Name string
+}
+
+func TestExecQuery(t *testing.T) {
+	t.Run("collects rows with params", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("select id, name from employees where name = $1", "Alice").
+			Reply("SELECT 1", employee{Id: 1, Name: "Alice"})
+		// Connect to mock
+		ctx := context.Background()
+		mock, err := utils.ConnectLocalPostgres(ctx, dbConfig, conn.Intercept)
+		require.NoError(t, err)
+		defer mock.Close(ctx)
+		// Run test
+		result, err := ExecQuery(ctx, mock, "select id, name from employees where name = $1", "Alice")
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Id", "Name"}, result.Columns)
+		assert.Equal(t, [][]any{{int64(1), "Alice"}}, result.Rows)
+	})
+
+	t.Run("throws error on query failure", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("select * from missing").
+			ReplyError(pgerrcode.UndefinedTable, `relation "missing" does not exist`)
+		// Connect to mock
+		ctx := context.Background()
+		mock, err := utils.ConnectLocalPostgres(ctx, dbConfig, conn.Intercept)
+		require.NoError(t, err)
+		defer mock.Close(ctx)
+		// Run test
+		_, err = ExecQuery(ctx, mock, "select * from missing")
+		// Check error
+		assert.ErrorContains(t, err, `ERROR: relation "missing" does not exist (SQLSTATE 42P01)`)
+	})
+}
+
+func TestEncodeResult(t *testing.T) {
+	result := Result{
+		Columns: []string{"id", "name"},
+		Rows:    [][]any{{int64(1), "Alice, Bob"}, {int64(2), nil}},
+	}
+
+	t.Run("encodes csv", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := result.Encode(utils.OutputCsv, &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "id,name\n1,\"Alice, Bob\"\n2,\n", out.String())
+	})
+
+	t.Run("encodes json", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := result.Encode(utils.OutputJson, &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[{"id":1,"name":"Alice, Bob"},{"id":2,"name":null}]`, out.String())
+	})
+
+	t.Run("encodes markdown table", func(t *testing.T) {
+		table := result.toMarkdown()
+		assert.Equal(t, "|id|name|\n|-|-|\n|`1`|`Alice, Bob`|\n|`2`|`NULL`|\n", table)
+	})
+}
+
+func TestQueryCommand(t *testing.T) {
+	t.Run("throws error on empty query", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), " ", "", nil, utils.OutputTable, dbConfig, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "No query specified.")
+	})
+
+	t.Run("throws error on missing file", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), "", "/tmp/missing.sql", nil, utils.OutputTable, dbConfig, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "failed to read query file:")
+	})
+}
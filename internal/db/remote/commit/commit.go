@@ -69,7 +69,7 @@ func fetchRemote(p utils.Program, ctx context.Context, schema []string, timestam
 		return err
 	} else if len(migrations) == 0 {
 		p.Send(utils.StatusMsg("Committing initial migration on remote database..."))
-		return dump.Run(ctx, path, config, nil, nil, false, false, false, false, false, fsys)
+		return dump.Run(ctx, path, config, nil, nil, false, false, false, false, false, false, fsys)
 	}
 
 	w := utils.StatusWriter{Program: p}
@@ -104,7 +104,7 @@ func assertRemoteInSync(ctx context.Context, conn *pgx.Conn, fsys afero.Fs) erro
 
 	for i, remoteTimestamp := range remoteMigrations {
 		// LoadLocalMigrations guarantees we always have a match
-		localTimestamp := utils.MigrateFilePattern.FindStringSubmatch(localMigrations[i])[1]
+		localTimestamp := utils.MatchMigrationFile(localMigrations[i])[1]
 		if localTimestamp != remoteTimestamp {
 			return conflictErr
 		}
@@ -20,6 +20,7 @@ import (
 	"github.com/supabase/cli/internal/db/start"
 	"github.com/supabase/cli/internal/gen/keys"
 	"github.com/supabase/cli/internal/migration/apply"
+	"github.com/supabase/cli/internal/migration/capture"
 	"github.com/supabase/cli/internal/migration/repair"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/pgxv5"
@@ -32,7 +33,7 @@ var (
 	ListSchemas string
 )
 
-func Run(ctx context.Context, version string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+func Run(ctx context.Context, version, projectRef string, force bool, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
 	if len(version) > 0 {
 		if _, err := strconv.Atoi(version); err != nil {
 			return errors.New(repair.ErrInvalidVersion)
@@ -41,6 +42,12 @@ func Run(ctx context.Context, version string, config pgconn.Config, fsys afero.F
 			return err
 		}
 	}
+	if len(projectRef) > 0 {
+		if err := confirmLinkedReset(ctx, projectRef, force, fsys); err != nil {
+			return err
+		}
+		return resetRemote(ctx, version, config, fsys, options...)
+	}
 	if !utils.IsLocalDatabase(config) {
 		msg := "Do you want to reset the remote database?"
 		if shouldReset, err := utils.NewConsole().PromptYesNo(ctx, msg, false); err != nil {
@@ -101,7 +108,10 @@ func resetDatabase14(ctx context.Context, version string, fsys afero.Fs, options
 			return err
 		}
 	}
-	return apply.MigrateAndSeed(ctx, version, conn, fsys)
+	if err := apply.MigrateAndSeed(ctx, version, conn, fsys); err != nil {
+		return err
+	}
+	return capture.Setup(ctx, conn)
 }
 
 func resetDatabase15(ctx context.Context, version string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
@@ -142,6 +152,9 @@ func resetDatabase15(ctx context.Context, version string, fsys afero.Fs, options
 	if err := apply.MigrateAndSeed(ctx, version, conn, fsys); err != nil {
 		return err
 	}
+	if err := capture.Setup(ctx, conn); err != nil {
+		return err
+	}
 	fmt.Fprintln(os.Stderr, "Restarting containers...")
 	return restartServices(ctx)
 }
@@ -217,6 +230,28 @@ func restartServices(ctx context.Context) error {
 	return errors.Join(result...)
 }
 
+// Resetting a linked project is irreversible, so the user must type its ref instead of a yes/no answer.
+func confirmLinkedReset(ctx context.Context, projectRef string, force bool, fsys afero.Fs) error {
+	if protected, err := utils.IsProtectedRef(projectRef, fsys); err != nil {
+		return err
+	} else if protected {
+		return utils.ConfirmProtectedRef(ctx, projectRef, "reset the database", force, fsys)
+	}
+	if force {
+		fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "Skipped confirmation to reset project "+utils.Aqua(projectRef)+".")
+		return nil
+	}
+	fmt.Fprintln(os.Stderr, utils.Red("WARNING:"), "This drops all user schemas on project "+utils.Aqua(projectRef)+" and replays local migrations and seed data.")
+	input, err := utils.NewConsole().PromptText(ctx, "Type the project ref to confirm: ")
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(input) != projectRef {
+		return errors.New(context.Canceled)
+	}
+	return nil
+}
+
 func resetRemote(ctx context.Context, version string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
 	fmt.Fprintln(os.Stderr, "Resetting remote database"+toLogMessage(version))
 	conn, err := utils.ConnectByConfigStream(ctx, config, io.Discard, options...)
@@ -39,7 +39,7 @@ func TestResetCommand(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := Run(context.Background(), "", pgconn.Config{Host: "db.supabase.co"}, fsys)
+		err := Run(context.Background(), "", "", false, pgconn.Config{Host: "db.supabase.co"}, fsys)
 		// Check error
 		assert.ErrorIs(t, err, context.Canceled)
 	})
@@ -49,7 +49,7 @@ func TestResetCommand(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := Run(context.Background(), "", pgconn.Config{Host: "db.supabase.co"}, fsys)
+		err := Run(context.Background(), "", "", false, pgconn.Config{Host: "db.supabase.co"}, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "invalid port (outside range)")
 	})
@@ -64,7 +64,7 @@ func TestResetCommand(t *testing.T) {
 			Get("/v" + utils.Docker.ClientVersion() + "/containers").
 			Reply(http.StatusNotFound)
 		// Run test
-		err := Run(context.Background(), "", dbConfig, fsys)
+		err := Run(context.Background(), "", "", false, dbConfig, fsys)
 		// Check error
 		assert.ErrorIs(t, err, utils.ErrNotRunning)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -86,7 +86,7 @@ func TestResetCommand(t *testing.T) {
 			Delete("/v" + utils.Docker.ClientVersion() + "/containers/" + utils.DbId).
 			ReplyError(errors.New("network error"))
 		// Run test
-		err := Run(context.Background(), "", dbConfig, fsys)
+		err := Run(context.Background(), "", "", false, dbConfig, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "network error")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -396,3 +396,40 @@ func TestResetRemote(t *testing.T) {
 		assert.ErrorContains(t, err, "ERROR: permission denied for relation supabase_migrations (SQLSTATE 42501)")
 	})
 }
+
+func TestConfirmLinkedReset(t *testing.T) {
+	projectRef := apitest.RandomProjectRef()
+
+	t.Run("confirms matching project ref", func(t *testing.T) {
+		defer fstest.MockStdin(t, projectRef)()
+		// Run test
+		err := confirmLinkedReset(context.Background(), projectRef, false, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on mismatched project ref", func(t *testing.T) {
+		defer fstest.MockStdin(t, "y")()
+		// Run test
+		err := confirmLinkedReset(context.Background(), projectRef, false, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("skips confirmation with force", func(t *testing.T) {
+		// Run test
+		err := confirmLinkedReset(context.Background(), projectRef, true, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("confirms protected ref", func(t *testing.T) {
+		utils.Config.Safety.ProtectedRefs = []string{projectRef}
+		t.Cleanup(func() { utils.Config.Safety.ProtectedRefs = nil })
+		defer fstest.MockStdin(t, "y")()
+		// Run test
+		err := confirmLinkedReset(context.Background(), projectRef, false, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorIs(t, err, utils.ErrProtectedRef)
+	})
+}
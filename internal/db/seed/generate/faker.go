@@ -0,0 +1,136 @@
+package generate
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	now = time.Now().UTC()
+
+	firstNames = []string{"Alice", "Bob", "Carol", "David", "Emma", "Farah", "George", "Hana", "Ivan", "Julia", "Kenji", "Lena", "Mateo", "Nadia", "Omar", "Priya", "Quinn", "Rosa", "Samuel", "Tara", "Umar", "Vera", "Wei", "Yusuf", "Zoe"}
+	lastNames  = []string{"Anderson", "Brown", "Chen", "Diaz", "Evans", "Fischer", "Garcia", "Hughes", "Ito", "Johnson", "Kim", "Lopez", "Martin", "Nguyen", "Okafor", "Patel", "Rossi", "Silva", "Tanaka", "Walker"}
+	cities     = []string{"Amsterdam", "Bangkok", "Berlin", "Buenos Aires", "Cairo", "Cape Town", "Lagos", "Lisbon", "London", "Melbourne", "Mexico City", "Mumbai", "Nairobi", "New York", "Paris", "Seoul", "Singapore", "Tokyo", "Toronto", "Warsaw"}
+	countries  = []string{"Argentina", "Australia", "Brazil", "Canada", "Egypt", "France", "Germany", "India", "Japan", "Kenya", "Mexico", "Netherlands", "Nigeria", "Poland", "Portugal", "Singapore", "South Africa", "South Korea", "Thailand", "United States"}
+	companies  = []string{"Acme", "Globex", "Initech", "Umbrella", "Hooli", "Stark Industries", "Wayne Enterprises", "Wonka", "Cyberdyne", "Soylent"}
+	words      = []string{"alpha", "amber", "bright", "canvas", "cloud", "delta", "ember", "field", "forest", "garden", "harbor", "island", "jade", "kernel", "lumen", "meadow", "nova", "orbit", "pixel", "quartz", "river", "signal", "summit", "tidal", "umbra", "vector", "willow", "zenith"}
+)
+
+// Generators produce unquoted text values, which are quoted as literals by the caller.
+var textGenerators = map[string]func(i int) string{
+	"first_name": func(i int) string { return pick(firstNames) },
+	"last_name":  func(i int) string { return pick(lastNames) },
+	"name":       func(i int) string { return pick(firstNames) + " " + pick(lastNames) },
+	"username":   func(i int) string { return fmt.Sprintf("%s%d", strings.ToLower(pick(firstNames)), i+1) },
+	"email": func(i int) string {
+		return fmt.Sprintf("%s.%s%d@example.com", strings.ToLower(pick(firstNames)), strings.ToLower(pick(lastNames)), i+1)
+	},
+	"phone":     func(i int) string { return fmt.Sprintf("+1-555-%03d-%04d", rng.Intn(1000), rng.Intn(10000)) },
+	"url":       func(i int) string { return fmt.Sprintf("https://%s.example.com", pick(words)) },
+	"city":      func(i int) string { return pick(cities) },
+	"country":   func(i int) string { return pick(countries) },
+	"company":   func(i int) string { return pick(companies) },
+	"word":      func(i int) string { return pick(words) },
+	"sentence":  func(i int) string { return sentence(4 + rng.Intn(8)) },
+	"paragraph": func(i int) string { return paragraph() },
+	"uuid":      func(i int) string { return uuid.NewString() },
+	"date":      func(i int) string { return randomTime().Format(time.DateOnly) },
+	"time":      func(i int) string { return randomTime().Format(time.TimeOnly) },
+	"timestamp": func(i int) string { return randomTime().Format(time.RFC3339) },
+	"json":      func(i int) string { return fmt.Sprintf(`{"%s": "%s"}`, pick(words), pick(words)) },
+}
+
+// Generators producing values that are inserted without quoting.
+var rawGenerators = map[string]func(i int) string{
+	"int":   func(i int) string { return fmt.Sprintf("%d", 1+rng.Intn(1000)) },
+	"float": func(i int) string { return fmt.Sprintf("%.2f", rng.Float64()*1000) },
+	"bool":  func(i int) string { return fmt.Sprintf("%t", rng.Intn(2) == 0) },
+	"null":  func(i int) string { return "NULL" },
+}
+
+// Column names that hint at more realistic text than random words.
+var nameHints = []struct {
+	suffix    string
+	generator string
+}{
+	{"email", "email"},
+	{"first_name", "first_name"},
+	{"last_name", "last_name"},
+	{"username", "username"},
+	{"name", "name"},
+	{"phone", "phone"},
+	{"url", "url"},
+	{"website", "url"},
+	{"city", "city"},
+	{"country", "country"},
+	{"company", "company"},
+	{"title", "sentence"},
+	{"bio", "paragraph"},
+	{"description", "paragraph"},
+	{"content", "paragraph"},
+	{"body", "paragraph"},
+}
+
+var typeGenerators = map[string]string{
+	"int2":        "int",
+	"int4":        "int",
+	"int8":        "int",
+	"numeric":     "float",
+	"float4":      "float",
+	"float8":      "float",
+	"bool":        "bool",
+	"uuid":        "uuid",
+	"date":        "date",
+	"time":        "time",
+	"timestamp":   "timestamp",
+	"timestamptz": "timestamp",
+	"json":        "json",
+	"jsonb":       "json",
+}
+
+func inferGenerator(col Column) string {
+	if g, ok := typeGenerators[col.Type]; ok {
+		return g
+	}
+	switch col.Type {
+	case "text", "varchar", "bpchar", "citext", "name":
+		for _, h := range nameHints {
+			if strings.HasSuffix(col.Name, h.suffix) {
+				return h.generator
+			}
+		}
+		return "word"
+	}
+	return ""
+}
+
+func pick(values []string) string {
+	return values[rng.Intn(len(values))]
+}
+
+func sentence(n int) string {
+	result := make([]string, n)
+	for i := range result {
+		result[i] = pick(words)
+	}
+	s := strings.Join(result, " ")
+	return strings.ToUpper(s[:1]) + s[1:] + "."
+}
+
+func paragraph() string {
+	result := make([]string, 2+rng.Intn(3))
+	for i := range result {
+		result[i] = sentence(6 + rng.Intn(8))
+	}
+	return strings.Join(result, " ")
+}
+
+// Spreads generated times over the past year.
+func randomTime() time.Time {
+	return now.Add(-time.Duration(rng.Int63n(int64(365 * 24 * time.Hour)))).Truncate(time.Second)
+}
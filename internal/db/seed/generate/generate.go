@@ -0,0 +1,279 @@
+package generate
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+//go:embed templates/columns.sql
+var listColumns string
+
+// Leaves the column out of inserts so that its default value is used.
+const skipGenerator = "skip"
+
+type Column struct {
+	Schema     string
+	Table      string
+	Name       string
+	Type       string
+	MaxLength  int
+	Nullable   bool
+	HasDefault bool
+	Generated  bool
+	Unique     bool
+	Comment    string
+	Enum       []string
+	RefSchema  string
+	RefTable   string
+	RefColumn  string
+}
+
+type Table struct {
+	Schema  string
+	Name    string
+	Columns []Column
+}
+
+func (t Table) QualifiedName() string {
+	return t.Schema + "." + t.Name
+}
+
+func Run(ctx context.Context, schema []string, rows uint, specPath, outPath string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	spec, err := LoadSpec(specPath, fsys)
+	if err != nil {
+		return err
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	if len(schema) == 0 {
+		schema = []string{"public"}
+	}
+	columns, err := LoadColumns(ctx, conn, schema)
+	if err != nil {
+		return err
+	}
+	tables := SortTables(groupTables(columns))
+	var w io.Writer = os.Stdout
+	if len(outPath) > 0 {
+		f, err := fsys.OpenFile(outPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			return errors.Errorf("failed to open seed file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+	fmt.Fprintf(os.Stderr, "Generating seed data for %d tables...\n", len(tables))
+	return WriteInserts(w, tables, rows, spec)
+}
+
+func LoadColumns(ctx context.Context, conn *pgx.Conn, schema []string) ([]Column, error) {
+	rows, err := conn.Query(ctx, listColumns, schema)
+	if err != nil {
+		return nil, errors.Errorf("failed to list columns: %w", err)
+	}
+	defer rows.Close()
+	var result []Column
+	for rows.Next() {
+		var c Column
+		var enum string
+		if err := rows.Scan(&c.Schema, &c.Table, &c.Name, &c.Type, &c.MaxLength, &c.Nullable, &c.HasDefault, &c.Generated, &c.Unique, &c.Comment, &enum, &c.RefSchema, &c.RefTable, &c.RefColumn); err != nil {
+			return nil, errors.Errorf("failed to scan row: %w", err)
+		}
+		if err := json.Unmarshal([]byte(enum), &c.Enum); err != nil {
+			return nil, errors.Errorf("failed to parse enum labels: %w", err)
+		}
+		result = append(result, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Errorf("failed to read rows: %w", err)
+	}
+	return result, nil
+}
+
+// Columns are expected to be ordered by table.
+func groupTables(columns []Column) []Table {
+	var result []Table
+	for _, c := range columns {
+		if n := len(result); n == 0 || result[n-1].Schema != c.Schema || result[n-1].Name != c.Table {
+			result = append(result, Table{Schema: c.Schema, Name: c.Table})
+		}
+		last := &result[len(result)-1]
+		last.Columns = append(last.Columns, c)
+	}
+	return result
+}
+
+// Orders tables so that referenced tables are seeded before the tables referencing them.
+// Tables in a reference cycle are appended last, in their original order.
+func SortTables(tables []Table) []Table {
+	index := make(map[string]int, len(tables))
+	for i, t := range tables {
+		index[t.QualifiedName()] = i
+	}
+	dependents := make([][]int, len(tables))
+	inDegree := make([]int, len(tables))
+	for i, t := range tables {
+		seen := map[int]bool{}
+		for _, c := range t.Columns {
+			j, ok := index[c.RefSchema+"."+c.RefTable]
+			if !ok || j == i || seen[j] {
+				continue
+			}
+			seen[j] = true
+			dependents[j] = append(dependents[j], i)
+			inDegree[i]++
+		}
+	}
+	var queue []int
+	for i, d := range inDegree {
+		if d == 0 {
+			queue = append(queue, i)
+		}
+	}
+	var result []Table
+	done := make([]bool, len(tables))
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		result = append(result, tables[i])
+		done[i] = true
+		for _, j := range dependents[i] {
+			if inDegree[j]--; inDegree[j] == 0 {
+				queue = append(queue, j)
+			}
+		}
+	}
+	var cyclic []string
+	for i, t := range tables {
+		if !done[i] {
+			result = append(result, t)
+			cyclic = append(cyclic, t.QualifiedName())
+		}
+	}
+	if len(cyclic) > 0 {
+		fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "Foreign keys form a cycle between tables:", strings.Join(cyclic, ", "))
+	}
+	return result
+}
+
+func WriteInserts(w io.Writer, tables []Table, defaultRows uint, spec Spec) error {
+	seeded := make(map[string]bool, len(tables))
+	for _, t := range tables {
+		seeded[t.QualifiedName()] = true
+	}
+	if _, err := fmt.Fprintln(w, "-- Generated by `supabase db seed generate`"); err != nil {
+		return errors.Errorf("failed to write seed data: %w", err)
+	}
+	for _, t := range tables {
+		n := spec.rowsFor(t.QualifiedName(), defaultRows)
+		if n == 0 {
+			continue
+		}
+		for _, c := range t.Columns {
+			ref := c.RefSchema + "." + c.RefTable
+			if len(c.RefTable) > 0 && !c.Nullable && !seeded[ref] {
+				fmt.Fprintf(os.Stderr, "%s %s.%s references %s which is not seeded. Make sure it has rows before seeding.\n", utils.Yellow("WARNING:"), t.QualifiedName(), c.Name, ref)
+			}
+		}
+		table := pgx.Identifier{t.Schema, t.Name}.Sanitize()
+		if _, err := fmt.Fprintf(w, "\n-- %s\n", t.QualifiedName()); err != nil {
+			return errors.Errorf("failed to write seed data: %w", err)
+		}
+		for i := 0; i < int(n); i++ {
+			var names, values []string
+			for _, c := range t.Columns {
+				value, ok := generateValue(t, c, i, spec)
+				if !ok {
+					continue
+				}
+				names = append(names, pgx.Identifier{c.Name}.Sanitize())
+				values = append(values, value)
+			}
+			stmt := fmt.Sprintf("INSERT INTO %s DEFAULT VALUES;", table)
+			if len(names) > 0 {
+				stmt = fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s);", table, strings.Join(names, ", "), strings.Join(values, ", "))
+			}
+			if _, err := fmt.Fprintln(w, stmt); err != nil {
+				return errors.Errorf("failed to write seed data: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the SQL expression for the i-th row of a column, or false to omit the column.
+func generateValue(t Table, c Column, i int, spec Spec) (string, bool) {
+	g := spec.generatorFor(t.QualifiedName(), c)
+	if len(g) == 0 {
+		if c.Generated || c.HasDefault {
+			return "", false
+		}
+		if len(c.RefTable) > 0 {
+			return referenceValue(t, c, i), true
+		}
+		if len(c.Enum) > 0 {
+			return quoteLiteral(pick(c.Enum)), true
+		}
+		if strings.HasPrefix(c.Type, "_") {
+			return quoteLiteral("{}"), true
+		}
+		if g = inferGenerator(c); len(g) == 0 {
+			if c.Nullable {
+				return "NULL", true
+			}
+			fmt.Fprintf(os.Stderr, "%s Unsupported type %s for column %s.%s. Set a generator with %s.\n", utils.Yellow("WARNING:"), c.Type, t.QualifiedName(), c.Name, utils.Aqua("@seed"))
+			return "", false
+		}
+	}
+	if g == skipGenerator || c.Generated {
+		return "", false
+	}
+	if gen, ok := rawGenerators[g]; ok {
+		// Sequential values satisfy unique constraints on integer columns
+		if g == "int" && c.Unique {
+			return fmt.Sprintf("%d", i+1), true
+		}
+		return gen(i), true
+	}
+	value := textGenerators[g](i)
+	if c.Unique && g != "uuid" && g != "email" && g != "username" {
+		value = fmt.Sprintf("%s %d", value, i+1)
+	}
+	if c.MaxLength > 0 && len(value) > c.MaxLength {
+		value = value[:c.MaxLength]
+	}
+	return quoteLiteral(value), true
+}
+
+// Picks an existing row from the referenced table when the statement runs, so that
+// generated keys need not be known in advance.
+func referenceValue(t Table, c Column, i int) string {
+	if c.RefSchema == t.Schema && c.RefTable == t.Name && c.Nullable {
+		return "NULL"
+	}
+	column := pgx.Identifier{c.RefColumn}.Sanitize()
+	table := pgx.Identifier{c.RefSchema, c.RefTable}.Sanitize()
+	// One-to-one references must each point to a different row
+	if c.Unique {
+		return fmt.Sprintf("(SELECT %s FROM %s ORDER BY %s OFFSET %d LIMIT 1)", column, table, column, i)
+	}
+	return fmt.Sprintf("(SELECT %s FROM %s ORDER BY random() LIMIT 1)", column, table)
+}
+
+func quoteLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
@@ -0,0 +1,180 @@
+package generate
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/pgtest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestGenerateCommand(t *testing.T) {
+	t.Run("writes seed file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(listColumns, []string{"public"}).
+			Reply("SELECT 2",
+				[]interface{}{"public", "posts", "id", "int8", 0, false, true, false, true, "", "[]", "", "", ""},
+				[]interface{}{"public", "posts", "status", "post_status", 0, false, false, false, false, "", `["draft"]`, "", "", ""},
+			)
+		// Run test
+		err := Run(context.Background(), []string{"public"}, 2, "", utils.SeedDataPath, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		contents, err := afero.ReadFile(fsys, utils.SeedDataPath)
+		assert.NoError(t, err)
+		assert.Equal(t, `-- Generated by `+"`supabase db seed generate`"+`
+
+-- public.posts
+INSERT INTO "public"."posts" ("status") VALUES ('draft');
+INSERT INTO "public"."posts" ("status") VALUES ('draft');
+`, string(contents))
+	})
+
+	t.Run("throws error on invalid spec", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "seed.yaml", []byte("tables:\n  public.posts:\n    columns:\n      title: lorem\n"), 0644))
+		// Run test
+		err := Run(context.Background(), []string{"public"}, 2, "seed.yaml", "", dbConfig, fsys)
+		// Check error
+		assert.ErrorContains(t, err, `Unknown generator "lorem" for column public.posts.title`)
+	})
+}
+
+func TestSortTables(t *testing.T) {
+	t.Run("orders referenced tables first", func(t *testing.T) {
+		tables := []Table{
+			{Schema: "public", Name: "comments", Columns: []Column{
+				{Name: "post_id", RefSchema: "public", RefTable: "posts", RefColumn: "id"},
+				{Name: "author_id", RefSchema: "public", RefTable: "users", RefColumn: "id"},
+			}},
+			{Schema: "public", Name: "posts", Columns: []Column{
+				{Name: "author_id", RefSchema: "public", RefTable: "users", RefColumn: "id"},
+			}},
+			{Schema: "public", Name: "users", Columns: []Column{
+				{Name: "manager_id", RefSchema: "public", RefTable: "users", RefColumn: "id"},
+			}},
+		}
+		// Run test
+		sorted := SortTables(tables)
+		// Check result
+		var names []string
+		for _, t := range sorted {
+			names = append(names, t.Name)
+		}
+		assert.Equal(t, []string{"users", "posts", "comments"}, names)
+	})
+
+	t.Run("appends cyclic tables", func(t *testing.T) {
+		tables := []Table{
+			{Schema: "public", Name: "a", Columns: []Column{{Name: "b_id", RefSchema: "public", RefTable: "b"}}},
+			{Schema: "public", Name: "b", Columns: []Column{{Name: "a_id", RefSchema: "public", RefTable: "a"}}},
+			{Schema: "public", Name: "c"},
+		}
+		// Run test
+		sorted := SortTables(tables)
+		// Check result
+		var names []string
+		for _, t := range sorted {
+			names = append(names, t.Name)
+		}
+		assert.Equal(t, []string{"c", "a", "b"}, names)
+	})
+}
+
+func TestWriteInserts(t *testing.T) {
+	rng = rand.New(rand.NewSource(0))
+
+	t.Run("generates values by constraint", func(t *testing.T) {
+		tables := []Table{{Schema: "public", Name: "profiles", Columns: []Column{
+			{Name: "id", Type: "int4", Unique: true},
+			{Name: "user_id", Type: "uuid", Unique: true, RefSchema: "auth", RefTable: "users", RefColumn: "id"},
+			{Name: "parent_id", Type: "int4", Nullable: true, RefSchema: "public", RefTable: "profiles", RefColumn: "id"},
+			{Name: "handle", Type: "varchar", MaxLength: 6, Unique: true, Comment: "@seed word"},
+			{Name: "avatar", Type: "text", Nullable: true},
+			{Name: "tags", Type: "_text"},
+			{Name: "created_at", Type: "timestamptz", HasDefault: true},
+		}}}
+		spec := Spec{Tables: map[string]TableSpec{
+			"public.profiles": {Columns: map[string]string{"avatar": "null"}},
+		}}
+		var buf bytes.Buffer
+		// Run test
+		err := WriteInserts(&buf, tables, 1, spec)
+		// Check error
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), `INSERT INTO "public"."profiles" ("id", "user_id", "parent_id", "handle", "avatar", "tags") VALUES (1, (SELECT "id" FROM "auth"."users" ORDER BY "id" OFFSET 0 LIMIT 1), NULL, '`)
+		assert.Contains(t, buf.String(), `', NULL, '{}');`)
+	})
+
+	t.Run("skips tables with zero rows", func(t *testing.T) {
+		tables := []Table{{Schema: "public", Name: "audit"}}
+		rows := uint(0)
+		spec := Spec{Tables: map[string]TableSpec{"public.audit": {Rows: &rows}}}
+		var buf bytes.Buffer
+		// Run test
+		err := WriteInserts(&buf, tables, 5, spec)
+		// Check error
+		assert.NoError(t, err)
+		assert.NotContains(t, buf.String(), "INSERT")
+	})
+
+	t.Run("inserts default values", func(t *testing.T) {
+		tables := []Table{{Schema: "public", Name: "counters", Columns: []Column{
+			{Name: "id", Type: "int8", HasDefault: true},
+		}}}
+		var buf bytes.Buffer
+		// Run test
+		err := WriteInserts(&buf, tables, 1, Spec{})
+		// Check error
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), `INSERT INTO "public"."counters" DEFAULT VALUES;`)
+	})
+}
+
+func TestLoadSpec(t *testing.T) {
+	t.Run("parses rows and columns", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "seed.yaml", []byte(`rows: 20
+tables:
+  public.users:
+    rows: 5
+    columns:
+      bio: paragraph
+`), 0644))
+		// Run test
+		spec, err := LoadSpec("seed.yaml", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, uint(5), spec.rowsFor("public.users", 10))
+		assert.Equal(t, uint(20), spec.rowsFor("public.posts", 10))
+		assert.Equal(t, "paragraph", spec.generatorFor("public.users", Column{Name: "bio"}))
+		assert.Equal(t, "email", spec.generatorFor("public.users", Column{Name: "contact", Comment: "Primary contact @seed email"}))
+	})
+
+	t.Run("throws error on missing file", func(t *testing.T) {
+		// Run test
+		_, err := LoadSpec("seed.yaml", afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "failed to read spec file:")
+	})
+}
@@ -0,0 +1,79 @@
+package generate
+
+import (
+	"regexp"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// Spec overrides the number of rows and column generators, keyed by `schema.table`.
+type Spec struct {
+	Rows   *uint                `yaml:"rows"`
+	Tables map[string]TableSpec `yaml:"tables"`
+}
+
+type TableSpec struct {
+	Rows    *uint             `yaml:"rows"`
+	Columns map[string]string `yaml:"columns"`
+}
+
+// Column comments may also pick a generator, ie. `COMMENT ON COLUMN users.bio IS '@seed paragraph'`.
+var commentPattern = regexp.MustCompile(`@seed\s+([a-z_]+)`)
+
+func LoadSpec(path string, fsys afero.Fs) (Spec, error) {
+	var spec Spec
+	if len(path) == 0 {
+		return spec, nil
+	}
+	contents, err := afero.ReadFile(fsys, path)
+	if err != nil {
+		return spec, errors.Errorf("failed to read spec file: %w", err)
+	}
+	if err := yaml.Unmarshal(contents, &spec); err != nil {
+		return spec, errors.Errorf("failed to parse spec file: %w", err)
+	}
+	for table, t := range spec.Tables {
+		for column, g := range t.Columns {
+			if !isKnownGenerator(g) {
+				return spec, errors.Errorf("Unknown generator %q for column %s.%s", g, table, column)
+			}
+		}
+	}
+	return spec, nil
+}
+
+func (s Spec) rowsFor(table string, defaultRows uint) uint {
+	if t, ok := s.Tables[table]; ok && t.Rows != nil {
+		return *t.Rows
+	}
+	if s.Rows != nil {
+		return *s.Rows
+	}
+	return defaultRows
+}
+
+// Spec takes precedence over column comments.
+func (s Spec) generatorFor(table string, col Column) string {
+	if t, ok := s.Tables[table]; ok {
+		if g, ok := t.Columns[col.Name]; ok {
+			return g
+		}
+	}
+	if matches := commentPattern.FindStringSubmatch(col.Comment); len(matches) > 1 && isKnownGenerator(matches[1]) {
+		return matches[1]
+	}
+	return ""
+}
+
+func isKnownGenerator(name string) bool {
+	if name == skipGenerator {
+		return true
+	}
+	if _, ok := textGenerators[name]; ok {
+		return true
+	}
+	_, ok := rawGenerators[name]
+	return ok
+}
@@ -0,0 +1,37 @@
+-- Lists columns of user tables, with the single column constraints needed to generate
+-- valid rows: uniqueness, enum labels, and foreign key references.
+SELECT
+  n.nspname,
+  c.relname,
+  a.attname,
+  t.typname,
+  CASE WHEN t.typname IN ('varchar', 'bpchar') AND a.atttypmod > 4 THEN a.atttypmod - 4 ELSE 0 END,
+  NOT a.attnotnull,
+  a.atthasdef OR a.attidentity <> '',
+  a.attgenerated <> '' OR a.attidentity = 'a',
+  EXISTS (
+    SELECT 1 FROM pg_constraint k
+    WHERE k.conrelid = c.oid AND k.contype IN ('p', 'u') AND k.conkey = ARRAY[a.attnum]
+  ),
+  coalesce(col_description(c.oid, a.attnum), ''),
+  coalesce((
+    SELECT json_agg(e.enumlabel ORDER BY e.enumsortorder) FROM pg_enum e WHERE e.enumtypid = t.oid
+  )::text, '[]'),
+  coalesce(fk.ref_schema, ''),
+  coalesce(fk.ref_table, ''),
+  coalesce(fk.ref_column, '')
+FROM pg_class c
+JOIN pg_namespace n ON n.oid = c.relnamespace
+JOIN pg_attribute a ON a.attrelid = c.oid AND a.attnum > 0 AND NOT a.attisdropped
+JOIN pg_type t ON t.oid = a.atttypid
+LEFT JOIN LATERAL (
+  SELECT fn.nspname AS ref_schema, fc.relname AS ref_table, fa.attname AS ref_column
+  FROM pg_constraint k
+  JOIN pg_class fc ON fc.oid = k.confrelid
+  JOIN pg_namespace fn ON fn.oid = fc.relnamespace
+  JOIN pg_attribute fa ON fa.attrelid = k.confrelid AND fa.attnum = k.confkey[1]
+  WHERE k.conrelid = c.oid AND k.contype = 'f' AND k.conkey = ARRAY[a.attnum]
+  LIMIT 1
+) fk ON true
+WHERE c.relkind IN ('r', 'p') AND NOT c.relispartition AND n.nspname = ANY($1)
+ORDER BY n.nspname, c.relname, a.attnum
@@ -0,0 +1,147 @@
+package shell
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/tools"
+	"github.com/supabase/cli/internal/utils"
+	"golang.org/x/term"
+)
+
+// Used by unit tests
+var lookPath = exec.LookPath
+
+func Run(ctx context.Context, command, file string, config pgconn.Config, fsys afero.Fs) error {
+	if len(file) > 0 && !filepath.IsAbs(file) {
+		file = filepath.Join(utils.CurrentDirAbs, file)
+	}
+	// Prefer psql installed on host because it has access to user's psqlrc and history
+	psql, err := lookPath("psql")
+	if err != nil {
+		psql, err = tools.Find("psql", fsys)
+	}
+	if err == nil {
+		args := psqlArgs(utils.ToPostgresURL(config), command, file)
+		cmd := exec.CommandContext(ctx, psql, args...)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return errors.Errorf("failed to run psql: %w", err)
+		}
+		return nil
+	}
+	fmt.Fprintln(utils.GetDebugLogger(), "psql not found on PATH, falling back to database container.")
+	// Fallback to the psql bundled in local database container
+	if err := utils.AssertSupabaseDbIsRunning(); err != nil {
+		utils.CmdSuggestion = fmt.Sprintf("Install psql or run %s to use the bundled client.", utils.Aqua("supabase db start"))
+		return err
+	}
+	if utils.IsLocalDatabase(config) {
+		config.Host = "127.0.0.1"
+		config.Port = 5432
+	}
+	var stdin io.Reader = os.Stdin
+	if len(file) > 0 {
+		// Host files are not visible to the container, so we stream them through stdin
+		sql, err := afero.ReadFile(fsys, file)
+		if err != nil {
+			return errors.Errorf("failed to read sql file: %w", err)
+		}
+		stdin = bytes.NewReader(sql)
+		file = "-"
+	}
+	cmd := append([]string{"psql"}, psqlArgs(utils.ToPostgresURL(config), command, file)...)
+	tty := len(command) == 0 && len(file) == 0 && term.IsTerminal(int(os.Stdin.Fd()))
+	return execAttach(ctx, utils.DbId, cmd, tty, stdin, os.Stdout, os.Stderr)
+}
+
+func psqlArgs(dbUrl, command, file string) []string {
+	args := []string{dbUrl}
+	if len(command) > 0 {
+		args = append(args, "--command", command)
+	}
+	if len(file) > 0 {
+		args = append(args, "--file", file)
+	}
+	// Stop on first error when running non-interactively
+	if len(command) > 0 || len(file) > 0 {
+		args = append(args, "--set", "ON_ERROR_STOP=1")
+	}
+	return args
+}
+
+func execAttach(ctx context.Context, containerId string, cmd []string, tty bool, stdin io.Reader, stdout, stderr io.Writer) error {
+	created, err := utils.Docker.ContainerExecCreate(ctx, containerId, types.ExecConfig{
+		Cmd:          cmd,
+		Tty:          tty,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return errors.Errorf("failed to exec docker create: %w", err)
+	}
+	resp, err := utils.Docker.ContainerExecAttach(ctx, created.ID, types.ExecStartCheck{Tty: tty})
+	if err != nil {
+		return errors.Errorf("failed to exec docker attach: %w", err)
+	}
+	defer resp.Close()
+	if tty {
+		fd := int(os.Stdin.Fd())
+		if width, height, err := term.GetSize(fd); err == nil {
+			if err := utils.Docker.ContainerExecResize(ctx, created.ID, container.ResizeOptions{
+				Height: uint(height),
+				Width:  uint(width),
+			}); err != nil {
+				fmt.Fprintln(utils.GetDebugLogger(), err)
+			}
+		}
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			return errors.Errorf("failed to set raw terminal: %w", err)
+		}
+		defer func() {
+			if err := term.Restore(fd, state); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+		}()
+	}
+	go func() {
+		if _, err := io.Copy(resp.Conn, stdin); err != nil {
+			fmt.Fprintln(utils.GetDebugLogger(), err)
+		}
+		if err := resp.CloseWrite(); err != nil {
+			fmt.Fprintln(utils.GetDebugLogger(), err)
+		}
+	}()
+	// Raw stream is not multiplexed when tty is allocated
+	if tty {
+		_, err = io.Copy(stdout, resp.Reader)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, resp.Reader)
+	}
+	if err != nil {
+		return errors.Errorf("failed to copy docker output: %w", err)
+	}
+	iresp, err := utils.Docker.ContainerExecInspect(ctx, created.ID)
+	if err != nil {
+		return errors.Errorf("failed to exec docker inspect: %w", err)
+	}
+	if iresp.ExitCode > 0 {
+		return errors.Errorf("psql exited with code %d", iresp.ExitCode)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package shell
+
+import (
+	"context"
+	"net/http"
+	"os/exec"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestPsqlArgs(t *testing.T) {
+	t.Run("starts interactive session", func(t *testing.T) {
+		args := psqlArgs("postgresql://localhost", "", "")
+		assert.Equal(t, []string{"postgresql://localhost"}, args)
+	})
+
+	t.Run("runs single command", func(t *testing.T) {
+		args := psqlArgs("postgresql://localhost", "select 1", "")
+		assert.Equal(t, []string{
+			"postgresql://localhost",
+			"--command", "select 1",
+			"--set", "ON_ERROR_STOP=1",
+		}, args)
+	})
+
+	t.Run("runs sql file", func(t *testing.T) {
+		args := psqlArgs("postgresql://localhost", "", "-")
+		assert.Equal(t, []string{
+			"postgresql://localhost",
+			"--file", "-",
+			"--set", "ON_ERROR_STOP=1",
+		}, args)
+	})
+}
+
+func TestShellFallback(t *testing.T) {
+	lookPath = func(file string) (string, error) {
+		return "", exec.ErrNotFound
+	}
+	t.Cleanup(func() { lookPath = exec.LookPath })
+
+	t.Run("throws error if database is not running", func(t *testing.T) {
+		utils.DbId = "test-shell"
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/" + utils.DbId + "/json").
+			Reply(http.StatusNotFound)
+		// Run test
+		err := Run(context.Background(), "select 1", "", dbConfig, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorIs(t, err, utils.ErrNotRunning)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing file", func(t *testing.T) {
+		utils.DbId = "test-shell"
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/" + utils.DbId + "/json").
+			Reply(http.StatusOK).
+			JSON(map[string]any{})
+		// Run test
+		err := Run(context.Background(), "", "/tmp/missing.sql", dbConfig, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "failed to read sql file:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
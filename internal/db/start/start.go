@@ -21,6 +21,7 @@ import (
 	"github.com/spf13/afero"
 	"github.com/supabase/cli/internal/db/push"
 	"github.com/supabase/cli/internal/migration/apply"
+	"github.com/supabase/cli/internal/migration/capture"
 	"github.com/supabase/cli/internal/status"
 	"github.com/supabase/cli/internal/utils"
 )
@@ -81,7 +82,7 @@ func NewContainerConfig() container.Config {
 			Timeout:  2 * time.Second,
 			Retries:  3,
 		},
-		Entrypoint: []string{"sh", "-c", `cat <<'EOF' > /etc/postgresql.schema.sql && cat <<'EOF' > /etc/postgresql-custom/pgsodium_root.key && docker-entrypoint.sh postgres -D /etc/postgresql
+		Entrypoint: []string{"sh", "-c", preloadPrefix() + `cat <<'EOF' > /etc/postgresql.schema.sql && cat <<'EOF' > /etc/postgresql-custom/pgsodium_root.key && ` + postgresCommand() + `
 ` + initialSchema + `
 EOF
 ` + utils.Config.Db.RootKey + `
@@ -95,14 +96,46 @@ EOF
 			"-c", `search_path="$user",public,extensions`,
 		}
 	}
+	// Entrypoint of PG15 ignores cmd, so custom parameters are added to its postgres command instead
+	if args := utils.Config.Db.Settings.ParameterArgs(); len(args) > 0 && utils.Config.Db.MajorVersion <= 14 {
+		if len(config.Cmd) == 0 {
+			config.Cmd = []string{"postgres"}
+		}
+		config.Cmd = append(config.Cmd, args...)
+	}
 	return config
 }
 
+// Reads the libraries preloaded by the image, so that extra libraries are appended instead of
+// replacing those required by Supabase services.
+func preloadPrefix() string {
+	if len(utils.Config.Db.Settings.SharedPreloadLibraries) == 0 {
+		return ""
+	}
+	return `PRELOAD=$(sed -n "s/^shared_preload_libraries *= *'\([^']*\)'.*/\1/p" /etc/postgresql/postgresql.conf) && `
+}
+
+func postgresCommand() string {
+	cmd := "docker-entrypoint.sh postgres -D /etc/postgresql"
+	if libs := utils.Config.Db.Settings.SharedPreloadLibraries; len(libs) > 0 {
+		cmd += ` -c "shared_preload_libraries=${PRELOAD:+$PRELOAD, }` + strings.Join(libs, ", ") + `"`
+	}
+	for _, arg := range utils.Config.Db.Settings.ParameterArgs() {
+		cmd += " " + shellQuote(arg)
+	}
+	return cmd
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
 func NewHostConfig() container.HostConfig {
 	hostPort := strconv.FormatUint(uint64(utils.Config.Db.Port), 10)
 	hostConfig := WithSyslogConfig(container.HostConfig{
 		PortBindings:  nat.PortMap{"5432/tcp": []nat.PortBinding{{HostPort: hostPort}}},
 		RestartPolicy: container.RestartPolicy{Name: "always"},
+		Resources:     utils.Config.Db.Resources.ToContainerResources(),
 		Binds: []string{
 			utils.DbId + ":/var/lib/postgresql/data",
 			utils.ConfigId + ":/etc/postgresql-custom",
@@ -165,7 +198,10 @@ func WaitForHealthyService(ctx context.Context, timeout time.Duration, started .
 		backoff.NewConstantBackOff(time.Second),
 		uint64(timeout.Seconds()),
 	), ctx)
+	span := utils.StartSpan("docker", "wait for healthy")
+	span.SetAttr("containers", strings.Join(started, ","))
 	err := backoff.Retry(probe, policy)
+	span.Finish(err)
 	if err != nil && !errors.Is(err, context.Canceled) {
 		// Print container logs for easier debugging
 		for _, containerId := range started {
@@ -281,12 +317,38 @@ func setupDatabase(ctx context.Context, fsys afero.Fs, w io.Writer, options ...f
 	if err := SetupDatabase(ctx, conn, utils.DbId, w, fsys); err != nil {
 		return err
 	}
-	return apply.MigrateAndSeed(ctx, "", conn, fsys)
+	if err := apply.MigrateAndSeed(ctx, "", conn, fsys); err != nil {
+		return err
+	}
+	return capture.Setup(ctx, conn)
 }
 
 func SetupDatabase(ctx context.Context, conn *pgx.Conn, host string, w io.Writer, fsys afero.Fs) error {
 	if err := initSchema(ctx, conn, host, w); err != nil {
 		return err
 	}
-	return push.CreateCustomRoles(ctx, conn, os.Stderr, fsys)
+	if err := push.CreateCustomRoles(ctx, conn, os.Stderr, fsys); err != nil {
+		return err
+	}
+	return runInitScripts(ctx, conn, w, fsys)
+}
+
+// Init scripts run after custom roles, so they may grant privileges to those roles.
+func runInitScripts(ctx context.Context, conn *pgx.Conn, w io.Writer, fsys afero.Fs) error {
+	for _, path := range utils.Config.Db.Settings.InitScripts {
+		if err := runInitScript(ctx, conn, path, w, fsys); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runInitScript(ctx context.Context, conn *pgx.Conn, path string, w io.Writer, fsys afero.Fs) error {
+	sql, err := fsys.Open(path)
+	if err != nil {
+		return errors.Errorf("failed to open init script: %w", err)
+	}
+	defer sql.Close()
+	fmt.Fprintln(w, "Running init script "+utils.Bold(path)+"...")
+	return apply.BatchExecDDL(ctx, conn, sql)
 }
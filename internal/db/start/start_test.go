@@ -234,6 +234,38 @@ func TestStartCommand(t *testing.T) {
 	})
 }
 
+func TestNewContainerConfig(t *testing.T) {
+	utils.Config.Db.Settings.SharedPreloadLibraries = []string{"pg_partman_bgw"}
+	utils.Config.Db.Settings.Parameters = map[string]string{
+		"pg_partman_bgw.role": "postgres",
+		"app.greeting":        "it's ok",
+	}
+	t.Cleanup(func() {
+		utils.Config.Db.Settings.SharedPreloadLibraries = nil
+		utils.Config.Db.Settings.Parameters = nil
+		utils.Config.Db.MajorVersion = 15
+	})
+
+	t.Run("appends settings to postgres command", func(t *testing.T) {
+		utils.Config.Db.MajorVersion = 15
+		// Run test
+		config := NewContainerConfig()
+		// Check output
+		script := config.Entrypoint[2]
+		assert.Contains(t, script, "PRELOAD=$(sed -n ")
+		assert.Contains(t, script, `docker-entrypoint.sh postgres -D /etc/postgresql -c "shared_preload_libraries=${PRELOAD:+$PRELOAD, }pg_partman_bgw" '-c' 'app.greeting=it'\''s ok' '-c' 'pg_partman_bgw.role=postgres'
+`)
+	})
+
+	t.Run("appends parameters to cmd on 14", func(t *testing.T) {
+		utils.Config.Db.MajorVersion = 14
+		// Run test
+		config := NewContainerConfig()
+		// Check output
+		assert.Equal(t, []string{"-c", "app.greeting=it's ok", "-c", "pg_partman_bgw.role=postgres"}, []string(config.Cmd[5:]))
+	})
+}
+
 func TestSetupDatabase(t *testing.T) {
 	utils.Config.Db.MajorVersion = 15
 
@@ -265,6 +297,58 @@ func TestSetupDatabase(t *testing.T) {
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
 
+	t.Run("runs init scripts", func(t *testing.T) {
+		utils.Config.Db.MajorVersion = 14
+		utils.Config.Db.Settings.InitScripts = []string{"supabase/init/cron.sql"}
+		defer func() {
+			utils.Config.Db.MajorVersion = 15
+			utils.Config.Db.Settings.InitScripts = nil
+		}()
+		utils.Config.Db.Port = 5432
+		utils.GlobalsSql = "create schema public"
+		utils.InitialSchemaSql = "create schema private"
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		script := "create extension pg_cron"
+		require.NoError(t, afero.WriteFile(fsys, "supabase/init/cron.sql", []byte(script), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(utils.GlobalsSql).
+			Reply("CREATE SCHEMA").
+			Query(utils.InitialSchemaSql).
+			Reply("CREATE SCHEMA").
+			Query(script).
+			Reply("CREATE EXTENSION")
+		// Run test
+		err := setupDatabase(context.Background(), fsys, io.Discard, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on missing init script", func(t *testing.T) {
+		utils.Config.Db.MajorVersion = 14
+		utils.Config.Db.Settings.InitScripts = []string{"supabase/init/missing.sql"}
+		defer func() {
+			utils.Config.Db.MajorVersion = 15
+			utils.Config.Db.Settings.InitScripts = nil
+		}()
+		utils.Config.Db.Port = 5432
+		utils.GlobalsSql = "create schema public"
+		utils.InitialSchemaSql = "create schema private"
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(utils.GlobalsSql).
+			Reply("CREATE SCHEMA").
+			Query(utils.InitialSchemaSql).
+			Reply("CREATE SCHEMA")
+		// Run test
+		err := setupDatabase(context.Background(), afero.NewMemMapFs(), io.Discard, conn.Intercept)
+		// Check error
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
 	t.Run("throws error on connect failure", func(t *testing.T) {
 		utils.Config.Db.Port = 0
 		// Run test
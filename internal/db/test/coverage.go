@@ -0,0 +1,129 @@
+package test
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/reset"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/pgxv5"
+)
+
+//go:embed coverage.sql
+var CoverageQuery string
+
+type CoverageObject struct {
+	Schema string
+	Table  string
+	// Empty for the table itself
+	Policy string
+}
+
+type tableCoverage struct {
+	schema   string
+	table    string
+	tests    int
+	policies int
+	tested   int
+}
+
+// PrintCoverage summarises which user tables and RLS policies are referenced by tests.
+// A table is covered when its name appears in a test file, and a policy when its name does.
+func PrintCoverage(ctx context.Context, conn *pgx.Conn, testFiles []string, fsys afero.Fs) error {
+	tests, err := readTests(testFiles, fsys)
+	if err != nil {
+		return err
+	}
+	rows, err := conn.Query(ctx, CoverageQuery, reset.LikeEscapeSchema(utils.InternalSchemas))
+	if err != nil {
+		return errors.Errorf("failed to query coverage: %w", err)
+	}
+	objects, err := pgxv5.CollectRows[CoverageObject](rows)
+	if err != nil {
+		return err
+	}
+	result := computeCoverage(objects, tests)
+	table := "|Schema|Table|Tests|Policies Tested|\n|-|-|-|-|\n"
+	var tablesCovered, policies, policiesCovered int
+	for _, r := range result {
+		table += fmt.Sprintf("|`%s`|`%s`|`%d`|`%d/%d`|\n", r.schema, r.table, r.tests, r.tested, r.policies)
+		if r.tests > 0 {
+			tablesCovered++
+		}
+		policies += r.policies
+		policiesCovered += r.tested
+	}
+	if err := list.RenderTable(table); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "%d of %d tables and %d of %d policies are referenced by tests.\n", tablesCovered, len(result), policiesCovered, policies)
+	return nil
+}
+
+func computeCoverage(objects []CoverageObject, tests []string) []tableCoverage {
+	var result []tableCoverage
+	index := map[string]int{}
+	for _, o := range objects {
+		key := o.Schema + "." + o.Table
+		i, ok := index[key]
+		if !ok {
+			i = len(result)
+			index[key] = i
+			result = append(result, tableCoverage{schema: o.Schema, table: o.Table})
+		}
+		if len(o.Policy) == 0 {
+			pattern := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(o.Table) + `\b`)
+			for _, t := range tests {
+				if pattern.MatchString(t) {
+					result[i].tests++
+				}
+			}
+			continue
+		}
+		result[i].policies++
+		for _, t := range tests {
+			if strings.Contains(t, o.Policy) {
+				result[i].tested++
+				break
+			}
+		}
+	}
+	return result
+}
+
+// Reads test files to be run by pg_prove, defaulting to all tests in the tests directory.
+func readTests(testFiles []string, fsys afero.Fs) ([]string, error) {
+	if len(testFiles) == 0 {
+		testFiles = []string{utils.DbTestsDir}
+	}
+	var result []string
+	for _, root := range testFiles {
+		if err := afero.Walk(fsys, root, func(path string, info fs.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if ext := filepath.Ext(path); info.IsDir() || (ext != ".sql" && ext != ".pg") {
+				return nil
+			}
+			contents, err := afero.ReadFile(fsys, path)
+			if err != nil {
+				return err
+			}
+			result = append(result, string(contents))
+			return nil
+		}); err != nil {
+			return nil, errors.Errorf("failed to read tests: %w", err)
+		}
+	}
+	return result, nil
+}
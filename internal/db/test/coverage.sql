@@ -0,0 +1,17 @@
+SELECT
+  n.nspname AS schema,
+  c.relname AS table,
+  '' AS policy
+FROM pg_class c
+JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE c.relkind IN ('r', 'p')
+  AND NOT c.relispartition
+  AND NOT n.nspname LIKE ANY($1)
+UNION ALL
+SELECT
+  p.schemaname AS schema,
+  p.tablename AS table,
+  p.policyname AS policy
+FROM pg_policies p
+WHERE NOT p.schemaname LIKE ANY($1)
+ORDER BY schema, "table", policy
@@ -23,7 +23,7 @@ const (
 	DISABLE_PGTAP = "drop extension if exists pgtap"
 )
 
-func Run(ctx context.Context, testFiles []string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+func Run(ctx context.Context, testFiles []string, config pgconn.Config, coverage bool, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
 	// Build test command
 	cmd := []string{"pg_prove", "--ext", ".pg", "--ext", ".sql", "-r"}
 	for _, fp := range testFiles {
@@ -74,7 +74,7 @@ func Run(ctx context.Context, testFiles []string, config pgconn.Config, fsys afe
 		hostConfig.NetworkMode = network.NetworkHost
 	}
 	// Run pg_prove on volume mount
-	return utils.DockerRunOnceWithConfig(
+	if err := utils.DockerRunOnceWithConfig(
 		ctx,
 		container.Config{
 			Image: utils.PgProveImage,
@@ -93,5 +93,8 @@ func Run(ctx context.Context, testFiles []string, config pgconn.Config, fsys afe
 		"",
 		os.Stdout,
 		os.Stderr,
-	)
+	); err != nil || !coverage {
+		return err
+	}
+	return PrintCoverage(ctx, conn, testFiles, fsys)
 }
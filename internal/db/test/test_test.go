@@ -3,6 +3,7 @@ package test
 import (
 	"context"
 	"errors"
+	"path/filepath"
 	"testing"
 
 	"github.com/h2non/gock"
@@ -11,6 +12,7 @@ import (
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/db/reset"
 	"github.com/supabase/cli/internal/testing/apitest"
 	"github.com/supabase/cli/internal/testing/pgtest"
 	"github.com/supabase/cli/internal/utils"
@@ -43,7 +45,7 @@ func TestRunCommand(t *testing.T) {
 		apitest.MockDockerStart(utils.Docker, utils.GetRegistryImageUrl(utils.PgProveImage), containerId)
 		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "Result: SUCCESS"))
 		// Run test
-		err := Run(context.Background(), []string{"nested"}, dbConfig, fsys, conn.Intercept)
+		err := Run(context.Background(), []string{"nested"}, dbConfig, false, fsys, conn.Intercept)
 		// Check error
 		assert.NoError(t, err)
 	})
@@ -53,7 +55,7 @@ func TestRunCommand(t *testing.T) {
 		fsys := afero.NewMemMapFs()
 		require.NoError(t, utils.WriteConfig(fsys, false))
 		// Run test
-		err := Run(context.Background(), nil, dbConfig, fsys)
+		err := Run(context.Background(), nil, dbConfig, false, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "failed to connect to postgres")
 	})
@@ -68,7 +70,7 @@ func TestRunCommand(t *testing.T) {
 		conn.Query(ENABLE_PGTAP).
 			ReplyError(pgerrcode.DuplicateObject, `extension "pgtap" already exists, skipping`)
 		// Run test
-		err := Run(context.Background(), nil, dbConfig, fsys, conn.Intercept)
+		err := Run(context.Background(), nil, dbConfig, false, fsys, conn.Intercept)
 		// Check error
 		assert.ErrorContains(t, err, "failed to enable pgTAP")
 	})
@@ -92,9 +94,61 @@ func TestRunCommand(t *testing.T) {
 			Get("/v" + utils.Docker.ClientVersion() + "/images/" + utils.GetRegistryImageUrl(utils.PgProveImage) + "/json").
 			ReplyError(errNetwork)
 		// Run test
-		err := Run(context.Background(), nil, dbConfig, fsys, conn.Intercept)
+		err := Run(context.Background(), nil, dbConfig, false, fsys, conn.Intercept)
 		// Check error
 		assert.ErrorIs(t, err, errNetwork)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
 }
+
+func TestCoverage(t *testing.T) {
+	t.Run("prints coverage after tests", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		path := filepath.Join(utils.DbTestsDir, "profiles_test.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(`SELECT has_table('public', 'profiles');`), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(ENABLE_PGTAP).
+			Reply("CREATE EXTENSION").
+			Query(CoverageQuery, reset.LikeEscapeSchema(utils.InternalSchemas)).
+			Reply("SELECT 2",
+				CoverageObject{Schema: "public", Table: "profiles"},
+				CoverageObject{Schema: "public", Table: "profiles", Policy: "Users can view own profile"},
+			).
+			Query(DISABLE_PGTAP).
+			Reply("DROP EXTENSION")
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		containerId := "test-pg-prove"
+		apitest.MockDockerStart(utils.Docker, utils.GetRegistryImageUrl(utils.PgProveImage), containerId)
+		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "Result: SUCCESS"))
+		// Run test
+		err := Run(context.Background(), nil, dbConfig, true, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+}
+
+func TestComputeCoverage(t *testing.T) {
+	objects := []CoverageObject{
+		{Schema: "public", Table: "profiles"},
+		{Schema: "public", Table: "profiles", Policy: "Users can view own profile"},
+		{Schema: "public", Table: "profiles", Policy: "Users can update own profile"},
+		{Schema: "public", Table: "profile_views"},
+	}
+	tests := []string{
+		`SELECT has_table('public', 'profiles');`,
+		`SELECT policies_are('public', 'profiles', ARRAY['Users can view own profile']);`,
+	}
+	// Run test
+	result := computeCoverage(objects, tests)
+	// Check output
+	assert.Equal(t, []tableCoverage{
+		{schema: "public", table: "profiles", tests: 2, policies: 2, tested: 1},
+		{schema: "public", table: "profile_views"},
+	}, result)
+}
@@ -0,0 +1,121 @@
+package url
+
+import (
+	"context"
+	"fmt"
+	"io"
+	neturl "net/url"
+	"os"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+type RunParams struct {
+	// One of session or transaction. Empty connects to the database directly.
+	PoolMode string
+	Role     string
+	EnvName  string
+	Copy     bool
+}
+
+// Used by unit tests
+var writeClipboard = clipboard.WriteAll
+
+// Run prints the connection string of the local or linked database, selecting either a
+// direct connection or the pooler in the requested mode.
+func Run(ctx context.Context, config pgconn.Config, params RunParams, stdout io.Writer, fsys afero.Fs) error {
+	var err error
+	if utils.IsLocalDatabase(config) {
+		config, err = localConfig(config, params.PoolMode)
+	} else {
+		config, err = linkedConfig(config, params.PoolMode)
+	}
+	if err != nil {
+		return err
+	}
+	dbUrl := toUrl(config, params.Role)
+	if len(params.EnvName) > 0 {
+		dbUrl = fmt.Sprintf("%s='%s'", params.EnvName, dbUrl)
+	}
+	if params.Copy {
+		if err := writeClipboard(dbUrl); err != nil {
+			return errors.Errorf("failed to copy to clipboard: %w", err)
+		}
+		fmt.Fprintln(os.Stderr, "Copied connection string to clipboard.")
+		return nil
+	}
+	fmt.Fprintln(stdout, dbUrl)
+	return nil
+}
+
+func localConfig(config pgconn.Config, poolMode string) (pgconn.Config, error) {
+	if len(poolMode) == 0 {
+		return config, nil
+	}
+	pooler := utils.Config.Db.Pooler
+	if !pooler.Enabled {
+		return config, errors.New("Local pooler is not enabled. Set enabled = true under [db.pooler] in config.toml.")
+	}
+	// Local pooler only exposes the port of its configured mode
+	if poolMode != string(pooler.PoolMode) {
+		return config, errors.Errorf("Local pooler runs in %s mode. Update pool_mode under [db.pooler] in config.toml to use %s mode.", pooler.PoolMode, poolMode)
+	}
+	config.Port = pooler.Port
+	config.User = config.User + "." + pooler.TenantId
+	return config, nil
+}
+
+func linkedConfig(config pgconn.Config, poolMode string) (pgconn.Config, error) {
+	// Pooler usernames are suffixed with project ref, ie. postgres.<ref>
+	user, ref, isPooler := strings.Cut(config.User, ".")
+	if len(ref) == 0 {
+		ref = flags.ProjectRef
+	}
+	if len(poolMode) == 0 {
+		if isPooler {
+			config.Host = utils.GetSupabaseDbHost(ref)
+			config.Port = 5432
+			config.User = user
+		}
+		return config, nil
+	}
+	if !isPooler {
+		poolerConfig := utils.GetPoolerConfig(ref)
+		if poolerConfig == nil {
+			return config, errors.Errorf("Pooler URL is not found. Have you run %s?", utils.Aqua("supabase link"))
+		}
+		config.Host = poolerConfig.Host
+		config.User = config.User + "." + ref
+	}
+	config.Port = utils.PoolerSessionPort
+	if poolMode == string(utils.TransactionMode) {
+		config.Port = utils.PoolerTransactionPort
+	}
+	return config, nil
+}
+
+func toUrl(config pgconn.Config, role string) string {
+	// Login roles assume a different role via runtime params, which libpq does not accept
+	// in connection strings, so it is passed in options instead.
+	params := make(map[string]string, len(config.RuntimeParams))
+	for k, v := range config.RuntimeParams {
+		params[k] = v
+	}
+	if len(role) == 0 {
+		role = params["role"]
+	}
+	delete(params, "role")
+	config.RuntimeParams = params
+	dbUrl := utils.ToPostgresURL(config)
+	if user, _, _ := strings.Cut(config.User, "."); len(role) > 0 && role != user {
+		options := strings.ReplaceAll(neturl.QueryEscape("-c role="+role), "+", "%20")
+		dbUrl += "&options=" + options
+	}
+	return dbUrl
+}
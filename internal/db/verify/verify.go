@@ -0,0 +1,238 @@
+package verify
+
+import (
+	"context"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/diff"
+	"github.com/supabase/cli/internal/db/parity"
+	"github.com/supabase/cli/internal/db/reset"
+	"github.com/supabase/cli/internal/db/start"
+	"github.com/supabase/cli/internal/migration/apply"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var (
+	//go:embed templates/schema.sql
+	schemaQuery string
+
+	ErrDrift = errors.New("Remote schema does not match the schema produced by migrations.")
+)
+
+type SchemaObject struct {
+	Name       string
+	Definition string
+}
+
+// Run compares the schema of the remote database against the schema produced by replaying
+// its applied migrations on a shadow database, failing if they diverge. With checkFunctions,
+// function definitions are compared first so that edited functions are reported on their own.
+func Run(ctx context.Context, schema []string, checkFunctions bool, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	if len(schema) == 0 {
+		if schema, err = reset.LoadUserSchemas(ctx, conn); err != nil {
+			return err
+		}
+	}
+	migrations, err := loadAppliedMigrations(ctx, conn, fsys)
+	if err != nil {
+		return err
+	}
+	actual, err := LoadSchemaObjects(ctx, conn, schema)
+	if err != nil {
+		return err
+	}
+	var functions []parity.Function
+	if checkFunctions {
+		if functions, err = parity.LoadFunctions(ctx, conn, schema); err != nil {
+			return err
+		}
+	}
+	var expected []SchemaObject
+	if err := withShadowDatabase(ctx, migrations, fsys, func(shadow *pgx.Conn) error {
+		if checkFunctions {
+			if err := compareFunctions(ctx, shadow, schema, functions); err != nil {
+				return err
+			}
+		}
+		objects, err := LoadSchemaObjects(ctx, shadow, schema)
+		expected = objects
+		return err
+	}, options...); err != nil {
+		return err
+	}
+	actualHash, expectedHash := Hash(actual), Hash(expected)
+	fmt.Println("Remote schema hash:", actualHash)
+	fmt.Println("Migrations schema hash:", expectedHash)
+	if actualHash == expectedHash {
+		fmt.Fprintln(os.Stderr, "Remote schema matches migrations.")
+		return nil
+	}
+	printDrift(os.Stderr, expected, actual)
+	return utils.WithCode(utils.CodeSchemaDrift, errors.New(ErrDrift))
+}
+
+// CheckFunctions compares only the functions in schema against those produced by the applied
+// migrations. Defaults to the user schemas exposed by the api when schema is empty.
+func CheckFunctions(ctx context.Context, schema []string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	if len(schema) == 0 {
+		schema = parity.GetExposedSchemas()
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	migrations, err := loadAppliedMigrations(ctx, conn, fsys)
+	if err != nil {
+		return err
+	}
+	actual, err := parity.LoadFunctions(ctx, conn, schema)
+	if err != nil {
+		return err
+	}
+	return withShadowDatabase(ctx, migrations, fsys, func(shadow *pgx.Conn) error {
+		return compareFunctions(ctx, shadow, schema, actual)
+	}, options...)
+}
+
+func compareFunctions(ctx context.Context, shadow *pgx.Conn, schema []string, actual []parity.Function) error {
+	expected, err := parity.LoadFunctions(ctx, shadow, schema)
+	if err != nil {
+		return err
+	}
+	if parity.PrintDrift(os.Stderr, expected, actual) {
+		return utils.WithCode(utils.CodeSchemaDrift, errors.New(parity.ErrDrift))
+	}
+	fmt.Fprintln(os.Stderr, "Remote functions match migrations.")
+	return nil
+}
+
+// Only migrations recorded in the remote history table are replayed, so that pending local
+// migrations are not reported as drift.
+func loadAppliedMigrations(ctx context.Context, conn *pgx.Conn, fsys afero.Fs) ([]string, error) {
+	remote, err := list.LoadRemoteMigrations(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	local, err := list.LoadLocalMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+	var applied, pending []string
+	for _, filename := range local {
+		version := utils.MatchMigrationFile(filename)[1]
+		if utils.SliceContains(remote, version) {
+			applied = append(applied, filename)
+		} else {
+			pending = append(pending, filename)
+		}
+	}
+	if len(applied) < len(remote) {
+		return nil, utils.WithCode(utils.CodeMigrationDrift, errors.New("Remote migration versions not found in "+utils.MigrationsDir+" directory."))
+	}
+	if len(pending) > 0 {
+		fmt.Fprintf(os.Stderr, "Skipping %d local migrations not yet applied to the remote database.\n", len(pending))
+	}
+	return applied, nil
+}
+
+func withShadowDatabase(ctx context.Context, migrations []string, fsys afero.Fs, fn func(*pgx.Conn) error, options ...func(*pgx.ConnConfig)) error {
+	fmt.Fprintln(os.Stderr, "Creating shadow database...")
+	shadow, err := diff.CreateShadowDatabase(ctx, utils.Config.Db.ShadowPort)
+	if err != nil {
+		return err
+	}
+	defer utils.DockerRemove(shadow)
+	if err := start.WaitForHealthyService(ctx, start.HealthTimeout, shadow); err != nil {
+		return err
+	}
+	conn, err := diff.ConnectShadowDatabase(ctx, 10*time.Second, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	if err := start.SetupDatabase(ctx, conn, shadow[:12], os.Stderr, fsys); err != nil {
+		return err
+	}
+	if err := apply.MigrateUp(ctx, conn, list.SkipDataMigrations(migrations), fsys); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+// LoadSchemaObjects lists the definition of each table, column, constraint, index, function,
+// trigger, policy, and enum in schema, ordered by name.
+func LoadSchemaObjects(ctx context.Context, conn *pgx.Conn, schema []string) ([]SchemaObject, error) {
+	rows, err := conn.Query(ctx, schemaQuery, schema)
+	if err != nil {
+		return nil, errors.Errorf("failed to query schema: %w", err)
+	}
+	defer rows.Close()
+	var result []SchemaObject
+	for rows.Next() {
+		var obj SchemaObject
+		if err := rows.Scan(&obj.Name, &obj.Definition); err != nil {
+			return nil, errors.Errorf("failed to scan schema: %w", err)
+		}
+		result = append(result, obj)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Errorf("failed to parse schema: %w", err)
+	}
+	return result, nil
+}
+
+// Hash returns a stable digest of schema objects, which must be ordered by name.
+func Hash(objects []SchemaObject) string {
+	h := sha256.New()
+	for _, obj := range objects {
+		h.Write([]byte(obj.Name))
+		h.Write([]byte{0})
+		h.Write([]byte(obj.Definition))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func printDrift(w io.Writer, expected, actual []SchemaObject) {
+	defs := make(map[string]string, len(expected))
+	for _, obj := range expected {
+		defs[obj.Name] = obj.Definition
+	}
+	fmt.Fprintln(w, "Found schema objects that differ from migrations:")
+	for _, obj := range actual {
+		if def, ok := defs[obj.Name]; !ok {
+			fmt.Fprintln(w, utils.Aqua(" + "+obj.Name))
+		} else if def != obj.Definition {
+			fmt.Fprintln(w, utils.Yellow(" ~ "+obj.Name))
+		}
+		delete(defs, obj.Name)
+	}
+	for _, obj := range expected {
+		if _, ok := defs[obj.Name]; ok {
+			fmt.Fprintln(w, utils.Red(" - "+obj.Name))
+		}
+	}
+}
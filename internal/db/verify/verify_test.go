@@ -0,0 +1,149 @@
+package verify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/db/parity"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/testing/pgtest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "db.supabase.co",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestVerifyCommand(t *testing.T) {
+	t.Run("throws error on missing config", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), []string{"public"}, false, dbConfig, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("throws error on missing local migration", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(list.LIST_MIGRATION_VERSION).
+			Reply("SELECT 1", []interface{}{"20240101000000"})
+		// Run test
+		err := Run(context.Background(), []string{"public"}, false, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "Remote migration versions not found in supabase/migrations directory.")
+		code, _ := utils.GetErrorCode(err)
+		assert.Equal(t, utils.CodeMigrationDrift, code)
+	})
+
+	t.Run("throws error on failure to create shadow", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		path := filepath.Join(utils.MigrationsDir, "20240101000000_test.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte("create table todos ()"), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(list.LIST_MIGRATION_VERSION).
+			Reply("SELECT 0")
+		conn.Query(schemaQuery, []string{"public"}).
+			Reply("SELECT 1", []interface{}{"table public.todos", "rls=false force_rls=false"})
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/images/" + utils.GetRegistryImageUrl(utils.Config.Db.Image) + "/json").
+			ReplyError(errors.New("network error"))
+		// Run test
+		err := Run(context.Background(), []string{"public"}, false, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "network error")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestCheckFunctions(t *testing.T) {
+	t.Run("throws error on failure to create shadow", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(list.LIST_MIGRATION_VERSION).
+			Reply("SELECT 0")
+		conn.Query(parity.FunctionsQuery, []string{"public"}).
+			Reply("SELECT 1", []interface{}{"public.greet()", "7d793037a0760186574b0282f2f435e7"})
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/images/" + utils.GetRegistryImageUrl(utils.Config.Db.Image) + "/json").
+			ReplyError(errors.New("network error"))
+		// Run test
+		err := CheckFunctions(context.Background(), nil, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "network error")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestHash(t *testing.T) {
+	objects := []SchemaObject{
+		{Name: "column public.todos.id", Definition: "bigint not null"},
+		{Name: "table public.todos", Definition: "rls=false force_rls=false"},
+	}
+
+	t.Run("matches identical schema", func(t *testing.T) {
+		clone := append([]SchemaObject{}, objects...)
+		assert.Equal(t, Hash(objects), Hash(clone))
+	})
+
+	t.Run("detects changed definition", func(t *testing.T) {
+		changed := append([]SchemaObject{}, objects...)
+		changed[1].Definition = "rls=true force_rls=false"
+		assert.NotEqual(t, Hash(objects), Hash(changed))
+	})
+
+	t.Run("separates name from definition", func(t *testing.T) {
+		a := []SchemaObject{{Name: "ab", Definition: "c"}}
+		b := []SchemaObject{{Name: "a", Definition: "bc"}}
+		assert.NotEqual(t, Hash(a), Hash(b))
+	})
+}
+
+func TestPrintDrift(t *testing.T) {
+	expected := []SchemaObject{
+		{Name: "column public.todos.id", Definition: "bigint not null"},
+		{Name: "table public.todos", Definition: "rls=true force_rls=false"},
+	}
+	actual := []SchemaObject{
+		{Name: "index public.todos_idx", Definition: "CREATE INDEX todos_idx ON public.todos USING btree (id)"},
+		{Name: "table public.todos", Definition: "rls=false force_rls=false"},
+	}
+	var out bytes.Buffer
+	// Run test
+	printDrift(&out, expected, actual)
+	// Check output
+	assert.Contains(t, out.String(), "+ index public.todos_idx")
+	assert.Contains(t, out.String(), "~ table public.todos")
+	assert.Contains(t, out.String(), "- column public.todos.id")
+}
@@ -0,0 +1,17 @@
+//go:build !windows
+
+package doctor
+
+import (
+	"syscall"
+
+	"github.com/go-errors/errors"
+)
+
+func getFreeDisk(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, errors.Errorf("failed to stat filesystem: %w", err)
+	}
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil
+}
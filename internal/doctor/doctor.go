@@ -0,0 +1,255 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+const (
+	StatusOk   = "ok"
+	StatusWarn = "warn"
+	StatusFail = "fail"
+	StatusSkip = "skip"
+)
+
+const (
+	minFreeDisk  = 2 * units.GiB
+	maxClockSkew = 30 * time.Second
+)
+
+type Check struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+}
+
+type Report struct {
+	Version string  `json:"version"`
+	OS      string  `json:"os"`
+	Arch    string  `json:"arch"`
+	Checks  []Check `json:"checks"`
+}
+
+func Run(ctx context.Context, format string, fsys afero.Fs) error {
+	report := Diagnose(ctx, fsys)
+	if format == utils.OutputPretty {
+		if err := list.RenderTable(report.toMarkdown()); err != nil {
+			return err
+		}
+	} else if err := utils.EncodeOutput(format, os.Stdout, report); err != nil {
+		return err
+	}
+	if failed := report.countFailures(); failed > 0 {
+		return errors.Errorf("Found %d failing checks.", failed)
+	}
+	return nil
+}
+
+// Diagnose runs all checks in order, continuing past failures so the report is always complete.
+func Diagnose(ctx context.Context, fsys afero.Fs) Report {
+	report := Report{
+		Version: utils.Version,
+		OS:      runtime.GOOS,
+		Arch:    runtime.GOARCH,
+	}
+	configCheck := checkConfig(fsys)
+	report.Checks = append(report.Checks, configCheck)
+	dockerCheck := checkDocker(ctx)
+	report.Checks = append(report.Checks, dockerCheck)
+	report.Checks = append(report.Checks, checkDisk(utils.CurrentDirAbs))
+	if configCheck.Status != StatusOk {
+		report.Checks = append(report.Checks, Check{Name: "ports", Status: StatusSkip, Detail: "config is not valid"})
+	} else if dockerCheck.Status == StatusOk && utils.AssertSupabaseDbIsRunning() == nil {
+		report.Checks = append(report.Checks, Check{Name: "ports", Status: StatusSkip, Detail: "local stack is running"})
+	} else {
+		report.Checks = append(report.Checks, checkPorts(utils.GetHostPorts()))
+	}
+	tokenCheck, serverTime := checkAccessToken(ctx, fsys)
+	report.Checks = append(report.Checks, tokenCheck)
+	report.Checks = append(report.Checks, checkLinkedProject(ctx, fsys))
+	report.Checks = append(report.Checks, checkClockSkew(serverTime, time.Now()))
+	return report
+}
+
+func checkConfig(fsys afero.Fs) Check {
+	result := Check{Name: "config"}
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		result.Status = StatusFail
+		result.Detail = err.Error()
+		return result
+	}
+	result.Status = StatusOk
+	result.Detail = utils.ConfigPath
+	return result
+}
+
+func checkDocker(ctx context.Context) Check {
+	result := Check{Name: "docker"}
+	version, err := utils.Docker.ServerVersion(ctx)
+	if err != nil {
+		result.Status = StatusFail
+		result.Detail = err.Error()
+		return result
+	}
+	result.Status = StatusOk
+	result.Detail = fmt.Sprintf("%s %s (API %s)", version.Platform.Name, version.Version, version.APIVersion)
+	return result
+}
+
+func checkDisk(path string) Check {
+	result := Check{Name: "disk"}
+	free, err := getFreeDisk(path)
+	if err != nil {
+		result.Status = StatusWarn
+		result.Detail = err.Error()
+		return result
+	}
+	result.Detail = units.BytesSize(float64(free)) + " free"
+	result.Status = StatusOk
+	if free < minFreeDisk {
+		result.Status = StatusWarn
+		result.Detail += fmt.Sprintf(", at least %s is recommended", units.BytesSize(minFreeDisk))
+	}
+	return result
+}
+
+func checkPorts(hostPorts map[string]*uint16) Check {
+	result := Check{Name: "ports"}
+	var conflicts []string
+	for name, port := range hostPorts {
+		if *port == 0 {
+			continue
+		}
+		l, err := net.Listen("tcp", fmt.Sprintf(":%d", *port))
+		if err != nil {
+			conflicts = append(conflicts, fmt.Sprintf("%d (%s)", *port, name))
+			continue
+		}
+		l.Close()
+	}
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		result.Status = StatusWarn
+		result.Detail = "in use: " + strings.Join(conflicts, ", ")
+		return result
+	}
+	result.Status = StatusOk
+	result.Detail = fmt.Sprintf("%d ports available", len(hostPorts))
+	return result
+}
+
+// Returns the server time reported by the Management API, used to detect clock skew.
+func checkAccessToken(ctx context.Context, fsys afero.Fs) (Check, time.Time) {
+	result := Check{Name: "access token"}
+	if _, err := utils.LoadAccessTokenFS(fsys); errors.Is(err, utils.ErrMissingToken) {
+		result.Status = StatusWarn
+		result.Detail = "not logged in"
+		return result, time.Time{}
+	} else if err != nil {
+		result.Status = StatusFail
+		result.Detail = err.Error()
+		return result, time.Time{}
+	}
+	resp, err := utils.GetSupabase().V1ListAllProjectsWithResponse(ctx)
+	if err != nil {
+		result.Status = StatusFail
+		result.Detail = err.Error()
+		return result, time.Time{}
+	}
+	serverTime, _ := http.ParseTime(resp.HTTPResponse.Header.Get("Date"))
+	if resp.JSON200 == nil {
+		result.Status = StatusFail
+		result.Detail = fmt.Sprintf("rejected by %s with status %d", utils.GetSupabaseAPIHost(), resp.StatusCode())
+		return result, serverTime
+	}
+	result.Status = StatusOk
+	result.Detail = fmt.Sprintf("%d projects accessible", len(*resp.JSON200))
+	return result, serverTime
+}
+
+func checkLinkedProject(ctx context.Context, fsys afero.Fs) Check {
+	result := Check{Name: "linked project"}
+	ref, err := flags.LoadProjectRef(fsys)
+	if errors.Is(err, utils.ErrNotLinked) {
+		result.Status = StatusSkip
+		result.Detail = "not linked"
+		return result
+	} else if err != nil {
+		result.Status = StatusFail
+		result.Detail = err.Error()
+		return result
+	}
+	url := "https://" + utils.GetSupabaseHost(ref) + "/rest/v1/"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		result.Status = StatusFail
+		result.Detail = err.Error()
+		return result
+	}
+	// Any response, including unauthorised, means the project is reachable
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		result.Status = StatusFail
+		result.Detail = fmt.Sprintf("%s is unreachable: %v", ref, err)
+		return result
+	}
+	defer resp.Body.Close()
+	result.Status = StatusOk
+	result.Detail = ref + " is reachable"
+	return result
+}
+
+func checkClockSkew(serverTime, localTime time.Time) Check {
+	result := Check{Name: "clock"}
+	if serverTime.IsZero() {
+		result.Status = StatusSkip
+		result.Detail = "server time unavailable"
+		return result
+	}
+	skew := localTime.Sub(serverTime).Round(time.Second)
+	if skew < 0 {
+		skew = -skew
+	}
+	result.Detail = fmt.Sprintf("%v skew from server", skew)
+	result.Status = StatusOk
+	if skew > maxClockSkew {
+		result.Status = StatusWarn
+		result.Detail += ", which may cause token validation errors"
+	}
+	return result
+}
+
+func (r Report) toMarkdown() string {
+	var table strings.Builder
+	fmt.Fprintf(&table, "|CHECK|STATUS|DETAIL|\n|-|-|-|\n")
+	for _, c := range r.Checks {
+		detail := strings.ReplaceAll(c.Detail, "|", `\|`)
+		fmt.Fprintf(&table, "|`%s`|`%s`|%s|\n", c.Name, c.Status, detail)
+	}
+	fmt.Fprintf(&table, "\nSupabase CLI %s on %s/%s\n", r.Version, r.OS, r.Arch)
+	return table.String()
+}
+
+func (r Report) countFailures() int {
+	var count int
+	for _, c := range r.Checks {
+		if c.Status == StatusFail {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,145 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestCheckConfig(t *testing.T) {
+	t.Run("passes on valid config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Run test
+		result := checkConfig(fsys)
+		// Check error
+		assert.Equal(t, StatusOk, result.Status)
+	})
+
+	t.Run("fails on missing config", func(t *testing.T) {
+		// Run test
+		result := checkConfig(afero.NewMemMapFs())
+		// Check error
+		assert.Equal(t, StatusFail, result.Status)
+		assert.Contains(t, result.Detail, "cannot read config")
+	})
+}
+
+func TestCheckPorts(t *testing.T) {
+	t.Run("warns on port conflict", func(t *testing.T) {
+		l, err := net.Listen("tcp", ":0")
+		require.NoError(t, err)
+		defer l.Close()
+		port := uint16(l.Addr().(*net.TCPAddr).Port)
+		// Run test
+		result := checkPorts(map[string]*uint16{"api.port": &port})
+		// Check error
+		assert.Equal(t, StatusWarn, result.Status)
+		assert.Equal(t, fmt.Sprintf("in use: %d (api.port)", port), result.Detail)
+	})
+
+	t.Run("ignores unset ports", func(t *testing.T) {
+		var port uint16
+		// Run test
+		result := checkPorts(map[string]*uint16{"db.pooler.port": &port})
+		// Check error
+		assert.Equal(t, StatusOk, result.Status)
+	})
+}
+
+func TestCheckAccessToken(t *testing.T) {
+	t.Run("reports server time on valid token", func(t *testing.T) {
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		now := time.Now().UTC().Truncate(time.Second)
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects").
+			Reply(http.StatusOK).
+			SetHeader("Date", now.Format(http.TimeFormat)).
+			JSON([]api.V1ProjectResponse{{Id: apitest.RandomProjectRef()}})
+		// Run test
+		result, serverTime := checkAccessToken(context.Background(), afero.NewMemMapFs())
+		// Check error
+		assert.Equal(t, StatusOk, result.Status)
+		assert.Equal(t, "1 projects accessible", result.Detail)
+		assert.True(t, now.Equal(serverTime))
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("fails on unauthorized token", func(t *testing.T) {
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects").
+			Reply(http.StatusUnauthorized)
+		// Run test
+		result, _ := checkAccessToken(context.Background(), afero.NewMemMapFs())
+		// Check error
+		assert.Equal(t, StatusFail, result.Status)
+		assert.Contains(t, result.Detail, "status 401")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestCheckClockSkew(t *testing.T) {
+	now := time.Now()
+
+	t.Run("skips without server time", func(t *testing.T) {
+		result := checkClockSkew(time.Time{}, now)
+		assert.Equal(t, StatusSkip, result.Status)
+	})
+
+	t.Run("passes within tolerance", func(t *testing.T) {
+		result := checkClockSkew(now.Add(2*time.Second), now)
+		assert.Equal(t, StatusOk, result.Status)
+		assert.Equal(t, "2s skew from server", result.Detail)
+	})
+
+	t.Run("warns on large skew", func(t *testing.T) {
+		result := checkClockSkew(now.Add(-time.Minute), now)
+		assert.Equal(t, StatusWarn, result.Status)
+		assert.Contains(t, result.Detail, "1m0s skew from server")
+	})
+}
+
+func TestCheckDisk(t *testing.T) {
+	result := checkDisk(t.TempDir())
+	assert.NotEqual(t, StatusFail, result.Status)
+	assert.Contains(t, result.Detail, "free")
+}
+
+func TestReportMarkdown(t *testing.T) {
+	report := Report{
+		Version: "1.0.0",
+		OS:      "linux",
+		Arch:    "amd64",
+		Checks: []Check{
+			{Name: "docker", Status: StatusFail, Detail: "cannot connect | refused"},
+			{Name: "clock", Status: StatusOk, Detail: "0s skew from server"},
+		},
+	}
+	// Check error
+	assert.Equal(t, "|CHECK|STATUS|DETAIL|\n|-|-|-|\n"+
+		"|`docker`|`fail`|cannot connect \\| refused|\n"+
+		"|`clock`|`ok`|0s skew from server|\n"+
+		"\nSupabase CLI 1.0.0 on linux/amd64\n", report.toMarkdown())
+	assert.Equal(t, 1, report.countFailures())
+}
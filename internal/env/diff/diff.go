@@ -0,0 +1,85 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/env/encrypt"
+	"github.com/supabase/cli/internal/secrets/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+const (
+	StatusAdded   = "added"
+	StatusRemoved = "removed"
+	StatusChanged = "changed"
+)
+
+type Change struct {
+	Name   string
+	Status string
+}
+
+func Run(ctx context.Context, projectRef, envFilePath string, fsys afero.Fs) error {
+	local, err := encrypt.ReadFile(envFilePath, fsys)
+	if err != nil {
+		return err
+	}
+	remote, err := list.GetSecretDigests(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	changes := Compare(local, remote)
+	if len(changes) == 0 {
+		fmt.Fprintln(os.Stderr, "Local env file is in sync with remote secrets.")
+		return nil
+	}
+	for _, c := range changes {
+		switch c.Status {
+		case StatusAdded:
+			fmt.Println(utils.Aqua("+ " + c.Name))
+		case StatusRemoved:
+			fmt.Println(utils.Red("- " + c.Name))
+		default:
+			fmt.Println(utils.Yellow("~ " + c.Name))
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Run %s to update remote secrets.\n", utils.Aqua("supabase env push"))
+	return nil
+}
+
+// Reserved secrets are managed by the platform, so they are excluded from comparison.
+func IsReserved(name string) bool {
+	return strings.HasPrefix(name, "SUPABASE_")
+}
+
+// Compares local env against remote secrets, from the perspective of pushing local changes.
+func Compare(local map[string]string, remote []api.SecretResponse) []Change {
+	var changes []Change
+	seen := make(map[string]bool, len(remote))
+	for _, secret := range remote {
+		if IsReserved(secret.Name) {
+			continue
+		}
+		seen[secret.Name] = true
+		if value, ok := local[secret.Name]; !ok {
+			changes = append(changes, Change{Name: secret.Name, Status: StatusRemoved})
+		} else if !list.MatchesValue(secret, value) {
+			changes = append(changes, Change{Name: secret.Name, Status: StatusChanged})
+		}
+	}
+	for name := range local {
+		if !seen[name] && !IsReserved(name) {
+			changes = append(changes, Change{Name: name, Status: StatusAdded})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
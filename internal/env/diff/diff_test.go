@@ -0,0 +1,73 @@
+package diff
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+// sha256 digest of "secret"
+const secretDigest = "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b"
+
+func TestCompare(t *testing.T) {
+	t.Run("detects added, removed, and changed secrets", func(t *testing.T) {
+		local := map[string]string{
+			"SAME":    "secret",
+			"CHANGED": "new",
+			"ADDED":   "value",
+		}
+		remote := []api.SecretResponse{
+			{Name: "SAME", Value: secretDigest},
+			{Name: "CHANGED", Value: secretDigest},
+			{Name: "REMOVED", Value: secretDigest},
+			{Name: "SUPABASE_URL", Value: secretDigest},
+		}
+		// Run test
+		changes := Compare(local, remote)
+		// Check result
+		assert.Equal(t, []Change{
+			{Name: "ADDED", Status: StatusAdded},
+			{Name: "CHANGED", Status: StatusChanged},
+			{Name: "REMOVED", Status: StatusRemoved},
+		}, changes)
+	})
+}
+
+func TestDiffCommand(t *testing.T) {
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	project := apitest.RandomProjectRef()
+
+	t.Run("diffs plaintext env file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.EncryptedEnvPath, []byte("SAME=secret"), 0644))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{{Name: "SAME", Value: secretDigest}})
+		// Run test
+		err := Run(context.Background(), project, utils.EncryptedEnvPath, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing file", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), project, utils.EncryptedEnvPath, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "failed to read env file:")
+	})
+}
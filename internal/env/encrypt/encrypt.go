@@ -0,0 +1,241 @@
+package encrypt
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/joho/godotenv"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/credentials"
+)
+
+// Values are encrypted individually so that variable names remain reviewable in git diffs.
+const (
+	valuePrefix = "ENC[AES256_GCM,data:"
+	valueSuffix = "]"
+	keyHeader   = "#supabase:key="
+)
+
+var ErrMissingKey = errors.Errorf("Missing encryption key. Set %s or run on a machine that has the key in its keyring.", utils.Aqua("SUPABASE_ENV_KEY"))
+
+// Key fingerprints identify which key an env file was encrypted with, without revealing it.
+func fingerprint(key []byte) string {
+	digest := sha256.Sum256(key)
+	return hex.EncodeToString(digest[:4])
+}
+
+func keyringName(id string) string {
+	return "env-" + id
+}
+
+func decodeKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.Errorf("failed to decode encryption key: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, errors.Errorf("Invalid encryption key length: expected 32 bytes, got %d", len(key))
+	}
+	return key, nil
+}
+
+// Loads the key with matching fingerprint from SUPABASE_ENV_KEY or the system keyring.
+func LoadKey(id string) ([]byte, error) {
+	if encoded := viper.GetString("ENV_KEY"); len(encoded) > 0 {
+		key, err := decodeKey(encoded)
+		if err != nil {
+			return nil, err
+		}
+		if actual := fingerprint(key); len(id) > 0 && actual != id {
+			return nil, errors.Errorf("Encryption key %s does not match env file key %s.", actual, id)
+		}
+		return key, nil
+	}
+	if len(id) == 0 {
+		return nil, errors.New(ErrMissingKey)
+	}
+	encoded, err := credentials.Get(keyringName(id))
+	if err != nil {
+		fmt.Fprintln(utils.GetDebugLogger(), err)
+		return nil, errors.New(ErrMissingKey)
+	}
+	return decodeKey(encoded)
+}
+
+// Generates a new key and saves it to the system keyring. A copy is written to a file only
+// readable by the current user, so the key is never echoed to terminal or CI logs.
+func NewKey(fsys afero.Fs) ([]byte, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, errors.Errorf("failed to generate encryption key: %w", err)
+	}
+	encoded := base64.StdEncoding.EncodeToString(key)
+	if err := credentials.Set(keyringName(fingerprint(key)), encoded); err != nil {
+		fmt.Fprintln(utils.GetDebugLogger(), err)
+	}
+	if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(utils.EnvKeyPath)); err != nil {
+		return nil, err
+	}
+	if err := afero.WriteFile(fsys, utils.EnvKeyPath, []byte(encoded), 0600); err != nil {
+		return nil, errors.Errorf("failed to save encryption key: %w", err)
+	}
+	fmt.Fprintln(os.Stderr, "Generated a new encryption key at", utils.Bold(utils.EnvKeyPath)+". Share it with your team via", utils.Aqua("SUPABASE_ENV_KEY")+".")
+	return key, nil
+}
+
+// Values are sealed with their variable name as additional data, so that moving an encrypted
+// value to another name fails to decrypt.
+func encryptValue(gcm cipher.AEAD, name, value string) (string, error) {
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", errors.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(value), []byte(name))
+	return valuePrefix + base64.StdEncoding.EncodeToString(sealed) + valueSuffix, nil
+}
+
+func decryptValue(gcm cipher.AEAD, name, value string) (string, error) {
+	data, found := strings.CutPrefix(value, valuePrefix)
+	if !found || !strings.HasSuffix(data, valueSuffix) {
+		return value, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(data, valueSuffix))
+	if err != nil {
+		return "", errors.Errorf("failed to decode encrypted value: %w", err)
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("Encrypted value is too short.")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, []byte(name))
+	if err != nil {
+		return "", errors.Errorf("failed to decrypt value: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+func newCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Errorf("failed to initialise cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Errorf("failed to initialise cipher: %w", err)
+	}
+	return gcm, nil
+}
+
+func Encrypt(envMap map[string]string, key []byte) ([]byte, error) {
+	gcm, err := newCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(envMap))
+	for name := range envMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "# Managed by `supabase env`. Values are encrypted, but names are not.")
+	fmt.Fprintln(&buf, keyHeader+fingerprint(key))
+	for _, name := range names {
+		value, err := encryptValue(gcm, name, envMap[name])
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintln(&buf, name+"="+strconv.Quote(value))
+	}
+	return buf.Bytes(), nil
+}
+
+// Returns the fingerprint of the key used to encrypt the env file, or empty if unencrypted.
+func GetKeyId(contents []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		if id, found := strings.CutPrefix(scanner.Text(), keyHeader); found {
+			return strings.TrimSpace(id)
+		}
+	}
+	return ""
+}
+
+func IsEncrypted(envMap map[string]string) bool {
+	for _, value := range envMap {
+		if strings.HasPrefix(value, valuePrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Parses an env file, transparently decrypting values that were encrypted by `supabase env`.
+func Decrypt(contents []byte) (map[string]string, error) {
+	envMap, err := godotenv.Unmarshal(string(contents))
+	if err != nil {
+		return nil, errors.Errorf("failed to parse env file: %w", err)
+	}
+	if !IsEncrypted(envMap) {
+		return envMap, nil
+	}
+	key, err := LoadKey(GetKeyId(contents))
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range envMap {
+		if envMap[name], err = decryptValue(gcm, name, value); err != nil {
+			return nil, errors.Errorf("failed to decrypt %s: %w", name, err)
+		}
+	}
+	return envMap, nil
+}
+
+func ReadFile(path string, fsys afero.Fs) (map[string]string, error) {
+	contents, err := afero.ReadFile(fsys, path)
+	if err != nil {
+		return nil, errors.Errorf("failed to read env file: %w", err)
+	}
+	return Decrypt(contents)
+}
+
+// Encrypts with the key of an existing env file, or generates a new key otherwise.
+func WriteFile(path string, envMap map[string]string, fsys afero.Fs) error {
+	var key []byte
+	if contents, err := afero.ReadFile(fsys, path); err == nil {
+		if key, err = LoadKey(GetKeyId(contents)); err != nil {
+			return err
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return errors.Errorf("failed to read env file: %w", err)
+	} else if key, err = LoadKey(""); errors.Is(err, ErrMissingKey) {
+		if key, err = NewKey(fsys); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+	contents, err := Encrypt(envMap, key)
+	if err != nil {
+		return err
+	}
+	return utils.WriteFile(path, contents, fsys)
+}
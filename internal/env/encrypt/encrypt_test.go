@@ -0,0 +1,101 @@
+package encrypt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"testing"
+
+	"github.com/joho/godotenv"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/zalando/go-keyring"
+)
+
+var testKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestEncryptDecrypt(t *testing.T) {
+	viper.Set("ENV_KEY", base64.StdEncoding.EncodeToString(testKey))
+	t.Cleanup(func() { viper.Set("ENV_KEY", "") })
+
+	t.Run("round trips encrypted values", func(t *testing.T) {
+		envMap := map[string]string{"API_KEY": "secret", "EMPTY": ""}
+		// Run test
+		contents, err := Encrypt(envMap, testKey)
+		require.NoError(t, err)
+		decrypted, err := Decrypt(contents)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, envMap, decrypted)
+		assert.NotContains(t, string(contents), "secret")
+		assert.Contains(t, string(contents), "API_KEY=")
+		assert.Equal(t, fingerprint(testKey), GetKeyId(contents))
+	})
+
+	t.Run("passes through plaintext values", func(t *testing.T) {
+		contents, err := Encrypt(map[string]string{"API_KEY": "secret"}, testKey)
+		require.NoError(t, err)
+		contents = append(contents, []byte("NEW_KEY=plain\n")...)
+		// Run test
+		decrypted, err := Decrypt(contents)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"API_KEY": "secret", "NEW_KEY": "plain"}, decrypted)
+	})
+
+	t.Run("throws error on swapped values", func(t *testing.T) {
+		contents, err := Encrypt(map[string]string{"PUBLIC_URL": "public", "SECRET_KEY": "secret"}, testKey)
+		require.NoError(t, err)
+		envMap, err := godotenv.Unmarshal(string(contents))
+		require.NoError(t, err)
+		swapped := bytes.Replace(contents, []byte(envMap["SECRET_KEY"]), []byte(envMap["PUBLIC_URL"]), 1)
+		// Run test
+		_, err = Decrypt(swapped)
+		// Check error
+		assert.ErrorContains(t, err, "failed to decrypt SECRET_KEY: failed to decrypt value:")
+	})
+
+	t.Run("throws error on mismatched key", func(t *testing.T) {
+		other := []byte("fedcba9876543210fedcba9876543210")
+		contents, err := Encrypt(map[string]string{"API_KEY": "secret"}, other)
+		require.NoError(t, err)
+		// Run test
+		_, err = Decrypt(contents)
+		// Check error
+		assert.ErrorContains(t, err, "does not match env file key "+fingerprint(other))
+	})
+}
+
+func TestWriteFile(t *testing.T) {
+	keyring.MockInit()
+
+	t.Run("generates key on new file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := WriteFile("supabase/.env.encrypted", map[string]string{"API_KEY": "secret"}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		envMap, err := ReadFile("supabase/.env.encrypted", fsys)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"API_KEY": "secret"}, envMap)
+		info, err := fsys.Stat(utils.EnvKeyPath)
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	})
+
+	t.Run("throws error on missing key", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		contents, err := Encrypt(map[string]string{"API_KEY": "secret"}, testKey)
+		require.NoError(t, err)
+		require.NoError(t, afero.WriteFile(fsys, "supabase/.env.encrypted", contents, 0644))
+		// Run test
+		_, err = ReadFile("supabase/.env.encrypted", fsys)
+		// Check error
+		assert.ErrorIs(t, err, ErrMissingKey)
+	})
+}
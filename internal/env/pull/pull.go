@@ -0,0 +1,61 @@
+package pull
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/env/diff"
+	"github.com/supabase/cli/internal/env/encrypt"
+	"github.com/supabase/cli/internal/secrets/list"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Merges remote secrets into the local env file. Since the API only exposes digests, local
+// values are kept and remote values are filled in only when returned as plaintext. Secrets
+// without a retrievable value are left out of the file instead of being written as empty.
+func Run(ctx context.Context, projectRef, envFilePath string, fsys afero.Fs) error {
+	remote, err := list.GetSecretDigests(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	envMap := map[string]string{}
+	if exists, err := afero.Exists(fsys, envFilePath); err != nil {
+		return errors.Errorf("failed to check env file: %w", err)
+	} else if exists {
+		if envMap, err = encrypt.ReadFile(envFilePath, fsys); err != nil {
+			return err
+		}
+	}
+	var pulled int
+	var missing, changed []string
+	for _, secret := range remote {
+		if diff.IsReserved(secret.Name) {
+			continue
+		}
+		if value, ok := envMap[secret.Name]; ok {
+			if !list.MatchesValue(secret, value) {
+				changed = append(changed, secret.Name)
+			}
+		} else if list.IsDigest(secret.Value) {
+			missing = append(missing, secret.Name)
+		} else {
+			envMap[secret.Name] = secret.Value
+			pulled++
+		}
+	}
+	if err := encrypt.WriteFile(envFilePath, envMap, fsys); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Pulled", pulled, "new secrets to", utils.Bold(envFilePath))
+	if len(missing) > 0 {
+		fmt.Fprintf(os.Stderr, "Values of these secrets are not retrievable and were not written to the env file:\n • %s\n", strings.Join(missing, "\n • "))
+	}
+	if len(changed) > 0 {
+		fmt.Fprintf(os.Stderr, "Kept local values that differ from remote:\n • %s\n", strings.Join(changed, "\n • "))
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package pull
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/env/encrypt"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestPullCommand(t *testing.T) {
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	project := apitest.RandomProjectRef()
+	// Setup encryption key
+	viper.Set("ENV_KEY", base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")))
+	t.Cleanup(func() { viper.Set("ENV_KEY", "") })
+
+	t.Run("merges remote secrets into encrypted file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.EncryptedEnvPath, []byte("LOCAL=value"), 0644))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{
+				{Name: "DIGEST", Value: "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b"},
+				{Name: "PLAIN", Value: "remote"},
+				{Name: "SUPABASE_URL", Value: "http://localhost"},
+			})
+		// Run test
+		err := Run(context.Background(), project, utils.EncryptedEnvPath, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		envMap, err := encrypt.ReadFile(utils.EncryptedEnvPath, fsys)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"LOCAL": "value",
+			"PLAIN": "remote",
+		}, envMap)
+	})
+
+	t.Run("throws error on service unavailable", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		err := Run(context.Background(), project, utils.EncryptedEnvPath, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Unexpected error retrieving project secrets:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -0,0 +1,83 @@
+package push
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/env/diff"
+	"github.com/supabase/cli/internal/env/encrypt"
+	"github.com/supabase/cli/internal/secrets/list"
+	"github.com/supabase/cli/internal/secrets/set"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(ctx context.Context, projectRef, envFilePath string, prune bool, fsys afero.Fs) error {
+	envMap, err := encrypt.ReadFile(envFilePath, fsys)
+	if err != nil {
+		return err
+	}
+	secrets := map[string]string{}
+	var empty []string
+	for name, value := range envMap {
+		if len(value) == 0 {
+			empty = append(empty, name)
+		} else {
+			secrets[name] = value
+		}
+	}
+	if len(empty) > 0 {
+		sort.Strings(empty)
+		fmt.Fprintf(os.Stderr, "Skipped secrets with empty values:\n • %s\n", strings.Join(empty, "\n • "))
+	}
+	if len(secrets) > 0 {
+		if err := set.CreateSecrets(ctx, projectRef, secrets); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, "Pushed", len(secrets), "secrets from", utils.Bold(envFilePath))
+	}
+	if prune {
+		if err := pruneSecrets(ctx, projectRef, envFilePath, envMap); err != nil {
+			return err
+		}
+	}
+	fmt.Println("Finished " + utils.Aqua("supabase env push") + ".")
+	return nil
+}
+
+func pruneSecrets(ctx context.Context, projectRef, envFilePath string, envMap map[string]string) error {
+	remote, err := list.GetSecretDigests(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	var stale []string
+	for _, secret := range remote {
+		if _, ok := envMap[secret.Name]; !ok && !diff.IsReserved(secret.Name) {
+			stale = append(stale, secret.Name)
+		}
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+	sort.Strings(stale)
+	msg := fmt.Sprintf("These secrets are not found in %s:\n • %s\nDo you want to delete them from the remote project?", utils.Bold(envFilePath), strings.Join(stale, "\n • "))
+	if shouldPrune, err := utils.NewConsole().PromptYesNo(ctx, msg, false); err != nil {
+		return err
+	} else if !shouldPrune {
+		return errors.New(context.Canceled)
+	}
+	resp, err := utils.GetSupabase().V1BulkDeleteSecretsWithResponse(ctx, projectRef, stale)
+	if err != nil {
+		return errors.Errorf("failed to delete secrets: %w", err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return errors.New("Unexpected error unsetting project secrets: " + string(resp.Body))
+	}
+	fmt.Fprintln(os.Stderr, "Pruned", len(stale), "secrets not found in", utils.Bold(envFilePath))
+	return nil
+}
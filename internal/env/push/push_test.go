@@ -0,0 +1,89 @@
+package push
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/testing/fstest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestPushCommand(t *testing.T) {
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	project := apitest.RandomProjectRef()
+
+	t.Run("pushes and prunes secrets", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.EncryptedEnvPath, []byte("API_KEY=secret\nEMPTY="), 0644))
+		defer fstest.MockStdin(t, "y")()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/secrets").
+			MatchType("json").
+			JSON(api.V1BulkCreateSecretsJSONRequestBody{{Name: "API_KEY", Value: "secret"}}).
+			Reply(http.StatusCreated)
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{
+				{Name: "API_KEY", Value: "secret"},
+				{Name: "STALE", Value: "value"},
+				{Name: "SUPABASE_URL", Value: "value"},
+			})
+		gock.New(utils.DefaultApiHost).
+			Delete("/v1/projects/" + project + "/secrets").
+			MatchType("json").
+			JSON([]string{"STALE"}).
+			Reply(http.StatusOK)
+		// Run test
+		err := Run(context.Background(), project, utils.EncryptedEnvPath, true, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on cancel prune", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.EncryptedEnvPath, []byte(""), 0644))
+		defer fstest.MockStdin(t, "n")()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{{Name: "STALE", Value: "value"}})
+		// Run test
+		err := Run(context.Background(), project, utils.EncryptedEnvPath, true, fsys)
+		// Check error
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on network error", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.EncryptedEnvPath, []byte("API_KEY=secret"), 0644))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		err := Run(context.Background(), project, utils.EncryptedEnvPath, false, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Unexpected error setting project secrets:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -0,0 +1,383 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const (
+	FailOnErrors   = "errors"
+	FailOnWarnings = "warnings"
+	FailOnNone     = "none"
+
+	SeverityWarning = "warning"
+	SeverityError   = "error"
+)
+
+var (
+	FailOnAllowed = []string{FailOnErrors, FailOnWarnings, FailOnNone}
+
+	importPattern = regexp.MustCompile(`(?m)(?:\bfrom\s*|\bimport\s*\(?\s*)["']([^"']+)["']`)
+	SourceExts    = []string{".ts", ".tsx", ".js", ".jsx", ".mjs", ".mts"}
+)
+
+type Finding struct {
+	Slug      string `json:"slug"`
+	Severity  string `json:"severity"`
+	Specifier string `json:"specifier"`
+	Message   string `json:"message"`
+}
+
+type Advisory struct {
+	Id       string   `json:"id"`
+	Package  string   `json:"package"`
+	Versions []string `json:"versions"`
+	Summary  string   `json:"summary"`
+}
+
+// Run audits the imports of each function before it is bundled, failing if any
+// finding is at or above the failOn threshold. An empty failOn defaults to errors, but
+// only warns if the advisory database cannot be reached.
+func Run(ctx context.Context, slugs []string, importMapPath, failOn string, fsys afero.Fs) error {
+	if viper.GetBool("FUNCTIONS_SKIP_AUDIT") {
+		fmt.Fprintln(os.Stderr, "Skipped auditing Function imports.")
+		return nil
+	}
+	strict := len(failOn) > 0
+	if !strict {
+		failOn = FailOnErrors
+	}
+	imports := make([][]string, len(slugs))
+	var all []string
+	for i, slug := range slugs {
+		fc := utils.GetFunctionConfig(slug, importMapPath, nil, fsys)
+		specifiers, err := CollectImports(slug, fc.ImportMap, fsys)
+		if err != nil {
+			return err
+		}
+		imports[i] = specifiers
+		all = append(all, specifiers...)
+	}
+	advisories, err := loadAdvisories(ctx, all)
+	if err != nil && (strict || !errors.Is(err, errAdvisoryUnreachable)) {
+		return err
+	} else if err != nil {
+		// Advisory databases being unreachable should not block deploys, ie. in air-gapped networks
+		fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "Skipped vulnerability check:", err.Error())
+	}
+	var findings []Finding
+	for i, slug := range slugs {
+		findings = append(findings, Check(slug, imports[i], advisories)...)
+	}
+	for _, f := range findings {
+		label := utils.Yellow("WARNING:")
+		if f.Severity == SeverityError {
+			label = utils.Red("ERROR:")
+		}
+		fmt.Fprintf(os.Stderr, "%s %s: %s (%s)\n", label, utils.Aqua(f.Slug), f.Message, f.Specifier)
+	}
+	if n := countFailures(findings, failOn); n > 0 {
+		return errors.Errorf("Import audit found %d issue(s) at or above --fail-on %s.", n, failOn)
+	}
+	return nil
+}
+
+func countFailures(findings []Finding, failOn string) int {
+	count := 0
+	for _, f := range findings {
+		switch failOn {
+		case FailOnWarnings:
+			count++
+		case FailOnErrors:
+			if f.Severity == SeverityError {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// CollectImports returns remote specifiers from the import map and the function source.
+func CollectImports(slug, importMapPath string, fsys afero.Fs) ([]string, error) {
+	var specifiers []string
+	if len(importMapPath) > 0 {
+		importMap, err := utils.NewImportMap(importMapPath, fsys)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range importMap.Imports {
+			specifiers = append(specifiers, v)
+		}
+		for _, mapping := range importMap.Scopes {
+			for _, v := range mapping {
+				specifiers = append(specifiers, v)
+			}
+		}
+	}
+	funcDir := filepath.Join(utils.FunctionsDir, slug)
+	if exists, err := afero.DirExists(fsys, funcDir); err != nil {
+		return nil, errors.Errorf("failed to check function dir: %w", err)
+	} else if !exists {
+		return specifiers, nil
+	}
+	if err := afero.Walk(fsys, funcDir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !utils.SliceContains(SourceExts, filepath.Ext(path)) {
+			return nil
+		}
+		contents, err := afero.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+		specifiers = append(specifiers, ParseImports(string(contents))...)
+		return nil
+	}); err != nil {
+		return nil, errors.Errorf("failed to read function source: %w", err)
+	}
+	sort.Strings(specifiers)
+	return specifiers, nil
+}
+
+// ParseImports returns the module specifiers of static and dynamic imports in source.
+func ParseImports(source string) []string {
+	var specifiers []string
+	for _, match := range importPattern.FindAllStringSubmatch(source, -1) {
+		specifiers = append(specifiers, match[1])
+	}
+	return specifiers
+}
+
+// Check reports unpinned, duplicate, and vulnerable packages among specifiers.
+func Check(slug string, specifiers []string, advisories []Advisory) []Finding {
+	var findings []Finding
+	versions := map[string][]string{}
+	for _, spec := range specifiers {
+		pkg, version, ok := ParseSpecifier(spec)
+		if !ok {
+			continue
+		}
+		if !isPinned(version) {
+			findings = append(findings, Finding{
+				Slug:      slug,
+				Severity:  SeverityWarning,
+				Specifier: spec,
+				Message:   "unpinned version of " + pkg,
+			})
+			continue
+		}
+		if !utils.SliceContains(versions[pkg], version) {
+			versions[pkg] = append(versions[pkg], version)
+		}
+		for _, a := range advisories {
+			if a.Package == pkg && utils.SliceContains(a.Versions, version) {
+				findings = append(findings, Finding{
+					Slug:      slug,
+					Severity:  SeverityError,
+					Specifier: spec,
+					Message:   fmt.Sprintf("%s@%s is vulnerable: %s %s", pkg, version, a.Id, a.Summary),
+				})
+			}
+		}
+	}
+	pkgs := make([]string, 0, len(versions))
+	for pkg := range versions {
+		pkgs = append(pkgs, pkg)
+	}
+	sort.Strings(pkgs)
+	for _, pkg := range pkgs {
+		if v := versions[pkg]; len(v) > 1 {
+			findings = append(findings, Finding{
+				Slug:      slug,
+				Severity:  SeverityWarning,
+				Specifier: pkg,
+				Message:   "duplicate package with versions " + strings.Join(v, ", "),
+			})
+		}
+	}
+	return findings
+}
+
+// ParseSpecifier extracts the package name and version from a remote module specifier.
+func ParseSpecifier(spec string) (string, string, bool) {
+	spec, _, _ = strings.Cut(spec, "?")
+	var prefix, rest string
+	if name, ok := strings.CutPrefix(spec, "npm:"); ok {
+		prefix, rest = "npm:", name
+	} else if name, ok := strings.CutPrefix(spec, "jsr:"); ok {
+		prefix, rest = "jsr:", name
+	} else if name, ok := strings.CutPrefix(spec, "https://esm.sh/"); ok {
+		prefix, rest = "npm:", name
+	} else if name, ok := strings.CutPrefix(spec, "https://deno.land/x/"); ok {
+		prefix, rest = "deno.land/x/", name
+	} else if name, ok := strings.CutPrefix(spec, "https://deno.land/std"); ok {
+		// Treat std as a single package since it is versioned as a whole
+		if version, found := strings.CutPrefix(name, "@"); found {
+			version, _, _ = strings.Cut(version, "/")
+			return "deno.land/std", version, true
+		}
+		return "deno.land/std", "", true
+	} else {
+		return "", "", false
+	}
+	// Scoped packages include one more path segment
+	segments := 1
+	if strings.HasPrefix(rest, "@") {
+		segments = 2
+	}
+	parts := strings.SplitN(rest, "/", segments+1)
+	if len(parts) < segments {
+		return "", "", false
+	}
+	name := strings.Join(parts[:segments], "/")
+	version := ""
+	if i := strings.LastIndex(name, "@"); i > 0 {
+		name, version = name[:i], name[i+1:]
+	}
+	return prefix + name, version, true
+}
+
+func isPinned(version string) bool {
+	if len(version) == 0 || version == "latest" || strings.ContainsAny(version, "^~*x><| ") {
+		return false
+	}
+	return true
+}
+
+// Uses the advisory feed at FUNCTIONS_ADVISORY_URL if set, or queries OSV for
+// pinned npm packages otherwise.
+func loadAdvisories(ctx context.Context, specifiers []string) ([]Advisory, error) {
+	if feedUrl := viper.GetString("FUNCTIONS_ADVISORY_URL"); len(feedUrl) > 0 {
+		return FetchAdvisories(ctx, feedUrl)
+	}
+	return QueryOSV(ctx, OSVBatchUrl, specifiers)
+}
+
+// Bounds the time spent on advisory lookups, so that a slow database never hangs deploys.
+var advisoryClient = &http.Client{Timeout: 10 * time.Second}
+
+var errAdvisoryUnreachable = errors.New("advisory database is unreachable")
+
+const OSVBatchUrl = "https://api.osv.dev/v1/querybatch"
+
+type osvPackage struct {
+	Name      string `json:"name"`
+	Ecosystem string `json:"ecosystem"`
+}
+
+type osvQuery struct {
+	Package osvPackage `json:"package"`
+	Version string     `json:"version"`
+}
+
+type osvBatchResponse struct {
+	Results []struct {
+		Vulns []struct {
+			Id string `json:"id"`
+		} `json:"vulns"`
+	} `json:"results"`
+}
+
+// QueryOSV looks up pinned npm packages in the OSV vulnerability database.
+// Other registries, like jsr and deno.land, are not covered by OSV.
+func QueryOSV(ctx context.Context, batchUrl string, specifiers []string) ([]Advisory, error) {
+	var queries []osvQuery
+	seen := map[osvQuery]bool{}
+	for _, spec := range specifiers {
+		pkg, version, ok := ParseSpecifier(spec)
+		if !ok || !isPinned(version) {
+			continue
+		}
+		name, ok := strings.CutPrefix(pkg, "npm:")
+		if !ok {
+			continue
+		}
+		q := osvQuery{Package: osvPackage{Name: name, Ecosystem: "npm"}, Version: version}
+		if !seen[q] {
+			seen[q] = true
+			queries = append(queries, q)
+		}
+	}
+	if len(queries) == 0 {
+		return nil, nil
+	}
+	body, err := json.Marshal(map[string][]osvQuery{"queries": queries})
+	if err != nil {
+		return nil, errors.Errorf("failed to encode osv query: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, batchUrl, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Errorf("failed to create osv request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := advisoryClient.Do(req)
+	if err != nil {
+		return nil, errors.Errorf("failed to query osv: %w: %w", errAdvisoryUnreachable, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, errors.Errorf("Unexpected error querying osv: %w: %s", errAdvisoryUnreachable, string(body))
+	}
+	var result osvBatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, errors.Errorf("failed to parse osv response: %w", err)
+	}
+	var advisories []Advisory
+	for i, r := range result.Results {
+		if i >= len(queries) {
+			break
+		}
+		for _, v := range r.Vulns {
+			advisories = append(advisories, Advisory{
+				Id:       v.Id,
+				Package:  "npm:" + queries[i].Package.Name,
+				Versions: []string{queries[i].Version},
+				Summary:  "https://osv.dev/vulnerability/" + v.Id,
+			})
+		}
+	}
+	return advisories, nil
+}
+
+// FetchAdvisories downloads known vulnerable package versions from the advisory feed.
+func FetchAdvisories(ctx context.Context, feedUrl string) ([]Advisory, error) {
+	if len(feedUrl) == 0 {
+		return nil, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedUrl, nil)
+	if err != nil {
+		return nil, errors.Errorf("failed to create advisory request: %w", err)
+	}
+	resp, err := advisoryClient.Do(req)
+	if err != nil {
+		return nil, errors.Errorf("failed to fetch advisories: %w: %w", errAdvisoryUnreachable, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, errors.Errorf("Unexpected error fetching advisories: %s", string(body))
+	}
+	var advisories []Advisory
+	if err := json.NewDecoder(resp.Body).Decode(&advisories); err != nil {
+		return nil, errors.Errorf("failed to parse advisories: %w", err)
+	}
+	return advisories, nil
+}
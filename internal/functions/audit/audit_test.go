@@ -0,0 +1,171 @@
+package audit
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestParseSpecifier(t *testing.T) {
+	cases := map[string][]string{
+		"https://deno.land/x/oak@v12.6.1/mod.ts":       {"deno.land/x/oak", "v12.6.1"},
+		"https://deno.land/x/oak/mod.ts":               {"deno.land/x/oak", ""},
+		"https://deno.land/std@0.168.0/http/server.ts": {"deno.land/std", "0.168.0"},
+		"https://esm.sh/@supabase/supabase-js@2.39.0":  {"npm:@supabase/supabase-js", "2.39.0"},
+		"https://esm.sh/stripe@14.0.0?target=deno":     {"npm:stripe", "14.0.0"},
+		"npm:@supabase/supabase-js":                    {"npm:@supabase/supabase-js", ""},
+		"jsr:@std/path@^1.0.0":                         {"jsr:@std/path", "^1.0.0"},
+		"npm:lodash@4.17.15/fp":                        {"npm:lodash", "4.17.15"},
+	}
+	for spec, expected := range cases {
+		pkg, version, ok := ParseSpecifier(spec)
+		assert.True(t, ok, spec)
+		assert.Equal(t, expected, []string{pkg, version}, spec)
+	}
+	_, _, ok := ParseSpecifier("./utils.ts")
+	assert.False(t, ok)
+}
+
+func TestCheckImports(t *testing.T) {
+	t.Run("reports unpinned and duplicate packages", func(t *testing.T) {
+		findings := Check("hello", []string{
+			"https://deno.land/x/oak/mod.ts",
+			"jsr:@std/path@^1.0.0",
+			"npm:zod@3.22.0",
+			"https://esm.sh/zod@3.21.4",
+			"./local.ts",
+		}, nil)
+		assert.Len(t, findings, 3)
+		assert.Equal(t, SeverityWarning, findings[0].Severity)
+		assert.Equal(t, "unpinned version of deno.land/x/oak", findings[0].Message)
+		assert.Equal(t, "unpinned version of jsr:@std/path", findings[1].Message)
+		assert.Equal(t, "duplicate package with versions 3.22.0, 3.21.4", findings[2].Message)
+	})
+
+	t.Run("reports vulnerable versions", func(t *testing.T) {
+		findings := Check("hello", []string{"npm:lodash@4.17.15"}, []Advisory{{
+			Id:       "GHSA-p6mc-m468-83gw",
+			Package:  "npm:lodash",
+			Versions: []string{"4.17.15"},
+			Summary:  "Prototype Pollution",
+		}})
+		assert.Len(t, findings, 1)
+		assert.Equal(t, SeverityError, findings[0].Severity)
+		assert.Equal(t, 1, countFailures(findings, FailOnErrors))
+		assert.Equal(t, 0, countFailures(findings, FailOnNone))
+	})
+}
+
+func TestAuditCommand(t *testing.T) {
+	t.Run("collects imports from source and import map", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		entrypoint := filepath.Join(utils.FunctionsDir, "hello", "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte(`import { serve } from "https://deno.land/std@0.168.0/http/server.ts"
+const { z } = await import('npm:zod@3.22.0')`), 0644))
+		require.NoError(t, afero.WriteFile(fsys, "import_map.json", []byte(`{"imports":{"oak":"https://deno.land/x/oak/mod.ts"}}`), 0644))
+		// Run test
+		specifiers, err := CollectImports("hello", "import_map.json", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"https://deno.land/std@0.168.0/http/server.ts",
+			"https://deno.land/x/oak/mod.ts",
+			"npm:zod@3.22.0",
+		}, specifiers)
+	})
+
+	t.Run("throws error on warnings", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		entrypoint := filepath.Join(utils.FunctionsDir, "hello", "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte(`import "npm:zod"`), 0644))
+		// Run test
+		err := Run(context.Background(), []string{"hello"}, "", FailOnWarnings, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Import audit found 1 issue(s) at or above --fail-on warnings.")
+		assert.NoError(t, Run(context.Background(), []string{"hello"}, "", FailOnErrors, fsys))
+	})
+
+	t.Run("warns on unreachable osv by default", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		entrypoint := filepath.Join(utils.FunctionsDir, "hello", "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte(`import "npm:zod@3.22.0"`), 0644))
+		// Setup mock osv
+		defer gock.OffAll()
+		gock.New("https://api.osv.dev").
+			Post("/v1/querybatch").
+			Times(2).
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		assert.NoError(t, Run(context.Background(), []string{"hello"}, "", "", fsys))
+		err := Run(context.Background(), []string{"hello"}, "", FailOnErrors, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Unexpected error querying osv: advisory database is unreachable")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("skips audit when disabled", func(t *testing.T) {
+		viper.Set("FUNCTIONS_SKIP_AUDIT", true)
+		t.Cleanup(func() { viper.Set("FUNCTIONS_SKIP_AUDIT", false) })
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		entrypoint := filepath.Join(utils.FunctionsDir, "hello", "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte(`import "npm:zod"`), 0644))
+		// Run test
+		err := Run(context.Background(), []string{"hello"}, "", FailOnWarnings, fsys)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("queries osv for pinned npm packages", func(t *testing.T) {
+		// Setup mock osv
+		defer gock.OffAll()
+		gock.New("https://api.osv.dev").
+			Post("/v1/querybatch").
+			JSON(map[string]any{"queries": []map[string]any{{
+				"package": map[string]string{"name": "lodash", "ecosystem": "npm"},
+				"version": "4.17.20",
+			}}}).
+			Reply(http.StatusOK).
+			JSON(map[string]any{"results": []map[string]any{{
+				"vulns": []map[string]string{{"id": "GHSA-35jh-r3h4-6jhm"}},
+			}}})
+		// Run test
+		advisories, err := QueryOSV(context.Background(), OSVBatchUrl, []string{
+			"npm:lodash@4.17.20",
+			"https://esm.sh/lodash@4.17.20",
+			"npm:zod",
+			"jsr:@std/path@1.0.0",
+		})
+		// Check error
+		assert.NoError(t, err)
+		require.Len(t, advisories, 1)
+		assert.Equal(t, "npm:lodash", advisories[0].Package)
+		assert.Equal(t, []string{"4.17.20"}, advisories[0].Versions)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on advisory failure", func(t *testing.T) {
+		// Setup mock feed
+		defer gock.OffAll()
+		gock.New("https://advisories.example.com").
+			Get("/feed.json").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		_, err := FetchAdvisories(context.Background(), "https://advisories.example.com/feed.json")
+		// Check error
+		assert.ErrorContains(t, err, "Unexpected error fetching advisories:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
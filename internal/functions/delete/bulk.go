@@ -0,0 +1,95 @@
+package delete
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Deletes all remote functions matching the given slugs or glob patterns, or every function
+// when all is true, after confirming with the user.
+func RunBulk(ctx context.Context, patterns []string, all bool, projectRef string, fsys afero.Fs) error {
+	remote, err := listRemoteSlugs(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	// Local only functions are never deployed, so there is nothing to delete
+	slugs := remote
+	if !all {
+		if slugs, err = MatchSlugs(patterns, remote); err != nil {
+			return err
+		}
+	}
+	if len(slugs) == 0 {
+		return errors.New("No Functions matched the given names.")
+	}
+	msg := fmt.Sprintf("Do you want to delete the following Functions from project %s?\n • %s\n", utils.Aqua(projectRef), strings.Join(slugs, "\n • "))
+	if shouldDelete, err := utils.NewConsole().PromptYesNo(ctx, msg, false); err != nil {
+		return err
+	} else if !shouldDelete {
+		return errors.New(context.Canceled)
+	}
+	var errs []error
+	for _, slug := range slugs {
+		if err := Run(ctx, slug, projectRef, fsys); errors.Is(err, errNotFound) {
+			fmt.Fprintln(os.Stderr, "Function "+utils.Aqua(slug)+" is already deleted.")
+		} else if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Errorf("failed to delete %d of %d Functions", len(errs), len(slugs))
+	}
+	return nil
+}
+
+// Expands glob patterns against known slugs. Plain slugs are kept as is so that
+// remote functions missing from the list are still attempted.
+func MatchSlugs(patterns, known []string) ([]string, error) {
+	set := map[string]struct{}{}
+	for _, pattern := range patterns {
+		if !strings.ContainsAny(pattern, "*?[") {
+			if err := utils.ValidateFunctionSlug(pattern); err != nil {
+				return nil, err
+			}
+			set[pattern] = struct{}{}
+			continue
+		}
+		for _, slug := range known {
+			if matched, err := path.Match(pattern, slug); err != nil {
+				return nil, errors.Errorf("failed to match pattern %s: %w", pattern, err)
+			} else if matched {
+				set[slug] = struct{}{}
+			}
+		}
+	}
+	result := make([]string, 0, len(set))
+	for slug := range set {
+		result = append(result, slug)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func listRemoteSlugs(ctx context.Context, projectRef string) ([]string, error) {
+	resp, err := utils.GetSupabase().V1ListAllFunctionsWithResponse(ctx, projectRef)
+	if err != nil {
+		return nil, errors.Errorf("failed to list functions: %w", err)
+	} else if resp.JSON200 == nil {
+		return nil, errors.New("Unexpected error listing Functions: " + string(resp.Body))
+	}
+	var slugs []string
+	for _, f := range *resp.JSON200 {
+		slugs = append(slugs, f.Slug)
+	}
+	sort.Strings(slugs)
+	return slugs, nil
+}
@@ -0,0 +1,93 @@
+package delete
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/testing/fstest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestMatchSlugs(t *testing.T) {
+	known := []string{"payments-stripe", "payments-paypal", "webhooks"}
+
+	t.Run("expands glob patterns", func(t *testing.T) {
+		slugs, err := MatchSlugs([]string{"payments-*", "hello"}, known)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"hello", "payments-paypal", "payments-stripe"}, slugs)
+	})
+
+	t.Run("throws error on malformed slug", func(t *testing.T) {
+		_, err := MatchSlugs([]string{"@"}, known)
+		assert.ErrorContains(t, err, "Invalid Function name.")
+	})
+}
+
+func TestDeleteBulk(t *testing.T) {
+	// Setup valid project ref
+	project := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("deletes matching remote functions", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "payments-local", "index.ts"), []byte{}, 0644))
+		defer fstest.MockStdin(t, "y")()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{{Slug: "payments-remote"}, {Slug: "webhooks"}})
+		gock.New(utils.DefaultApiHost).
+			Delete("/v1/projects/" + project + "/functions/payments-remote").
+			Reply(http.StatusOK)
+		// Run test
+		err := RunBulk(context.Background(), []string{"payments-*"}, false, project, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("skips functions already deleted", func(t *testing.T) {
+		defer fstest.MockStdin(t, "y")()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{{Slug: "webhooks"}})
+		gock.New(utils.DefaultApiHost).
+			Delete("/v1/projects/" + project + "/functions/webhooks").
+			Reply(http.StatusNotFound)
+		// Run test
+		err := RunBulk(context.Background(), nil, true, project, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error when nothing matches", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{})
+		// Run test
+		err := RunBulk(context.Background(), nil, true, project, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "No Functions matched the given names.")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/supabase/cli/internal/utils"
 )
 
+var errNotFound = errors.New("does not exist on the Supabase project.")
+
 func Run(ctx context.Context, slug string, projectRef string, fsys afero.Fs) error {
 	// 1. Sanity checks.
 	{
@@ -25,7 +27,7 @@ func Run(ctx context.Context, slug string, projectRef string, fsys afero.Fs) err
 	}
 	switch resp.StatusCode() {
 	case http.StatusNotFound:
-		return errors.New("Function " + utils.Aqua(slug) + " does not exist on the Supabase project.")
+		return errors.Errorf("Function %s %w", utils.Aqua(slug), errNotFound)
 	case http.StatusOK:
 		break
 	default:
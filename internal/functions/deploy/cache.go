@@ -0,0 +1,232 @@
+package deploy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+
+	"github.com/docker/docker/client"
+	"github.com/go-errors/errors"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Bump this to invalidate all cached bundles when the eszip format changes.
+const bundleCacheVersion = "1"
+
+// Transitive dependencies in a pnpm store are reached through sibling links that are never
+// followed from the functions directory, so their versions are pinned by the project lockfile.
+var projectLockfiles = []string{"deno.lock", "package-lock.json", "pnpm-lock.yaml", "yarn.lock", "bun.lockb"}
+
+var BundleCacheDir = filepath.Join(utils.TempDir, "cache")
+
+// Bundles a function unless a compressed eszip built from identical inputs is
+// already cached. The returned eszip is always brotli compressed.
+func bundleWithCache(ctx context.Context, slug, hostImportMapPath string, opts CompressOptions, fsys afero.Fs) (*eszipFunction, error) {
+	result, err := newEszipFunction(slug, hostImportMapPath)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := getRuntimeDigest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	key, err := bundleCacheKey(slug, hostImportMapPath, digest, *result, opts, fsys)
+	if err != nil {
+		return nil, err
+	}
+	result.eszipPath = filepath.Join(BundleCacheDir, key+".eszip.br")
+	if exists, err := afero.Exists(fsys, result.eszipPath); err != nil {
+		return nil, errors.Errorf("failed to check bundle cache: %w", err)
+	} else if exists {
+		fmt.Fprintln(utils.GetStatusWriter(), "Using cached bundle for "+utils.Bold(slug))
+		return result, nil
+	}
+	fmt.Fprintln(utils.GetStatusWriter(), "Bundling "+utils.Bold(slug))
+	hostOutputDir, cleanup, err := newOutputDir(slug, fsys)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+	eszip, err := bundleFunction(ctx, slug, hostImportMapPath, hostOutputDir, fsys)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeCache(result.eszipPath, eszip.eszipPath, opts, fsys); err != nil {
+		return nil, err
+	}
+	eszip.eszipPath = result.eszipPath
+	return eszip, nil
+}
+
+// Returns the id of the local edge runtime image, which changes even if a tag is
+// repointed to a different build.
+func getRuntimeDigest(ctx context.Context) (string, error) {
+	imageUrl := utils.GetRegistryImageUrl(utils.Config.EdgeRuntime.Image)
+	resp, _, err := utils.Docker.ImageInspectWithRaw(ctx, imageUrl)
+	if client.IsErrNotFound(err) {
+		if err := utils.DockerPullImageIfNotCached(ctx, utils.Config.EdgeRuntime.Image); err != nil {
+			return "", err
+		}
+		resp, _, err = utils.Docker.ImageInspectWithRaw(ctx, imageUrl)
+	}
+	if err != nil {
+		return "", errors.Errorf("failed to inspect docker image: %w", err)
+	}
+	return resp.ID, nil
+}
+
+// Mirrors the paths used by bundleFunction so that a cached bundle deploys with the same paths.
+func newEszipFunction(slug, hostImportMapPath string) (*eszipFunction, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, errors.Errorf("failed to get working directory: %w", err)
+	}
+	dockerFuncDir := utils.ToDockerPath(filepath.Join(cwd, utils.FunctionsDir))
+	result := eszipFunction{
+		entrypointPath: path.Join(dockerFuncDir, slug, "index.ts"),
+		importMapPath:  path.Join(dockerFuncDir, "import_map.json"),
+	}
+	if hostImportMapPath != "" {
+		absImportMapPath, err := filepath.Abs(hostImportMapPath)
+		if err != nil {
+			return nil, errors.Errorf("failed to resolve host import map: %w", err)
+		}
+		result.importMapPath = utils.ToDockerPath(absImportMapPath)
+	}
+	return &result, nil
+}
+
+// Hashes all function sources not matched by .funcignore, since shared modules may be
+// imported from outside the function directory, together with the import map, project lockfiles and runtime image digest.
+func bundleCacheKey(slug, hostImportMapPath, imageDigest string, eszip eszipFunction, opts CompressOptions, fsys afero.Fs) (string, error) {
+	hash := sha256.New()
+	for _, field := range []string{
+		bundleCacheVersion,
+		slug,
+		imageDigest,
+		eszip.entrypointPath,
+		eszip.importMapPath,
+		strconv.Itoa(opts.Level),
+	} {
+		fmt.Fprintln(hash, field)
+	}
+	if hostImportMapPath != "" {
+		if err := hashFile(hash, hostImportMapPath, fsys); err != nil {
+			return "", err
+		}
+	}
+	matcher, err := loadFuncIgnore(fsys)
+	if err != nil {
+		return "", err
+	}
+	for _, lockfile := range projectLockfiles {
+		if err := hashFile(hash, lockfile, fsys); errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return "", err
+		}
+		fmt.Fprintln(hash, lockfile)
+	}
+	if err := hashDir(hash, utils.FunctionsDir, matcher, &[]fs.FileInfo{}, fsys); err != nil {
+		return "", errors.Errorf("failed to hash function sources: %w", err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Follows symlinks like utils.BindSymlinks, so that edits to shared modules linked from outside
+// the functions directory also invalidate the cache. Each linked directory is hashed once, which
+// guards against cycles such as those in pnpm's node_modules.
+func hashDir(w io.Writer, dir string, matcher gitignore.Matcher, linked *[]fs.FileInfo, fsys afero.Fs) error {
+	entries, err := afero.ReadDir(fsys, dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for _, info := range entries {
+		filePath := filepath.Join(dir, info.Name())
+		if info.Mode()&fs.ModeSymlink != 0 {
+			if info, err = fsys.Stat(filePath); err != nil {
+				// Dangling links fail the same way when bundling
+				fmt.Fprintln(utils.GetDebugLogger(), err)
+				continue
+			}
+			if info.IsDir() {
+				if containsFile(*linked, info) {
+					continue
+				}
+				*linked = append(*linked, info)
+			}
+		}
+		// Changes to ignored files never reach the bundle
+		if matcher != nil && isIgnored(matcher, filePath, info.IsDir()) {
+			continue
+		}
+		fmt.Fprintln(w, filepath.ToSlash(filePath))
+		if info.IsDir() {
+			if err := hashDir(w, filePath, matcher, linked, fsys); err != nil {
+				return err
+			}
+		} else if err := hashFile(w, filePath, fsys); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func containsFile(infos []fs.FileInfo, info fs.FileInfo) bool {
+	for _, i := range infos {
+		if os.SameFile(i, info) {
+			return true
+		}
+	}
+	return false
+}
+
+func hashFile(w io.Writer, filePath string, fsys afero.Fs) error {
+	f, err := fsys.Open(filePath)
+	if err != nil {
+		return errors.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(w, f); err != nil {
+		return errors.Errorf("failed to hash file: %w", err)
+	}
+	return nil
+}
+
+// Writes to a unique temp file first so that an interrupted or concurrent deploy never
+// leaves a partial cache entry.
+func writeCache(cachePath, eszipPath string, opts CompressOptions, fsys afero.Fs) error {
+	if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(cachePath)); err != nil {
+		return err
+	}
+	f, err := afero.TempFile(fsys, filepath.Dir(cachePath), filepath.Base(cachePath)+".*.tmp")
+	if err != nil {
+		return errors.Errorf("failed to create cache file: %w", err)
+	}
+	tmpPath := f.Name()
+	if err := compressEszip(f, eszipPath, opts, fsys); err != nil {
+		f.Close()
+		if err := fsys.Remove(tmpPath); err != nil {
+			fmt.Fprintln(utils.GetDebugLogger(), err)
+		}
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return errors.Errorf("failed to close cache file: %w", err)
+	}
+	if err := fsys.Rename(tmpPath, cachePath); err != nil {
+		return errors.Errorf("failed to rename cache file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package deploy
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestBundleCacheKey(t *testing.T) {
+	const slug = "test-func"
+	entrypoint := filepath.Join(utils.FunctionsDir, slug, "index.ts")
+	eszip := eszipFunction{entrypointPath: "/functions/" + slug + "/index.ts"}
+
+	t.Run("changes with function source", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte("v1"), 0644))
+		before, err := bundleCacheKey(slug, "", "sha256:test", eszip, CompressOptions{}, fsys)
+		require.NoError(t, err)
+		// Run test
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte("v2"), 0644))
+		after, err := bundleCacheKey(slug, "", "sha256:test", eszip, CompressOptions{}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.NotEqual(t, before, after)
+	})
+
+	t.Run("ignores files in funcignore", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte("v1"), 0644))
+		ignorePath := filepath.Join(utils.FunctionsDir, slug, ".funcignore")
+		require.NoError(t, afero.WriteFile(fsys, ignorePath, []byte("README.md"), 0644))
+		readme := filepath.Join(utils.FunctionsDir, slug, "README.md")
+		require.NoError(t, afero.WriteFile(fsys, readme, []byte("v1"), 0644))
+		before, err := bundleCacheKey(slug, "", "sha256:test", eszip, CompressOptions{}, fsys)
+		require.NoError(t, err)
+		// Run test
+		require.NoError(t, afero.WriteFile(fsys, readme, []byte("v2"), 0644))
+		after, err := bundleCacheKey(slug, "", "sha256:test", eszip, CompressOptions{}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, before, after)
+	})
+
+	t.Run("changes with compression level", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte("v1"), 0644))
+		before, err := bundleCacheKey(slug, "", "sha256:test", eszip, CompressOptions{Level: 6}, fsys)
+		require.NoError(t, err)
+		// Run test
+		after, err := bundleCacheKey(slug, "", "sha256:test", eszip, CompressOptions{Level: 11}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.NotEqual(t, before, after)
+	})
+
+	t.Run("changes with image digest", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte("v1"), 0644))
+		before, err := bundleCacheKey(slug, "", "sha256:v1", eszip, CompressOptions{}, fsys)
+		require.NoError(t, err)
+		// Run test
+		after, err := bundleCacheKey(slug, "", "sha256:v2", eszip, CompressOptions{}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.NotEqual(t, before, after)
+	})
+
+	t.Run("follows symlinked directories", func(t *testing.T) {
+		root := t.TempDir()
+		fsys := afero.NewBasePathFs(afero.NewOsFs(), root)
+		require.NoError(t, fsys.MkdirAll(filepath.Dir(entrypoint), 0755))
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte("v1"), 0644))
+		shared := filepath.Join(root, "packages", "shared")
+		require.NoError(t, os.MkdirAll(shared, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(shared, "mod.ts"), []byte("v1"), 0644))
+		require.NoError(t, os.Symlink("../../packages/shared", filepath.Join(root, utils.FunctionsDir, "_shared")))
+		// Links back to an ancestor are only hashed once
+		require.NoError(t, os.Symlink("..", filepath.Join(shared, "parent")))
+		before, err := bundleCacheKey(slug, "", "sha256:test", eszip, CompressOptions{}, fsys)
+		require.NoError(t, err)
+		// Run test
+		require.NoError(t, os.WriteFile(filepath.Join(shared, "mod.ts"), []byte("v2"), 0644))
+		after, err := bundleCacheKey(slug, "", "sha256:test", eszip, CompressOptions{}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.NotEqual(t, before, after)
+	})
+
+	t.Run("follows pnpm node modules", func(t *testing.T) {
+		root := t.TempDir()
+		fsys := afero.NewBasePathFs(afero.NewOsFs(), root)
+		require.NoError(t, fsys.MkdirAll(filepath.Dir(entrypoint), 0755))
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte("v1"), 0644))
+		modules := filepath.Join(root, utils.FunctionsDir, slug, "node_modules")
+		pkg := filepath.Join(root, "node_modules", ".pnpm", "a@1.0.0", "node_modules", "a")
+		require.NoError(t, os.MkdirAll(modules, 0755))
+		require.NoError(t, os.MkdirAll(pkg, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(pkg, "index.js"), []byte("v1"), 0644))
+		require.NoError(t, os.Symlink("../../../../node_modules/.pnpm/a@1.0.0/node_modules/a", filepath.Join(modules, "a")))
+		before, err := bundleCacheKey(slug, "", "sha256:test", eszip, CompressOptions{}, fsys)
+		require.NoError(t, err)
+		// Run test
+		require.NoError(t, os.WriteFile(filepath.Join(pkg, "index.js"), []byte("v2"), 0644))
+		after, err := bundleCacheKey(slug, "", "sha256:test", eszip, CompressOptions{}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.NotEqual(t, before, after)
+	})
+
+	t.Run("changes with project lockfile", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte("v1"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, "pnpm-lock.yaml", []byte("v1"), 0644))
+		before, err := bundleCacheKey(slug, "", "sha256:test", eszip, CompressOptions{}, fsys)
+		require.NoError(t, err)
+		// Run test
+		require.NoError(t, afero.WriteFile(fsys, "pnpm-lock.yaml", []byte("v2"), 0644))
+		after, err := bundleCacheKey(slug, "", "sha256:test", eszip, CompressOptions{}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.NotEqual(t, before, after)
+	})
+
+	t.Run("throws error on missing import map", func(t *testing.T) {
+		// Run test
+		_, err := bundleCacheKey(slug, "import_map.json", "sha256:test", eszip, CompressOptions{}, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "failed to open file:")
+	})
+}
+
+func TestBundleWithCache(t *testing.T) {
+	const slug = "test-func"
+
+	t.Run("reuses cached bundle", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		entrypoint := filepath.Join(utils.FunctionsDir, slug, "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte("hello"), 0644))
+		// Setup cached bundle
+		expected, err := newEszipFunction(slug, "")
+		require.NoError(t, err)
+		key, err := bundleCacheKey(slug, "", "sha256:test", *expected, CompressOptions{}, fsys)
+		require.NoError(t, err)
+		cachePath := filepath.Join(BundleCacheDir, key+".eszip.br")
+		require.NoError(t, afero.WriteFile(fsys, cachePath, []byte(compressedEszipMagicId), 0644))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		imageUrl := utils.GetRegistryImageUrl(utils.Config.EdgeRuntime.Image)
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/images/" + imageUrl + "/json").
+			Reply(http.StatusOK).
+			JSON(types.ImageInspect{ID: "sha256:test"})
+		// Run test
+		eszip, err := bundleWithCache(context.Background(), slug, "", CompressOptions{}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, cachePath, eszip.eszipPath)
+		assert.Equal(t, expected.entrypointPath, eszip.entrypointPath)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("writes compressed eszip to cache", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "output.eszip", []byte("eszip"), 0644))
+		cachePath := filepath.Join(BundleCacheDir, "test.eszip.br")
+		// Run test
+		err := writeCache(cachePath, "output.eszip", CompressOptions{Level: 6}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		compressed, err := isCompressed(cachePath, fsys)
+		assert.NoError(t, err)
+		assert.True(t, compressed)
+		tmpFiles, err := afero.Glob(fsys, cachePath+".*.tmp")
+		assert.NoError(t, err)
+		assert.Empty(t, tmpFiles)
+	})
+}
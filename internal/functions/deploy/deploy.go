@@ -1,7 +1,6 @@
 package deploy
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -9,16 +8,17 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"time"
 
 	"github.com/andybalholm/brotli"
-	"github.com/cenkalti/backoff/v4"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
-	"github.com/docker/go-units"
 	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
 	"github.com/spf13/viper"
 	"github.com/supabase/cli/internal/db/start"
+	"github.com/supabase/cli/internal/functions/audit"
+	"github.com/supabase/cli/internal/hooks"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/pkg/api"
 )
@@ -28,7 +28,7 @@ const (
 	compressedEszipMagicId = "EZBR"
 )
 
-func Run(ctx context.Context, slugs []string, projectRef string, noVerifyJWT *bool, importMapPath string, fsys afero.Fs) error {
+func Run(ctx context.Context, slugs []string, projectRef string, noVerifyJWT *bool, importMapPath, failOn string, fsys afero.Fs, options ...func(*CompressOptions)) error {
 	// Load function config and project id
 	if err := utils.LoadConfigFS(fsys); err != nil {
 		return err
@@ -49,7 +49,18 @@ func Run(ctx context.Context, slugs []string, projectRef string, noVerifyJWT *bo
 	if len(slugs) == 0 {
 		return errors.New("No Functions specified or found in " + utils.Bold(utils.FunctionsDir))
 	}
-	return deployAll(ctx, slugs, projectRef, importMapPath, noVerifyJWT, fsys)
+	if err := audit.Run(ctx, slugs, importMapPath, failOn, fsys); err != nil {
+		return err
+	}
+	if err := checkSecrets(ctx, slugs, projectRef, failOn, nil, fsys); err != nil {
+		return err
+	}
+	started := time.Now()
+	err := deployAll(ctx, slugs, projectRef, importMapPath, noVerifyJWT, fsys, options...)
+	payload := hooks.NewPayload(utils.HookFunctionsDeploy, projectRef, started, err)
+	payload.Slugs = slugs
+	hooks.Notify(ctx, payload)
+	return err
 }
 
 func RunDefault(ctx context.Context, projectRef string, fsys afero.Fs) error {
@@ -77,41 +88,93 @@ func GetFunctionSlugs(fsys afero.Fs) ([]string, error) {
 }
 
 type eszipFunction struct {
-	compressedBody *bytes.Buffer
+	eszipPath      string
 	entrypointPath string
 	importMapPath  string
 }
 
-func bundleFunction(ctx context.Context, slug, hostImportMapPath string, fsys afero.Fs) (*eszipFunction, error) {
-	cwd, err := os.Getwd()
-	if err != nil {
-		return nil, errors.Errorf("failed to get working directory: %w", err)
+type CompressOptions struct {
+	// Brotli quality level between 0 and 11.
+	Level int
+	// Uploads the raw eszip which is useful for debugging the bundle.
+	Disabled bool
+}
+
+func WithCompressLevel(level int) func(*CompressOptions) {
+	return func(co *CompressOptions) {
+		co.Level = level
 	}
+}
 
-	// Create temp directory to store generated eszip
-	hostOutputDir := filepath.Join(utils.TempDir, fmt.Sprintf(".output_%s", slug))
+func WithoutCompression() func(*CompressOptions) {
+	return func(co *CompressOptions) {
+		co.Disabled = true
+	}
+}
+
+func NewCompressOptions(options ...func(*CompressOptions)) (CompressOptions, error) {
+	result := CompressOptions{Level: brotli.DefaultCompression}
+	for _, apply := range options {
+		apply(&result)
+	}
+	if result.Level < brotli.BestSpeed || result.Level > brotli.BestCompression {
+		return result, errors.Errorf("Invalid compression level %d: must be between %d and %d", result.Level, brotli.BestSpeed, brotli.BestCompression)
+	}
+	return result, nil
+}
+
+// Creates a temp directory to store generated eszip. The caller must invoke cleanup when done.
+func newOutputDir(slug string, fsys afero.Fs) (string, func(), error) {
+	hostOutputDir := filepath.Join(utils.RunTempDir, fmt.Sprintf(".output_%s", slug))
 	// BitBucket pipelines require docker bind mounts to be world writable
 	if err := fsys.MkdirAll(hostOutputDir, 0777); err != nil {
-		return nil, errors.Errorf("failed to mkdir: %w", err)
+		return "", nil, errors.Errorf("failed to mkdir: %w", err)
 	}
-	defer func() {
+	return hostOutputDir, func() {
 		if err := fsys.RemoveAll(hostOutputDir); err != nil {
 			fmt.Fprintln(os.Stderr, err)
 		}
-	}()
+	}, nil
+}
+
+func bundleFunction(ctx context.Context, slug, hostImportMapPath, hostOutputDir string, fsys afero.Fs) (*eszipFunction, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, errors.Errorf("failed to get working directory: %w", err)
+	}
 
 	hostFuncDir := filepath.Join(cwd, utils.FunctionsDir)
 	dockerFuncDir := utils.ToDockerPath(hostFuncDir)
 
+	bindSource := utils.ResolveBindSource(hostFuncDir)
+	matcher, err := loadFuncIgnore(fsys)
+	if err != nil {
+		return nil, err
+	}
+	if matcher != nil {
+		// Ignored files are left out of the staged copy mounted in place of the functions directory
+		stagingDir := filepath.Join(hostOutputDir, "functions")
+		if err := stageFunctions(stagingDir, matcher, fsys); err != nil {
+			return nil, err
+		}
+		bindSource = filepath.Join(cwd, stagingDir)
+	}
+
 	outputPath := utils.DockerEszipDir + "/output.eszip"
 	binds := []string{
 		// Reuse deno cache directory, ie. DENO_DIR, between container restarts
 		// https://denolib.gitbook.io/guide/advanced/deno_dir-code-fetch-and-cache
 		utils.EdgeRuntimeId + ":/root/.cache/deno:rw",
-		hostFuncDir + ":" + dockerFuncDir + ":ro",
+		bindSource + ":" + dockerFuncDir + ":ro",
 		filepath.Join(cwd, hostOutputDir) + ":" + utils.DockerEszipDir + ":rw",
 	}
 
+	links, err := utils.BindSymlinks(hostFuncDir)
+	if err != nil {
+		return nil, err
+	}
+	binds = append(binds, links...)
+
 	result := eszipFunction{
 		entrypointPath: path.Join(dockerFuncDir, slug, "index.ts"),
 		importMapPath:  path.Join(dockerFuncDir, "import_map.json"),
@@ -131,41 +194,99 @@ func bundleFunction(ctx context.Context, slug, hostImportMapPath string, fsys af
 		cmd = append(cmd, "--import-map", result.importMapPath)
 	}
 
+	span := utils.StartSpan("functions", "bundle "+slug)
 	err = utils.DockerRunOnceWithConfig(
 		ctx,
 		container.Config{
-			Image: utils.EdgeRuntimeImage,
+			Image: utils.Config.EdgeRuntime.Image,
 			Env:   []string{},
 			Cmd:   cmd,
 		},
 		start.WithSyslogConfig(container.HostConfig{
-			Binds: binds,
+			Binds: utils.RemoveDuplicates(binds),
 		}),
 		network.NetworkingConfig{},
 		"",
-		os.Stdout,
+		utils.GetVerboseLogger(),
 		os.Stderr,
 	)
+	span.Finish(err)
 	if err != nil {
-		return nil, err
+		return nil, utils.WithCode(utils.CodeBundleFailed, err)
 	}
 
-	eszipBytes, err := fsys.Open(filepath.Join(hostOutputDir, "output.eszip"))
+	result.eszipPath = filepath.Join(hostOutputDir, "output.eszip")
+	return &result, nil
+}
+
+// Streams the eszip file through brotli so the bundle is never fully buffered in memory.
+func compressEszip(w io.Writer, eszipPath string, opts CompressOptions, fsys afero.Fs) error {
+	eszip, err := fsys.Open(eszipPath)
 	if err != nil {
-		return nil, errors.Errorf("failed to open eszip: %w", err)
+		return errors.Errorf("failed to open eszip: %w", err)
+	}
+	defer eszip.Close()
+	return compress(w, eszip, opts)
+}
+
+func compress(w io.Writer, r io.Reader, opts CompressOptions) error {
+	if opts.Disabled {
+		if _, err := io.Copy(w, r); err != nil {
+			return errors.Errorf("failed to copy eszip: %w", err)
+		}
+		return nil
+	}
+	if _, err := io.WriteString(w, compressedEszipMagicId); err != nil {
+		return errors.Errorf("failed to write eszip header: %w", err)
 	}
-	defer eszipBytes.Close()
+	brw := brotli.NewWriterLevel(w, opts.Level)
+	if _, err := io.Copy(brw, r); err != nil {
+		return errors.Errorf("failed to compress brotli: %w", err)
+	}
+	if err := brw.Close(); err != nil {
+		return errors.Errorf("failed to flush brotli: %w", err)
+	}
+	return nil
+}
 
-	result.compressedBody = bytes.NewBufferString(compressedEszipMagicId)
-	brw := brotli.NewWriter(result.compressedBody)
-	defer brw.Close()
+// Returns a reader that compresses the eszip on demand as the request body is sent.
+func streamEszip(eszipPath string, opts CompressOptions, fsys afero.Fs) io.ReadCloser {
+	r, w := io.Pipe()
+	go func() {
+		w.CloseWithError(uploadProgress(w, eszipPath, opts, fsys))
+	}()
+	return r
+}
 
-	_, err = io.Copy(brw, eszipBytes)
+// Reports upload progress by tracking how much of the source eszip has been consumed.
+func uploadProgress(w io.Writer, eszipPath string, opts CompressOptions, fsys afero.Fs) error {
+	eszip, err := fsys.Open(eszipPath)
+	if err != nil {
+		return errors.Errorf("failed to open eszip: %w", err)
+	}
+	defer eszip.Close()
+	info, err := eszip.Stat()
 	if err != nil {
-		return nil, errors.Errorf("failed to compress brotli: %w", err)
+		return errors.Errorf("failed to stat eszip: %w", err)
 	}
+	return compress(w, utils.NewProgressReader(eszip, "Uploading", info.Size()), opts)
+}
 
-	return &result, nil
+// Bundles a function without deploying it, useful for checking that it compiles.
+func Bundle(ctx context.Context, slug, importMapPath string, fsys afero.Fs) error {
+	fc := utils.GetFunctionConfig(slug, importMapPath, nil, fsys)
+	if err := runHook(ctx, HookPreBundle, fc.PreBundle, slug, ""); err != nil {
+		return err
+	}
+	hostOutputDir, cleanup, err := newOutputDir(slug, fsys)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	if _, err := bundleFunction(ctx, slug, fc.ImportMap, hostOutputDir, fsys); err != nil {
+		return err
+	}
+	return runHook(ctx, HookPostBundle, fc.PostBundle, slug, "")
 }
 
 func deployFunction(ctx context.Context, projectRef, slug, entrypointUrl, importMapUrl string, verifyJWT bool, functionBody io.Reader) error {
@@ -184,10 +305,10 @@ func deployFunction(ctx context.Context, projectRef, slug, entrypointUrl, import
 			EntrypointPath: &entrypointUrl,
 		}, eszipContentType, functionBody)
 		if err != nil {
-			return errors.Errorf("failed to create function: %w", err)
+			return utils.WithCode(utils.CodeDeployFailed, errors.Errorf("failed to create function: %w", err))
 		}
 		if resp.JSON201 == nil {
-			return errors.New("Failed to create a new Function on the Supabase project: " + string(resp.Body))
+			return utils.WithCode(utils.CodeDeployFailed, errors.New("Failed to create a new Function on the Supabase project: "+string(resp.Body)))
 		}
 	case http.StatusOK: // Function already exists, so do a PATCH
 		resp, err := utils.GetSupabase().V1UpdateAFunctionWithBodyWithResponse(ctx, projectRef, slug, &api.V1UpdateAFunctionParams{
@@ -196,13 +317,13 @@ func deployFunction(ctx context.Context, projectRef, slug, entrypointUrl, import
 			EntrypointPath: &entrypointUrl,
 		}, eszipContentType, functionBody)
 		if err != nil {
-			return errors.Errorf("failed to update function: %w", err)
+			return utils.WithCode(utils.CodeDeployFailed, errors.Errorf("failed to update function: %w", err))
 		}
 		if resp.JSON200 == nil {
-			return errors.New("Failed to update an existing Function's body on the Supabase project: " + string(resp.Body))
+			return utils.WithCode(utils.CodeDeployFailed, errors.New("Failed to update an existing Function's body on the Supabase project: "+string(resp.Body)))
 		}
 	default:
-		return errors.New("Unexpected error deploying Function: " + string(resp.Body))
+		return utils.WithCode(utils.CodeDeployFailed, errors.New("Unexpected error deploying Function: "+string(resp.Body)))
 	}
 
 	fmt.Println("Deployed Function " + utils.Aqua(slug) + " on project " + utils.Aqua(projectRef))
@@ -211,35 +332,51 @@ func deployFunction(ctx context.Context, projectRef, slug, entrypointUrl, import
 	return nil
 }
 
-func deployOne(ctx context.Context, slug, projectRef, importMapPath string, noVerifyJWT *bool, fsys afero.Fs) error {
+func deployOne(ctx context.Context, slug, projectRef, importMapPath string, noVerifyJWT *bool, fsys afero.Fs, options ...func(*CompressOptions)) error {
+	opts, err := NewCompressOptions(options...)
+	if err != nil {
+		return err
+	}
 	// 1. Bundle Function.
-	fmt.Println("Bundling " + utils.Bold(slug))
 	fc := utils.GetFunctionConfig(slug, importMapPath, noVerifyJWT, fsys)
-	eszip, err := bundleFunction(ctx, slug, fc.ImportMap, fsys)
-	if err != nil {
+	if err := runHook(ctx, HookPreBundle, fc.PreBundle, slug, projectRef); err != nil {
+		return err
+	}
+	var eszip *eszipFunction
+	if opts.Disabled {
+		fmt.Fprintln(utils.GetStatusWriter(), "Bundling "+utils.Bold(slug))
+		hostOutputDir, cleanup, err := newOutputDir(slug, fsys)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+		if eszip, err = bundleFunction(ctx, slug, fc.ImportMap, hostOutputDir, fsys); err != nil {
+			return err
+		}
+	} else {
+		if eszip, err = bundleWithCache(ctx, slug, fc.ImportMap, opts, fsys); err != nil {
+			return err
+		}
+		// Cached bundles are already compressed
+		opts.Disabled = true
+	}
+	if err := runHook(ctx, HookPostBundle, fc.PostBundle, slug, projectRef); err != nil {
 		return err
 	}
 	// 2. Deploy new Function.
-	functionSize := units.HumanSize(float64(eszip.compressedBody.Len()))
-	fmt.Println("Deploying " + utils.Bold(slug) + " (script size: " + utils.Bold(functionSize) + ")")
-	policy := backoff.WithContext(backoff.WithMaxRetries(backoff.NewExponentialBackOff(), 3), ctx)
-	return backoff.Retry(func() error {
-		return deployFunction(
-			ctx,
-			projectRef,
-			slug,
-			"file://"+eszip.entrypointPath,
-			"file://"+eszip.importMapPath,
-			*fc.VerifyJWT,
-			eszip.compressedBody,
-		)
-	}, policy)
-}
-
-func deployAll(ctx context.Context, slugs []string, projectRef, importMapPath string, noVerifyJWT *bool, fsys afero.Fs) error {
+	if err := runHook(ctx, HookPreDeploy, fc.PreDeploy, slug, projectRef); err != nil {
+		return err
+	}
+	if err := uploadEszip(ctx, slug, projectRef, eszip, *fc.VerifyJWT, opts, fsys); err != nil {
+		return err
+	}
+	return runHook(ctx, HookPostDeploy, fc.PostDeploy, slug, projectRef)
+}
+
+func deployAll(ctx context.Context, slugs []string, projectRef, importMapPath string, noVerifyJWT *bool, fsys afero.Fs, options ...func(*CompressOptions)) error {
 	// TODO: api has a race condition that prevents deploying in parallel
 	for _, slug := range slugs {
-		if err := deployOne(ctx, slug, projectRef, importMapPath, noVerifyJWT, fsys); err != nil {
+		if err := deployOne(ctx, slug, projectRef, importMapPath, noVerifyJWT, fsys, options...); err != nil {
 			return err
 		}
 	}
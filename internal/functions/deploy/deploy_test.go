@@ -6,16 +6,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/andybalholm/brotli"
+	"github.com/docker/docker/api/types"
 	"github.com/h2non/gock"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/functions/audit"
 	"github.com/supabase/cli/internal/testing/apitest"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/pkg/api"
@@ -51,11 +55,12 @@ func TestDeployOne(t *testing.T) {
 
 		// Setup mock docker
 		require.NoError(t, apitest.MockDocker(utils.Docker))
+		mockRuntimeImage(imageUrl)
 		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
 		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "bundled"))
 
 		// Setup output file
-		outputDir := filepath.Join(utils.TempDir, fmt.Sprintf(".output_%s", slug))
+		outputDir := filepath.Join(utils.RunTempDir, fmt.Sprintf(".output_%s", slug))
 		require.NoError(t, afero.WriteFile(fsys, filepath.Join(outputDir, "output.eszip"), []byte(""), 0644))
 
 		// Run test
@@ -90,11 +95,12 @@ func TestDeployOne(t *testing.T) {
 
 		// Setup mock docker
 		require.NoError(t, apitest.MockDocker(utils.Docker))
+		mockRuntimeImage(imageUrl)
 		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
 		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "bundled"))
 
 		// Setup output file
-		outputDir := filepath.Join(utils.TempDir, fmt.Sprintf(".output_%s", slug))
+		outputDir := filepath.Join(utils.RunTempDir, fmt.Sprintf(".output_%s", slug))
 		require.NoError(t, afero.WriteFile(fsys, filepath.Join(outputDir, "output.eszip"), []byte(""), 0644))
 
 		// Run test
@@ -109,6 +115,10 @@ func TestDeployOne(t *testing.T) {
 		fsys := afero.NewMemMapFs()
 		// Setup valid project ref
 		project := apitest.RandomProjectRef()
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		mockRuntimeImage(imageUrl)
 		// Run test
 		err := deployOne(context.Background(), slug, project, "import_map.json", nil, fsys)
 		// Check error
@@ -138,6 +148,7 @@ func TestDeployOne(t *testing.T) {
 
 		// Setup mock docker
 		require.NoError(t, apitest.MockDocker(utils.Docker))
+		mockRuntimeImage(imageUrl)
 		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
 		require.NoError(t, apitest.MockDockerLogsExitCode(utils.Docker, containerId, 1))
 
@@ -147,6 +158,69 @@ func TestDeployOne(t *testing.T) {
 		assert.ErrorContains(t, err, "error running container: exit 1")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
+
+	t.Run("runs hooks around each stage", func(t *testing.T) {
+		logPath := filepath.Join(t.TempDir(), "hooks.log")
+		record := func(stage string) string {
+			return fmt.Sprintf(`echo "%s $SUPABASE_FUNCTION_SLUG $SUPABASE_PROJECT_REF" >> "%s"`, stage, logPath)
+		}
+		utils.Config.FunctionHooks = utils.FunctionHooks{
+			PreBundle:  record(HookPreBundle),
+			PostBundle: record(HookPostBundle),
+			PreDeploy:  record(HookPreDeploy),
+			PostDeploy: record(HookPostDeploy),
+		}
+		t.Cleanup(func() { utils.Config.FunctionHooks = utils.FunctionHooks{} })
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions/" + slug).
+			Reply(http.StatusNotFound)
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/functions").
+			Reply(http.StatusCreated).
+			JSON(api.FunctionResponse{Id: "1"})
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
+		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "bundled"))
+		// Setup output file
+		outputDir := filepath.Join(utils.RunTempDir, fmt.Sprintf(".output_%s", slug))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(outputDir, "output.eszip"), []byte(""), 0644))
+		// Run test
+		err := deployOne(context.Background(), slug, project, "", nil, fsys, WithoutCompression())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		data, err := os.ReadFile(logPath)
+		require.NoError(t, err)
+		assert.Equal(t, strings.Join([]string{
+			"pre_bundle " + slug + " " + project,
+			"post_bundle " + slug + " " + project,
+			"pre_deploy " + slug + " " + project,
+			"post_deploy " + slug + " " + project,
+		}, "\n")+"\n", string(data))
+	})
+
+	t.Run("throws error on hook failure", func(t *testing.T) {
+		utils.Config.FunctionHooks.PreBundle = "exit 1"
+		t.Cleanup(func() { utils.Config.FunctionHooks = utils.FunctionHooks{} })
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Run test
+		err := deployOne(context.Background(), slug, project, "", nil, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to run pre_bundle hook for test-func: exit status 1")
+	})
 }
 
 func TestDeployAll(t *testing.T) {
@@ -179,13 +253,14 @@ func TestDeployAll(t *testing.T) {
 
 			// Setup mock docker
 			require.NoError(t, apitest.MockDocker(utils.Docker))
+			mockRuntimeImage(imageUrl)
 			apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
 			require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "bundled"))
 		}
 
 		// Setup output file
 		for _, v := range functions {
-			outputDir := filepath.Join(utils.TempDir, fmt.Sprintf(".output_%s", v))
+			outputDir := filepath.Join(utils.RunTempDir, fmt.Sprintf(".output_%s", v))
 			require.NoError(t, afero.WriteFile(fsys, filepath.Join(outputDir, "output.eszip"), []byte(""), 0644))
 		}
 
@@ -202,6 +277,10 @@ func TestDeployAll(t *testing.T) {
 		fsys := afero.NewReadOnlyFs(afero.NewMemMapFs())
 		// Setup valid project ref
 		project := apitest.RandomProjectRef()
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		mockRuntimeImage(imageUrl)
 		// Run test
 		err := deployAll(context.Background(), []string{slug}, project, "", nil, fsys)
 		// Check error
@@ -216,6 +295,10 @@ func TestDeployAll(t *testing.T) {
 		// Setup valid deno path
 		_, err := fsys.Create(utils.DenoPathOverride)
 		require.NoError(t, err)
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		mockRuntimeImage(imageUrl)
 		// Run test
 		err = deployAll(context.Background(), []string{slug}, project, "", nil, afero.NewReadOnlyFs(fsys))
 		// Check error
@@ -257,19 +340,20 @@ func TestDeployCommand(t *testing.T) {
 
 				// Setup mock docker
 			require.NoError(t, apitest.MockDocker(utils.Docker))
+			mockRuntimeImage(imageUrl)
 			apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
 			require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "bundled"))
 		}
 
 		// Setup output file
 		for _, v := range functions {
-			outputDir := filepath.Join(utils.TempDir, fmt.Sprintf(".output_%s", v))
+			outputDir := filepath.Join(utils.RunTempDir, fmt.Sprintf(".output_%s", v))
 			require.NoError(t, afero.WriteFile(fsys, filepath.Join(outputDir, "output.eszip"), []byte(""), 0644))
 		}
 
 		// Run test
 		noVerifyJWT := true
-		err = Run(context.Background(), functions, project, &noVerifyJWT, "", fsys)
+		err = Run(context.Background(), functions, project, &noVerifyJWT, "", audit.FailOnErrors, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -309,15 +393,16 @@ func TestDeployCommand(t *testing.T) {
 
 		// Setup mock docker
 		require.NoError(t, apitest.MockDocker(utils.Docker))
+		mockRuntimeImage(imageUrl)
 		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
 		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "bundled"))
 
 		// Setup output file
-		outputDir := filepath.Join(utils.TempDir, fmt.Sprintf(".output_%s", slug))
+		outputDir := filepath.Join(utils.RunTempDir, fmt.Sprintf(".output_%s", slug))
 		require.NoError(t, afero.WriteFile(fsys, filepath.Join(outputDir, "output.eszip"), []byte(""), 0644))
 
 		// Run test
-		err = Run(context.Background(), nil, project, nil, "", fsys)
+		err = Run(context.Background(), nil, project, nil, "", audit.FailOnErrors, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -328,7 +413,7 @@ func TestDeployCommand(t *testing.T) {
 		fsys := afero.NewMemMapFs()
 		require.NoError(t, utils.WriteConfig(fsys, false))
 		// Run test
-		err := Run(context.Background(), []string{"_invalid"}, "", nil, "", fsys)
+		err := Run(context.Background(), []string{"_invalid"}, "", nil, "", audit.FailOnErrors, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "Invalid Function name.")
 	})
@@ -339,7 +424,7 @@ func TestDeployCommand(t *testing.T) {
 		require.NoError(t, utils.WriteConfig(fsys, false))
 		require.NoError(t, fsys.MkdirAll(utils.FunctionsDir, 0755))
 		// Run test
-		err := Run(context.Background(), nil, "", nil, "", fsys)
+		err := Run(context.Background(), nil, "", nil, "", audit.FailOnErrors, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "No Functions specified or found in supabase/functions")
 	})
@@ -377,15 +462,16 @@ verify_jwt = false
 
 		// Setup mock docker
 		require.NoError(t, apitest.MockDocker(utils.Docker))
+		mockRuntimeImage(imageUrl)
 		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
 		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "bundled"))
 
 		// Setup output file
-		outputDir := filepath.Join(utils.TempDir, fmt.Sprintf(".output_%s", slug))
+		outputDir := filepath.Join(utils.RunTempDir, fmt.Sprintf(".output_%s", slug))
 		require.NoError(t, afero.WriteFile(fsys, filepath.Join(outputDir, "output.eszip"), []byte(""), 0644))
 
 		// Run test
-		assert.NoError(t, Run(context.Background(), []string{slug}, project, nil, "", fsys))
+		assert.NoError(t, Run(context.Background(), []string{slug}, project, nil, "", audit.FailOnErrors, fsys))
 		// Validate api
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
@@ -423,16 +509,17 @@ verify_jwt = false
 
 		// Setup mock docker
 		require.NoError(t, apitest.MockDocker(utils.Docker))
+		mockRuntimeImage(imageUrl)
 		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
 		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "bundled"))
 
 		// Setup output file
-		outputDir := filepath.Join(utils.TempDir, fmt.Sprintf(".output_%s", slug))
+		outputDir := filepath.Join(utils.RunTempDir, fmt.Sprintf(".output_%s", slug))
 		require.NoError(t, afero.WriteFile(fsys, filepath.Join(outputDir, "output.eszip"), []byte(""), 0644))
 
 		// Run test
 		noVerifyJwt := false
-		assert.NoError(t, Run(context.Background(), []string{slug}, project, &noVerifyJwt, "", fsys))
+		assert.NoError(t, Run(context.Background(), []string{slug}, project, &noVerifyJwt, "", audit.FailOnErrors, fsys))
 		// Validate api
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
@@ -533,3 +620,60 @@ func TestDeployFunction(t *testing.T) {
 		assert.ErrorContains(t, err, "Failed to update an existing Function's body on the Supabase project:")
 	})
 }
+
+func TestCompressEszip(t *testing.T) {
+	const eszipPath = "output.eszip"
+	// Setup in-memory fs
+	fsys := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fsys, eszipPath, []byte("eszip"), 0644))
+
+	t.Run("streams brotli compressed body", func(t *testing.T) {
+		opts, err := NewCompressOptions(WithCompressLevel(brotli.BestCompression))
+		require.NoError(t, err)
+		// Run test
+		body := streamEszip(eszipPath, opts, fsys)
+		defer body.Close()
+		data, err := io.ReadAll(body)
+		// Check error
+		assert.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(data, []byte(compressedEszipMagicId)))
+		decoded, err := io.ReadAll(brotli.NewReader(bytes.NewReader(data[len(compressedEszipMagicId):])))
+		assert.NoError(t, err)
+		assert.Equal(t, "eszip", string(decoded))
+	})
+
+	t.Run("skips compression when disabled", func(t *testing.T) {
+		opts, err := NewCompressOptions(WithoutCompression())
+		require.NoError(t, err)
+		// Run test
+		var out bytes.Buffer
+		err = compressEszip(&out, eszipPath, opts, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "eszip", out.String())
+	})
+
+	t.Run("throws error on missing eszip", func(t *testing.T) {
+		// Run test
+		body := streamEszip("missing.eszip", CompressOptions{}, fsys)
+		defer body.Close()
+		_, err := io.ReadAll(body)
+		// Check error
+		assert.ErrorContains(t, err, "failed to open eszip:")
+	})
+
+	t.Run("throws error on invalid level", func(t *testing.T) {
+		// Run test
+		_, err := NewCompressOptions(WithCompressLevel(12))
+		// Check error
+		assert.ErrorContains(t, err, "Invalid compression level 12: must be between 0 and 11")
+	})
+}
+
+// Ref: cache.go::getRuntimeDigest
+func mockRuntimeImage(imageUrl string) {
+	gock.New(utils.Docker.DaemonHost()).
+		Get("/v" + utils.Docker.ClientVersion() + "/images/" + imageUrl + "/json").
+		Reply(http.StatusOK).
+		JSON(types.ImageInspect{ID: "sha256:test"})
+}
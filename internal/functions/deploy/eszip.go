@@ -0,0 +1,142 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/docker/go-units"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/functions/audit"
+	"github.com/supabase/cli/internal/hooks"
+	"github.com/supabase/cli/internal/utils"
+)
+
+type EszipParams struct {
+	// Entrypoint path recorded in the eszip, defaults to the path used by local bundling.
+	EntrypointPath string
+	// Import map path recorded in the eszip, defaults to the path used by local bundling.
+	ImportMapPath string
+}
+
+// RunEszip deploys a prebuilt eszip artifact without bundling the function in docker.
+func RunEszip(ctx context.Context, slug, eszipPath, projectRef string, params EszipParams, noVerifyJWT *bool, failOn string, fsys afero.Fs, options ...func(*CompressOptions)) error {
+	if err := utils.ValidateFunctionSlug(slug); err != nil {
+		return err
+	}
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	opts, err := NewCompressOptions(options...)
+	if err != nil {
+		return err
+	}
+	eszip, err := resolveEszip(slug, eszipPath, params, fsys)
+	if err != nil {
+		return err
+	}
+	// Artifacts compressed by a previous build stage are uploaded as is
+	if compressed, err := isCompressed(eszip.eszipPath, fsys); err != nil {
+		return err
+	} else if compressed {
+		opts.Disabled = true
+	}
+	// Audits the function source, if present, since the artifact was bundled elsewhere
+	if err := audit.Run(ctx, []string{slug}, "", failOn, fsys); err != nil {
+		return err
+	}
+	fc := utils.GetFunctionConfig(slug, "", noVerifyJWT, fsys)
+	started := time.Now()
+	err = uploadPrebuilt(ctx, slug, projectRef, eszip, *fc.VerifyJWT, fc.FunctionHooks, opts, fsys)
+	payload := hooks.NewPayload(utils.HookFunctionsDeploy, projectRef, started, err)
+	payload.Slugs = []string{slug}
+	hooks.Notify(ctx, payload)
+	return err
+}
+
+// Bundle hooks are skipped because the artifact is already bundled.
+func uploadPrebuilt(ctx context.Context, slug, projectRef string, eszip *eszipFunction, verifyJWT bool, fh utils.FunctionHooks, opts CompressOptions, fsys afero.Fs) error {
+	if err := runHook(ctx, HookPreDeploy, fh.PreDeploy, slug, projectRef); err != nil {
+		return err
+	}
+	if err := uploadEszip(ctx, slug, projectRef, eszip, verifyJWT, opts, fsys); err != nil {
+		return err
+	}
+	return runHook(ctx, HookPostDeploy, fh.PostDeploy, slug, projectRef)
+}
+
+func resolveEszip(slug, eszipPath string, params EszipParams, fsys afero.Fs) (*eszipFunction, error) {
+	if exists, err := afero.Exists(fsys, eszipPath); err != nil {
+		return nil, errors.Errorf("failed to check eszip: %w", err)
+	} else if !exists {
+		return nil, errors.Errorf("Eszip artifact not found: %s", utils.Bold(eszipPath))
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, errors.Errorf("failed to get working directory: %w", err)
+	}
+	dockerFuncDir := utils.ToDockerPath(filepath.Join(cwd, utils.FunctionsDir))
+	result := eszipFunction{
+		eszipPath:      eszipPath,
+		entrypointPath: path.Join(dockerFuncDir, slug, "index.ts"),
+		importMapPath:  path.Join(dockerFuncDir, "import_map.json"),
+	}
+	if len(params.EntrypointPath) > 0 {
+		result.entrypointPath = strings.TrimPrefix(params.EntrypointPath, "file://")
+	}
+	if len(params.ImportMapPath) > 0 {
+		result.importMapPath = strings.TrimPrefix(params.ImportMapPath, "file://")
+	}
+	return &result, nil
+}
+
+func isCompressed(eszipPath string, fsys afero.Fs) (bool, error) {
+	f, err := fsys.Open(eszipPath)
+	if err != nil {
+		return false, errors.Errorf("failed to open eszip: %w", err)
+	}
+	defer f.Close()
+	header := make([]byte, len(compressedEszipMagicId))
+	if _, err := io.ReadFull(f, header); errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Errorf("failed to read eszip: %w", err)
+	}
+	return string(header) == compressedEszipMagicId, nil
+}
+
+func uploadEszip(ctx context.Context, slug, projectRef string, eszip *eszipFunction, verifyJWT bool, opts CompressOptions, fsys afero.Fs) error {
+	info, err := fsys.Stat(eszip.eszipPath)
+	if err != nil {
+		return errors.Errorf("failed to stat eszip: %w", err)
+	}
+	functionSize := units.HumanSize(float64(info.Size()))
+	fmt.Fprintln(utils.GetStatusWriter(), "Deploying "+utils.Bold(slug)+" (script size: "+utils.Bold(functionSize)+")")
+	policy := utils.NewApiBackoff(ctx)
+	span := utils.StartSpan("functions", "upload "+slug)
+	span.SetAttr("size", strconv.FormatInt(info.Size(), 10))
+	err = backoff.Retry(func() error {
+		// Body is consumed on each attempt, so recompress from the eszip file.
+		body := streamEszip(eszip.eszipPath, opts, fsys)
+		defer body.Close()
+		return deployFunction(
+			ctx,
+			projectRef,
+			slug,
+			"file://"+eszip.entrypointPath,
+			"file://"+eszip.importMapPath,
+			verifyJWT,
+			body,
+		)
+	}, policy)
+	span.Finish(err)
+	return err
+}
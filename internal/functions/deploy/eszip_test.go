@@ -0,0 +1,113 @@
+package deploy
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/functions/audit"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestDeployEszip(t *testing.T) {
+	const slug = "test-func"
+
+	t.Run("deploys prebuilt eszip", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, afero.WriteFile(fsys, "output.eszip", []byte(compressedEszipMagicId+"body"), 0644))
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions/" + slug).
+			Reply(http.StatusNotFound)
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/functions").
+			ParamPresent("entrypoint_path").
+			ParamPresent("import_map_path").
+			Reply(http.StatusCreated).
+			JSON(api.FunctionResponse{Id: "1"})
+		// Run test
+		err := RunEszip(context.Background(), slug, "output.eszip", project, EszipParams{
+			EntrypointPath: "file:///src/index.ts",
+			ImportMapPath:  "/src/deno.json",
+		}, nil, audit.FailOnErrors, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on audit failure", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, afero.WriteFile(fsys, "output.eszip", []byte(compressedEszipMagicId+"body"), 0644))
+		entrypoint := filepath.Join(utils.FunctionsDir, slug, "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte(`import "npm:zod"`), 0644))
+		// Run test
+		err := RunEszip(context.Background(), slug, "output.eszip", "", EszipParams{}, nil, audit.FailOnWarnings, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Import audit found 1 issue(s) at or above --fail-on warnings.")
+	})
+
+	t.Run("throws error on pre deploy hook failure", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, afero.WriteFile(fsys, "output.eszip", []byte(compressedEszipMagicId+"body"), 0644))
+		f, err := fsys.OpenFile(utils.ConfigPath, os.O_APPEND|os.O_WRONLY, 0644)
+		require.NoError(t, err)
+		_, err = f.WriteString("\n[functions." + slug + "]\npre_deploy = \"exit 1\"\n")
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		// Run test
+		err = RunEszip(context.Background(), slug, "output.eszip", "", EszipParams{}, nil, audit.FailOnErrors, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to run pre_deploy hook for test-func:")
+	})
+
+	t.Run("throws error on missing artifact", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Run test
+		err := RunEszip(context.Background(), slug, "missing.eszip", "", EszipParams{}, nil, audit.FailOnErrors, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Eszip artifact not found:")
+	})
+
+	t.Run("throws error on invalid slug", func(t *testing.T) {
+		// Run test
+		err := RunEszip(context.Background(), "_invalid", "output.eszip", "", EszipParams{}, nil, audit.FailOnErrors, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorIs(t, err, utils.ErrInvalidSlug)
+	})
+}
+
+func TestIsCompressed(t *testing.T) {
+	// Setup in-memory fs
+	fsys := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fsys, "raw.eszip", []byte("ES"), 0644))
+	require.NoError(t, afero.WriteFile(fsys, "compressed.eszip", []byte(compressedEszipMagicId), 0644))
+	// Run test
+	compressed, err := isCompressed("raw.eszip", fsys)
+	assert.NoError(t, err)
+	assert.False(t, compressed)
+	compressed, err = isCompressed("compressed.eszip", fsys)
+	assert.NoError(t, err)
+	assert.True(t, compressed)
+}
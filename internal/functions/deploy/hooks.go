@@ -0,0 +1,46 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const (
+	HookPreBundle  = "pre_bundle"
+	HookPostBundle = "post_bundle"
+	HookPreDeploy  = "pre_deploy"
+	HookPostDeploy = "post_deploy"
+)
+
+// Runs the shell command configured for a deploy stage from the project directory,
+// exposing the function slug and project ref as environment variables.
+func runHook(ctx context.Context, stage, command, slug, projectRef string) error {
+	if len(command) == 0 {
+		return nil
+	}
+	fmt.Fprintf(utils.GetStatusWriter(), "Running %s hook for %s: %s\n", stage, utils.Bold(slug), command)
+	cmd := shellCommand(ctx, command)
+	cmd.Env = append(os.Environ(),
+		"SUPABASE_FUNCTION_SLUG="+slug,
+		"SUPABASE_PROJECT_REF="+projectRef,
+	)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return errors.Errorf("failed to run %s hook for %s: %w", stage, slug, err)
+	}
+	return nil
+}
+
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
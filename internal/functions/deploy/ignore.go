@@ -0,0 +1,124 @@
+package deploy
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const funcIgnoreFile = ".funcignore"
+
+// Loads .funcignore patterns from the functions directory and each function directory,
+// scoped to the directory they are declared in. Returns nil if no patterns are declared.
+func loadFuncIgnore(fsys afero.Fs) (gitignore.Matcher, error) {
+	paths, err := afero.Glob(fsys, filepath.Join(utils.FunctionsDir, "*", funcIgnoreFile))
+	if err != nil {
+		return nil, errors.Errorf("failed to glob %s: %w", funcIgnoreFile, err)
+	}
+	paths = append([]string{filepath.Join(utils.FunctionsDir, funcIgnoreFile)}, paths...)
+	var patterns []gitignore.Pattern
+	for _, p := range paths {
+		data, err := afero.ReadFile(fsys, p)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, errors.Errorf("failed to read %s: %w", funcIgnoreFile, err)
+		}
+		var domain []string
+		if dir := filepath.Dir(p); dir != filepath.Clean(utils.FunctionsDir) {
+			domain = []string{filepath.Base(dir)}
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSuffix(line, "\r")
+			if strings.HasPrefix(line, "#") || len(strings.TrimSpace(line)) == 0 {
+				continue
+			}
+			patterns = append(patterns, gitignore.ParsePattern(line, domain))
+		}
+	}
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+	patterns = append(patterns, gitignore.ParsePattern(funcIgnoreFile, nil))
+	return gitignore.NewMatcher(patterns), nil
+}
+
+func isIgnored(matcher gitignore.Matcher, filePath string, isDir bool) bool {
+	rel, err := filepath.Rel(utils.FunctionsDir, filePath)
+	if err != nil || rel == "." {
+		return false
+	}
+	return matcher.Match(strings.Split(filepath.ToSlash(rel), "/"), isDir)
+}
+
+// Copies the functions directory to stagingDir, leaving out files matched by matcher.
+// Symlinks are recreated as is, so that they resolve to the same bind mounts in container.
+func stageFunctions(stagingDir string, matcher gitignore.Matcher, fsys afero.Fs) error {
+	logger := utils.GetDebugLogger()
+	if err := afero.Walk(fsys, utils.FunctionsDir, func(filePath string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if isIgnored(matcher, filePath, info.IsDir()) {
+			fmt.Fprintln(logger, "Excluding", filePath)
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(utils.FunctionsDir, filePath)
+		if err != nil {
+			return err
+		}
+		dstPath := filepath.Join(stagingDir, rel)
+		if info.IsDir() {
+			return fsys.MkdirAll(dstPath, 0755)
+		}
+		if info.Mode()&fs.ModeSymlink != 0 {
+			return copySymlink(filePath, dstPath, fsys)
+		}
+		return copyFile(filePath, dstPath, fsys)
+	}); err != nil {
+		return errors.Errorf("failed to stage functions: %w", err)
+	}
+	return nil
+}
+
+func copySymlink(srcPath, dstPath string, fsys afero.Fs) error {
+	reader, ok := fsys.(afero.LinkReader)
+	if !ok {
+		return copyFile(srcPath, dstPath, fsys)
+	}
+	link, err := reader.ReadlinkIfPossible(srcPath)
+	if err != nil {
+		return err
+	}
+	linker, ok := fsys.(afero.Linker)
+	if !ok {
+		return copyFile(srcPath, dstPath, fsys)
+	}
+	return linker.SymlinkIfPossible(link, dstPath)
+}
+
+func copyFile(srcPath, dstPath string, fsys afero.Fs) error {
+	src, err := fsys.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := fsys.OpenFile(dstPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+	_, err = io.Copy(dst, src)
+	return err
+}
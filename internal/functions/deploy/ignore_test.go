@@ -0,0 +1,80 @@
+package deploy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/fstest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestStageFunctions(t *testing.T) {
+	stagingDir := filepath.Join(utils.TempDir, "functions")
+
+	t.Run("excludes ignored files", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		files := map[string]string{
+			".funcignore":              "**/*.test.ts\n# local secrets\n.env",
+			"_shared/cors.ts":          "export {}",
+			"_shared/cors.test.ts":     "test",
+			"hello/.funcignore":        "fixtures/\nREADME.md",
+			"hello/index.ts":           "import '../_shared/cors.ts'",
+			"hello/.env":               "SECRET=1",
+			"hello/README.md":          "# hello",
+			"hello/fixtures/data.json": "{}",
+			"world/index.ts":           "export {}",
+			"world/README.md":          "# world",
+		}
+		for name, contents := range files {
+			path := filepath.Join(utils.FunctionsDir, name)
+			require.NoError(t, afero.WriteFile(fsys, path, []byte(contents), 0644))
+		}
+		// Run test
+		matcher, err := loadFuncIgnore(fsys)
+		require.NoError(t, err)
+		require.NotNil(t, matcher)
+		err = stageFunctions(stagingDir, matcher, fsys)
+		// Check error
+		assert.NoError(t, err)
+		var staged []string
+		require.NoError(t, afero.Walk(fsys, stagingDir, func(path string, info os.FileInfo, err error) error {
+			if err == nil && !info.IsDir() {
+				rel, _ := filepath.Rel(stagingDir, path)
+				staged = append(staged, filepath.ToSlash(rel))
+			}
+			return err
+		}))
+		assert.ElementsMatch(t, []string{
+			"_shared/cors.ts",
+			"hello/index.ts",
+			"world/index.ts",
+			"world/README.md",
+		}, staged)
+	})
+
+	t.Run("skips staging without funcignore", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.FunctionsDir, "hello", "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte("export {}"), 0644))
+		// Run test
+		matcher, err := loadFuncIgnore(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Nil(t, matcher)
+	})
+
+	t.Run("throws error on read failure", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := &fstest.OpenErrorFs{DenyPath: filepath.Join(utils.FunctionsDir, ".funcignore")}
+		// Run test
+		_, err := loadFuncIgnore(fsys)
+		// Check error
+		assert.ErrorIs(t, err, os.ErrPermission)
+	})
+}
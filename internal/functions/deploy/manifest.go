@@ -0,0 +1,156 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/functions/audit"
+	"github.com/supabase/cli/internal/hooks"
+	"github.com/supabase/cli/internal/secrets/set"
+	"github.com/supabase/cli/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+type ManifestFunction struct {
+	Slug      string            `yaml:"slug"`
+	ImportMap string            `yaml:"import_map"`
+	VerifyJWT *bool             `yaml:"verify_jwt"`
+	Secrets   map[string]string `yaml:"secrets"`
+	Regions   []string          `yaml:"regions"`
+}
+
+type Manifest struct {
+	Functions []ManifestFunction `yaml:"functions"`
+}
+
+// Loads a deploy manifest, resolving import maps relative to the manifest file and
+// expanding $VAR references in secret values from the environment.
+func LoadManifest(manifestPath string, fsys afero.Fs) (*Manifest, error) {
+	if !filepath.IsAbs(manifestPath) {
+		manifestPath = filepath.Join(utils.CurrentDirAbs, manifestPath)
+	}
+	f, err := fsys.Open(manifestPath)
+	if err != nil {
+		return nil, errors.Errorf("failed to open manifest: %w", err)
+	}
+	defer f.Close()
+	var manifest Manifest
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(&manifest); err != nil {
+		return nil, errors.Errorf("failed to parse manifest: %w", err)
+	}
+	if len(manifest.Functions) == 0 {
+		return nil, errors.New("No Functions found in manifest " + utils.Bold(manifestPath))
+	}
+	// Secrets are shared across a project, so each name can only have one value
+	type owner struct{ slug, value string }
+	owners := map[string]owner{}
+	for i, fn := range manifest.Functions {
+		if err := utils.ValidateFunctionSlug(fn.Slug); err != nil {
+			return nil, err
+		}
+		if len(fn.ImportMap) > 0 && !filepath.IsAbs(fn.ImportMap) {
+			manifest.Functions[i].ImportMap = filepath.Join(filepath.Dir(manifestPath), fn.ImportMap)
+		}
+		names := make([]string, 0, len(fn.Secrets))
+		for name := range fn.Secrets {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			value := os.ExpandEnv(fn.Secrets[name])
+			if prev, ok := owners[name]; ok && prev.value != value {
+				return nil, errors.Errorf("Conflicting values for secret %s in functions %s and %s", name, prev.slug, fn.Slug)
+			}
+			fn.Secrets[name] = value
+			owners[name] = owner{slug: fn.Slug, value: value}
+		}
+	}
+	return &manifest, nil
+}
+
+func RunManifest(ctx context.Context, manifestPath, projectRef, failOn string, fsys afero.Fs, options ...func(*CompressOptions)) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	manifest, err := LoadManifest(manifestPath, fsys)
+	if err != nil {
+		return err
+	}
+	// Audit imports before making any changes to the project
+	for _, fn := range manifest.Functions {
+		if err := audit.Run(ctx, []string{fn.Slug}, fn.ImportMap, failOn, fsys); err != nil {
+			return err
+		}
+	}
+	if err := checkSecrets(ctx, manifest.slugs(), projectRef, failOn, manifest.secretNames(), fsys); err != nil {
+		return err
+	}
+	// 1. Set secrets before deploying so that new functions can read them
+	if args := manifest.secretPairs(); len(args) > 0 {
+		if err := set.Run(ctx, projectRef, "", args, nil, fsys); err != nil {
+			return err
+		}
+	}
+	// 2. Deploy functions with per function overrides
+	started := time.Now()
+	var slugs []string
+	for _, fn := range manifest.Functions {
+		if len(fn.Regions) > 0 {
+			fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "Region pinning is not supported by the Management API yet, ignoring regions of "+utils.Aqua(fn.Slug))
+		}
+		var noVerifyJWT *bool
+		if fn.VerifyJWT != nil {
+			noVerifyJWT = utils.Ptr(!*fn.VerifyJWT)
+		}
+		slugs = append(slugs, fn.Slug)
+		if err = deployOne(ctx, fn.Slug, projectRef, fn.ImportMap, noVerifyJWT, fsys, options...); err != nil {
+			break
+		}
+	}
+	payload := hooks.NewPayload(utils.HookFunctionsDeploy, projectRef, started, err)
+	payload.Slugs = slugs
+	hooks.Notify(ctx, payload)
+	return err
+}
+
+func (m Manifest) slugs() []string {
+	result := make([]string, len(m.Functions))
+	for i, fn := range m.Functions {
+		result[i] = fn.Slug
+	}
+	return result
+}
+
+func (m Manifest) secretNames() []string {
+	var names []string
+	for _, fn := range m.Functions {
+		for name := range fn.Secrets {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Merges secrets of all functions, since secrets are shared across a project.
+func (m Manifest) secretPairs() []string {
+	secrets := map[string]string{}
+	for _, fn := range m.Functions {
+		for name, value := range fn.Secrets {
+			secrets[name] = value
+		}
+	}
+	var pairs []string
+	for name, value := range secrets {
+		pairs = append(pairs, name+"="+value)
+	}
+	sort.Strings(pairs)
+	return pairs
+}
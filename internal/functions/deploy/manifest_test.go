@@ -0,0 +1,93 @@
+package deploy
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestLoadManifest(t *testing.T) {
+	utils.CurrentDirAbs = "/project"
+	t.Cleanup(func() { utils.CurrentDirAbs = "" })
+
+	t.Run("loads manifest with overrides", func(t *testing.T) {
+		t.Setenv("STRIPE_KEY", "sk_test")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/project/deploy/deploy.yaml", []byte(`
+functions:
+  - slug: payments
+    import_map: import_map.json
+    verify_jwt: false
+    secrets:
+      STRIPE_KEY: $STRIPE_KEY
+    regions: [us-east-1]
+  - slug: hello
+`), 0644))
+		// Run test
+		manifest, err := LoadManifest("deploy/deploy.yaml", fsys)
+		// Check error
+		assert.NoError(t, err)
+		require.Len(t, manifest.Functions, 2)
+		assert.Equal(t, filepath.Join("/project", "deploy", "import_map.json"), manifest.Functions[0].ImportMap)
+		assert.False(t, *manifest.Functions[0].VerifyJWT)
+		assert.Nil(t, manifest.Functions[1].VerifyJWT)
+		assert.Equal(t, []string{"STRIPE_KEY=sk_test"}, manifest.secretPairs())
+	})
+
+	t.Run("throws error on conflicting secrets", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/project/deploy.yaml", []byte(`
+functions:
+  - slug: payments
+    secrets:
+      STRIPE_KEY: sk_live
+  - slug: billing
+    secrets:
+      STRIPE_KEY: sk_test
+`), 0644))
+		// Run test
+		_, err := LoadManifest("deploy.yaml", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Conflicting values for secret STRIPE_KEY in functions payments and billing")
+	})
+
+	t.Run("throws error on unknown field", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/project/deploy.yaml", []byte(`
+functions:
+  - slug: hello
+    verify: false
+`), 0644))
+		// Run test
+		_, err := LoadManifest("deploy.yaml", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to parse manifest:")
+	})
+
+	t.Run("throws error on invalid slug", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/project/deploy.yaml", []byte(`
+functions:
+  - slug: "@"
+`), 0644))
+		// Run test
+		_, err := LoadManifest("deploy.yaml", fsys)
+		// Check error
+		assert.ErrorIs(t, err, utils.ErrInvalidSlug)
+	})
+
+	t.Run("throws error on missing manifest", func(t *testing.T) {
+		// Run test
+		_, err := LoadManifest("deploy.yaml", afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "failed to open manifest:")
+	})
+}
@@ -0,0 +1,144 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/functions/audit"
+	"github.com/supabase/cli/internal/secrets/list"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var (
+	envPattern = regexp.MustCompile("\\bDeno\\.env\\.get\\(\\s*[\"'`]([A-Za-z_][A-Za-z0-9_]*)[\"'`]\\s*\\)")
+	// Set by the edge runtime on every deployed function
+	builtinEnv = []string{"SB_REGION", "SB_EXECUTION_ID", "DENO_DEPLOYMENT_ID", "DENO_REGION"}
+)
+
+// Warns about environment variables read by functions that are not set as secrets on
+// the project, failing the deploy only if failOn is warnings. Secrets in pending are
+// about to be set, so they are treated as present.
+func checkSecrets(ctx context.Context, slugs []string, projectRef, failOn string, pending []string, fsys afero.Fs) error {
+	required := map[string][]string{}
+	for _, slug := range slugs {
+		names, err := resolveEnvNames(slug, fsys)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			if !isBuiltinEnv(name) && !utils.SliceContains(pending, name) {
+				required[slug] = append(required[slug], name)
+			}
+		}
+	}
+	if len(required) == 0 {
+		return nil
+	}
+	secrets, err := list.GetSecretDigests(ctx, projectRef)
+	if err != nil {
+		// Missing secrets are only advisory, so they should never block a deploy
+		fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "skipped checking for missing secrets:", err)
+		return nil
+	}
+	set := make(map[string]struct{}, len(secrets))
+	for _, s := range secrets {
+		set[s.Name] = struct{}{}
+	}
+	missing := 0
+	for _, slug := range slugs {
+		for _, name := range required[slug] {
+			if _, ok := set[name]; !ok {
+				fmt.Fprintf(os.Stderr, "%s %s: secret %s is not set on project %s\n", utils.Yellow("WARNING:"), utils.Aqua(slug), utils.Bold(name), projectRef)
+				missing++
+			}
+		}
+	}
+	if missing == 0 {
+		return nil
+	}
+	if failOn == audit.FailOnWarnings {
+		return errors.Errorf("Found %d missing secret(s) at or above --fail-on %s. Run %s to set them.", missing, failOn, utils.Aqua("supabase secrets set"))
+	}
+	fmt.Fprintln(os.Stderr, "Run "+utils.Aqua("supabase secrets set")+" to set the missing secrets.")
+	return nil
+}
+
+func isBuiltinEnv(name string) bool {
+	return strings.HasPrefix(name, "SUPABASE_") || utils.SliceContains(builtinEnv, name)
+}
+
+// Secrets declared under [functions.<slug>] take precedence over those found in source.
+func resolveEnvNames(slug string, fsys afero.Fs) ([]string, error) {
+	if declared := utils.Config.Functions[slug].Secrets; len(declared) > 0 {
+		return declared, nil
+	}
+	return CollectEnvNames(slug, fsys)
+}
+
+// CollectEnvNames returns the names passed as string literals to Deno.env.get in the
+// function source, including shared modules it imports by relative path, such as those
+// under supabase/functions/_shared. Names computed at runtime cannot be detected.
+func CollectEnvNames(slug string, fsys afero.Fs) ([]string, error) {
+	funcDir := filepath.Join(utils.FunctionsDir, slug)
+	if exists, err := afero.DirExists(fsys, funcDir); err != nil {
+		return nil, errors.Errorf("failed to check function dir: %w", err)
+	} else if !exists {
+		return nil, nil
+	}
+	var queue []string
+	if err := afero.Walk(fsys, funcDir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && utils.SliceContains(audit.SourceExts, filepath.Ext(path)) {
+			queue = append(queue, path)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Errorf("failed to read function source: %w", err)
+	}
+	visited := map[string]struct{}{}
+	for _, path := range queue {
+		visited[path] = struct{}{}
+	}
+	found := map[string]struct{}{}
+	for len(queue) > 0 {
+		path := queue[0]
+		queue = queue[1:]
+		contents, err := afero.ReadFile(fsys, path)
+		if errors.Is(err, os.ErrNotExist) {
+			// Unresolved imports are reported by the bundler instead
+			continue
+		} else if err != nil {
+			return nil, errors.Errorf("failed to read function source: %w", err)
+		}
+		for _, match := range envPattern.FindAllStringSubmatch(string(contents), -1) {
+			found[match[1]] = struct{}{}
+		}
+		for _, spec := range audit.ParseImports(string(contents)) {
+			if !strings.HasPrefix(spec, "./") && !strings.HasPrefix(spec, "../") {
+				continue
+			}
+			dep := filepath.Join(filepath.Dir(path), spec)
+			if _, ok := visited[dep]; ok || !utils.SliceContains(audit.SourceExts, filepath.Ext(dep)) {
+				continue
+			}
+			visited[dep] = struct{}{}
+			queue = append(queue, dep)
+		}
+	}
+	names := make([]string, 0, len(found))
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
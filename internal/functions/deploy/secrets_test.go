@@ -0,0 +1,197 @@
+package deploy
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/functions/audit"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestCollectEnvNames(t *testing.T) {
+	t.Run("collects literal env names", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		source := `const key = Deno.env.get("STRIPE_KEY")!
+const url = Deno.env.get('SUPABASE_URL')
+const hook = Deno.env.get( ` + "`WEBHOOK_SECRET`" + ` )
+const dynamic = Deno.env.get(name)`
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "pay", "index.ts"), []byte(source), 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "pay", "util.js"), []byte(`Deno.env.get("STRIPE_KEY")`), 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "pay", "README.md"), []byte(`Deno.env.get("DOCS_ONLY")`), 0644))
+		// Run test
+		names, err := CollectEnvNames("pay", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"STRIPE_KEY", "SUPABASE_URL", "WEBHOOK_SECRET"}, names)
+	})
+
+	t.Run("follows relative imports to shared modules", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		source := `import { stripe } from "../_shared/stripe.ts"
+import { cors } from "../_shared/missing.ts"
+import { serve } from "https://deno.land/std/http/server.ts"`
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "pay", "index.ts"), []byte(source), 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "_shared", "stripe.ts"), []byte(`import "./env.ts"
+export const stripe = Deno.env.get("STRIPE_KEY")`), 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "_shared", "env.ts"), []byte(`Deno.env.get("WEBHOOK_SECRET")`), 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "_shared", "unused.ts"), []byte(`Deno.env.get("UNUSED")`), 0644))
+		// Run test
+		names, err := CollectEnvNames("pay", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"STRIPE_KEY", "WEBHOOK_SECRET"}, names)
+	})
+
+	t.Run("ignores missing function", func(t *testing.T) {
+		// Run test
+		names, err := CollectEnvNames("missing", afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, names)
+	})
+}
+
+func TestCheckSecrets(t *testing.T) {
+	source := []byte(`Deno.env.get("STRIPE_KEY"); Deno.env.get("SUPABASE_URL")`)
+
+	t.Run("warns on missing secret", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "pay", "index.ts"), source, 0644))
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{{Name: "OTHER_KEY", Value: "value"}})
+		// Run test
+		err := checkSecrets(context.Background(), []string{"pay"}, project, audit.FailOnErrors, nil, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing secret", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "pay", "index.ts"), source, 0644))
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{})
+		// Run test
+		err := checkSecrets(context.Background(), []string{"pay"}, project, audit.FailOnWarnings, nil, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Found 1 missing secret(s) at or above --fail-on warnings.")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("warns on failure to list secrets", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "pay", "index.ts"), source, 0644))
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusForbidden)
+		// Run test
+		err := checkSecrets(context.Background(), []string{"pay"}, project, audit.FailOnWarnings, nil, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("passes when secrets are set", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "pay", "index.ts"), source, 0644))
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{{Name: "STRIPE_KEY", Value: "value"}})
+		// Run test
+		err := checkSecrets(context.Background(), []string{"pay"}, project, audit.FailOnWarnings, nil, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("uses declared secrets from config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		f, err := fsys.OpenFile(utils.ConfigPath, os.O_APPEND|os.O_WRONLY, 0600)
+		require.NoError(t, err)
+		_, err = f.WriteString(`
+[functions.pay]
+secrets = ["WEBHOOK_SECRET"]
+`)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		require.NoError(t, utils.LoadConfigFS(fsys))
+		defer delete(utils.Config.Functions, "pay")
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "pay", "index.ts"), source, 0644))
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{{Name: "WEBHOOK_SECRET", Value: "value"}})
+		// Run test
+		err = checkSecrets(context.Background(), []string{"pay"}, project, audit.FailOnWarnings, nil, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("skips pending and builtin secrets", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "pay", "index.ts"), source, 0644))
+		// Run test
+		err := checkSecrets(context.Background(), []string{"pay"}, apitest.RandomProjectRef(), audit.FailOnWarnings, []string{"STRIPE_KEY"}, fsys)
+		// Check error
+		assert.NoError(t, err)
+	})
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
 	"github.com/supabase/cli/internal/db/start"
+	"github.com/supabase/cli/internal/tools"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/pkg/api"
 )
@@ -32,7 +33,8 @@ func RunLegacy(ctx context.Context, slug string, projectRef string, fsys afero.F
 			return err
 		}
 	}
-	if err := utils.InstallOrUpgradeDeno(ctx, fsys); err != nil {
+	denoPath, err := tools.EnsureDeno(ctx, fsys)
+	if err != nil {
 		return err
 	}
 
@@ -42,7 +44,7 @@ func RunLegacy(ctx context.Context, slug string, projectRef string, fsys afero.F
 	}
 
 	// 2. Download Function.
-	if err := downloadFunction(ctx, projectRef, slug, scriptDir.ExtractPath); err != nil {
+	if err := downloadFunction(ctx, projectRef, slug, denoPath, scriptDir.ExtractPath); err != nil {
 		return err
 	}
 
@@ -74,13 +76,8 @@ func getFunctionMetadata(ctx context.Context, projectRef, slug string) (*api.Fun
 	return resp.JSON200, nil
 }
 
-func downloadFunction(ctx context.Context, projectRef, slug, extractScriptPath string) error {
+func downloadFunction(ctx context.Context, projectRef, slug, denoPath, extractScriptPath string) error {
 	fmt.Println("Downloading " + utils.Bold(slug))
-	denoPath, err := utils.GetDenoPath()
-	if err != nil {
-		return err
-	}
-
 	meta, err := getFunctionMetadata(ctx, projectRef, slug)
 	if err != nil {
 		return err
@@ -149,8 +146,8 @@ func downloadOne(ctx context.Context, slug, projectRef string, fsys afero.Fs) (s
 		return "", errors.Errorf("Error status %d: %s", resp.StatusCode, string(body))
 	}
 	// Create temp file to store downloaded eszip
-	eszipPath := filepath.Join(utils.TempDir, fmt.Sprintf("output_%s.eszip", slug))
-	if err := utils.MkdirIfNotExistFS(fsys, utils.TempDir); err != nil {
+	eszipPath := filepath.Join(utils.RunTempDir, fmt.Sprintf("output_%s.eszip", slug))
+	if err := utils.MkdirIfNotExistFS(fsys, utils.RunTempDir); err != nil {
 		return "", err
 	}
 	if err := afero.WriteReader(fsys, eszipPath, resp.Body); err != nil {
@@ -182,7 +179,7 @@ func extractOne(ctx context.Context, slug, eszipPath string) error {
 	return utils.DockerRunOnceWithConfig(
 		ctx,
 		container.Config{
-			Image: utils.EdgeRuntimeImage,
+			Image: utils.Config.EdgeRuntime.Image,
 			Cmd:   []string{"unbundle", "--eszip", dockerEszipPath, "--output", utils.DockerDenoDir},
 		},
 		start.WithSyslogConfig(container.HostConfig{
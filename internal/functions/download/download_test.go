@@ -146,7 +146,7 @@ func TestDownloadFunction(t *testing.T) {
 			Get("/v1/projects/" + project + "/functions/" + slug + "/body").
 			ReplyError(errors.New("network error"))
 		// Run test
-		err := downloadFunction(context.Background(), project, slug, "")
+		err := downloadFunction(context.Background(), project, slug, utils.DenoPathOverride, "")
 		// Check error
 		assert.ErrorContains(t, err, "network error")
 	})
@@ -162,7 +162,7 @@ func TestDownloadFunction(t *testing.T) {
 			Get("/v1/projects/" + project + "/functions/" + slug + "/body").
 			Reply(http.StatusServiceUnavailable)
 		// Run test
-		err := downloadFunction(context.Background(), project, slug, "")
+		err := downloadFunction(context.Background(), project, slug, utils.DenoPathOverride, "")
 		// Check error
 		assert.ErrorContains(t, err, "Unexpected error downloading Function:")
 	})
@@ -180,7 +180,7 @@ func TestDownloadFunction(t *testing.T) {
 			Get("/v1/projects/" + project + "/functions/" + slug + "/body").
 			Reply(http.StatusOK)
 		// Run test
-		err := downloadFunction(context.Background(), project, slug, "")
+		err := downloadFunction(context.Background(), project, slug, utils.DenoPathOverride, "")
 		// Check error
 		assert.ErrorContains(t, err, "Error downloading function: exit status 1\nextract failed\n")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
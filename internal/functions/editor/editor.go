@@ -0,0 +1,156 @@
+package editor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/functions/deploy"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var (
+	settingsPath = filepath.Join(".vscode", "settings.json")
+	// Edge runtime exposes Deno APIs including unstable ones to user workers
+	denoLib = []interface{}{"deno.window", "deno.unstable"}
+)
+
+// Run writes Deno settings for each function so that editor IntelliSense resolves
+// modules with the same import map used when bundling.
+func Run(ctx context.Context, slugs []string, importMapPath string, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	if len(slugs) == 0 {
+		var err error
+		if slugs, err = deploy.GetFunctionSlugs(fsys); err != nil {
+			return err
+		}
+	}
+	if len(slugs) == 0 {
+		return errors.New("No Functions found in " + utils.Bold(utils.FunctionsDir))
+	}
+	enablePaths := make([]string, len(slugs))
+	for i, slug := range slugs {
+		if err := utils.ValidateFunctionSlug(slug); err != nil {
+			return err
+		}
+		funcDir := filepath.Join(utils.FunctionsDir, slug)
+		fc := utils.GetFunctionConfig(slug, importMapPath, nil, fsys)
+		if err := writeDenoConfig(funcDir, fc.ImportMap, fsys); err != nil {
+			return err
+		}
+		enablePaths[i] = filepath.ToSlash(funcDir)
+	}
+	if err := writeVscodeSettings(enablePaths, fsys); err != nil {
+		return err
+	}
+	fmt.Printf("Updated editor settings in %s for %d Functions.\n", utils.Bold(settingsPath), len(slugs))
+	return nil
+}
+
+// Merges the resolved import map and compiler options into the function's deno.json.
+func writeDenoConfig(funcDir, importMapPath string, fsys afero.Fs) error {
+	configPath := filepath.Join(funcDir, "deno.json")
+	config, err := loadJson(configPath, fsys)
+	if err != nil {
+		return err
+	}
+	if len(importMapPath) > 0 && !isSamePath(importMapPath, configPath) {
+		// Inline imports take precedence over an external import map
+		if _, ok := config["imports"]; !ok {
+			rel, err := relativePath(funcDir, importMapPath)
+			if err != nil {
+				return err
+			}
+			config["importMap"] = rel
+		}
+	}
+	options, _ := config["compilerOptions"].(map[string]interface{})
+	if options == nil {
+		options = map[string]interface{}{}
+	}
+	options["lib"] = denoLib
+	config["compilerOptions"] = options
+	return saveJson(configPath, config, fsys)
+}
+
+// Adds function directories to deno.enablePaths, preserving other user settings.
+func writeVscodeSettings(enablePaths []string, fsys afero.Fs) error {
+	settings, err := loadJson(settingsPath, fsys)
+	if err != nil {
+		return err
+	}
+	existing, _ := settings["deno.enablePaths"].([]interface{})
+	seen := map[string]bool{}
+	var paths []string
+	for _, p := range existing {
+		if s, ok := p.(string); ok && !seen[s] {
+			seen[s] = true
+			paths = append(paths, s)
+		}
+	}
+	for _, p := range enablePaths {
+		if !seen[p] {
+			seen[p] = true
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+	settings["deno.enablePaths"] = paths
+	if _, ok := settings["deno.lint"]; !ok {
+		settings["deno.lint"] = true
+	}
+	return saveJson(settingsPath, settings, fsys)
+}
+
+func loadJson(path string, fsys afero.Fs) (map[string]interface{}, error) {
+	result := map[string]interface{}{}
+	f, err := fsys.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return result, nil
+	} else if err != nil {
+		return nil, errors.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+	if err := json.NewDecoder(f).Decode(&result); err != nil && !errors.Is(err, io.EOF) {
+		return nil, errors.Errorf("failed to parse %s: %w", path, err)
+	}
+	return result, nil
+}
+
+func saveJson(path string, value map[string]interface{}, fsys afero.Fs) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return errors.Errorf("failed to marshal %s: %w", path, err)
+	}
+	return utils.WriteFile(path, append(data, '\n'), fsys)
+}
+
+func relativePath(base, target string) (string, error) {
+	absBase, err := filepath.Abs(base)
+	if err != nil {
+		return "", errors.Errorf("failed to resolve path: %w", err)
+	}
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		return "", errors.Errorf("failed to resolve path: %w", err)
+	}
+	rel, err := filepath.Rel(absBase, absTarget)
+	if err != nil {
+		return "", errors.Errorf("failed to resolve import map: %w", err)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+func isSamePath(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
+}
@@ -0,0 +1,69 @@
+package editor
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestSetupEditor(t *testing.T) {
+	t.Run("writes settings for all functions", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "hello", "index.ts"), []byte{}, 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "world", "index.ts"), []byte{}, 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "world", "deno.json"), []byte(`{"imports":{"std/":"https://deno.land/std/"}}`), 0644))
+		require.NoError(t, afero.WriteFile(fsys, utils.FallbackImportMapPath, []byte("{}"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, settingsPath, []byte(`{"deno.enablePaths":["scripts"],"editor.tabSize":2}`), 0644))
+		// Run test
+		err := Run(context.Background(), nil, "", fsys)
+		// Check error
+		assert.NoError(t, err)
+		settings, err := afero.ReadFile(fsys, settingsPath)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{
+			"deno.enablePaths": ["scripts", "supabase/functions/hello", "supabase/functions/world"],
+			"deno.lint": true,
+			"editor.tabSize": 2
+		}`, string(settings))
+		hello, err := afero.ReadFile(fsys, filepath.Join(utils.FunctionsDir, "hello", "deno.json"))
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{
+			"importMap": "../import_map.json",
+			"compilerOptions": {"lib": ["deno.window", "deno.unstable"]}
+		}`, string(hello))
+		world, err := afero.ReadFile(fsys, filepath.Join(utils.FunctionsDir, "world", "deno.json"))
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{
+			"imports": {"std/": "https://deno.land/std/"},
+			"compilerOptions": {"lib": ["deno.window", "deno.unstable"]}
+		}`, string(world))
+	})
+
+	t.Run("throws error on no functions", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Run test
+		err := Run(context.Background(), nil, "", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "No Functions found in")
+	})
+
+	t.Run("throws error on malformed settings", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, afero.WriteFile(fsys, settingsPath, []byte("{"), 0644))
+		// Run test
+		err := Run(context.Background(), []string{"hello"}, "", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to parse .vscode/settings.json:")
+	})
+}
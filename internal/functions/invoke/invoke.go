@@ -0,0 +1,148 @@
+package invoke
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/tenant"
+)
+
+const (
+	RoleAnon        = "anon"
+	RoleServiceRole = "service_role"
+	RoleNone        = "none"
+)
+
+type Request struct {
+	Method  string
+	Body    string
+	Headers []string
+	Role    string
+}
+
+// Run invokes the local function when projectRef is empty, otherwise the deployed function.
+func Run(ctx context.Context, slug, projectRef string, req Request, fsys afero.Fs) error {
+	if err := utils.ValidateFunctionSlug(slug); err != nil {
+		return err
+	}
+	endpoint, keys, err := ResolveTarget(ctx, projectRef, fsys)
+	if err != nil {
+		return err
+	}
+	httpReq, err := NewRequest(ctx, endpoint+"/functions/v1/"+slug, req, keys, fsys)
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return errors.Errorf("failed to invoke function: %w", err)
+	}
+	defer resp.Body.Close()
+	elapsed := time.Since(start)
+	PrintHeader(os.Stderr, resp, elapsed)
+	if _, err := io.Copy(os.Stdout, resp.Body); err != nil {
+		return errors.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errors.Errorf("Function returned status %d.", resp.StatusCode)
+	}
+	return nil
+}
+
+// ResolveTarget returns the API endpoint and keys of the local stack when projectRef is
+// empty, otherwise those of the linked project.
+func ResolveTarget(ctx context.Context, projectRef string, fsys afero.Fs) (string, tenant.ApiKey, error) {
+	if len(projectRef) == 0 {
+		if err := utils.LoadConfigFS(fsys); err != nil {
+			return "", tenant.ApiKey{}, err
+		}
+		endpoint := fmt.Sprintf("http://%s:%d", utils.Config.Hostname, utils.Config.Api.Port)
+		return endpoint, tenant.ApiKey{
+			Anon:        utils.Config.Auth.AnonKey,
+			ServiceRole: utils.Config.Auth.ServiceRoleKey,
+		}, nil
+	}
+	keys, err := tenant.GetApiKeys(ctx, projectRef)
+	if err != nil {
+		return "", tenant.ApiKey{}, err
+	}
+	return "https://" + utils.GetSupabaseHost(projectRef), keys, nil
+}
+
+func NewRequest(ctx context.Context, url string, req Request, keys tenant.ApiKey, fsys afero.Fs) (*http.Request, error) {
+	var body io.Reader
+	if len(req.Body) > 0 {
+		data := []byte(req.Body)
+		// Follows curl convention of reading the request body from a file.
+		if path, ok := strings.CutPrefix(req.Body, "@"); ok {
+			var err error
+			if data, err = afero.ReadFile(fsys, path); err != nil {
+				return nil, errors.Errorf("failed to read request body: %w", err)
+			}
+		}
+		body = strings.NewReader(string(data))
+	}
+	method := strings.ToUpper(req.Method)
+	if len(method) == 0 {
+		method = http.MethodPost
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, errors.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("User-Agent", "SupabaseCLI/"+utils.Version)
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	SetApiKey(httpReq, req.Role, keys)
+	// User specified headers take precedence over defaults
+	for _, h := range req.Headers {
+		name, value, found := strings.Cut(h, ":")
+		if !found || len(strings.TrimSpace(name)) == 0 {
+			return nil, errors.Errorf("Invalid header %q: must be of the form Name: Value", h)
+		}
+		httpReq.Header.Set(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+	return httpReq, nil
+}
+
+// SetApiKey authorises the request with the project API key matching role.
+func SetApiKey(httpReq *http.Request, role string, keys tenant.ApiKey) {
+	var key string
+	switch role {
+	case RoleServiceRole:
+		key = keys.ServiceRole
+	case RoleNone:
+	default:
+		key = keys.Anon
+	}
+	if len(key) > 0 {
+		httpReq.Header.Set("apikey", key)
+		httpReq.Header.Set("Authorization", "Bearer "+key)
+	}
+}
+
+func PrintHeader(w io.Writer, resp *http.Response, elapsed time.Duration) {
+	fmt.Fprintln(w, resp.Proto, resp.Status)
+	names := make([]string, 0, len(resp.Header))
+	for name := range resp.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range resp.Header[name] {
+			fmt.Fprintf(w, "%s: %s\n", name, value)
+		}
+	}
+	fmt.Fprintf(w, "Time: %s\n\n", elapsed.Round(time.Millisecond))
+}
@@ -0,0 +1,137 @@
+package invoke
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/tenant"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestInvokeCommand(t *testing.T) {
+	const slug = "test-func"
+
+	t.Run("invokes remote function with anon key", func(t *testing.T) {
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{Name: "anon", ApiKey: "anon-key"}})
+		gock.New("https://"+utils.GetSupabaseHost(project)).
+			Post("/functions/v1/"+slug).
+			MatchHeader("Authorization", "Bearer anon-key").
+			MatchHeader("X-Test", "true").
+			BodyString(`{"name":"world"}`).
+			Reply(http.StatusOK).
+			JSON(map[string]string{"message": "Hello world"})
+		// Run test
+		err := Run(context.Background(), slug, project, Request{
+			Body:    `{"name":"world"}`,
+			Headers: []string{"X-Test: true"},
+		}, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on failure status", func(t *testing.T) {
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{Name: "service_role", ApiKey: "service-key"}})
+		gock.New("https://"+utils.GetSupabaseHost(project)).
+			Get("/functions/v1/"+slug).
+			MatchHeader("Authorization", "Bearer service-key").
+			Reply(http.StatusInternalServerError)
+		// Run test
+		err := Run(context.Background(), slug, project, Request{
+			Method: "get",
+			Role:   RoleServiceRole,
+		}, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Function returned status 500.")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on invalid slug", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), "@", "", Request{}, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Invalid Function name.")
+	})
+}
+
+func TestNewRequest(t *testing.T) {
+	keys := tenant.ApiKey{Anon: "anon-key", ServiceRole: "service-key"}
+
+	t.Run("reads body from file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "body.json", []byte(`{}`), 0644))
+		// Run test
+		req, err := NewRequest(context.Background(), "http://127.0.0.1", Request{Body: "@body.json"}, keys, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, int64(2), req.ContentLength)
+	})
+
+	t.Run("skips auth for none role", func(t *testing.T) {
+		// Run test
+		req, err := NewRequest(context.Background(), "http://127.0.0.1", Request{Role: RoleNone}, keys, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, req.Header.Get("Authorization"))
+	})
+
+	t.Run("overrides authorization header", func(t *testing.T) {
+		// Run test
+		req, err := NewRequest(context.Background(), "http://127.0.0.1", Request{
+			Headers: []string{"Authorization: Bearer user-jwt"},
+		}, keys, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer user-jwt", req.Header.Get("Authorization"))
+		assert.Equal(t, "anon-key", req.Header.Get("apikey"))
+	})
+
+	t.Run("throws error on malformed header", func(t *testing.T) {
+		// Run test
+		_, err := NewRequest(context.Background(), "http://127.0.0.1", Request{Headers: []string{"invalid"}}, keys, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, `Invalid header "invalid"`)
+	})
+}
+
+func TestPrintHeader(t *testing.T) {
+	var out bytes.Buffer
+	resp := &http.Response{
+		Proto:  "HTTP/1.1",
+		Status: "200 OK",
+		Header: http.Header{"X-B": {"2"}, "X-A": {"1"}},
+	}
+	PrintHeader(&out, resp, 1234*time.Microsecond)
+	assert.Equal(t, "HTTP/1.1 200 OK\nX-A: 1\nX-B: 2\nTime: 1ms\n\n", out.String())
+}
@@ -3,15 +3,31 @@ package list
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
 	"github.com/supabase/cli/internal/migration/list"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
 )
 
-func Run(ctx context.Context, projectRef string, fsys afero.Fs) error {
+var (
+	AllColumns     = []string{"id", "name", "slug", "status", "version", "created_at", "updated_at", "verify_jwt", "entrypoint_path", "import_map_path"}
+	DefaultColumns = []string{"id", "name", "slug", "status", "version", "updated_at"}
+)
+
+func Run(ctx context.Context, projectRef, format string, columns []string, fsys afero.Fs) error {
+	if len(columns) == 0 {
+		columns = DefaultColumns
+	}
+	for _, c := range columns {
+		if !utils.SliceContains(AllColumns, c) {
+			return errors.Errorf("Invalid column %q. Must be one of: %v", c, AllColumns)
+		}
+	}
 	resp, err := utils.GetSupabase().V1ListAllFunctionsWithResponse(ctx, projectRef)
 	if err != nil {
 		return errors.Errorf("failed to list functions: %w", err)
@@ -21,21 +37,70 @@ func Run(ctx context.Context, projectRef string, fsys afero.Fs) error {
 		return errors.New("Unexpected error retrieving functions: " + string(resp.Body))
 	}
 
-	table := `|ID|NAME|SLUG|STATUS|VERSION|UPDATED_AT (UTC)|
-|-|-|-|-|-|-|
-`
-	for _, function := range *resp.JSON200 {
-		t := time.UnixMilli(int64(function.UpdatedAt))
-		table += fmt.Sprintf(
-			"|`%s`|`%s`|`%s`|`%s`|`%d`|`%s`|\n",
-			function.Id,
-			function.Name,
-			function.Slug,
-			function.Status,
-			uint64(function.Version),
-			t.UTC().Format("2006-01-02 15:04:05"),
-		)
+	rows := make([]map[string]any, len(*resp.JSON200))
+	for i, function := range *resp.JSON200 {
+		rows[i] = toRow(function, columns)
 	}
+	if format != utils.OutputPretty {
+		return utils.EncodeOutput(format, os.Stdout, rows)
+	}
+	return list.RenderTable(toMarkdown(rows, columns))
+}
 
-	return list.RenderTable(table)
+func toRow(function api.FunctionResponse, columns []string) map[string]any {
+	all := map[string]any{
+		"id":              function.Id,
+		"name":            function.Name,
+		"slug":            function.Slug,
+		"status":          function.Status,
+		"version":         uint64(function.Version),
+		"created_at":      time.UnixMilli(int64(function.CreatedAt)).UTC(),
+		"updated_at":      time.UnixMilli(int64(function.UpdatedAt)).UTC(),
+		"verify_jwt":      function.VerifyJwt,
+		"entrypoint_path": function.EntrypointPath,
+		"import_map_path": function.ImportMapPath,
+	}
+	row := make(map[string]any, len(columns))
+	for _, c := range columns {
+		row[c] = all[c]
+	}
+	return row
+}
+
+func toMarkdown(rows []map[string]any, columns []string) string {
+	var table strings.Builder
+	for _, c := range columns {
+		header := strings.ToUpper(c)
+		if strings.HasSuffix(c, "_at") {
+			header += " (UTC)"
+		}
+		table.WriteString("|" + header)
+	}
+	table.WriteString("|\n|" + strings.Repeat("-|", len(columns)) + "\n")
+	for _, row := range rows {
+		for _, c := range columns {
+			table.WriteString("|`" + formatValue(row[c]) + "`")
+		}
+		table.WriteString("|\n")
+	}
+	return table.String()
+}
+
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format("2006-01-02 15:04:05")
+	case *string:
+		if v == nil {
+			return ""
+		}
+		return *v
+	case *bool:
+		if v == nil {
+			return ""
+		}
+		return fmt.Sprint(*v)
+	default:
+		return fmt.Sprint(v)
+	}
 }
@@ -47,7 +47,7 @@ func TestFunctionsListCommand(t *testing.T) {
 				ImportMapPath:  &testImportMapPath,
 			}})
 		// Run test
-		err := Run(context.Background(), project, fsys)
+		err := Run(context.Background(), project, utils.OutputPretty, nil, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -57,7 +57,7 @@ func TestFunctionsListCommand(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := Run(context.Background(), "", fsys)
+		err := Run(context.Background(), "", utils.OutputPretty, nil, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "Unexpected error retrieving functions")
 	})
@@ -76,9 +76,35 @@ func TestFunctionsListCommand(t *testing.T) {
 			Get("/v1/projects/" + project + "/functions").
 			ReplyError(errors.New("network error"))
 		// Run test
-		err := Run(context.Background(), project, fsys)
+		err := Run(context.Background(), project, utils.OutputPretty, nil, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "network error")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
 }
+
+func TestFunctionsListFormat(t *testing.T) {
+	function := api.FunctionResponse{
+		Id:        "test-id",
+		Slug:      "test-function",
+		UpdatedAt: 1687423025152.000000,
+		VerifyJwt: utils.Ptr(true),
+	}
+
+	t.Run("selects columns", func(t *testing.T) {
+		row := toRow(function, []string{"slug", "verify_jwt", "import_map_path"})
+		table := toMarkdown([]map[string]any{row}, []string{"slug", "verify_jwt", "import_map_path"})
+		assert.Equal(t, "|SLUG|VERIFY_JWT|IMPORT_MAP_PATH|\n|-|-|-|\n|`test-function`|`true`|``|\n", table)
+	})
+
+	t.Run("formats timestamps in utc", func(t *testing.T) {
+		row := toRow(function, []string{"updated_at"})
+		table := toMarkdown([]map[string]any{row}, []string{"updated_at"})
+		assert.Equal(t, "|UPDATED_AT (UTC)|\n|-|\n|`2023-06-22 08:37:05`|\n", table)
+	})
+
+	t.Run("throws error on invalid column", func(t *testing.T) {
+		err := Run(context.Background(), "", utils.OutputJson, []string{"size"}, afero.NewMemMapFs())
+		assert.ErrorContains(t, err, `Invalid column "size".`)
+	})
+}
@@ -0,0 +1,197 @@
+package ping
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/functions/invoke"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/tenant"
+)
+
+const (
+	// Asks the edge network to run the function in a specific region
+	RegionHintHeader = "x-region"
+	// Set by the edge network to the region that ran the function
+	ServedRegionHeader = "x-sb-edge-region"
+	AllRegions         = "all"
+)
+
+var Regions = []string{
+	"ap-northeast-1",
+	"ap-northeast-2",
+	"ap-south-1",
+	"ap-southeast-1",
+	"ap-southeast-2",
+	"ca-central-1",
+	"eu-central-1",
+	"eu-west-1",
+	"eu-west-2",
+	"eu-west-3",
+	"sa-east-1",
+	"us-east-1",
+	"us-west-1",
+	"us-west-2",
+}
+
+type Result struct {
+	// Empty if the request was routed without a region hint
+	Region   string
+	ServedBy string
+	// Highest status code of all requests, so that a failed cold start is never masked
+	Status int
+	// The first request may boot a new worker, while the rest reuse it
+	First time.Duration
+	Warm  time.Duration
+}
+
+// ColdStart estimates the time spent booting a worker, which is zero if one was already running.
+func (r Result) ColdStart() time.Duration {
+	if r.First > r.Warm {
+		return r.First - r.Warm
+	}
+	return 0
+}
+
+// Run sends count requests to the deployed function from each region in turn, and reports
+// the latency of the first request against the median of the rest.
+func Run(ctx context.Context, slug, projectRef string, regions []string, count uint, req invoke.Request, fsys afero.Fs) error {
+	if err := utils.ValidateFunctionSlug(slug); err != nil {
+		return err
+	}
+	if count < 2 {
+		return errors.New("--count must be at least 2 to measure warm requests.")
+	}
+	regions, err := ResolveRegions(regions)
+	if err != nil {
+		return err
+	}
+	endpoint, keys, err := invoke.ResolveTarget(ctx, projectRef, fsys)
+	if err != nil {
+		return err
+	}
+	url := endpoint + "/functions/v1/" + slug
+	var results []Result
+	failed := 0
+	for _, region := range regions {
+		fmt.Fprintln(os.Stderr, "Pinging", utils.Aqua(slug), "in region", formatRegion(region)+"...")
+		result, err := Ping(ctx, url, region, count, req, keys, fsys)
+		if err != nil {
+			return err
+		}
+		if result.Status >= http.StatusBadRequest {
+			failed++
+		}
+		results = append(results, result)
+	}
+	if err := list.RenderTable(renderResults(results)); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return errors.Errorf("Function returned an error status in %d of %d regions.", failed, len(results))
+	}
+	return nil
+}
+
+// ResolveRegions expands all to every region, and validates the rest.
+func ResolveRegions(regions []string) ([]string, error) {
+	if len(regions) == 0 {
+		return []string{""}, nil
+	}
+	var result []string
+	for _, r := range regions {
+		if r == AllRegions {
+			return Regions, nil
+		}
+		if !utils.SliceContains(Regions, r) {
+			return nil, errors.Errorf("Invalid region: %s. Must be %s or one of: %s", r, AllRegions, strings.Join(Regions, ", "))
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+func Ping(ctx context.Context, url, region string, count uint, req invoke.Request, keys tenant.ApiKey, fsys afero.Fs) (Result, error) {
+	result := Result{Region: region}
+	var warm []time.Duration
+	for i := uint(0); i < count; i++ {
+		httpReq, err := invoke.NewRequest(ctx, url, req, keys, fsys)
+		if err != nil {
+			return result, err
+		}
+		if len(region) > 0 {
+			httpReq.Header.Set(RegionHintHeader, region)
+		}
+		start := time.Now()
+		resp, err := http.DefaultClient.Do(httpReq)
+		if err != nil {
+			return result, errors.Errorf("failed to ping function: %w", err)
+		}
+		// Latency includes reading the full response body
+		_, err = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return result, errors.Errorf("failed to read response body: %w", err)
+		}
+		elapsed := time.Since(start)
+		result.Status = max(result.Status, resp.StatusCode)
+		result.ServedBy = resp.Header.Get(ServedRegionHeader)
+		if i == 0 {
+			result.First = elapsed
+		} else {
+			warm = append(warm, elapsed)
+		}
+	}
+	result.Warm = median(warm)
+	return result, nil
+}
+
+func median(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration{}, durations...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+func renderResults(results []Result) string {
+	table := "|REGION|SERVED BY|STATUS|FIRST REQUEST|WARM (MEDIAN)|COLD START (EST.)|\n|-|-|-|-|-|-|\n"
+	for _, r := range results {
+		servedBy := r.ServedBy
+		if len(servedBy) == 0 {
+			servedBy = "-"
+		}
+		table += fmt.Sprintf("|`%s`|`%s`|`%d`|`%s`|`%s`|`%s`|\n",
+			formatRegion(r.Region),
+			servedBy,
+			r.Status,
+			r.First.Round(time.Millisecond),
+			r.Warm.Round(time.Millisecond),
+			r.ColdStart().Round(time.Millisecond),
+		)
+	}
+	return table
+}
+
+func formatRegion(region string) string {
+	if len(region) == 0 {
+		return "nearest"
+	}
+	return region
+}
@@ -0,0 +1,122 @@
+package ping
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/functions/invoke"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestPingCommand(t *testing.T) {
+	const slug = "test-func"
+	// Setup valid project ref
+	project := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("pings function in each region", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{Name: "anon", ApiKey: "anon-key"}})
+		for _, region := range []string{"us-east-1", "eu-central-1"} {
+			gock.New("https://"+utils.GetSupabaseHost(project)).
+				Post("/functions/v1/"+slug).
+				MatchHeader("Authorization", "Bearer anon-key").
+				MatchHeader(RegionHintHeader, region).
+				Times(2).
+				Reply(http.StatusOK).
+				SetHeader(ServedRegionHeader, region).
+				JSON(map[string]string{"message": "pong"})
+		}
+		// Run test
+		err := Run(context.Background(), slug, project, []string{"us-east-1", "eu-central-1"}, 2, invoke.Request{}, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on failure status", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{Name: "anon", ApiKey: "anon-key"}})
+		gock.New("https://" + utils.GetSupabaseHost(project)).
+			Get("/functions/v1/" + slug).
+			Times(3).
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		err := Run(context.Background(), slug, project, nil, 3, invoke.Request{Method: "GET"}, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Function returned an error status in 1 of 1 regions.")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on failed cold start", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{Name: "anon", ApiKey: "anon-key"}})
+		gock.New("https://" + utils.GetSupabaseHost(project)).
+			Get("/functions/v1/" + slug).
+			Reply(http.StatusInternalServerError)
+		gock.New("https://" + utils.GetSupabaseHost(project)).
+			Get("/functions/v1/" + slug).
+			Times(2).
+			Reply(http.StatusOK)
+		// Run test
+		err := Run(context.Background(), slug, project, nil, 3, invoke.Request{Method: "GET"}, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Function returned an error status in 1 of 1 regions.")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on invalid region", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), slug, project, []string{"mars-1"}, 3, invoke.Request{}, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Invalid region: mars-1.")
+	})
+
+	t.Run("throws error on single request", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), slug, project, nil, 1, invoke.Request{}, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "--count must be at least 2")
+	})
+}
+
+func TestResolveRegions(t *testing.T) {
+	t.Run("expands all regions", func(t *testing.T) {
+		regions, err := ResolveRegions([]string{"us-east-1", AllRegions})
+		assert.NoError(t, err)
+		assert.Equal(t, Regions, regions)
+	})
+
+	t.Run("defaults to nearest region", func(t *testing.T) {
+		regions, err := ResolveRegions(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{""}, regions)
+	})
+}
+
+func TestColdStart(t *testing.T) {
+	assert.Equal(t, 400*time.Millisecond, Result{First: 500 * time.Millisecond, Warm: 100 * time.Millisecond}.ColdStart())
+	assert.Equal(t, time.Duration(0), Result{First: 90 * time.Millisecond, Warm: 100 * time.Millisecond}.ColdStart())
+	assert.Equal(t, 150*time.Millisecond, median([]time.Duration{200 * time.Millisecond, 100 * time.Millisecond}))
+}
@@ -0,0 +1,157 @@
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/functions/invoke"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Exchange is a single request and response pair recorded by `functions serve --record`.
+type Exchange struct {
+	Time     string  `json:"time"`
+	Function string  `json:"function"`
+	Request  Message `json:"request"`
+	Response Message `json:"response"`
+}
+
+type Message struct {
+	Method   string            `json:"method,omitempty"`
+	Path     string            `json:"path,omitempty"`
+	Status   int               `json:"status,omitempty"`
+	Headers  map[string]string `json:"headers"`
+	Body     string            `json:"body"`
+	Encoding string            `json:"encoding,omitempty"`
+}
+
+func (m Message) DecodeBody() ([]byte, error) {
+	if m.Encoding != "base64" {
+		return []byte(m.Body), nil
+	}
+	data, err := base64.StdEncoding.DecodeString(m.Body)
+	if err != nil {
+		return nil, errors.Errorf("failed to decode body: %w", err)
+	}
+	return data, nil
+}
+
+// Headers that are tied to the original connection or credentials are not replayed.
+var skipHeaders = map[string]bool{
+	"apikey":            true,
+	"authorization":     true,
+	"connection":        true,
+	"content-length":    true,
+	"host":              true,
+	"transfer-encoding": true,
+}
+
+// Run re-sends recorded requests to the local functions when projectRef is empty,
+// otherwise to the deployed functions.
+func Run(ctx context.Context, recordPath, slug, projectRef, role string, fsys afero.Fs) error {
+	exchanges, err := LoadExchanges(recordPath, fsys)
+	if err != nil {
+		return err
+	}
+	if len(slug) > 0 {
+		exchanges = filterFunction(exchanges, slug)
+	}
+	if len(exchanges) == 0 {
+		fmt.Fprintln(os.Stderr, "No recorded requests to replay.")
+		return nil
+	}
+	endpoint, keys, err := invoke.ResolveTarget(ctx, projectRef, fsys)
+	if err != nil {
+		return err
+	}
+	failed := 0
+	for _, e := range exchanges {
+		body, err := e.Request.DecodeBody()
+		if err != nil {
+			return err
+		}
+		req, err := http.NewRequestWithContext(ctx, e.Request.Method, endpoint+"/functions/v1"+e.Request.Path, bytes.NewReader(body))
+		if err != nil {
+			return errors.Errorf("failed to create request: %w", err)
+		}
+		for name, value := range e.Request.Headers {
+			if !skipHeaders[strings.ToLower(name)] {
+				req.Header.Set(name, value)
+			}
+		}
+		req.Header.Set("User-Agent", "SupabaseCLI/"+utils.Version)
+		invoke.SetApiKey(req, role, keys)
+		start := time.Now()
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return errors.Errorf("failed to replay request: %w", err)
+		}
+		// Drain the body so that the connection can be reused
+		_, err = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return errors.Errorf("failed to read response body: %w", err)
+		}
+		elapsed := time.Since(start).Round(time.Millisecond)
+		line := fmt.Sprintf("%s %s %d (%s)", e.Request.Method, e.Request.Path, resp.StatusCode, elapsed)
+		if resp.StatusCode != e.Response.Status {
+			failed++
+			line = utils.Red(line) + fmt.Sprintf(", recorded %d", e.Response.Status)
+		}
+		fmt.Println(line)
+	}
+	if failed > 0 {
+		return errors.Errorf("%d of %d replayed requests returned a different status.", failed, len(exchanges))
+	}
+	fmt.Fprintf(os.Stderr, "Replayed %d requests from %s.\n", len(exchanges), utils.Bold(recordPath))
+	return nil
+}
+
+// LoadExchanges reads recorded exchanges from a newline delimited JSON file.
+func LoadExchanges(recordPath string, fsys afero.Fs) ([]Exchange, error) {
+	f, err := fsys.Open(recordPath)
+	if err != nil {
+		return nil, errors.Errorf("failed to open record file: %w", err)
+	}
+	defer f.Close()
+	var result []Exchange
+	scanner := bufio.NewScanner(f)
+	// Recorded bodies may exceed the default token size
+	scanner.Buffer(nil, 64*1024*1024)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var e Exchange
+		if err := json.Unmarshal(line, &e); err != nil {
+			return nil, errors.Errorf("failed to parse line %d of %s: %w", lineNum, recordPath, err)
+		}
+		result = append(result, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Errorf("failed to read record file: %w", err)
+	}
+	return result, nil
+}
+
+func filterFunction(exchanges []Exchange, slug string) []Exchange {
+	var result []Exchange
+	for _, e := range exchanges {
+		if e.Function == slug {
+			result = append(result, e)
+		}
+	}
+	return result
+}
@@ -0,0 +1,99 @@
+package replay
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/functions/invoke"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+const mockRecord = `{"time":"2024-06-01T00:00:00Z","function":"hello","request":{"method":"POST","path":"/hello?name=world","headers":{"authorization":"Bearer local-key","content-type":"application/json","x-test":"true"},"body":"{\"name\":\"world\"}"},"response":{"status":200,"headers":{},"body":"Hello world"}}
+
+{"time":"2024-06-01T00:00:01Z","function":"upload","request":{"method":"PUT","path":"/upload","headers":{},"body":"AAEC","encoding":"base64"},"response":{"status":201,"headers":{},"body":""}}
+`
+
+func TestReplayCommand(t *testing.T) {
+	t.Run("replays requests against deployed functions", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "requests.ndjson", []byte(mockRecord), 0644))
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{Name: "anon", ApiKey: "anon-key"}})
+		gock.New("https://"+utils.GetSupabaseHost(project)).
+			Post("/functions/v1/hello").
+			MatchParam("name", "world").
+			MatchHeader("Authorization", "Bearer anon-key").
+			MatchHeader("X-Test", "true").
+			BodyString(`{"name":"world"}`).
+			Reply(http.StatusOK)
+		gock.New("https://" + utils.GetSupabaseHost(project)).
+			Put("/functions/v1/upload").
+			BodyString("\x00\x01\x02").
+			Reply(http.StatusCreated)
+		// Run test
+		err := Run(context.Background(), "requests.ndjson", "", project, invoke.RoleAnon, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on status mismatch", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "requests.ndjson", []byte(mockRecord), 0644))
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{Name: "service_role", ApiKey: "service-key"}})
+		gock.New("https://"+utils.GetSupabaseHost(project)).
+			Put("/functions/v1/upload").
+			MatchHeader("Authorization", "Bearer service-key").
+			Reply(http.StatusInternalServerError)
+		// Run test
+		err := Run(context.Background(), "requests.ndjson", "upload", project, invoke.RoleServiceRole, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "1 of 1 replayed requests returned a different status.")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on malformed record", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "requests.ndjson", []byte("{}\nnot json\n"), 0644))
+		// Run test
+		err := Run(context.Background(), "requests.ndjson", "", "", invoke.RoleAnon, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to parse line 2 of requests.ndjson:")
+	})
+
+	t.Run("throws error on missing file", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), "requests.ndjson", "", "", invoke.RoleAnon, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "failed to open record file:")
+	})
+}
@@ -6,8 +6,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/docker/docker/api/types/container"
@@ -46,6 +51,12 @@ func (mode InspectMode) toFlag() string {
 type RuntimeOption struct {
 	InspectMode *InspectMode
 	InspectMain bool
+	// Host address to expose the inspector on, defaults to the configured inspector port
+	InspectAddr string
+	// Prints the resolved environment before starting the runtime
+	PrintEnv bool
+	// Appends served requests and responses to this NDJSON file
+	RecordPath string
 }
 
 func (i *RuntimeOption) toArgs() []string {
@@ -59,6 +70,60 @@ func (i *RuntimeOption) toArgs() []string {
 	return flags
 }
 
+// Returns the host ip and port to bind the inspector to.
+func (i *RuntimeOption) inspectorAddr() (string, uint16, error) {
+	if len(i.InspectAddr) == 0 {
+		return "", utils.Config.EdgeRuntime.InspectorPort, nil
+	}
+	return ParseInspectAddr(i.InspectAddr)
+}
+
+// ParseInspectAddr accepts an inspector address in the form of `[host:]port`.
+func ParseInspectAddr(addr string) (string, uint16, error) {
+	host, port := "", addr
+	if strings.Contains(addr, ":") {
+		var err error
+		if host, port, err = net.SplitHostPort(addr); err != nil {
+			return "", 0, errors.Errorf("failed to parse inspector address: %w", err)
+		}
+	}
+	value, err := strconv.ParseUint(port, 10, 16)
+	if err != nil || value == 0 {
+		return "", 0, errors.Errorf("Invalid inspector port: %s", port)
+	}
+	return host, uint16(value), nil
+}
+
+// InspectFlag is a boolean flag that optionally accepts an inspector address, ie. --inspect=:9229
+type InspectFlag struct {
+	Enabled bool
+	Addr    string
+}
+
+func (f *InspectFlag) String() string {
+	if len(f.Addr) > 0 {
+		return f.Addr
+	}
+	return strconv.FormatBool(f.Enabled)
+}
+
+func (f *InspectFlag) Set(value string) error {
+	if enabled, err := strconv.ParseBool(value); err == nil {
+		f.Enabled, f.Addr = enabled, ""
+		return nil
+	}
+	if _, _, err := ParseInspectAddr(value); err != nil {
+		return err
+	}
+	f.Enabled, f.Addr = true, value
+	return nil
+}
+
+// Reports as bool so that the flag can be passed without a value.
+func (f *InspectFlag) Type() string {
+	return "bool"
+}
+
 const (
 	dockerRuntimeServerPort    = 8081
 	dockerRuntimeInspectorPort = 8083
@@ -69,7 +134,7 @@ var (
 	mainFuncEmbed string
 )
 
-func Run(ctx context.Context, envFilePath string, noVerifyJWT *bool, importMapPath string, runtimeOption RuntimeOption, fsys afero.Fs) error {
+func Run(ctx context.Context, envFilePaths []string, noVerifyJWT *bool, importMapPath string, runtimeOption RuntimeOption, fsys afero.Fs) error {
 	// 1. Sanity checks.
 	if err := utils.LoadConfigFS(fsys); err != nil {
 		return err
@@ -85,7 +150,7 @@ func Run(ctx context.Context, envFilePath string, noVerifyJWT *bool, importMapPa
 	// Use network alias because Deno cannot resolve `_` in hostname
 	dbUrl := fmt.Sprintf("postgresql://postgres:postgres@%s:5432/postgres", utils.DbAliases[0])
 	// 3. Serve and log to console
-	if err := ServeFunctions(ctx, envFilePath, noVerifyJWT, importMapPath, dbUrl, runtimeOption, os.Stderr, fsys); err != nil {
+	if err := ServeFunctions(ctx, envFilePaths, noVerifyJWT, importMapPath, dbUrl, runtimeOption, os.Stderr, fsys); err != nil {
 		return err
 	}
 	if err := utils.DockerStreamLogs(ctx, utils.EdgeRuntimeId, os.Stdout, os.Stderr); err != nil {
@@ -95,17 +160,15 @@ func Run(ctx context.Context, envFilePath string, noVerifyJWT *bool, importMapPa
 	return nil
 }
 
-func ServeFunctions(ctx context.Context, envFilePath string, noVerifyJWT *bool, importMapPath string, dbUrl string, runtimeOption RuntimeOption, w io.Writer, fsys afero.Fs) error {
+func ServeFunctions(ctx context.Context, envFilePaths []string, noVerifyJWT *bool, importMapPath string, dbUrl string, runtimeOption RuntimeOption, w io.Writer, fsys afero.Fs) error {
 	// 1. Load default values
-	if envFilePath == "" {
+	if len(envFilePaths) == 0 {
 		if f, err := fsys.Stat(utils.FallbackEnvFilePath); err == nil && !f.IsDir() {
-			envFilePath = utils.FallbackEnvFilePath
+			envFilePaths = []string{utils.FallbackEnvFilePath}
 		}
-	} else if !filepath.IsAbs(envFilePath) {
-		envFilePath = filepath.Join(utils.CurrentDirAbs, envFilePath)
 	}
 	// 2. Parse user defined env
-	env, err := parseEnvFile(envFilePath, fsys)
+	userEnv, err := parseEnvFiles(envFilePaths, fsys)
 	if err != nil {
 		return err
 	}
@@ -114,15 +177,15 @@ func ServeFunctions(ctx context.Context, envFilePath string, noVerifyJWT *bool,
 		return errors.Errorf("failed to resolve functions dir: %w", err)
 	}
 	dockerFuncDir := utils.ToDockerPath(hostFuncDir)
-	env = append(env,
+	env := []string{
 		fmt.Sprintf("SUPABASE_URL=http://%s:8000", utils.KongAliases[0]),
-		"SUPABASE_ANON_KEY="+utils.Config.Auth.AnonKey,
-		"SUPABASE_SERVICE_ROLE_KEY="+utils.Config.Auth.ServiceRoleKey,
-		"SUPABASE_DB_URL="+dbUrl,
-		"SUPABASE_INTERNAL_JWT_SECRET="+utils.Config.Auth.JwtSecret,
+		"SUPABASE_ANON_KEY=" + utils.Config.Auth.AnonKey,
+		"SUPABASE_SERVICE_ROLE_KEY=" + utils.Config.Auth.ServiceRoleKey,
+		"SUPABASE_DB_URL=" + dbUrl,
+		"SUPABASE_INTERNAL_JWT_SECRET=" + utils.Config.Auth.JwtSecret,
 		fmt.Sprintf("SUPABASE_INTERNAL_HOST_PORT=%d", utils.Config.Api.Port),
-		"SUPABASE_INTERNAL_FUNCTIONS_PATH="+dockerFuncDir,
-	)
+		"SUPABASE_INTERNAL_FUNCTIONS_PATH=" + dockerFuncDir,
+	}
 	if viper.GetBool("DEBUG") {
 		env = append(env, "SUPABASE_INTERNAL_DEBUG=true")
 	}
@@ -134,13 +197,37 @@ func ServeFunctions(ctx context.Context, envFilePath string, noVerifyJWT *bool,
 	if err != nil {
 		return err
 	}
+	// Copied sources are read-only because changes cannot be synced back to host
+	funcMode := "rw"
+	if utils.GetMountStrategy() == utils.MountStrategyCopy {
+		funcMode = "ro"
+	}
 	binds = append(binds,
 		// Reuse deno cache directory, ie. DENO_DIR, between container restarts
 		// https://denolib.gitbook.io/guide/advanced/deno_dir-code-fetch-and-cache
 		utils.EdgeRuntimeId+":/root/.cache/deno:rw",
-		hostFuncDir+":"+dockerFuncDir+":rw",
+		utils.ResolveBindSource(hostFuncDir)+":"+dockerFuncDir+":"+funcMode,
 	)
+	links, err := utils.BindSymlinks(hostFuncDir)
+	if err != nil {
+		return err
+	}
+	binds = utils.RemoveDuplicates(append(binds, links...))
 	env = append(env, "SUPABASE_INTERNAL_FUNCTIONS_CONFIG="+functionsConfigString)
+	if len(runtimeOption.RecordPath) > 0 {
+		bind, dockerRecordPath, err := bindRecordFile(runtimeOption.RecordPath, fsys)
+		if err != nil {
+			return err
+		}
+		binds = append(binds, bind)
+		env = append(env, "SUPABASE_INTERNAL_RECORD_PATH="+dockerRecordPath)
+	}
+	if runtimeOption.PrintEnv {
+		printEnv(w, userEnv, env)
+	}
+	for _, v := range userEnv {
+		env = append(env, v.Name+"="+v.Value)
+	}
 	// 4. Parse entrypoint script
 	cmd := append([]string{
 		"edge-runtime",
@@ -159,8 +246,13 @@ EOF
 `}
 	// 5. Parse exposed ports
 	ports := []string{fmt.Sprintf("::%d/tcp", dockerRuntimeServerPort)}
+	var inspectHost string
+	var inspectPort uint16
 	if runtimeOption.InspectMode != nil {
-		ports = append(ports, fmt.Sprintf(":%d:%d/tcp", utils.Config.EdgeRuntime.InspectorPort, dockerRuntimeInspectorPort))
+		if inspectHost, inspectPort, err = runtimeOption.inspectorAddr(); err != nil {
+			return err
+		}
+		ports = append(ports, fmt.Sprintf("%s:%d:%d/tcp", inspectHost, inspectPort, dockerRuntimeInspectorPort))
 	}
 	exposedPorts, portBindings, err := nat.ParsePortSpecs(ports)
 	if err != nil {
@@ -171,7 +263,7 @@ EOF
 	_, err = utils.DockerStart(
 		ctx,
 		container.Config{
-			Image:        utils.EdgeRuntimeImage,
+			Image:        utils.Config.EdgeRuntime.Image,
 			Env:          env,
 			Entrypoint:   entrypoint,
 			ExposedPorts: exposedPorts,
@@ -181,6 +273,7 @@ EOF
 		start.WithSyslogConfig(container.HostConfig{
 			Binds:        binds,
 			PortBindings: portBindings,
+			Resources:    utils.Config.EdgeRuntime.Resources.ToContainerResources(),
 		}),
 		network.NetworkingConfig{
 			EndpointsConfig: map[string]*network.EndpointSettings{
@@ -191,26 +284,116 @@ EOF
 		},
 		utils.EdgeRuntimeId,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	if runtimeOption.InspectMode != nil {
+		if len(inspectHost) == 0 || inspectHost == "0.0.0.0" {
+			inspectHost = utils.Config.Hostname
+		}
+		addr := net.JoinHostPort(inspectHost, strconv.Itoa(int(inspectPort)))
+		fmt.Fprintf(w, "Inspector listening on %s\n", utils.Aqua("ws://"+addr))
+		fmt.Fprintf(w, "Open %s in Chrome and add %s as a network target to attach DevTools.\n", utils.Bold("chrome://inspect"), utils.Bold(addr))
+	}
+	for _, url := range getRoutedUrls() {
+		fmt.Fprintln(w, "Serving functions on "+utils.Aqua(url))
+	}
+	if len(runtimeOption.RecordPath) > 0 {
+		fmt.Fprintln(w, "Recording requests to "+utils.Bold(runtimeOption.RecordPath))
+	}
+	if utils.GetMountStrategy() == utils.MountStrategyCopy {
+		fmt.Fprintln(w, "Functions are copied into the container. Restart serve to pick up changes.")
+	}
+	return nil
+}
+
+// Returns the URL templates of functions served under custom path prefixes
+// and hosts, which are routed by the API gateway in addition to /functions/v1/.
+func getRoutedUrls() []string {
+	var result []string
+	port := strconv.FormatUint(uint64(utils.Config.Api.Port), 10)
+	for _, prefix := range utils.Config.EdgeRuntime.PathPrefixes {
+		result = append(result, "http://"+net.JoinHostPort(utils.Config.Hostname, port)+prefix+"<function-name>")
+	}
+	for _, host := range utils.Config.EdgeRuntime.Hosts {
+		host = strings.Replace(host, "*", "<subdomain>", 1)
+		result = append(result, "http://"+net.JoinHostPort(host, port)+"/<function-name>")
+	}
+	return result
 }
 
-func parseEnvFile(envFilePath string, fsys afero.Fs) ([]string, error) {
-	env := []string{}
-	if len(envFilePath) == 0 {
-		return env, nil
+// Creates the record file on host and returns its bind mount and path within the container.
+func bindRecordFile(recordPath string, fsys afero.Fs) (string, string, error) {
+	hostPath, err := filepath.Abs(recordPath)
+	if err != nil {
+		return "", "", errors.Errorf("failed to resolve record path: %w", err)
+	}
+	hostDir := filepath.Dir(hostPath)
+	if err := utils.MkdirIfNotExistFS(fsys, hostDir); err != nil {
+		return "", "", err
 	}
-	envMap, err := set.ParseEnvFile(envFilePath, fsys)
+	f, err := fsys.OpenFile(hostPath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
 	if err != nil {
-		return env, err
+		return "", "", errors.Errorf("failed to open record file: %w", err)
+	}
+	defer f.Close()
+	// Mounts the parent directory so that appends still reach the host if the file is recreated
+	dockerDir := utils.ToDockerPath(hostDir)
+	return hostDir + ":" + dockerDir + ":rw", path.Join(dockerDir, filepath.Base(hostPath)), nil
+}
+
+type EnvVar struct {
+	Name   string
+	Value  string
+	Source string
+}
+
+var envNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// Parses env files in order, with values in later files taking precedence over earlier ones.
+func parseEnvFiles(envFilePaths []string, fsys afero.Fs) ([]EnvVar, error) {
+	resolved := map[string]EnvVar{}
+	for _, source := range envFilePaths {
+		envFilePath := source
+		if !filepath.IsAbs(envFilePath) {
+			envFilePath = filepath.Join(utils.CurrentDirAbs, envFilePath)
+		}
+		envMap, err := set.ParseEnvFile(envFilePath, fsys)
+		if err != nil {
+			return nil, err
+		}
+		for name, value := range envMap {
+			if !envNamePattern.MatchString(name) {
+				return nil, errors.Errorf("Invalid env name in %s: %s", source, name)
+			}
+			if strings.HasPrefix(name, "SUPABASE_") {
+				fmt.Fprintln(os.Stderr, "Env name cannot start with SUPABASE_, skipping: "+name)
+				continue
+			}
+			resolved[name] = EnvVar{Name: name, Value: value, Source: source}
+		}
+	}
+	result := make([]EnvVar, 0, len(resolved))
+	for _, v := range resolved {
+		result = append(result, v)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+func printEnv(w io.Writer, userEnv []EnvVar, reserved []string) {
+	fmt.Fprintln(w, "Resolved environment for Edge Functions:")
+	for _, v := range userEnv {
+		fmt.Fprintf(w, "  %s=%s (from %s)\n", v.Name, v.Value, v.Source)
 	}
-	for name, value := range envMap {
-		if strings.HasPrefix(name, "SUPABASE_") {
-			fmt.Fprintln(os.Stderr, "Env name cannot start with SUPABASE_, skipping: "+name)
-			continue
+	for _, kv := range reserved {
+		// Skip the internal functions config which is too long to be useful
+		if !strings.HasPrefix(kv, "SUPABASE_INTERNAL_FUNCTIONS_CONFIG=") {
+			fmt.Fprintf(w, "  %s (reserved)\n", kv)
 		}
-		env = append(env, name+"="+value)
 	}
-	return env, nil
 }
 
 func populatePerFunctionConfigs(importMapPath string, noVerifyJWT *bool, fsys afero.Fs) ([]string, string, error) {
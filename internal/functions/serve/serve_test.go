@@ -1,7 +1,9 @@
 package serve
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -37,7 +39,7 @@ func TestServeCommand(t *testing.T) {
 		apitest.MockDockerStart(utils.Docker, utils.GetRegistryImageUrl(utils.EdgeRuntimeImage), containerId)
 		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "success"))
 		// Run test
-		err := Run(context.Background(), "", nil, "", RuntimeOption{}, fsys)
+		err := Run(context.Background(), nil, nil, "", RuntimeOption{}, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -47,7 +49,7 @@ func TestServeCommand(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := Run(context.Background(), "", nil, "", RuntimeOption{}, fsys)
+		err := Run(context.Background(), nil, nil, "", RuntimeOption{}, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "open supabase/config.toml: file does not exist")
 	})
@@ -63,7 +65,7 @@ func TestServeCommand(t *testing.T) {
 			Get("/v" + utils.Docker.ClientVersion() + "/containers/supabase_db_test/json").
 			Reply(http.StatusNotFound)
 		// Run test
-		err := Run(context.Background(), "", nil, "", RuntimeOption{}, fsys)
+		err := Run(context.Background(), nil, nil, "", RuntimeOption{}, fsys)
 		// Check error
 		assert.ErrorIs(t, err, utils.ErrNotRunning)
 	})
@@ -80,7 +82,7 @@ func TestServeCommand(t *testing.T) {
 			Reply(http.StatusOK).
 			JSON(types.ContainerJSON{})
 		// Run test
-		err := Run(context.Background(), ".env", nil, "", RuntimeOption{}, fsys)
+		err := Run(context.Background(), []string{".env"}, nil, "", RuntimeOption{}, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "open .env: file does not exist")
 	})
@@ -100,8 +102,159 @@ func TestServeCommand(t *testing.T) {
 			Reply(http.StatusOK).
 			JSON(types.ContainerJSON{})
 		// Run test
-		err := Run(context.Background(), ".env", utils.Ptr(true), "import_map.json", RuntimeOption{}, fsys)
+		err := Run(context.Background(), []string{".env"}, utils.Ptr(true), "import_map.json", RuntimeOption{}, fsys)
 		// Check error
 		assert.ErrorIs(t, err, os.ErrNotExist)
 	})
 }
+
+func TestParseEnvFiles(t *testing.T) {
+	t.Run("later files take precedence", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ".env", []byte("API_URL=http://base\nLOG_LEVEL=info\nSUPABASE_URL=http://ignored\n"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, ".env.local", []byte("API_URL=http://local\n"), 0644))
+		// Run test
+		env, err := parseEnvFiles([]string{".env", ".env.local"}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []EnvVar{
+			{Name: "API_URL", Value: "http://local", Source: ".env.local"},
+			{Name: "LOG_LEVEL", Value: "info", Source: ".env"},
+		}, env)
+	})
+
+	t.Run("throws error on invalid name", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ".env", []byte("1PASSWORD=secret\n"), 0644))
+		// Run test
+		_, err := parseEnvFiles([]string{".env"}, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid env name in .env: 1PASSWORD")
+	})
+}
+
+func TestPrintEnv(t *testing.T) {
+	var buf bytes.Buffer
+	userEnv := []EnvVar{{Name: "API_URL", Value: "http://local", Source: ".env.local"}}
+	// Run test
+	printEnv(&buf, userEnv, []string{"SUPABASE_URL=http://kong:8000", "SUPABASE_INTERNAL_FUNCTIONS_CONFIG={}"})
+	// Check output
+	assert.Equal(t, `Resolved environment for Edge Functions:
+  API_URL=http://local (from .env.local)
+  SUPABASE_URL=http://kong:8000 (reserved)
+`, buf.String())
+}
+
+func TestGetRoutedUrls(t *testing.T) {
+	utils.Config.Hostname = "127.0.0.1"
+	utils.Config.Api.Port = 54321
+	utils.Config.EdgeRuntime.PathPrefixes = []string{"/api/functions/"}
+	utils.Config.EdgeRuntime.Hosts = []string{"*.functions.localhost"}
+	defer func() {
+		utils.Config.EdgeRuntime.PathPrefixes = nil
+		utils.Config.EdgeRuntime.Hosts = nil
+	}()
+	// Run test
+	urls := getRoutedUrls()
+	// Check output
+	assert.Equal(t, []string{
+		"http://127.0.0.1:54321/api/functions/<function-name>",
+		"http://<subdomain>.functions.localhost:54321/<function-name>",
+	}, urls)
+}
+
+func TestServeInspector(t *testing.T) {
+	t.Run("prints inspector address", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		require.NoError(t, utils.LoadConfigFS(fsys))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		apitest.MockDockerStart(utils.Docker, utils.GetRegistryImageUrl(utils.EdgeRuntimeImage), "supabase_edge_runtime_test")
+		option := RuntimeOption{
+			InspectMode: utils.Ptr(InspectModeRun),
+			InspectAddr: ":9229",
+		}
+		var buf bytes.Buffer
+		// Run test
+		err := ServeFunctions(context.Background(), nil, nil, "", "", option, &buf, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		assert.Contains(t, buf.String(), "ws://127.0.0.1:9229")
+	})
+
+	t.Run("throws error on invalid address", func(t *testing.T) {
+		option := RuntimeOption{
+			InspectMode: utils.Ptr(InspectModeBrk),
+			InspectAddr: "localhost",
+		}
+		// Run test
+		err := ServeFunctions(context.Background(), nil, nil, "", "", option, io.Discard, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Invalid inspector port: localhost")
+	})
+}
+
+func TestServeRecord(t *testing.T) {
+	t.Run("creates record file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		require.NoError(t, utils.LoadConfigFS(fsys))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		apitest.MockDockerStart(utils.Docker, utils.GetRegistryImageUrl(utils.EdgeRuntimeImage), "supabase_edge_runtime_test")
+		option := RuntimeOption{RecordPath: "requests.ndjson"}
+		var buf bytes.Buffer
+		// Run test
+		err := ServeFunctions(context.Background(), nil, nil, "", "", option, &buf, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		assert.Contains(t, buf.String(), "Recording requests to")
+		recordPath, err := filepath.Abs("requests.ndjson")
+		require.NoError(t, err)
+		exists, err := afero.Exists(fsys, recordPath)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+}
+
+func TestInspectFlag(t *testing.T) {
+	t.Run("accepts bool value", func(t *testing.T) {
+		var flag InspectFlag
+		assert.NoError(t, flag.Set("true"))
+		assert.True(t, flag.Enabled)
+		assert.Empty(t, flag.Addr)
+	})
+
+	t.Run("accepts host and port", func(t *testing.T) {
+		var flag InspectFlag
+		assert.NoError(t, flag.Set("0.0.0.0:9229"))
+		assert.True(t, flag.Enabled)
+		assert.Equal(t, "0.0.0.0:9229", flag.String())
+		host, port, err := ParseInspectAddr(flag.Addr)
+		assert.NoError(t, err)
+		assert.Equal(t, "0.0.0.0", host)
+		assert.Equal(t, uint16(9229), port)
+	})
+
+	t.Run("accepts port only", func(t *testing.T) {
+		host, port, err := ParseInspectAddr("9229")
+		assert.NoError(t, err)
+		assert.Empty(t, host)
+		assert.Equal(t, uint16(9229), port)
+	})
+
+	t.Run("throws error on invalid port", func(t *testing.T) {
+		var flag InspectFlag
+		assert.ErrorContains(t, flag.Set(":70000"), "Invalid inspector port: 70000")
+		assert.False(t, flag.Enabled)
+	})
+}
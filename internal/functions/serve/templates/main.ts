@@ -35,6 +35,7 @@ const FUNCTIONS_CONFIG_STRING = Deno.env.get(
 )!;
 
 const WALLCLOCK_LIMIT_SEC = parseInt(Deno.env.get("SUPABASE_INTERNAL_WALLCLOCK_LIMIT_SEC"));
+const RECORD_PATH = Deno.env.get("SUPABASE_INTERNAL_RECORD_PATH");
 
 const DENO_SB_ERROR_MAP = new Map([
   [Deno.errors.InvalidWorkerCreation, SB_SPECIFIC_ERROR_CODE.BootError],
@@ -77,34 +78,117 @@ const functionsConfig: Record<string, FunctionConfig> = (() => {
       );
     }
 
+    const unverified = Object.keys(functionsConfig)
+      .filter((name) => !functionsConfig[name].verifyJWT);
+    if (unverified.length > 0) {
+      console.log(`JWT verification is disabled for: ${unverified.join(", ")}`);
+    }
+
     return functionsConfig;
   } catch (cause) {
     throw new Error("Failed to parse functions config", { cause });
   }
 })();
 
+class AuthError extends Error {
+  constructor(message: string, readonly reason = message) {
+    super(message);
+  }
+}
+
 function getAuthToken(req: Request) {
   const authHeader = req.headers.get("authorization");
   if (!authHeader) {
-    throw new Error("Missing authorization header");
+    throw new AuthError("Missing authorization header");
   }
   const [bearer, token] = authHeader.split(" ");
-  if (bearer !== "Bearer") {
-    throw new Error(`Auth header is not 'Bearer {token}'`);
+  if (bearer !== "Bearer" || !token) {
+    throw new AuthError(`Auth header is not 'Bearer {token}'`);
   }
   return token;
 }
 
-async function verifyJWT(jwt: string): Promise<boolean> {
+// Verifies the token signature and expiry against the local JWT secret, like the gateway
+// in front of hosted functions does against the project's JWT secret.
+async function verifyJWT(jwt: string) {
   const encoder = new TextEncoder();
   const secretKey = encoder.encode(JWT_SECRET);
   try {
     await jose.jwtVerify(jwt, secretKey);
   } catch (e) {
-    console.error(e);
-    return false;
+    throw new AuthError("Invalid JWT", getJWTErrorReason(e));
+  }
+}
+
+function getJWTErrorReason(e: Error) {
+  if (e instanceof jose.errors.JWTExpired) {
+    return "token has expired";
+  }
+  if (e instanceof jose.errors.JWSSignatureVerificationFailed) {
+    return "signature does not match the local JWT secret, tokens issued by a hosted project are not valid locally";
+  }
+  if (e instanceof jose.errors.JOSEAlgNotAllowed) {
+    return "token is not signed with the local JWT secret";
+  }
+  return e.message;
+}
+
+// Matches the error response of hosted functions, so that clients handle both the same way.
+function getUnauthorizedResponse(functionName: string, e: Error) {
+  const { message, reason } = e instanceof AuthError
+    ? e
+    : { message: "Invalid JWT", reason: e.message };
+  console.error(
+    `${functionName}: 401 Unauthorized (${reason}). Set verify_jwt = false under [functions.${functionName}] in supabase/config.toml or pass --no-verify-jwt to skip verification.`,
+  );
+  return getResponse(
+    { code: STATUS_CODE.Unauthorized, message },
+    STATUS_CODE.Unauthorized,
+  );
+}
+
+function encodeBody(data: ArrayBuffer) {
+  try {
+    return { body: new TextDecoder("utf-8", { fatal: true }).decode(data) };
+  } catch {
+    // Binary payloads are stored as base64 to keep each record valid JSON
+    let binary = "";
+    for (const byte of new Uint8Array(data)) {
+      binary += String.fromCharCode(byte);
+    }
+    return { body: btoa(binary), encoding: "base64" };
+  }
+}
+
+async function recordExchange(
+  functionName: string,
+  url: URL,
+  req: Request,
+  reqBody: ArrayBuffer,
+  res: Response,
+) {
+  try {
+    const entry = {
+      time: new Date().toISOString(),
+      function: functionName,
+      request: {
+        method: req.method,
+        path: url.pathname + url.search,
+        headers: Object.fromEntries(req.headers),
+        ...encodeBody(reqBody),
+      },
+      response: {
+        status: res.status,
+        headers: Object.fromEntries(res.headers),
+        ...encodeBody(await res.arrayBuffer()),
+      },
+    };
+    await Deno.writeTextFile(RECORD_PATH!, JSON.stringify(entry) + "\n", {
+      append: true,
+    });
+  } catch (e) {
+    console.error("Failed to record request:", e);
   }
-  return true;
 }
 
 Deno.serve({
@@ -130,17 +214,12 @@ Deno.serve({
       return getResponse("Function not found", STATUS_CODE.NotFound);
     }
 
+    // CORS preflight requests never carry credentials, so they are let through as in production
     if (req.method !== "OPTIONS" && functionsConfig[functionName].verifyJWT) {
       try {
-        const token = getAuthToken(req);
-        const isValidJWT = await verifyJWT(token);
-
-        if (!isValidJWT) {
-          return getResponse({ msg: "Invalid JWT" }, STATUS_CODE.Unauthorized);
-        }
+        await verifyJWT(getAuthToken(req));
       } catch (e) {
-        console.error(e);
-        return getResponse({ msg: e.toString() }, STATUS_CODE.Unauthorized);
+        return getUnauthorizedResponse(functionName, e);
       }
     }
 
@@ -188,7 +267,14 @@ Deno.serve({
       // TODO: make this configuarable
       setTimeout(() => controller.abort(), 200 * 1000);
 
-      return await worker.fetch(req, { signal });
+      if (!RECORD_PATH) {
+        return await worker.fetch(req, { signal });
+      }
+      const reqBody = await req.clone().arrayBuffer();
+      const res = await worker.fetch(req, { signal });
+      // Records in the background so streaming responses are not delayed
+      recordExchange(functionName, url, req, reqBody, res.clone());
+      return res;
     } catch (e) {
       console.error(e);
 
@@ -0,0 +1,59 @@
+package check
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var ErrStale = errors.New("Generated file is out of date.")
+
+// Run compares generated output against the file committed at path, printing a unified diff
+// to stdout if they differ. A missing file is diffed against empty content.
+func Run(path string, generated []byte, fsys afero.Fs) error {
+	committed, err := afero.ReadFile(fsys, path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Errorf("failed to read generated file: %w", err)
+	}
+	if string(committed) == string(generated) {
+		fmt.Fprintln(os.Stderr, utils.Bold(path), "is up to date.")
+		return nil
+	}
+	if err := writeDiff(os.Stdout, path, string(committed), string(generated)); err != nil {
+		return err
+	}
+	utils.CmdSuggestion = fmt.Sprintf("Run the same command without %s and save its output to %s.", utils.Aqua("--check"), utils.Bold(path))
+	return errors.New(ErrStale)
+}
+
+func writeDiff(w io.Writer, path, committed, generated string) error {
+	diff := difflib.UnifiedDiff{
+		A:        splitLines(committed),
+		B:        splitLines(generated),
+		FromFile: "a/" + path,
+		ToFile:   "b/" + path,
+		Context:  3,
+	}
+	if err := difflib.WriteUnifiedDiff(w, diff); err != nil {
+		return errors.Errorf("failed to write diff: %w", err)
+	}
+	return nil
+}
+
+// Unlike difflib.SplitLines, the trailing newline does not add an empty line.
+func splitLines(s string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	if !strings.HasSuffix(s, "\n") {
+		s += "\n"
+	}
+	lines := strings.SplitAfter(s, "\n")
+	return lines[:len(lines)-1]
+}
@@ -0,0 +1,77 @@
+package check
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/fstest"
+)
+
+func TestCheckCommand(t *testing.T) {
+	t.Run("passes on up to date file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "types.ts", []byte("export type A = string\n"), 0644))
+		// Run test
+		err := Run("types.ts", []byte("export type A = string\n"), fsys)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on stale file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "types.ts", []byte("export type A = string\n"), 0644))
+		// Run test
+		err := Run("types.ts", []byte("export type A = number\n"), fsys)
+		// Check error
+		assert.ErrorIs(t, err, ErrStale)
+	})
+
+	t.Run("throws error on missing file", func(t *testing.T) {
+		// Run test
+		err := Run("types.ts", []byte("export type A = string\n"), afero.NewMemMapFs())
+		// Check error
+		assert.ErrorIs(t, err, ErrStale)
+	})
+
+	t.Run("throws error on permission denied", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := &fstest.OpenErrorFs{DenyPath: "types.ts"}
+		// Run test
+		err := Run("types.ts", []byte{}, fsys)
+		// Check error
+		assert.ErrorIs(t, err, os.ErrPermission)
+	})
+}
+
+func TestWriteDiff(t *testing.T) {
+	t.Run("writes unified diff", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := writeDiff(&out, "types.ts", "a\nb\nc\n", "a\nB\nc\n")
+		// Check output
+		assert.NoError(t, err)
+		assert.Equal(t, `--- a/types.ts
++++ b/types.ts
+@@ -1,3 +1,3 @@
+ a
+-b
++B
+ c
+`, out.String())
+	})
+
+	t.Run("diffs new file against empty", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := writeDiff(&out, "types.ts", "", "a\n")
+		// Check output
+		assert.NoError(t, err)
+		assert.Equal(t, "--- a/types.ts\n+++ b/types.ts\n@@ -0,0 +1 @@\n+a\n", out.String())
+	})
+}
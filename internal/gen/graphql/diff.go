@@ -0,0 +1,164 @@
+package graphql
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Definition is a named type in SDL, with its members keyed by name. Object fields map
+// to their output type, arguments are keyed by `field(arg)`, and enum values, union
+// members, and implemented interfaces map to an empty string.
+type Definition struct {
+	Kind    string
+	Members map[string]string
+}
+
+var (
+	typePattern   = regexp.MustCompile(`^(type|interface|input|enum) (\w+)(?: implements (.+?))? \{$`)
+	unionPattern  = regexp.MustCompile(`^union (\w+) = (.+)$`)
+	scalarPattern = regexp.MustCompile(`^scalar (\w+)$`)
+	fieldPattern  = regexp.MustCompile(`^(\w+)(?:\((.*)\))?: (.+?)(?: @deprecated.*)?$`)
+	valuePattern  = regexp.MustCompile(`^(\w+)`)
+)
+
+// ParseSDL reads back the subset of SDL produced by PrintSchema.
+func ParseSDL(sdl string) map[string]Definition {
+	result := map[string]Definition{}
+	var current *Definition
+	inDescription := false
+	for _, line := range strings.Split(sdl, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, `"""`) {
+			if len(line) < 6 || !strings.HasSuffix(line, `"""`) {
+				inDescription = !inDescription
+			}
+			continue
+		}
+		if inDescription || len(line) == 0 {
+			continue
+		}
+		if line == "}" {
+			current = nil
+			continue
+		}
+		if matches := typePattern.FindStringSubmatch(line); len(matches) > 0 {
+			def := Definition{Kind: matches[1], Members: map[string]string{}}
+			if len(matches[3]) > 0 {
+				for _, name := range strings.Split(matches[3], " & ") {
+					def.Members["implements "+name] = ""
+				}
+			}
+			result[matches[2]] = def
+			current = &def
+			continue
+		}
+		if matches := unionPattern.FindStringSubmatch(line); len(matches) > 0 {
+			def := Definition{Kind: "union", Members: map[string]string{}}
+			for _, name := range strings.Split(matches[2], " | ") {
+				def.Members[name] = ""
+			}
+			result[matches[1]] = def
+			continue
+		}
+		if matches := scalarPattern.FindStringSubmatch(line); len(matches) > 0 {
+			result[matches[1]] = Definition{Kind: "scalar"}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		switch current.Kind {
+		case "enum":
+			if matches := valuePattern.FindStringSubmatch(line); len(matches) > 0 {
+				current.Members[matches[1]] = ""
+			}
+		case "input":
+			if name, value, found := strings.Cut(line, ": "); found {
+				current.Members[name] = value
+			}
+		default:
+			matches := fieldPattern.FindStringSubmatch(line)
+			if len(matches) == 0 {
+				continue
+			}
+			current.Members[matches[1]] = matches[3]
+			for _, arg := range splitArgs(matches[2]) {
+				if name, value, found := strings.Cut(arg, ": "); found {
+					current.Members[fmt.Sprintf("%s(%s)", matches[1], name)] = value
+				}
+			}
+		}
+	}
+	return result
+}
+
+// Splits arguments on top level commas, ignoring those within default values.
+func splitArgs(args string) []string {
+	var result []string
+	depth, start := 0, 0
+	inString := false
+	for i, c := range args {
+		switch {
+		case c == '"' && (i == 0 || args[i-1] != '\\'):
+			inString = !inString
+		case inString:
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		case c == ',' && depth == 0:
+			result = append(result, strings.TrimSpace(args[start:i]))
+			start = i + 1
+		}
+	}
+	if rest := strings.TrimSpace(args[start:]); len(rest) > 0 {
+		result = append(result, rest)
+	}
+	return result
+}
+
+// FindBreakingChanges lists changes that may break existing clients: removed types and
+// members, changed member types, and newly added required inputs.
+func FindBreakingChanges(before, after map[string]Definition) []string {
+	var result []string
+	for name, o := range before {
+		n, ok := after[name]
+		if !ok {
+			result = append(result, fmt.Sprintf("Removed %s %s", o.Kind, name))
+			continue
+		}
+		if n.Kind != o.Kind {
+			result = append(result, fmt.Sprintf("Changed %s from %s to %s", name, o.Kind, n.Kind))
+			continue
+		}
+		for member, ov := range o.Members {
+			nv, ok := n.Members[member]
+			if !ok {
+				result = append(result, fmt.Sprintf("Removed %s.%s", name, member))
+			} else if typeOf(ov) != typeOf(nv) {
+				result = append(result, fmt.Sprintf("Changed %s.%s from %s to %s", name, member, typeOf(ov), typeOf(nv)))
+			}
+		}
+		for member, nv := range n.Members {
+			if _, ok := o.Members[member]; ok {
+				continue
+			}
+			if (n.Kind == "input" || strings.Contains(member, "(")) && isRequired(nv) {
+				result = append(result, fmt.Sprintf("Added required %s.%s", name, member))
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func typeOf(value string) string {
+	t, _, _ := strings.Cut(value, " = ")
+	return t
+}
+
+func isRequired(value string) bool {
+	return strings.HasSuffix(value, "!") && !strings.Contains(value, " = ")
+}
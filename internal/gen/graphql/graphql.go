@@ -0,0 +1,119 @@
+package graphql
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/tenant"
+	"github.com/supabase/cli/pkg/fetcher"
+)
+
+//go:embed templates/introspection.graphql
+var introspectionQuery string
+
+var ErrBreakingChange = errors.New("GraphQL schema has breaking changes")
+
+func Run(ctx context.Context, projectRef, outPath string, failOnBreaking bool, fsys afero.Fs) error {
+	api, err := newGraphqlAPI(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	schema, err := Introspect(ctx, api)
+	if err != nil {
+		return err
+	}
+	sdl := PrintSchema(schema)
+	if len(outPath) == 0 {
+		fmt.Print(sdl)
+		return nil
+	}
+	if failOnBreaking {
+		if err := checkSnapshot(outPath, sdl, fsys); err != nil {
+			return err
+		}
+	}
+	if err := utils.WriteFile(outPath, []byte(sdl), fsys); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Saved GraphQL schema to "+utils.Bold(outPath)+".")
+	return nil
+}
+
+func newGraphqlAPI(ctx context.Context, projectRef string) (*fetcher.Fetcher, error) {
+	server := fmt.Sprintf("http://%s:%d", utils.Config.Hostname, utils.Config.Api.Port)
+	token := utils.Config.Auth.AnonKey
+	if len(projectRef) > 0 {
+		server = "https://" + utils.GetSupabaseHost(projectRef)
+		keys, err := tenant.GetApiKeys(ctx, projectRef)
+		if err != nil {
+			return nil, err
+		}
+		token = keys.Anon
+	}
+	// Introspects as the anon role to match what API consumers see
+	header := func(req *http.Request) {
+		req.Header.Add("apikey", token)
+	}
+	return fetcher.NewFetcher(
+		server,
+		fetcher.WithBearerToken(token),
+		fetcher.WithRequestEditor(header),
+		fetcher.WithUserAgent("SupabaseCLI/"+utils.Version),
+		fetcher.WithExpectedStatus(http.StatusOK),
+	), nil
+}
+
+type introspectionResponse struct {
+	Data struct {
+		Schema Schema `json:"__schema"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func Introspect(ctx context.Context, api *fetcher.Fetcher) (Schema, error) {
+	body := map[string]string{"query": introspectionQuery}
+	resp, err := api.Send(ctx, http.MethodPost, "/graphql/v1", body)
+	if err != nil {
+		return Schema{}, err
+	}
+	defer resp.Body.Close()
+	data, err := fetcher.ParseJSON[introspectionResponse](resp.Body)
+	if err != nil {
+		return Schema{}, err
+	}
+	if len(data.Errors) > 0 {
+		var messages []string
+		for _, e := range data.Errors {
+			messages = append(messages, e.Message)
+		}
+		return Schema{}, errors.Errorf("failed to introspect schema: %s", strings.Join(messages, "\n"))
+	}
+	return data.Data.Schema, nil
+}
+
+func checkSnapshot(path, sdl string, fsys afero.Fs) error {
+	snapshot, err := afero.ReadFile(fsys, path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return errors.Errorf("failed to read schema snapshot: %w", err)
+	}
+	changes := FindBreakingChanges(ParseSDL(string(snapshot)), ParseSDL(sdl))
+	if len(changes) == 0 {
+		return nil
+	}
+	fmt.Fprintln(os.Stderr, "Found breaking changes compared to "+utils.Bold(path)+":")
+	for _, c := range changes {
+		fmt.Fprintln(os.Stderr, "  "+c)
+	}
+	return errors.New(ErrBreakingChange)
+}
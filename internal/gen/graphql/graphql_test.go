@@ -0,0 +1,212 @@
+package graphql
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func named(kind, name string) TypeRef {
+	return TypeRef{Kind: kind, Name: &name}
+}
+
+func nonNull(t TypeRef) TypeRef {
+	return TypeRef{Kind: "NON_NULL", OfType: &t}
+}
+
+var mockSchema = Schema{
+	QueryType: &NamedType{Name: "Query"},
+	Types: []FullType{{
+		Kind: "OBJECT",
+		Name: "Query",
+		Fields: []Field{{
+			Name: "todosCollection",
+			Args: []InputValue{{
+				Name:         "first",
+				Type:         named("SCALAR", "Int"),
+				DefaultValue: utils.Ptr("10"),
+			}, {
+				Name: "filter",
+				Type: named("INPUT_OBJECT", "TodoFilter"),
+			}},
+			Type: named("OBJECT", "Todo"),
+		}},
+	}, {
+		Kind:        "OBJECT",
+		Name:        "Todo",
+		Description: utils.Ptr("A task to complete"),
+		Fields: []Field{{
+			Name: "id",
+			Type: nonNull(named("SCALAR", "ID")),
+		}, {
+			Name:              "title",
+			Type:              named("SCALAR", "String"),
+			IsDeprecated:      true,
+			DeprecationReason: utils.Ptr("Use name"),
+		}},
+	}, {
+		Kind: "INPUT_OBJECT",
+		Name: "TodoFilter",
+		InputFields: []InputValue{{
+			Name: "status",
+			Type: named("ENUM", "Status"),
+		}},
+	}, {
+		Kind:       "ENUM",
+		Name:       "Status",
+		EnumValues: []EnumValue{{Name: "done"}, {Name: "open"}},
+	}, {
+		Kind: "SCALAR",
+		Name: "String",
+	}, {
+		Kind: "OBJECT",
+		Name: "__Type",
+	}},
+}
+
+const mockSDL = `type Query {
+  todosCollection(first: Int = 10, filter: TodoFilter): Todo
+}
+
+enum Status {
+  done
+  open
+}
+
+"""A task to complete"""
+type Todo {
+  id: ID!
+  title: String @deprecated(reason: "Use name")
+}
+
+input TodoFilter {
+  status: Status
+}
+`
+
+func TestPrintSchema(t *testing.T) {
+	t.Run("prints sorted types", func(t *testing.T) {
+		assert.Equal(t, mockSDL, PrintSchema(mockSchema))
+	})
+
+	t.Run("prints custom root types", func(t *testing.T) {
+		schema := Schema{QueryType: &NamedType{Name: "Root"}}
+		assert.Equal(t, "schema {\n  query: Root\n}\n", PrintSchema(schema))
+	})
+}
+
+func TestFindBreakingChanges(t *testing.T) {
+	t.Run("ignores additive changes", func(t *testing.T) {
+		after := mockSDL + `
+type Comment {
+  id: ID!
+}
+`
+		assert.Empty(t, FindBreakingChanges(ParseSDL(mockSDL), ParseSDL(after)))
+	})
+
+	t.Run("detects breaking changes", func(t *testing.T) {
+		after := `enum Status {
+  open
+}
+
+type Query {
+  todosCollection(first: Int = 10, filter: TodoFilter, owner: ID!): Todo
+}
+
+type Todo {
+  id: String!
+}
+
+type TodoFilter {
+  status: Status
+}
+`
+		assert.Equal(t, []string{
+			"Added required Query.todosCollection(owner)",
+			"Changed Todo.id from ID! to String!",
+			"Changed TodoFilter from input to type",
+			"Removed Status.done",
+			"Removed Todo.title",
+		}, FindBreakingChanges(ParseSDL(mockSDL), ParseSDL(after)))
+	})
+}
+
+func TestGraphqlSchemaCommand(t *testing.T) {
+	utils.Config.Hostname = "127.0.0.1"
+	utils.Config.Api.Port = 54321
+	utils.Config.Auth.AnonKey = "anon-key"
+	localApi := "http://127.0.0.1:54321"
+
+	t.Run("writes schema to file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(localApi).
+			Post("/graphql/v1").
+			MatchHeader("apikey", "anon-key").
+			Reply(http.StatusOK).
+			JSON(map[string]any{"data": map[string]any{"__schema": mockSchema}})
+		// Run test
+		err := Run(context.Background(), "", "schema.graphql", true, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		contents, err := afero.ReadFile(fsys, "schema.graphql")
+		assert.NoError(t, err)
+		assert.Equal(t, mockSDL, string(contents))
+	})
+
+	t.Run("throws error on breaking change", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		snapshot := mockSDL + "\nscalar Cursor\n"
+		require.NoError(t, afero.WriteFile(fsys, "schema.graphql", []byte(snapshot), 0644))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(localApi).
+			Post("/graphql/v1").
+			Reply(http.StatusOK).
+			JSON(map[string]any{"data": map[string]any{"__schema": mockSchema}})
+		// Run test
+		err := Run(context.Background(), "", "schema.graphql", true, fsys)
+		// Check error
+		assert.ErrorIs(t, err, ErrBreakingChange)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		contents, err := afero.ReadFile(fsys, "schema.graphql")
+		assert.NoError(t, err)
+		assert.Equal(t, snapshot, string(contents))
+	})
+
+	t.Run("uses anon key of linked project", func(t *testing.T) {
+		projectRef := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{Name: "anon", ApiKey: "linked-key"}})
+		gock.New("https://"+utils.GetSupabaseHost(projectRef)).
+			Post("/graphql/v1").
+			MatchHeader("apikey", "linked-key").
+			Reply(http.StatusOK).
+			JSON(map[string]any{"errors": []map[string]string{{"message": "permission denied"}}})
+		// Run test
+		err := Run(context.Background(), projectRef, "", false, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "failed to introspect schema: permission denied")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -0,0 +1,216 @@
+package graphql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type Schema struct {
+	QueryType        *NamedType `json:"queryType"`
+	MutationType     *NamedType `json:"mutationType"`
+	SubscriptionType *NamedType `json:"subscriptionType"`
+	Types            []FullType `json:"types"`
+}
+
+type NamedType struct {
+	Name string `json:"name"`
+}
+
+type FullType struct {
+	Kind          string       `json:"kind"`
+	Name          string       `json:"name"`
+	Description   *string      `json:"description"`
+	Fields        []Field      `json:"fields"`
+	InputFields   []InputValue `json:"inputFields"`
+	Interfaces    []TypeRef    `json:"interfaces"`
+	EnumValues    []EnumValue  `json:"enumValues"`
+	PossibleTypes []TypeRef    `json:"possibleTypes"`
+}
+
+type Field struct {
+	Name              string       `json:"name"`
+	Description       *string      `json:"description"`
+	Args              []InputValue `json:"args"`
+	Type              TypeRef      `json:"type"`
+	IsDeprecated      bool         `json:"isDeprecated"`
+	DeprecationReason *string      `json:"deprecationReason"`
+}
+
+type InputValue struct {
+	Name         string  `json:"name"`
+	Description  *string `json:"description"`
+	Type         TypeRef `json:"type"`
+	DefaultValue *string `json:"defaultValue"`
+}
+
+type EnumValue struct {
+	Name              string  `json:"name"`
+	Description       *string `json:"description"`
+	IsDeprecated      bool    `json:"isDeprecated"`
+	DeprecationReason *string `json:"deprecationReason"`
+}
+
+type TypeRef struct {
+	Kind   string   `json:"kind"`
+	Name   *string  `json:"name"`
+	OfType *TypeRef `json:"ofType"`
+}
+
+func (t TypeRef) String() string {
+	switch t.Kind {
+	case "NON_NULL":
+		if t.OfType != nil {
+			return t.OfType.String() + "!"
+		}
+	case "LIST":
+		if t.OfType != nil {
+			return "[" + t.OfType.String() + "]"
+		}
+	}
+	if t.Name != nil {
+		return *t.Name
+	}
+	return ""
+}
+
+var builtinScalars = map[string]bool{
+	"String":  true,
+	"Int":     true,
+	"Float":   true,
+	"Boolean": true,
+	"ID":      true,
+}
+
+// PrintSchema renders introspection results as SDL, with types sorted by name so that
+// the output is stable across runs.
+func PrintSchema(schema Schema) string {
+	types := make([]FullType, 0, len(schema.Types))
+	for _, t := range schema.Types {
+		if strings.HasPrefix(t.Name, "__") || builtinScalars[t.Name] {
+			continue
+		}
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].Name < types[j].Name
+	})
+	var sb strings.Builder
+	if roots := printRoots(schema); len(roots) > 0 {
+		sb.WriteString(roots)
+	}
+	for i, t := range types {
+		if i > 0 || sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		printType(&sb, t)
+	}
+	return sb.String()
+}
+
+// Root types are only printed when they differ from the default names.
+func printRoots(schema Schema) string {
+	var roots []string
+	if t := schema.QueryType; t != nil && t.Name != "Query" {
+		roots = append(roots, "  query: "+t.Name)
+	}
+	if t := schema.MutationType; t != nil && t.Name != "Mutation" {
+		roots = append(roots, "  mutation: "+t.Name)
+	}
+	if t := schema.SubscriptionType; t != nil && t.Name != "Subscription" {
+		roots = append(roots, "  subscription: "+t.Name)
+	}
+	if len(roots) == 0 {
+		return ""
+	}
+	return "schema {\n" + strings.Join(roots, "\n") + "\n}\n"
+}
+
+func printType(sb *strings.Builder, t FullType) {
+	printDescription(sb, t.Description, "")
+	switch t.Kind {
+	case "SCALAR":
+		fmt.Fprintf(sb, "scalar %s\n", t.Name)
+	case "UNION":
+		var members []string
+		for _, p := range t.PossibleTypes {
+			members = append(members, p.String())
+		}
+		fmt.Fprintf(sb, "union %s = %s\n", t.Name, strings.Join(members, " | "))
+	case "ENUM":
+		fmt.Fprintf(sb, "enum %s {\n", t.Name)
+		for _, v := range t.EnumValues {
+			printDescription(sb, v.Description, "  ")
+			fmt.Fprintf(sb, "  %s%s\n", v.Name, printDeprecated(v.IsDeprecated, v.DeprecationReason))
+		}
+		sb.WriteString("}\n")
+	case "INPUT_OBJECT":
+		fmt.Fprintf(sb, "input %s {\n", t.Name)
+		for _, f := range t.InputFields {
+			printDescription(sb, f.Description, "  ")
+			fmt.Fprintf(sb, "  %s\n", printInputValue(f))
+		}
+		sb.WriteString("}\n")
+	default:
+		keyword := "type"
+		if t.Kind == "INTERFACE" {
+			keyword = "interface"
+		}
+		fmt.Fprintf(sb, "%s %s", keyword, t.Name)
+		if len(t.Interfaces) > 0 {
+			var names []string
+			for _, i := range t.Interfaces {
+				names = append(names, i.String())
+			}
+			fmt.Fprintf(sb, " implements %s", strings.Join(names, " & "))
+		}
+		sb.WriteString(" {\n")
+		for _, f := range t.Fields {
+			printDescription(sb, f.Description, "  ")
+			var args []string
+			for _, a := range f.Args {
+				args = append(args, printInputValue(a))
+			}
+			fmt.Fprintf(sb, "  %s", f.Name)
+			if len(args) > 0 {
+				fmt.Fprintf(sb, "(%s)", strings.Join(args, ", "))
+			}
+			fmt.Fprintf(sb, ": %s%s\n", f.Type, printDeprecated(f.IsDeprecated, f.DeprecationReason))
+		}
+		sb.WriteString("}\n")
+	}
+}
+
+func printInputValue(v InputValue) string {
+	result := fmt.Sprintf("%s: %s", v.Name, v.Type)
+	if v.DefaultValue != nil {
+		result += " = " + *v.DefaultValue
+	}
+	return result
+}
+
+func printDescription(sb *strings.Builder, description *string, indent string) {
+	if description == nil || len(*description) == 0 {
+		return
+	}
+	escaped := strings.ReplaceAll(*description, `"""`, `\"""`)
+	if strings.Contains(escaped, "\n") {
+		fmt.Fprintf(sb, "%s\"\"\"\n", indent)
+		for _, line := range strings.Split(escaped, "\n") {
+			fmt.Fprintf(sb, "%s%s\n", indent, line)
+		}
+		fmt.Fprintf(sb, "%s\"\"\"\n", indent)
+		return
+	}
+	fmt.Fprintf(sb, "%s\"\"\"%s\"\"\"\n", indent, escaped)
+}
+
+func printDeprecated(isDeprecated bool, reason *string) string {
+	if !isDeprecated {
+		return ""
+	}
+	if reason == nil || len(*reason) == 0 {
+		return " @deprecated"
+	}
+	return fmt.Sprintf(" @deprecated(reason: %q)", *reason)
+}
@@ -0,0 +1,134 @@
+package openapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Document is the subset of Swagger 2.0 served by PostgREST needed to detect breaking changes.
+type Document struct {
+	Paths       map[string]map[string]Operation `json:"paths"`
+	Definitions map[string]Schema               `json:"definitions"`
+}
+
+type Operation struct {
+	Parameters []Parameter `json:"parameters"`
+}
+
+type Parameter struct {
+	Ref      string  `json:"$ref"`
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+type Schema struct {
+	Ref        string            `json:"$ref"`
+	Type       string            `json:"type"`
+	Format     string            `json:"format"`
+	Required   []string          `json:"required"`
+	Properties map[string]Schema `json:"properties"`
+	Items      *Schema           `json:"items"`
+}
+
+func (s Schema) String() string {
+	if len(s.Ref) > 0 {
+		return s.Ref
+	}
+	if s.Items != nil {
+		return s.Items.String() + "[]"
+	}
+	if len(s.Format) > 0 {
+		return fmt.Sprintf("%s (%s)", s.Type, s.Format)
+	}
+	return s.Type
+}
+
+// FindBreakingChanges lists changes that may break existing REST clients: removed
+// endpoints, tables, or columns, changed column types, and newly required inputs.
+func FindBreakingChanges(before, after Document) []string {
+	var result []string
+	for path, ops := range before.Paths {
+		newOps, ok := after.Paths[path]
+		if !ok {
+			result = append(result, "Removed path "+path)
+			continue
+		}
+		for method, op := range ops {
+			newOp, ok := newOps[method]
+			if !ok {
+				result = append(result, fmt.Sprintf("Removed operation %s %s", strings.ToUpper(method), path))
+				continue
+			}
+			name := fmt.Sprintf("%s %s", strings.ToUpper(method), path)
+			result = append(result, diffParameters(name, op.Parameters, newOp.Parameters)...)
+		}
+	}
+	for name, def := range before.Definitions {
+		newDef, ok := after.Definitions[name]
+		if !ok {
+			result = append(result, "Removed definition "+name)
+			continue
+		}
+		result = append(result, diffSchema(name, def, newDef)...)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func diffParameters(name string, before, after []Parameter) []string {
+	var result []string
+	params := map[string]Parameter{}
+	for _, p := range before {
+		params[p.key()] = p
+	}
+	for _, p := range after {
+		old, ok := params[p.key()]
+		if !ok {
+			if p.Required && p.In != "body" {
+				result = append(result, fmt.Sprintf("Added required parameter %s to %s", p.key(), name))
+			}
+			continue
+		}
+		delete(params, p.key())
+		// Function arguments are described by an inline body schema
+		if old.Schema != nil && p.Schema != nil {
+			result = append(result, diffSchema(name, *old.Schema, *p.Schema)...)
+		}
+	}
+	for key := range params {
+		result = append(result, fmt.Sprintf("Removed parameter %s from %s", key, name))
+	}
+	return result
+}
+
+func (p Parameter) key() string {
+	if len(p.Ref) > 0 {
+		return p.Ref
+	}
+	return p.In + ":" + p.Name
+}
+
+func diffSchema(name string, before, after Schema) []string {
+	var result []string
+	for prop, old := range before.Properties {
+		s, ok := after.Properties[prop]
+		if !ok {
+			result = append(result, fmt.Sprintf("Removed property %s.%s", name, prop))
+		} else if old.String() != s.String() {
+			result = append(result, fmt.Sprintf("Changed property %s.%s from %s to %s", name, prop, old, s))
+		}
+	}
+	required := map[string]bool{}
+	for _, r := range before.Required {
+		required[r] = true
+	}
+	for _, r := range after.Required {
+		if !required[r] {
+			result = append(result, fmt.Sprintf("Added required property %s.%s", name, r))
+		}
+	}
+	return result
+}
@@ -0,0 +1,111 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/tenant"
+	"github.com/supabase/cli/pkg/fetcher"
+)
+
+var ErrBreakingChange = errors.New("REST API has breaking changes")
+
+func Run(ctx context.Context, projectRef, outPath, diffPath string, fsys afero.Fs) error {
+	api, err := newRestAPI(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	spec, err := GetSpec(ctx, api)
+	if err != nil {
+		return err
+	}
+	// Read snapshot before writing in case both paths point to the same file
+	var snapshot []byte
+	if len(diffPath) > 0 {
+		if snapshot, err = afero.ReadFile(fsys, diffPath); err != nil {
+			return errors.Errorf("failed to read snapshot: %w", err)
+		}
+	}
+	if len(outPath) == 0 {
+		fmt.Println(string(spec))
+	} else if err := utils.WriteFile(outPath, append(spec, '\n'), fsys); err != nil {
+		return err
+	} else {
+		fmt.Fprintln(os.Stderr, "Saved OpenAPI spec to "+utils.Bold(outPath)+".")
+	}
+	if len(diffPath) == 0 {
+		return nil
+	}
+	return checkSnapshot(diffPath, snapshot, spec)
+}
+
+func newRestAPI(ctx context.Context, projectRef string) (*fetcher.Fetcher, error) {
+	server := fmt.Sprintf("http://%s:%d", utils.Config.Hostname, utils.Config.Api.Port)
+	token := utils.Config.Auth.AnonKey
+	if len(projectRef) > 0 {
+		server = "https://" + utils.GetSupabaseHost(projectRef)
+		keys, err := tenant.GetApiKeys(ctx, projectRef)
+		if err != nil {
+			return nil, err
+		}
+		token = keys.Anon
+	}
+	header := func(req *http.Request) {
+		req.Header.Add("apikey", token)
+		req.Header.Add("Accept", "application/openapi+json")
+	}
+	return fetcher.NewFetcher(
+		server,
+		fetcher.WithBearerToken(token),
+		fetcher.WithRequestEditor(header),
+		fetcher.WithUserAgent("SupabaseCLI/"+utils.Version),
+		fetcher.WithExpectedStatus(http.StatusOK),
+	), nil
+}
+
+// GetSpec returns the indented OpenAPI description of schemas exposed by PostgREST.
+func GetSpec(ctx context.Context, api *fetcher.Fetcher) ([]byte, error) {
+	resp, err := api.Send(ctx, http.MethodGet, "/rest/v1/", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Errorf("failed to read response body: %w", err)
+	}
+	// Indenting in place preserves the key order returned by PostgREST
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return nil, errors.Errorf("failed to parse OpenAPI spec: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func checkSnapshot(path string, snapshot, spec []byte) error {
+	var before, after Document
+	if err := json.Unmarshal(snapshot, &before); err != nil {
+		return errors.Errorf("failed to parse snapshot: %w", err)
+	}
+	if err := json.Unmarshal(spec, &after); err != nil {
+		return errors.Errorf("failed to parse OpenAPI spec: %w", err)
+	}
+	changes := FindBreakingChanges(before, after)
+	if len(changes) == 0 {
+		fmt.Fprintln(os.Stderr, "No breaking changes found compared to "+utils.Bold(path)+".")
+		return nil
+	}
+	fmt.Fprintln(os.Stderr, "Found breaking changes compared to "+utils.Bold(path)+":")
+	for _, c := range changes {
+		fmt.Fprintln(os.Stderr, "  "+c)
+	}
+	return errors.New(ErrBreakingChange)
+}
@@ -0,0 +1,210 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+const mockSpec = `{
+  "swagger": "2.0",
+  "paths": {
+    "/todos": {
+      "get": {
+        "parameters": [
+          {
+            "$ref": "#/parameters/rowFilter.todos.id"
+          }
+        ]
+      },
+      "post": {
+        "parameters": [
+          {
+            "$ref": "#/parameters/body.todos"
+          }
+        ]
+      }
+    },
+    "/rpc/search": {
+      "post": {
+        "parameters": [
+          {
+            "in": "body",
+            "name": "args",
+            "required": true,
+            "schema": {
+              "required": [
+                "query"
+              ],
+              "properties": {
+                "query": {
+                  "type": "string",
+                  "format": "text"
+                }
+              },
+              "type": "object"
+            }
+          }
+        ]
+      }
+    }
+  },
+  "definitions": {
+    "todos": {
+      "required": [
+        "id"
+      ],
+      "properties": {
+        "id": {
+          "type": "integer",
+          "format": "bigint"
+        },
+        "title": {
+          "type": "string",
+          "format": "text"
+        }
+      },
+      "type": "object"
+    }
+  }
+}`
+
+func TestOpenapiCommand(t *testing.T) {
+	utils.Config.Hostname = "127.0.0.1"
+	utils.Config.Api.Port = 54321
+	utils.Config.Auth.AnonKey = "anon-key"
+	localApi := "http://127.0.0.1:54321"
+
+	t.Run("writes spec to file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(localApi).
+			Get("/rest/v1/").
+			MatchHeader("apikey", "anon-key").
+			Reply(http.StatusOK).
+			BodyString(mockSpec)
+		// Run test
+		err := Run(context.Background(), "", "openapi.json", "", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		contents, err := afero.ReadFile(fsys, "openapi.json")
+		assert.NoError(t, err)
+		assert.Equal(t, mockSpec+"\n", string(contents))
+	})
+
+	t.Run("passes diff against same file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "openapi.json", []byte(mockSpec), 0644))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(localApi).
+			Get("/rest/v1/").
+			Reply(http.StatusOK).
+			BodyString(mockSpec)
+		// Run test
+		err := Run(context.Background(), "", "openapi.json", "openapi.json", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on breaking change", func(t *testing.T) {
+		projectRef := apitest.RandomProjectRef()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "openapi.json", []byte(mockSpec), 0644))
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{Name: "anon", ApiKey: "linked-key"}})
+		gock.New("https://"+utils.GetSupabaseHost(projectRef)).
+			Get("/rest/v1/").
+			MatchHeader("apikey", "linked-key").
+			Reply(http.StatusOK).
+			BodyString(`{"swagger": "2.0", "paths": {}}`)
+		// Run test
+		err := Run(context.Background(), projectRef, "", "openapi.json", fsys)
+		// Check error
+		assert.ErrorIs(t, err, ErrBreakingChange)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing snapshot", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(localApi).
+			Get("/rest/v1/").
+			Reply(http.StatusOK).
+			BodyString(mockSpec)
+		// Run test
+		err := Run(context.Background(), "", "", "openapi.json", afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "failed to read snapshot:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestFindBreakingChanges(t *testing.T) {
+	var before Document
+	require.NoError(t, json.Unmarshal([]byte(mockSpec), &before))
+
+	t.Run("ignores additive changes", func(t *testing.T) {
+		var after Document
+		require.NoError(t, json.Unmarshal([]byte(mockSpec), &after))
+		after.Paths["/posts"] = map[string]Operation{"get": {}}
+		after.Definitions["todos"].Properties["done"] = Schema{Type: "boolean"}
+		// Run test
+		assert.Empty(t, FindBreakingChanges(before, after))
+	})
+
+	t.Run("detects breaking changes", func(t *testing.T) {
+		after := Document{
+			Paths: map[string]map[string]Operation{
+				"/todos": {"get": {}},
+				"/rpc/search": {"post": {Parameters: []Parameter{{
+					In:   "body",
+					Name: "args",
+					Schema: &Schema{
+						Required: []string{"query", "limit"},
+						Properties: map[string]Schema{
+							"query": {Type: "string", Format: "text"},
+							"limit": {Type: "integer", Format: "integer"},
+						},
+					},
+				}}}},
+			},
+			Definitions: map[string]Schema{
+				"todos": {
+					Required:   []string{"id"},
+					Properties: map[string]Schema{"id": {Type: "string", Format: "uuid"}},
+				},
+			},
+		}
+		// Run test
+		assert.Equal(t, []string{
+			"Added required property POST /rpc/search.limit",
+			"Changed property todos.id from integer (bigint) to string (uuid)",
+			"Removed operation POST /todos",
+			"Removed parameter #/parameters/rowFilter.todos.id from GET /todos",
+			"Removed property todos.title",
+		}, FindBreakingChanges(before, after))
+	})
+}
@@ -0,0 +1,120 @@
+package typescript
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/internal/utils"
+	"golang.org/x/mod/semver"
+)
+
+// Options select the features of generated types, so that they match the type helpers of
+// the supabase-js version in use.
+type Options struct {
+	// Disables one-to-one relationship detection for PostgREST v9 and below
+	PostgrestV9Compat bool
+	// Marks the Database type for PostgREST v12 helpers, which resolve function overloads
+	PostgrestV12 bool
+	// Compares generated types against this file instead of printing them
+	Check string
+}
+
+const (
+	databaseType = "export type Database = {\n"
+
+	// First releases whose type helpers understand each feature of generated types
+	supabaseJsV2           = "v2.0.0"
+	supabaseJsPostgrestV12 = "v2.50.0"
+)
+
+var (
+	//go:embed templates/overloads.sql
+	overloadsQuery string
+
+	postgrestV12Marker = `  __InternalSupabase: {
+    PostgrestVersion: "12"
+  }
+`
+)
+
+// TargetSupabaseJs sets the options supported by the type helpers of a supabase-js version.
+func (o *Options) TargetSupabaseJs(version string) error {
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	if !semver.IsValid(version) {
+		return errors.Errorf("Invalid supabase-js version: %s. Must be a semantic version, ie. 2.50.0", strings.TrimPrefix(version, "v"))
+	}
+	// supabase-js v1 only talks to PostgREST v9, which has no one-to-one relationships
+	o.PostgrestV9Compat = semver.Compare(version, supabaseJsV2) < 0
+	o.PostgrestV12 = semver.Compare(version, supabaseJsPostgrestV12) >= 0
+	return nil
+}
+
+// Inserts the PostgREST version into the generated Database type.
+func addPostgrestVersion(types string) string {
+	i := strings.Index(types, databaseType)
+	if i < 0 {
+		fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "Database type not found in generated types. Skipped adding PostgREST version.")
+		return types
+	}
+	i += len(databaseType)
+	return types[:i] + postgrestV12Marker + types[i:]
+}
+
+type Overload struct {
+	Schema string
+	Name   string
+	// Sorted input argument names of each signature, joined by comma
+	Args []string
+}
+
+// LoadOverloads returns the functions in schemas that have more than one signature.
+func LoadOverloads(ctx context.Context, conn *pgx.Conn, schemas []string) ([]Overload, error) {
+	rows, err := conn.Query(ctx, overloadsQuery, schemas)
+	if err != nil {
+		return nil, errors.Errorf("failed to query overloaded functions: %w", err)
+	}
+	defer rows.Close()
+	var result []Overload
+	for rows.Next() {
+		var o Overload
+		if err := rows.Scan(&o.Schema, &o.Name, &o.Args); err != nil {
+			return nil, errors.Errorf("failed to scan overloaded functions: %w", err)
+		}
+		result = append(result, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Errorf("failed to parse overloaded functions: %w", err)
+	}
+	return result, nil
+}
+
+// Overloaded functions are generated as a union of their signatures. Only PostgREST v12
+// type helpers narrow the union by the arguments passed to rpc, while older helpers return
+// a union of all return types. Signatures with the same argument names cannot be called
+// at all, because PostgREST resolves overloads by argument names.
+func checkOverloads(overloads []Overload, opts Options) {
+	var loose []string
+	for _, o := range overloads {
+		name := o.Schema + "." + o.Name
+		seen := map[string]bool{}
+		for _, args := range o.Args {
+			if seen[args] {
+				fmt.Fprintf(os.Stderr, "%s Function %s has overloads with the same argument names (%s), so PostgREST cannot choose between them when called with rpc.\n", utils.Yellow("WARNING:"), utils.Aqua(name), args)
+				break
+			}
+			seen[args] = true
+		}
+		loose = append(loose, name)
+	}
+	if len(loose) > 0 && !opts.PostgrestV12 {
+		fmt.Fprintf(os.Stderr, "%s Overloaded functions return a union of all their return types: %s\n", utils.Yellow("WARNING:"), strings.Join(loose, ", "))
+		fmt.Fprintf(os.Stderr, "Pass %s or %s to resolve overloads by their arguments.\n", utils.Aqua("--postgrest-v12"), utils.Aqua("--supabase-js "+strings.TrimPrefix(supabaseJsPostgrestV12, "v")))
+	}
+}
@@ -0,0 +1,136 @@
+package typescript
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/pgtest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const generatedTypes = `export type Database = {
+  public: {
+    Tables: {}
+  }
+}
+`
+
+func TestAddPostgrestVersion(t *testing.T) {
+	t.Run("marks database type", func(t *testing.T) {
+		// Run test
+		types := addPostgrestVersion(generatedTypes)
+		// Check output
+		assert.Equal(t, `export type Database = {
+  __InternalSupabase: {
+    PostgrestVersion: "12"
+  }
+  public: {
+    Tables: {}
+  }
+}
+`, types)
+	})
+
+	t.Run("ignores missing database type", func(t *testing.T) {
+		assert.Equal(t, "export type Json = string\n", addPostgrestVersion("export type Json = string\n"))
+	})
+}
+
+func TestWriteTypes(t *testing.T) {
+	t.Run("marks postgrest version", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := writeTypes(generatedTypes, Options{PostgrestV12: true}, &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), `PostgrestVersion: "12"`)
+	})
+
+	t.Run("writes types unchanged", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := writeTypes(generatedTypes, Options{}, &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, generatedTypes, out.String())
+	})
+}
+
+func TestTargetSupabaseJs(t *testing.T) {
+	t.Run("targets postgrest v12 helpers", func(t *testing.T) {
+		var opts Options
+		// Run test
+		err := opts.TargetSupabaseJs("2.50.0")
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, Options{PostgrestV12: true}, opts)
+	})
+
+	t.Run("targets older v2 helpers", func(t *testing.T) {
+		opts := Options{PostgrestV12: true}
+		// Run test
+		err := opts.TargetSupabaseJs("v2.39.3")
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, Options{}, opts)
+	})
+
+	t.Run("targets postgrest v9 for v1", func(t *testing.T) {
+		var opts Options
+		// Run test
+		err := opts.TargetSupabaseJs("1.35.7")
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, Options{PostgrestV9Compat: true}, opts)
+	})
+
+	t.Run("throws error on invalid version", func(t *testing.T) {
+		var opts Options
+		// Run test
+		err := opts.TargetSupabaseJs("latest")
+		// Check error
+		assert.ErrorContains(t, err, "Invalid supabase-js version: latest.")
+	})
+}
+
+func TestLoadOverloads(t *testing.T) {
+	t.Run("loads overloaded functions", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(overloadsQuery, []string{"public"}).
+			Reply("SELECT 1", []interface{}{"public", "search", []string{"query", "limit,query"}})
+		mock, err := utils.ConnectLocalPostgres(context.Background(), pgconn.Config{Port: 5432}, conn.Intercept)
+		require.NoError(t, err)
+		defer mock.Close(context.Background())
+		// Run test
+		overloads, err := LoadOverloads(context.Background(), mock, []string{"public"})
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []Overload{{
+			Schema: "public",
+			Name:   "search",
+			Args:   []string{"query", "limit,query"},
+		}}, overloads)
+	})
+
+	t.Run("throws error on query failure", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(overloadsQuery, []string{"public"}).
+			ReplyError(pgerrcode.InsufficientPrivilege, "permission denied for table pg_proc")
+		mock, err := utils.ConnectLocalPostgres(context.Background(), pgconn.Config{Port: 5432}, conn.Intercept)
+		require.NoError(t, err)
+		defer mock.Close(context.Background())
+		// Run test
+		_, err = LoadOverloads(context.Background(), mock, []string{"public"})
+		// Check error
+		assert.ErrorContains(t, err, "permission denied for table pg_proc")
+	})
+}
@@ -0,0 +1,17 @@
+-- Lists overloaded functions in the included schemas, with the input argument names of each
+-- signature, since PostgREST resolves overloads by the names of arguments passed to rpc
+SELECT n.nspname, p.proname, array_agg(args.names ORDER BY p.oid)
+FROM pg_proc p
+JOIN pg_namespace n ON n.oid = p.pronamespace
+CROSS JOIN LATERAL (
+  SELECT coalesce(string_agg(a.name, ',' ORDER BY a.name), '') AS names
+  FROM unnest(
+    p.proargnames,
+    coalesce(p.proargmodes, array_fill('i'::"char", ARRAY[cardinality(coalesce(p.proargnames, '{}'))]))
+  ) AS a(name, mode)
+  WHERE a.mode IN ('i', 'b', 'v')
+) args
+WHERE p.prokind = 'f' AND n.nspname = ANY($1)
+GROUP BY n.nspname, p.proname
+HAVING count(*) > 1
+ORDER BY 1, 2
@@ -1,8 +1,10 @@
 package typescript
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -12,19 +14,18 @@ import (
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/reset"
+	"github.com/supabase/cli/internal/gen/check"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/pkg/api"
 )
 
-func Run(ctx context.Context, projectId string, dbConfig pgconn.Config, schemas []string, postgrestV9Compat bool, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+func Run(ctx context.Context, projectId string, dbConfig pgconn.Config, schemas []string, opts Options, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
 	originalURL := utils.ToPostgresURL(dbConfig)
-	// Add default schemas if --schema flag is not specified
-	if len(schemas) == 0 {
-		schemas = utils.RemoveDuplicates(append([]string{"public"}, utils.Config.Api.Schemas...))
-	}
-	included := strings.Join(schemas, ",")
 
 	if projectId != "" {
+		schemas = getDefaultSchemas(schemas)
+		included := strings.Join(schemas, ",")
 		resp, err := utils.GetSupabase().V1GenerateTypescriptTypesWithResponse(ctx, projectId, &api.V1GenerateTypescriptTypesParams{
 			IncludedSchemas: &included,
 		})
@@ -35,19 +36,18 @@ func Run(ctx context.Context, projectId string, dbConfig pgconn.Config, schemas
 		if resp.JSON200 == nil {
 			return errors.New("failed to retrieve generated types: " + string(resp.Body))
 		}
-
-		fmt.Print(resp.JSON200.Types)
-		return nil
+		return output(resp.JSON200.Types, opts, fsys)
 	}
 
 	hostConfig := container.HostConfig{}
-	if utils.IsLocalDatabase(dbConfig) {
+	isLocal := utils.IsLocalDatabase(dbConfig)
+	if isLocal {
 		if err := utils.AssertSupabaseDbIsRunning(); err != nil {
 			return err
 		}
 
 		if strings.Contains(utils.Config.Api.Image, "v9") {
-			postgrestV9Compat = true
+			opts.PostgrestV9Compat = true
 		}
 
 		// Use custom network when connecting to local database
@@ -63,19 +63,78 @@ func Run(ctx context.Context, projectId string, dbConfig pgconn.Config, schemas
 
 	fmt.Fprintln(os.Stderr, "Connecting to", dbConfig.Host, dbConfig.Port)
 	escaped := utils.ToPostgresURL(dbConfig)
-	if require, err := isRequireSSL(ctx, originalURL, options...); err != nil {
+	conn, requireSSL, err := connectRequireSSL(ctx, originalURL, options...)
+	if err != nil {
 		return err
-	} else if require {
+	}
+	defer conn.Close(context.Background())
+	if requireSSL {
 		// node-postgres does not support sslmode=prefer
 		escaped += "&sslmode=require"
 	}
+	if len(schemas) == 0 && !isLocal {
+		if schemas, err = loadPlainSchemas(ctx, conn); err != nil {
+			return err
+		}
+	}
+	schemas = getDefaultSchemas(schemas)
+
+	var out bytes.Buffer
+	if err := generateTypes(ctx, escaped, strings.Join(schemas, ","), opts.PostgrestV9Compat, hostConfig, &out); err != nil {
+		return err
+	}
+	// Overloads are only reported as warnings, so they never block type generation
+	if overloads, err := LoadOverloads(ctx, conn, schemas); err != nil {
+		fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "skipped checking overloaded functions:", err)
+	} else {
+		checkOverloads(overloads, opts)
+	}
+	return output(out.String(), opts, fsys)
+}
+
+// Adds default schemas if --schema flag is not specified
+func getDefaultSchemas(schemas []string) []string {
+	if len(schemas) > 0 {
+		return schemas
+	}
+	return utils.RemoveDuplicates(append([]string{"public"}, utils.Config.Api.Schemas...))
+}
+
+func output(types string, opts Options, fsys afero.Fs) error {
+	if len(opts.Check) == 0 {
+		return writeTypes(types, opts, os.Stdout)
+	}
+	var out bytes.Buffer
+	if err := writeTypes(types, opts, &out); err != nil {
+		return err
+	}
+	return check.Run(opts.Check, out.Bytes(), fsys)
+}
+
+func writeTypes(types string, opts Options, w io.Writer) error {
+	if opts.PostgrestV12 {
+		types = addPostgrestVersion(types)
+	}
+	if _, err := io.WriteString(w, types); err != nil {
+		return errors.Errorf("failed to write types: %w", err)
+	}
+	return nil
+}
+
+// Generates types from a database reachable on host network, such as the shadow database.
+func GenerateFromHost(ctx context.Context, dbConfig pgconn.Config, schemas []string, w io.Writer) error {
+	included := strings.Join(schemas, ",")
+	hostConfig := container.HostConfig{NetworkMode: network.NetworkHost}
+	return generateTypes(ctx, utils.ToPostgresURL(dbConfig), included, false, hostConfig, w)
+}
 
+func generateTypes(ctx context.Context, dbUrl, included string, postgrestV9Compat bool, hostConfig container.HostConfig, w io.Writer) error {
 	return utils.DockerRunOnceWithConfig(
 		ctx,
 		container.Config{
 			Image: utils.Config.Studio.PgmetaImage,
 			Env: []string{
-				"PG_META_DB_URL=" + escaped,
+				"PG_META_DB_URL=" + dbUrl,
 				"PG_META_GENERATE_TYPES=typescript",
 				"PG_META_GENERATE_TYPES_INCLUDED_SCHEMAS=" + included,
 				fmt.Sprintf("PG_META_GENERATE_TYPES_DETECT_ONE_TO_ONE_RELATIONSHIPS=%v", !postgrestV9Compat),
@@ -85,18 +144,42 @@ func Run(ctx context.Context, projectId string, dbConfig pgconn.Config, schemas
 		hostConfig,
 		network.NetworkingConfig{},
 		"",
-		os.Stdout,
+		w,
 		os.Stderr,
 	)
 }
 
-func isRequireSSL(ctx context.Context, dbUrl string, options ...func(*pgx.ConnConfig)) (bool, error) {
-	conn, err := utils.ConnectByUrl(ctx, dbUrl+"&sslmode=require", options...)
+const SELECT_SUPABASE_ROLE = "SELECT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'supabase_admin')"
+
+// Lists user schemas of a plain Postgres database, which has no exposed schemas configured.
+// Returns nil for Supabase databases, so that the default schemas are used instead.
+func loadPlainSchemas(ctx context.Context, conn *pgx.Conn) ([]string, error) {
+	var isSupabase bool
+	if err := conn.QueryRow(ctx, SELECT_SUPABASE_ROLE).Scan(&isSupabase); err != nil {
+		return nil, errors.Errorf("failed to check supabase role: %w", err)
+	} else if isSupabase {
+		return nil, nil
+	}
+	// Schemas created by extensions are excluded by the query
+	schemas, err := reset.LoadUserSchemas(ctx, conn, utils.PgSchemas...)
 	if err != nil {
-		if strings.HasSuffix(err.Error(), "(server refused TLS connection)") {
-			return false, nil
-		}
-		return false, err
+		return nil, err
+	}
+	if len(schemas) == 0 {
+		return nil, errors.New("No user schemas found in database. Pass --schema to select schemas explicitly.")
+	}
+	fmt.Fprintln(os.Stderr, "Generating types for schemas:", strings.Join(schemas, ","))
+	return schemas, nil
+}
+
+// Connects with TLS if the server supports it, falling back to an unencrypted connection.
+func connectRequireSSL(ctx context.Context, dbUrl string, options ...func(*pgx.ConnConfig)) (*pgx.Conn, bool, error) {
+	conn, err := utils.ConnectByUrl(ctx, dbUrl+"&sslmode=require", options...)
+	if err == nil {
+		return conn, true, nil
+	} else if !strings.HasSuffix(err.Error(), "(server refused TLS connection)") {
+		return nil, false, err
 	}
-	return true, conn.Close(ctx)
+	conn, err = utils.ConnectByUrl(ctx, dbUrl, options...)
+	return conn, false, err
 }
@@ -9,9 +9,12 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/h2non/gock"
 	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/db/reset"
+	"github.com/supabase/cli/internal/gen/check"
 	"github.com/supabase/cli/internal/testing/apitest"
 	"github.com/supabase/cli/internal/testing/pgtest"
 	"github.com/supabase/cli/internal/utils"
@@ -47,8 +50,10 @@ func TestGenLocalCommand(t *testing.T) {
 		// Setup mock postgres
 		conn := pgtest.NewConn()
 		defer conn.Close(t)
+		conn.Query(overloadsQuery, []string{"public"}).
+			Reply("SELECT 0")
 		// Run test
-		assert.NoError(t, Run(context.Background(), "", dbConfig, []string{}, true, fsys, conn.Intercept))
+		assert.NoError(t, Run(context.Background(), "", dbConfig, []string{}, Options{PostgrestV9Compat: true}, fsys, conn.Intercept))
 		// Validate api
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
@@ -63,7 +68,7 @@ func TestGenLocalCommand(t *testing.T) {
 			Get("/v" + utils.Docker.ClientVersion() + "/containers/" + utils.DbId).
 			Reply(http.StatusServiceUnavailable)
 		// Run test
-		assert.Error(t, Run(context.Background(), "", dbConfig, []string{}, true, fsys))
+		assert.Error(t, Run(context.Background(), "", dbConfig, []string{}, Options{PostgrestV9Compat: true}, fsys))
 		// Validate api
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
@@ -83,7 +88,7 @@ func TestGenLocalCommand(t *testing.T) {
 			Get("/v" + utils.Docker.ClientVersion() + "/images").
 			Reply(http.StatusServiceUnavailable)
 		// Run test
-		assert.Error(t, Run(context.Background(), "", dbConfig, []string{}, true, fsys))
+		assert.Error(t, Run(context.Background(), "", dbConfig, []string{}, Options{PostgrestV9Compat: true}, fsys))
 		// Validate api
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
@@ -106,11 +111,28 @@ func TestGenLinkedCommand(t *testing.T) {
 			Reply(200).
 			JSON(api.TypescriptResponse{Types: ""})
 		// Run test
-		assert.NoError(t, Run(context.Background(), projectId, pgconn.Config{}, []string{}, true, fsys))
+		assert.NoError(t, Run(context.Background(), projectId, pgconn.Config{}, []string{}, Options{PostgrestV9Compat: true}, fsys))
 		// Validate api
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
 
+	t.Run("checks types against file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "database.types.ts", []byte("export type Json = string\n"), 0644))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectId + "/types/typescript").
+			Reply(200).
+			JSON(api.TypescriptResponse{Types: "export type Json = number\n"})
+		// Run test
+		err := Run(context.Background(), projectId, pgconn.Config{}, []string{}, Options{Check: "database.types.ts"}, fsys)
+		// Check error
+		assert.ErrorIs(t, err, check.ErrStale)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
 	t.Run("throws error on network failure", func(t *testing.T) {
 		errNetwork := errors.New("network error")
 		// Setup in-memory fs
@@ -121,7 +143,7 @@ func TestGenLinkedCommand(t *testing.T) {
 			Get("/v1/projects/" + projectId + "/types/typescript").
 			ReplyError(errNetwork)
 		// Run test
-		err := Run(context.Background(), projectId, pgconn.Config{}, []string{}, true, fsys)
+		err := Run(context.Background(), projectId, pgconn.Config{}, []string{}, Options{PostgrestV9Compat: true}, fsys)
 		// Validate api
 		assert.ErrorIs(t, err, errNetwork)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -136,7 +158,7 @@ func TestGenLinkedCommand(t *testing.T) {
 			Get("/v1/projects/" + projectId + "/types/typescript").
 			Reply(http.StatusServiceUnavailable)
 		// Run test
-		assert.Error(t, Run(context.Background(), projectId, pgconn.Config{}, []string{}, true, fsys))
+		assert.Error(t, Run(context.Background(), projectId, pgconn.Config{}, []string{}, Options{PostgrestV9Compat: true}, fsys))
 	})
 }
 
@@ -160,8 +182,68 @@ func TestGenRemoteCommand(t *testing.T) {
 		// Setup mock postgres
 		conn := pgtest.NewConn()
 		defer conn.Close(t)
+		conn.Query(overloadsQuery, []string{"public"}).
+			Reply("SELECT 1", []interface{}{"public", "search", []string{"query", "query"}})
+		// Run test
+		assert.NoError(t, Run(context.Background(), "", dbConfig, []string{"public"}, Options{PostgrestV9Compat: true}, afero.NewMemMapFs(), conn.Intercept))
+		// Validate api
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("detects schemas of plain postgres", func(t *testing.T) {
+		const containerId = "test-pgmeta"
+		imageUrl := utils.GetRegistryImageUrl(utils.PgmetaImage)
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
+		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "hello world\n"))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(SELECT_SUPABASE_ROLE).
+			Reply("SELECT 1", []interface{}{false}).
+			Query(reset.ListSchemas, reset.LikeEscapeSchema(utils.PgSchemas)).
+			Reply("SELECT 2", []interface{}{"inventory"}, []interface{}{"public"}).
+			Query(overloadsQuery, []string{"inventory", "public"}).
+			Reply("SELECT 0")
+		// Run test
+		assert.NoError(t, Run(context.Background(), "", dbConfig, []string{}, Options{}, afero.NewMemMapFs(), conn.Intercept))
+		// Validate api
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on plain postgres without schemas", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(SELECT_SUPABASE_ROLE).
+			Reply("SELECT 1", []interface{}{false}).
+			Query(reset.ListSchemas, reset.LikeEscapeSchema(utils.PgSchemas)).
+			Reply("SELECT 0")
+		// Run test
+		err := Run(context.Background(), "", dbConfig, []string{}, Options{}, afero.NewMemMapFs(), conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "No user schemas found in database.")
+	})
+
+	t.Run("uses default schemas of supabase database", func(t *testing.T) {
+		const containerId = "test-pgmeta"
+		imageUrl := utils.GetRegistryImageUrl(utils.PgmetaImage)
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
+		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "hello world\n"))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(SELECT_SUPABASE_ROLE).
+			Reply("SELECT 1", []interface{}{true}).
+			Query(overloadsQuery, getDefaultSchemas(nil)).
+			ReplyError(pgerrcode.InsufficientPrivilege, "permission denied for table pg_proc")
 		// Run test
-		assert.NoError(t, Run(context.Background(), "", dbConfig, []string{"public"}, true, afero.NewMemMapFs(), conn.Intercept))
+		assert.NoError(t, Run(context.Background(), "", dbConfig, []string{}, Options{}, afero.NewMemMapFs(), conn.Intercept))
 		// Validate api
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
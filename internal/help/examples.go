@@ -0,0 +1,110 @@
+package help
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/supabase/cli/internal/utils"
+)
+
+type Recipe struct {
+	Name        string
+	Title       string
+	Description string
+	Steps       []Step
+}
+
+type Step struct {
+	// Printed as a comment above the command, defaults to its short description
+	Comment string
+	// Path of the command without the root, ie. functions deploy
+	Command string
+	// Flags and positional args passed to the command
+	Args []string
+}
+
+var ErrRecipeNotFound = errors.New("Recipe not found.")
+
+// RunExamples lists all recipes, or prints the named recipe as a shell script.
+func RunExamples(root *cobra.Command, name string, w io.Writer) error {
+	if len(name) == 0 {
+		for _, r := range Recipes {
+			fmt.Fprintf(w, "%-24s %s\n", r.Name, r.Title)
+		}
+		return nil
+	}
+	for _, r := range Recipes {
+		if r.Name == name {
+			return RenderRecipe(root, r, w)
+		}
+	}
+	utils.CmdSuggestion = fmt.Sprintf("Run %s to show available recipes.", utils.Aqua("supabase examples"))
+	return errors.New(ErrRecipeNotFound)
+}
+
+// RenderRecipe resolves each step against the command tree, so that recipes fail instead of
+// printing commands or flags that no longer exist.
+func RenderRecipe(root *cobra.Command, r Recipe, w io.Writer) error {
+	lines := []string{utils.Bold("# " + r.Title)}
+	for _, desc := range strings.Split(r.Description, "\n") {
+		lines = append(lines, strings.TrimRight("# "+desc, " "))
+	}
+	for _, step := range r.Steps {
+		cmd, rest, err := root.Find(strings.Fields(step.Command))
+		if err != nil || len(rest) > 0 || cmd == root {
+			return errors.Errorf("Recipe %s uses unknown command: %s", r.Name, step.Command)
+		}
+		if err := validateArgs(cmd, step.Args); err != nil {
+			return errors.Errorf("Recipe %s uses invalid args for %s: %w", r.Name, cmd.CommandPath(), err)
+		}
+		comment := step.Comment
+		if len(comment) == 0 {
+			comment = cmd.Short
+		}
+		line := strings.Join(append([]string{cmd.CommandPath()}, step.Args...), " ")
+		lines = append(lines, "", utils.Aqua("# "+comment), line)
+	}
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
+
+func validateArgs(cmd *cobra.Command, args []string) error {
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			positional = append(positional, arg)
+			continue
+		}
+		name, _, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		flag := lookupFlag(cmd, name, !strings.HasPrefix(arg, "--"))
+		if flag == nil {
+			return errors.Errorf("unknown flag: %s", arg)
+		}
+		if len(flag.Deprecated) > 0 {
+			return errors.Errorf("deprecated flag: %s", arg)
+		}
+		// Flags without an optional value consume the next arg
+		if !hasValue && len(flag.NoOptDefVal) == 0 {
+			i++
+		}
+	}
+	return cmd.ValidateArgs(positional)
+}
+
+func lookupFlag(cmd *cobra.Command, name string, short bool) *pflag.Flag {
+	for _, fs := range []*pflag.FlagSet{cmd.Flags(), cmd.InheritedFlags()} {
+		if short && len(name) == 1 {
+			if f := fs.ShorthandLookup(name); f != nil {
+				return f
+			}
+		} else if f := fs.Lookup(name); f != nil {
+			return f
+		}
+	}
+	return nil
+}
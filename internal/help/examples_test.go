@@ -0,0 +1,117 @@
+package help
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRoot() *cobra.Command {
+	root := &cobra.Command{Use: "supabase"}
+	root.PersistentFlags().Bool("debug", false, "output debug logs")
+	deploy := &cobra.Command{
+		Use:   "deploy [Function name]",
+		Short: "Deploy a Function",
+		Run:   func(cmd *cobra.Command, args []string) {},
+	}
+	deploy.Flags().String("project-ref", "", "Project ref")
+	deploy.Flags().StringP("import-map", "i", "", "Path to import map")
+	deploy.Flags().Bool("no-verify-jwt", false, "Disable JWT verification")
+	deploy.Flags().Bool("legacy-bundle", false, "Use legacy bundling")
+	cobra.CheckErr(deploy.Flags().MarkDeprecated("legacy-bundle", "use the default bundler"))
+	functions := &cobra.Command{Use: "functions", Aliases: []string{"fn"}}
+	functions.AddCommand(deploy)
+	root.AddCommand(functions)
+	return root
+}
+
+func TestRenderRecipe(t *testing.T) {
+	root := newTestRoot()
+
+	t.Run("renders canonical command path", func(t *testing.T) {
+		recipe := Recipe{
+			Name:        "deploy",
+			Title:       "Deploy functions",
+			Description: "Line one\nLine two",
+			Steps: []Step{{
+				Command: "fn deploy",
+				Args:    []string{"hello", "--project-ref", "abc", "-i", "import_map.json", "--no-verify-jwt", "--debug"},
+			}},
+		}
+		var out bytes.Buffer
+		// Run test
+		err := RenderRecipe(root, recipe, &out)
+		// Check output
+		assert.NoError(t, err)
+		assert.Equal(t, `# Deploy functions
+# Line one
+# Line two
+
+# Deploy a Function
+supabase functions deploy hello --project-ref abc -i import_map.json --no-verify-jwt --debug
+`, out.String())
+	})
+
+	t.Run("throws error on unknown command", func(t *testing.T) {
+		recipe := Recipe{Name: "deploy", Steps: []Step{{Command: "functions publish"}}}
+		// Run test
+		err := RenderRecipe(root, recipe, &bytes.Buffer{})
+		// Check error
+		assert.ErrorContains(t, err, "Recipe deploy uses unknown command: functions publish")
+	})
+
+	t.Run("throws error on unknown flag", func(t *testing.T) {
+		recipe := Recipe{Name: "deploy", Steps: []Step{{Command: "functions deploy", Args: []string{"--project-id=abc"}}}}
+		// Run test
+		err := RenderRecipe(root, recipe, &bytes.Buffer{})
+		// Check error
+		assert.ErrorContains(t, err, "unknown flag: --project-id=abc")
+	})
+
+	t.Run("throws error on deprecated flag", func(t *testing.T) {
+		recipe := Recipe{Name: "deploy", Steps: []Step{{Command: "functions deploy", Args: []string{"--legacy-bundle"}}}}
+		// Run test
+		err := RenderRecipe(root, recipe, &bytes.Buffer{})
+		// Check error
+		assert.ErrorContains(t, err, "deprecated flag: --legacy-bundle")
+	})
+}
+
+func TestRunExamples(t *testing.T) {
+	t.Run("lists recipes", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := RunExamples(newTestRoot(), "", &out)
+		// Check output
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "functions-deploy-ci")
+	})
+
+	t.Run("throws error on unknown recipe", func(t *testing.T) {
+		// Run test
+		err := RunExamples(newTestRoot(), "missing", &bytes.Buffer{})
+		// Check error
+		assert.ErrorIs(t, err, ErrRecipeNotFound)
+	})
+}
+
+func TestNewCommandDoc(t *testing.T) {
+	// Run test
+	doc := NewCommandDoc(newTestRoot())
+	// Check output
+	assert.Equal(t, "supabase", doc.Path)
+	assert.Len(t, doc.Subcommands, 1)
+	deploy := doc.Subcommands[0].Subcommands[0]
+	assert.Equal(t, "supabase functions deploy [Function name] [flags]", deploy.Usage)
+	assert.True(t, deploy.Runnable)
+	assert.Equal(t, []FlagDoc{
+		{Name: "import-map", Shorthand: "i", Type: "string", Usage: "Path to import map"},
+		{Name: "no-verify-jwt", Type: "bool", Default: "false", Usage: "Disable JWT verification"},
+		{Name: "project-ref", Type: "string", Usage: "Project ref"},
+	}, deploy.Flags)
+	assert.Equal(t, []FlagDoc{
+		{Name: "debug", Type: "bool", Default: "false", Usage: "output debug logs"},
+	}, deploy.InheritedFlags)
+}
@@ -0,0 +1,95 @@
+package help
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// CommandDoc is the machine readable metadata of a command, printed by --help-json for
+// documentation tooling.
+type CommandDoc struct {
+	Name           string       `json:"name"`
+	Path           string       `json:"path"`
+	Aliases        []string     `json:"aliases,omitempty"`
+	Short          string       `json:"short,omitempty"`
+	Long           string       `json:"long,omitempty"`
+	Usage          string       `json:"usage"`
+	Example        string       `json:"example,omitempty"`
+	Group          string       `json:"group,omitempty"`
+	Runnable       bool         `json:"runnable"`
+	Flags          []FlagDoc    `json:"flags,omitempty"`
+	InheritedFlags []FlagDoc    `json:"inherited_flags,omitempty"`
+	Subcommands    []CommandDoc `json:"subcommands,omitempty"`
+}
+
+type FlagDoc struct {
+	Name      string   `json:"name"`
+	Shorthand string   `json:"shorthand,omitempty"`
+	Type      string   `json:"type"`
+	Default   string   `json:"default,omitempty"`
+	Usage     string   `json:"usage,omitempty"`
+	Required  bool     `json:"required,omitempty"`
+	Enum      []string `json:"enum,omitempty"`
+}
+
+func WriteJson(cmd *cobra.Command, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(NewCommandDoc(cmd)); err != nil {
+		return errors.Errorf("failed to encode command metadata: %w", err)
+	}
+	return nil
+}
+
+// NewCommandDoc describes cmd and its available subcommands, skipping hidden ones.
+func NewCommandDoc(cmd *cobra.Command) CommandDoc {
+	doc := CommandDoc{
+		Name:           cmd.Name(),
+		Path:           cmd.CommandPath(),
+		Aliases:        cmd.Aliases,
+		Short:          cmd.Short,
+		Long:           cmd.Long,
+		Usage:          cmd.UseLine(),
+		Example:        cmd.Example,
+		Group:          cmd.GroupID,
+		Runnable:       cmd.Runnable(),
+		Flags:          newFlagDocs(cmd.LocalFlags()),
+		InheritedFlags: newFlagDocs(cmd.InheritedFlags()),
+	}
+	for _, c := range cmd.Commands() {
+		if c.IsAvailableCommand() && !c.IsAdditionalHelpTopicCommand() {
+			doc.Subcommands = append(doc.Subcommands, NewCommandDoc(c))
+		}
+	}
+	return doc
+}
+
+func newFlagDocs(fs *pflag.FlagSet) []FlagDoc {
+	var result []FlagDoc
+	fs.VisitAll(func(f *pflag.Flag) {
+		if f.Hidden || len(f.Deprecated) > 0 {
+			return
+		}
+		doc := FlagDoc{
+			Name:      f.Name,
+			Shorthand: f.Shorthand,
+			Type:      f.Value.Type(),
+			Default:   f.DefValue,
+			Usage:     f.Usage,
+		}
+		if required, ok := f.Annotations[cobra.BashCompOneRequiredFlag]; ok {
+			doc.Required = len(required) > 0 && required[0] == "true"
+		}
+		if enum, ok := f.Value.(*utils.EnumFlag); ok {
+			doc.Enum = enum.Allowed
+		}
+		result = append(result, doc)
+	})
+	return result
+}
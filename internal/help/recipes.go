@@ -0,0 +1,66 @@
+package help
+
+// Recipes are validated against the command tree by tests, so renamed commands and flags
+// must be updated here.
+var Recipes = []Recipe{{
+	Name:  "functions-deploy-ci",
+	Title: "Deploy Edge Functions from CI",
+	Description: `Set SUPABASE_ACCESS_TOKEN to a personal access token and SUPABASE_PROJECT_ID
+to the project ref in your CI secrets.`,
+	Steps: []Step{{
+		Comment: "Deploy all functions under supabase/functions",
+		Command: "functions deploy",
+		Args:    []string{"--project-ref", `"$SUPABASE_PROJECT_ID"`},
+	}},
+}, {
+	Name:  "db-push-ci",
+	Title: "Apply migrations to a remote database from CI",
+	Description: `Set SUPABASE_ACCESS_TOKEN, SUPABASE_DB_PASSWORD, and SUPABASE_PROJECT_ID
+in your CI secrets.`,
+	Steps: []Step{{
+		Command: "link",
+		Args:    []string{"--project-ref", `"$SUPABASE_PROJECT_ID"`},
+	}, {
+		Comment: "Show pending migrations without applying them",
+		Command: "db push",
+		Args:    []string{"--dry-run"},
+	}, {
+		Command: "db push",
+	}},
+}, {
+	Name:  "gen-types-ci",
+	Title: "Fail CI when committed types are out of date",
+	Description: `Regenerates types into memory and prints a diff if they differ from the
+committed file. Set SUPABASE_ACCESS_TOKEN and SUPABASE_PROJECT_ID in your CI secrets.`,
+	Steps: []Step{{
+		Command: "gen types typescript",
+		Args:    []string{"--project-id", `"$SUPABASE_PROJECT_ID"`, "--check", "src/database.types.ts"},
+	}},
+}, {
+	Name:        "migration-from-diff",
+	Title:       "Create a migration from schema changes made in Studio",
+	Description: "Run against the local stack after editing tables in the local Studio.",
+	Steps: []Step{{
+		Comment: "Save changes to the local database as a new migration",
+		Command: "db diff",
+		Args:    []string{"-f", "add_todos"},
+	}, {
+		Comment: "Verify that the migration applies cleanly from scratch",
+		Command: "db reset",
+	}},
+}, {
+	Name:        "local-snapshots",
+	Title:       "Save and restore local data around risky changes",
+	Description: "Snapshots are kept until deleted, including across supabase stop --no-backup.",
+	Steps: []Step{{
+		Command: "snapshots create",
+		Args:    []string{"before-refactor"},
+	}, {
+		Comment: "Experiment freely, then return to the saved state",
+		Command: "snapshots restore",
+		Args:    []string{"before-refactor"},
+	}, {
+		Command: "snapshots delete",
+		Args:    []string{"before-refactor"},
+	}},
+}}
@@ -0,0 +1,25 @@
+package help_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/cmd"
+	"github.com/supabase/cli/internal/help"
+)
+
+func TestRecipes(t *testing.T) {
+	root := cmd.GetRootCmd()
+	names := map[string]bool{}
+	for _, r := range help.Recipes {
+		t.Run(r.Name, func(t *testing.T) {
+			assert.False(t, names[r.Name], "duplicate recipe name")
+			names[r.Name] = true
+			// Run test
+			err := help.RenderRecipe(root, r, io.Discard)
+			// Check error
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/utils"
+)
+
+type Payload struct {
+	// Slack incoming webhooks only render the text field.
+	Text       string    `json:"text"`
+	Event      string    `json:"event"`
+	ProjectRef string    `json:"project_ref"`
+	Slugs      []string  `json:"slugs,omitempty"`
+	Migrations []string  `json:"migrations,omitempty"`
+	Duration   float64   `json:"duration"`
+	Success    bool      `json:"success"`
+	Error      string    `json:"error,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+func NewPayload(event, projectRef string, started time.Time, err error) Payload {
+	payload := Payload{
+		Event:      event,
+		ProjectRef: projectRef,
+		Duration:   time.Since(started).Seconds(),
+		Success:    err == nil,
+		Timestamp:  time.Now().UTC(),
+	}
+	if err != nil {
+		payload.Error = err.Error()
+	}
+	return payload
+}
+
+// Posts the payload to all hooks subscribed to its event. Delivery failures are
+// reported as warnings so that they never fail the deploy itself.
+func Notify(ctx context.Context, payload Payload) {
+	if len(payload.Text) == 0 {
+		payload.Text = summarise(payload)
+	}
+	for name, hook := range utils.Config.Hooks {
+		if !utils.SliceContains(hook.On, payload.Event) {
+			continue
+		}
+		if err := post(ctx, hook.Url, payload); err != nil {
+			fmt.Fprintf(os.Stderr, "%s failed to notify hooks.%s: %v\n", utils.Yellow("WARNING:"), name, err)
+		}
+	}
+}
+
+func summarise(p Payload) string {
+	status := "succeeded"
+	if !p.Success {
+		status = "failed"
+	}
+	var targets []string
+	targets = append(targets, p.Slugs...)
+	targets = append(targets, p.Migrations...)
+	msg := fmt.Sprintf("%s %s for project %s in %.1fs", p.Event, status, p.ProjectRef, p.Duration)
+	if len(targets) > 0 {
+		msg += ": " + strings.Join(targets, ", ")
+	}
+	if len(p.Error) > 0 {
+		msg += "\n" + p.Error
+	}
+	return msg
+}
+
+func post(ctx context.Context, url string, payload Payload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Errorf("failed to encode payload: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errors.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
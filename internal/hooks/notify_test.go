@@ -0,0 +1,63 @@
+package hooks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestNotifyHooks(t *testing.T) {
+	project := apitest.RandomProjectRef()
+	utils.Config.Hooks = map[string]utils.NotifyHook{
+		"deploy": {Url: "https://hooks.slack.com/services/test", On: []string{utils.HookFunctionsDeploy}},
+		"push":   {Url: "https://example.com/push", On: []string{utils.HookDbPush}},
+	}
+	t.Cleanup(func() { utils.Config.Hooks = nil })
+
+	t.Run("posts payload to subscribed hooks", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("https://hooks.slack.com").
+			Post("/services/test").
+			MatchType("json").
+			BodyString(`"event":"functions.deploy"`).
+			Reply(http.StatusOK)
+		// Run test
+		payload := NewPayload(utils.HookFunctionsDeploy, project, time.Now(), errors.New("bundle failed"))
+		payload.Slugs = []string{"hello"}
+		Notify(context.Background(), payload)
+		// Check error
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("ignores delivery failure", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("https://example.com").
+			Post("/push").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		Notify(context.Background(), NewPayload(utils.HookDbPush, project, time.Now(), nil))
+		// Check error
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestSummarise(t *testing.T) {
+	payload := Payload{
+		Event:      utils.HookDbPush,
+		ProjectRef: "test",
+		Migrations: []string{"20240101000000"},
+		Duration:   1.23,
+		Success:    true,
+	}
+	assert.Equal(t, "db.push succeeded for project test in 1.2s: 20240101000000", summarise(payload))
+}
@@ -0,0 +1,59 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/services"
+	"github.com/supabase/cli/internal/utils"
+	"golang.org/x/term"
+)
+
+func Run(ctx context.Context, output string, fsys afero.Fs) error {
+	// Image versions may be pinned by config or linked project, but defaults are fine without one
+	if err := utils.LoadConfigFS(fsys); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	var w io.Writer = os.Stdout
+	if len(output) > 0 {
+		f, err := fsys.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			return errors.Errorf("failed to open output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	} else if term.IsTerminal(int(os.Stdout.Fd())) {
+		return errors.New("Refusing to write image archive to terminal. Redirect stdout or use --output.")
+	}
+	images := GetRequiredImages()
+	for i, image := range images {
+		if err := utils.DockerPullImageIfNotCached(ctx, image); err != nil {
+			return err
+		}
+		images[i] = utils.GetRegistryImageUrl(image)
+	}
+	fmt.Fprintf(os.Stderr, "Exporting %d images...\n", len(images))
+	return SaveImages(ctx, images, w)
+}
+
+func GetRequiredImages() []string {
+	images := append(services.GetServiceImages(), utils.KongImage, utils.InbucketImage, utils.VectorImage)
+	images = append(images, utils.JobImages...)
+	return utils.RemoveDuplicates(images)
+}
+
+func SaveImages(ctx context.Context, images []string, w io.Writer) error {
+	r, err := utils.Docker.ImageSave(ctx, images)
+	if err != nil {
+		return errors.Errorf("failed to save docker images: %w", err)
+	}
+	defer r.Close()
+	if _, err := io.Copy(w, r); err != nil {
+		return errors.Errorf("failed to write image archive: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestExportImages(t *testing.T) {
+	t.Run("throws error on malformed config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte("malformed"), 0644))
+		// Run test
+		err := Run(context.Background(), "images.tar", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "toml: ")
+		exists, err := afero.Exists(fsys, "images.tar")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestSaveImages(t *testing.T) {
+	images := []string{utils.Pg15Image, utils.GotrueImage}
+
+	t.Run("writes image archive", func(t *testing.T) {
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v"+utils.Docker.ClientVersion()+"/images/get").
+			MatchParam("names", utils.Pg15Image).
+			Reply(http.StatusOK).
+			BodyString("archive")
+		// Run test
+		var out bytes.Buffer
+		err := SaveImages(context.Background(), images, &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "archive", out.String())
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on docker failure", func(t *testing.T) {
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/images/get").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		err := SaveImages(context.Background(), images, &bytes.Buffer{})
+		// Check error
+		assert.ErrorContains(t, err, "failed to save docker images:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestRequiredImages(t *testing.T) {
+	images := GetRequiredImages()
+	assert.Contains(t, images, utils.Config.Db.Image)
+	assert.Contains(t, images, utils.KongImage)
+	assert.Contains(t, images, utils.DifferImage)
+}
@@ -0,0 +1,38 @@
+package load
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/docker/cli/cli/streams"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(ctx context.Context, input string, fsys afero.Fs) error {
+	var r io.Reader = os.Stdin
+	if len(input) > 0 {
+		f, err := fsys.Open(input)
+		if err != nil {
+			return errors.Errorf("failed to open input file: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+	return LoadImages(ctx, r, os.Stderr)
+}
+
+func LoadImages(ctx context.Context, r io.Reader, w io.Writer) error {
+	resp, err := utils.Docker.ImageLoad(ctx, r, false)
+	if err != nil {
+		return errors.Errorf("failed to load docker images: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := jsonmessage.DisplayJSONMessagesToStream(resp.Body, streams.NewOut(w), nil); err != nil {
+		return errors.Errorf("failed to display json stream: %w", err)
+	}
+	return nil
+}
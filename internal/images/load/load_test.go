@@ -0,0 +1,48 @@
+package load
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestLoadImages(t *testing.T) {
+	t.Run("loads image archive", func(t *testing.T) {
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Post("/v" + utils.Docker.ClientVersion() + "/images/load").
+			Reply(http.StatusOK).
+			JSON(jsonmessage.JSONMessage{Stream: "Loaded image: " + utils.Pg15Image})
+		// Run test
+		err := LoadImages(context.Background(), strings.NewReader("archive"), io.Discard)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on invalid archive", func(t *testing.T) {
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Post("/v" + utils.Docker.ClientVersion() + "/images/load").
+			Reply(http.StatusInternalServerError).
+			JSON(map[string]string{"message": "unexpected EOF"})
+		// Run test
+		err := LoadImages(context.Background(), strings.NewReader("archive"), io.Discard)
+		// Check error
+		assert.ErrorContains(t, err, "unexpected EOF")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -0,0 +1,306 @@
+package inspect
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/services"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Number of log lines to collect from each container.
+const logTail = "500"
+
+type ContainerState struct {
+	Name   string `json:"name"`
+	Image  string `json:"image"`
+	State  string `json:"state"`
+	Status string `json:"status"`
+}
+
+// Bundle collects inspect reports, local config, service versions, container states, and
+// recent logs into a single tarball that can be attached to support tickets.
+func Bundle(ctx context.Context, out string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	date := time.Now().Format("2006-01-02")
+	// Stage all files in memory so that secrets can be redacted before writing to disk
+	staging := afero.NewMemMapFs()
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	fmt.Fprintln(os.Stderr, "Running queries...")
+	if err := utils.MkdirIfNotExistFS(staging, "inspect"); err != nil {
+		return err
+	}
+	if err := writeReports(ctx, "inspect", date, conn.PgConn(), staging); err != nil {
+		return err
+	}
+	if err := copyConfig(fsys, staging); err != nil {
+		return err
+	}
+	if err := writeVersions(staging); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Collecting container logs...")
+	if err := writeContainers(ctx, staging); err != nil {
+		fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), err)
+	}
+	if err := confirmRedaction(ctx, staging); err != nil {
+		return err
+	}
+	if err := utils.MkdirIfNotExistFS(fsys, out); err != nil {
+		return err
+	}
+	outPath := filepath.Join(out, fmt.Sprintf("supabase-report-%s.tar.gz", date))
+	if err := writeTarball(staging, outPath, fsys); err != nil {
+		return err
+	}
+	if !filepath.IsAbs(outPath) {
+		outPath, _ = filepath.Abs(outPath)
+	}
+	fmt.Fprintln(os.Stderr, "Report bundle saved to "+utils.Bold(outPath))
+	return nil
+}
+
+func copyConfig(fsys, staging afero.Fs) error {
+	contents, err := afero.ReadFile(fsys, utils.ConfigPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return errors.Errorf("failed to read config: %w", err)
+	}
+	return utils.WriteFile(filepath.Base(utils.ConfigPath), contents, staging)
+}
+
+func writeVersions(staging afero.Fs) error {
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "supabase/cli:"+utils.Version)
+	for _, image := range services.GetServiceImages() {
+		fmt.Fprintln(&buf, image)
+	}
+	return utils.WriteFile("versions.txt", buf.Bytes(), staging)
+}
+
+func writeContainers(ctx context.Context, staging afero.Fs) error {
+	containers, err := utils.Docker.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: utils.CliProjectFilter(),
+	})
+	if err != nil {
+		return errors.Errorf("failed to list containers: %w", err)
+	}
+	states := make([]ContainerState, len(containers))
+	for i, c := range containers {
+		states[i] = ContainerState{Image: c.Image, State: c.State, Status: c.Status}
+		if len(c.Names) > 0 {
+			states[i].Name = strings.TrimPrefix(c.Names[0], "/")
+		}
+	}
+	data, err := json.MarshalIndent(states, "", "  ")
+	if err != nil {
+		return errors.Errorf("failed to encode containers: %w", err)
+	}
+	if err := utils.WriteFile("containers.json", data, staging); err != nil {
+		return err
+	}
+	if err := utils.MkdirIfNotExistFS(staging, "logs"); err != nil {
+		return err
+	}
+	for i, c := range containers {
+		logs, err := readLogs(ctx, c.ID)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), err)
+			continue
+		}
+		name := states[i].Name
+		if len(name) == 0 {
+			name = c.ID
+		}
+		if err := utils.WriteFile(filepath.Join("logs", name+".log"), logs, staging); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readLogs(ctx context.Context, containerId string) ([]byte, error) {
+	logs, err := utils.Docker.ContainerLogs(ctx, containerId, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Timestamps: true,
+		Tail:       logTail,
+	})
+	if err != nil {
+		return nil, errors.Errorf("failed to read docker logs: %w", err)
+	}
+	defer logs.Close()
+	var buf bytes.Buffer
+	if _, err := stdcopy.StdCopy(&buf, &buf, logs); err != nil {
+		return nil, errors.Errorf("failed to copy docker logs: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Each pattern captures the secret value in its first group.
+var secretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)[a-z_]*(?:secret|password|passwd|token|api_key|apikey|private_key)[a-z_]*["']?\s*[=:]\s*["']?([^"'\s]{6,})`),
+	regexp.MustCompile(`(?i)postgres(?:ql)?://[^:/\s]+:([^@\s]+)@`),
+	regexp.MustCompile(`\b(eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+)`),
+	regexp.MustCompile(`\b(sbp_[a-f0-9]{40})\b`),
+}
+
+const redacted = "[REDACTED]"
+
+type Finding struct {
+	Path  string
+	Line  int
+	Value string
+}
+
+// FindSecrets scans staged files line by line for values that look like credentials.
+func FindSecrets(staging afero.Fs) ([]Finding, error) {
+	var result []Finding
+	err := afero.Walk(staging, "", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return errors.Errorf("failed to walk staging dir: %w", err)
+		}
+		if info.IsDir() {
+			return nil
+		}
+		contents, err := afero.ReadFile(staging, path)
+		if err != nil {
+			return errors.Errorf("failed to read staged file: %w", err)
+		}
+		for i, line := range strings.Split(string(contents), "\n") {
+			for _, value := range matchSecrets(line) {
+				result = append(result, Finding{Path: path, Line: i + 1, Value: value})
+			}
+		}
+		return nil
+	})
+	return result, err
+}
+
+func matchSecrets(line string) []string {
+	var result []string
+	for _, re := range secretPatterns {
+		for _, m := range re.FindAllStringSubmatch(line, -1) {
+			// Values loaded from env are not secret themselves
+			if value := m[1]; value != redacted && !strings.HasPrefix(value, "env(") {
+				result = append(result, value)
+			}
+		}
+	}
+	return result
+}
+
+func confirmRedaction(ctx context.Context, staging afero.Fs) error {
+	findings, err := FindSecrets(staging)
+	if err != nil || len(findings) == 0 {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Found secret-like values in the following files:")
+	for _, f := range findings {
+		fmt.Fprintf(os.Stderr, "  %s:%d %s\n", f.Path, f.Line, mask(f.Value))
+	}
+	title := fmt.Sprintf("Redact %d values from the report bundle?", len(findings))
+	if shouldRedact, err := utils.NewConsole().PromptYesNo(ctx, title, true); err != nil {
+		return err
+	} else if !shouldRedact {
+		fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "Report bundle contains unredacted secrets. Review its contents before sharing.")
+		return nil
+	}
+	return Redact(staging, findings)
+}
+
+func Redact(staging afero.Fs, findings []Finding) error {
+	values := map[string][]string{}
+	for _, f := range findings {
+		values[f.Path] = append(values[f.Path], f.Value)
+	}
+	for path, secrets := range values {
+		contents, err := afero.ReadFile(staging, path)
+		if err != nil {
+			return errors.Errorf("failed to read staged file: %w", err)
+		}
+		for _, s := range secrets {
+			contents = bytes.ReplaceAll(contents, []byte(s), []byte(redacted))
+		}
+		if err := utils.WriteFile(path, contents, staging); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func mask(value string) string {
+	if len(value) <= 4 {
+		return "****"
+	}
+	return value[:4] + "****"
+}
+
+func writeTarball(staging afero.Fs, outPath string, fsys afero.Fs) error {
+	f, err := fsys.OpenFile(outPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return errors.Errorf("failed to create report bundle: %w", err)
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	if err := afero.Walk(staging, "", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return errors.Errorf("failed to walk staging dir: %w", err)
+		}
+		if info.IsDir() {
+			return nil
+		}
+		return addFile(tw, staging, path, info)
+	}); err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return errors.Errorf("failed to close tar writer: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return errors.Errorf("failed to close gzip writer: %w", err)
+	}
+	return nil
+}
+
+func addFile(tw *tar.Writer, staging afero.Fs, path string, info os.FileInfo) error {
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return errors.Errorf("failed to create tar header: %w", err)
+	}
+	header.Name = filepath.ToSlash(filepath.Clean(path))
+	if err := tw.WriteHeader(header); err != nil {
+		return errors.Errorf("failed to write tar header: %w", err)
+	}
+	f, err := staging.Open(path)
+	if err != nil {
+		return errors.Errorf("failed to open staged file: %w", err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(tw, f); err != nil {
+		return errors.Errorf("failed to write tar entry: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+SELECT
+  a.pid,
+  pg_blocking_pids(a.pid) AS blocked_by,
+  COALESCE(a.usename::text, '') AS usename,
+  COALESCE(a.state, '') AS state,
+  a.query,
+  COALESCE(age(now(), a.query_start)::text, '') AS age
+FROM pg_stat_activity a
+WHERE a.pid <> pg_backend_pid()
+AND a.query <> '<insufficient privilege>'
+AND (
+  cardinality(pg_blocking_pids(a.pid)) > 0
+  OR a.pid IN (SELECT unnest(pg_blocking_pids(b.pid)) FROM pg_stat_activity b)
+)
+ORDER BY a.query_start
@@ -0,0 +1,78 @@
+package locks
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Kill prints the blocking tree and terminates the given backends after confirmation. If
+// no pid is given, the user is prompted for each blocker at the root of a tree.
+func Kill(ctx context.Context, config pgconn.Config, pids []int, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	sessions, err := LoadSessions(ctx, conn)
+	if err != nil {
+		return err
+	}
+	PrintTree(os.Stdout, sessions)
+	var targets []Session
+	if len(pids) > 0 {
+		for _, pid := range pids {
+			targets = append(targets, findSession(sessions, pid))
+		}
+	} else if targets = FindBlockers(sessions); len(targets) == 0 {
+		fmt.Println("No blocking backends found.")
+		return nil
+	}
+	console := utils.NewConsole()
+	for _, s := range targets {
+		title := fmt.Sprintf("Terminate backend %s?", utils.Bold(s.String()))
+		if n := CountBlocked(sessions, s.Pid); n > 0 {
+			title = fmt.Sprintf("Terminate backend %s blocking %d other queries?", utils.Bold(s.String()), n)
+		}
+		if shouldKill, err := console.PromptYesNo(ctx, title, false); err != nil {
+			return err
+		} else if !shouldKill {
+			fmt.Fprintln(os.Stderr, "Skipped backend:", s.Pid)
+			continue
+		}
+		if err := Terminate(ctx, conn, s.Pid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func findSession(sessions []Session, pid int) Session {
+	for _, s := range sessions {
+		if s.Pid == pid {
+			return s
+		}
+	}
+	// Backends outside of any blocking tree may still be terminated by pid
+	return Session{Pid: pid}
+}
+
+// Terminate ends the backend process, rolling back any open transaction and releasing its locks.
+func Terminate(ctx context.Context, conn *pgx.Conn, pid int) error {
+	var terminated bool
+	if err := conn.QueryRow(ctx, "SELECT pg_terminate_backend($1)", pid).Scan(&terminated); err != nil {
+		return errors.Errorf("failed to terminate backend %d: %w", pid, err)
+	}
+	if !terminated {
+		fmt.Fprintln(os.Stderr, "Backend not found:", pid)
+		return nil
+	}
+	fmt.Println("Terminated backend " + utils.Aqua(fmt.Sprintf("%d", pid)) + ".")
+	return nil
+}
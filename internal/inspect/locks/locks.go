@@ -4,6 +4,7 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
+	"os"
 	"regexp"
 
 	"github.com/go-errors/errors"
@@ -53,5 +54,13 @@ func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...fu
 		query = re.ReplaceAllString(query, `\|`)
 		table += fmt.Sprintf("|`%d`|`%s`|`%s`|`%t`|%s|`%s`|\n", r.Pid, r.Relname, r.Transactionid, r.Granted, query, r.Age)
 	}
-	return list.RenderTable(table)
+	if err := list.RenderTable(table); err != nil {
+		return err
+	}
+	sessions, err := LoadSessions(ctx, conn)
+	if err != nil {
+		return err
+	}
+	PrintTree(os.Stdout, sessions)
+	return nil
 }
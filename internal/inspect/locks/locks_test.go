@@ -1,13 +1,17 @@
 package locks
 
 import (
+	"bytes"
 	"context"
 	"testing"
 
 	"github.com/jackc/pgconn"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/fstest"
 	"github.com/supabase/cli/internal/testing/pgtest"
+	"github.com/supabase/cli/internal/utils"
 )
 
 var dbConfig = pgconn.Config{
@@ -34,9 +38,159 @@ func TestLocksCommand(t *testing.T) {
 				Query:         "select 1",
 				Age:           "300ms",
 			})
+		conn.Query(TreeQuery).
+			Reply("SELECT 2", Session{
+				Pid:        1,
+				Blocked_by: []int32{},
+				Usename:    "postgres",
+				State:      "idle in transaction",
+				Query:      "select 1",
+				Age:        "300ms",
+			}, Session{
+				Pid:        2,
+				Blocked_by: []int32{1},
+				Usename:    "authenticator",
+				State:      "active",
+				Query:      "update todos set done = true",
+				Age:        "200ms",
+			})
 		// Run test
 		err := Run(context.Background(), dbConfig, fsys, conn.Intercept)
 		// Check error
 		assert.NoError(t, err)
 	})
 }
+
+var sessions = []Session{
+	{Pid: 1, Usename: "postgres", State: "idle in transaction", Query: "begin; lock table todos", Age: "00:05:00"},
+	{Pid: 2, Blocked_by: []int32{1}, Usename: "authenticator", State: "active", Query: "update todos\n  set done = true", Age: "00:04:00"},
+	{Pid: 3, Blocked_by: []int32{2}, Usename: "authenticator", State: "active", Query: "select * from todos", Age: "00:03:00"},
+	{Pid: 4, Blocked_by: []int32{1}, Usename: "postgres", State: "active", Query: "vacuum todos", Age: "00:02:00"},
+}
+
+func TestPrintTree(t *testing.T) {
+	t.Run("renders blocking tree", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		PrintTree(&out, sessions)
+		// Check output
+		assert.Equal(t, `Blocking tree:
+pid 1 [postgres, idle in transaction, 00:05:00] begin; lock table todos
+├─ pid 2 [authenticator, active, 00:04:00] update todos set done = true
+│  └─ pid 3 [authenticator, active, 00:03:00] select * from todos
+└─ pid 4 [postgres, active, 00:02:00] vacuum todos
+`, out.String())
+	})
+
+	t.Run("skips empty tree", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		PrintTree(&out, nil)
+		// Check output
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("counts blocked sessions", func(t *testing.T) {
+		assert.Equal(t, 3, CountBlocked(sessions, 1))
+		assert.Equal(t, 1, CountBlocked(sessions, 2))
+		assert.Equal(t, 0, CountBlocked(sessions, 4))
+	})
+}
+
+func TestKillCommand(t *testing.T) {
+	t.Run("terminates pid on confirmation", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock stdin
+		defer fstest.MockStdin(t, "y")()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(TreeQuery).
+			Reply("SELECT 0").
+			Query("SELECT pg_terminate_backend($1)", 3).
+			Reply("SELECT 1", []interface{}{true})
+		// Run test
+		err := Kill(context.Background(), dbConfig, []int{3}, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("skips termination without confirmation", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(TreeQuery).
+			Reply("SELECT 2", Session{
+				Pid:        1,
+				Blocked_by: []int32{},
+				Usename:    "postgres",
+				State:      "idle in transaction",
+				Query:      "select 1",
+				Age:        "300ms",
+			}, Session{
+				Pid:        2,
+				Blocked_by: []int32{1},
+				Usename:    "authenticator",
+				State:      "active",
+				Query:      "update todos set done = true",
+				Age:        "200ms",
+			})
+		// Run test
+		err := Kill(context.Background(), dbConfig, nil, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on query failure", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(TreeQuery).
+			ReplyError("42501", "permission denied for function pg_blocking_pids")
+		// Run test
+		err := Kill(context.Background(), dbConfig, []int{1}, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "permission denied for function pg_blocking_pids")
+	})
+}
+
+func TestTerminateBackend(t *testing.T) {
+	t.Run("terminates backend by pid", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("SELECT pg_terminate_backend($1)", 1).
+			Reply("SELECT 1", []interface{}{true})
+		// Connect to mock
+		ctx := context.Background()
+		mock, err := utils.ConnectLocalPostgres(ctx, pgconn.Config{Port: 5432}, conn.Intercept)
+		require.NoError(t, err)
+		defer mock.Close(ctx)
+		// Run test
+		err = Terminate(ctx, mock, 1)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("ignores missing backend", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("SELECT pg_terminate_backend($1)", 2).
+			Reply("SELECT 1", []interface{}{false})
+		// Connect to mock
+		ctx := context.Background()
+		mock, err := utils.ConnectLocalPostgres(ctx, pgconn.Config{Port: 5432}, conn.Intercept)
+		require.NoError(t, err)
+		defer mock.Close(ctx)
+		// Run test
+		err = Terminate(ctx, mock, 2)
+		// Check error
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,118 @@
+package locks
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/internal/utils/pgxv5"
+)
+
+//go:embed blocking_tree.sql
+var TreeQuery string
+
+// Session is a backend that is either waiting on or holding a lock another backend needs.
+type Session struct {
+	Pid        int
+	Blocked_by []int32
+	Usename    string
+	State      string
+	Query      string
+	Age        string
+}
+
+func (s Session) IsBlockedBy(pid int) bool {
+	return slices.Contains(s.Blocked_by, int32(pid))
+}
+
+func (s Session) String() string {
+	if len(s.Query) == 0 {
+		return fmt.Sprintf("pid %d", s.Pid)
+	}
+	query := whitespacePattern.ReplaceAllString(strings.TrimSpace(s.Query), " ")
+	if len(query) > maxQueryLength {
+		query = query[:maxQueryLength-3] + "..."
+	}
+	return fmt.Sprintf("pid %d [%s, %s, %s] %s", s.Pid, s.Usename, s.State, s.Age, query)
+}
+
+const maxQueryLength = 80
+
+var whitespacePattern = regexp.MustCompile(`\s+`)
+
+func LoadSessions(ctx context.Context, conn *pgx.Conn) ([]Session, error) {
+	rows, err := conn.Query(ctx, TreeQuery)
+	if err != nil {
+		return nil, errors.Errorf("failed to query blocking sessions: %w", err)
+	}
+	return pgxv5.CollectRows[Session](rows)
+}
+
+// FindBlockers returns the sessions at the root of each blocking tree, ie. those holding
+// locks without waiting on any other backend.
+func FindBlockers(sessions []Session) []Session {
+	var result []Session
+	for _, s := range sessions {
+		if len(s.Blocked_by) == 0 {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// CountBlocked returns the number of sessions transitively waiting on pid.
+func CountBlocked(sessions []Session, pid int) int {
+	visited := map[int]bool{pid: true}
+	queue := []int{pid}
+	for len(queue) > 0 {
+		head := queue[0]
+		queue = queue[1:]
+		for _, s := range sessions {
+			if !visited[s.Pid] && s.IsBlockedBy(head) {
+				visited[s.Pid] = true
+				queue = append(queue, s.Pid)
+			}
+		}
+	}
+	return len(visited) - 1
+}
+
+// PrintTree renders each blocker followed by the sessions waiting on it, indented by depth.
+// Sessions waiting on multiple blockers are listed under each one.
+func PrintTree(w io.Writer, sessions []Session) {
+	blockers := FindBlockers(sessions)
+	if len(blockers) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "Blocking tree:")
+	for _, root := range blockers {
+		fmt.Fprintln(w, root.String())
+		printChildren(w, sessions, root.Pid, "", map[int]bool{root.Pid: true})
+	}
+}
+
+func printChildren(w io.Writer, sessions []Session, pid int, indent string, visited map[int]bool) {
+	var children []Session
+	for _, s := range sessions {
+		// Guards against deadlocks that have yet to be resolved by the server
+		if !visited[s.Pid] && s.IsBlockedBy(pid) {
+			children = append(children, s)
+		}
+	}
+	for i, s := range children {
+		branch, next := "├─ ", "│  "
+		if i == len(children)-1 {
+			branch, next = "└─ ", "   "
+		}
+		fmt.Fprintln(w, indent+branch+s.String())
+		visited[s.Pid] = true
+		printChildren(w, sessions, s.Pid, indent+next, visited)
+		delete(visited, s.Pid)
+	}
+}
@@ -0,0 +1,148 @@
+package outliers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/pgxv5"
+)
+
+// Not embedded as a sql file so that inspect report does not export it. Statistics are summed
+// across roles, because api requests run as anon or authenticated rather than the login role.
+const SnapshotQuery = `SELECT
+  queryid::text AS queryid,
+  min(query) AS query,
+  sum(calls)::bigint AS calls,
+  sum(total_exec_time) AS total_exec_time,
+  sum(total_exec_time) / sum(calls) AS mean_exec_time
+FROM pg_stat_statements
+WHERE dbid = (SELECT oid FROM pg_database WHERE datname = current_database())
+GROUP BY queryid
+HAVING sum(calls) >= greatest($1::bigint, 1)
+ORDER BY total_exec_time DESC
+LIMIT 50`
+
+type Stat struct {
+	Queryid         string  `json:"query_id"`
+	Query           string  `json:"query"`
+	Calls           int64   `json:"calls"`
+	Total_exec_time float64 `json:"total_exec_time"`
+	Mean_exec_time  float64 `json:"mean_exec_time"`
+}
+
+type Baseline struct {
+	CreatedAt time.Time `json:"created_at"`
+	Queries   []Stat    `json:"queries"`
+}
+
+type Regression struct {
+	Query    string
+	Baseline float64
+	Current  float64
+	// Percentage change in mean execution time
+	Change float64
+}
+
+// SaveBaseline records the top queries that were called at least minCalls times, since the mean
+// execution time of rarely called queries is too noisy to compare.
+func SaveBaseline(ctx context.Context, config pgconn.Config, path string, minCalls uint, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	stats, err := loadStats(ctx, config, minCalls, options...)
+	if err != nil {
+		return err
+	}
+	baseline := Baseline{CreatedAt: time.Now().UTC(), Queries: stats}
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return errors.Errorf("failed to encode baseline: %w", err)
+	}
+	if err := utils.WriteFile(path, data, fsys); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Saved %d queries to baseline: %s\n", len(stats), utils.Bold(path))
+	return nil
+}
+
+// CompareBaseline fails if the mean execution time of any query in the baseline increased by
+// more than threshold percent.
+func CompareBaseline(ctx context.Context, config pgconn.Config, path string, threshold float64, minCalls uint, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	baseline, err := LoadBaseline(path, fsys)
+	if err != nil {
+		return err
+	}
+	stats, err := loadStats(ctx, config, minCalls, options...)
+	if err != nil {
+		return err
+	}
+	regressions := FindRegressions(baseline.Queries, stats, threshold)
+	if len(regressions) == 0 {
+		fmt.Fprintf(os.Stderr, "No queries regressed by more than %g%% since %s.\n", threshold, baseline.CreatedAt.Format(time.RFC3339))
+		return nil
+	}
+	table := "|Query|Baseline mean time|Current mean time|Change|\n|-|-|-|-|\n"
+	for _, r := range regressions {
+		table += fmt.Sprintf("|`%s`|`%.3fms`|`%.3fms`|`+%.1f%%`|\n", formatQuery(r.Query), r.Baseline, r.Current, r.Change)
+	}
+	if err := list.RenderTable(table); err != nil {
+		return err
+	}
+	return errors.Errorf("%d queries regressed by more than %g%% since %s", len(regressions), threshold, baseline.CreatedAt.Format(time.RFC3339))
+}
+
+func LoadBaseline(path string, fsys afero.Fs) (Baseline, error) {
+	var result Baseline
+	data, err := afero.ReadFile(fsys, path)
+	if err != nil {
+		return result, errors.Errorf("failed to read baseline: %w", err)
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, errors.Errorf("failed to parse baseline: %w", err)
+	}
+	return result, nil
+}
+
+// FindRegressions matches queries by their query id, which is stable for the same statement on
+// the same major version. Queries missing from either side are ignored.
+func FindRegressions(baseline, current []Stat, threshold float64) []Regression {
+	means := make(map[string]float64, len(current))
+	for _, s := range current {
+		means[s.Queryid] = s.Mean_exec_time
+	}
+	var result []Regression
+	for _, s := range baseline {
+		mean, ok := means[s.Queryid]
+		if !ok || s.Mean_exec_time <= 0 {
+			continue
+		}
+		if change := (mean - s.Mean_exec_time) / s.Mean_exec_time * 100; change > threshold {
+			result = append(result, Regression{
+				Query:    s.Query,
+				Baseline: s.Mean_exec_time,
+				Current:  mean,
+				Change:   change,
+			})
+		}
+	}
+	return result
+}
+
+func loadStats(ctx context.Context, config pgconn.Config, minCalls uint, options ...func(*pgx.ConnConfig)) ([]Stat, error) {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close(context.Background())
+	rows, err := conn.Query(ctx, SnapshotQuery, int64(minCalls))
+	if err != nil {
+		return nil, errors.Errorf("failed to query rows: %w", err)
+	}
+	return pgxv5.CollectRows[Stat](rows)
+}
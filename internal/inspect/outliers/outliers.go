@@ -43,12 +43,15 @@ func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...fu
 	// TODO: implement a markdown table marshaller
 	table := "|Query|Execution Time|Proportion of exec time|Number Calls|Sync IO time|\n|-|-|-|-|-|\n"
 	for _, r := range result {
-		re := regexp.MustCompile(`\s+|\r+|\n+|\t+|\v`)
-		query := re.ReplaceAllString(r.Query, " ")
-
-		re = regexp.MustCompile(`\|`)
-		query = re.ReplaceAllString(query, `\|`)
-		table += fmt.Sprintf("|`%s`|`%s`|`%s`|`%s`|`%s`|\n", query, r.Total_exec_time, r.Prop_exec_time, r.Ncalls, r.Sync_io_time)
+		table += fmt.Sprintf("|`%s`|`%s`|`%s`|`%s`|`%s`|\n", formatQuery(r.Query), r.Total_exec_time, r.Prop_exec_time, r.Ncalls, r.Sync_io_time)
 	}
 	return list.RenderTable(table)
 }
+
+func formatQuery(query string) string {
+	re := regexp.MustCompile(`\s+|\r+|\n+|\t+|\v`)
+	query = re.ReplaceAllString(query, " ")
+
+	re = regexp.MustCompile(`\|`)
+	return re.ReplaceAllString(query, `\|`)
+}
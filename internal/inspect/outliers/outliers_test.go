@@ -2,11 +2,14 @@ package outliers
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/jackc/pgconn"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/supabase/cli/internal/testing/pgtest"
 )
 
@@ -39,3 +42,130 @@ func TestOutliersCommand(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+var baseline = Baseline{
+	CreatedAt: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+	Queries: []Stat{{
+		Queryid:         "-123",
+		Query:           "select * from todos where id = $1",
+		Calls:           100,
+		Total_exec_time: 100,
+		Mean_exec_time:  1,
+	}, {
+		Queryid:         "456",
+		Query:           "insert into todos (name) values ($1)",
+		Calls:           10,
+		Total_exec_time: 20,
+		Mean_exec_time:  2,
+	}},
+}
+
+func TestSaveBaseline(t *testing.T) {
+	t.Run("saves top queries", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(SnapshotQuery, int64(10)).
+			Reply("SELECT 1", baseline.Queries[0])
+		// Run test
+		err := SaveBaseline(context.Background(), dbConfig, "perf/baseline.json", 10, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		saved, err := LoadBaseline("perf/baseline.json", fsys)
+		assert.NoError(t, err)
+		assert.Equal(t, baseline.Queries[:1], saved.Queries)
+		assert.False(t, saved.CreatedAt.IsZero())
+	})
+
+	t.Run("throws error on query failure", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(SnapshotQuery, int64(10)).
+			ReplyError("42P01", `relation "pg_stat_statements" does not exist`)
+		// Run test
+		err := SaveBaseline(context.Background(), dbConfig, "baseline.json", 10, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, `relation "pg_stat_statements" does not exist`)
+		exists, err := afero.Exists(fsys, "baseline.json")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestCompareBaseline(t *testing.T) {
+	data, err := json.Marshal(baseline)
+	require.NoError(t, err)
+
+	t.Run("passes within threshold", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "baseline.json", data, 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(SnapshotQuery, int64(10)).
+			Reply("SELECT 1", Stat{
+				Queryid:         "-123",
+				Query:           "select * from todos where id = $1",
+				Calls:           200,
+				Total_exec_time: 230,
+				Mean_exec_time:  1.15,
+			})
+		// Run test
+		err := CompareBaseline(context.Background(), dbConfig, "baseline.json", 20, 10, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on regression", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "baseline.json", data, 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(SnapshotQuery, int64(10)).
+			Reply("SELECT 1", Stat{
+				Queryid:         "456",
+				Query:           "insert into todos (name) values ($1)",
+				Calls:           20,
+				Total_exec_time: 100,
+				Mean_exec_time:  5,
+			})
+		// Run test
+		err := CompareBaseline(context.Background(), dbConfig, "baseline.json", 20, 10, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "1 queries regressed by more than 20% since 2024-05-01T00:00:00Z")
+	})
+
+	t.Run("throws error on missing baseline", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := CompareBaseline(context.Background(), dbConfig, "baseline.json", 20, 10, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to read baseline:")
+	})
+}
+
+func TestFindRegressions(t *testing.T) {
+	current := []Stat{
+		{Queryid: "-123", Mean_exec_time: 0.5},
+		{Queryid: "456", Mean_exec_time: 3},
+		{Queryid: "789", Mean_exec_time: 100},
+	}
+	// Run test
+	result := FindRegressions(baseline.Queries, current, 20)
+	// Check output
+	assert.Equal(t, []Regression{{
+		Query:    "insert into todos (name) values ($1)",
+		Baseline: 2,
+		Current:  3,
+		Change:   50,
+	}}, result)
+}
@@ -32,7 +32,18 @@ func Report(ctx context.Context, out string, config pgconn.Config, fsys afero.Fs
 	}
 	defer conn.Close(context.Background())
 	fmt.Fprintln(os.Stderr, "Running queries...")
-	if err := fs.WalkDir(queries, ".", func(path string, d fs.DirEntry, err error) error {
+	if err := writeReports(ctx, out, date, conn.PgConn(), fsys); err != nil {
+		return err
+	}
+	if !filepath.IsAbs(out) {
+		out, _ = filepath.Abs(out)
+	}
+	fmt.Fprintln(os.Stderr, "Reports saved to "+utils.Bold(out))
+	return nil
+}
+
+func writeReports(ctx context.Context, out, date string, conn *pgconn.PgConn, fsys afero.Fs) error {
+	return fs.WalkDir(queries, ".", func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return errors.Errorf("failed to walk queries: %w", err)
 		}
@@ -45,15 +56,8 @@ func Report(ctx context.Context, out string, config pgconn.Config, fsys afero.Fs
 		}
 		name := strings.Split(d.Name(), ".")[0]
 		outPath := filepath.Join(out, fmt.Sprintf("%s_%s.csv", name, date))
-		return copyToCSV(ctx, string(query), outPath, conn.PgConn(), fsys)
-	}); err != nil {
-		return err
-	}
-	if !filepath.IsAbs(out) {
-		out, _ = filepath.Abs(out)
-	}
-	fmt.Fprintln(os.Stderr, "Reports saved to "+utils.Bold(out))
-	return nil
+		return copyToCSV(ctx, string(query), outPath, conn, fsys)
+	})
 }
 
 func copyToCSV(ctx context.Context, query, outPath string, conn *pgconn.PgConn, fsys afero.Fs) error {
@@ -57,6 +57,8 @@ func TestReportCommand(t *testing.T) {
 			Reply("COPY 0").
 			Query(wrapQuery(index_usage.IndexUsageQuery)).
 			Reply("COPY 0").
+			Query(wrapQuery(locks.TreeQuery)).
+			Reply("COPY 0").
 			Query(wrapQuery(locks.LocksQuery)).
 			Reply("COPY 0").
 			Query(wrapQuery(long_running_queries.LongRunningQueriesQuery)).
@@ -89,7 +91,7 @@ func TestReportCommand(t *testing.T) {
 		assert.NoError(t, err)
 		matches, err := afero.Glob(fsys, "*.csv")
 		assert.NoError(t, err)
-		assert.Len(t, matches, 19)
+		assert.Len(t, matches, 20)
 	})
 }
 
@@ -35,16 +35,41 @@ func (c ConfigCopy) IsEmpty() bool {
 	return c.Api == nil && c.Db == nil && c.Pooler == nil
 }
 
-func Run(ctx context.Context, projectRef string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
-	// 1. Check service config
-	keys, err := tenant.GetApiKeys(ctx, projectRef)
+// Run links the local project to projectRef. If skipServices is set, the management API
+// is not called, so linking works with restricted access tokens or no network access to
+// the platform. Service configs and versions are left as they were in that case.
+func Run(ctx context.Context, projectRef string, skipServices bool, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	return run(ctx, projectRef, skipServices, flags.GetDbConfigOptionalPassword, fsys, options...)
+}
+
+// RunWithPassword is like Run, but connects with password instead of reading it from the
+// environment or stdin. The database is not linked if password is empty.
+func RunWithPassword(ctx context.Context, projectRef, password string, skipServices bool, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	return run(ctx, projectRef, skipServices, func(projectRef string) pgconn.Config {
+		return flags.NewDbConfigFromPassword(projectRef, password)
+	}, fsys, options...)
+}
+
+func run(ctx context.Context, projectRef string, skipServices bool, getDbConfig func(string) pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	release, err := utils.AcquireLock(utils.ProjectLock, fsys)
 	if err != nil {
 		return err
 	}
-	LinkServices(ctx, projectRef, keys.Anon, fsys)
+	defer release()
+	// 1. Check service config
+	if skipServices {
+		fmt.Fprintln(os.Stderr, "Skipped linking service configs and versions.")
+	} else {
+		keys, err := tenant.GetApiKeys(ctx, projectRef)
+		if err != nil {
+			utils.CmdSuggestion = fmt.Sprintf("Run %s to link without reading service configs.", utils.Aqua("supabase link --skip-services"))
+			return err
+		}
+		LinkServices(ctx, projectRef, keys.Anon, fsys)
+	}
 
 	// 2. Check database connection
-	config := flags.GetDbConfigOptionalPassword(projectRef)
+	config := getDbConfig(projectRef)
 	if len(config.Password) > 0 {
 		if err := linkDatabase(ctx, config, options...); err != nil {
 			return err
@@ -3,6 +3,7 @@ package link
 import (
 	"context"
 	"errors"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/jackc/pgx/v4"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/supabase/cli/internal/migration/history"
 	"github.com/supabase/cli/internal/testing/apitest"
 	"github.com/supabase/cli/internal/testing/fstest"
@@ -131,7 +133,7 @@ func TestLinkCommand(t *testing.T) {
 				},
 			})
 		// Run test
-		err := Run(context.Background(), project, fsys, conn.Intercept)
+		err := Run(context.Background(), project, false, fsys, conn.Intercept)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -181,7 +183,7 @@ func TestLinkCommand(t *testing.T) {
 			Get("/v1/projects").
 			ReplyError(errors.New("network error"))
 		// Run test
-		err := Run(context.Background(), project, fsys, func(cc *pgx.ConnConfig) {
+		err := Run(context.Background(), project, false, fsys, func(cc *pgx.ConnConfig) {
 			cc.LookupFunc = func(ctx context.Context, host string) (addrs []string, err error) {
 				return nil, errors.New("hostname resolving error")
 			}
@@ -191,6 +193,65 @@ func TestLinkCommand(t *testing.T) {
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
 
+	t.Run("skips linking services", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		// Run test
+		err := Run(context.Background(), project, true, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		// Validate file contents
+		content, err := afero.ReadFile(fsys, utils.ProjectRefPath)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(project), content)
+		exists, err := afero.Exists(fsys, utils.RestVersionPath)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("suggests skipping services on unauthorized", func(t *testing.T) {
+		defer teardown()
+		defer func() { utils.CmdSuggestion = "" }()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/api-keys").
+			Reply(http.StatusForbidden).
+			JSON(map[string]string{"message": "Forbidden"})
+		// Run test
+		err := Run(context.Background(), project, false, fsys)
+		// Check error
+		assert.ErrorIs(t, err, tenant.ErrAuthToken)
+		assert.Contains(t, utils.CmdSuggestion, "--skip-services")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		exists, err := afero.Exists(fsys, utils.ProjectRefPath)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("throws error when another command is running", func(t *testing.T) {
+		defer teardown()
+		defer func() { utils.CmdSuggestion = "" }()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		release, err := utils.AcquireLock(utils.ProjectLock, fsys)
+		require.NoError(t, err)
+		defer release()
+		// Run test
+		err = Run(context.Background(), project, true, fsys)
+		// Check error
+		assert.ErrorIs(t, err, utils.ErrLocked)
+		exists, err := afero.Exists(fsys, utils.ProjectRefPath)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
 	t.Run("throws error on write failure", func(t *testing.T) {
 		defer teardown()
 		// Setup in-memory fs
@@ -222,7 +283,7 @@ func TestLinkCommand(t *testing.T) {
 			Get("/v1/projects").
 			ReplyError(errors.New("network error"))
 		// Run test
-		err := Run(context.Background(), project, fsys)
+		err := Run(context.Background(), project, false, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "operation not permitted")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -367,7 +428,10 @@ func TestLinkDatabase(t *testing.T) {
 			Query(history.CREATE_VERSION_TABLE).
 			ReplyError(pgerrcode.InsufficientPrivilege, "permission denied for relation supabase_migrations").
 			Query(history.ADD_STATEMENTS_COLUMN).
-			Query(history.ADD_NAME_COLUMN)
+			Query(history.ADD_NAME_COLUMN).
+			Query(history.ADD_CREATED_AT_COLUMN).
+			Query(history.SET_CREATED_AT_DEFAULT).
+			Query(history.ADD_CHECKSUM_COLUMN)
 		// Run test
 		err := linkDatabase(context.Background(), dbConfig, conn.Intercept)
 		// Check error
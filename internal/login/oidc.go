@@ -0,0 +1,124 @@
+package login
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"github.com/supabase/cli/pkg/fetcher"
+)
+
+const (
+	// Set by CI platforms that let jobs configure their own id tokens, such as GitLab
+	EnvOidcToken = "SUPABASE_OIDC_TOKEN"
+
+	grantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+	tokenTypeIdToken       = "urn:ietf:params:oauth:token-type:id_token"
+)
+
+var ErrMissingOidc = errors.Errorf("OIDC token not found. Run inside GitHub Actions with %s permission, or set the %s environment variable.", utils.Aqua("id-token: write"), utils.Aqua(EnvOidcToken))
+
+// RunOidc exchanges the OIDC token issued to the current CI job for a short-lived
+// access token, so that pipelines don't need to store a personal access token.
+func RunOidc(ctx context.Context, stdout io.Writer, fsys afero.Fs) error {
+	idToken, err := fetchIdToken(ctx)
+	if err != nil {
+		return err
+	}
+	resp, err := exchangeIdToken(ctx, idToken)
+	if err != nil {
+		return err
+	}
+	if err := utils.SaveAccessToken(resp.AccessToken, fsys); err != nil {
+		return errors.Errorf("cannot save exchanged token: %w", err)
+	}
+	if resp.ExpiresIn > 0 {
+		expiresIn := time.Duration(resp.ExpiresIn) * time.Second
+		fmt.Fprintf(stdout, "Access token expires in %s.\n", expiresIn)
+	}
+	fmt.Fprintln(stdout, loggedInMsg)
+	return nil
+}
+
+func fetchIdToken(ctx context.Context) (string, error) {
+	if token := os.Getenv(EnvOidcToken); len(token) > 0 {
+		return token, nil
+	}
+	// Ref: https://docs.github.com/en/actions/deployment/security-hardening-your-deployments/about-security-hardening-with-openid-connect
+	if requestUrl := os.Getenv("ACTIONS_ID_TOKEN_REQUEST_URL"); len(requestUrl) > 0 {
+		return fetchGitHubIdToken(ctx, requestUrl, os.Getenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN"))
+	}
+	if os.Getenv("GITLAB_CI") == "true" {
+		return "", errors.Errorf("OIDC token not found. Configure %s with audience %s in your GitLab job.", utils.Aqua("id_tokens."+EnvOidcToken), utils.Aqua(utils.GetSupabaseAPIHost()))
+	}
+	return "", errors.New(ErrMissingOidc)
+}
+
+type gitHubIdTokenResponse struct {
+	Value string `json:"value"`
+}
+
+func fetchGitHubIdToken(ctx context.Context, requestUrl, requestToken string) (string, error) {
+	if len(requestToken) == 0 {
+		return "", errors.New(ErrMissingOidc)
+	}
+	parsed, err := url.Parse(requestUrl)
+	if err != nil {
+		return "", errors.Errorf("failed to parse id token request url: %w", err)
+	}
+	query := parsed.Query()
+	query.Set("audience", utils.GetSupabaseAPIHost())
+	parsed.RawQuery = query.Encode()
+	client := fetcher.NewFetcher(
+		"",
+		fetcher.WithHTTPClient(&http.Client{
+			Timeout: 10 * time.Second,
+		}),
+		fetcher.WithBearerToken(requestToken),
+		fetcher.WithExpectedStatus(http.StatusOK),
+	)
+	resp, err := client.Send(ctx, http.MethodGet, parsed.String(), nil)
+	if err != nil {
+		return "", errors.Errorf("failed to request id token: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := fetcher.ParseJSON[gitHubIdTokenResponse](resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return body.Value, nil
+}
+
+// Ref: https://datatracker.ietf.org/doc/html/rfc8693
+func exchangeIdToken(ctx context.Context, idToken string) (api.OAuthTokenResponse, error) {
+	form := url.Values{
+		"grant_type":         {grantTypeTokenExchange},
+		"subject_token":      {idToken},
+		"subject_token_type": {tokenTypeIdToken},
+	}
+	client := fetcher.NewFetcher(
+		utils.GetSupabaseAPIHost(),
+		fetcher.WithHTTPClient(&http.Client{
+			Timeout: 10 * time.Second,
+		}),
+		fetcher.WithUserAgent("SupabaseCLI/"+utils.Version),
+		fetcher.WithExpectedStatus(http.StatusOK, http.StatusCreated),
+	)
+	resp, err := client.Send(ctx, http.MethodPost, "/v1/oauth/token", strings.NewReader(form.Encode()), func(req *http.Request) {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	})
+	if err != nil {
+		return api.OAuthTokenResponse{}, errors.Errorf("failed to exchange id token: %w", err)
+	}
+	defer resp.Body.Close()
+	return fetcher.ParseJSON[api.OAuthTokenResponse](resp.Body)
+}
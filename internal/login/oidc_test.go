@@ -0,0 +1,102 @@
+package login
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/credentials"
+	"github.com/supabase/cli/pkg/api"
+	"github.com/zalando/go-keyring"
+)
+
+func TestLoginOidc(t *testing.T) {
+	keyring.MockInit()
+
+	t.Run("exchanges github actions token", func(t *testing.T) {
+		t.Setenv(EnvOidcToken, "")
+		t.Setenv("ACTIONS_ID_TOKEN_REQUEST_URL", "https://token.actions.githubusercontent.com/request?api-version=2.0")
+		t.Setenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN", "request-token")
+		token := string(apitest.RandomAccessToken(t))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("https://token.actions.githubusercontent.com").
+			Get("/request").
+			MatchParam("api-version", "2.0").
+			MatchParam("audience", utils.GetSupabaseAPIHost()).
+			MatchHeader("Authorization", "Bearer request-token").
+			Reply(http.StatusOK).
+			JSON(gitHubIdTokenResponse{Value: "id-token"})
+		gock.New(utils.GetSupabaseAPIHost()).
+			Post("/v1/oauth/token").
+			MatchType("url").
+			BodyString("grant_type=urn%3Aietf%3Aparams%3Aoauth%3Agrant-type%3Atoken-exchange&subject_token=id-token&subject_token_type=urn%3Aietf%3Aparams%3Aoauth%3Atoken-type%3Aid_token").
+			Reply(http.StatusOK).
+			JSON(api.OAuthTokenResponse{AccessToken: token, ExpiresIn: 3600})
+		// Run test
+		err := RunOidc(context.Background(), io.Discard, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		saved, err := credentials.Get(utils.AccessTokenKey)
+		assert.NoError(t, err)
+		assert.Equal(t, token, saved)
+	})
+
+	t.Run("exchanges configured token", func(t *testing.T) {
+		t.Setenv(EnvOidcToken, "id-token")
+		token := string(apitest.RandomAccessToken(t))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.GetSupabaseAPIHost()).
+			Post("/v1/oauth/token").
+			Reply(http.StatusCreated).
+			JSON(api.OAuthTokenResponse{AccessToken: token})
+		// Run test
+		err := RunOidc(context.Background(), io.Discard, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing token", func(t *testing.T) {
+		t.Setenv(EnvOidcToken, "")
+		t.Setenv("ACTIONS_ID_TOKEN_REQUEST_URL", "")
+		t.Setenv("GITLAB_CI", "")
+		// Run test
+		err := RunOidc(context.Background(), io.Discard, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorIs(t, err, ErrMissingOidc)
+	})
+
+	t.Run("throws error on missing github permission", func(t *testing.T) {
+		t.Setenv(EnvOidcToken, "")
+		t.Setenv("ACTIONS_ID_TOKEN_REQUEST_URL", "https://token.actions.githubusercontent.com/request")
+		t.Setenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN", "")
+		// Run test
+		err := RunOidc(context.Background(), io.Discard, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorIs(t, err, ErrMissingOidc)
+	})
+
+	t.Run("throws error on rejected token", func(t *testing.T) {
+		t.Setenv(EnvOidcToken, "id-token")
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.GetSupabaseAPIHost()).
+			Post("/v1/oauth/token").
+			Reply(http.StatusUnauthorized).
+			JSON(map[string]string{"message": "unauthorized"})
+		// Run test
+		err := RunOidc(context.Background(), io.Discard, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, `failed to exchange id token: Error status 401: {"message":"unauthorized"}`)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
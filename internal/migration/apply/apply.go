@@ -10,6 +10,7 @@ import (
 	"github.com/go-errors/errors"
 	"github.com/jackc/pgx/v4"
 	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/data"
 	"github.com/supabase/cli/internal/migration/history"
 	"github.com/supabase/cli/internal/migration/list"
 	"github.com/supabase/cli/internal/migration/repair"
@@ -29,14 +30,22 @@ func MigrateAndSeed(ctx context.Context, version string, conn *pgx.Conn, fsys af
 
 func SeedDatabase(ctx context.Context, conn *pgx.Conn, fsys afero.Fs) error {
 	seed, err := repair.NewMigrationFromFile(utils.SeedDataPath, fsys)
-	if errors.Is(err, os.ErrNotExist) {
-		return nil
-	} else if err != nil {
+	if err == nil {
+		fmt.Fprintln(utils.GetStatusWriter(), "Seeding data "+utils.Bold(utils.SeedDataPath)+"...")
+		// Batch seed commands, safe to use statement cache
+		if err := seed.ExecBatchWithCache(ctx, conn); err != nil {
+			return err
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := SeedTables(ctx, conn, utils.Config.Seed.Tables, fsys); err != nil {
 		return err
 	}
-	fmt.Fprintln(os.Stderr, "Seeding data "+utils.Bold(utils.SeedDataPath)+"...")
-	// Batch seed commands, safe to use statement cache
-	return seed.ExecBatchWithCache(ctx, conn)
+	if utils.Config.Seed.ResetSequences {
+		return ResetSequences(ctx, conn)
+	}
+	return nil
 }
 
 func MigrateUp(ctx context.Context, conn *pgx.Conn, pending []string, fsys afero.Fs) error {
@@ -53,9 +62,26 @@ func MigrateUp(ctx context.Context, conn *pgx.Conn, pending []string, fsys afero
 	return nil
 }
 
+// ResumeMigration applies the statements of a partially applied migration file from start,
+// and records the whole file in history.
+func ResumeMigration(ctx context.Context, conn *pgx.Conn, filename string, start int, fsys afero.Fs) error {
+	fmt.Fprintf(utils.GetStatusWriter(), "Resuming migration %s from statement %d...\n", utils.Bold(filename), start)
+	migration, err := repair.NewMigrationFromFile(filepath.Join(utils.MigrationsDir, filename), fsys)
+	if err != nil {
+		return err
+	}
+	if start > len(migration.Lines) {
+		return errors.Errorf("Migration %s has only %d statements.", filename, len(migration.Lines))
+	}
+	return migration.ExecBatchFrom(ctx, conn, start)
+}
+
 func applyMigration(ctx context.Context, conn *pgx.Conn, filename string, fsys afero.Fs) error {
-	fmt.Fprintln(os.Stderr, "Applying migration "+utils.Bold(filename)+"...")
+	fmt.Fprintln(utils.GetStatusWriter(), "Applying migration "+utils.Bold(filename)+"...")
 	path := filepath.Join(utils.MigrationsDir, filename)
+	if utils.DataFilePattern.MatchString(filename) {
+		return data.Run(ctx, conn, path, fsys)
+	}
 	migration, err := repair.NewMigrationFromFile(path, fsys)
 	if err != nil {
 		return err
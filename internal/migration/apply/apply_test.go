@@ -30,7 +30,7 @@ func TestMigrateDatabase(t *testing.T) {
 		pgtest.MockMigrationHistory(conn)
 		conn.Query(sql).
 			Reply("CREATE SCHEMA").
-			Query(history.INSERT_MIGRATION_VERSION, "0", "test", []string{sql}).
+			Query(history.INSERT_MIGRATION_VERSION, "0", "test", []string{sql}, history.Checksum([]string{sql})).
 			Reply("INSERT 0 1")
 		// Connect to mock
 		ctx := context.Background()
@@ -78,6 +78,30 @@ func TestSeedDatabase(t *testing.T) {
 		assert.NoError(t, SeedDatabase(ctx, mock, fsys))
 	})
 
+	t.Run("resets sequences after seeding", func(t *testing.T) {
+		utils.Config.Seed.ResetSequences = true
+		defer func() { utils.Config.Seed.ResetSequences = false }()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup seed file
+		sql := "INSERT INTO employees(id, name) VALUES (1, 'Alice')"
+		require.NoError(t, afero.WriteFile(fsys, utils.SeedDataPath, []byte(sql), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(sql).
+			Reply("INSERT 0 1").
+			Query(RESET_SEQUENCES).
+			Reply("DO")
+		// Connect to mock
+		ctx := context.Background()
+		mock, err := utils.ConnectLocalPostgres(ctx, pgconn.Config{Port: 5432}, conn.Intercept)
+		require.NoError(t, err)
+		defer mock.Close(ctx)
+		// Run test
+		assert.NoError(t, SeedDatabase(ctx, mock, fsys))
+	})
+
 	t.Run("ignores missing seed", func(t *testing.T) {
 		assert.NoError(t, SeedDatabase(context.Background(), nil, afero.NewMemMapFs()))
 	})
@@ -0,0 +1,44 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Sequences are only ever advanced, so that values handed out before seeding are not reused.
+// Those the current role cannot update, such as sequences owned by platform roles, are skipped.
+const RESET_SEQUENCES = `DO $$
+DECLARE
+  r record;
+BEGIN
+  FOR r IN
+    SELECT s.seqrelid::regclass AS seq, d.refobjid::regclass AS tbl, quote_ident(a.attname) AS col
+    FROM pg_sequence s
+    JOIN pg_depend d ON d.classid = 'pg_class'::regclass AND d.objid = s.seqrelid
+      AND d.refclassid = 'pg_class'::regclass AND d.deptype IN ('a', 'i')
+    JOIN pg_attribute a ON a.attrelid = d.refobjid AND a.attnum = d.refobjsubid
+    WHERE s.seqincrement > 0
+      AND has_sequence_privilege(s.seqrelid, 'SELECT,UPDATE')
+      AND has_table_privilege(d.refobjid, 'SELECT')
+  LOOP
+    EXECUTE format(
+      'SELECT setval(%L, max(%s)) FROM %s HAVING max(%s) >= (SELECT CASE WHEN is_called THEN last_value + 1 ELSE last_value END FROM %s)',
+      r.seq, r.col, r.tbl, r.col, r.seq
+    );
+  END LOOP;
+END
+$$`
+
+// ResetSequences advances every sequence owned by a serial or identity column past the
+// largest value in that column, which seed data with explicit ids leaves behind.
+func ResetSequences(ctx context.Context, conn *pgx.Conn) error {
+	fmt.Fprintln(utils.GetStatusWriter(), "Resetting sequences...")
+	if _, err := conn.Exec(ctx, RESET_SEQUENCES); err != nil {
+		return errors.Errorf("failed to reset sequences: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package apply
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Seeds tables declared under [[seed.tables]] in config, using the COPY protocol which
+// is much faster than inserting rows individually.
+func SeedTables(ctx context.Context, conn *pgx.Conn, tables []utils.SeedTable, fsys afero.Fs) error {
+	for _, t := range tables {
+		path := filepath.Join(utils.SupabaseDirPath, t.File)
+		fmt.Fprintln(utils.GetStatusWriter(), "Seeding table "+utils.Bold(t.Table)+" from "+utils.Bold(path)+"...")
+		contents, err := afero.ReadFile(fsys, path)
+		if err != nil {
+			return errors.Errorf("failed to read seed file: %w", err)
+		}
+		var columns []string
+		var data []byte
+		var format string
+		switch filepath.Ext(path) {
+		case ".json":
+			columns, data, err = encodeJson(contents)
+			format = "text"
+		default:
+			columns, data, err = splitCsvHeader(contents)
+			format = "csv"
+		}
+		if err != nil {
+			return errors.Errorf("failed to parse %s: %w", path, err)
+		}
+		if len(columns) == 0 {
+			continue
+		}
+		sql := fmt.Sprintf("COPY %s (%s) FROM STDIN WITH (FORMAT %s)", quoteTable(t.Table), quoteColumns(columns), format)
+		if _, err := conn.PgConn().CopyFrom(ctx, bytes.NewReader(data), sql); err != nil {
+			return errors.Errorf("failed to seed table %s: %w", t.Table, err)
+		}
+	}
+	return nil
+}
+
+func quoteTable(table string) string {
+	return pgx.Identifier(strings.Split(table, ".")).Sanitize()
+}
+
+func quoteColumns(columns []string) string {
+	quoted := make([]string, len(columns))
+	for i, c := range columns {
+		quoted[i] = pgx.Identifier{c}.Sanitize()
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// Returns the column names from the header row, and the remaining rows which are
+// streamed as is so that Postgres can tell quoted empty strings from nulls.
+func splitCsvHeader(contents []byte) ([]string, []byte, error) {
+	r := csv.NewReader(bytes.NewReader(contents))
+	header, err := r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	return header, contents[r.InputOffset():], nil
+}
+
+// Converts an array of JSON objects to COPY text format, with columns taken from the
+// union of all object keys. Missing keys and null values are both loaded as null.
+func encodeJson(contents []byte) ([]string, []byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(contents))
+	dec.UseNumber()
+	var rows []map[string]any
+	if err := dec.Decode(&rows); err != nil {
+		return nil, nil, err
+	}
+	keys := map[string]struct{}{}
+	for _, r := range rows {
+		for k := range r {
+			keys[k] = struct{}{}
+		}
+	}
+	columns := make([]string, 0, len(keys))
+	for k := range keys {
+		columns = append(columns, k)
+	}
+	sort.Strings(columns)
+	var buf bytes.Buffer
+	for _, r := range rows {
+		for i, c := range columns {
+			if i > 0 {
+				buf.WriteByte('\t')
+			}
+			value, err := encodeValue(r[c])
+			if err != nil {
+				return nil, nil, err
+			}
+			buf.WriteString(value)
+		}
+		buf.WriteByte('\n')
+	}
+	return columns, buf.Bytes(), nil
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+func encodeValue(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return `\N`, nil
+	case string:
+		return textEscaper.Replace(v), nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return fmt.Sprintf("%t", v), nil
+	}
+	// Nested objects and arrays are loaded as json
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", errors.Errorf("failed to encode value: %w", err)
+	}
+	return textEscaper.Replace(string(encoded)), nil
+}
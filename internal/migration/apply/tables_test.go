@@ -0,0 +1,79 @@
+package apply
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestSeedTables(t *testing.T) {
+	t.Run("throws error on missing file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		tables := []utils.SeedTable{{Table: "public.cities", File: "seeds/cities.csv"}}
+		// Run test
+		err := SeedTables(context.Background(), nil, tables, fsys)
+		// Check error
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("throws error on invalid json", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.SupabaseDirPath, "seeds", "cities.json")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(`{"name": "Paris"}`), 0644))
+		tables := []utils.SeedTable{{Table: "public.cities", File: "seeds/cities.json"}}
+		// Run test
+		err := SeedTables(context.Background(), nil, tables, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to parse "+path)
+	})
+
+	t.Run("skips empty file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.SupabaseDirPath, "seeds", "cities.csv")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte{}, 0644))
+		tables := []utils.SeedTable{{Table: "public.cities", File: "seeds/cities.csv"}}
+		// Run test
+		err := SeedTables(context.Background(), nil, tables, fsys)
+		// Check error
+		assert.NoError(t, err)
+	})
+}
+
+func TestEncodeRows(t *testing.T) {
+	t.Run("splits csv header", func(t *testing.T) {
+		contents := "id,\"city name\"\n1,\"Paris, France\"\n2,\n"
+		// Run test
+		columns, data, err := splitCsvHeader([]byte(contents))
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"id", "city name"}, columns)
+		assert.Equal(t, "1,\"Paris, France\"\n2,\n", string(data))
+	})
+
+	t.Run("encodes json as text format", func(t *testing.T) {
+		contents := `[
+			{"id": 1, "name": "Paris\tFrance", "tags": ["capital"]},
+			{"id": 2, "active": true, "name": null}
+		]`
+		// Run test
+		columns, data, err := encodeJson([]byte(contents))
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"active", "id", "name", "tags"}, columns)
+		assert.Equal(t, "\\N\t1\tParis\\tFrance\t[\"capital\"]\ntrue\t2\t\\N\t\\N\n", string(data))
+	})
+
+	t.Run("quotes table identifiers", func(t *testing.T) {
+		assert.Equal(t, `"public"."cities"`, quoteTable("public.cities"))
+		assert.Equal(t, `"id", "city name"`, quoteColumns([]string{"id", "city name"}))
+	})
+}
@@ -0,0 +1,198 @@
+package capture
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/history"
+	"github.com/supabase/cli/internal/migration/new"
+	"github.com/supabase/cli/internal/migration/repair"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/parser"
+)
+
+const (
+	// Changes made by migrations share a transaction with the insert into history table
+	SELECT_PENDING_CHANGES = `SELECT id, statement FROM supabase_migrations.pending_changes p
+WHERE NOT p.schemas <@ $1
+AND NOT EXISTS (
+  SELECT 1 FROM supabase_migrations.schema_migrations m WHERE m.xmin::text::bigint = p.txid % 4294967296
+)
+ORDER BY id`
+	DELETE_PENDING_CHANGES = "DELETE FROM supabase_migrations.pending_changes WHERE id <= $1"
+)
+
+var (
+	//go:embed templates/capture.sql
+	captureSchema string
+
+	// Used by unit tests
+	getCurrentTimestamp = utils.GetCurrentTimestamp
+
+	ErrNotEnabled = errors.New("Change capture is not enabled. Set enabled = true under [db.capture] in config.toml and run supabase db reset.")
+)
+
+type pendingChange struct {
+	Id        int64
+	Statement string
+}
+
+// Setup installs the event triggers that record schema changes on the local database,
+// if change capture is enabled in config.
+func Setup(ctx context.Context, conn *pgx.Conn) error {
+	if !utils.Config.Db.Capture.Enabled {
+		return nil
+	}
+	fmt.Fprintln(os.Stderr, "Enabling change capture...")
+	// History table must exist for changes made by migrations to be excluded
+	if err := history.CreateMigrationTable(ctx, conn); err != nil {
+		return err
+	}
+	migration, err := repair.NewMigrationFromReader(strings.NewReader(captureSchema))
+	if err != nil {
+		return err
+	}
+	return migration.ExecBatch(ctx, conn)
+}
+
+// Run writes schema changes recorded on the local database since the last capture to a
+// new migration file, and marks it as applied so that it is not run again locally.
+func Run(ctx context.Context, name string, dryRun bool, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	if !utils.Config.Db.Capture.Enabled {
+		return errors.New(ErrNotEnabled)
+	}
+	conn, err := utils.ConnectLocalPostgres(ctx, pgconn.Config{}, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	changes, err := listPendingChanges(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		fmt.Fprintln(os.Stderr, "No schema changes to capture.")
+		return nil
+	}
+	sql, err := toMigration(changes)
+	if err != nil {
+		return err
+	}
+	if len(sql) == 0 {
+		fmt.Fprintln(os.Stderr, "No schema changes to capture.")
+		return nil
+	}
+	if dryRun {
+		fmt.Print(sql)
+		return nil
+	}
+	if len(name) == 0 {
+		name = "captured_changes"
+	}
+	path := new.GetMigrationPath(getCurrentTimestamp(), name)
+	if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(path)); err != nil {
+		return err
+	}
+	if err := afero.WriteFile(fsys, path, []byte(sql), 0644); err != nil {
+		return errors.Errorf("failed to write migration file: %w", err)
+	}
+	// Changes already exist on the local database, so only the history is updated
+	migration, err := repair.NewMigrationFromFile(path, fsys)
+	if err != nil {
+		return err
+	}
+	if err := migration.InsertVersion(ctx, conn); err != nil {
+		return err
+	}
+	last := changes[len(changes)-1].Id
+	if _, err := conn.Exec(ctx, DELETE_PENDING_CHANGES, last); err != nil {
+		return errors.Errorf("failed to clear pending changes: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Captured %d schema changes to %s\n", len(changes), utils.Bold(path))
+	return nil
+}
+
+func listPendingChanges(ctx context.Context, conn *pgx.Conn) ([]pendingChange, error) {
+	changes, err := queryPendingChanges(ctx, conn)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UndefinedTable {
+			// Change log is only created when the local database is started with capture enabled
+			return nil, errors.New(ErrNotEnabled)
+		}
+	}
+	return changes, err
+}
+
+func queryPendingChanges(ctx context.Context, conn *pgx.Conn) ([]pendingChange, error) {
+	rows, err := conn.Query(ctx, SELECT_PENDING_CHANGES, utils.InternalSchemas)
+	if err != nil {
+		return nil, errors.Errorf("failed to query rows: %w", err)
+	}
+	defer rows.Close()
+	var result []pendingChange
+	for rows.Next() {
+		var c pendingChange
+		if err := rows.Scan(&c.Id, &c.Statement); err != nil {
+			return nil, errors.Errorf("failed to scan rows: %w", err)
+		}
+		result = append(result, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Errorf("failed to parse rows: %w", err)
+	}
+	return result, nil
+}
+
+// Only schema changes are written because a captured query may contain other statements,
+// such as inserts into the new table, that were executed in the same round trip.
+func toMigration(changes []pendingChange) (string, error) {
+	var sql strings.Builder
+	for _, c := range changes {
+		lines, err := parser.SplitAndTrim(strings.NewReader(c.Statement))
+		if err != nil {
+			return "", err
+		}
+		for _, line := range lines {
+			if !isSchemaChange(line) {
+				fmt.Fprintln(os.Stderr, "Skipping statement that is not a schema change:", line)
+				continue
+			}
+			sql.WriteString(line)
+			sql.WriteString(";\n\n")
+		}
+	}
+	return sql.String(), nil
+}
+
+var ddlKeywords = []string{"CREATE", "ALTER", "DROP", "COMMENT", "GRANT", "REVOKE"}
+
+func isSchemaChange(stat string) bool {
+	// Skip leading line comments to find the command keyword
+	for strings.HasPrefix(stat, "--") {
+		_, rest, _ := strings.Cut(stat, "\n")
+		stat = strings.TrimSpace(rest)
+	}
+	fields := strings.Fields(stat)
+	if len(fields) == 0 {
+		return false
+	}
+	for _, k := range ddlKeywords {
+		if strings.EqualFold(fields[0], k) {
+			return true
+		}
+	}
+	return false
+}
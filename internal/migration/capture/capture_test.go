@@ -0,0 +1,172 @@
+package capture
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/migration/history"
+	"github.com/supabase/cli/internal/testing/pgtest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func writeConfig(t *testing.T, fsys afero.Fs) {
+	require.NoError(t, utils.WriteConfig(fsys, false))
+	config, err := afero.ReadFile(fsys, utils.ConfigPath)
+	require.NoError(t, err)
+	config = bytes.Replace(config, []byte("[db.capture]\nenabled = false"), []byte("[db.capture]\nenabled = true"), 1)
+	require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, config, 0644))
+}
+
+func TestCaptureCommand(t *testing.T) {
+	getCurrentTimestamp = func() string { return "20240101000000" }
+
+	t.Run("writes pending changes to migration", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		writeConfig(t, fsys)
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(SELECT_PENDING_CHANGES, utils.InternalSchemas).
+			Reply("SELECT 2",
+				[]interface{}{int64(1), "create table public.todos (id bigint primary key)"},
+				[]interface{}{int64(3), "\nalter table public.todos enable row level security;\n"},
+			)
+		lines := []string{
+			"create table public.todos (id bigint primary key)",
+			"alter table public.todos enable row level security",
+		}
+		conn.Query(history.INSERT_MIGRATION_VERSION, "20240101000000", "add_todos", lines, history.Checksum(lines)).
+			Reply("INSERT 0 1").
+			Query(DELETE_PENDING_CHANGES, int64(3)).
+			Reply("DELETE 3")
+		// Run test
+		err := Run(context.Background(), "add_todos", false, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		contents, err := afero.ReadFile(fsys, filepath.Join(utils.MigrationsDir, "20240101000000_add_todos.sql"))
+		assert.NoError(t, err)
+		assert.Equal(t, "create table public.todos (id bigint primary key);\n\nalter table public.todos enable row level security;\n\n", string(contents))
+	})
+
+	t.Run("skips data statements in captured query", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		writeConfig(t, fsys)
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(SELECT_PENDING_CHANGES, utils.InternalSchemas).
+			Reply("SELECT 1",
+				[]interface{}{int64(2), "create table public.todos (id bigint primary key);\ninsert into public.todos values (1);\n-- enable rls\nalter table public.todos enable row level security;"},
+			)
+		lines := []string{
+			"create table public.todos (id bigint primary key)",
+			"-- enable rls\nalter table public.todos enable row level security",
+		}
+		conn.Query(history.INSERT_MIGRATION_VERSION, "20240101000000", "add_todos", lines, history.Checksum(lines)).
+			Reply("INSERT 0 1").
+			Query(DELETE_PENDING_CHANGES, int64(2)).
+			Reply("DELETE 1")
+		// Run test
+		err := Run(context.Background(), "add_todos", false, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		contents, err := afero.ReadFile(fsys, filepath.Join(utils.MigrationsDir, "20240101000000_add_todos.sql"))
+		assert.NoError(t, err)
+		assert.NotContains(t, string(contents), "insert into")
+	})
+
+	t.Run("skips query without schema changes", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		writeConfig(t, fsys)
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(SELECT_PENDING_CHANGES, utils.InternalSchemas).
+			Reply("SELECT 1", []interface{}{int64(1), "select 1"})
+		// Run test
+		err := Run(context.Background(), "", false, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		exists, err := afero.DirExists(fsys, utils.MigrationsDir)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("prints changes on dry run", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		writeConfig(t, fsys)
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(SELECT_PENDING_CHANGES, utils.InternalSchemas).
+			Reply("SELECT 1", []interface{}{int64(1), "create schema private"})
+		// Run test
+		err := Run(context.Background(), "", true, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		exists, err := afero.DirExists(fsys, utils.MigrationsDir)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("skips empty changes", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		writeConfig(t, fsys)
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(SELECT_PENDING_CHANGES, utils.InternalSchemas).
+			Reply("SELECT 0")
+		// Run test
+		err := Run(context.Background(), "", false, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		exists, err := afero.DirExists(fsys, utils.MigrationsDir)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("throws error on missing change log", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		writeConfig(t, fsys)
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(SELECT_PENDING_CHANGES, utils.InternalSchemas).
+			ReplyError(pgerrcode.UndefinedTable, `relation "supabase_migrations.pending_changes" does not exist`)
+		// Run test
+		err := Run(context.Background(), "", false, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorIs(t, err, ErrNotEnabled)
+	})
+
+	t.Run("throws error when disabled", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Run test
+		err := Run(context.Background(), "", false, fsys)
+		// Check error
+		assert.ErrorIs(t, err, ErrNotEnabled)
+	})
+
+	t.Run("throws error on missing config", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), "", false, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -0,0 +1,69 @@
+CREATE SCHEMA IF NOT EXISTS supabase_migrations;
+
+CREATE TABLE IF NOT EXISTS supabase_migrations.pending_changes (
+  id bigserial PRIMARY KEY,
+  txid bigint NOT NULL DEFAULT txid_current(),
+  schemas text[] NOT NULL,
+  statement text NOT NULL,
+  captured_at timestamptz NOT NULL DEFAULT now(),
+  UNIQUE (txid, statement)
+);
+
+-- Records the top level query of each schema change, along with the schemas it touched.
+-- Objects created or altered by extension scripts are left to the extension itself.
+CREATE OR REPLACE FUNCTION supabase_migrations.capture_changes()
+RETURNS event_trigger
+LANGUAGE plpgsql
+AS $$
+DECLARE
+  touched text[];
+BEGIN
+  IF TG_EVENT = 'sql_drop' THEN
+    SELECT array_agg(DISTINCT coalesce(schema_name, object_identity)) INTO touched
+    FROM pg_event_trigger_dropped_objects()
+    WHERE original;
+  ELSE
+    SELECT array_agg(DISTINCT coalesce(schema_name, object_identity)) INTO touched
+    FROM pg_event_trigger_ddl_commands()
+    WHERE NOT in_extension;
+  END IF;
+  -- Drops are reported by sql_drop before ddl_command_end fires with no commands
+  IF touched IS NULL THEN
+    RETURN;
+  END IF;
+  INSERT INTO supabase_migrations.pending_changes (schemas, statement)
+  VALUES (touched, current_query())
+  ON CONFLICT (txid, statement) DO UPDATE
+  SET schemas = pending_changes.schemas || excluded.schemas;
+END
+$$;
+
+DROP EVENT TRIGGER IF EXISTS supabase_capture_ddl;
+CREATE EVENT TRIGGER supabase_capture_ddl ON ddl_command_end
+WHEN TAG IN (
+  'CREATE SCHEMA', 'ALTER SCHEMA',
+  'CREATE TABLE', 'CREATE TABLE AS', 'ALTER TABLE',
+  'CREATE VIEW', 'ALTER VIEW',
+  'CREATE MATERIALIZED VIEW', 'ALTER MATERIALIZED VIEW',
+  'CREATE INDEX', 'ALTER INDEX',
+  'CREATE SEQUENCE', 'ALTER SEQUENCE',
+  'CREATE FUNCTION', 'ALTER FUNCTION',
+  'CREATE PROCEDURE', 'ALTER PROCEDURE',
+  'CREATE AGGREGATE', 'ALTER AGGREGATE',
+  'CREATE TRIGGER', 'ALTER TRIGGER',
+  'CREATE TYPE', 'ALTER TYPE',
+  'CREATE DOMAIN', 'ALTER DOMAIN',
+  'CREATE POLICY', 'ALTER POLICY',
+  'CREATE EXTENSION', 'ALTER EXTENSION',
+  'COMMENT', 'GRANT', 'REVOKE', 'ALTER DEFAULT PRIVILEGES'
+)
+EXECUTE PROCEDURE supabase_migrations.capture_changes();
+
+DROP EVENT TRIGGER IF EXISTS supabase_capture_drop;
+CREATE EVENT TRIGGER supabase_capture_drop ON sql_drop
+WHEN TAG IN (
+  'DROP SCHEMA', 'DROP TABLE', 'ALTER TABLE', 'DROP VIEW', 'DROP MATERIALIZED VIEW',
+  'DROP INDEX', 'DROP SEQUENCE', 'DROP FUNCTION', 'DROP PROCEDURE', 'DROP AGGREGATE',
+  'DROP TRIGGER', 'DROP TYPE', 'DROP DOMAIN', 'DROP POLICY', 'DROP EXTENSION'
+)
+EXECUTE PROCEDURE supabase_migrations.capture_changes();
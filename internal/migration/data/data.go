@@ -0,0 +1,61 @@
+package data
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/repair"
+	"github.com/supabase/cli/internal/tools"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Connection string of the target database exposed to data migration scripts
+const EnvDbUrl = "SUPABASE_DB_URL"
+
+// Run executes a data migration script with Deno against the database of conn, then
+// records it in the migration history table. The script runs outside of any transaction
+// on conn, so it should be safe to retry if recording fails.
+func Run(ctx context.Context, conn *pgx.Conn, path string, fsys afero.Fs) error {
+	migration, err := repair.NewMigrationFromFile(path, fsys)
+	if err != nil {
+		return err
+	}
+	if err := runScript(ctx, path, utils.ToPostgresURL(conn.Config().Config), fsys); err != nil {
+		return err
+	}
+	return migration.InsertVersion(ctx, conn)
+}
+
+func runScript(ctx context.Context, path, dbUrl string, fsys afero.Fs) error {
+	denoPath, err := tools.EnsureDeno(ctx, fsys)
+	if err != nil {
+		return err
+	}
+	migrationsDir, err := filepath.Abs(utils.MigrationsDir)
+	if err != nil {
+		return errors.Errorf("failed to resolve migrations directory: %w", err)
+	}
+	// Scripts may only connect to the network, read the target database url, and read
+	// files in the migrations directory
+	args := []string{
+		"run",
+		"--no-prompt",
+		"--allow-net",
+		"--allow-env=" + EnvDbUrl,
+		"--allow-read=" + migrationsDir,
+		path,
+	}
+	cmd := exec.CommandContext(ctx, denoPath, args...)
+	cmd.Env = append(os.Environ(), EnvDbUrl+"="+dbUrl)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return errors.Errorf("failed to run data migration %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/migration/history"
+	"github.com/supabase/cli/internal/testing/pgtest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestMain(m *testing.M) {
+	// Setup fake deno binary
+	if len(os.Args) > 1 && os.Args[1] == "upgrade" {
+		os.Exit(0)
+	}
+	if len(os.Args) > 1 && os.Args[1] == "run" {
+		if len(os.Getenv(EnvDbUrl)) == 0 {
+			fmt.Fprintln(os.Stderr, "missing database url")
+			os.Exit(1)
+		}
+		if msg := os.Getenv("TEST_DENO_ERROR"); msg != "" {
+			fmt.Fprintln(os.Stderr, msg)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	denoPath, err := os.Executable()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	utils.DenoPathOverride = denoPath
+	// Run test suite
+	os.Exit(m.Run())
+}
+
+func TestRunDataMigration(t *testing.T) {
+	script := `console.log(Deno.env.get("SUPABASE_DB_URL"))`
+	path := filepath.Join(utils.MigrationsDir, "20240101000000_backfill.ts")
+
+	t.Run("runs script and records history", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(script), 0644))
+		_, err := fsys.Create(utils.DenoPathOverride)
+		require.NoError(t, err)
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(history.INSERT_MIGRATION_VERSION, "20240101000000", "backfill", []string{script}, history.Checksum([]string{script})).
+			Reply("INSERT 0 1")
+		// Connect to mock
+		ctx := context.Background()
+		mock, err := utils.ConnectLocalPostgres(ctx, pgconn.Config{Port: 5432}, conn.Intercept)
+		require.NoError(t, err)
+		defer mock.Close(ctx)
+		// Run test
+		err = Run(ctx, mock, path, fsys)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on script failure", func(t *testing.T) {
+		t.Setenv("TEST_DENO_ERROR", "Uncaught Error: connection refused")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(script), 0644))
+		_, err := fsys.Create(utils.DenoPathOverride)
+		require.NoError(t, err)
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		// Connect to mock
+		ctx := context.Background()
+		mock, err := utils.ConnectLocalPostgres(ctx, pgconn.Config{Port: 5432}, conn.Intercept)
+		require.NoError(t, err)
+		defer mock.Close(ctx)
+		// Run test
+		err = Run(ctx, mock, path, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to run data migration")
+	})
+
+	t.Run("throws error on history failure", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(script), 0644))
+		_, err := fsys.Create(utils.DenoPathOverride)
+		require.NoError(t, err)
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(history.INSERT_MIGRATION_VERSION, "20240101000000", "backfill", []string{script}, history.Checksum([]string{script})).
+			ReplyError(pgerrcode.UniqueViolation, `duplicate key value violates unique constraint "schema_migrations_pkey"`)
+		// Connect to mock
+		ctx := context.Background()
+		mock, err := utils.ConnectLocalPostgres(ctx, pgconn.Config{Port: 5432}, conn.Intercept)
+		require.NoError(t, err)
+		defer mock.Close(ctx)
+		// Run test
+		err = Run(ctx, mock, path, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to update migration history")
+	})
+
+	t.Run("throws error on missing file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		// Connect to mock
+		ctx := context.Background()
+		mock, err := utils.ConnectLocalPostgres(ctx, pgconn.Config{Port: 5432}, conn.Intercept)
+		require.NoError(t, err)
+		defer mock.Close(ctx)
+		// Run test
+		err = Run(ctx, mock, path, fsys)
+		// Check error
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -0,0 +1,151 @@
+package down
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/history"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/migration/repair"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/parser"
+)
+
+var ErrMissingDown = errors.New("down migration not found")
+
+func Run(ctx context.Context, count uint, target string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if len(target) > 0 {
+		if _, err := strconv.Atoi(target); err != nil {
+			return errors.Errorf("failed to parse %s: %w", target, repair.ErrInvalidVersion)
+		}
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	remoteVersions, err := list.LoadRemoteMigrations(ctx, conn)
+	if err != nil {
+		return err
+	}
+	versions := GetRevertVersions(remoteVersions, count, target)
+	if len(versions) == 0 {
+		fmt.Fprintln(os.Stderr, "No migrations to revert.")
+		return nil
+	}
+	// Load all down migrations upfront so that a missing script does not leave a partial revert
+	var migrations []*repair.MigrationFile
+	for _, v := range versions {
+		m, err := NewDownMigration(v, fsys)
+		if err != nil {
+			return err
+		}
+		migrations = append(migrations, m)
+	}
+	msg := "Do you want to revert the following migrations?\n • " + strings.Join(versions, "\n • ") + "\n"
+	if shouldRevert, err := utils.NewConsole().PromptYesNo(ctx, msg, false); err != nil {
+		return err
+	} else if !shouldRevert {
+		return errors.New(context.Canceled)
+	}
+	for _, m := range migrations {
+		fmt.Fprintln(utils.GetStatusWriter(), "Reverting migration "+utils.Bold(m.Version)+"...")
+		if err := revertMigration(ctx, conn, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns remote versions to revert in reverse chronological order, either all versions
+// after target or the latest count versions.
+func GetRevertVersions(remoteVersions []string, count uint, target string) []string {
+	var result []string
+	for i := len(remoteVersions) - 1; i >= 0; i-- {
+		if len(target) > 0 {
+			if remoteVersions[i] <= target {
+				break
+			}
+		} else if uint(len(result)) >= count {
+			break
+		}
+		result = append(result, remoteVersions[i])
+	}
+	return result
+}
+
+// Loads the revert script from `<version>_<name>.down.sql`, falling back to the
+// `-- supabase:down` section of the migration file itself.
+func NewDownMigration(version string, fsys afero.Fs) (*repair.MigrationFile, error) {
+	pattern := filepath.Join(utils.MigrationsDir, version+"_*.down.sql")
+	matches, err := afero.Glob(fsys, pattern)
+	if err != nil {
+		return nil, errors.Errorf("failed to glob migration files: %w", err)
+	}
+	var down []byte
+	if len(matches) > 0 {
+		if down, err = repair.ReadMigrationFile(matches[0], fsys); err != nil {
+			return nil, err
+		}
+	} else {
+		path, err := repair.GetMigrationFile(version, fsys)
+		if err != nil {
+			return nil, err
+		}
+		if utils.DataFilePattern.MatchString(filepath.Base(path)) {
+			// Data migrations are scripts, so their revert must be a separate SQL file
+			utils.CmdSuggestion = fmt.Sprintf("Create %s to revert this data migration.", utils.Bold(strings.TrimSuffix(path, ".ts")+".down.sql"))
+			return nil, errors.Errorf("%w: %s", ErrMissingDown, version)
+		}
+		contents, err := repair.ReadMigrationFile(path, fsys)
+		if err != nil {
+			return nil, err
+		}
+		var found bool
+		if _, down, found = parser.CutDownSection(contents); !found {
+			utils.CmdSuggestion = fmt.Sprintf("Add a %s section to %s, or create %s.", utils.Aqua("-- supabase:down"), utils.Bold(path), utils.Bold(strings.TrimSuffix(path, ".sql")+".down.sql"))
+			return nil, errors.Errorf("%w: %s", ErrMissingDown, version)
+		}
+	}
+	m, err := repair.NewMigrationFromReader(bytes.NewReader(down))
+	if err != nil {
+		return nil, err
+	}
+	m.Version = version
+	return m, nil
+}
+
+func revertMigration(ctx context.Context, conn *pgx.Conn, m *repair.MigrationFile) error {
+	// Batch revert commands, without using statement cache
+	batch := &pgconn.Batch{}
+	for _, line := range m.Lines {
+		batch.ExecParams(line, nil, nil, nil, nil)
+	}
+	batch.ExecParams(
+		history.DELETE_MIGRATION_ROW,
+		[][]byte{[]byte(m.Version)},
+		[]uint32{pgtype.TextOID},
+		[]int16{pgtype.TextFormatCode},
+		nil,
+	)
+	// ExecBatch is implicitly transactional
+	if result, err := conn.PgConn().ExecBatch(ctx, batch).ReadAll(); err != nil {
+		stat := history.DELETE_MIGRATION_ROW
+		i := len(result)
+		if i < len(m.Lines) {
+			stat = m.Lines[i]
+		}
+		return errors.Errorf("%w\nAt statement %d: %s", err, i, stat)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package down
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/migration/history"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/migration/repair"
+	"github.com/supabase/cli/internal/testing/fstest"
+	"github.com/supabase/cli/internal/testing/pgtest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestMigrationDown(t *testing.T) {
+	t.Run("reverts latest migration", func(t *testing.T) {
+		defer fstest.MockStdin(t, "y")()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "1_test.down.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte("drop schema test"), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(list.LIST_MIGRATION_VERSION).
+			Reply("SELECT 2", []interface{}{"0"}, []interface{}{"1"})
+		conn.Query("drop schema test").
+			Reply("DROP SCHEMA").
+			Query(history.DELETE_MIGRATION_ROW, "1").
+			Reply("DELETE 1")
+		// Run test
+		err := Run(context.Background(), 1, "", dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("ignores empty history", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(list.LIST_MIGRATION_VERSION).
+			Reply("SELECT 0")
+		// Run test
+		err := Run(context.Background(), 1, "", dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on invalid version", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), 0, "invalid", dbConfig, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorIs(t, err, repair.ErrInvalidVersion)
+	})
+
+	t.Run("throws error on missing down migration", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "0_test.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte("create schema test"), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(list.LIST_MIGRATION_VERSION).
+			Reply("SELECT 1", []interface{}{"0"})
+		// Run test
+		err := Run(context.Background(), 1, "", dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorIs(t, err, ErrMissingDown)
+	})
+
+	t.Run("throws error on revert failure", func(t *testing.T) {
+		defer fstest.MockStdin(t, "y")()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "0_test.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte("create schema test;\n-- supabase:down\ndrop schema test;\n"), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(list.LIST_MIGRATION_VERSION).
+			Reply("SELECT 1", []interface{}{"0"})
+		conn.Query("drop schema test").
+			ReplyError(pgerrcode.InvalidSchemaName, `schema "test" does not exist`).
+			Query(history.DELETE_MIGRATION_ROW, "0").
+			Reply("DELETE 1")
+		// Run test
+		err := Run(context.Background(), 1, "", dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, `ERROR: schema "test" does not exist (SQLSTATE 3F000)`)
+		assert.ErrorContains(t, err, "At statement 0: drop schema test")
+	})
+}
+
+func TestRevertVersions(t *testing.T) {
+	remote := []string{"20240101000000", "20240102000000", "20240103000000"}
+
+	t.Run("reverts latest count", func(t *testing.T) {
+		versions := GetRevertVersions(remote, 2, "")
+		assert.Equal(t, []string{"20240103000000", "20240102000000"}, versions)
+	})
+
+	t.Run("reverts to target version", func(t *testing.T) {
+		versions := GetRevertVersions(remote, 1, "20240101000000")
+		assert.Equal(t, []string{"20240103000000", "20240102000000"}, versions)
+	})
+
+	t.Run("reverts nothing on latest target", func(t *testing.T) {
+		versions := GetRevertVersions(remote, 1, "20240103000000")
+		assert.Empty(t, versions)
+	})
+}
+
+func TestDownMigration(t *testing.T) {
+	t.Run("prefers down file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "0_test.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte("create schema a;\n-- supabase:down\ndrop schema a;\n"), 0644))
+		path = filepath.Join(utils.MigrationsDir, "0_test.down.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte("drop schema b;"), 0644))
+		// Run test
+		m, err := NewDownMigration("0", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "0", m.Version)
+		assert.Equal(t, []string{"drop schema b"}, m.Lines)
+	})
+
+	t.Run("loads down section", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "0_test.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte("create schema a;\n-- supabase:down\ndrop schema a;\n"), 0644))
+		// Run test
+		m, err := NewDownMigration("0", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"drop schema a"}, m.Lines)
+	})
+
+	t.Run("throws error on data migration without down file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "0_test.ts")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte("// -- supabase:down\n"), 0644))
+		// Run test
+		_, err := NewDownMigration("0", fsys)
+		// Check error
+		assert.ErrorIs(t, err, ErrMissingDown)
+		assert.NotContains(t, utils.CmdSuggestion, "supabase:down")
+	})
+
+	t.Run("throws error on missing file", func(t *testing.T) {
+		// Run test
+		_, err := NewDownMigration("0", afero.NewMemMapFs())
+		// Check error
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
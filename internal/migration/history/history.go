@@ -2,6 +2,8 @@ package history
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 
 	"github.com/go-errors/errors"
 	"github.com/jackc/pgconn"
@@ -15,11 +17,15 @@ const (
 	CREATE_VERSION_TABLE     = "CREATE TABLE IF NOT EXISTS supabase_migrations.schema_migrations (version text NOT NULL PRIMARY KEY)"
 	ADD_STATEMENTS_COLUMN    = "ALTER TABLE supabase_migrations.schema_migrations ADD COLUMN IF NOT EXISTS statements text[]"
 	ADD_NAME_COLUMN          = "ALTER TABLE supabase_migrations.schema_migrations ADD COLUMN IF NOT EXISTS name text"
-	INSERT_MIGRATION_VERSION = "INSERT INTO supabase_migrations.schema_migrations(version, name, statements) VALUES($1, $2, $3)"
+	ADD_CREATED_AT_COLUMN    = "ALTER TABLE supabase_migrations.schema_migrations ADD COLUMN IF NOT EXISTS created_at timestamptz"
+	SET_CREATED_AT_DEFAULT   = "ALTER TABLE supabase_migrations.schema_migrations ALTER COLUMN created_at SET DEFAULT now()"
+	ADD_CHECKSUM_COLUMN      = "ALTER TABLE supabase_migrations.schema_migrations ADD COLUMN IF NOT EXISTS checksum text"
+	INSERT_MIGRATION_VERSION = "INSERT INTO supabase_migrations.schema_migrations(version, name, statements, checksum) VALUES($1, $2, $3, $4)"
 	DELETE_MIGRATION_VERSION = "DELETE FROM supabase_migrations.schema_migrations WHERE version = ANY($1)"
+	DELETE_MIGRATION_ROW     = "DELETE FROM supabase_migrations.schema_migrations WHERE version = $1"
 	DELETE_MIGRATION_BEFORE  = "DELETE FROM supabase_migrations.schema_migrations WHERE version <= $1"
 	TRUNCATE_VERSION_TABLE   = "TRUNCATE supabase_migrations.schema_migrations"
-	SELECT_VERSION_TABLE     = "SELECT * FROM supabase_migrations.schema_migrations"
+	SELECT_VERSION_TABLE     = "SELECT version, name, statements FROM supabase_migrations.schema_migrations"
 )
 
 type SchemaMigration struct {
@@ -37,6 +43,10 @@ func CreateMigrationTable(ctx context.Context, conn *pgx.Conn) error {
 	batch.ExecParams(CREATE_VERSION_TABLE, nil, nil, nil, nil)
 	batch.ExecParams(ADD_STATEMENTS_COLUMN, nil, nil, nil, nil)
 	batch.ExecParams(ADD_NAME_COLUMN, nil, nil, nil, nil)
+	// Default is set separately so that existing rows are not stamped with the current time
+	batch.ExecParams(ADD_CREATED_AT_COLUMN, nil, nil, nil, nil)
+	batch.ExecParams(SET_CREATED_AT_DEFAULT, nil, nil, nil, nil)
+	batch.ExecParams(ADD_CHECKSUM_COLUMN, nil, nil, nil, nil)
 	if _, err := conn.PgConn().ExecBatch(ctx, &batch).ReadAll(); err != nil {
 		return errors.Errorf("failed to create migration table: %w", err)
 	}
@@ -50,3 +60,14 @@ func ReadMigrationTable(ctx context.Context, conn *pgx.Conn) ([]SchemaMigration,
 	}
 	return pgxv5.CollectRows[SchemaMigration](rows)
 }
+
+// Checksum identifies the contents of a migration by its parsed statements,
+// so that whitespace around statements does not count as a change.
+func Checksum(statements []string) string {
+	h := sha256.New()
+	for _, s := range statements {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,224 @@
+package list
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/migration/history"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/parser"
+)
+
+// Columns are read through jsonb so that history tables created by older CLI
+// versions, which lack some of the columns, can still be listed.
+const LIST_MIGRATION_HISTORY = `SELECT m->>'version', coalesce(m->>'name', ''), coalesce(m->'statements', '[]')::text, coalesce(m->>'created_at', ''), coalesce(m->>'checksum', '')
+FROM supabase_migrations.schema_migrations s, to_jsonb(s) m
+ORDER BY m->>'version'`
+
+const (
+	StatusLocal   = "local"
+	StatusRemote  = "remote"
+	StatusSynced  = "synced"
+	StatusChanged = "changed"
+)
+
+type Migration struct {
+	Version        string     `json:"version"`
+	Name           string     `json:"name"`
+	Local          bool       `json:"local"`
+	Remote         bool       `json:"remote"`
+	Status         string     `json:"status"`
+	AppliedAt      *time.Time `json:"applied_at,omitempty"`
+	LocalChecksum  string     `json:"local_checksum,omitempty"`
+	RemoteChecksum string     `json:"remote_checksum,omitempty"`
+}
+
+func loadRemoteHistory(ctx context.Context, config pgconn.Config, options ...func(*pgx.ConnConfig)) ([]Migration, error) {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close(context.Background())
+	return LoadRemoteHistory(ctx, conn)
+}
+
+func LoadRemoteHistory(ctx context.Context, conn *pgx.Conn) ([]Migration, error) {
+	result, err := listMigrationHistory(ctx, conn)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UndefinedTable {
+			// If migration history table is undefined, the remote project has no migrations
+			return nil, nil
+		}
+	}
+	return result, err
+}
+
+func listMigrationHistory(ctx context.Context, conn *pgx.Conn) ([]Migration, error) {
+	rows, err := conn.Query(ctx, LIST_MIGRATION_HISTORY)
+	if err != nil {
+		return nil, errors.Errorf("failed to query rows: %w", err)
+	}
+	defer rows.Close()
+	var result []Migration
+	for rows.Next() {
+		var statements, createdAt string
+		m := Migration{Remote: true}
+		if err := rows.Scan(&m.Version, &m.Name, &statements, &createdAt, &m.RemoteChecksum); err != nil {
+			return nil, errors.Errorf("failed to scan row: %w", err)
+		}
+		var lines []string
+		if err := json.Unmarshal([]byte(statements), &lines); err != nil {
+			return nil, errors.Errorf("failed to parse statements: %w", err)
+		}
+		// Versions applied by older CLI releases have no stored checksum, or even statements
+		if len(m.RemoteChecksum) == 0 && len(lines) > 0 {
+			m.RemoteChecksum = history.Checksum(lines)
+		}
+		if len(createdAt) > 0 {
+			appliedAt, err := time.Parse(time.RFC3339, createdAt)
+			if err != nil {
+				return nil, errors.Errorf("failed to parse applied time: %w", err)
+			}
+			m.AppliedAt = &appliedAt
+		}
+		result = append(result, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Errorf("failed to read rows: %w", err)
+	}
+	return result, nil
+}
+
+// Returns applied migrations whose local files have been edited since they were pushed.
+func FindChangedMigrations(ctx context.Context, conn *pgx.Conn, fsys afero.Fs) ([]Migration, error) {
+	remoteMigrations, err := LoadRemoteHistory(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	localMigrations, err := loadLocalHistory(fsys)
+	if err != nil {
+		return nil, err
+	}
+	var changed []Migration
+	for _, m := range mergeHistory(remoteMigrations, localMigrations) {
+		if m.Status == StatusChanged {
+			changed = append(changed, m)
+		}
+	}
+	return changed, nil
+}
+
+func loadLocalHistory(fsys afero.Fs) ([]Migration, error) {
+	names, err := LoadLocalMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+	var result []Migration
+	for _, filename := range names {
+		// LoadLocalMigrations guarantees we always have a match
+		matches := utils.MatchMigrationFile(filename)
+		path := filepath.Join(utils.MigrationsDir, filename)
+		checksum, err := loadChecksum(path, fsys)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, Migration{
+			Version:       matches[1],
+			Name:          matches[2],
+			Local:         true,
+			LocalChecksum: checksum,
+		})
+	}
+	return result, nil
+}
+
+func loadChecksum(path string, fsys afero.Fs) (string, error) {
+	sql, err := fsys.Open(path)
+	if err != nil {
+		return "", errors.Errorf("failed to open migration file: %w", err)
+	}
+	defer sql.Close()
+	// Unless explicitly specified, Use file length as max buffer size
+	if !viper.IsSet("SCANNER_BUFFER_SIZE") {
+		if fi, err := sql.Stat(); err == nil {
+			if size := int(fi.Size()); size > parser.MaxScannerCapacity {
+				parser.MaxScannerCapacity = size
+			}
+		}
+	}
+	contents, err := io.ReadAll(sql)
+	if err != nil {
+		return "", errors.Errorf("failed to read migration file: %w", err)
+	}
+	// Data migrations are recorded as a single script
+	if utils.DataFilePattern.MatchString(filepath.Base(path)) {
+		return history.Checksum([]string{string(contents)}), nil
+	}
+	// Down sections are excluded to match the statements recorded on push
+	up, _, _ := parser.CutDownSection(contents)
+	lines, err := parser.SplitAndTrim(bytes.NewReader(up))
+	if err != nil {
+		return "", err
+	}
+	return history.Checksum(lines), nil
+}
+
+// Merges both histories in chronological order, ignoring non-numeric versions.
+func mergeHistory(remoteMigrations, localMigrations []Migration) []Migration {
+	var result []Migration
+	for i, j := 0, 0; i < len(remoteMigrations) || j < len(localMigrations); {
+		remoteTimestamp := math.MaxInt
+		if i < len(remoteMigrations) {
+			var err error
+			if remoteTimestamp, err = strconv.Atoi(remoteMigrations[i].Version); err != nil {
+				i++
+				continue
+			}
+		}
+		localTimestamp := math.MaxInt
+		if j < len(localMigrations) {
+			var err error
+			if localTimestamp, err = strconv.Atoi(localMigrations[j].Version); err != nil {
+				j++
+				continue
+			}
+		}
+		if localTimestamp < remoteTimestamp {
+			m := localMigrations[j]
+			m.Status = StatusLocal
+			result = append(result, m)
+			j++
+		} else if remoteTimestamp < localTimestamp {
+			m := remoteMigrations[i]
+			m.Status = StatusRemote
+			result = append(result, m)
+			i++
+		} else {
+			m := remoteMigrations[i]
+			m.Local = true
+			m.LocalChecksum = localMigrations[j].LocalChecksum
+			m.Name = localMigrations[j].Name
+			m.Status = StatusSynced
+			if len(m.RemoteChecksum) > 0 && m.RemoteChecksum != m.LocalChecksum {
+				m.Status = StatusChanged
+			}
+			result = append(result, m)
+			i++
+			j++
+		}
+	}
+	return result
+}
@@ -3,10 +3,10 @@ package list
 import (
 	"context"
 	"fmt"
-	"math"
 	"os"
 	"regexp"
 	"strconv"
+	"time"
 
 	"github.com/charmbracelet/glamour"
 	"github.com/go-errors/errors"
@@ -22,26 +22,20 @@ const LIST_MIGRATION_VERSION = "SELECT version FROM supabase_migrations.schema_m
 
 var initSchemaPattern = regexp.MustCompile(`([0-9]{14})_init\.sql`)
 
-func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
-	remoteVersions, err := loadRemoteVersions(ctx, config, options...)
+func Run(ctx context.Context, config pgconn.Config, format string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	remoteMigrations, err := loadRemoteHistory(ctx, config, options...)
 	if err != nil {
 		return err
 	}
-	localVersions, err := LoadLocalVersions(fsys)
+	localMigrations, err := loadLocalHistory(fsys)
 	if err != nil {
 		return err
 	}
-	table := makeTable(remoteVersions, localVersions)
-	return RenderTable(table)
-}
-
-func loadRemoteVersions(ctx context.Context, config pgconn.Config, options ...func(*pgx.ConnConfig)) ([]string, error) {
-	conn, err := utils.ConnectByConfig(ctx, config, options...)
-	if err != nil {
-		return nil, err
+	migrations := mergeHistory(remoteMigrations, localMigrations)
+	if format == utils.OutputPretty {
+		return RenderTable(makeTable(migrations))
 	}
-	defer conn.Close(context.Background())
-	return LoadRemoteMigrations(ctx, conn)
+	return utils.EncodeOutput(format, os.Stdout, migrations)
 }
 
 func LoadRemoteMigrations(ctx context.Context, conn *pgx.Conn) ([]string, error) {
@@ -64,43 +58,31 @@ func listMigrationVersions(ctx context.Context, conn *pgx.Conn) ([]string, error
 	return pgxv5.CollectStrings(rows)
 }
 
-func makeTable(remoteMigrations, localMigrations []string) string {
-	var err error
-	table := "|Local|Remote|Time (UTC)|\n|-|-|-|\n"
-	for i, j := 0, 0; i < len(remoteMigrations) || j < len(localMigrations); {
-		remoteTimestamp := math.MaxInt
-		if i < len(remoteMigrations) {
-			if remoteTimestamp, err = strconv.Atoi(remoteMigrations[i]); err != nil {
-				i++
-				continue
-			}
+func makeTable(migrations []Migration) string {
+	table := "|Local|Remote|Time (UTC)|Status|Applied (UTC)|\n|-|-|-|-|-|\n"
+	for _, m := range migrations {
+		local, remote, applied := " ", " ", " "
+		if m.Local {
+			local = m.Version
 		}
-		localTimestamp := math.MaxInt
-		if j < len(localMigrations) {
-			if localTimestamp, err = strconv.Atoi(localMigrations[j]); err != nil {
-				j++
-				continue
-			}
+		if m.Remote {
+			remote = m.Version
 		}
-		// Top to bottom chronological order
-		if localTimestamp < remoteTimestamp {
-			table += fmt.Sprintf("|`%s`|` `|`%s`|\n", localMigrations[j], utils.FormatTimestampVersion(localMigrations[j]))
-			j++
-		} else if remoteTimestamp < localTimestamp {
-			table += fmt.Sprintf("|` `|`%s`|`%s`|\n", remoteMigrations[i], utils.FormatTimestampVersion(remoteMigrations[i]))
-			i++
-		} else {
-			table += fmt.Sprintf("|`%s`|`%s`|`%s`|\n", localMigrations[j], remoteMigrations[i], utils.FormatTimestampVersion(remoteMigrations[i]))
-			i++
-			j++
+		if m.AppliedAt != nil {
+			applied = m.AppliedAt.UTC().Format(time.DateTime)
 		}
+		table += fmt.Sprintf("|`%s`|`%s`|`%s`|`%s`|`%s`|\n", local, remote, utils.FormatTimestampVersion(m.Version), m.Status, applied)
 	}
 	return table
 }
 
 func RenderTable(markdown string) error {
+	style := glamour.WithAutoStyle()
+	if utils.NoColor() {
+		style = glamour.WithStandardStyle("notty")
+	}
 	r, err := glamour.NewTermRenderer(
-		glamour.WithAutoStyle(),
+		style,
 		glamour.WithWordWrap(-1),
 	)
 	if err != nil {
@@ -122,7 +104,7 @@ func LoadLocalVersions(fsys afero.Fs) ([]string, error) {
 	var versions []string
 	for _, filename := range names {
 		// LoadLocalMigrations guarantees we always have a match
-		version := utils.MigrateFilePattern.FindStringSubmatch(filename)[1]
+		version := utils.MatchMigrationFile(filename)[1]
 		versions = append(versions, version)
 	}
 	return versions, nil
@@ -144,9 +126,13 @@ func LoadPartialMigrations(version string, fsys afero.Fs) ([]string, error) {
 			fmt.Fprintln(os.Stderr, "Skipping migration "+utils.Bold(filename)+`... (replace "init" with a different file name to apply this migration)`)
 			continue
 		}
-		matches := utils.MigrateFilePattern.FindStringSubmatch(filename)
+		// Down migrations are only loaded when reverting
+		if utils.DownFilePattern.MatchString(filename) {
+			continue
+		}
+		matches := utils.MatchMigrationFile(filename)
 		if len(matches) == 0 {
-			fmt.Fprintln(os.Stderr, "Skipping migration "+utils.Bold(filename)+`... (file name must match pattern "<timestamp>_name.sql")`)
+			fmt.Fprintln(os.Stderr, "Skipping migration "+utils.Bold(filename)+`... (file name must match pattern "<timestamp>_name.sql" or "<timestamp>_name.ts")`)
 			continue
 		}
 		names = append(names, filename)
@@ -157,6 +143,20 @@ func LoadPartialMigrations(version string, fsys afero.Fs) ([]string, error) {
 	return names, nil
 }
 
+// Data migrations only change rows, so they are skipped when replaying schema changes on a
+// shadow database.
+func SkipDataMigrations(migrations []string) []string {
+	var result []string
+	for _, filename := range migrations {
+		if utils.DataFilePattern.MatchString(filename) {
+			fmt.Fprintln(os.Stderr, "Skipping data migration "+utils.Bold(filename)+" on shadow database...")
+			continue
+		}
+		result = append(result, filename)
+	}
+	return result
+}
+
 func shouldSkip(name string) bool {
 	// NOTE: To handle backward-compatibility. `<timestamp>_init.sql` as
 	// the first migration (prev versions of the CLI) is deprecated.
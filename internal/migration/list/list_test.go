@@ -6,12 +6,14 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgerrcode"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/migration/history"
 	"github.com/supabase/cli/internal/testing/fstest"
 	"github.com/supabase/cli/internal/testing/pgtest"
 	"github.com/supabase/cli/internal/utils"
@@ -32,10 +34,24 @@ func TestMigrationList(t *testing.T) {
 		// Setup mock postgres
 		conn := pgtest.NewConn()
 		defer conn.Close(t)
-		conn.Query(LIST_MIGRATION_VERSION).
+		conn.Query(LIST_MIGRATION_HISTORY).
 			Reply("SELECT 0")
 		// Run test
-		err := Run(context.Background(), dbConfig, fsys, conn.Intercept)
+		err := Run(context.Background(), dbConfig, utils.OutputPretty, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("encodes json output", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(LIST_MIGRATION_HISTORY).
+			Reply("SELECT 1", []interface{}{"20220727064247", "test", "[]", "", ""})
+		// Run test
+		err := Run(context.Background(), dbConfig, utils.OutputJson, fsys, conn.Intercept)
 		// Check error
 		assert.NoError(t, err)
 	})
@@ -44,7 +60,7 @@ func TestMigrationList(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := Run(context.Background(), pgconn.Config{}, fsys)
+		err := Run(context.Background(), pgconn.Config{}, utils.OutputPretty, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "invalid port (outside range)")
 	})
@@ -55,32 +71,67 @@ func TestMigrationList(t *testing.T) {
 		// Setup mock postgres
 		conn := pgtest.NewConn()
 		defer conn.Close(t)
-		conn.Query(LIST_MIGRATION_VERSION).
+		conn.Query(LIST_MIGRATION_HISTORY).
 			Reply("SELECT 0")
 		// Run test
-		err := Run(context.Background(), dbConfig, fsys, conn.Intercept)
+		err := Run(context.Background(), dbConfig, utils.OutputPretty, fsys, conn.Intercept)
 		// Check error
 		assert.ErrorIs(t, err, os.ErrPermission)
 	})
 }
 
-func TestRemoteMigrations(t *testing.T) {
-	t.Run("loads migration versions", func(t *testing.T) {
+func TestRemoteHistory(t *testing.T) {
+	t.Run("loads migration history", func(t *testing.T) {
 		// Setup mock postgres
 		conn := pgtest.NewConn()
 		defer conn.Close(t)
-		conn.Query(LIST_MIGRATION_VERSION).
-			Reply("SELECT 1", []interface{}{"20220727064247"})
+		conn.Query(LIST_MIGRATION_HISTORY).
+			Reply("SELECT 1", []interface{}{"20220727064247", "test", `["select 1"]`, "2022-07-27T06:50:00.123456+00:00", ""})
+		// Run test
+		migrations, err := loadRemoteHistory(context.Background(), dbConfig, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		require.Len(t, migrations, 1)
+		assert.Equal(t, "20220727064247", migrations[0].Version)
+		assert.Equal(t, "test", migrations[0].Name)
+		assert.True(t, migrations[0].Remote)
+		assert.Equal(t, history.Checksum([]string{"select 1"}), migrations[0].RemoteChecksum)
+		require.NotNil(t, migrations[0].AppliedAt)
+		assert.Equal(t, time.Date(2022, 7, 27, 6, 50, 0, 123456000, time.UTC), migrations[0].AppliedAt.UTC())
+	})
+
+	t.Run("prefers stored checksum", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(LIST_MIGRATION_HISTORY).
+			Reply("SELECT 1", []interface{}{"20220727064247", "test", `["select 1"]`, "", "stored"})
+		// Run test
+		migrations, err := loadRemoteHistory(context.Background(), dbConfig, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		require.Len(t, migrations, 1)
+		assert.Equal(t, "stored", migrations[0].RemoteChecksum)
+	})
+
+	t.Run("skips checksum without statements", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(LIST_MIGRATION_HISTORY).
+			Reply("SELECT 1", []interface{}{"20220727064247", "", "null", "", ""})
 		// Run test
-		versions, err := loadRemoteVersions(context.Background(), dbConfig, conn.Intercept)
+		migrations, err := loadRemoteHistory(context.Background(), dbConfig, conn.Intercept)
 		// Check error
 		assert.NoError(t, err)
-		assert.ElementsMatch(t, []string{"20220727064247"}, versions)
+		require.Len(t, migrations, 1)
+		assert.Empty(t, migrations[0].RemoteChecksum)
+		assert.Nil(t, migrations[0].AppliedAt)
 	})
 
 	t.Run("throws error on connect failure", func(t *testing.T) {
 		// Run test
-		_, err := loadRemoteVersions(context.Background(), pgconn.Config{})
+		_, err := loadRemoteHistory(context.Background(), pgconn.Config{})
 		// Check error
 		assert.ErrorContains(t, err, "invalid port (outside range)")
 	})
@@ -89,25 +140,25 @@ func TestRemoteMigrations(t *testing.T) {
 		// Setup mock postgres
 		conn := pgtest.NewConn()
 		defer conn.Close(t)
-		conn.Query(LIST_MIGRATION_VERSION).
+		conn.Query(LIST_MIGRATION_HISTORY).
 			ReplyError(pgerrcode.UndefinedTable, "relation \"supabase_migrations.schema_migrations\" does not exist")
 		// Run test
-		versions, err := loadRemoteVersions(context.Background(), dbConfig, conn.Intercept)
+		migrations, err := loadRemoteHistory(context.Background(), dbConfig, conn.Intercept)
 		// Check error
 		assert.NoError(t, err)
-		assert.Empty(t, versions)
+		assert.Empty(t, migrations)
 	})
 
 	t.Run("throws error on invalid row", func(t *testing.T) {
 		// Setup mock postgres
 		conn := pgtest.NewConn()
 		defer conn.Close(t)
-		conn.Query(LIST_MIGRATION_VERSION).
-			Reply("SELECT 1", []interface{}{})
+		conn.Query(LIST_MIGRATION_HISTORY).
+			Reply("SELECT 1", []interface{}{"20220727064247"})
 		// Run test
-		_, err := loadRemoteVersions(context.Background(), dbConfig, conn.Intercept)
+		_, err := loadRemoteHistory(context.Background(), dbConfig, conn.Intercept)
 		// Check error
-		assert.ErrorContains(t, err, "number of field descriptions must equal number of destinations, got 0 and 1")
+		assert.ErrorContains(t, err, "number of field descriptions must equal number of destinations, got 1 and 5")
 	})
 }
 
@@ -126,12 +177,41 @@ func TestLocalMigrations(t *testing.T) {
 		assert.ElementsMatch(t, []string{"20220727064246", "20220727064248"}, versions)
 	})
 
+	t.Run("loads data migrations", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "20220727064246_test.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte{}, 0644))
+		path = filepath.Join(utils.MigrationsDir, "20220727064247_backfill.ts")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte{}, 0644))
+		// Run test
+		migrations, err := LoadLocalMigrations(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"20220727064246_test.sql", "20220727064247_backfill.ts"}, migrations)
+		assert.Equal(t, []string{"20220727064246_test.sql"}, SkipDataMigrations(migrations))
+	})
+
+	t.Run("ignores down migrations", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "20220727064246_test.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte{}, 0644))
+		path = filepath.Join(utils.MigrationsDir, "20220727064246_test.down.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte{}, 0644))
+		// Run test
+		versions, err := LoadLocalVersions(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"20220727064246"}, versions)
+	})
+
 	t.Run("ignores outdated and invalid files", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		path := filepath.Join(utils.MigrationsDir, "20211208000000_init.sql")
 		require.NoError(t, afero.WriteFile(fsys, path, []byte{}, 0644))
-		path = filepath.Join(utils.MigrationsDir, "20211208000001_invalid.ts")
+		path = filepath.Join(utils.MigrationsDir, "20211208000001_invalid.js")
 		require.NoError(t, afero.WriteFile(fsys, path, []byte{}, 0644))
 		// Run test
 		versions, err := LoadLocalVersions(fsys)
@@ -150,43 +230,77 @@ func TestLocalMigrations(t *testing.T) {
 	})
 }
 
-func TestMakeTable(t *testing.T) {
-	t.Run("tabulate version", func(t *testing.T) {
+func TestMergeHistory(t *testing.T) {
+	t.Run("compares checksums", func(t *testing.T) {
+		remote := []Migration{
+			{Version: "20220727064246", Remote: true, RemoteChecksum: "a"},
+			{Version: "20220727064247", Remote: true, RemoteChecksum: "b"},
+			{Version: "20220727064248", Remote: true},
+			{Version: "20220727064250", Remote: true},
+		}
+		local := []Migration{
+			{Version: "20220727064246", Name: "same", Local: true, LocalChecksum: "a"},
+			{Version: "20220727064247", Name: "edited", Local: true, LocalChecksum: "c"},
+			{Version: "20220727064248", Name: "unknown", Local: true, LocalChecksum: "d"},
+			{Version: "20220727064249", Name: "new", Local: true, LocalChecksum: "e"},
+		}
 		// Run test
-		table := makeTable([]string{"0", "2"}, []string{"0", "1"})
+		migrations := mergeHistory(remote, local)
 		// Check error
-		lines := strings.Split(strings.TrimSpace(table), "\n")
-		assert.ElementsMatch(t, []string{
-			"|Local|Remote|Time (UTC)|",
-			"|-|-|-|",
-			"|`0`|`0`|`0`|",
-			"|`1`|` `|`1`|",
-			"|` `|`2`|`2`|",
-		}, lines)
+		assert.Equal(t, []Migration{
+			{Version: "20220727064246", Name: "same", Local: true, Remote: true, Status: StatusSynced, LocalChecksum: "a", RemoteChecksum: "a"},
+			{Version: "20220727064247", Name: "edited", Local: true, Remote: true, Status: StatusChanged, LocalChecksum: "c", RemoteChecksum: "b"},
+			{Version: "20220727064248", Name: "unknown", Local: true, Remote: true, Status: StatusSynced, LocalChecksum: "d"},
+			{Version: "20220727064249", Name: "new", Local: true, Status: StatusLocal, LocalChecksum: "e"},
+			{Version: "20220727064250", Remote: true, Status: StatusRemote},
+		}, migrations)
 	})
 
-	t.Run("tabulate timestamp", func(t *testing.T) {
+	t.Run("ignores string values", func(t *testing.T) {
 		// Run test
-		table := makeTable([]string{"20220727064246", "20220727064248"}, []string{"20220727064246", "20220727064247"})
+		migrations := mergeHistory([]Migration{{Version: "a"}}, []Migration{{Version: "b"}})
 		// Check error
-		lines := strings.Split(strings.TrimSpace(table), "\n")
-		assert.ElementsMatch(t, []string{
-			"|Local|Remote|Time (UTC)|",
-			"|-|-|-|",
-			"|`20220727064246`|`20220727064246`|`2022-07-27 06:42:46`|",
-			"|`20220727064247`|` `|`2022-07-27 06:42:47`|",
-			"|` `|`20220727064248`|`2022-07-27 06:42:48`|",
-		}, lines)
+		assert.Empty(t, migrations)
 	})
+}
 
-	t.Run("ignores string values", func(t *testing.T) {
+func TestLocalHistory(t *testing.T) {
+	t.Run("computes checksum of statements", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "20220727064246_test.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte("select 1;\n\n"), 0644))
+		// Run test
+		migrations, err := loadLocalHistory(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []Migration{{
+			Version:       "20220727064246",
+			Name:          "test",
+			Local:         true,
+			LocalChecksum: history.Checksum([]string{"select 1"}),
+		}}, migrations)
+	})
+}
+
+func TestMakeTable(t *testing.T) {
+	t.Run("tabulate status", func(t *testing.T) {
+		appliedAt := time.Date(2022, 7, 27, 6, 50, 0, 0, time.UTC)
+		migrations := []Migration{
+			{Version: "20220727064246", Local: true, Remote: true, Status: StatusSynced, AppliedAt: &appliedAt},
+			{Version: "20220727064247", Local: true, Status: StatusLocal},
+			{Version: "20220727064248", Remote: true, Status: StatusRemote},
+		}
 		// Run test
-		table := makeTable([]string{"a", "c"}, []string{"a", "b"})
+		table := makeTable(migrations)
 		// Check error
 		lines := strings.Split(strings.TrimSpace(table), "\n")
 		assert.ElementsMatch(t, []string{
-			"|Local|Remote|Time (UTC)|",
-			"|-|-|-|",
+			"|Local|Remote|Time (UTC)|Status|Applied (UTC)|",
+			"|-|-|-|-|-|",
+			"|`20220727064246`|`20220727064246`|`2022-07-27 06:42:46`|`synced`|`2022-07-27 06:50:00`|",
+			"|`20220727064247`|` `|`2022-07-27 06:42:47`|`local`|` `|",
+			"|` `|`20220727064248`|`2022-07-27 06:42:48`|`remote`|` `|",
 		}, lines)
 	})
 }
@@ -1,6 +1,7 @@
 package repair
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -75,7 +76,7 @@ func UpdateMigrationTable(ctx context.Context, conn *pgx.Conn, version []string,
 			if err != nil {
 				return err
 			}
-			batch.Queue(history.INSERT_MIGRATION_VERSION, f.Version, f.Name, f.Lines)
+			batch.Queue(history.INSERT_MIGRATION_VERSION, f.Version, f.Name, f.Lines, history.Checksum(f.Lines))
 		}
 	case Reverted:
 		if !repairAll {
@@ -92,15 +93,18 @@ func UpdateMigrationTable(ctx context.Context, conn *pgx.Conn, version []string,
 }
 
 func GetMigrationFile(version string, fsys afero.Fs) (string, error) {
-	path := filepath.Join(utils.MigrationsDir, version+"_*.sql")
+	path := filepath.Join(utils.MigrationsDir, version+"_*")
 	matches, err := afero.Glob(fsys, path)
 	if err != nil {
 		return "", errors.Errorf("failed to glob migration files: %w", err)
 	}
-	if len(matches) == 0 {
-		return "", errors.Errorf("glob %s: %w", path, os.ErrNotExist)
+	for _, m := range matches {
+		filename := filepath.Base(m)
+		if len(utils.MatchMigrationFile(filename)) > 0 && !utils.DownFilePattern.MatchString(filename) {
+			return m, nil
+		}
 	}
-	return matches[0], nil
+	return "", errors.Errorf("glob %s: %w", path, os.ErrNotExist)
 }
 
 type MigrationFile struct {
@@ -118,6 +122,30 @@ func NewMigrationFromVersion(version string, fsys afero.Fs) (*MigrationFile, err
 }
 
 func NewMigrationFromFile(path string, fsys afero.Fs) (*MigrationFile, error) {
+	contents, err := ReadMigrationFile(path, fsys)
+	if err != nil {
+		return nil, err
+	}
+	filename := filepath.Base(path)
+	if matches := utils.DataFilePattern.FindStringSubmatch(filename); len(matches) > 2 {
+		// Data migrations are recorded as a single script
+		return &MigrationFile{Lines: []string{string(contents)}, Version: matches[1], Name: matches[2]}, nil
+	}
+	// Statements after `-- supabase:down` are only run when reverting
+	up, _, _ := parser.CutDownSection(contents)
+	file, err := NewMigrationFromReader(bytes.NewReader(up))
+	if err == nil {
+		// Parse version from file name
+		matches := utils.MigrateFilePattern.FindStringSubmatch(filename)
+		if len(matches) > 2 {
+			file.Version = matches[1]
+			file.Name = matches[2]
+		}
+	}
+	return file, err
+}
+
+func ReadMigrationFile(path string, fsys afero.Fs) ([]byte, error) {
 	sql, err := fsys.Open(path)
 	if err != nil {
 		return nil, errors.Errorf("failed to open migration file: %w", err)
@@ -131,17 +159,11 @@ func NewMigrationFromFile(path string, fsys afero.Fs) (*MigrationFile, error) {
 			}
 		}
 	}
-	file, err := NewMigrationFromReader(sql)
-	if err == nil {
-		// Parse version from file name
-		filename := filepath.Base(path)
-		matches := utils.MigrateFilePattern.FindStringSubmatch(filename)
-		if len(matches) > 2 {
-			file.Version = matches[1]
-			file.Name = matches[2]
-		}
+	contents, err := io.ReadAll(sql)
+	if err != nil {
+		return nil, errors.Errorf("failed to read migration file: %w", err)
 	}
-	return file, err
+	return contents, nil
 }
 
 func NewMigrationFromReader(sql io.Reader) (*MigrationFile, error) {
@@ -152,10 +174,33 @@ func NewMigrationFromReader(sql io.Reader) (*MigrationFile, error) {
 	return &MigrationFile{Lines: lines}, nil
 }
 
+// StatementError reports the statement of a migration file that failed to execute.
+type StatementError struct {
+	Version string
+	// Index of the failed statement, or the number of statements if the history insert failed
+	Index     int
+	Statement string
+	Err       error
+}
+
+func (e *StatementError) Error() string {
+	return fmt.Sprintf("%v\nAt statement %d: %s", e.Err, e.Index, e.Statement)
+}
+
+func (e *StatementError) Unwrap() error {
+	return e.Err
+}
+
 func (m *MigrationFile) ExecBatch(ctx context.Context, conn *pgx.Conn) error {
+	return m.ExecBatchFrom(ctx, conn, 0)
+}
+
+// ExecBatchFrom skips statements before start, which were already committed by a previous
+// run, but records all statements in history.
+func (m *MigrationFile) ExecBatchFrom(ctx context.Context, conn *pgx.Conn, start int) error {
 	// Batch migration commands, without using statement cache
 	batch := &pgconn.Batch{}
-	for _, line := range m.Lines {
+	for _, line := range m.Lines[start:] {
 		batch.ExecParams(line, nil, nil, nil, nil)
 	}
 	// Insert into migration history
@@ -168,11 +213,23 @@ func (m *MigrationFile) ExecBatch(ctx context.Context, conn *pgx.Conn) error {
 	if result, err := conn.PgConn().ExecBatch(ctx, batch).ReadAll(); err != nil {
 		// Defaults to printing the last statement on error
 		stat := history.INSERT_MIGRATION_VERSION
-		i := len(result)
+		i := start + len(result)
 		if i < len(m.Lines) {
 			stat = m.Lines[i]
 		}
-		return errors.Errorf("%w\nAt statement %d: %s", err, i, stat)
+		return errors.New(&StatementError{Version: m.Version, Index: i, Statement: stat, Err: err})
+	}
+	return nil
+}
+
+// InsertVersion records the migration in history without executing its statements.
+func (m *MigrationFile) InsertVersion(ctx context.Context, conn *pgx.Conn) error {
+	batch := &pgconn.Batch{}
+	if err := m.insertVersionSQL(conn, batch); err != nil {
+		return err
+	}
+	if _, err := conn.PgConn().ExecBatch(ctx, batch).ReadAll(); err != nil {
+		return errors.Errorf("failed to update migration history: %w", err)
 	}
 	return nil
 }
@@ -198,9 +255,9 @@ func (m *MigrationFile) insertVersionSQL(conn *pgx.Conn, batch *pgconn.Batch) er
 	}
 	batch.ExecParams(
 		history.INSERT_MIGRATION_VERSION,
-		[][]byte{[]byte(m.Version), []byte(m.Name), encoded},
-		[]uint32{pgtype.TextOID, pgtype.TextOID, pgtype.TextArrayOID},
-		[]int16{pgtype.TextFormatCode, pgtype.TextFormatCode, valueFormat},
+		[][]byte{[]byte(m.Version), []byte(m.Name), encoded, []byte(history.Checksum(m.Lines))},
+		[]uint32{pgtype.TextOID, pgtype.TextOID, pgtype.TextArrayOID, pgtype.TextOID},
+		[]int16{pgtype.TextFormatCode, pgtype.TextFormatCode, valueFormat, pgtype.TextFormatCode},
 		nil,
 	)
 	return nil
@@ -41,7 +41,7 @@ func TestRepairCommand(t *testing.T) {
 		conn := pgtest.NewConn()
 		defer conn.Close(t)
 		pgtest.MockMigrationHistory(conn)
-		conn.Query(history.INSERT_MIGRATION_VERSION, "0", "test", []string{"select 1"}).
+		conn.Query(history.INSERT_MIGRATION_VERSION, "0", "test", []string{"select 1"}, history.Checksum([]string{"select 1"})).
 			Reply("INSERT 0 1")
 		// Run test
 		err := Run(context.Background(), dbConfig, []string{"0"}, Applied, fsys, conn.Intercept)
@@ -91,7 +91,7 @@ func TestRepairCommand(t *testing.T) {
 		conn := pgtest.NewConn()
 		defer conn.Close(t)
 		pgtest.MockMigrationHistory(conn)
-		conn.Query(history.INSERT_MIGRATION_VERSION, "0", "test", nil).
+		conn.Query(history.INSERT_MIGRATION_VERSION, "0", "test", nil, history.Checksum(nil)).
 			ReplyError(pgerrcode.DuplicateObject, `relation "supabase_migrations.schema_migrations" does not exist`)
 		// Run test
 		err := Run(context.Background(), dbConfig, []string{"0"}, Applied, fsys, conn.Intercept)
@@ -111,7 +111,7 @@ func TestRepairAll(t *testing.T) {
 		conn := pgtest.NewConn()
 		defer conn.Close(t)
 		pgtest.MockMigrationHistory(conn)
-		conn.Query(history.TRUNCATE_VERSION_TABLE + `;INSERT INTO supabase_migrations.schema_migrations(version, name, statements) VALUES( '0' ,  'test' ,  '{select 1}' )`).
+		conn.Query(history.TRUNCATE_VERSION_TABLE + `;INSERT INTO supabase_migrations.schema_migrations(version, name, statements, checksum) VALUES( '0' ,  'test' ,  '{select 1}' ,  '` + history.Checksum([]string{"select 1"}) + `' )`).
 			Reply("TRUNCATE TABLE").
 			Reply("INSERT 0 1")
 		// Run test
@@ -176,6 +176,19 @@ func TestMigrationFile(t *testing.T) {
 		assert.Equal(t, "20220727064247", migration.Version)
 	})
 
+	t.Run("new from file excludes down section", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "0_test.sql")
+		sql := "create schema test;\n-- supabase:down\ndrop schema test;\n"
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(sql), 0644))
+		// Run test
+		migration, err := NewMigrationFromFile(path, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"create schema test"}, migration.Lines)
+	})
+
 	t.Run("new from reader errors on max token", func(t *testing.T) {
 		viper.Reset()
 		sql := "\tBEGIN; " + strings.Repeat("a", parser.MaxScannerCapacity)
@@ -197,7 +210,7 @@ func TestMigrationFile(t *testing.T) {
 		defer conn.Close(t)
 		conn.Query(migration.Lines[0]).
 			Reply("CREATE SCHEMA").
-			Query(history.INSERT_MIGRATION_VERSION, "0", "", migration.Lines).
+			Query(history.INSERT_MIGRATION_VERSION, "0", "", migration.Lines, history.Checksum(migration.Lines)).
 			Reply("INSERT 0 1")
 		// Connect to mock
 		ctx := context.Background()
@@ -220,7 +233,7 @@ func TestMigrationFile(t *testing.T) {
 		defer conn.Close(t)
 		conn.Query(migration.Lines[0]).
 			ReplyError(pgerrcode.DuplicateSchema, `schema "public" already exists`).
-			Query(history.INSERT_MIGRATION_VERSION, "0", "", fmt.Sprintf("{%s}", migration.Lines[0])).
+			Query(history.INSERT_MIGRATION_VERSION, "0", "", fmt.Sprintf("{%s}", migration.Lines[0]), history.Checksum(migration.Lines)).
 			Reply("INSERT 0 1")
 		// Connect to mock via text protocol
 		ctx := context.Background()
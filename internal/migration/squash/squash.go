@@ -63,6 +63,13 @@ func squashToVersion(ctx context.Context, version string, fsys afero.Fs, options
 	if len(migrations) == 0 {
 		return errors.New(ErrMissingVersion)
 	}
+	// Data migrations cannot be merged into a schema dump, nor deleted without losing them
+	for _, name := range migrations {
+		if utils.DataFilePattern.MatchString(name) {
+			utils.CmdSuggestion = fmt.Sprintf("Pass %s to squash only the migrations before it.", utils.Aqua("--version"))
+			return errors.Errorf("Cannot squash data migration %s.", utils.Bold(name))
+		}
+	}
 	// Migrate to target version and dump
 	path := filepath.Join(utils.MigrationsDir, migrations[len(migrations)-1])
 	if len(migrations) == 1 {
@@ -165,7 +172,7 @@ func baselineMigrations(ctx context.Context, config pgconn.Config, version strin
 	if len(version) == 0 {
 		// Expecting no errors here because the caller should have handled them
 		if migrations, err := list.LoadPartialMigrations(version, fsys); len(migrations) > 0 {
-			if matches := utils.MigrateFilePattern.FindStringSubmatch(migrations[0]); len(matches) > 1 {
+			if matches := utils.MatchMigrationFile(migrations[0]); len(matches) > 1 {
 				version = matches[1]
 			}
 		} else if err != nil {
@@ -189,7 +196,7 @@ func baselineMigrations(ctx context.Context, config pgconn.Config, version strin
 	// Data statements don't mutate schemas, safe to use statement cache
 	batch := pgx.Batch{}
 	batch.Queue(history.DELETE_MIGRATION_BEFORE, m.Version)
-	batch.Queue(history.INSERT_MIGRATION_VERSION, m.Version, m.Name, m.Lines)
+	batch.Queue(history.INSERT_MIGRATION_VERSION, m.Version, m.Name, m.Lines, history.Checksum(m.Lines))
 	if err := conn.SendBatch(ctx, &batch).Close(); err != nil {
 		return errors.Errorf("failed to update migration history: %w", err)
 	}
@@ -0,0 +1,101 @@
+package up
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/apply"
+	"github.com/supabase/cli/internal/migration/repair"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var errNotPending = errors.New("Migration is not pending on the target database.")
+
+// RunTargeted applies either the single pending migration matching version, or all pending
+// migrations up to and including target, leaving newer migrations pending.
+func RunTargeted(ctx context.Context, version, target string, includeAll, dryRun bool, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	for _, v := range []string{version, target} {
+		if _, err := strconv.Atoi(v); len(v) > 0 && err != nil {
+			return errors.Errorf("failed to parse %s: %w", v, repair.ErrInvalidVersion)
+		}
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	pending, err := GetPendingMigrations(ctx, includeAll, conn, fsys)
+	if err != nil {
+		return err
+	}
+	selected, remaining, err := SelectPending(pending, version, target, includeAll)
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		for _, filename := range selected {
+			fmt.Fprintln(os.Stderr, "Would apply migration "+utils.Bold(filename)+"...")
+		}
+	} else {
+		msg := "Do you want to apply these migrations?\n • " + strings.Join(selected, "\n • ") + "\n"
+		if shouldApply, err := utils.NewConsole().PromptYesNo(ctx, msg, true); err != nil {
+			return err
+		} else if !shouldApply {
+			return errors.New(context.Canceled)
+		}
+		if err := apply.MigrateUp(ctx, conn, selected, fsys); err != nil {
+			return err
+		}
+	}
+	printRemaining(remaining)
+	return nil
+}
+
+// SelectPending splits pending migrations into those to apply and those that remain. Unless
+// includeAll is set, a single version must be the oldest pending migration so that
+// migrations are still applied in chronological order. Target need not match a file exactly.
+func SelectPending(pending []string, version, target string, includeAll bool) ([]string, []string, error) {
+	var selected, remaining []string
+	for i, filename := range pending {
+		v := utils.MatchMigrationFile(filename)[1]
+		if len(version) > 0 && v == version {
+			if i > 0 && !includeAll {
+				utils.CmdSuggestion = fmt.Sprintf("Run %s to apply all migrations up to this version.", utils.Aqua("supabase migration apply --to "+version))
+				return nil, nil, errors.Errorf("Migration %s must be applied before %s.", utils.Bold(pending[0]), utils.Bold(filename))
+			}
+			selected = append(selected, filename)
+		} else if len(target) > 0 && v <= target {
+			selected = append(selected, filename)
+		} else {
+			remaining = append(remaining, filename)
+		}
+	}
+	if len(selected) == 0 {
+		want := version
+		if len(want) == 0 {
+			want = target
+		}
+		utils.CmdSuggestion = fmt.Sprintf("Run %s to show pending migrations.", utils.Aqua("supabase migration list"))
+		return nil, nil, errors.Errorf("%w: %s", errNotPending, want)
+	}
+	return selected, remaining, nil
+}
+
+func printRemaining(remaining []string) {
+	if len(remaining) == 0 {
+		fmt.Fprintln(os.Stderr, "No pending migrations remain.")
+		return
+	}
+	fmt.Fprintf(os.Stderr, "%d pending migration(s) remain:\n", len(remaining))
+	for _, filename := range remaining {
+		fmt.Fprintln(os.Stderr, " •", utils.Bold(filepath.Join(utils.MigrationsDir, filename)))
+	}
+}
@@ -0,0 +1,117 @@
+package up
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/migration/history"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/migration/repair"
+	"github.com/supabase/cli/internal/testing/fstest"
+	"github.com/supabase/cli/internal/testing/pgtest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestRunTargeted(t *testing.T) {
+	t.Run("applies single pending migration", func(t *testing.T) {
+		defer fstest.MockStdin(t, "y")()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		sql := "create schema test"
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.MigrationsDir, "1_test.sql"), []byte(sql), 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.MigrationsDir, "2_test.sql"), []byte("drop schema test"), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(list.LIST_MIGRATION_VERSION).
+			Reply("SELECT 0")
+		pgtest.MockMigrationHistory(conn)
+		conn.Query(sql).
+			Reply("CREATE SCHEMA").
+			Query(history.INSERT_MIGRATION_VERSION, "1", "test", []string{sql}, history.Checksum([]string{sql})).
+			Reply("INSERT 0 1")
+		// Run test
+		err := RunTargeted(context.Background(), "1", "", false, false, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("prints migrations on dry run", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.MigrationsDir, "1_test.sql"), []byte(""), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(list.LIST_MIGRATION_VERSION).
+			Reply("SELECT 0")
+		// Run test
+		err := RunTargeted(context.Background(), "", "1", false, true, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on invalid version", func(t *testing.T) {
+		// Run test
+		err := RunTargeted(context.Background(), "invalid", "", false, false, dbConfig, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorIs(t, err, repair.ErrInvalidVersion)
+	})
+}
+
+func TestSelectPending(t *testing.T) {
+	pending := []string{
+		"20240101000000_a.sql",
+		"20240102000000_b.sql",
+		"20240103000000_c.sql",
+	}
+
+	t.Run("selects oldest pending migration", func(t *testing.T) {
+		selected, remaining, err := SelectPending(pending, "20240101000000", "", false)
+		assert.NoError(t, err)
+		assert.Equal(t, pending[:1], selected)
+		assert.Equal(t, pending[1:], remaining)
+	})
+
+	t.Run("selects out of order migration with include all", func(t *testing.T) {
+		selected, remaining, err := SelectPending(pending, "20240102000000", "", true)
+		assert.NoError(t, err)
+		assert.Equal(t, pending[1:2], selected)
+		assert.Equal(t, []string{pending[0], pending[2]}, remaining)
+	})
+
+	t.Run("selects migrations up to target", func(t *testing.T) {
+		selected, remaining, err := SelectPending(pending, "", "20240102120000", false)
+		assert.NoError(t, err)
+		assert.Equal(t, pending[:2], selected)
+		assert.Equal(t, pending[2:], remaining)
+	})
+
+	t.Run("throws error on out of order migration", func(t *testing.T) {
+		defer func() { utils.CmdSuggestion = "" }()
+		_, _, err := SelectPending(pending, "20240102000000", "", false)
+		assert.ErrorContains(t, err, "must be applied before")
+		assert.Contains(t, utils.CmdSuggestion, "--to 20240102000000")
+	})
+
+	t.Run("throws error on missing version", func(t *testing.T) {
+		defer func() { utils.CmdSuggestion = "" }()
+		_, _, err := SelectPending(pending, "20231231000000", "", false)
+		assert.ErrorIs(t, err, errNotPending)
+		_, _, err = SelectPending(pending, "", "20231231000000", false)
+		assert.ErrorIs(t, err, errNotPending)
+	})
+}
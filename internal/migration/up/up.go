@@ -50,7 +50,7 @@ func GetPendingMigrations(ctx context.Context, includeAll bool, conn *pgx.Conn,
 		remote := remoteMigrations[i]
 		filename := localMigrations[j]
 		// Check if migration has been applied before, LoadLocalMigrations guarantees a match
-		local := utils.MigrateFilePattern.FindStringSubmatch(filename)[1]
+		local := utils.MatchMigrationFile(filename)[1]
 		if remote == local {
 			j++
 			i++
@@ -69,12 +69,12 @@ func GetPendingMigrations(ctx context.Context, includeAll bool, conn *pgx.Conn,
 	}
 	if len(missing) > 0 {
 		utils.CmdSuggestion = suggestRevertHistory(missing)
-		return nil, errMissingLocal
+		return nil, utils.WithCode(utils.CodeMigrationDrift, errMissingLocal)
 	}
 	// Enforce migrations are applied in chronological order by default
 	if !includeAll && len(unapplied) > 0 {
 		utils.CmdSuggestion = suggestIgnoreFlag(unapplied)
-		return nil, errMissingRemote
+		return nil, utils.WithCode(utils.CodeMigrationDrift, errMissingRemote)
 	}
 	pending := localMigrations[len(remoteMigrations)+len(unapplied):]
 	return append(unapplied, pending...), nil
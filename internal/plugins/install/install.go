@@ -0,0 +1,91 @@
+package install
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/plugins"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(ctx context.Context, source, name, checksum string, fsys afero.Fs) error {
+	if len(name) == 0 {
+		name = strings.TrimPrefix(filepath.Base(source), plugins.Prefix)
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	if err := utils.ValidateFunctionSlug(name); err != nil {
+		return errors.Errorf("Invalid plugin name: %s. Use --name to specify a different name.", name)
+	}
+	if strings.HasPrefix(source, "http://") {
+		return errors.New("Plugins can only be downloaded over https.")
+	}
+	if isRemote(source) && len(checksum) == 0 {
+		return errors.New("Missing required flag for remote plugin: --sha256")
+	}
+	pluginsDir, err := plugins.GetPluginsDir()
+	if err != nil {
+		return err
+	}
+	r, err := openSource(ctx, source, fsys)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	// Verify the plugin before writing anything executable to disk
+	var buf bytes.Buffer
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(&buf, hash), r); err != nil {
+		return errors.Errorf("failed to read plugin: %w", err)
+	}
+	if len(checksum) > 0 {
+		if actual := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(actual, checksum) {
+			return errors.Errorf("Checksum mismatch for plugin %s: expected %s, got %s", name, checksum, actual)
+		}
+	}
+	if err := utils.MkdirIfNotExistFS(fsys, pluginsDir); err != nil {
+		return err
+	}
+	target := filepath.Join(pluginsDir, plugins.GetFilename(name))
+	if err := afero.WriteFile(fsys, target, buf.Bytes(), 0755); err != nil {
+		return errors.Errorf("failed to write plugin: %w", err)
+	}
+	fmt.Println("Installed plugin " + utils.Aqua(name) + " to " + utils.Bold(target))
+	fmt.Println("Run it with " + utils.Aqua("supabase "+name) + ".")
+	return nil
+}
+
+func isRemote(source string) bool {
+	return strings.HasPrefix(source, "https://")
+}
+
+func openSource(ctx context.Context, source string, fsys afero.Fs) (io.ReadCloser, error) {
+	if !isRemote(source) {
+		f, err := fsys.Open(source)
+		if err != nil {
+			return nil, errors.Errorf("failed to open plugin: %w", err)
+		}
+		return f, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, errors.Errorf("failed to initialise download request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Errorf("failed to download plugin: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, errors.Errorf("failed to download plugin: unexpected status %d", resp.StatusCode)
+	}
+	return resp.Body, nil
+}
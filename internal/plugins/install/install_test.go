@@ -0,0 +1,95 @@
+package install
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/plugins"
+	"github.com/supabase/cli/internal/testing/apitest"
+)
+
+func TestInstallCommand(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	pluginsDir := filepath.Join(home, ".supabase", "plugins")
+	// sha256 of "#!/bin/sh"
+	digest := "3af71adb278ad4af33c144b78fa1ae708da03b773d98324ae991a7daedb53ca2"
+
+	t.Run("installs plugin from file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "bin/supabase-mask", []byte("#!/bin/sh"), 0644))
+		// Run test
+		err := Run(context.Background(), "bin/supabase-mask", "", "", fsys)
+		// Check error
+		assert.NoError(t, err)
+		fi, err := fsys.Stat(filepath.Join(pluginsDir, plugins.GetFilename("mask")))
+		require.NoError(t, err)
+		assert.NotZero(t, fi.Mode().Perm()&0111)
+	})
+
+	t.Run("installs plugin from url", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock server
+		defer gock.OffAll()
+		gock.New("https://example.com").
+			Get("/releases/supabase-tenant").
+			Reply(http.StatusOK).
+			BodyString("#!/bin/sh")
+		// Run test
+		err := Run(context.Background(), "https://example.com/releases/supabase-tenant", "tenant", digest, fsys)
+		// Check error
+		assert.NoError(t, err)
+		exists, err := afero.Exists(fsys, filepath.Join(pluginsDir, plugins.GetFilename("tenant")))
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on checksum mismatch", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock server
+		defer gock.OffAll()
+		gock.New("https://example.com").
+			Get("/releases/supabase-tenant").
+			Reply(http.StatusOK).
+			BodyString("#!/bin/bash")
+		// Run test
+		err := Run(context.Background(), "https://example.com/releases/supabase-tenant", "tenant", digest, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Checksum mismatch for plugin tenant")
+		exists, err := afero.Exists(fsys, filepath.Join(pluginsDir, plugins.GetFilename("tenant")))
+		assert.NoError(t, err)
+		assert.False(t, exists)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing checksum", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), "https://example.com/releases/supabase-tenant", "tenant", "", afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Missing required flag for remote plugin: --sha256")
+	})
+
+	t.Run("throws error on insecure url", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), "http://example.com/releases/supabase-tenant", "tenant", digest, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Plugins can only be downloaded over https.")
+	})
+
+	t.Run("throws error on invalid name", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), "bin/supabase-mask", "Bad Name", "", afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Invalid plugin name: Bad Name")
+	})
+}
@@ -0,0 +1,21 @@
+package list
+
+import (
+	"fmt"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/plugins"
+)
+
+func Run(fsys afero.Fs) error {
+	found, err := plugins.FindPlugins(fsys)
+	if err != nil {
+		return err
+	}
+	table := "|NAME|PATH|\n|-|-|\n"
+	for _, p := range found {
+		table += fmt.Sprintf("|`%s`|`%s`|\n", p.Name, p.Path)
+	}
+	return list.RenderTable(table)
+}
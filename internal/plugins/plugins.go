@@ -0,0 +1,118 @@
+package plugins
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const Prefix = "supabase-"
+
+type Plugin struct {
+	Name string
+	Path string
+}
+
+// GetFilename returns the executable name of a plugin. Windows only runs files with an
+// executable extension, so plugins are named supabase-<name>.exe there.
+func GetFilename(name string) string {
+	filename := Prefix + name
+	if runtime.GOOS == "windows" {
+		filename += ".exe"
+	}
+	return filename
+}
+
+func GetPluginsDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.Errorf("failed to get $HOME directory: %w", err)
+	}
+	return filepath.Join(home, ".supabase", "plugins"), nil
+}
+
+// Returns all plugins found in the plugins directory and $PATH. When multiple
+// executables share a name, the one found first takes precedence.
+func FindPlugins(fsys afero.Fs) ([]Plugin, error) {
+	pluginsDir, err := GetPluginsDir()
+	if err != nil {
+		return nil, err
+	}
+	dirs := append([]string{pluginsDir}, filepath.SplitList(os.Getenv("PATH"))...)
+	var result []Plugin
+	seen := map[string]struct{}{}
+	for _, dir := range dirs {
+		entries, err := afero.ReadDir(fsys, dir)
+		if err != nil {
+			// Skip missing and unreadable directories in $PATH
+			continue
+		}
+		for _, fi := range entries {
+			name, ok := parsePluginName(fi.Name())
+			if !ok || fi.IsDir() || !isExecutable(fi) {
+				continue
+			}
+			if _, ok := seen[name]; ok {
+				continue
+			}
+			seen[name] = struct{}{}
+			result = append(result, Plugin{Name: name, Path: filepath.Join(dir, fi.Name())})
+		}
+	}
+	return result, nil
+}
+
+func LookupPlugin(name string, fsys afero.Fs) (string, bool) {
+	plugins, err := FindPlugins(fsys)
+	if err != nil {
+		return "", false
+	}
+	for _, p := range plugins {
+		if p.Name == name {
+			return p.Path, true
+		}
+	}
+	return "", false
+}
+
+// Runs the plugin executable, forwarding all remaining arguments and standard streams.
+func Exec(ctx context.Context, path string, args []string) error {
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), "SUPABASE_CLI_VERSION="+utils.Version)
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return errors.Errorf("plugin %s exited with code %d", filepath.Base(path), exitErr.ExitCode())
+		}
+		return errors.Errorf("failed to run plugin: %w", err)
+	}
+	return nil
+}
+
+func parsePluginName(filename string) (string, bool) {
+	if !strings.HasPrefix(filename, Prefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(filename, Prefix)
+	if runtime.GOOS == "windows" {
+		var found bool
+		if name, found = strings.CutSuffix(name, ".exe"); !found {
+			return "", false
+		}
+	}
+	return name, len(name) > 0
+}
+
+func isExecutable(fi os.FileInfo) bool {
+	return runtime.GOOS == "windows" || fi.Mode().Perm()&0111 != 0
+}
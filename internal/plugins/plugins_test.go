@@ -0,0 +1,49 @@
+package plugins
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindPlugins(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	pluginsDir := filepath.Join(home, ".supabase", "plugins")
+	binDir := filepath.Join(home, "bin")
+	t.Setenv("PATH", binDir)
+
+	t.Run("finds executables with prefix", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(pluginsDir, GetFilename("mask")), []byte{}, 0755))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(binDir, GetFilename("mask")), []byte{}, 0755))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(binDir, GetFilename("tenant")), []byte{}, 0755))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(binDir, GetFilename("readme")), []byte{}, 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(binDir, "psql"), []byte{}, 0755))
+		// Run test
+		plugins, err := FindPlugins(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []Plugin{
+			{Name: "mask", Path: filepath.Join(pluginsDir, GetFilename("mask"))},
+			{Name: "tenant", Path: filepath.Join(binDir, GetFilename("tenant"))},
+		}, plugins)
+	})
+
+	t.Run("looks up plugin by name", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(binDir, GetFilename("tenant")), []byte{}, 0755))
+		// Run test
+		path, ok := LookupPlugin("tenant", fsys)
+		// Check error
+		assert.True(t, ok)
+		assert.Equal(t, filepath.Join(binDir, GetFilename("tenant")), path)
+		_, ok = LookupPlugin("missing", fsys)
+		assert.False(t, ok)
+	})
+}
@@ -3,6 +3,7 @@ package apiKeys
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/go-errors/errors"
@@ -12,24 +13,49 @@ import (
 	"github.com/supabase/cli/pkg/api"
 )
 
-func Run(ctx context.Context, projectRef string, fsys afero.Fs) error {
-	keys, err := RunGetApiKeys(ctx, projectRef)
+// Run lists all api keys of the project, or only the key matching name. Secret keys
+// are revealed only when requested by name, so that listing never leaks them.
+func Run(ctx context.Context, projectRef, name, format string, fsys afero.Fs) error {
+	if format == utils.OutputRaw && len(name) == 0 {
+		return errors.New("Must specify --name with raw output.")
+	}
+	keys, err := RunGetApiKeys(ctx, projectRef, len(name) > 0)
 	if err != nil {
 		return err
 	}
+	if len(name) > 0 {
+		key, err := FindApiKey(keys, name)
+		if err != nil {
+			return err
+		}
+		keys = []api.ApiKeyResponse{key}
+	}
+	switch format {
+	case utils.OutputRaw:
+		fmt.Println(keys[0].ApiKey)
+		return nil
+	case utils.OutputPretty:
+		return list.RenderTable(toMarkdown(keys))
+	}
+	return utils.EncodeOutput(format, os.Stdout, keys)
+}
 
+func toMarkdown(keys []api.ApiKeyResponse) string {
 	table := `|NAME|KEY VALUE|
 |-|-|
 `
 	for _, entry := range keys {
 		table += fmt.Sprintf("|`%s`|`%s`|\n", strings.ReplaceAll(entry.Name, "|", "\\|"), entry.ApiKey)
 	}
-
-	return list.RenderTable(table)
+	return table
 }
 
-func RunGetApiKeys(ctx context.Context, projectRef string) ([]api.ApiKeyResponse, error) {
-	resp, err := utils.GetSupabase().V1GetProjectApiKeysWithResponse(ctx, projectRef)
+func RunGetApiKeys(ctx context.Context, projectRef string, reveal bool) ([]api.ApiKeyResponse, error) {
+	params := api.V1GetProjectApiKeysParams{}
+	if reveal {
+		params.Reveal = &reveal
+	}
+	resp, err := utils.GetSupabase().V1GetProjectApiKeysWithResponse(ctx, projectRef, &params)
 	if err != nil {
 		return nil, errors.Errorf("failed to get api keys: %w", err)
 	}
@@ -38,3 +64,15 @@ func RunGetApiKeys(ctx context.Context, projectRef string) ([]api.ApiKeyResponse
 	}
 	return *resp.JSON200, nil
 }
+
+// FindApiKey returns the key matching either name or id.
+func FindApiKey(keys []api.ApiKeyResponse, name string) (api.ApiKeyResponse, error) {
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		if key.Name == name || (key.Id != nil && *key.Id == name) {
+			return key, nil
+		}
+		names[i] = key.Name
+	}
+	return api.ApiKeyResponse{}, errors.Errorf("API key not found: %s. Must be one of: %v", utils.Bold(name), names)
+}
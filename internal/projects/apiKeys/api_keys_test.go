@@ -32,17 +32,72 @@ func TestProjectApiKeysCommand(t *testing.T) {
 				ApiKey: "dummy-api-key-value",
 			}})
 		// Run test
-		err := Run(context.Background(), project, fsys)
+		err := Run(context.Background(), project, "", utils.OutputPretty, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
 
+	t.Run("prints raw key by name", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/"+project+"/api-keys").
+			MatchParam("reveal", "true").
+			Reply(200).
+			JSON([]api.ApiKeyResponse{
+				{Name: "anon", ApiKey: "anon-key"},
+				{Name: "service_role", ApiKey: "service-key"},
+			})
+		// Run test
+		err := Run(context.Background(), project, "anon", utils.OutputRaw, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on raw output without name", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Run(context.Background(), apitest.RandomProjectRef(), "", utils.OutputRaw, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Must specify --name with raw output.")
+	})
+
+	t.Run("throws error on unknown name", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/api-keys").
+			Reply(200).
+			JSON([]api.ApiKeyResponse{{Name: "anon", ApiKey: "anon-key"}})
+		// Run test
+		err := Run(context.Background(), project, "missing", utils.OutputRaw, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "API key not found: missing. Must be one of: [anon]")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
 	t.Run("throws error on missing access token", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := Run(context.Background(), "", fsys)
+		err := Run(context.Background(), "", "", utils.OutputPretty, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "Unexpected error retrieving project api-keys")
 	})
@@ -61,7 +116,7 @@ func TestProjectApiKeysCommand(t *testing.T) {
 			Get("/v1/projects/" + project + "/api-keys").
 			ReplyError(errors.New("network error"))
 		// Run test
-		err := Run(context.Background(), project, fsys)
+		err := Run(context.Background(), project, "", utils.OutputPretty, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "network error")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
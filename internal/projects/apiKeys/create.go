@@ -0,0 +1,37 @@
+package apiKeys
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+// RunCreate creates a publishable or secret key, printing only its value to stdout.
+func RunCreate(ctx context.Context, projectRef, keyType, description string) error {
+	key, err := createApiKey(ctx, projectRef, api.CreateApiKeyBodyType(keyType), description)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Created "+keyType+" key "+utils.Aqua(key.Name)+".")
+	fmt.Println(key.ApiKey)
+	return nil
+}
+
+func createApiKey(ctx context.Context, projectRef string, keyType api.CreateApiKeyBodyType, description string) (api.ApiKeyResponse, error) {
+	body := api.V1CreateProjectApiKeyJSONRequestBody{Type: keyType}
+	if len(description) > 0 {
+		body.Description = &description
+	}
+	resp, err := utils.GetSupabase().V1CreateProjectApiKeyWithResponse(ctx, projectRef, body)
+	if err != nil {
+		return api.ApiKeyResponse{}, errors.Errorf("failed to create api key: %w", err)
+	}
+	if resp.JSON201 == nil {
+		return api.ApiKeyResponse{}, errors.New("Unexpected error creating api key: " + string(resp.Body))
+	}
+	return *resp.JSON201, nil
+}
@@ -0,0 +1,36 @@
+package apiKeys
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestCreateApiKey(t *testing.T) {
+	t.Run("creates publishable key", func(t *testing.T) {
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/api-keys").
+			MatchType("json").
+			JSON(api.CreateApiKeyBody{Type: api.CreateApiKeyBodyTypePublishable}).
+			Reply(http.StatusCreated).
+			JSON(api.ApiKeyResponse{Name: "default", ApiKey: "sb_publishable_key"})
+		// Run test
+		err := RunCreate(context.Background(), project, "publishable", "")
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -0,0 +1,64 @@
+package apiKeys
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+var ErrLegacyKey = errors.New("Legacy anon and service_role keys are signed by the project's JWT secret and cannot be rotated individually. Create a publishable or secret key to replace them instead.")
+
+// RunRotate creates a new key with the same type and description as a publishable or secret
+// key. The old key stays valid unless revokeOld is set and the user confirms its deletion.
+// Only the new value is printed to stdout.
+func RunRotate(ctx context.Context, projectRef, name string, revokeOld bool) error {
+	keys, err := RunGetApiKeys(ctx, projectRef, false)
+	if err != nil {
+		return err
+	}
+	old, err := FindApiKey(keys, name)
+	if err != nil {
+		return err
+	}
+	if old.Id == nil || old.Type == nil || *old.Type == api.ApiKeyResponseTypeLegacy {
+		return errors.New(ErrLegacyKey)
+	}
+	var description string
+	if old.Description != nil {
+		description = *old.Description
+	}
+	// Creates the new key first so that clients are never left without a valid key
+	key, err := createApiKey(ctx, projectRef, api.CreateApiKeyBodyType(*old.Type), description)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Created "+string(*old.Type)+" key "+utils.Aqua(key.Name)+" to replace "+utils.Aqua(old.Name)+".")
+	fmt.Println(key.ApiKey)
+	if revokeOld {
+		title := fmt.Sprintf("Do you want to revoke the old key %s? Clients still using it will stop working.", utils.Aqua(old.Name))
+		if shouldRevoke, err := utils.NewConsole().PromptYesNo(ctx, title, false); err != nil {
+			return err
+		} else if shouldRevoke {
+			return revokeApiKey(ctx, projectRef, *old.Id, old.Name)
+		}
+	}
+	url := fmt.Sprintf("%s/project/%s/settings/api-keys", utils.GetSupabaseDashboardURL(), projectRef)
+	fmt.Fprintln(os.Stderr, "The old key "+utils.Aqua(old.Name)+" is still valid. Revoke it once your clients use the new key:", url)
+	return nil
+}
+
+func revokeApiKey(ctx context.Context, projectRef, id, name string) error {
+	resp, err := utils.GetSupabase().V1DeleteProjectApiKeyWithResponse(ctx, projectRef, id)
+	if err != nil {
+		return errors.Errorf("failed to delete api key: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return errors.New("Unexpected error deleting api key: " + string(resp.Body))
+	}
+	fmt.Fprintln(os.Stderr, "Revoked the old key "+utils.Aqua(name)+".")
+	return nil
+}
@@ -0,0 +1,119 @@
+package apiKeys
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/testing/fstest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestRotateApiKey(t *testing.T) {
+	secretType := api.ApiKeyResponseTypeSecret
+	legacyType := api.ApiKeyResponseTypeLegacy
+
+	t.Run("keeps old key by default", func(t *testing.T) {
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{Id: utils.Ptr("old-id"), Name: "default", ApiKey: "sb_secret_old", Type: &secretType}})
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/api-keys").
+			Reply(http.StatusCreated).
+			JSON(api.ApiKeyResponse{Id: utils.Ptr("new-id"), Name: "backend", ApiKey: "sb_secret_new", Type: &secretType})
+		// Run test
+		err := RunRotate(context.Background(), project, "default", false)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("revokes old key on confirm", func(t *testing.T) {
+		defer fstest.MockStdin(t, "y")()
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{
+				Id:          utils.Ptr("old-id"),
+				Name:        "default",
+				ApiKey:      "sb_secret_old",
+				Type:        &secretType,
+				Description: utils.Ptr("backend"),
+			}})
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/api-keys").
+			MatchType("json").
+			JSON(api.CreateApiKeyBody{Type: api.CreateApiKeyBodyTypeSecret, Description: utils.Ptr("backend")}).
+			Reply(http.StatusCreated).
+			JSON(api.ApiKeyResponse{Id: utils.Ptr("new-id"), Name: "backend", ApiKey: "sb_secret_new", Type: &secretType})
+		gock.New(utils.DefaultApiHost).
+			Delete("/v1/projects/" + project + "/api-keys/old-id").
+			Reply(http.StatusOK).
+			JSON(api.ApiKeyResponse{Id: utils.Ptr("old-id"), Name: "default", ApiKey: "sb_secret_old"})
+		// Run test
+		err := RunRotate(context.Background(), project, "default", true)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on legacy key", func(t *testing.T) {
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{Name: "anon", ApiKey: "anon-key", Type: &legacyType}})
+		// Run test
+		err := RunRotate(context.Background(), project, "anon", true)
+		// Check error
+		assert.ErrorIs(t, err, ErrLegacyKey)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on create failure", func(t *testing.T) {
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{Id: utils.Ptr("old-id"), Name: "default", ApiKey: "sb_secret_old", Type: &secretType}})
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/api-keys").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		err := RunRotate(context.Background(), project, "default", true)
+		// Check error
+		assert.ErrorContains(t, err, "Unexpected error creating api key:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
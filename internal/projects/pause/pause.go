@@ -0,0 +1,68 @@
+package pause
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func Run(ctx context.Context, ref string, wait bool, fsys afero.Fs) error {
+	if err := utils.AssertProjectRefIsValid(ref); err != nil {
+		return err
+	}
+	resp, err := utils.GetSupabase().V1PauseAProjectWithResponse(ctx, ref)
+	if err != nil {
+		return errors.Errorf("failed to pause project: %w", err)
+	}
+	switch resp.StatusCode() {
+	case http.StatusNotFound:
+		return errors.New("Project does not exist: " + utils.Aqua(ref))
+	case http.StatusOK:
+		break
+	default:
+		return errors.Errorf("Failed to pause project %s: %s", utils.Aqua(ref), string(resp.Body))
+	}
+	fmt.Fprintln(os.Stderr, "Pausing project: "+utils.Aqua(ref))
+	if wait {
+		if err := WaitForStatus(ctx, ref, api.V1ProjectResponseStatusINACTIVE); err != nil {
+			return err
+		}
+		fmt.Println("Paused project: " + utils.Aqua(ref))
+	}
+	return nil
+}
+
+var PollInterval = 5 * time.Second
+
+// Pausing and restoring a project may take several minutes depending on database size
+const maxPollRetries = 360
+
+// WaitForStatus polls the project until it reaches the given status.
+func WaitForStatus(ctx context.Context, ref string, status api.V1ProjectResponseStatus) error {
+	fmt.Fprintf(os.Stderr, "Waiting for project to become %s...\n", status)
+	policy := backoff.WithContext(backoff.WithMaxRetries(backoff.NewConstantBackOff(PollInterval), maxPollRetries), ctx)
+	return backoff.Retry(func() error {
+		resp, err := utils.GetSupabase().V1GetProjectWithResponse(ctx, ref)
+		if err != nil {
+			return errors.Errorf("failed to get project: %w", err)
+		}
+		if resp.JSON200 == nil {
+			return errors.Errorf("Error status %d: %s", resp.StatusCode(), resp.Body)
+		}
+		switch resp.JSON200.Status {
+		case status:
+			return nil
+		case api.V1ProjectResponseStatusINITFAILED, api.V1ProjectResponseStatusREMOVED:
+			return backoff.Permanent(errors.Errorf("Project %s is %s.", utils.Aqua(ref), resp.JSON200.Status))
+		}
+		return errors.Errorf("Project status: %s", resp.JSON200.Status)
+	}, policy)
+}
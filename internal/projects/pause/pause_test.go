@@ -0,0 +1,100 @@
+package pause
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestPauseCommand(t *testing.T) {
+	ref := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	PollInterval = 0
+
+	t.Run("pauses project", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + ref + "/pause").
+			Reply(http.StatusOK)
+		// Run test
+		err := Run(context.Background(), ref, false, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("waits for inactive status", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + ref + "/pause").
+			Reply(http.StatusOK)
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + ref).
+			Reply(http.StatusOK).
+			JSON(api.V1ProjectResponse{Id: ref, Status: api.V1ProjectResponseStatusPAUSING})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + ref).
+			Reply(http.StatusOK).
+			JSON(api.V1ProjectResponse{Id: ref, Status: api.V1ProjectResponseStatusINACTIVE})
+		// Run test
+		err := Run(context.Background(), ref, true, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on removed project", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + ref + "/pause").
+			Reply(http.StatusOK)
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + ref).
+			Reply(http.StatusOK).
+			JSON(api.V1ProjectResponse{Id: ref, Status: api.V1ProjectResponseStatusREMOVED})
+		// Run test
+		err := Run(context.Background(), ref, true, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "is REMOVED.")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on network failure", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + ref + "/pause").
+			ReplyError(errors.New("network error"))
+		// Run test
+		err := Run(context.Background(), ref, false, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "network error")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on project not found", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + ref + "/pause").
+			Reply(http.StatusNotFound)
+		// Run test
+		err := Run(context.Background(), ref, false, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Project does not exist:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
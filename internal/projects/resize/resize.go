@@ -0,0 +1,342 @@
+package resize
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+type DiskParams struct {
+	Type            string
+	SizeGb          int
+	Iops            int
+	ThroughputMibps int
+}
+
+func (p DiskParams) IsEmpty() bool {
+	return len(p.Type) == 0 && p.SizeGb == 0 && p.Iops == 0 && p.ThroughputMibps == 0
+}
+
+type change struct {
+	resource string
+	current  string
+	next     string
+	cost     string
+}
+
+var (
+	// Compute changes take a while to begin restarting the project
+	restartDelay = 10 * time.Second
+	pollInterval = 5 * time.Second
+)
+
+const maxPollRetries = 120
+
+// Monthly prices of disk resources in USD, excluding usage included in the plan.
+// Ref: https://supabase.com/docs/guides/platform/manage-your-usage/disk-size
+// Ref: https://supabase.com/docs/guides/platform/manage-your-usage/disk-iops
+// Ref: https://supabase.com/docs/guides/platform/manage-your-usage/disk-throughput
+type diskPrice struct {
+	perGb, perIops, perMibps float64
+	// Provisioned performance that is free of charge
+	baseIops, baseMibps int
+}
+
+var diskPrices = map[api.DiskAttributesType]diskPrice{
+	api.Gp3: {perGb: 0.125, perIops: 0.024, perMibps: 0.095, baseIops: 3000, baseMibps: 125},
+	api.Io2: {perGb: 0.195, perIops: 0.119},
+}
+
+// Run previews the changes to compute size and disk, including the difference in
+// price of compute add-ons, and applies them after confirmation.
+func Run(ctx context.Context, projectRef, compute string, disk DiskParams, wait bool, fsys afero.Fs) error {
+	if len(compute) == 0 && disk.IsEmpty() {
+		return errors.New("Nothing to resize. Specify --compute or any of the --disk flags.")
+	}
+	var changes []change
+	var variant *api.AddonVariant
+	if len(compute) > 0 {
+		current, next, err := resolveCompute(ctx, projectRef, compute)
+		if err != nil {
+			return err
+		}
+		if current == nil || current.Id != next.Id {
+			variant = next
+			changes = append(changes, computeChange(current, next))
+		}
+	}
+	var attributes *api.DiskAttributes
+	if !disk.IsEmpty() {
+		current, err := getDisk(ctx, projectRef)
+		if err != nil {
+			return err
+		}
+		next, err := mergeDisk(current, disk)
+		if err != nil {
+			return err
+		}
+		if diskChanges := diffDisk(current, next); len(diskChanges) > 0 {
+			attributes = &next
+			changes = append(changes, diskChanges...)
+			changes = append(changes, diskCostChange(current, next))
+		}
+	}
+	if len(changes) == 0 {
+		fmt.Fprintln(os.Stderr, "Project "+utils.Aqua(projectRef)+" already has the requested size.")
+		return nil
+	}
+	if err := list.RenderTable(toMarkdown(changes)); err != nil {
+		return err
+	}
+	msg := "Do you want to apply these changes?"
+	if variant != nil {
+		msg = "Changing compute size restarts your project. " + msg
+	}
+	if shouldResize, err := utils.NewConsole().PromptYesNo(ctx, msg, false); err != nil {
+		return err
+	} else if !shouldResize {
+		return errors.New(context.Canceled)
+	}
+	if variant != nil {
+		if err := applyCompute(ctx, projectRef, variant.Id); err != nil {
+			return err
+		}
+	}
+	if attributes != nil {
+		if err := applyDisk(ctx, projectRef, *attributes); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintln(os.Stderr, "Finished "+utils.Aqua("supabase projects resize")+".")
+	if wait {
+		return waitForHealthy(ctx, projectRef)
+	}
+	return nil
+}
+
+// Returns the selected compute variant, which may be nil, and the requested variant
+// matched by either its id or name.
+func resolveCompute(ctx context.Context, projectRef, compute string) (*api.AddonVariant, *api.AddonVariant, error) {
+	resp, err := utils.GetSupabase().V1ListProjectAddonsWithResponse(ctx, projectRef)
+	if err != nil {
+		return nil, nil, errors.Errorf("failed to list project addons: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return nil, nil, errors.New("Unexpected error listing project addons: " + string(resp.Body))
+	}
+	var current *api.AddonVariant
+	for _, addon := range resp.JSON200.SelectedAddons {
+		if addon.Type == api.ComputeInstance {
+			current = &addon.Variant
+		}
+	}
+	var allowed []string
+	for _, addon := range resp.JSON200.AvailableAddons {
+		if addon.Type != api.ComputeInstance {
+			continue
+		}
+		for i, v := range addon.Variants {
+			if v.Id == compute || strings.EqualFold(v.Name, compute) {
+				return current, &addon.Variants[i], nil
+			}
+			allowed = append(allowed, v.Id)
+		}
+	}
+	return nil, nil, errors.Errorf("Invalid compute size: %s. Must be one of: %v", utils.Bold(compute), allowed)
+}
+
+func computeChange(current, next *api.AddonVariant) change {
+	result := change{resource: "Compute", current: "-", next: formatVariant(*next)}
+	if current != nil {
+		result.current = formatVariant(*current)
+		if current.Price.Interval == next.Price.Interval {
+			diff := next.Price.Amount - current.Price.Amount
+			result.cost = fmt.Sprintf("%+.2f USD %s", diff, next.Price.Interval)
+		}
+	} else {
+		result.cost = fmt.Sprintf("%+.2f USD %s", next.Price.Amount, next.Price.Interval)
+	}
+	return result
+}
+
+func formatVariant(v api.AddonVariant) string {
+	return fmt.Sprintf("%s (%s)", v.Name, v.Price.Description)
+}
+
+func getDisk(ctx context.Context, projectRef string) (api.DiskAttributes, error) {
+	resp, err := utils.GetSupabase().V1GetProjectDiskConfigWithResponse(ctx, projectRef)
+	if err != nil {
+		return api.DiskAttributes{}, errors.Errorf("failed to get disk config: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return api.DiskAttributes{}, errors.New("Unexpected error retrieving disk config: " + string(resp.Body))
+	}
+	return resp.JSON200.Attributes, nil
+}
+
+func mergeDisk(current api.DiskAttributes, disk DiskParams) (api.DiskAttributes, error) {
+	result := current
+	if len(disk.Type) > 0 {
+		result.Type = api.DiskAttributesType(disk.Type)
+	}
+	if disk.SizeGb > 0 {
+		// Disks can only grow because the file system cannot be shrunk in place
+		if disk.SizeGb < current.SizeGb {
+			return result, errors.Errorf("Disk size cannot be reduced from %d GB to %d GB.", current.SizeGb, disk.SizeGb)
+		}
+		result.SizeGb = disk.SizeGb
+	}
+	if disk.Iops > 0 {
+		result.Iops = disk.Iops
+	}
+	if disk.ThroughputMibps > 0 {
+		result.ThroughputMibps = &disk.ThroughputMibps
+	}
+	return result, nil
+}
+
+func diffDisk(current, next api.DiskAttributes) []change {
+	var result []change
+	if current.Type != next.Type {
+		result = append(result, change{resource: "Disk type", current: string(current.Type), next: string(next.Type)})
+	}
+	if current.SizeGb != next.SizeGb {
+		result = append(result, change{resource: "Disk size", current: fmt.Sprintf("%d GB", current.SizeGb), next: fmt.Sprintf("%d GB", next.SizeGb)})
+	}
+	if current.Iops != next.Iops {
+		result = append(result, change{resource: "Disk IOPS", current: fmt.Sprintf("%d", current.Iops), next: fmt.Sprintf("%d", next.Iops)})
+	}
+	if next.ThroughputMibps != nil && (current.ThroughputMibps == nil || *current.ThroughputMibps != *next.ThroughputMibps) {
+		value := "-"
+		if current.ThroughputMibps != nil {
+			value = fmt.Sprintf("%d MiB/s", *current.ThroughputMibps)
+		}
+		result = append(result, change{resource: "Disk throughput", current: value, next: fmt.Sprintf("%d MiB/s", *next.ThroughputMibps)})
+	}
+	return result
+}
+
+// Estimates the difference in monthly price of the disk, which is billed by usage.
+func diskCostChange(current, next api.DiskAttributes) change {
+	result := change{resource: "Disk cost (estimate)", current: "-", next: "-"}
+	before, ok := estimateDiskCost(current)
+	if !ok {
+		result.cost = "unknown"
+		return result
+	}
+	after, ok := estimateDiskCost(next)
+	if !ok {
+		result.cost = "unknown"
+		return result
+	}
+	result.current = fmt.Sprintf("%.2f USD month", before)
+	result.next = fmt.Sprintf("%.2f USD month", after)
+	result.cost = fmt.Sprintf("%+.2f USD month", after-before)
+	return result
+}
+
+func estimateDiskCost(disk api.DiskAttributes) (float64, bool) {
+	price, ok := diskPrices[disk.Type]
+	if !ok {
+		return 0, false
+	}
+	cost := float64(disk.SizeGb) * price.perGb
+	if disk.Iops > price.baseIops {
+		cost += float64(disk.Iops-price.baseIops) * price.perIops
+	}
+	if disk.ThroughputMibps != nil && *disk.ThroughputMibps > price.baseMibps {
+		cost += float64(*disk.ThroughputMibps-price.baseMibps) * price.perMibps
+	}
+	return cost, true
+}
+
+func toMarkdown(changes []change) string {
+	table := `|RESOURCE|CURRENT|NEW|COST|
+|-|-|-|-|
+`
+	for _, c := range changes {
+		cost := c.cost
+		if len(cost) == 0 {
+			cost = "-"
+		}
+		table += fmt.Sprintf("|`%s`|`%s`|`%s`|`%s`|\n", c.resource, c.current, c.next, cost)
+	}
+	return table
+}
+
+func applyCompute(ctx context.Context, projectRef, variant string) error {
+	resp, err := utils.GetSupabase().V1ApplyProjectAddonWithResponse(ctx, projectRef, api.V1ApplyProjectAddonJSONRequestBody{
+		AddonType:    api.ComputeInstance,
+		AddonVariant: variant,
+	})
+	if err != nil {
+		return errors.Errorf("failed to change compute size: %w", err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return errors.New("Unexpected error changing compute size: " + string(resp.Body))
+	}
+	return nil
+}
+
+func applyDisk(ctx context.Context, projectRef string, attributes api.DiskAttributes) error {
+	resp, err := utils.GetSupabase().V1ModifyProjectDiskConfigWithResponse(ctx, projectRef, api.V1ModifyProjectDiskConfigJSONRequestBody{
+		Attributes: attributes,
+	})
+	if err != nil {
+		return errors.Errorf("failed to modify disk config: %w", err)
+	}
+	if resp.StatusCode() != http.StatusCreated {
+		return errors.New("Unexpected error modifying disk config: " + string(resp.Body))
+	}
+	return nil
+}
+
+func waitForHealthy(ctx context.Context, projectRef string) error {
+	fmt.Fprintln(os.Stderr, "Waiting for project to restart...")
+	select {
+	case <-ctx.Done():
+		return errors.New(ctx.Err())
+	case <-time.After(restartDelay):
+	}
+	policy := backoff.WithContext(backoff.WithMaxRetries(backoff.NewConstantBackOff(pollInterval), maxPollRetries), ctx)
+	if err := backoff.Retry(func() error {
+		return checkHealth(ctx, projectRef)
+	}, policy); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Project "+utils.Aqua(projectRef)+" is healthy.")
+	return nil
+}
+
+func checkHealth(ctx context.Context, projectRef string) error {
+	params := api.V1GetServicesHealthParams{
+		Services: []api.V1GetServicesHealthParamsServices{
+			api.V1GetServicesHealthParamsServicesDb,
+			api.V1GetServicesHealthParamsServicesRest,
+			api.V1GetServicesHealthParamsServicesAuth,
+		},
+	}
+	resp, err := utils.GetSupabase().V1GetServicesHealthWithResponse(ctx, projectRef, &params)
+	if err != nil {
+		return errors.Errorf("failed to check project health: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return errors.Errorf("Error status %d: %s", resp.StatusCode(), resp.Body)
+	}
+	for _, service := range *resp.JSON200 {
+		if !service.Healthy {
+			return errors.Errorf("Service not healthy: %s (%s)", service.Name, service.Status)
+		}
+	}
+	return nil
+}
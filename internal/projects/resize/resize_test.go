@@ -0,0 +1,213 @@
+package resize
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/testing/fstest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+var (
+	computeMicro = api.AddonVariant{
+		Id:    "ci_micro",
+		Name:  "Micro",
+		Price: api.AddonVariantPrice{Amount: 10, Description: "$10/month", Interval: api.Monthly, Type: api.Fixed},
+	}
+	computeSmall = api.AddonVariant{
+		Id:    "ci_small",
+		Name:  "Small",
+		Price: api.AddonVariantPrice{Amount: 15, Description: "$15/month", Interval: api.Monthly, Type: api.Fixed},
+	}
+	addons = api.ListProjectAddonsResponse{
+		SelectedAddons: []api.SelectedAddon{{Type: api.ComputeInstance, Variant: computeMicro}},
+		AvailableAddons: []api.AvailableAddon{{
+			Name:     "Compute Instance",
+			Type:     api.ComputeInstance,
+			Variants: []api.AddonVariant{computeMicro, computeSmall},
+		}},
+	}
+	disk = api.DiskResponse{Attributes: api.DiskAttributes{Type: api.Gp3, SizeGb: 8, Iops: 3000}}
+)
+
+func TestResizeCommand(t *testing.T) {
+	restartDelay = 0
+	pollInterval = 0
+
+	t.Run("resizes compute and disk", func(t *testing.T) {
+		fstest.MockStdin(t, "y")
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/billing/addons").
+			Reply(http.StatusOK).
+			JSON(addons)
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/config/disk").
+			Reply(http.StatusOK).
+			JSON(disk)
+		gock.New(utils.DefaultApiHost).
+			Patch("/v1/projects/" + project + "/billing/addons").
+			MatchType("json").
+			JSON(api.ApplyProjectAddonBody{AddonType: api.ComputeInstance, AddonVariant: "ci_small"}).
+			Reply(http.StatusOK)
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/config/disk").
+			MatchType("json").
+			JSON(api.DiskRequestBody{Attributes: api.DiskAttributes{Type: api.Gp3, SizeGb: 16, Iops: 3000}}).
+			Reply(http.StatusCreated)
+		// Run test
+		err := Run(context.Background(), project, "small", DiskParams{SizeGb: 16}, false, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("waits for healthy project", func(t *testing.T) {
+		fstest.MockStdin(t, "y")
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/billing/addons").
+			Reply(http.StatusOK).
+			JSON(addons)
+		gock.New(utils.DefaultApiHost).
+			Patch("/v1/projects/" + project + "/billing/addons").
+			Reply(http.StatusOK)
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/health").
+			Reply(http.StatusOK).
+			JSON([]api.V1ServiceHealthResponse{{Name: api.V1ServiceHealthResponseNameDb, Healthy: false, Status: api.V1ServiceHealthResponseStatusCOMINGUP}})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/health").
+			Reply(http.StatusOK).
+			JSON([]api.V1ServiceHealthResponse{{Name: api.V1ServiceHealthResponseNameDb, Healthy: true, Status: api.V1ServiceHealthResponseStatusACTIVEHEALTHY}})
+		// Run test
+		err := Run(context.Background(), project, "ci_small", DiskParams{}, true, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("skips unchanged size", func(t *testing.T) {
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/billing/addons").
+			Reply(http.StatusOK).
+			JSON(addons)
+		// Run test
+		err := Run(context.Background(), project, "ci_micro", DiskParams{}, false, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on cancel", func(t *testing.T) {
+		fstest.MockStdin(t, "n")
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/config/disk").
+			Reply(http.StatusOK).
+			JSON(disk)
+		// Run test
+		err := Run(context.Background(), project, "", DiskParams{Type: string(api.Io2)}, false, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on invalid compute", func(t *testing.T) {
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/billing/addons").
+			Reply(http.StatusOK).
+			JSON(addons)
+		// Run test
+		err := Run(context.Background(), project, "huge", DiskParams{}, false, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Invalid compute size:")
+		assert.ErrorContains(t, err, "[ci_micro ci_small]")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on smaller disk", func(t *testing.T) {
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/config/disk").
+			Reply(http.StatusOK).
+			JSON(disk)
+		// Run test
+		err := Run(context.Background(), project, "", DiskParams{SizeGb: 4}, false, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Disk size cannot be reduced from 8 GB to 4 GB.")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing flags", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), apitest.RandomProjectRef(), "", DiskParams{}, false, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Nothing to resize.")
+	})
+}
+
+func TestDiskCost(t *testing.T) {
+	t.Run("estimates gp3 cost above baseline", func(t *testing.T) {
+		current := api.DiskAttributes{Type: api.Gp3, SizeGb: 8, Iops: 3000, ThroughputMibps: utils.Ptr(125)}
+		next := api.DiskAttributes{Type: api.Gp3, SizeGb: 16, Iops: 4000, ThroughputMibps: utils.Ptr(225)}
+		// Run test
+		c := diskCostChange(current, next)
+		// Check error
+		assert.Equal(t, "1.00 USD month", c.current)
+		assert.Equal(t, "35.50 USD month", c.next)
+		assert.Equal(t, "+34.50 USD month", c.cost)
+	})
+
+	t.Run("reports unknown disk type", func(t *testing.T) {
+		current := api.DiskAttributes{Type: "st1", SizeGb: 8}
+		// Run test
+		c := diskCostChange(current, current)
+		// Check error
+		assert.Equal(t, "unknown", c.cost)
+	})
+}
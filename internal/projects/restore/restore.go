@@ -0,0 +1,40 @@
+package restore
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/projects/pause"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func Run(ctx context.Context, ref string, wait bool, fsys afero.Fs) error {
+	if err := utils.AssertProjectRefIsValid(ref); err != nil {
+		return err
+	}
+	resp, err := utils.GetSupabase().V1RestoreAProjectWithResponse(ctx, ref)
+	if err != nil {
+		return errors.Errorf("failed to restore project: %w", err)
+	}
+	switch resp.StatusCode() {
+	case http.StatusNotFound:
+		return errors.New("Project does not exist: " + utils.Aqua(ref))
+	case http.StatusOK:
+		break
+	default:
+		return errors.Errorf("Failed to restore project %s: %s", utils.Aqua(ref), string(resp.Body))
+	}
+	fmt.Fprintln(os.Stderr, "Restoring project: "+utils.Aqua(ref))
+	if wait {
+		if err := pause.WaitForStatus(ctx, ref, api.V1ProjectResponseStatusACTIVEHEALTHY); err != nil {
+			return err
+		}
+		fmt.Println("Restored project: " + utils.Aqua(ref))
+	}
+	return nil
+}
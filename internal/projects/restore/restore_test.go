@@ -0,0 +1,58 @@
+package restore
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/projects/pause"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestRestoreCommand(t *testing.T) {
+	ref := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	pause.PollInterval = 0
+
+	t.Run("restores project", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + ref + "/restore").
+			Reply(http.StatusOK)
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + ref).
+			Reply(http.StatusOK).
+			JSON(api.V1ProjectResponse{Id: ref, Status: api.V1ProjectResponseStatusRESTORING})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + ref).
+			Reply(http.StatusOK).
+			JSON(api.V1ProjectResponse{Id: ref, Status: api.V1ProjectResponseStatusACTIVEHEALTHY})
+		// Run test
+		err := Run(context.Background(), ref, true, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on unexpected status", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + ref + "/restore").
+			Reply(http.StatusBadRequest).
+			JSON(map[string]string{"message": "project is not paused"})
+		// Run test
+		err := Run(context.Background(), ref, false, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "project is not paused")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -0,0 +1,34 @@
+package get
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func Run(ctx context.Context, projectRef string, fsys afero.Fs) error {
+	config, err := GetRealtimeConfig(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	table := "|SETTING|VALUE|\n|-|-|\n"
+	table += fmt.Sprintf("|`max_concurrent_users`|`%d`|\n", config.MaxConcurrentUsers)
+	table += fmt.Sprintf("|`private_only`|`%t`|\n", config.PrivateOnly)
+	return list.RenderTable(table)
+}
+
+func GetRealtimeConfig(ctx context.Context, projectRef string) (api.RealtimeConfigResponse, error) {
+	resp, err := utils.GetSupabase().V1GetRealtimeConfigWithResponse(ctx, projectRef)
+	if err != nil {
+		return api.RealtimeConfigResponse{}, errors.Errorf("failed to retrieve realtime config: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return api.RealtimeConfigResponse{}, errors.New("Unexpected error retrieving realtime config: " + string(resp.Body))
+	}
+	return *resp.JSON200, nil
+}
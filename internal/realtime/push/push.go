@@ -0,0 +1,75 @@
+package push
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/realtime/get"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+type Change struct {
+	Setting string
+	Remote  string
+	Local   string
+}
+
+func Run(ctx context.Context, projectRef string, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	remote, err := get.GetRealtimeConfig(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	body, changes := NewUpdateBody(remote)
+	if len(changes) == 0 {
+		fmt.Fprintln(os.Stderr, "Remote realtime config is up to date.")
+		return nil
+	}
+	table := "|SETTING|REMOTE|LOCAL|\n|-|-|-|\n"
+	for _, c := range changes {
+		table += fmt.Sprintf("|`%s`|`%s`|`%s`|\n", c.Setting, c.Remote, c.Local)
+	}
+	if err := list.RenderTable(table); err != nil {
+		return err
+	}
+	title := fmt.Sprintf("Do you want to push these realtime settings to project %s?", utils.Aqua(projectRef))
+	if shouldPush, err := utils.NewConsole().PromptYesNo(ctx, title, true); err != nil {
+		return err
+	} else if !shouldPush {
+		return errors.New(context.Canceled)
+	}
+	resp, err := utils.GetSupabase().V1UpdateRealtimeConfigWithResponse(ctx, projectRef, body)
+	if err != nil {
+		return errors.Errorf("failed to update realtime config: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return errors.New("Unexpected error updating realtime config: " + string(resp.Body))
+	}
+	fmt.Println("Finished " + utils.Aqua("supabase realtime config push") + ".")
+	return nil
+}
+
+// NewUpdateBody compares the local realtime config with remote, returning a request body that
+// only contains the settings that differ.
+func NewUpdateBody(remote api.RealtimeConfigResponse) (api.UpdateRealtimeConfigBody, []Change) {
+	local := utils.Config.Realtime
+	var body api.UpdateRealtimeConfigBody
+	var changes []Change
+	if users := int(local.MaxConcurrentUsers); users != remote.MaxConcurrentUsers {
+		body.MaxConcurrentUsers = &users
+		changes = append(changes, Change{"max_concurrent_users", strconv.Itoa(remote.MaxConcurrentUsers), strconv.Itoa(users)})
+	}
+	if private := local.PrivateOnly; private != remote.PrivateOnly {
+		body.PrivateOnly = &private
+		changes = append(changes, Change{"private_only", strconv.FormatBool(remote.PrivateOnly), strconv.FormatBool(private)})
+	}
+	return body, changes
+}
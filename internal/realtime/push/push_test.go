@@ -0,0 +1,112 @@
+package push
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/testing/fstest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestPushRealtimeConfig(t *testing.T) {
+	projectRef := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("pushes changed settings", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Setup mock stdin
+		defer fstest.MockStdin(t, "y")()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/config/realtime").
+			Reply(http.StatusOK).
+			JSON(api.RealtimeConfigResponse{
+				MaxConcurrentUsers: 500,
+				PrivateOnly:        true,
+			})
+		gock.New(utils.DefaultApiHost).
+			Patch("/v1/projects/" + projectRef + "/config/realtime").
+			MatchType("json").
+			JSON(api.UpdateRealtimeConfigBody{
+				MaxConcurrentUsers: utils.Ptr(200),
+				PrivateOnly:        utils.Ptr(false),
+			}).
+			Reply(http.StatusOK).
+			JSON(api.RealtimeConfigResponse{
+				MaxConcurrentUsers: 200,
+			})
+		// Run test
+		err := Run(context.Background(), projectRef, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("skips update if up to date", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/config/realtime").
+			Reply(http.StatusOK).
+			JSON(api.RealtimeConfigResponse{
+				MaxConcurrentUsers: 200,
+			})
+		// Run test
+		err := Run(context.Background(), projectRef, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on cancel", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Setup mock stdin
+		defer fstest.MockStdin(t, "n")()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/config/realtime").
+			Reply(http.StatusOK).
+			JSON(api.RealtimeConfigResponse{
+				MaxConcurrentUsers: 100,
+			})
+		// Run test
+		err := Run(context.Background(), projectRef, fsys)
+		// Check error
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on service unavailable", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/config/realtime").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		err := Run(context.Background(), projectRef, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Unexpected error retrieving realtime config:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
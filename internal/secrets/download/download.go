@@ -0,0 +1,75 @@
+package download
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/secrets/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func Run(ctx context.Context, projectRef, envFilePath string, reveal bool, fsys afero.Fs) error {
+	secrets, err := list.GetSecretDigests(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	if reveal {
+		msg := fmt.Sprintf("Do you want to write secret values of project %s to %s?", utils.Aqua(projectRef), utils.Bold(getTarget(envFilePath)))
+		if shouldReveal, err := utils.NewConsole().PromptYesNo(ctx, msg, false); err != nil {
+			return err
+		} else if !shouldReveal {
+			return errors.New(context.Canceled)
+		}
+	}
+	if len(envFilePath) == 0 {
+		return WriteEnv(secrets, projectRef, reveal, os.Stdout)
+	}
+	f, err := fsys.OpenFile(envFilePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return errors.Errorf("failed to create env file: %w", err)
+	}
+	defer f.Close()
+	if err := WriteEnv(secrets, projectRef, reveal, f); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Downloaded", len(secrets), "secrets to", utils.Bold(envFilePath))
+	return nil
+}
+
+func getTarget(envFilePath string) string {
+	if len(envFilePath) == 0 {
+		return "stdout"
+	}
+	return envFilePath
+}
+
+// Writes secrets in dotenv format. Values are left empty unless revealed, in which
+// case digests are written as comments since they can't be used as values.
+func WriteEnv(secrets []api.SecretResponse, projectRef string, reveal bool, w io.Writer) error {
+	lines := []string{"# Secrets downloaded from project " + projectRef}
+	if !reveal {
+		lines = append(lines, "# Values are redacted. Use --reveal to include them.")
+	}
+	for _, secret := range secrets {
+		value := ""
+		if reveal {
+			if list.IsDigest(secret.Value) {
+				lines = append(lines, "# sha256:"+secret.Value)
+			} else {
+				value = strconv.Quote(secret.Value)
+			}
+		}
+		lines = append(lines, secret.Name+"="+value)
+	}
+	if _, err := fmt.Fprintln(w, strings.Join(lines, "\n")); err != nil {
+		return errors.Errorf("failed to write env file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package download
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/testing/fstest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+const digest = "ab8c2f8d0e3c7e9ad2e0c6a55a5de0c4c3b6f0e1c4b6f1dd3b6c2d1e8f9a0b1c"
+
+func TestWriteEnv(t *testing.T) {
+	secrets := []api.SecretResponse{
+		{Name: "API_URL", Value: "https://example.com"},
+		{Name: "STRIPE_KEY", Value: digest},
+	}
+
+	t.Run("redacts values by default", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := WriteEnv(secrets, "test", false, &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, `# Secrets downloaded from project test
+# Values are redacted. Use --reveal to include them.
+API_URL=
+STRIPE_KEY=
+`, out.String())
+	})
+
+	t.Run("reveals values and digests", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := WriteEnv(secrets, "test", true, &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, `# Secrets downloaded from project test
+API_URL="https://example.com"
+# sha256:`+digest+`
+STRIPE_KEY=
+`, out.String())
+	})
+}
+
+func TestDownloadCommand(t *testing.T) {
+	// Setup valid project ref
+	project := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("writes env file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{{Name: "STRIPE_KEY", Value: digest}})
+		// Run test
+		err := Run(context.Background(), project, ".env.remote", false, fsys)
+		// Check error
+		assert.NoError(t, err)
+		contents, err := afero.ReadFile(fsys, ".env.remote")
+		require.NoError(t, err)
+		assert.Contains(t, string(contents), "STRIPE_KEY=\n")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on declined reveal", func(t *testing.T) {
+		defer fstest.MockStdin(t, "n")()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{})
+		// Run test
+		err := Run(context.Background(), project, "", true, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -2,7 +2,10 @@ package list
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -29,6 +32,22 @@ func Run(ctx context.Context, projectRef string, fsys afero.Fs) error {
 	return list.RenderTable(table)
 }
 
+// The Management API returns hex encoded sha256 digests instead of plaintext values.
+var digestPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+func IsDigest(value string) bool {
+	return digestPattern.MatchString(value)
+}
+
+// Reports whether a secret holds the given value, comparing digests if the plaintext is unavailable.
+func MatchesValue(secret api.SecretResponse, value string) bool {
+	if !IsDigest(secret.Value) {
+		return secret.Value == value
+	}
+	digest := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(digest[:]) == secret.Value
+}
+
 func GetSecretDigests(ctx context.Context, projectRef string) ([]api.SecretResponse, error) {
 	resp, err := utils.GetSupabase().V1ListAllSecretsWithResponse(ctx, projectRef)
 	if err != nil {
@@ -1,22 +1,29 @@
 package set
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
 	"maps"
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/go-errors/errors"
-	"github.com/joho/godotenv"
 	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/env/encrypt"
+	"github.com/supabase/cli/internal/secrets/list"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/pkg/api"
 )
 
-func Run(ctx context.Context, projectRef, envFilePath string, args []string, fsys afero.Fs) error {
+func Run(ctx context.Context, projectRef, envFilePath string, args []string, stdin afero.File, fsys afero.Fs) error {
 	// 1. Sanity checks.
 	envMap := make(map[string]string, len(args))
 	if len(envFilePath) > 0 {
@@ -28,6 +35,13 @@ func Run(ctx context.Context, projectRef, envFilePath string, args []string, fsy
 			return err
 		}
 		maps.Copy(envMap, parsed)
+	} else if len(args) == 0 && stdin != nil {
+		// Reading from stdin keeps secret values out of shell history
+		parsed, err := ParseStdin(stdin)
+		if err != nil {
+			return err
+		}
+		maps.Copy(envMap, parsed)
 	}
 	for _, pair := range args {
 		name, value, found := strings.Cut(pair, "=")
@@ -37,9 +51,123 @@ func Run(ctx context.Context, projectRef, envFilePath string, args []string, fsy
 		envMap[name] = value
 	}
 	if len(envMap) == 0 {
-		return errors.New("No arguments found. Use --env-file to read from a .env file.")
+		return errors.New("No arguments found. Use --env-file to read from a .env file, or pipe secrets to stdin.")
+	}
+	// 2. Compare with existing secrets.
+	existing, err := list.GetSecretDigests(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	changes := diffSecrets(existing, envMap)
+	if len(changes) == 0 {
+		fmt.Fprintln(os.Stderr, "No secrets changed.")
+		return nil
+	}
+	// 3. Set all changed secrets in a single request.
+	changed := make(map[string]string, len(changes))
+	for _, c := range changes {
+		changed[c.Name] = envMap[c.Name]
+	}
+	if err := CreateSecrets(ctx, projectRef, changed); err != nil {
+		return err
+	}
+	printChanges(changes)
+	fmt.Println("Finished " + utils.Aqua("supabase secrets set") + ".")
+	return nil
+}
+
+type secretChange struct {
+	Name string
+	// Digests of the previous and new values, where Old is empty for new secrets
+	Old string
+	New string
+}
+
+// Returns the secrets whose values differ from the project, sorted by name.
+func diffSecrets(existing []api.SecretResponse, envMap map[string]string) []secretChange {
+	current := make(map[string]api.SecretResponse, len(existing))
+	for _, secret := range existing {
+		current[secret.Name] = secret
+	}
+	var changes []secretChange
+	for name, value := range envMap {
+		c := secretChange{Name: name, New: digest(value)}
+		if secret, ok := current[name]; ok {
+			if list.MatchesValue(secret, value) {
+				continue
+			}
+			c.Old = secret.Value
+			if !list.IsDigest(c.Old) {
+				c.Old = digest(c.Old)
+			}
+		}
+		changes = append(changes, c)
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+func digest(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+const digestPrefixLen = 12
+
+// Prints shortened digests so that values are never echoed to the terminal.
+func printChanges(changes []secretChange) {
+	for _, c := range changes {
+		// Skipped by CreateSecrets
+		if strings.HasPrefix(c.Name, "SUPABASE_") {
+			continue
+		}
+		if len(c.Old) == 0 {
+			fmt.Fprintf(os.Stderr, "Created %s: %s\n", utils.Bold(c.Name), c.New[:digestPrefixLen])
+		} else {
+			fmt.Fprintf(os.Stderr, "Updated %s: %s -> %s\n", utils.Bold(c.Name), c.Old[:digestPrefixLen], c.New[:digestPrefixLen])
+		}
 	}
-	// 2. Set secret(s).
+}
+
+// ParseStdin reads secrets piped to stdin as either a JSON object or KEY=value lines.
+// Stdin is ignored when attached to a terminal.
+func ParseStdin(stdin afero.File) (map[string]string, error) {
+	if fi, err := stdin.Stat(); err != nil {
+		return nil, errors.Errorf("failed to initialise stdin: %w", err)
+	} else if (fi.Mode() & os.ModeCharDevice) != 0 {
+		return nil, nil
+	}
+	contents, err := io.ReadAll(stdin)
+	if err != nil {
+		return nil, errors.Errorf("failed to read stdin: %w", err)
+	}
+	trimmed := bytes.TrimSpace(contents)
+	if !bytes.HasPrefix(trimmed, []byte("{")) {
+		return encrypt.Decrypt(contents)
+	}
+	var parsed map[string]any
+	if err := json.Unmarshal(trimmed, &parsed); err != nil {
+		return nil, errors.Errorf("failed to parse secrets from stdin: %w", err)
+	}
+	envMap := make(map[string]string, len(parsed))
+	for name, value := range parsed {
+		if str, ok := value.(string); ok {
+			envMap[name] = str
+			continue
+		}
+		// Nested values are stored as their JSON encoding
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, errors.Errorf("failed to encode secret %s: %w", name, err)
+		}
+		envMap[name] = string(encoded)
+	}
+	return envMap, nil
+}
+
+func CreateSecrets(ctx context.Context, projectRef string, envMap map[string]string) error {
 	var secrets api.V1BulkCreateSecretsJSONBody
 	for name, value := range envMap {
 		// Lower case prefix is accepted by API
@@ -62,20 +190,9 @@ func Run(ctx context.Context, projectRef, envFilePath string, args []string, fsy
 	if resp.StatusCode() != http.StatusCreated {
 		return errors.New("Unexpected error setting project secrets: " + string(resp.Body))
 	}
-
-	fmt.Println("Finished " + utils.Aqua("supabase secrets set") + ".")
 	return nil
 }
 
 func ParseEnvFile(envFilePath string, fsys afero.Fs) (map[string]string, error) {
-	f, err := fsys.Open(envFilePath)
-	if err != nil {
-		return nil, errors.Errorf("failed to open env file: %w", err)
-	}
-	defer f.Close()
-	envMap, err := godotenv.Parse(f)
-	if err != nil {
-		return nil, errors.Errorf("failed to parse env file: %w", err)
-	}
-	return envMap, nil
+	return encrypt.ReadFile(envFilePath, fsys)
 }
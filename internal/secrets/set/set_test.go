@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"os"
 	"testing"
 
 	"github.com/h2non/gock"
@@ -11,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/testing/fstest"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/pkg/api"
 )
@@ -29,13 +31,17 @@ func TestSecretSetCommand(t *testing.T) {
 		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
 		// Flush pending mocks after test execution
 		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{})
 		gock.New(utils.DefaultApiHost).
 			Post("/v1/projects/" + project + "/secrets").
 			MatchType("json").
 			JSON(api.V1BulkCreateSecretsJSONRequestBody{dummy}).
 			Reply(http.StatusCreated)
 		// Run test
-		err := Run(context.Background(), project, "", []string{dummyEnv}, fsys)
+		err := Run(context.Background(), project, "", []string{dummyEnv}, nil, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -52,13 +58,70 @@ func TestSecretSetCommand(t *testing.T) {
 		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
 		// Flush pending mocks after test execution
 		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{})
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/secrets").
+			MatchType("json").
+			JSON(api.V1BulkCreateSecretsJSONRequestBody{dummy}).
+			Reply(http.StatusCreated)
+		// Run test
+		err := Run(context.Background(), project, "/tmp/.env", []string{}, nil, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("Sets secrets via stdin", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup stdin
+		teardown := fstest.MockStdin(t, `{"my_name": "my_value", "unchanged": "value"}`)
+		defer teardown()
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{{
+				Name:  "unchanged",
+				Value: "cd42404d52ad55ccfa9aca4adc828aa5800ad9d385a0671fbcbf724118320619",
+			}})
 		gock.New(utils.DefaultApiHost).
 			Post("/v1/projects/" + project + "/secrets").
 			MatchType("json").
 			JSON(api.V1BulkCreateSecretsJSONRequestBody{dummy}).
 			Reply(http.StatusCreated)
 		// Run test
-		err := Run(context.Background(), project, "/tmp/.env", []string{}, fsys)
+		err := Run(context.Background(), project, "", []string{}, os.Stdin, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("Skips unchanged secrets", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{{Name: dummy.Name, Value: dummy.Value}})
+		// Run test
+		err := Run(context.Background(), project, "", []string{dummyEnv}, nil, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -73,9 +136,9 @@ func TestSecretSetCommand(t *testing.T) {
 		token := apitest.RandomAccessToken(t)
 		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
 		// Run test
-		err := Run(context.Background(), project, "", []string{}, fsys)
+		err := Run(context.Background(), project, "", []string{}, nil, fsys)
 		// Check error
-		assert.ErrorContains(t, err, "No arguments found. Use --env-file to read from a .env file.")
+		assert.ErrorContains(t, err, "No arguments found. Use --env-file to read from a .env file, or pipe secrets to stdin.")
 	})
 
 	t.Run("throws error on malformed secret", func(t *testing.T) {
@@ -87,7 +150,7 @@ func TestSecretSetCommand(t *testing.T) {
 		token := apitest.RandomAccessToken(t)
 		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
 		// Run test
-		err := Run(context.Background(), project, "", []string{"malformed"}, fsys)
+		err := Run(context.Background(), project, "", []string{"malformed"}, nil, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "Invalid secret pair: malformed. Must be NAME=VALUE.")
 	})
@@ -102,13 +165,17 @@ func TestSecretSetCommand(t *testing.T) {
 		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
 		// Flush pending mocks after test execution
 		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{})
 		gock.New(utils.DefaultApiHost).
 			Post("/v1/projects/" + project + "/secrets").
 			MatchType("json").
 			JSON(api.V1BulkCreateSecretsJSONRequestBody{dummy}).
 			ReplyError(errors.New("network error"))
 		// Run test
-		err := Run(context.Background(), project, "", []string{dummyEnv}, fsys)
+		err := Run(context.Background(), project, "", []string{dummyEnv}, nil, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "network error")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -124,6 +191,10 @@ func TestSecretSetCommand(t *testing.T) {
 		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
 		// Flush pending mocks after test execution
 		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{})
 		gock.New(utils.DefaultApiHost).
 			Post("/v1/projects/" + project + "/secrets").
 			MatchType("json").
@@ -131,9 +202,41 @@ func TestSecretSetCommand(t *testing.T) {
 			Reply(500).
 			JSON(map[string]string{"message": "unavailable"})
 		// Run test
-		err := Run(context.Background(), project, "", []string{dummyEnv}, fsys)
+		err := Run(context.Background(), project, "", []string{dummyEnv}, nil, fsys)
 		// Check error
 		assert.ErrorContains(t, err, `Unexpected error setting project secrets: {"message":"unavailable"}`)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
 }
+
+func TestParseStdin(t *testing.T) {
+	t.Run("parses env lines", func(t *testing.T) {
+		teardown := fstest.MockStdin(t, "A=1\n# comment\nB=\"two words\"\n")
+		defer teardown()
+		// Run test
+		envMap, err := ParseStdin(os.Stdin)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"A": "1", "B": "two words"}, envMap)
+	})
+
+	t.Run("parses json object", func(t *testing.T) {
+		teardown := fstest.MockStdin(t, `{"A": "1", "B": 2, "C": {"nested": true}}`)
+		defer teardown()
+		// Run test
+		envMap, err := ParseStdin(os.Stdin)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"A": "1", "B": "2", "C": `{"nested":true}`}, envMap)
+	})
+
+	t.Run("throws error on malformed json", func(t *testing.T) {
+		teardown := fstest.MockStdin(t, `{"A": `)
+		defer teardown()
+		// Run test
+		envMap, err := ParseStdin(os.Stdin)
+		// Check error
+		assert.ErrorContains(t, err, "failed to parse secrets from stdin: unexpected end of JSON input")
+		assert.Nil(t, envMap)
+	})
+}
@@ -19,10 +19,13 @@ func Run(ctx context.Context, fsys afero.Fs) error {
 	_ = utils.LoadConfigFS(fsys)
 	serviceImages := GetServiceImages()
 
-	var linked map[string]string
+	linked := GetLinkedImages(fsys)
 	if projectRef, err := flags.LoadProjectRef(fsys); err == nil {
-		linked = GetRemoteImages(ctx, projectRef)
+		for image, version := range GetRemoteImages(ctx, projectRef) {
+			linked[image] = version
+		}
 	}
+	pinned := GetPinnedImages()
 
 	table := `|SERVICE IMAGE|LOCAL|LINKED|
 |-|-|-|
@@ -32,10 +35,14 @@ func Run(ctx context.Context, fsys afero.Fs) error {
 		version, ok := linked[image]
 		if !ok {
 			version = "-"
-		} else if parts[1] != version && image != utils.Config.Db.Image {
+		} else if parts[1] != version && image != utils.Config.Db.Image && !pinned[image] {
 			utils.CmdSuggestion = suggestLinkCommand
 		}
-		table += fmt.Sprintf("|`%s`|`%s`|`%s`|\n", parts[0], parts[1], version)
+		local := fmt.Sprintf("`%s`", parts[1])
+		if pinned[image] {
+			local += " (pinned)"
+		}
+		table += fmt.Sprintf("|`%s`|%s|`%s`|\n", parts[0], local, version)
 	}
 
 	return list.RenderTable(table)
@@ -48,7 +55,7 @@ func GetServiceImages() []string {
 		utils.Config.Api.Image,
 		utils.Config.Realtime.Image,
 		utils.Config.Storage.Image,
-		utils.EdgeRuntimeImage,
+		utils.Config.EdgeRuntime.Image,
 		utils.Config.Studio.Image,
 		utils.Config.Studio.PgmetaImage,
 		utils.LogflareImage,
@@ -57,6 +64,43 @@ func GetServiceImages() []string {
 	}
 }
 
+// GetLinkedImages returns the remote versions saved by supabase link, keyed by local image.
+func GetLinkedImages(fsys afero.Fs) map[string]string {
+	linked := map[string]string{}
+	for path, image := range map[string]string{
+		utils.PostgresVersionPath: utils.Config.Db.Image,
+		utils.GotrueVersionPath:   utils.Config.Auth.Image,
+		utils.RestVersionPath:     utils.Config.Api.Image,
+		utils.StorageVersionPath:  utils.Config.Storage.Image,
+		utils.RealtimeVersionPath: utils.Config.Realtime.Image,
+		utils.StudioVersionPath:   utils.Config.Studio.Image,
+		utils.PgmetaVersionPath:   utils.Config.Studio.PgmetaImage,
+		utils.PoolerVersionPath:   utils.Config.Db.Pooler.Image,
+	} {
+		if version, err := afero.ReadFile(fsys, path); err == nil && len(version) > 0 {
+			linked[image] = strings.TrimSpace(string(version))
+		}
+	}
+	return linked
+}
+
+// GetPinnedImages returns the service images pinned to a version in config.toml.
+func GetPinnedImages() map[string]bool {
+	pinned := map[string]bool{}
+	for image, version := range map[string]string{
+		utils.Config.Api.Image:         utils.Config.Api.Version,
+		utils.Config.Auth.Image:        utils.Config.Auth.Version,
+		utils.Config.Realtime.Image:    utils.Config.Realtime.Version,
+		utils.Config.Storage.Image:     utils.Config.Storage.Version,
+		utils.Config.EdgeRuntime.Image: utils.Config.EdgeRuntime.Version,
+	} {
+		if len(version) > 0 {
+			pinned[image] = true
+		}
+	}
+	return pinned
+}
+
 func GetRemoteImages(ctx context.Context, projectRef string) map[string]string {
 	linked := make(map[string]string, 4)
 	var wg sync.WaitGroup
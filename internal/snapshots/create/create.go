@@ -0,0 +1,112 @@
+package create
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/start"
+	"github.com/supabase/cli/internal/snapshots/list"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var ErrExists = errors.New("Snapshot already exists.")
+
+func Run(ctx context.Context, name string, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	if err := list.ValidateName(name); err != nil {
+		return err
+	}
+	if err := utils.AssertSupabaseDbIsRunning(); err != nil {
+		return err
+	}
+	dst := list.GetVolumeName(name)
+	if _, err := utils.Docker.VolumeInspect(ctx, dst); err == nil {
+		utils.CmdSuggestion = fmt.Sprintf("Run %s to replace it.", utils.Aqua("supabase snapshots delete "+name))
+		return errors.New(ErrExists)
+	} else if !client.IsErrNotFound(err) {
+		return errors.Errorf("failed to inspect snapshot: %w", err)
+	}
+	if _, err := utils.Docker.VolumeCreate(ctx, volume.CreateOptions{
+		Name: dst,
+		Labels: map[string]string{
+			utils.CliSnapshotLabel:             name,
+			utils.CliSnapshotProjectLabel:      utils.Config.ProjectId,
+			utils.CliSnapshotImageLabel:        utils.Config.Db.Image,
+			utils.CliSnapshotMajorVersionLabel: strconv.FormatUint(uint64(utils.Config.Db.MajorVersion), 10),
+		},
+	}); err != nil {
+		return errors.Errorf("failed to create volume: %w", err)
+	}
+	fmt.Fprintln(os.Stderr, "Creating snapshot", utils.Bold(name)+"...")
+	if err := CopyVolume(ctx, utils.DbId, dst); err != nil {
+		if rmErr := utils.Docker.VolumeRemove(context.Background(), dst, true); rmErr != nil {
+			fmt.Fprintln(os.Stderr, "Failed to remove volume:", dst, rmErr)
+		}
+		return err
+	}
+	fmt.Println("Created snapshot " + utils.Aqua(name) + ".")
+	return nil
+}
+
+// CopyScript replaces the contents of /dst with those of /src, keeping file ownership.
+const CopyScript = "find /dst -mindepth 1 -delete && cp -a /src/. /dst/"
+
+// CopyVolume replaces the contents of dst volume with those of src. The local database is
+// stopped while copying so that its data directory is consistent.
+func CopyVolume(ctx context.Context, src, dst string) error {
+	return WithDatabaseStopped(ctx, func() error {
+		return RunVolumeScript(ctx, CopyScript, src, dst)
+	})
+}
+
+// WithDatabaseStopped runs fn while the local database container is stopped. The database
+// is always restarted afterwards, even if fn failed.
+func WithDatabaseStopped(ctx context.Context, fn func() error) error {
+	if err := utils.Docker.ContainerStop(ctx, utils.DbId, container.StopOptions{}); err != nil {
+		return errors.Errorf("failed to stop database container: %w", err)
+	}
+	runErr := fn()
+	if err := utils.Docker.ContainerStart(ctx, utils.DbId, container.StartOptions{}); err != nil {
+		return errors.Join(runErr, errors.Errorf("failed to start database container: %w", err))
+	}
+	if err := start.WaitForHealthyService(ctx, start.HealthTimeout, utils.DbId); err != nil {
+		return errors.Join(runErr, err)
+	}
+	return runErr
+}
+
+// RunVolumeScript runs a shell script in a throwaway container with src volume mounted
+// read-only at /src and dst volume mounted at /dst.
+func RunVolumeScript(ctx context.Context, script, src, dst string) error {
+	// Mounts are used in place of binds because volumes are already created with their labels
+	return utils.DockerRunOnceWithConfig(
+		ctx,
+		container.Config{
+			Image:      utils.Config.Db.Image,
+			Entrypoint: []string{"sh", "-c", script},
+		},
+		container.HostConfig{
+			Mounts: []mount.Mount{
+				{Type: mount.TypeVolume, Source: src, Target: "/src", ReadOnly: true},
+				{Type: mount.TypeVolume, Source: dst, Target: "/dst"},
+			},
+			NetworkMode: network.NetworkNone,
+		},
+		network.NetworkingConfig{},
+		"",
+		io.Discard,
+		os.Stderr,
+	)
+}
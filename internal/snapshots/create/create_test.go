@@ -0,0 +1,156 @@
+package create
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestCreateCommand(t *testing.T) {
+	const volumeId = "supabase_snapshot_before_test"
+
+	t.Run("creates snapshot from local database", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/supabase_db_test/json").
+			Reply(http.StatusOK).
+			JSON(types.ContainerJSON{})
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/volumes/" + volumeId).
+			Reply(http.StatusNotFound)
+		gock.New(utils.Docker.DaemonHost()).
+			Post("/v" + utils.Docker.ClientVersion() + "/volumes/create").
+			Reply(http.StatusCreated).
+			JSON(volume.Volume{Name: volumeId})
+		mockCopyVolume(t)
+		// Run test
+		err := Run(context.Background(), "before", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on invalid name", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Run test
+		err := Run(context.Background(), "../before", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid snapshot name: ../before.")
+	})
+
+	t.Run("throws error on existing snapshot", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/supabase_db_test/json").
+			Reply(http.StatusOK).
+			JSON(types.ContainerJSON{})
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/volumes/" + volumeId).
+			Reply(http.StatusOK).
+			JSON(volume.Volume{Name: volumeId})
+		// Run test
+		err := Run(context.Background(), "before", fsys)
+		// Check error
+		assert.ErrorIs(t, err, ErrExists)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("removes snapshot on copy failure", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/supabase_db_test/json").
+			Reply(http.StatusOK).
+			JSON(types.ContainerJSON{})
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/volumes/" + volumeId).
+			Reply(http.StatusNotFound)
+		gock.New(utils.Docker.DaemonHost()).
+			Post("/v" + utils.Docker.ClientVersion() + "/volumes/create").
+			Reply(http.StatusCreated).
+			JSON(volume.Volume{Name: volumeId})
+		gock.New(utils.Docker.DaemonHost()).
+			Post("/v" + utils.Docker.ClientVersion() + "/containers/supabase_db_test/stop").
+			ReplyError(errors.New("network error"))
+		gock.New(utils.Docker.DaemonHost()).
+			Delete("/v" + utils.Docker.ClientVersion() + "/volumes/" + volumeId).
+			Reply(http.StatusNoContent)
+		// Run test
+		err := Run(context.Background(), "before", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "network error")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestCopyVolume(t *testing.T) {
+	t.Run("restarts database on copy failure", func(t *testing.T) {
+		utils.DbId = "supabase_db_test"
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Post("/v" + utils.Docker.ClientVersion() + "/containers/supabase_db_test/stop").
+			Reply(http.StatusNoContent)
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/images/" + utils.GetRegistryImageUrl(utils.Config.Db.Image) + "/json").
+			ReplyError(errors.New("network error"))
+		mockStartDatabase()
+		// Run test
+		err := CopyVolume(context.Background(), "src", "dst")
+		// Check error
+		assert.ErrorContains(t, err, "network error")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func mockCopyVolume(t *testing.T) {
+	const containerId = "test-copy"
+	gock.New(utils.Docker.DaemonHost()).
+		Post("/v" + utils.Docker.ClientVersion() + "/containers/supabase_db_test/stop").
+		Reply(http.StatusNoContent)
+	apitest.MockDockerStart(utils.Docker, utils.GetRegistryImageUrl(utils.Config.Db.Image), containerId)
+	require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, ""))
+	mockStartDatabase()
+}
+
+func mockStartDatabase() {
+	gock.New(utils.Docker.DaemonHost()).
+		Post("/v" + utils.Docker.ClientVersion() + "/containers/supabase_db_test/start").
+		Reply(http.StatusNoContent)
+	gock.New(utils.Docker.DaemonHost()).
+		Get("/v" + utils.Docker.ClientVersion() + "/containers/supabase_db_test/json").
+		Reply(http.StatusOK).
+		JSON(types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{
+			State: &types.ContainerState{
+				Running: true,
+				Health:  &types.Health{Status: "healthy"},
+			},
+		}})
+}
@@ -0,0 +1,26 @@
+package delete
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/snapshots/list"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(ctx context.Context, name string, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	snapshot, err := list.GetSnapshot(ctx, name)
+	if err != nil {
+		return err
+	}
+	if err := utils.Docker.VolumeRemove(ctx, snapshot.Volume, false); err != nil {
+		return errors.Errorf("failed to delete snapshot: %w", err)
+	}
+	fmt.Println("Deleted snapshot " + utils.Aqua(name) + ".")
+	return nil
+}
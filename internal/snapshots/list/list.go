@@ -0,0 +1,112 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var (
+	ErrNotFound = errors.New("Snapshot not found.")
+
+	// Snapshot names are part of docker volume names
+	namePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+)
+
+type Snapshot struct {
+	Name      string
+	Volume    string
+	CreatedAt string
+	// Empty for snapshots created by older versions of the CLI
+	Image        string
+	MajorVersion uint
+}
+
+func Run(ctx context.Context, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	snapshots, err := ListSnapshots(ctx)
+	if err != nil {
+		return err
+	}
+	if len(snapshots) == 0 {
+		fmt.Println("No snapshots found.")
+		return nil
+	}
+	table := `|NAME|CREATED AT (UTC)|
+|-|-|
+`
+	for _, s := range snapshots {
+		table += fmt.Sprintf("|`%s`|`%s`|\n", s.Name, utils.FormatTimestamp(s.CreatedAt))
+	}
+	return list.RenderTable(table)
+}
+
+// ListSnapshots returns the snapshots of the current project, ordered by name.
+func ListSnapshots(ctx context.Context) ([]Snapshot, error) {
+	resp, err := utils.Docker.VolumeList(ctx, volume.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("label", utils.CliSnapshotProjectLabel+"="+utils.Config.ProjectId)),
+	})
+	if err != nil {
+		return nil, errors.Errorf("failed to list volumes: %w", err)
+	}
+	result := make([]Snapshot, 0, len(resp.Volumes))
+	for _, v := range resp.Volumes {
+		result = append(result, toSnapshot(v))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+func GetSnapshot(ctx context.Context, name string) (Snapshot, error) {
+	if err := ValidateName(name); err != nil {
+		return Snapshot{}, err
+	}
+	v, err := utils.Docker.VolumeInspect(ctx, GetVolumeName(name))
+	if client.IsErrNotFound(err) {
+		utils.CmdSuggestion = fmt.Sprintf("Run %s to show available snapshots.", utils.Aqua("supabase snapshots list"))
+		return Snapshot{}, errors.New(ErrNotFound)
+	} else if err != nil {
+		return Snapshot{}, errors.Errorf("failed to inspect snapshot: %w", err)
+	}
+	result := toSnapshot(&v)
+	result.Name = name
+	return result, nil
+}
+
+func toSnapshot(v *volume.Volume) Snapshot {
+	result := Snapshot{
+		Name:      v.Labels[utils.CliSnapshotLabel],
+		Volume:    v.Name,
+		CreatedAt: v.CreatedAt,
+		Image:     v.Labels[utils.CliSnapshotImageLabel],
+	}
+	if version, err := strconv.ParseUint(v.Labels[utils.CliSnapshotMajorVersionLabel], 10, 0); err == nil {
+		result.MajorVersion = uint(version)
+	}
+	return result
+}
+
+func GetVolumeName(name string) string {
+	return utils.GetId("snapshot_" + name)
+}
+
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return errors.Errorf("Invalid snapshot name: %s. Must start with a letter or digit and contain only letters, digits, _, ., or -.", name)
+	}
+	return nil
+}
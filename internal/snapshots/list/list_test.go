@@ -0,0 +1,94 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types/volume"
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestListCommand(t *testing.T) {
+	t.Run("lists snapshots of project", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v"+utils.Docker.ClientVersion()+"/volumes").
+			MatchParam("filters", utils.CliSnapshotProjectLabel+"=test").
+			Reply(http.StatusOK).
+			JSON(volume.ListResponse{Volumes: []*volume.Volume{{
+				Name:      "supabase_snapshot_before_test",
+				CreatedAt: "2024-01-01T00:00:00Z",
+				Labels:    map[string]string{utils.CliSnapshotLabel: "before"},
+			}}})
+		// Run test
+		err := Run(context.Background(), fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on docker failure", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/volumes").
+			ReplyError(errors.New("network error"))
+		// Run test
+		err := Run(context.Background(), fsys)
+		// Check error
+		assert.ErrorContains(t, err, "network error")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestListSnapshots(t *testing.T) {
+	// Setup mock docker
+	require.NoError(t, apitest.MockDocker(utils.Docker))
+	defer gock.OffAll()
+	gock.New(utils.Docker.DaemonHost()).
+		Get("/v" + utils.Docker.ClientVersion() + "/volumes").
+		Reply(http.StatusOK).
+		JSON(volume.ListResponse{Volumes: []*volume.Volume{{
+			Name:   "supabase_snapshot_refactor_test",
+			Labels: map[string]string{utils.CliSnapshotLabel: "refactor"},
+		}, {
+			Name: "supabase_snapshot_before_test",
+			Labels: map[string]string{
+				utils.CliSnapshotLabel:             "before",
+				utils.CliSnapshotImageLabel:        "supabase/postgres:15.1.0.117",
+				utils.CliSnapshotMajorVersionLabel: "15",
+			},
+		}}})
+	// Run test
+	snapshots, err := ListSnapshots(context.Background())
+	// Check error
+	assert.NoError(t, err)
+	assert.Equal(t, []Snapshot{
+		{Name: "before", Volume: "supabase_snapshot_before_test", Image: "supabase/postgres:15.1.0.117", MajorVersion: 15},
+		{Name: "refactor", Volume: "supabase_snapshot_refactor_test"},
+	}, snapshots)
+}
+
+func TestValidateName(t *testing.T) {
+	assert.NoError(t, ValidateName("before-big-refactor"))
+	assert.NoError(t, ValidateName("v1.2_rc"))
+	assert.Error(t, ValidateName(""))
+	assert.Error(t, ValidateName("-before"))
+	assert.Error(t, ValidateName("before/after"))
+}
@@ -0,0 +1,96 @@
+package restore
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/docker/docker/api/types/volume"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/snapshots/create"
+	"github.com/supabase/cli/internal/snapshots/list"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Moves the current data aside before copying the staged snapshot in, so that a failed
+// copy puts the original data back instead of leaving the database half restored.
+const swapScript = `set -e
+rm -rf /dst/.pre_restore
+mkdir /dst/.pre_restore
+find /dst -mindepth 1 -maxdepth 1 ! -name .pre_restore -exec mv {} /dst/.pre_restore/ \;
+if cp -a /src/. /dst/; then
+  rm -rf /dst/.pre_restore
+else
+  find /dst -mindepth 1 -maxdepth 1 ! -name .pre_restore -exec rm -rf {} +
+  find /dst/.pre_restore -mindepth 1 -maxdepth 1 -exec mv {} /dst/ \;
+  rmdir /dst/.pre_restore
+  exit 1
+fi`
+
+func Run(ctx context.Context, name string, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	if err := utils.AssertSupabaseDbIsRunning(); err != nil {
+		return err
+	}
+	snapshot, err := list.GetSnapshot(ctx, name)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(snapshot); err != nil {
+		return err
+	}
+	msg := fmt.Sprintf("Do you want to replace all data in your local database with snapshot %s?", utils.Bold(name))
+	if shouldRestore, err := utils.NewConsole().PromptYesNo(ctx, msg, false); err != nil {
+		return err
+	} else if !shouldRestore {
+		return errors.New(context.Canceled)
+	}
+	fmt.Fprintln(os.Stderr, "Restoring snapshot", utils.Bold(name)+"...")
+	if err := restoreVolume(ctx, snapshot.Volume); err != nil {
+		return err
+	}
+	fmt.Println("Restored local database to snapshot " + utils.Aqua(name) + ".")
+	return nil
+}
+
+func checkVersion(snapshot list.Snapshot) error {
+	if snapshot.MajorVersion == 0 || len(snapshot.Image) == 0 {
+		fmt.Fprintf(os.Stderr, "%s snapshot %s does not record its database version and may not start with your local database.\n", utils.Yellow("WARNING:"), utils.Bold(snapshot.Name))
+		return nil
+	}
+	if snapshot.MajorVersion != utils.Config.Db.MajorVersion {
+		utils.CmdSuggestion = fmt.Sprintf("Set %s to %d in %s to restore this snapshot.", utils.Aqua("db.major_version"), snapshot.MajorVersion, utils.Bold(utils.ConfigPath))
+		return errors.Errorf("Snapshot %s was created with Postgres %d, but your local database runs Postgres %d.", snapshot.Name, snapshot.MajorVersion, utils.Config.Db.MajorVersion)
+	}
+	if snapshot.Image != utils.Config.Db.Image {
+		fmt.Fprintf(os.Stderr, "%s snapshot %s was created with image %s, but your local database uses %s.\n", utils.Yellow("WARNING:"), utils.Bold(snapshot.Name), snapshot.Image, utils.Config.Db.Image)
+	}
+	return nil
+}
+
+// Stages the snapshot in a temporary volume while the database is still running, which
+// keeps the snapshot intact and the downtime limited to swapping data directories.
+func restoreVolume(ctx context.Context, src string) error {
+	tmp := utils.GetId("restore")
+	if _, err := utils.Docker.VolumeCreate(ctx, volume.CreateOptions{
+		Name: tmp,
+		// Labelled with project so that stop --no-backup removes any leftovers
+		Labels: map[string]string{utils.CliProjectLabel: utils.Config.ProjectId},
+	}); err != nil {
+		return errors.Errorf("failed to create volume: %w", err)
+	}
+	defer func() {
+		if err := utils.Docker.VolumeRemove(context.Background(), tmp, true); err != nil {
+			fmt.Fprintln(os.Stderr, "Failed to remove volume:", tmp, err)
+		}
+	}()
+	if err := create.RunVolumeScript(ctx, create.CopyScript, src, tmp); err != nil {
+		return err
+	}
+	return create.WithDatabaseStopped(ctx, func() error {
+		return create.RunVolumeScript(ctx, swapScript, tmp, utils.DbId)
+	})
+}
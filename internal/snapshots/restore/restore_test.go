@@ -0,0 +1,184 @@
+package restore
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/snapshots/list"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/testing/fstest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestRestoreCommand(t *testing.T) {
+	const (
+		volumeId = "supabase_snapshot_before_test"
+		tempId   = "supabase_restore_test"
+	)
+
+	t.Run("throws error on cancel", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Setup mock stdin
+		defer fstest.MockStdin(t, "n")()
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		mockSnapshot("15")
+		// Run test
+		err := Run(context.Background(), "before", fsys)
+		// Check error
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on major version mismatch", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		mockSnapshot("13")
+		// Run test
+		err := Run(context.Background(), "before", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Snapshot before was created with Postgres 13")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("restores local database from snapshot", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Setup mock stdin
+		defer fstest.MockStdin(t, "y")()
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		mockSnapshot("15")
+		gock.New(utils.Docker.DaemonHost()).
+			Post("/v" + utils.Docker.ClientVersion() + "/volumes/create").
+			Reply(http.StatusCreated).
+			JSON(volume.Volume{Name: tempId})
+		imageUrl := utils.GetRegistryImageUrl(utils.Config.Db.Image)
+		apitest.MockDockerStart(utils.Docker, imageUrl, "test-stage")
+		require.NoError(t, apitest.MockDockerLogs(utils.Docker, "test-stage", ""))
+		gock.New(utils.Docker.DaemonHost()).
+			Post("/v" + utils.Docker.ClientVersion() + "/containers/supabase_db_test/stop").
+			Reply(http.StatusNoContent)
+		apitest.MockDockerStart(utils.Docker, imageUrl, "test-swap")
+		require.NoError(t, apitest.MockDockerLogs(utils.Docker, "test-swap", ""))
+		gock.New(utils.Docker.DaemonHost()).
+			Post("/v" + utils.Docker.ClientVersion() + "/containers/supabase_db_test/start").
+			Reply(http.StatusNoContent)
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/supabase_db_test/json").
+			Reply(http.StatusOK).
+			JSON(types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{
+				State: &types.ContainerState{
+					Running: true,
+					Health:  &types.Health{Status: "healthy"},
+				},
+			}})
+		gock.New(utils.Docker.DaemonHost()).
+			Delete("/v" + utils.Docker.ClientVersion() + "/volumes/" + tempId).
+			Reply(http.StatusNoContent)
+		// Run test
+		err := Run(context.Background(), "before", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("keeps database running on staging failure", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Setup mock stdin
+		defer fstest.MockStdin(t, "y")()
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		mockSnapshot("15")
+		gock.New(utils.Docker.DaemonHost()).
+			Post("/v" + utils.Docker.ClientVersion() + "/volumes/create").
+			Reply(http.StatusCreated).
+			JSON(volume.Volume{Name: tempId})
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/images/" + utils.GetRegistryImageUrl(utils.Config.Db.Image) + "/json").
+			ReplyError(errors.New("network error"))
+		gock.New(utils.Docker.DaemonHost()).
+			Delete("/v" + utils.Docker.ClientVersion() + "/volumes/" + tempId).
+			Reply(http.StatusNoContent)
+		// Run test
+		err := Run(context.Background(), "before", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "network error")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing snapshot", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/supabase_db_test/json").
+			Reply(http.StatusOK).
+			JSON(types.ContainerJSON{})
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/volumes/" + volumeId).
+			Reply(http.StatusNotFound)
+		// Run test
+		err := Run(context.Background(), "before", fsys)
+		// Check error
+		assert.ErrorIs(t, err, list.ErrNotFound)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing db", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/supabase_db_test/json").
+			Reply(http.StatusNotFound)
+		// Run test
+		err := Run(context.Background(), "before", fsys)
+		// Check error
+		assert.ErrorIs(t, err, utils.ErrNotRunning)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func mockSnapshot(majorVersion string) {
+	gock.New(utils.Docker.DaemonHost()).
+		Get("/v" + utils.Docker.ClientVersion() + "/containers/supabase_db_test/json").
+		Reply(http.StatusOK).
+		JSON(types.ContainerJSON{})
+	gock.New(utils.Docker.DaemonHost()).
+		Get("/v" + utils.Docker.ClientVersion() + "/volumes/supabase_snapshot_before_test").
+		Reply(http.StatusOK).
+		JSON(volume.Volume{
+			Name: "supabase_snapshot_before_test",
+			Labels: map[string]string{
+				utils.CliSnapshotImageLabel:        utils.Pg15Image,
+				utils.CliSnapshotMajorVersionLabel: majorVersion,
+			},
+		})
+}
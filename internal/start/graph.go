@@ -0,0 +1,148 @@
+package start
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/supabase/cli/internal/utils"
+)
+
+type dependency struct {
+	name string
+	// Dependents are excluded together with a required dependency
+	required bool
+}
+
+// Dependencies between excludable containers, keyed by their short image names. All
+// services depend on the database, which cannot be excluded.
+var serviceGraph = map[string][]dependency{
+	shortName(utils.VectorImage):     {{shortName(utils.LogflareImage), true}},
+	shortName(utils.StudioImage):     {{shortName(utils.PgmetaImage), true}, {shortName(utils.KongImage), false}, {shortName(utils.LogflareImage), false}},
+	shortName(utils.ImageProxyImage): {{shortName(utils.StorageImage), true}},
+	shortName(utils.GotrueImage):     {{shortName(utils.InbucketImage), false}},
+	shortName(utils.KongImage): {
+		{shortName(utils.GotrueImage), false},
+		{shortName(utils.PostgrestImage), false},
+		{shortName(utils.RealtimeImage), false},
+		{shortName(utils.StorageImage), false},
+		{shortName(utils.EdgeRuntimeImage), false},
+	},
+}
+
+// Groups of containers that can be excluded by a single name.
+var serviceAliases = map[string][]string{
+	"analytics": {shortName(utils.LogflareImage), shortName(utils.VectorImage)},
+}
+
+func shortName(image string) string {
+	return utils.ShortContainerImageName(image)
+}
+
+// ResolveExcluded expands aliases in excluded and adds dependents of excluded containers
+// that cannot start without them. Dependents that only lose some features are reported
+// to w instead. Excluding vector also disables analytics because other containers would
+// otherwise fail to forward their logs.
+func ResolveExcluded(excluded []string, w io.Writer) []string {
+	allowed := ExcludableContainers()
+	result := map[string]bool{}
+	for _, name := range excluded {
+		if names, ok := serviceAliases[name]; ok {
+			for _, n := range names {
+				result[n] = true
+			}
+		} else if utils.SliceContains(allowed, name) {
+			result[name] = true
+		} else {
+			fmt.Fprintf(w, "%s unknown container %s is ignored. Must be one of: %v\n", utils.Yellow("WARNING:"), utils.Bold(name), allowed)
+		}
+	}
+	// Propagates exclusion until no more dependents are affected
+	for changed := true; changed; {
+		changed = false
+		for _, name := range sortedServices() {
+			if result[name] {
+				continue
+			}
+			for _, dep := range serviceGraph[name] {
+				if dep.required && result[dep.name] {
+					fmt.Fprintf(w, "Excluding %s because it depends on %s.\n", utils.Bold(name), utils.Bold(dep.name))
+					result[name] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	for _, name := range sortedServices() {
+		if result[name] {
+			continue
+		}
+		for _, dep := range serviceGraph[name] {
+			if !dep.required && result[dep.name] {
+				fmt.Fprintf(w, "%s %s may not work as expected without %s.\n", utils.Yellow("WARNING:"), utils.Bold(name), utils.Bold(dep.name))
+			}
+		}
+	}
+	if result[shortName(utils.VectorImage)] && utils.Config.Analytics.Enabled {
+		fmt.Fprintln(w, "Disabling analytics because "+utils.Bold(shortName(utils.VectorImage))+" is excluded.")
+		utils.Config.Analytics.Enabled = false
+	}
+	names := make([]string, 0, len(result))
+	for name := range result {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedServices() []string {
+	names := make([]string, 0, len(serviceGraph))
+	for name := range serviceGraph {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PrintGraph prints the dependency tree of all excludable containers, starting from
+// those that no other container depends on.
+func PrintGraph(w io.Writer) {
+	dependents := map[string]bool{}
+	for _, deps := range serviceGraph {
+		for _, dep := range deps {
+			dependents[dep.name] = true
+		}
+	}
+	var roots []string
+	for _, name := range ExcludableContainers() {
+		if !dependents[name] {
+			roots = append(roots, name)
+		}
+	}
+	sort.Strings(roots)
+	for _, name := range roots {
+		fmt.Fprintln(w, name)
+		deps := serviceGraph[name]
+		for i, dep := range deps {
+			printTree(w, dep, "", i == len(deps)-1)
+		}
+	}
+	fmt.Fprintln(w, "\nAll services depend on the database, which cannot be excluded.")
+}
+
+func printTree(w io.Writer, node dependency, prefix string, last bool) {
+	branch, indent := "├── ", "│   "
+	if last {
+		branch, indent = "└── ", "    "
+	}
+	label := node.name
+	if !node.required {
+		label += " (optional)"
+	}
+	fmt.Fprintln(w, prefix+branch+label)
+	deps := serviceGraph[node.name]
+	for i, dep := range deps {
+		printTree(w, dep, prefix+indent, i == len(deps)-1)
+	}
+}
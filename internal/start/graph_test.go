@@ -0,0 +1,63 @@
+package start
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestResolveExcluded(t *testing.T) {
+	t.Run("excludes required dependents", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		excluded := ResolveExcluded([]string{"postgres-meta", "storage-api"}, &out)
+		// Check output
+		assert.Equal(t, []string{"imgproxy", "postgres-meta", "storage-api", "studio"}, excluded)
+		assert.Contains(t, out.String(), "Excluding imgproxy because it depends on storage-api.")
+		assert.Contains(t, out.String(), "Excluding studio because it depends on postgres-meta.")
+		assert.Contains(t, out.String(), "kong may not work as expected without storage-api.")
+	})
+
+	t.Run("disables analytics by alias", func(t *testing.T) {
+		utils.Config.Analytics.Enabled = true
+		t.Cleanup(func() { utils.Config.Analytics.Enabled = false })
+		var out bytes.Buffer
+		// Run test
+		excluded := ResolveExcluded([]string{"analytics"}, &out)
+		// Check output
+		assert.Equal(t, []string{"logflare", "vector"}, excluded)
+		assert.False(t, utils.Config.Analytics.Enabled)
+		assert.Contains(t, out.String(), "studio may not work as expected without logflare.")
+	})
+
+	t.Run("disables analytics when logflare is excluded", func(t *testing.T) {
+		utils.Config.Analytics.Enabled = true
+		t.Cleanup(func() { utils.Config.Analytics.Enabled = false })
+		// Run test
+		excluded := ResolveExcluded([]string{"logflare"}, io.Discard)
+		// Check output
+		assert.Equal(t, []string{"logflare", "vector"}, excluded)
+		assert.False(t, utils.Config.Analytics.Enabled)
+	})
+
+	t.Run("ignores unknown container", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		excluded := ResolveExcluded([]string{"unknown"}, &out)
+		// Check output
+		assert.Empty(t, excluded)
+		assert.Contains(t, out.String(), "unknown container")
+	})
+}
+
+func TestPrintGraph(t *testing.T) {
+	var out bytes.Buffer
+	// Run test
+	PrintGraph(&out)
+	// Check output
+	assert.Contains(t, out.String(), "vector\n└── logflare\n")
+	assert.Contains(t, out.String(), "studio\n├── postgres-meta\n├── kong (optional)\n")
+}
@@ -0,0 +1,89 @@
+package start
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Reassigns host ports that are already in use and persists only the reassigned
+// ports, so subsequent commands like status and db reset connect to the same ports.
+func assignFreePorts(fsys afero.Fs) error {
+	previous, err := utils.LoadReassignedPorts(fsys)
+	if err != nil {
+		return err
+	}
+	hostPorts := utils.GetHostPorts()
+	names := make([]string, 0, len(hostPorts))
+	for name, port := range hostPorts {
+		// Start from the configured port instead of the previous selection
+		if p, ok := previous[name]; ok && *port == p.Selected {
+			*port = p.Configured
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	taken := make(map[uint16]bool, len(names))
+	for _, name := range names {
+		taken[*hostPorts[name]] = true
+	}
+	selected := map[string]utils.ReassignedPort{}
+	for _, name := range names {
+		port := hostPorts[name]
+		if isPortAvailable(*port) {
+			continue
+		}
+		// Keep the previous selection stable across restarts
+		free := previous[name].Selected
+		if free == 0 || taken[free] || !isPortAvailable(free) {
+			if free, err = findFreePort(*port, taken); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintf(os.Stderr, "Port %d is in use, using %d for %s.\n", *port, free, utils.Aqua(name))
+		selected[name] = utils.ReassignedPort{Configured: *port, Selected: free}
+		*port = free
+		taken[free] = true
+	}
+	if len(selected) == 0 {
+		if err := fsys.Remove(utils.PortsPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return errors.Errorf("failed to remove %s: %w", utils.PortsPath, err)
+		}
+		return nil
+	}
+	contents, err := json.Marshal(selected)
+	if err != nil {
+		return errors.Errorf("failed to encode ports: %w", err)
+	}
+	return utils.WriteFile(utils.PortsPath, contents, fsys)
+}
+
+func isPortAvailable(port uint16) bool {
+	l, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return false
+	}
+	return l.Close() == nil
+}
+
+// Prefers ports close to the configured one so they remain easy to recognise.
+func findFreePort(start uint16, taken map[uint16]bool) (uint16, error) {
+	for port := uint32(start) + 1; port <= uint32(start)+100 && port <= 65535; port++ {
+		if !taken[uint16(port)] && isPortAvailable(uint16(port)) {
+			return uint16(port), nil
+		}
+	}
+	// Fallback to any ephemeral port assigned by the OS
+	l, err := net.Listen("tcp", ":0")
+	if err != nil {
+		return 0, errors.Errorf("failed to find free port: %w", err)
+	}
+	defer l.Close()
+	return uint16(l.Addr().(*net.TCPAddr).Port), nil
+}
@@ -0,0 +1,66 @@
+package start
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestAssignFreePorts(t *testing.T) {
+	t.Run("reassigns port in use", func(t *testing.T) {
+		// Setup listener on api port
+		l, err := net.Listen("tcp", ":0")
+		require.NoError(t, err)
+		defer l.Close()
+		original := utils.Config.Api.Port
+		t.Cleanup(func() { utils.Config.Api.Port = original })
+		utils.Config.Api.Port = uint16(l.Addr().(*net.TCPAddr).Port)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err = assignFreePorts(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.NotEqual(t, uint16(l.Addr().(*net.TCPAddr).Port), utils.Config.Api.Port)
+		contents, err := afero.ReadFile(fsys, utils.PortsPath)
+		require.NoError(t, err)
+		var ports map[string]utils.ReassignedPort
+		require.NoError(t, json.Unmarshal(contents, &ports))
+		assert.Equal(t, utils.ReassignedPort{
+			Configured: uint16(l.Addr().(*net.TCPAddr).Port),
+			Selected:   utils.Config.Api.Port,
+		}, ports["api.port"])
+		assert.NotContains(t, ports, "studio.port")
+	})
+
+	t.Run("removes selection when configured port is free", func(t *testing.T) {
+		original := utils.Config.Api.Port
+		t.Cleanup(func() { utils.Config.Api.Port = original })
+		utils.Config.Api.Port = 54330
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.PortsPath, []byte(`{"api.port":{"configured":54329,"selected":54330}}`), 0644))
+		// Run test
+		err := assignFreePorts(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, uint16(54329), utils.Config.Api.Port)
+		exists, err := afero.Exists(fsys, utils.PortsPath)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("throws error on permission denied", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewReadOnlyFs(afero.NewMemMapFs())
+		// Run test
+		err := assignFreePorts(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "operation not permitted")
+	})
+}
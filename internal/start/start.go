@@ -37,19 +37,62 @@ func suggestUpdateCmd(serviceImages map[string]string) string {
 	return cmd
 }
 
-func Run(ctx context.Context, fsys afero.Fs, excludedContainers []string, ignoreHealthCheck bool) error {
+// Returns the workspace directory of the running database container, if labelled.
+func getRunningWorkdir(ctx context.Context) string {
+	resp, err := utils.Docker.ContainerInspect(ctx, utils.DbId)
+	if err != nil || resp.Config == nil {
+		return ""
+	}
+	return resp.Config.Labels[utils.CliWorkdirLabel]
+}
+
+func getCurrentWorkdir() string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	return cwd
+}
+
+func isolateWorkspace(other string, fsys afero.Fs) error {
+	projectId := utils.GetWorkspaceProjectId(utils.Config.ProjectId, getCurrentWorkdir())
+	fmt.Fprintf(utils.GetStatusWriter(), "Project %s is already running in %s. Starting an isolated stack as %s.\n", utils.Aqua(utils.Config.ProjectId), utils.Bold(other), utils.Aqua(projectId))
+	if err := utils.WriteFile(utils.WorkspaceIdPath, []byte(projectId), fsys); err != nil {
+		return err
+	}
+	utils.Config.ProjectId = projectId
+	utils.UpdateDockerIds()
+	return nil
+}
+
+func Run(ctx context.Context, fsys afero.Fs, excludedContainers []string, ignoreHealthCheck, autoPorts, isolate bool) error {
 	// Sanity checks.
 	{
 		if err := utils.LoadConfigFS(fsys); err != nil {
 			return err
 		}
+		excludedContainers = ResolveExcluded(excludedContainers, os.Stderr)
 		if err := utils.AssertSupabaseDbIsRunning(); err == nil {
-			fmt.Fprintln(os.Stderr, utils.Aqua("supabase start")+" is already running.")
-			utils.CmdSuggestion = fmt.Sprintf("Run %s to show status of local Supabase containers.", utils.Aqua("supabase status"))
-			return nil
+			if workdir := getRunningWorkdir(ctx); len(workdir) == 0 || workdir == getCurrentWorkdir() {
+				fmt.Fprintln(os.Stderr, utils.Aqua("supabase start")+" is already running.")
+				utils.CmdSuggestion = fmt.Sprintf("Run %s to show status of local Supabase containers.", utils.Aqua("supabase status"))
+				return nil
+			} else if !isolate {
+				utils.CmdSuggestion = fmt.Sprintf("Run %s to start a separate stack for this workspace.", utils.Aqua("supabase start --isolate"))
+				return errors.Errorf("Project %s is already running in %s.", utils.Config.ProjectId, workdir)
+			} else if err := isolateWorkspace(workdir, fsys); err != nil {
+				return err
+			}
+			// Host ports are still bound by the other workspace's stack
+			autoPorts = true
 		} else if !errors.Is(err, utils.ErrNotRunning) {
 			return err
 		}
+		if autoPorts {
+			if err := assignFreePorts(fsys); err != nil {
+				return err
+			}
+		}
 		if _, err := utils.LoadAccessTokenFS(fsys); err == nil {
 			if ref, err := flags.LoadProjectRef(fsys); err == nil {
 				local := services.GetServiceImages()
@@ -80,7 +123,7 @@ func Run(ctx context.Context, fsys afero.Fs, excludedContainers []string, ignore
 		if ignoreHealthCheck && start.IsUnhealthyError(err) {
 			fmt.Fprintln(os.Stderr, err)
 		} else {
-			if err := utils.DockerRemoveAll(context.Background(), os.Stderr); err != nil {
+			if err := utils.DockerRemoveAll(context.Background(), utils.GetStatusWriter()); err != nil {
 				fmt.Fprintln(os.Stderr, err)
 			}
 			return err
@@ -102,6 +145,9 @@ type kongConfig struct {
 	LogflareId    string
 	ApiHost       string
 	ApiPort       uint16
+	// Routes to edge runtime in addition to /functions/v1/
+	FunctionsPaths []string
+	FunctionsHosts []string
 }
 
 var (
@@ -317,15 +363,17 @@ EOF
 	if !isContainerExcluded(utils.KongImage, excluded) {
 		var kongConfigBuf bytes.Buffer
 		if err := kongConfigTemplate.Execute(&kongConfigBuf, kongConfig{
-			GotrueId:      utils.GotrueId,
-			RestId:        utils.RestId,
-			RealtimeId:    utils.Config.Realtime.TenantId,
-			StorageId:     utils.StorageId,
-			PgmetaId:      utils.PgmetaId,
-			EdgeRuntimeId: utils.EdgeRuntimeId,
-			LogflareId:    utils.LogflareId,
-			ApiHost:       utils.Config.Hostname,
-			ApiPort:       utils.Config.Api.Port,
+			GotrueId:       utils.GotrueId,
+			RestId:         utils.RestId,
+			RealtimeId:     utils.Config.Realtime.TenantId,
+			StorageId:      utils.StorageId,
+			PgmetaId:       utils.PgmetaId,
+			EdgeRuntimeId:  utils.EdgeRuntimeId,
+			LogflareId:     utils.LogflareId,
+			ApiHost:        utils.Config.Hostname,
+			ApiPort:        utils.Config.Api.Port,
+			FunctionsPaths: utils.Config.EdgeRuntime.PathPrefixes,
+			FunctionsHosts: utils.Config.EdgeRuntime.Hosts,
 		}); err != nil {
 			return errors.Errorf("failed to exec template: %w", err)
 		}
@@ -595,6 +643,7 @@ EOF
 				},
 			},
 			start.WithSyslogConfig(container.HostConfig{
+				Resources:     utils.Config.Auth.Resources.ToContainerResources(),
 				RestartPolicy: container.RestartPolicy{Name: "always"},
 			}),
 			network.NetworkingConfig{
@@ -692,6 +741,7 @@ EOF
 				},
 			},
 			start.WithSyslogConfig(container.HostConfig{
+				Resources:     utils.Config.Realtime.Resources.ToContainerResources(),
 				RestartPolicy: container.RestartPolicy{Name: "always"},
 			}),
 			network.NetworkingConfig{
@@ -710,6 +760,11 @@ EOF
 
 	// Start PostgREST.
 	if utils.Config.Api.Enabled && !isContainerExcluded(utils.Config.Api.Image, excluded) {
+		// Accepts JWTs signed by either the local secret or a third-party auth provider
+		jwks, err := utils.ResolveJWKS(ctx, fsys)
+		if err != nil {
+			return err
+		}
 		if _, err := utils.DockerStart(
 			ctx,
 			container.Config{
@@ -720,12 +775,13 @@ EOF
 					"PGRST_DB_EXTRA_SEARCH_PATH=" + strings.Join(utils.Config.Api.ExtraSearchPath, ","),
 					fmt.Sprintf("PGRST_DB_MAX_ROWS=%d", utils.Config.Api.MaxRows),
 					"PGRST_DB_ANON_ROLE=anon",
-					"PGRST_JWT_SECRET=" + utils.Config.Auth.JwtSecret,
+					"PGRST_JWT_SECRET=" + jwks,
 					"PGRST_ADMIN_SERVER_PORT=3001",
 				},
 				// PostgREST does not expose a shell for health check
 			},
 			start.WithSyslogConfig(container.HostConfig{
+				Resources:     utils.Config.Api.Resources.ToContainerResources(),
 				RestartPolicy: container.RestartPolicy{Name: "always"},
 			}),
 			network.NetworkingConfig{
@@ -782,6 +838,7 @@ EOF
 				},
 			},
 			start.WithSyslogConfig(container.HostConfig{
+				Resources:     utils.Config.Storage.Resources.ToContainerResources(),
 				RestartPolicy: container.RestartPolicy{Name: "always"},
 				Binds:         []string{utils.StorageId + ":" + dockerStoragePath},
 			}),
@@ -836,9 +893,9 @@ EOF
 	}
 
 	// Start all functions.
-	if utils.Config.EdgeRuntime.Enabled && !isContainerExcluded(utils.EdgeRuntimeImage, excluded) {
+	if utils.Config.EdgeRuntime.Enabled && !isContainerExcluded(utils.Config.EdgeRuntime.Image, excluded) {
 		dbUrl := fmt.Sprintf("postgresql://%s:%s@%s:%d/%s", dbConfig.User, dbConfig.Password, dbConfig.Host, dbConfig.Port, dbConfig.Database)
-		if err := serve.ServeFunctions(ctx, "", nil, "", dbUrl, serve.RuntimeOption{}, w, fsys); err != nil {
+		if err := serve.ServeFunctions(ctx, nil, nil, "", dbUrl, serve.RuntimeOption{}, w, fsys); err != nil {
 			return err
 		}
 		started = append(started, utils.EdgeRuntimeId)
@@ -912,6 +969,7 @@ EOF
 				},
 			},
 			container.HostConfig{
+				Resources:     utils.Config.Studio.Resources.ToContainerResources(),
 				PortBindings:  nat.PortMap{"3000/tcp": []nat.PortBinding{{HostPort: strconv.FormatUint(uint64(utils.Config.Studio.Port), 10)}}},
 				RestartPolicy: container.RestartPolicy{Name: "always"},
 			},
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/volume"
 	"github.com/h2non/gock"
 	"github.com/jackc/pgconn"
@@ -23,7 +24,7 @@ import (
 
 func TestStartCommand(t *testing.T) {
 	t.Run("throws error on missing config", func(t *testing.T) {
-		err := Run(context.Background(), afero.NewMemMapFs(), []string{}, false)
+		err := Run(context.Background(), afero.NewMemMapFs(), []string{}, false, false, false)
 		assert.ErrorIs(t, err, os.ErrNotExist)
 	})
 
@@ -32,7 +33,7 @@ func TestStartCommand(t *testing.T) {
 		fsys := afero.NewMemMapFs()
 		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte("malformed"), 0644))
 		// Run test
-		err := Run(context.Background(), fsys, []string{}, false)
+		err := Run(context.Background(), fsys, []string{}, false, false, false)
 		// Check error
 		assert.ErrorContains(t, err, "toml: line 0: unexpected EOF; expected key separator '='")
 	})
@@ -48,7 +49,7 @@ func TestStartCommand(t *testing.T) {
 			Get("/v" + utils.Docker.ClientVersion() + "/containers").
 			ReplyError(errors.New("network error"))
 		// Run test
-		err := Run(context.Background(), fsys, []string{}, false)
+		err := Run(context.Background(), fsys, []string{}, false, false, false)
 		// Check error
 		assert.ErrorContains(t, err, "network error")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -63,14 +64,77 @@ func TestStartCommand(t *testing.T) {
 		defer gock.OffAll()
 		gock.New(utils.Docker.DaemonHost()).
 			Get("/v" + utils.Docker.ClientVersion() + "/containers").
+			Times(2).
 			Reply(http.StatusOK).
 			JSON(types.ContainerJSON{})
 		// Run test
-		err := Run(context.Background(), fsys, []string{}, false)
+		err := Run(context.Background(), fsys, []string{}, false, false, false)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
+
+	t.Run("throws error if running in another workspace", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers").
+			Times(2).
+			Reply(http.StatusOK).
+			JSON(types.ContainerJSON{Config: &container.Config{
+				Labels: map[string]string{utils.CliWorkdirLabel: "/tmp/other"},
+			}})
+		// Run test
+		err := Run(context.Background(), fsys, []string{}, false, false, false)
+		// Check error
+		assert.ErrorContains(t, err, "is already running in /tmp/other")
+		exists, err := afero.Exists(fsys, utils.WorkspaceIdPath)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestIsolateWorkspace(t *testing.T) {
+	t.Run("derives project id from workdir", func(t *testing.T) {
+		projectId := utils.Config.ProjectId
+		t.Cleanup(func() {
+			utils.Config.ProjectId = projectId
+			utils.UpdateDockerIds()
+		})
+		utils.Config.ProjectId = "test"
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := isolateWorkspace("/tmp/other", fsys)
+		// Check error
+		assert.NoError(t, err)
+		isolated := utils.GetWorkspaceProjectId("test", getCurrentWorkdir())
+		assert.Equal(t, isolated, utils.Config.ProjectId)
+		assert.Equal(t, "supabase_db_"+isolated, utils.DbId)
+		contents, err := afero.ReadFile(fsys, utils.WorkspaceIdPath)
+		assert.NoError(t, err)
+		assert.Equal(t, isolated, string(contents))
+	})
+
+	t.Run("throws error on permission denied", func(t *testing.T) {
+		projectId := utils.Config.ProjectId
+		t.Cleanup(func() {
+			utils.Config.ProjectId = projectId
+			utils.UpdateDockerIds()
+		})
+		utils.Config.ProjectId = "test"
+		// Setup in-memory fs
+		fsys := afero.NewReadOnlyFs(afero.NewMemMapFs())
+		// Run test
+		err := isolateWorkspace("/tmp/other", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "operation not permitted")
+	})
 }
 
 func TestDatabaseStart(t *testing.T) {
@@ -253,3 +317,38 @@ func TestFormatMapForEnvConfig(t *testing.T) {
 		}
 	})
 }
+
+func TestKongConfig(t *testing.T) {
+	t.Run("routes functions on custom paths and hosts", func(t *testing.T) {
+		var buf bytes.Buffer
+		// Run test
+		err := kongConfigTemplate.Execute(&buf, kongConfig{
+			EdgeRuntimeId:  "edge_runtime",
+			FunctionsPaths: []string{"/api/functions/"},
+			FunctionsHosts: []string{"*.functions.localhost"},
+		})
+		// Check output
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), `        paths:
+          - /functions/v1/
+          - /api/functions/
+      - name: functions-v1-hosts
+        strip_path: false
+        hosts:
+          - "*.functions.localhost"
+`)
+	})
+
+	t.Run("omits host route by default", func(t *testing.T) {
+		var buf bytes.Buffer
+		// Run test
+		err := kongConfigTemplate.Execute(&buf, kongConfig{EdgeRuntimeId: "edge_runtime"})
+		// Check output
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), `        paths:
+          - /functions/v1/
+  - name: analytics-v1
+`)
+		assert.NotContains(t, buf.String(), "functions-v1-hosts")
+	})
+}
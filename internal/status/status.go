@@ -1,6 +1,7 @@
 package status
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -8,6 +9,7 @@ import (
 	"net/url"
 	"os"
 	"reflect"
+	"strings"
 	"sync"
 	"time"
 
@@ -23,6 +25,8 @@ type CustomName struct {
 	GraphqlURL               string `env:"api.graphql_url,default=GRAPHQL_URL"`
 	StorageS3URL             string `env:"api.storage_s3_url,default=STORAGE_S3_URL"`
 	DbURL                    string `env:"db.url,default=DB_URL"`
+	DbPreloadLibraries       string `env:"db.shared_preload_libraries,default=DB_SHARED_PRELOAD_LIBRARIES"`
+	DbParameters             string `env:"db.parameters,default=DB_PARAMETERS"`
 	StudioURL                string `env:"studio.url,default=STUDIO_URL"`
 	InbucketURL              string `env:"inbucket.url,default=INBUCKET_URL"`
 	JWTSecret                string `env:"auth.jwt_secret,default=JWT_SECRET"`
@@ -31,12 +35,35 @@ type CustomName struct {
 	StorageS3AccessKeyId     string `env:"storage.s3_access_key_id,default=S3_PROTOCOL_ACCESS_KEY_ID"`
 	StorageS3SecretAccessKey string `env:"storage.s3_secret_access_key,default=S3_PROTOCOL_ACCESS_KEY_SECRET"`
 	StorageS3Region          string `env:"storage.s3_region,default=S3_PROTOCOL_REGION"`
+	FunctionsURL             string `env:"api.functions_url,default=FUNCTIONS_URL"`
+	StorageURL               string `env:"api.storage_url,default=STORAGE_URL"`
+}
+
+// Prepends prefix to all variable names, eg. NEXT_PUBLIC_ for frontend frameworks.
+// Names in overrides are used verbatim.
+func (c *CustomName) AddPrefix(prefix string, overrides map[string]string) {
+	val := reflect.ValueOf(c).Elem()
+	for i := 0; i < val.NumField(); i++ {
+		key, _, _ := strings.Cut(val.Type().Field(i).Tag.Get("env"), ",")
+		if _, ok := overrides[key]; ok {
+			continue
+		}
+		field := val.Field(i)
+		field.SetString(prefix + field.String())
+	}
 }
 
 func (c *CustomName) toValues(exclude ...string) map[string]string {
 	values := map[string]string{
 		c.DbURL: fmt.Sprintf("postgresql://%s@%s:%d/postgres", url.UserPassword("postgres", utils.Config.Db.Password), utils.Config.Hostname, utils.Config.Db.Port),
 	}
+	// Only extra libraries are listed, in addition to those preloaded by the image
+	if libs := utils.Config.Db.Settings.SharedPreloadLibraries; len(libs) > 0 {
+		values[c.DbPreloadLibraries] = strings.Join(libs, ",")
+	}
+	if params := utils.Config.Db.Settings.Assignments(); len(params) > 0 {
+		values[c.DbParameters] = strings.Join(params, ",")
+	}
 	if utils.Config.Api.Enabled && !utils.SliceContains(exclude, utils.RestId) && !utils.SliceContains(exclude, utils.ShortContainerImageName(utils.Config.Api.Image)) {
 		values[c.ApiURL] = fmt.Sprintf("http://%s:%d", utils.Config.Hostname, utils.Config.Api.Port)
 		values[c.GraphqlURL] = fmt.Sprintf("http://%s:%d/graphql/v1", utils.Config.Hostname, utils.Config.Api.Port)
@@ -57,11 +84,16 @@ func (c *CustomName) toValues(exclude ...string) map[string]string {
 		values[c.StorageS3AccessKeyId] = utils.Config.Storage.S3Credentials.AccessKeyId
 		values[c.StorageS3SecretAccessKey] = utils.Config.Storage.S3Credentials.SecretAccessKey
 		values[c.StorageS3Region] = utils.Config.Storage.S3Credentials.Region
+		values[c.StorageURL] = fmt.Sprintf("http://%s:%d/storage/v1", utils.Config.Hostname, utils.Config.Api.Port)
+	}
+	// Functions are served through the api gateway
+	if utils.Config.Api.Enabled && utils.Config.EdgeRuntime.Enabled && !utils.SliceContains(exclude, utils.EdgeRuntimeId) && !utils.SliceContains(exclude, utils.ShortContainerImageName(utils.Config.EdgeRuntime.Image)) {
+		values[c.FunctionsURL] = fmt.Sprintf("http://%s:%d/functions/v1", utils.Config.Hostname, utils.Config.Api.Port)
 	}
 	return values
 }
 
-func Run(ctx context.Context, names CustomName, format string, fsys afero.Fs) error {
+func Run(ctx context.Context, names CustomName, format, outputFile string, fsys afero.Fs) error {
 	// Sanity checks.
 	if err := utils.LoadConfigFS(fsys); err != nil {
 		return err
@@ -77,6 +109,21 @@ func Run(ctx context.Context, names CustomName, format string, fsys afero.Fs) er
 	if len(stopped) > 0 {
 		fmt.Fprintln(os.Stderr, "Stopped services:", stopped)
 	}
+	if len(outputFile) > 0 {
+		// Dotenv is the most common format for writing to file
+		if format == utils.OutputPretty {
+			format = utils.OutputEnv
+		}
+		var buf bytes.Buffer
+		if err := printStatus(names, format, &buf, stopped...); err != nil {
+			return err
+		}
+		if err := utils.WriteFile(outputFile, buf.Bytes(), fsys); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, "Written status to", utils.Bold(outputFile))
+		return nil
+	}
 	if format == utils.OutputPretty {
 		fmt.Fprintf(os.Stderr, "%s local development setup is running.\n\n", utils.Aqua("supabase"))
 		PrettyPrint(os.Stdout, stopped...)
@@ -167,6 +214,8 @@ func PrettyPrint(w io.Writer, exclude ...string) {
 		GraphqlURL:               "     " + utils.Aqua("GraphQL URL"),
 		StorageS3URL:             "  " + utils.Aqua("S3 Storage URL"),
 		DbURL:                    "          " + utils.Aqua("DB URL"),
+		DbPreloadLibraries:       "      " + utils.Aqua("DB preload"),
+		DbParameters:             "   " + utils.Aqua("DB parameters"),
 		StudioURL:                "      " + utils.Aqua("Studio URL"),
 		InbucketURL:              "    " + utils.Aqua("Inbucket URL"),
 		JWTSecret:                "      " + utils.Aqua("JWT secret"),
@@ -175,6 +224,8 @@ func PrettyPrint(w io.Writer, exclude ...string) {
 		StorageS3AccessKeyId:     "   " + utils.Aqua("S3 Access Key"),
 		StorageS3SecretAccessKey: "   " + utils.Aqua("S3 Secret Key"),
 		StorageS3Region:          "       " + utils.Aqua("S3 Region"),
+		FunctionsURL:             "   " + utils.Aqua("Functions URL"),
+		StorageURL:               "     " + utils.Aqua("Storage URL"),
 	}
 	values := names.toValues(exclude...)
 	// Iterate through map in order of declared struct fields
@@ -1,10 +1,13 @@
 package new
 
 import (
+	"bytes"
 	"context"
 	_ "embed"
 	"fmt"
+	"os"
 	"path/filepath"
+	"text/template"
 
 	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
@@ -12,30 +15,89 @@ import (
 )
 
 const (
-	TemplatePgTAP = "pgtap"
+	TemplatePgTAP    = "pgtap"
+	TemplateRLS      = "rls"
+	TemplateTrigger  = "trigger"
+	TemplateFunction = "function"
 )
 
 var (
 	//go:embed templates/pgtap.sql
 	pgtapTest []byte
+	//go:embed templates/rls.sql
+	rlsTest string
+	//go:embed templates/trigger.sql
+	triggerTest string
+	//go:embed templates/function.sql
+	functionTest string
+
+	TemplateAllowed = []string{TemplatePgTAP, TemplateRLS, TemplateTrigger, TemplateFunction}
 )
 
-func Run(ctx context.Context, name, template string, fsys afero.Fs) error {
+type testParams struct {
+	Schema string
+	Name   string
+}
+
+// Run creates a new test file. Except for pgtap, templates target the table or function
+// named by the test, in schema or the first user schema exposed by the API.
+func Run(ctx context.Context, name, template, schema string, fsys afero.Fs) error {
 	path := filepath.Join(utils.DbTestsDir, fmt.Sprintf("%s_test.sql", name))
 	if _, err := fsys.Stat(path); err == nil {
 		return errors.New(path + " already exists.")
 	}
-	if err := utils.WriteFile(path, getTemplate(template), fsys); err != nil {
+	contents := pgtapTest
+	if template != TemplatePgTAP {
+		if err := utils.LoadConfigFS(fsys); err != nil {
+			return err
+		}
+		params := testParams{Schema: resolveSchema(schema), Name: name}
+		var err error
+		if contents, err = renderTemplate(template, params); err != nil {
+			return err
+		}
+	}
+	if err := utils.WriteFile(path, contents, fsys); err != nil {
 		return err
 	}
 	fmt.Printf("Created new %s test at %s.\n", template, utils.Bold(path))
 	return nil
 }
 
-func getTemplate(name string) []byte {
+func resolveSchema(schema string) string {
+	if len(schema) > 0 {
+		if !utils.SliceContains(utils.Config.Api.Schemas, schema) {
+			fmt.Fprintf(os.Stderr, "%s schema %s is not exposed by the API in %s\n", utils.Yellow("WARNING:"), utils.Bold(schema), utils.Bold(utils.ConfigPath))
+		}
+		return schema
+	}
+	for _, s := range utils.Config.Api.Schemas {
+		if !utils.SliceContains(utils.InternalSchemas, s) {
+			return s
+		}
+	}
+	return "public"
+}
+
+func renderTemplate(name string, params testParams) ([]byte, error) {
+	var text string
 	switch name {
-	case TemplatePgTAP:
-		return pgtapTest
+	case TemplateRLS:
+		text = rlsTest
+	case TemplateTrigger:
+		text = triggerTest
+	case TemplateFunction:
+		text = functionTest
+	default:
+		return nil, errors.Errorf("Unknown test template: %s", name)
 	}
-	return nil
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, params); err != nil {
+		return nil, errors.Errorf("failed to render template: %w", err)
+	}
+	return buf.Bytes(), nil
 }
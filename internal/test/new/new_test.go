@@ -2,6 +2,7 @@ package new
 
 import (
 	"context"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -16,7 +17,7 @@ func TestCreatePgTAP(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := Run(context.Background(), "pet", TemplatePgTAP, fsys)
+		err := Run(context.Background(), "pet", TemplatePgTAP, "", fsys)
 		// Check error
 		assert.NoError(t, err)
 		f, err := fsys.Stat(filepath.Join(utils.DbTestsDir, "pet_test.sql"))
@@ -28,7 +29,7 @@ func TestCreatePgTAP(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := Run(context.Background(), "pet", TemplatePgTAP, afero.NewReadOnlyFs(fsys))
+		err := Run(context.Background(), "pet", TemplatePgTAP, "", afero.NewReadOnlyFs(fsys))
 		// Check error
 		assert.ErrorContains(t, err, "operation not permitted")
 	})
@@ -39,8 +40,46 @@ func TestCreatePgTAP(t *testing.T) {
 		_, err := fsys.Create(filepath.Join(utils.DbTestsDir, "pet_test.sql"))
 		require.NoError(t, err)
 		// Run test
-		err = Run(context.Background(), "pet", TemplatePgTAP, fsys)
+		err = Run(context.Background(), "pet", TemplatePgTAP, "", fsys)
 		// Check error
 		assert.ErrorContains(t, err, "already exists")
 	})
 }
+
+func TestCreateFromTemplate(t *testing.T) {
+	t.Run("creates rls test for exposed schema", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Run test
+		err := Run(context.Background(), "profiles", TemplateRLS, "", fsys)
+		// Check error
+		assert.NoError(t, err)
+		contents, err := afero.ReadFile(fsys, filepath.Join(utils.DbTestsDir, "profiles_test.sql"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(contents), "SELECT has_table('public', 'profiles'")
+		assert.Contains(t, string(contents), "'public.profiles'::regclass")
+	})
+
+	t.Run("creates function test in custom schema", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Run test
+		err := Run(context.Background(), "is_admin", TemplateFunction, "private", fsys)
+		// Check error
+		assert.NoError(t, err)
+		contents, err := afero.ReadFile(fsys, filepath.Join(utils.DbTestsDir, "is_admin_test.sql"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(contents), "SELECT has_function('private', 'is_admin'")
+	})
+
+	t.Run("throws error on missing config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Run(context.Background(), "profiles", TemplateTrigger, "", fsys)
+		// Check error
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
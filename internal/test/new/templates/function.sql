@@ -0,0 +1,10 @@
+BEGIN;
+SELECT plan(1);
+
+SELECT has_function('{{ .Schema }}', '{{ .Name }}', 'Function {{ .Schema }}.{{ .Name }} should exist');
+
+-- Example: verify the function result
+-- SELECT is({{ .Schema }}.{{ .Name }}(...), ..., 'Function {{ .Schema }}.{{ .Name }} should return ...');
+
+SELECT * FROM finish();
+ROLLBACK;
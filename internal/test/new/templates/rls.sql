@@ -0,0 +1,26 @@
+BEGIN;
+SELECT plan(4);
+
+SELECT has_table('{{ .Schema }}', '{{ .Name }}', 'Table {{ .Schema }}.{{ .Name }} should exist');
+SELECT is(
+  (SELECT relrowsecurity FROM pg_class WHERE oid = '{{ .Schema }}.{{ .Name }}'::regclass),
+  true,
+  'Row level security should be enabled on {{ .Schema }}.{{ .Name }}'
+);
+SELECT ok(
+  EXISTS (SELECT 1 FROM pg_policies WHERE schemaname = '{{ .Schema }}' AND tablename = '{{ .Name }}'),
+  'Table {{ .Schema }}.{{ .Name }} should have policies'
+);
+-- Example: SELECT policies_are('{{ .Schema }}', '{{ .Name }}', ARRAY['policy name']);
+
+-- Simulate a request from an authenticated user
+SET LOCAL ROLE authenticated;
+SELECT set_config('request.jwt.claims', '{"sub": "00000000-0000-0000-0000-000000000000", "role": "authenticated"}', true);
+SELECT lives_ok(
+  'SELECT * FROM {{ .Schema }}.{{ .Name }}',
+  'Authenticated users should be able to query {{ .Schema }}.{{ .Name }}'
+);
+RESET ROLE;
+
+SELECT * FROM finish();
+ROLLBACK;
@@ -0,0 +1,16 @@
+BEGIN;
+SELECT plan(2);
+
+SELECT has_table('{{ .Schema }}', '{{ .Name }}', 'Table {{ .Schema }}.{{ .Name }} should exist');
+SELECT ok(
+  EXISTS (SELECT 1 FROM pg_trigger WHERE tgrelid = '{{ .Schema }}.{{ .Name }}'::regclass AND NOT tgisinternal),
+  'Table {{ .Schema }}.{{ .Name }} should have triggers'
+);
+-- Example: SELECT has_trigger('{{ .Schema }}', '{{ .Name }}', 'trigger name');
+
+-- Example: verify the trigger effect on write
+-- INSERT INTO {{ .Schema }}.{{ .Name }} (...) VALUES (...);
+-- SELECT is((SELECT ... FROM {{ .Schema }}.{{ .Name }}), ..., 'Trigger should update ...');
+
+SELECT * FROM finish();
+ROLLBACK;
@@ -13,5 +13,11 @@ func MockMigrationHistory(conn *MockConn) {
 		Query(history.ADD_STATEMENTS_COLUMN).
 		Reply("ALTER TABLE").
 		Query(history.ADD_NAME_COLUMN).
+		Reply("ALTER TABLE").
+		Query(history.ADD_CREATED_AT_COLUMN).
+		Reply("ALTER TABLE").
+		Query(history.SET_CREATED_AT_DEFAULT).
+		Reply("ALTER TABLE").
+		Query(history.ADD_CHECKSUM_COLUMN).
 		Reply("ALTER TABLE")
 }
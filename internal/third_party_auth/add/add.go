@@ -0,0 +1,46 @@
+package add
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	migration "github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/third_party_auth/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+// Run registers a third-party auth integration on the project. When neither url is
+// specified, the issuer of the provider enabled under [auth.third_party] is used.
+func Run(ctx context.Context, projectRef, issuerUrl, jwksUrl, format string, fsys afero.Fs) error {
+	if len(issuerUrl) == 0 && len(jwksUrl) == 0 {
+		if err := utils.LoadConfigFS(fsys); err != nil {
+			return err
+		}
+		if issuerUrl = utils.Config.Auth.ThirdParty.IssuerURL(); len(issuerUrl) == 0 {
+			return errors.New("Missing third-party auth provider. Specify --issuer-url or enable a provider under [auth.third_party] in config.toml.")
+		}
+	}
+	body := api.V1CreateProjectTpaIntegrationJSONRequestBody{}
+	if len(issuerUrl) > 0 {
+		body.OidcIssuerUrl = &issuerUrl
+	}
+	if len(jwksUrl) > 0 {
+		body.JwksUrl = &jwksUrl
+	}
+	resp, err := utils.GetSupabase().V1CreateProjectTpaIntegrationWithResponse(ctx, projectRef, body)
+	if err != nil {
+		return errors.Errorf("failed to add third-party auth integration: %w", err)
+	}
+	if resp.JSON201 == nil {
+		return errors.New("Unexpected error adding third-party auth integration: " + string(resp.Body))
+	}
+	if format == utils.OutputPretty {
+		fmt.Fprintln(os.Stderr, "Added third-party auth integration to project "+utils.Aqua(projectRef)+".")
+		return migration.RenderTable(list.ToMarkdown([]api.ThirdPartyAuth{*resp.JSON201}))
+	}
+	return utils.EncodeOutput(format, os.Stdout, resp.JSON201)
+}
@@ -0,0 +1,97 @@
+package add
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestAddCommand(t *testing.T) {
+	// Setup valid project ref
+	project := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	tpa := api.ThirdPartyAuth{
+		Id:            "5e9b8d7c-7a1f-4b9a-9d7c-2f1e0c3b4a5d",
+		Type:          "firebase",
+		OidcIssuerUrl: utils.Ptr("https://securetoken.google.com/my-project"),
+		InsertedAt:    "2024-06-01T00:00:00Z",
+		UpdatedAt:     "2024-06-01T00:00:00Z",
+	}
+
+	t.Run("adds issuer from flag", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/config/auth/third-party-auth").
+			MatchType("json").
+			JSON(api.CreateThirdPartyAuthBody{OidcIssuerUrl: tpa.OidcIssuerUrl}).
+			Reply(http.StatusCreated).
+			JSON(tpa)
+		// Run test
+		err := Run(context.Background(), project, *tpa.OidcIssuerUrl, "", utils.OutputPretty, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("adds issuer from config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		config, err := afero.ReadFile(fsys, utils.ConfigPath)
+		require.NoError(t, err)
+		config = bytes.Replace(config, []byte(`[auth.third_party.firebase]
+enabled = false
+# project_id = "my-firebase-project"`), []byte(`[auth.third_party.firebase]
+enabled = true
+project_id = "my-project"`), 1)
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, config, 0644))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/config/auth/third-party-auth").
+			MatchType("json").
+			JSON(api.CreateThirdPartyAuthBody{OidcIssuerUrl: tpa.OidcIssuerUrl}).
+			Reply(http.StatusCreated).
+			JSON(tpa)
+		// Run test
+		err = Run(context.Background(), project, "", "", utils.OutputJson, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing provider", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Run test
+		err := Run(context.Background(), project, "", "", utils.OutputPretty, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Missing third-party auth provider.")
+	})
+
+	t.Run("throws error on service unavailable", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/config/auth/third-party-auth").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		err := Run(context.Background(), project, "", "https://example.com/jwks.json", utils.OutputPretty, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Unexpected error adding third-party auth integration:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -0,0 +1,50 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	migration "github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func Run(ctx context.Context, projectRef, format string) error {
+	resp, err := utils.GetSupabase().V1ListProjectTpaIntegrationsWithResponse(ctx, projectRef)
+	if err != nil {
+		return errors.Errorf("failed to list third-party auth integrations: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return errors.New("Unexpected error listing third-party auth integrations: " + string(resp.Body))
+	}
+	if format == utils.OutputPretty {
+		return migration.RenderTable(ToMarkdown(*resp.JSON200))
+	}
+	return utils.EncodeOutput(format, os.Stdout, *resp.JSON200)
+}
+
+func ToMarkdown(integrations []api.ThirdPartyAuth) string {
+	table := `|ID|TYPE|ISSUER URL|JWKS URL|CREATED AT (UTC)|
+|-|-|-|-|-|
+`
+	for _, tpa := range integrations {
+		table += fmt.Sprintf(
+			"|`%s`|`%s`|`%s`|`%s`|`%s`|\n",
+			tpa.Id,
+			tpa.Type,
+			valueOrDash(tpa.OidcIssuerUrl),
+			valueOrDash(tpa.JwksUrl),
+			utils.FormatTimestamp(tpa.InsertedAt),
+		)
+	}
+	return table
+}
+
+func valueOrDash(value *string) string {
+	if value == nil || len(*value) == 0 {
+		return "-"
+	}
+	return *value
+}
@@ -0,0 +1,55 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestListCommand(t *testing.T) {
+	// Setup valid project ref
+	project := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("lists integrations", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/config/auth/third-party-auth").
+			Reply(http.StatusOK).
+			JSON([]api.ThirdPartyAuth{{
+				Id:            "5e9b8d7c-7a1f-4b9a-9d7c-2f1e0c3b4a5d",
+				Type:          "auth0",
+				OidcIssuerUrl: utils.Ptr("https://acme.auth0.com"),
+				InsertedAt:    "2024-06-01T00:00:00Z",
+				UpdatedAt:     "2024-06-01T00:00:00Z",
+			}})
+		// Run test
+		err := Run(context.Background(), project, utils.OutputPretty)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on network error", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/config/auth/third-party-auth").
+			ReplyError(errors.New("network error"))
+		// Run test
+		err := Run(context.Background(), project, utils.OutputPretty)
+		// Check error
+		assert.ErrorContains(t, err, "network error")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -0,0 +1,25 @@
+package remove
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(ctx context.Context, projectRef, tpaId string) error {
+	resp, err := utils.GetSupabase().V1DeleteProjectTpaIntegrationWithResponse(ctx, projectRef, tpaId)
+	if err != nil {
+		return errors.Errorf("failed to remove third-party auth integration: %w", err)
+	}
+	if resp.JSON200 == nil {
+		if resp.StatusCode() == http.StatusNotFound {
+			return errors.Errorf("A third-party auth integration with ID %q could not be found.", tpaId)
+		}
+		return errors.New("Unexpected error removing third-party auth integration: " + string(resp.Body))
+	}
+	fmt.Println("Removed third-party auth integration: " + utils.Aqua(tpaId))
+	return nil
+}
@@ -0,0 +1,49 @@
+package remove
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestRemoveCommand(t *testing.T) {
+	// Setup valid project ref
+	project := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	id := "5e9b8d7c-7a1f-4b9a-9d7c-2f1e0c3b4a5d"
+
+	t.Run("removes integration", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Delete("/v1/projects/" + project + "/config/auth/third-party-auth/" + id).
+			Reply(http.StatusOK).
+			JSON(api.ThirdPartyAuth{Id: id, Type: "auth0"})
+		// Run test
+		err := Run(context.Background(), project, id)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on not found", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Delete("/v1/projects/" + project + "/config/auth/third-party-auth/" + id).
+			Reply(http.StatusNotFound)
+		// Run test
+		err := Run(context.Background(), project, id)
+		// Check error
+		assert.ErrorContains(t, err, "could not be found")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -0,0 +1,54 @@
+// Downloads every asset in tools.Packages and writes their checksums to checksums.txt.
+package main
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/supabase/cli/internal/tools"
+)
+
+func main() {
+	urls := map[string]struct{}{}
+	for _, p := range tools.Packages {
+		for _, u := range p.Assets {
+			urls[u] = struct{}{}
+		}
+	}
+	var lines []string
+	for u := range urls {
+		digest, err := checksum(u)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		lines = append(lines, fmt.Sprintf("%x  %s", digest, u))
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		return strings.Fields(lines[i])[1] < strings.Fields(lines[j])[1]
+	})
+	if err := os.WriteFile("checksums.txt", []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+func checksum(url string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, resp.Body); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
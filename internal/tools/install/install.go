@@ -0,0 +1,39 @@
+package install
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/tools"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(ctx context.Context, names []string, fsys afero.Fs) error {
+	// Validate all names before downloading anything
+	packages := make([]tools.Package, len(names))
+	for i, name := range names {
+		p, err := tools.FindPackage(name)
+		if err != nil {
+			return err
+		}
+		packages[i] = p
+	}
+	for i, name := range names {
+		// Tools from the same package, ie. psql and pg_dump, are installed together
+		binPath, err := tools.Find(name, fsys)
+		if errors.Is(err, os.ErrNotExist) {
+			if err := tools.Install(ctx, packages[i], fsys); err != nil {
+				return err
+			}
+			binPath, err = tools.Find(name, fsys)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Println("Installed " + utils.Aqua(name) + " " + packages[i].Version + " at " + utils.Bold(binPath))
+	}
+	return nil
+}
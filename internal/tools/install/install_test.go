@@ -0,0 +1,40 @@
+package install
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/tools"
+)
+
+func TestInstallCommand(t *testing.T) {
+	viper.Set("TOOLS_DIR", "/tools")
+	t.Cleanup(func() { viper.Set("TOOLS_DIR", "") })
+
+	t.Run("skips installed tools", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		p, err := tools.FindPackage("psql")
+		require.NoError(t, err)
+		for _, name := range []string{"psql", "pg_dump"} {
+			binPath, err := p.BinPath(name)
+			require.NoError(t, err)
+			require.NoError(t, afero.WriteFile(fsys, binPath, []byte("binary"), 0755))
+		}
+		// Run test
+		err = Run(context.Background(), []string{"psql", "pg_dump"}, fsys)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on unknown tool", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), []string{"deno", "node"}, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Unknown tool: node. Must be one of: [deno pg_dump psql]")
+	})
+}
@@ -0,0 +1,20 @@
+//go:build darwin
+
+package tools
+
+import (
+	"runtime"
+	"syscall"
+)
+
+func getPlatform() string {
+	// Simple runtime.GOARCH detection doesn't work if the CLI is
+	// running under Rosetta:
+	// https://github.com/supabase/cli/issues/1266. So as a workaround
+	// we use Apple Silicon detection:
+	// https://www.yellowduck.be/posts/detecting-apple-silicon-via-go.
+	if _, err := syscall.Sysctl("sysctl.proc_translated"); err == nil {
+		return "darwin/arm64"
+	}
+	return runtime.GOOS + "/" + runtime.GOARCH
+}
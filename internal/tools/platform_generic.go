@@ -0,0 +1,9 @@
+//go:build !darwin
+
+package tools
+
+import "runtime"
+
+func getPlatform() string {
+	return runtime.GOOS + "/" + runtime.GOARCH
+}
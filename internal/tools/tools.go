@@ -0,0 +1,320 @@
+package tools
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/utils"
+)
+
+//go:generate go run ./gen
+
+// Checksums of all assets in Packages, following the sha256sum format, ie. `<hex digest>  <url>`
+// per line. Regenerate with go generate after changing any version.
+//
+//go:embed checksums.txt
+var checksums []byte
+
+var ErrChecksumNotFound = errors.New("Checksum not found")
+
+// Package is a pinned release of one or more tools, downloaded as a zip archive.
+type Package struct {
+	Name    string
+	Version string
+	// Executables provided by the package, relative to its install directory
+	Bins map[string]string
+	// Download urls keyed by GOOS/GOARCH
+	Assets map[string]string
+	// Printed when no asset is available for the current platform
+	Suggestion string
+}
+
+const (
+	postgresVersion = "16.4-1"
+	edbReleaseUrl   = "https://get.enterprisedb.com/postgresql/postgresql-" + postgresVersion
+)
+
+var Packages = []Package{{
+	Name:    "deno",
+	Version: utils.DenoVersion,
+	Bins:    map[string]string{"deno": "deno"},
+	Assets: map[string]string{
+		"darwin/amd64":  denoReleaseUrl("denoland/deno", "deno-x86_64-apple-darwin.zip"),
+		"darwin/arm64":  denoReleaseUrl("denoland/deno", "deno-aarch64-apple-darwin.zip"),
+		"linux/amd64":   denoReleaseUrl("denoland/deno", "deno-x86_64-unknown-linux-gnu.zip"),
+		"windows/amd64": denoReleaseUrl("denoland/deno", "deno-x86_64-pc-windows-msvc.zip"),
+		// TODO: version pin to official release once available https://github.com/denoland/deno/issues/1846
+		"linux/arm64": denoReleaseUrl("LukeChannings/deno-arm64", "deno-linux-arm64.zip"),
+	},
+}, {
+	Name:    "postgres",
+	Version: postgresVersion,
+	Bins: map[string]string{
+		"psql":    "pgsql/bin/psql",
+		"pg_dump": "pgsql/bin/pg_dump",
+	},
+	// EDB binaries are universal on macOS
+	Assets: map[string]string{
+		"darwin/amd64":  edbReleaseUrl + "-osx-binaries.zip",
+		"darwin/arm64":  edbReleaseUrl + "-osx-binaries.zip",
+		"windows/amd64": edbReleaseUrl + "-windows-x64-binaries.zip",
+	},
+	Suggestion: "Install postgresql-client with your system package manager, then set bin_dir under [db.tools] in config.toml to its bin directory.",
+}}
+
+func denoReleaseUrl(repo, asset string) string {
+	return fmt.Sprintf("https://github.com/%s/releases/download/v%s/%s", repo, utils.DenoVersion, asset)
+}
+
+// Names returns all tools that can be installed, in alphabetical order.
+func Names() []string {
+	var result []string
+	for _, p := range Packages {
+		for name := range p.Bins {
+			result = append(result, name)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func FindPackage(tool string) (Package, error) {
+	for _, p := range Packages {
+		if _, ok := p.Bins[tool]; ok {
+			return p, nil
+		}
+	}
+	return Package{}, errors.Errorf("Unknown tool: %s. Must be one of: %v", tool, Names())
+}
+
+// GetToolsDir returns the cache directory shared by all projects, which can be overridden
+// by SUPABASE_TOOLS_DIR to persist tools between CI runs.
+func GetToolsDir() (string, error) {
+	if dir := viper.GetString("TOOLS_DIR"); len(dir) > 0 {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.Errorf("failed to get $HOME directory: %w", err)
+	}
+	return filepath.Join(home, ".supabase", "tools"), nil
+}
+
+func (p Package) InstallDir() (string, error) {
+	toolsDir, err := GetToolsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(toolsDir, p.Name, p.Version), nil
+}
+
+func (p Package) BinPath(tool string) (string, error) {
+	if tool == "deno" && len(utils.DenoPathOverride) > 0 {
+		return utils.DenoPathOverride, nil
+	}
+	dir, err := p.InstallDir()
+	if err != nil {
+		return "", err
+	}
+	binPath := filepath.Join(dir, filepath.FromSlash(p.Bins[tool]))
+	if runtime.GOOS == "windows" {
+		binPath += ".exe"
+	}
+	return binPath, nil
+}
+
+// Find returns the path to an installed tool, or an error wrapping os.ErrNotExist if it is
+// not installed yet.
+func Find(tool string, fsys afero.Fs) (string, error) {
+	p, err := FindPackage(tool)
+	if err != nil {
+		return "", err
+	}
+	binPath, err := p.BinPath(tool)
+	if err != nil {
+		return "", err
+	}
+	if _, err := fsys.Stat(binPath); err != nil {
+		return "", errors.Errorf("failed to find %s: %w", tool, err)
+	}
+	return binPath, nil
+}
+
+// Ensure returns the path to a tool, installing its pinned version on first use.
+func Ensure(ctx context.Context, tool string, fsys afero.Fs) (string, error) {
+	binPath, err := Find(tool, fsys)
+	if !errors.Is(err, os.ErrNotExist) {
+		return binPath, err
+	}
+	p, err := FindPackage(tool)
+	if err != nil {
+		return "", err
+	}
+	if err := Install(ctx, p, fsys); err != nil {
+		return "", err
+	}
+	return p.BinPath(tool)
+}
+
+// EnsureDeno is like Ensure, but falls back to the unverified deno install at utils.GetDenoPath
+// when no checksum is embedded for the current platform.
+func EnsureDeno(ctx context.Context, fsys afero.Fs) (string, error) {
+	denoPath, err := Ensure(ctx, "deno", fsys)
+	if !errors.Is(err, ErrChecksumNotFound) {
+		return denoPath, err
+	}
+	fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "Installing deno without checksum verification:", err)
+	if err := utils.InstallOrUpgradeDeno(ctx, fsys); err != nil {
+		return "", errors.Errorf("failed to install deno: %w", err)
+	}
+	return utils.GetDenoPath()
+}
+
+// Install downloads and verifies the package archive for the current platform, then
+// extracts it to a temporary directory that is renamed into place once complete.
+func Install(ctx context.Context, p Package, fsys afero.Fs) error {
+	platform := getPlatform()
+	assetUrl, ok := p.Assets[platform]
+	if !ok {
+		utils.CmdSuggestion = p.Suggestion
+		return errors.Errorf("Platform %s is currently unsupported for %s.", platform, p.Name)
+	}
+	installDir, err := p.InstallDir()
+	if err != nil {
+		return err
+	}
+	if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(installDir)); err != nil {
+		return err
+	}
+	expected, err := lookupChecksum(assetUrl)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Downloading "+utils.Bold(p.Name)+" "+p.Version+"...")
+	archive, err := download(ctx, assetUrl)
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256(archive)
+	if actual := hex.EncodeToString(digest[:]); actual != expected {
+		return errors.Errorf("Checksum mismatch for %s: expected %s, got %s", assetUrl, expected, actual)
+	}
+	tmpDir := installDir + ".tmp"
+	if err := fsys.RemoveAll(tmpDir); err != nil {
+		return errors.Errorf("failed to remove temporary directory: %w", err)
+	}
+	if err := extractZip(archive, tmpDir, fsys); err != nil {
+		return err
+	}
+	for _, bin := range p.Bins {
+		if err := fsys.Chmod(filepath.Join(tmpDir, filepath.FromSlash(bin)), 0755); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return errors.Errorf("failed to make %s executable: %w", bin, err)
+		}
+	}
+	if err := fsys.RemoveAll(installDir); err != nil {
+		return errors.Errorf("failed to remove previous install: %w", err)
+	}
+	if err := fsys.Rename(tmpDir, installDir); err != nil {
+		return errors.Errorf("failed to move install directory: %w", err)
+	}
+	return nil
+}
+
+func lookupChecksum(assetUrl string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[1] == assetUrl {
+			return fields[0], nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", errors.Errorf("failed to read checksums: %w", err)
+	}
+	return "", errors.Errorf("%w for %s.", ErrChecksumNotFound, assetUrl)
+}
+
+func download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Errorf("failed to initialise download request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Errorf("failed to download tool: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to download tool: %s: %s", url, resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Errorf("failed to read tool: %w", err)
+	}
+	return body, nil
+}
+
+func extractZip(archive []byte, dstDir string, fsys afero.Fs) error {
+	r, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return errors.Errorf("failed to open archive: %w", err)
+	}
+	for _, f := range r.File {
+		// Guards against zip slip by rejecting entries outside of dstDir
+		name := filepath.Clean(filepath.FromSlash(f.Name))
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return errors.Errorf("Invalid file path in archive: %s", f.Name)
+		}
+		dstPath := filepath.Join(dstDir, name)
+		if f.FileInfo().IsDir() {
+			if err := fsys.MkdirAll(dstPath, 0755); err != nil {
+				return errors.Errorf("failed to mkdir: %w", err)
+			}
+			continue
+		}
+		if err := extractFile(f, dstPath, fsys); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractFile(f *zip.File, dstPath string, fsys afero.Fs) error {
+	if err := fsys.MkdirAll(filepath.Dir(dstPath), 0755); err != nil {
+		return errors.Errorf("failed to mkdir: %w", err)
+	}
+	src, err := f.Open()
+	if err != nil {
+		return errors.Errorf("failed to open archive file: %w", err)
+	}
+	defer src.Close()
+	perm := os.FileMode(0644)
+	if f.Mode()&0111 != 0 {
+		perm = 0755
+	}
+	dst, err := fsys.OpenFile(dstPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return errors.Errorf("failed to create file: %w", err)
+	}
+	defer dst.Close()
+	if _, err := io.Copy(dst, src); err != nil {
+		return errors.Errorf("failed to extract file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package tools
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const assetUrl = "https://example.com/fake.zip"
+
+var fakePackage = Package{
+	Name:    "fake",
+	Version: "1.0.0",
+	Bins:    map[string]string{"fake": "bin/fake"},
+	Assets:  map[string]string{getPlatform(): assetUrl},
+}
+
+func newArchive(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, contents := range files {
+		w, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(contents))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+	return buf.Bytes()
+}
+
+func setupChecksum(t *testing.T, archive []byte) {
+	original := checksums
+	checksums = []byte(fmt.Sprintf("%x  %s\n", sha256.Sum256(archive), assetUrl))
+	t.Cleanup(func() { checksums = original })
+}
+
+func TestInstall(t *testing.T) {
+	viper.Set("TOOLS_DIR", "/tools")
+	t.Cleanup(func() { viper.Set("TOOLS_DIR", "") })
+
+	t.Run("installs verified archive", func(t *testing.T) {
+		archive := newArchive(t, map[string]string{"bin/fake": "binary", "lib/libfake.so": "library"})
+		setupChecksum(t, archive)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock server
+		defer gock.OffAll()
+		gock.New("https://example.com").
+			Get("/fake.zip").
+			Reply(http.StatusOK).
+			Body(bytes.NewReader(archive))
+		// Run test
+		err := Install(context.Background(), fakePackage, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		contents, err := afero.ReadFile(fsys, "/tools/fake/1.0.0/bin/fake")
+		assert.NoError(t, err)
+		assert.Equal(t, "binary", string(contents))
+		info, err := fsys.Stat("/tools/fake/1.0.0/bin/fake")
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+		exists, err := afero.Exists(fsys, "/tools/fake/1.0.0.tmp")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("throws error on checksum mismatch", func(t *testing.T) {
+		setupChecksum(t, []byte("expected"))
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock server
+		defer gock.OffAll()
+		gock.New("https://example.com").
+			Get("/fake.zip").
+			Reply(http.StatusOK).
+			Body(bytes.NewReader(newArchive(t, map[string]string{"bin/fake": "tampered"})))
+		// Run test
+		err := Install(context.Background(), fakePackage, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Checksum mismatch for "+assetUrl)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		exists, err := afero.Exists(fsys, "/tools/fake/1.0.0")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("throws error on missing checksum", func(t *testing.T) {
+		original := checksums
+		checksums = nil
+		t.Cleanup(func() { checksums = original })
+		// Run test
+		err := Install(context.Background(), fakePackage, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Checksum not found for "+assetUrl)
+	})
+
+	t.Run("throws error on path outside install dir", func(t *testing.T) {
+		archive := newArchive(t, map[string]string{"../escape": "binary"})
+		setupChecksum(t, archive)
+		// Setup mock server
+		defer gock.OffAll()
+		gock.New("https://example.com").
+			Get("/fake.zip").
+			Reply(http.StatusOK).
+			Body(bytes.NewReader(archive))
+		// Run test
+		err := Install(context.Background(), fakePackage, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Invalid file path in archive: ../escape")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on unsupported platform", func(t *testing.T) {
+		unsupported := fakePackage
+		unsupported.Assets = nil
+		unsupported.Suggestion = "Install fake manually."
+		// Run test
+		err := Install(context.Background(), unsupported, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "is currently unsupported for fake.")
+		assert.Equal(t, "Install fake manually.", utils.CmdSuggestion)
+	})
+}
+
+func TestEnsure(t *testing.T) {
+	viper.Set("TOOLS_DIR", "/tools")
+	t.Cleanup(func() { viper.Set("TOOLS_DIR", "") })
+	original := Packages
+	Packages = []Package{fakePackage}
+	t.Cleanup(func() { Packages = original })
+
+	t.Run("returns installed tool", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/tools/fake/1.0.0/bin/fake", []byte("binary"), 0755))
+		// Run test
+		binPath, err := Ensure(context.Background(), "fake", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "/tools/fake/1.0.0/bin/fake", binPath)
+	})
+
+	t.Run("installs missing tool", func(t *testing.T) {
+		archive := newArchive(t, map[string]string{"bin/fake": "binary"})
+		setupChecksum(t, archive)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock server
+		defer gock.OffAll()
+		gock.New("https://example.com").
+			Get("/fake.zip").
+			Reply(http.StatusOK).
+			Body(bytes.NewReader(archive))
+		// Run test
+		binPath, err := Ensure(context.Background(), "fake", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "/tools/fake/1.0.0/bin/fake", binPath)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on unknown tool", func(t *testing.T) {
+		// Run test
+		_, err := Ensure(context.Background(), "pg_restore", afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Unknown tool: pg_restore. Must be one of: [fake]")
+	})
+}
+
+func TestEnsureDeno(t *testing.T) {
+	utils.DenoPathOverride = "/home/deno"
+	t.Cleanup(func() { utils.DenoPathOverride = "" })
+	original := checksums
+	checksums = nil
+	t.Cleanup(func() { checksums = original })
+
+	t.Run("falls back to unverified install on missing checksum", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock server
+		defer gock.OffAll()
+		gock.New("https://github.com").
+			Get("/releases/download/v" + utils.DenoVersion + "/").
+			Reply(http.StatusOK).
+			Body(bytes.NewReader(newArchive(t, map[string]string{"deno": "binary"})))
+		// Run test
+		denoPath, err := EnsureDeno(context.Background(), fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "/home/deno", denoPath)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		contents, err := afero.ReadFile(fsys, "/home/deno")
+		assert.NoError(t, err)
+		assert.Equal(t, "binary", string(contents))
+	})
+}
+
+func TestPackages(t *testing.T) {
+	assert.Equal(t, []string{"deno", "pg_dump", "psql"}, Names())
+	for _, p := range Packages {
+		for platform, url := range p.Assets {
+			assert.Regexp(t, `^(darwin|linux|windows)/(amd64|arm64)$`, platform)
+			assert.Regexp(t, `^https://.+\.zip$`, url)
+		}
+	}
+}
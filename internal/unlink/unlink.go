@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
@@ -26,12 +27,16 @@ func Run(ctx context.Context, fsys afero.Fs) error {
 }
 
 func Unlink(projectRef string, fsys afero.Fs) error {
+	release, err := utils.AcquireLock(utils.ProjectLock, fsys)
+	if err != nil {
+		return err
+	}
+	defer release()
 	fmt.Fprintln(os.Stderr, "Unlinking project:", projectRef)
 	var allErrors []error
-	// Remove temp directory
-	if err := fsys.RemoveAll(utils.TempDir); err != nil {
-		wrapped := errors.Errorf("failed to remove temp directory: %w", err)
-		allErrors = append(allErrors, wrapped)
+	// Remove temp directory, except for the lock file that other commands may have open
+	if err := removeTempDir(fsys); err != nil {
+		allErrors = append(allErrors, err)
 	}
 	// Remove linked credentials
 	if err := credentials.Delete(projectRef); err != nil &&
@@ -41,3 +46,21 @@ func Unlink(projectRef string, fsys afero.Fs) error {
 	}
 	return errors.Join(allErrors...)
 }
+
+func removeTempDir(fsys afero.Fs) error {
+	entries, err := afero.ReadDir(fsys, utils.TempDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return errors.Errorf("failed to read temp directory: %w", err)
+	}
+	for _, e := range entries {
+		if e.Name() == utils.ProjectLock+".lock" {
+			continue
+		}
+		if err := fsys.RemoveAll(filepath.Join(utils.TempDir, e.Name())); err != nil {
+			return errors.Errorf("failed to remove temp directory: %w", err)
+		}
+	}
+	return nil
+}
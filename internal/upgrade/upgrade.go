@@ -0,0 +1,258 @@
+package upgrade
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/google/go-github/v62/github"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"golang.org/x/mod/semver"
+)
+
+const (
+	ChannelStable = "stable"
+	ChannelBeta   = "beta"
+)
+
+var ChannelAllowed = []string{ChannelStable, ChannelBeta}
+
+// Package managers own the binary they install, so replacing it in place would break their upgrades.
+var packageManagers = []struct {
+	pattern string
+	command string
+}{
+	{"/Cellar/", "brew upgrade supabase"},
+	{"/node_modules/", "npm update supabase"},
+	{"/scoop/", "scoop update supabase"},
+}
+
+func Run(ctx context.Context, channel, exePath string, fsys afero.Fs) error {
+	RemoveOldBinary(exePath, fsys)
+	for _, pm := range packageManagers {
+		if strings.Contains(filepath.ToSlash(exePath), pm.pattern) {
+			utils.CmdSuggestion = fmt.Sprintf("Run %s instead.", utils.Aqua(pm.command))
+			return errors.Errorf("Cannot upgrade Supabase CLI installed by a package manager: %s", exePath)
+		}
+	}
+	release, err := GetRelease(ctx, channel)
+	if err != nil {
+		return err
+	}
+	version := release.GetTagName()
+	if semver.Compare(version, "v"+utils.Version) <= 0 {
+		fmt.Printf("Supabase CLI v%s is already the latest %s release.\n", utils.Version, channel)
+		return nil
+	}
+	archiveName := fmt.Sprintf("supabase_%s_%s.tar.gz", runtime.GOOS, runtime.GOARCH)
+	archiveUrl, checksumUrl := findAssets(release, archiveName)
+	if len(archiveUrl) == 0 {
+		return errors.Errorf("Release %s has no binary for %s/%s.", version, runtime.GOOS, runtime.GOARCH)
+	}
+	if len(checksumUrl) == 0 {
+		return errors.Errorf("Release %s has no checksums file.", version)
+	}
+	fmt.Fprintln(utils.GetStatusWriter(), "Downloading "+utils.Bold(archiveName)+" from release "+utils.Aqua(version)+"...")
+	checksums, err := download(ctx, checksumUrl)
+	if err != nil {
+		return err
+	}
+	archive, err := download(ctx, archiveUrl)
+	if err != nil {
+		return err
+	}
+	// Releases are not signed yet, so checksums are the only integrity guarantee we can offer.
+	if err := verifyChecksum(archive, archiveName, checksums); err != nil {
+		return err
+	}
+	binaryName := "supabase"
+	if runtime.GOOS == "windows" {
+		binaryName += ".exe"
+	}
+	binary, err := extractBinary(archive, binaryName)
+	if err != nil {
+		return err
+	}
+	if err := replaceExecutable(exePath, binary, fsys); err != nil {
+		return err
+	}
+	fmt.Println("Upgraded Supabase CLI to " + utils.Aqua(version) + ".")
+	return nil
+}
+
+// GetRelease returns the latest stable release, or the latest release including pre-releases for beta.
+func GetRelease(ctx context.Context, channel string) (*github.RepositoryRelease, error) {
+	client := utils.GetGtihubClient(ctx)
+	if channel != ChannelBeta {
+		release, _, err := client.Repositories.GetLatestRelease(ctx, utils.CLI_OWNER, utils.CLI_REPO)
+		if err != nil {
+			return nil, errors.Errorf("failed to fetch latest release: %w", err)
+		}
+		return release, nil
+	}
+	releases, _, err := client.Repositories.ListReleases(ctx, utils.CLI_OWNER, utils.CLI_REPO, &github.ListOptions{PerPage: 10})
+	if err != nil {
+		return nil, errors.Errorf("failed to list releases: %w", err)
+	}
+	for _, r := range releases {
+		if !r.GetDraft() {
+			return r, nil
+		}
+	}
+	return nil, errors.New("No beta release found.")
+}
+
+func findAssets(release *github.RepositoryRelease, archiveName string) (string, string) {
+	var archiveUrl, checksumUrl string
+	for _, asset := range release.Assets {
+		name := asset.GetName()
+		if name == archiveName {
+			archiveUrl = asset.GetBrowserDownloadURL()
+		} else if strings.HasSuffix(name, "_checksums.txt") {
+			checksumUrl = asset.GetBrowserDownloadURL()
+		}
+	}
+	return archiveUrl, checksumUrl
+}
+
+func download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Errorf("failed to initialise download request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Errorf("failed to download release asset: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to download release asset: %s: %s", url, resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Errorf("failed to read release asset: %w", err)
+	}
+	return body, nil
+}
+
+// Checksums file follows the sha256sum format, ie. `<hex digest>  <filename>` per line.
+func verifyChecksum(archive []byte, archiveName string, checksums []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || fields[1] != archiveName {
+			continue
+		}
+		digest := sha256.Sum256(archive)
+		if actual := hex.EncodeToString(digest[:]); actual != fields[0] {
+			return errors.Errorf("Checksum mismatch for %s: expected %s, got %s", archiveName, fields[0], actual)
+		}
+		return nil
+	}
+	if err := scanner.Err(); err != nil {
+		return errors.Errorf("failed to read checksums: %w", err)
+	}
+	return errors.Errorf("Checksum not found for %s.", archiveName)
+}
+
+func extractBinary(archive []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, errors.Errorf("failed to decompress archive: %w", err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, errors.Errorf("failed to read archive: %w", err)
+		}
+		if header.Typeflag == tar.TypeReg && filepath.Base(header.Name) == name {
+			binary, err := io.ReadAll(tr)
+			if err != nil {
+				return nil, errors.Errorf("failed to extract binary: %w", err)
+			}
+			return binary, nil
+		}
+	}
+	return nil, errors.Errorf("Binary %s not found in archive.", name)
+}
+
+// Writes the verified binary next to the current one and renames it into place in a
+// single step, so the executable is never left half written. A running executable on
+// Windows cannot be replaced, but it can be renamed out of the way first.
+func replaceExecutable(exePath string, binary []byte, fsys afero.Fs) error {
+	f, err := afero.TempFile(fsys, filepath.Dir(exePath), filepath.Base(exePath)+".*.new")
+	if err != nil {
+		return errors.Errorf("failed to create new binary: %w", err)
+	}
+	tmpPath := f.Name()
+	cleanup := func() {
+		if err := fsys.Remove(tmpPath); err != nil {
+			fmt.Fprintln(utils.GetDebugLogger(), err)
+		}
+	}
+	if _, err := f.Write(binary); err != nil {
+		f.Close()
+		cleanup()
+		return errors.Errorf("failed to write new binary: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		cleanup()
+		return errors.Errorf("failed to close new binary: %w", err)
+	}
+	if err := fsys.Chmod(tmpPath, 0755); err != nil {
+		cleanup()
+		return errors.Errorf("failed to set binary permissions: %w", err)
+	}
+	if runtime.GOOS != "windows" {
+		if err := fsys.Rename(tmpPath, exePath); err != nil {
+			cleanup()
+			return errors.Errorf("failed to replace binary: %w", err)
+		}
+		return nil
+	}
+	oldPath := getOldPath(exePath)
+	if err := fsys.Rename(exePath, oldPath); err != nil {
+		cleanup()
+		return errors.Errorf("failed to move current binary: %w", err)
+	}
+	if err := fsys.Rename(tmpPath, exePath); err != nil {
+		// Restore the previous binary so the CLI remains usable
+		if rbErr := fsys.Rename(oldPath, exePath); rbErr != nil {
+			fmt.Fprintln(os.Stderr, "Failed to restore previous binary:", rbErr)
+		}
+		cleanup()
+		return errors.Errorf("failed to replace binary: %w", err)
+	}
+	// Usually fails on Windows because the old binary is still running, so it is removed on next run
+	RemoveOldBinary(exePath, fsys)
+	return nil
+}
+
+func getOldPath(exePath string) string {
+	return exePath + ".old"
+}
+
+// RemoveOldBinary deletes the binary left behind by a previous upgrade on Windows, which
+// cannot be removed while it is still running.
+func RemoveOldBinary(exePath string, fsys afero.Fs) {
+	if err := fsys.Remove(getOldPath(exePath)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintln(utils.GetDebugLogger(), err)
+	}
+}
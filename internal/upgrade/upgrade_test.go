@@ -0,0 +1,215 @@
+package upgrade
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"runtime"
+	"testing"
+
+	"github.com/google/go-github/v62/github"
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const (
+	exePath     = "/usr/local/bin/supabase"
+	downloadUrl = "https://github.com/supabase/cli/releases/download/v2.0.0"
+)
+
+func newArchive(t *testing.T, name string, contents []byte) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0755,
+		Size:     int64(len(contents)),
+		Typeflag: tar.TypeReg,
+	}))
+	_, err := tw.Write(contents)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func newRelease(archiveName string) github.RepositoryRelease {
+	return github.RepositoryRelease{
+		TagName: utils.Ptr("v2.0.0"),
+		Assets: []*github.ReleaseAsset{{
+			Name:               utils.Ptr(archiveName),
+			BrowserDownloadURL: utils.Ptr(downloadUrl + "/" + archiveName),
+		}, {
+			Name:               utils.Ptr("supabase_2.0.0_checksums.txt"),
+			BrowserDownloadURL: utils.Ptr(downloadUrl + "/supabase_2.0.0_checksums.txt"),
+		}},
+	}
+}
+
+func TestUpgradeCommand(t *testing.T) {
+	utils.Version = "1.0.0"
+	archiveName := fmt.Sprintf("supabase_%s_%s.tar.gz", runtime.GOOS, runtime.GOARCH)
+	binaryName := "supabase"
+	if runtime.GOOS == "windows" {
+		binaryName += ".exe"
+	}
+
+	t.Run("replaces executable with latest release", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, exePath, []byte("old"), 0755))
+		// Setup mock api
+		archive := newArchive(t, binaryName, []byte("new"))
+		digest := sha256.Sum256(archive)
+		defer gock.OffAll()
+		gock.New("https://api.github.com").
+			Get("/repos/supabase/cli/releases/latest").
+			Reply(http.StatusOK).
+			JSON(newRelease(archiveName))
+		gock.New(downloadUrl).
+			Get("/supabase_2.0.0_checksums.txt").
+			Reply(http.StatusOK).
+			BodyString(hex.EncodeToString(digest[:]) + "  " + archiveName + "\n")
+		gock.New(downloadUrl).
+			Get("/" + archiveName).
+			Reply(http.StatusOK).
+			Body(bytes.NewReader(archive))
+		// Run test
+		err := Run(context.Background(), ChannelStable, exePath, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		contents, err := afero.ReadFile(fsys, exePath)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("new"), contents)
+		exists, err := afero.Exists(fsys, exePath+".old")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+		tmpFiles, err := afero.Glob(fsys, exePath+".*.new")
+		assert.NoError(t, err)
+		assert.Empty(t, tmpFiles)
+	})
+
+	t.Run("throws error on checksum mismatch", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, exePath, []byte("old"), 0755))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("https://api.github.com").
+			Get("/repos/supabase/cli/releases/latest").
+			Reply(http.StatusOK).
+			JSON(newRelease(archiveName))
+		gock.New(downloadUrl).
+			Get("/supabase_2.0.0_checksums.txt").
+			Reply(http.StatusOK).
+			BodyString("0000  " + archiveName + "\n")
+		gock.New(downloadUrl).
+			Get("/" + archiveName).
+			Reply(http.StatusOK).
+			Body(bytes.NewReader(newArchive(t, binaryName, []byte("tampered"))))
+		// Run test
+		err := Run(context.Background(), ChannelStable, exePath, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Checksum mismatch for "+archiveName)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		contents, err := afero.ReadFile(fsys, exePath)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("old"), contents)
+	})
+
+	t.Run("skips upgrade when already latest", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, exePath+".old", []byte("old"), 0755))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("https://api.github.com").
+			Get("/repos/supabase/cli/releases/latest").
+			Reply(http.StatusOK).
+			JSON(github.RepositoryRelease{TagName: utils.Ptr("v1.0.0")})
+		// Run test
+		err := Run(context.Background(), ChannelStable, exePath, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		exists, err := afero.Exists(fsys, exePath+".old")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("throws error on package manager install", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), ChannelStable, "/opt/homebrew/Cellar/supabase/1.0.0/bin/supabase", afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Cannot upgrade Supabase CLI installed by a package manager")
+		assert.Contains(t, utils.CmdSuggestion, "brew upgrade supabase")
+	})
+}
+
+func TestRemoveOldBinary(t *testing.T) {
+	t.Run("removes binary left by previous upgrade", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, exePath, []byte("new"), 0755))
+		require.NoError(t, afero.WriteFile(fsys, exePath+".old", []byte("old"), 0755))
+		// Run test
+		RemoveOldBinary(exePath, fsys)
+		// Check error
+		exists, err := afero.Exists(fsys, exePath+".old")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+		contents, err := afero.ReadFile(fsys, exePath)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("new"), contents)
+	})
+
+	t.Run("ignores missing binary", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		RemoveOldBinary(exePath, fsys)
+		// Check error
+		exists, err := afero.Exists(fsys, exePath+".old")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestGetRelease(t *testing.T) {
+	t.Run("selects latest pre-release for beta", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("https://api.github.com").
+			Get("/repos/supabase/cli/releases").
+			Reply(http.StatusOK).
+			JSON([]github.RepositoryRelease{
+				{TagName: utils.Ptr("v2.1.0-beta.2"), Draft: utils.Ptr(true)},
+				{TagName: utils.Ptr("v2.1.0-beta.1"), Prerelease: utils.Ptr(true)},
+				{TagName: utils.Ptr("v2.0.0")},
+			})
+		// Run test
+		release, err := GetRelease(context.Background(), ChannelBeta)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "v2.1.0-beta.1", release.GetTagName())
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	t.Run("throws error on missing entry", func(t *testing.T) {
+		err := verifyChecksum([]byte("data"), "supabase_linux_amd64.tar.gz", []byte("abcd  supabase_darwin_arm64.tar.gz\n"))
+		assert.ErrorContains(t, err, "Checksum not found for supabase_linux_amd64.tar.gz.")
+	})
+}
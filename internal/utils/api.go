@@ -175,11 +175,18 @@ func GetSupabase() *supabase.ClientWithResponses {
 		if err != nil {
 			log.Fatalln(err)
 		}
-		if t, ok := http.DefaultTransport.(*http.Transport); ok {
+		if t, ok := unwrapTransport(http.DefaultTransport).(*http.Transport); ok {
 			t.DialContext = withFallbackDNS(t.DialContext)
+			if err := configureTransport(t); err != nil {
+				log.Fatalln(err)
+			}
 		}
 		apiClient, err = supabase.NewClientWithResponses(
 			GetSupabaseAPIHost(),
+			supabase.WithHTTPClient(&http.Client{
+				Timeout:   GetApiTimeout(),
+				Transport: apiTransport,
+			}),
 			supabase.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
 				req.Header.Set("Authorization", "Bearer "+token)
 				req.Header.Set("User-Agent", "SupabaseCLI/"+Version)
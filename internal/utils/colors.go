@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"os"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -22,3 +24,8 @@ func Red(str string) string {
 func Bold(str string) string {
 	return lipgloss.NewStyle().Bold(true).Render(str)
 }
+
+// Lipgloss styles already honour NO_COLOR, but other renderers need to check it explicitly.
+func NoColor() bool {
+	return len(os.Getenv("NO_COLOR")) > 0
+}
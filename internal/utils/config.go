@@ -2,12 +2,15 @@ package utils
 
 import (
 	"bytes"
+	"crypto/sha256"
 	_ "embed"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"text/template"
 	"time"
@@ -67,12 +70,23 @@ var (
 	initConfigTemplate = template.Must(template.New("initConfig").Parse(initConfigEmbed))
 	invalidProjectId   = regexp.MustCompile("[^a-zA-Z0-9_.-]+")
 	envPattern         = regexp.MustCompile(`^env\((.*)\)$`)
+	// Kong supports a wildcard as the leftmost label of route hosts
+	functionsHostPattern = regexp.MustCompile(`^(\*\.)?[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?)*$`)
+	// Postgres parameter names, including those qualified by an extension, ie. cron.database_name
+	parameterPattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*(\.[a-zA-Z_][a-zA-Z0-9_]*)?$`)
+	libraryPattern   = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
 )
 
 func GetId(name string) string {
 	return "supabase_" + name + "_" + Config.ProjectId
 }
 
+// Derives a project id that is unique to the given workspace directory.
+func GetWorkspaceProjectId(projectId, workdir string) string {
+	digest := sha256.Sum256([]byte(workdir))
+	return fmt.Sprintf("%s_%x", projectId, digest[:4])
+}
+
 func UpdateDockerIds() {
 	if NetId = viper.GetString("network-id"); len(NetId) == 0 {
 		NetId = GetId("network")
@@ -95,6 +109,43 @@ func UpdateDockerIds() {
 	PoolerId = GetId(PoolerAliases[0])
 }
 
+// Returns all configurable host ports keyed by their config path.
+func GetHostPorts() map[string]*uint16 {
+	return map[string]*uint16{
+		"api.port":                    &Config.Api.Port,
+		"db.port":                     &Config.Db.Port,
+		"db.shadow_port":              &Config.Db.ShadowPort,
+		"db.pooler.port":              &Config.Db.Pooler.Port,
+		"studio.port":                 &Config.Studio.Port,
+		"inbucket.port":               &Config.Inbucket.Port,
+		"inbucket.smtp_port":          &Config.Inbucket.SmtpPort,
+		"inbucket.pop3_port":          &Config.Inbucket.Pop3Port,
+		"edge_runtime.inspector_port": &Config.EdgeRuntime.InspectorPort,
+		"analytics.port":              &Config.Analytics.Port,
+		"analytics.vector_port":       &Config.Analytics.VectorPort,
+	}
+}
+
+// Host port selected by supabase start --auto-ports in place of a configured port that was in use.
+type ReassignedPort struct {
+	Configured uint16 `json:"configured"`
+	Selected   uint16 `json:"selected"`
+}
+
+func LoadReassignedPorts(fsys afero.Fs) (map[string]ReassignedPort, error) {
+	contents, err := afero.ReadFile(fsys, PortsPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Errorf("failed to read %s: %w", PortsPath, err)
+	}
+	var ports map[string]ReassignedPort
+	if err := json.Unmarshal(contents, &ports); err != nil {
+		return nil, errors.Errorf("failed to parse %s: %w", PortsPath, err)
+	}
+	return ports, nil
+}
+
 func GetDockerIds() []string {
 	return []string{
 		KongId,
@@ -194,6 +245,9 @@ var Config = config{
 		Image:    Pg15Image,
 		Password: "postgres",
 		RootKey:  "d4dc5b6d4a1d6a10b2c1e76112c994d65db7cec380572cc1839624d4be3fa275",
+		Tools: tools{
+			Image: Pg15Image,
+		},
 		Pooler: pooler{
 			Image:         SupavisorImage,
 			TenantId:      "pooler-dev",
@@ -208,6 +262,8 @@ var Config = config{
 		TenantId:        "realtime-dev",
 		EncryptionKey:   "supabaserealtime",
 		SecretKeyBase:   "EAx3IQ/wRG1v47ZD4NE4/9RzBI8Jmil3x0yhcW4V2NHBP6c2iPIzwjofi2Ep4HIG",
+
+		MaxConcurrentUsers: 200,
 	},
 	Storage: storage{
 		Image: StorageImage,
@@ -257,6 +313,9 @@ var Config = config{
 		Image:       StudioImage,
 		PgmetaImage: PgmetaImage,
 	},
+	EdgeRuntime: edgeRuntime{
+		Image: EdgeRuntimeImage,
+	},
 	Analytics: analytics{
 		ApiKey: "api-key",
 		// Defaults to bigquery for backwards compatibility with existing config.toml
@@ -287,40 +346,82 @@ var Config = config{
 // Default values for internal configs should be added to `var Config` initializer.
 type (
 	config struct {
-		ProjectId    string              `toml:"project_id"`
-		Hostname     string              `toml:"-"`
-		Api          api                 `toml:"api"`
-		Db           db                  `toml:"db" mapstructure:"db"`
-		Realtime     realtime            `toml:"realtime"`
-		Studio       studio              `toml:"studio"`
-		Inbucket     inbucket            `toml:"inbucket"`
-		Storage      storage             `toml:"storage"`
-		Auth         auth                `toml:"auth" mapstructure:"auth"`
-		EdgeRuntime  edgeRuntime         `toml:"edge_runtime"`
-		Functions    map[string]function `toml:"functions"`
-		Analytics    analytics           `toml:"analytics"`
-		Experimental experimental        `toml:"experimental" mapstructure:"-"`
+		ProjectId    string                `toml:"project_id"`
+		Hostname     string                `toml:"-"`
+		Api          api                   `toml:"api"`
+		Db           db                    `toml:"db" mapstructure:"db"`
+		Seed         seed                  `toml:"seed"`
+		Realtime     realtime              `toml:"realtime"`
+		Studio       studio                `toml:"studio"`
+		Inbucket     inbucket              `toml:"inbucket"`
+		Storage      storage               `toml:"storage"`
+		Auth         auth                  `toml:"auth" mapstructure:"auth"`
+		EdgeRuntime  edgeRuntime           `toml:"edge_runtime"`
+		Functions    map[string]function   `toml:"functions"`
+		Analytics    analytics             `toml:"analytics"`
+		Docker       docker                `toml:"docker"`
+		Hooks        map[string]NotifyHook `toml:"hooks"`
+		Remotes      map[string]string     `toml:"remotes"`
+		Experimental experimental          `toml:"experimental" mapstructure:"-"`
+		// Deprecated: merged into safety.protected_refs
+		ProductionRefs []string `toml:"production_refs"`
+		Safety         safety   `toml:"safety"`
+		// Hooks shared by all functions
+		FunctionHooks FunctionHooks `toml:"function_hooks"`
 		// TODO
 		// Scripts   scripts
 	}
 
 	api struct {
-		Enabled         bool     `toml:"enabled"`
-		Image           string   `toml:"-"`
-		Port            uint16   `toml:"port"`
-		Schemas         []string `toml:"schemas"`
-		ExtraSearchPath []string `toml:"extra_search_path"`
-		MaxRows         uint     `toml:"max_rows"`
+		Enabled         bool      `toml:"enabled"`
+		Image           string    `toml:"-"`
+		Version         string    `toml:"version"`
+		Port            uint16    `toml:"port"`
+		Schemas         []string  `toml:"schemas"`
+		ExtraSearchPath []string  `toml:"extra_search_path"`
+		MaxRows         uint      `toml:"max_rows"`
+		Resources       resources `toml:"resources"`
 	}
 
 	db struct {
-		Image        string `toml:"-"`
-		Port         uint16 `toml:"port"`
-		ShadowPort   uint16 `toml:"shadow_port"`
-		MajorVersion uint   `toml:"major_version"`
-		Password     string `toml:"-"`
-		RootKey      string `toml:"-" mapstructure:"root_key"`
-		Pooler       pooler `toml:"pooler"`
+		Image        string    `toml:"-"`
+		Port         uint16    `toml:"port"`
+		ShadowPort   uint16    `toml:"shadow_port"`
+		MajorVersion uint      `toml:"major_version"`
+		Password     string    `toml:"-"`
+		RootKey      string    `toml:"-" mapstructure:"root_key"`
+		Pooler       pooler    `toml:"pooler"`
+		Resources    resources `toml:"resources"`
+		Capture      capture   `toml:"capture"`
+		Tools        tools     `toml:"tools"`
+		Settings     settings  `toml:"settings"`
+	}
+
+	settings struct {
+		SharedPreloadLibraries []string          `toml:"shared_preload_libraries"`
+		Parameters             map[string]string `toml:"parameters"`
+		InitScripts            []string          `toml:"init_scripts"`
+	}
+
+	tools struct {
+		Image   string `toml:"-"`
+		Version string `toml:"version"`
+		BinDir  string `toml:"bin_dir"`
+		Managed bool   `toml:"managed"`
+	}
+
+	capture struct {
+		Enabled bool `toml:"enabled"`
+	}
+
+	seed struct {
+		ResetSequences bool        `toml:"reset_sequences"`
+		Tables         []SeedTable `toml:"tables"`
+	}
+
+	SeedTable struct {
+		Table string `toml:"table"`
+		File  string `toml:"file"`
 	}
 
 	pooler struct {
@@ -339,20 +440,27 @@ type (
 	realtime struct {
 		Enabled         bool          `toml:"enabled"`
 		Image           string        `toml:"-"`
+		Version         string        `toml:"version"`
 		IpVersion       AddressFamily `toml:"ip_version"`
 		MaxHeaderLength uint          `toml:"max_header_length"`
+		Resources       resources     `toml:"resources"`
 		TenantId        string        `toml:"-"`
 		EncryptionKey   string        `toml:"-"`
 		SecretKeyBase   string        `toml:"-"`
+
+		// Settings below are only applied to the linked project by realtime config push
+		MaxConcurrentUsers uint `toml:"max_concurrent_users"`
+		PrivateOnly        bool `toml:"private_only"`
 	}
 
 	studio struct {
-		Enabled      bool   `toml:"enabled"`
-		Image        string `toml:"-"`
-		Port         uint16 `toml:"port"`
-		ApiUrl       string `toml:"api_url"`
-		OpenaiApiKey string `toml:"openai_api_key"`
-		PgmetaImage  string `toml:"-"`
+		Enabled      bool      `toml:"enabled"`
+		Image        string    `toml:"-"`
+		Port         uint16    `toml:"port"`
+		ApiUrl       string    `toml:"api_url"`
+		OpenaiApiKey string    `toml:"openai_api_key"`
+		PgmetaImage  string    `toml:"-"`
+		Resources    resources `toml:"resources"`
 	}
 
 	inbucket struct {
@@ -365,9 +473,11 @@ type (
 	storage struct {
 		Enabled             bool                 `toml:"enabled"`
 		Image               string               `toml:"-"`
+		Version             string               `toml:"version"`
 		FileSizeLimit       sizeInBytes          `toml:"file_size_limit"`
 		S3Credentials       storageS3Credentials `toml:"-"`
 		ImageTransformation imageTransformation  `toml:"image_transformation"`
+		Resources           resources            `toml:"resources"`
 	}
 
 	imageTransformation struct {
@@ -381,10 +491,12 @@ type (
 	}
 
 	auth struct {
-		Enabled                bool     `toml:"enabled"`
-		Image                  string   `toml:"-"`
-		SiteUrl                string   `toml:"site_url"`
-		AdditionalRedirectUrls []string `toml:"additional_redirect_urls"`
+		Enabled                bool      `toml:"enabled"`
+		Image                  string    `toml:"-"`
+		Version                string    `toml:"version"`
+		SiteUrl                string    `toml:"site_url"`
+		AdditionalRedirectUrls []string  `toml:"additional_redirect_urls"`
+		Resources              resources `toml:"resources"`
 
 		JwtExpiry                  uint `toml:"jwt_expiry"`
 		EnableRefreshTokenRotation bool `toml:"enable_refresh_token_rotation"`
@@ -397,6 +509,7 @@ type (
 		Email                  email `toml:"email"`
 		Sms                    sms   `toml:"sms"`
 		External               map[string]provider
+		ThirdParty             thirdParty `toml:"third_party"`
 
 		// Custom secrets can be injected from .env file
 		JwtSecret      string `toml:"-" mapstructure:"jwt_secret"`
@@ -479,15 +592,57 @@ type (
 		SkipNonceCheck bool   `toml:"skip_nonce_check"`
 	}
 
+	thirdParty struct {
+		Firebase tpaFirebase `toml:"firebase"`
+		Auth0    tpaAuth0    `toml:"auth0"`
+		Cognito  tpaCognito  `toml:"aws_cognito"`
+	}
+
+	tpaFirebase struct {
+		Enabled   bool   `toml:"enabled"`
+		ProjectID string `toml:"project_id"`
+	}
+
+	tpaAuth0 struct {
+		Enabled      bool   `toml:"enabled"`
+		Tenant       string `toml:"tenant"`
+		TenantRegion string `toml:"tenant_region"`
+	}
+
+	tpaCognito struct {
+		Enabled        bool   `toml:"enabled"`
+		UserPoolID     string `toml:"user_pool_id"`
+		UserPoolRegion string `toml:"user_pool_region"`
+	}
+
 	edgeRuntime struct {
 		Enabled       bool          `toml:"enabled"`
+		Image         string        `toml:"-"`
+		Version       string        `toml:"version"`
 		Policy        RequestPolicy `toml:"policy"`
 		InspectorPort uint16        `toml:"inspector_port"`
+		Resources     resources     `toml:"resources"`
+		// Routes requests under these paths to functions, in addition to /functions/v1/
+		PathPrefixes []string `toml:"path_prefixes"`
+		// Routes all requests to these hosts, optionally with a leading wildcard, to functions
+		Hosts []string `toml:"hosts"`
 	}
 
 	function struct {
 		VerifyJWT *bool  `toml:"verify_jwt" json:"verifyJWT"`
 		ImportMap string `toml:"import_map" json:"importMapPath,omitempty"`
+		// Names of the secrets read by the function, in place of those detected from source
+		Secrets []string `toml:"secrets" json:"-"`
+		// Overrides the hooks declared under [function_hooks]
+		FunctionHooks
+	}
+
+	// Shell commands run by `functions deploy` around each stage of deploying a function
+	FunctionHooks struct {
+		PreBundle  string `toml:"pre_bundle" json:"-"`
+		PostBundle string `toml:"post_bundle" json:"-"`
+		PreDeploy  string `toml:"pre_deploy" json:"-"`
+		PostDeploy string `toml:"post_deploy" json:"-"`
 	}
 
 	analytics struct {
@@ -501,6 +656,34 @@ type (
 		ApiKey           string          `toml:"-" mapstructure:"api_key"`
 	}
 
+	resources struct {
+		Memory sizeInBytes `toml:"memory"`
+		Cpus   float64     `toml:"cpus"`
+	}
+
+	docker struct {
+		Registry string       `toml:"registry"`
+		Images   dockerImages `toml:"images"`
+	}
+
+	dockerImages struct {
+		Postgres      string `toml:"postgres"`
+		Gotrue        string `toml:"gotrue"`
+		Realtime      string `toml:"realtime"`
+		Storage       string `toml:"storage"`
+		EdgeRuntime   string `toml:"edge_runtime"`
+		PostgresTools string `toml:"postgres_tools"`
+	}
+
+	NotifyHook struct {
+		Url string   `toml:"url"`
+		On  []string `toml:"on"`
+	}
+
+	safety struct {
+		ProtectedRefs []string `toml:"protected_refs"`
+	}
+
 	experimental struct {
 		OrioleDBVersion string `toml:"orioledb_version"`
 		S3Host          string `toml:"s3_host"`
@@ -516,6 +699,13 @@ type (
 	// }
 )
 
+const (
+	HookFunctionsDeploy = "functions.deploy"
+	HookDbPush          = "db.push"
+)
+
+var HookEvents = []string{HookFunctionsDeploy, HookDbPush}
+
 func (h *hookConfig) HandleHook(hookType string) error {
 	// If not enabled do nothing
 	if !h.Enabled {
@@ -534,6 +724,55 @@ func (h *hookConfig) HandleHook(hookType string) error {
 	return nil
 }
 
+func (s *settings) validate(majorVersion uint) error {
+	for _, lib := range s.SharedPreloadLibraries {
+		if !libraryPattern.MatchString(lib) {
+			return errors.Errorf("Invalid config for db.settings.shared_preload_libraries: %s", lib)
+		}
+	}
+	if len(s.SharedPreloadLibraries) > 0 && majorVersion < 15 {
+		return errors.New("Invalid config for db.settings.shared_preload_libraries: requires db.major_version 15 or above.")
+	}
+	for name := range s.Parameters {
+		if !parameterPattern.MatchString(name) {
+			return errors.Errorf("Invalid config for db.settings.parameters: %s", name)
+		}
+		if strings.EqualFold(name, "shared_preload_libraries") {
+			return errors.New("Invalid config for db.settings.parameters: use db.settings.shared_preload_libraries to preload libraries.")
+		}
+	}
+	// Resolve init scripts relative to supabase directory
+	for i, path := range s.InitScripts {
+		if !filepath.IsAbs(path) {
+			s.InitScripts[i] = filepath.Join(SupabaseDirPath, path)
+		}
+	}
+	return nil
+}
+
+// Assignments returns custom parameters as name=value pairs, sorted by name.
+func (s settings) Assignments() []string {
+	names := make([]string, 0, len(s.Parameters))
+	for name := range s.Parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := make([]string, len(names))
+	for i, name := range names {
+		result[i] = name + "=" + s.Parameters[name]
+	}
+	return result
+}
+
+// ParameterArgs returns postgres command line flags for custom parameters.
+func (s settings) ParameterArgs() []string {
+	var args []string
+	for _, assignment := range s.Assignments() {
+		args = append(args, "-c", assignment)
+	}
+	return args
+}
+
 func LoadConfigFS(fsys afero.Fs) error {
 	// Load default values
 	var buf bytes.Buffer
@@ -551,9 +790,29 @@ func LoadConfigFS(fsys afero.Fs) error {
 		if osErr != nil {
 			cwd = "current directory"
 		}
-		return errors.Errorf("cannot read config in %s: %w", Bold(cwd), err)
+		return WithCode(CodeConfigNotFound, errors.Errorf("cannot read config in %s: %w", Bold(cwd), err))
 	} else if undecoded := metadata.Undecoded(); len(undecoded) > 0 {
-		fmt.Fprintf(os.Stderr, "Unknown config fields: %+v\n", undecoded)
+		keys := make([]string, len(undecoded))
+		for i, k := range undecoded {
+			keys[i] = k.String()
+		}
+		var deprecated []string
+		if data, err := afero.ReadFile(fsys, ConfigPath); err == nil {
+			deprecated = findDeprecatedFields(data, keys)
+		}
+		var unknown []string
+		for _, k := range keys {
+			if !SliceContains(deprecated, k) {
+				unknown = append(unknown, k)
+			}
+		}
+		if len(deprecated) > 0 {
+			fmt.Fprintf(os.Stderr, "Deprecated config fields: %+v\n", deprecated)
+			fmt.Fprintf(os.Stderr, "Run %s to update %s to the current schema.\n", Aqua("supabase config migrate"), Bold(ConfigPath))
+		}
+		if len(unknown) > 0 {
+			fmt.Fprintf(os.Stderr, "Unknown config fields: %+v\n", unknown)
+		}
 	}
 	// Load secrets from .env file
 	if err := loadDefaultEnv(); err != nil {
@@ -586,8 +845,24 @@ func LoadConfigFS(fsys afero.Fs) error {
 		if Config.ProjectId == "" {
 			return errors.New("Missing required field in config: project_id")
 		}
+		// Isolates containers from other workspaces sharing the same project id
+		if workspaceId, err := afero.ReadFile(fsys, WorkspaceIdPath); err == nil && len(workspaceId) > 0 {
+			Config.ProjectId = strings.TrimSpace(string(workspaceId))
+		}
 		Config.Hostname = GetHostname()
 		UpdateDockerIds()
+		// Apply host ports selected by supabase start --auto-ports, unless the
+		// configured port has since been changed in config.toml
+		ports, err := LoadReassignedPorts(fsys)
+		if err != nil {
+			return err
+		}
+		hostPorts := GetHostPorts()
+		for name, port := range ports {
+			if p, ok := hostPorts[name]; ok && *p == port.Configured {
+				*p = port.Selected
+			}
+		}
 		// Validate api config
 		if Config.Api.Port == 0 {
 			return errors.New("Missing required field in config: api.port")
@@ -652,7 +927,26 @@ func LoadConfigFS(fsys afero.Fs) error {
 		if connString, err := afero.ReadFile(fsys, PoolerUrlPath); err == nil && len(connString) > 0 {
 			Config.Db.Pooler.ConnectionString = string(connString)
 		}
+		// Validate tools config
+		if len(Config.Db.Tools.BinDir) > 0 {
+			var err error
+			if Config.Db.Tools.BinDir, err = maybeLoadEnv(Config.Db.Tools.BinDir); err != nil {
+				return err
+			}
+			if len(Config.Db.Tools.Version) > 0 {
+				return errors.New("Invalid config for db.tools: bin_dir and version cannot both be set.")
+			}
+		}
+		if Config.Db.Tools.Managed && (len(Config.Db.Tools.BinDir) > 0 || len(Config.Db.Tools.Version) > 0) {
+			return errors.New("Invalid config for db.tools: managed cannot be set with bin_dir or version.")
+		}
+		if err := Config.Db.Settings.validate(Config.Db.MajorVersion); err != nil {
+			return err
+		}
 		// Validate realtime config
+		if Config.Realtime.MaxConcurrentUsers == 0 {
+			return errors.New("Invalid config for realtime.max_concurrent_users. Must be greater than 0.")
+		}
 		if Config.Realtime.Enabled {
 			allowed := []AddressFamily{AddressIPv6, AddressIPv4}
 			if !SliceContains(allowed, Config.Realtime.IpVersion) {
@@ -816,6 +1110,9 @@ func LoadConfigFS(fsys afero.Fs) error {
 				}
 				Config.Auth.External[ext] = provider
 			}
+			if err := Config.Auth.ThirdParty.validate(); err != nil {
+				return err
+			}
 		}
 	}
 	// Validate functions config
@@ -824,6 +1121,20 @@ func LoadConfigFS(fsys afero.Fs) error {
 		if !SliceContains(allowed, Config.EdgeRuntime.Policy) {
 			return errors.Errorf("Invalid config for edge_runtime.policy. Must be one of: %v", allowed)
 		}
+		for i, prefix := range Config.EdgeRuntime.PathPrefixes {
+			if !strings.HasPrefix(prefix, "/") || len(prefix) == 1 {
+				return errors.Errorf("Invalid config for edge_runtime.path_prefixes: %s. Must be an absolute path other than /.", prefix)
+			}
+			// Matches /prefix/* without also matching /prefixed/*
+			if !strings.HasSuffix(prefix, "/") {
+				Config.EdgeRuntime.PathPrefixes[i] = prefix + "/"
+			}
+		}
+		for _, host := range Config.EdgeRuntime.Hosts {
+			if !functionsHostPattern.MatchString(host) {
+				return errors.Errorf("Invalid config for edge_runtime.hosts: %s. Must be a hostname without scheme or port, eg. *.functions.localhost", host)
+			}
+		}
 	}
 	for name, functionConfig := range Config.Functions {
 		if functionConfig.VerifyJWT == nil {
@@ -851,6 +1162,126 @@ func LoadConfigFS(fsys afero.Fs) error {
 			return errors.Errorf("Invalid config for analytics.backend. Must be one of: %v", allowed)
 		}
 	}
+	// Validate resource limits
+	for name, r := range map[string]resources{
+		"db":           Config.Db.Resources,
+		"api":          Config.Api.Resources,
+		"realtime":     Config.Realtime.Resources,
+		"studio":       Config.Studio.Resources,
+		"storage":      Config.Storage.Resources,
+		"auth":         Config.Auth.Resources,
+		"edge_runtime": Config.EdgeRuntime.Resources,
+	} {
+		// Docker rejects memory limits below 6MB
+		if r.Memory != 0 && r.Memory < 6*units.MiB {
+			return errors.Errorf("Invalid config for %s.resources.memory. Must be at least 6MB.", name)
+		}
+		if r.Cpus < 0 {
+			return errors.Errorf("Invalid config for %s.resources.cpus. Must be a positive number.", name)
+		}
+	}
+	// Validate docker config
+	if strings.Contains(Config.Docker.Registry, "://") {
+		return errors.New("Invalid config for docker.registry. Must be a registry host without scheme, eg. ghcr.io")
+	}
+	// Version pins take precedence over versions synced by supabase link
+	pins := []struct {
+		name    string
+		version string
+		base    string
+		image   *string
+	}{
+		{"api", Config.Api.Version, PostgrestImage, &Config.Api.Image},
+		{"auth", Config.Auth.Version, GotrueImage, &Config.Auth.Image},
+		{"realtime", Config.Realtime.Version, RealtimeImage, &Config.Realtime.Image},
+		{"storage", Config.Storage.Version, StorageImage, &Config.Storage.Image},
+		{"edge_runtime", Config.EdgeRuntime.Version, EdgeRuntimeImage, &Config.EdgeRuntime.Image},
+		{"db.tools", Config.Db.Tools.Version, Pg15Image, &Config.Db.Tools.Image},
+	}
+	for _, p := range pins {
+		if len(p.version) == 0 {
+			continue
+		}
+		if strings.ContainsAny(p.version, ":@/ ") {
+			return errors.Errorf("Invalid config for %s.version. Must be an image tag, eg. %s", p.name, p.base[strings.IndexByte(p.base, ':')+1:])
+		}
+		*p.image = replaceImageTag(p.base, p.version)
+	}
+	overrides := []struct {
+		name  string
+		value string
+		image *string
+	}{
+		{"postgres", Config.Docker.Images.Postgres, &Config.Db.Image},
+		{"gotrue", Config.Docker.Images.Gotrue, &Config.Auth.Image},
+		{"realtime", Config.Docker.Images.Realtime, &Config.Realtime.Image},
+		{"storage", Config.Docker.Images.Storage, &Config.Storage.Image},
+		{"edge_runtime", Config.Docker.Images.EdgeRuntime, &Config.EdgeRuntime.Image},
+		{"postgres_tools", Config.Docker.Images.PostgresTools, &Config.Db.Tools.Image},
+	}
+	for _, o := range overrides {
+		if len(o.value) == 0 {
+			continue
+		}
+		image, err := maybeLoadEnv(o.value)
+		if err != nil {
+			return err
+		}
+		if name := image[strings.LastIndex(image, "/")+1:]; !strings.ContainsAny(name, ":@") {
+			return errors.Errorf("Invalid config for docker.images.%s. Must include an image tag, eg. %s", o.name, *o.image)
+		}
+		*o.image = image
+	}
+	// Validate notification hooks
+	for name, hook := range Config.Hooks {
+		hookUrl, err := maybeLoadEnv(hook.Url)
+		if err != nil {
+			return err
+		}
+		if parsed, err := url.Parse(hookUrl); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+			return errors.Errorf("Invalid config for hooks.%s.url. Must be a http or https URL.", name)
+		}
+		for _, event := range hook.On {
+			if !SliceContains(HookEvents, event) {
+				return errors.Errorf("Invalid config for hooks.%s.on. Must be one of: %v", name, HookEvents)
+			}
+		}
+		hook.Url = hookUrl
+		Config.Hooks[name] = hook
+	}
+	// Validate branch to project ref mapping
+	for branch, ref := range Config.Remotes {
+		if !ProjectRefPattern.MatchString(ref) {
+			return errors.Errorf("Invalid config for remotes.%s. Must be a valid project ref.", branch)
+		}
+	}
+	// Validate seed tables
+	for i, t := range Config.Seed.Tables {
+		if len(t.Table) == 0 {
+			return errors.Errorf("Missing required field in config: seed.tables[%d].table", i)
+		}
+		if ext := filepath.Ext(t.File); ext != ".csv" && ext != ".json" {
+			return errors.Errorf("Invalid config for seed.tables[%d].file. Must be a .csv or .json file.", i)
+		}
+	}
+	for _, ref := range Config.ProductionRefs {
+		if !ProjectRefPattern.MatchString(ref) {
+			return errors.Errorf("Invalid config for production_refs. %s must be a valid project ref.", ref)
+		}
+	}
+	for _, ref := range Config.Safety.ProtectedRefs {
+		if !ProjectRefPattern.MatchString(ref) {
+			return errors.Errorf("Invalid config for safety.protected_refs. %s must be a valid project ref.", ref)
+		}
+	}
+	if len(Config.ProductionRefs) > 0 {
+		fmt.Fprintln(os.Stderr, "production_refs is deprecated. Move them to protected_refs under [safety] instead.")
+		for _, ref := range Config.ProductionRefs {
+			if !SliceContains(Config.Safety.ProtectedRefs, ref) {
+				Config.Safety.ProtectedRefs = append(Config.Safety.ProtectedRefs, ref)
+			}
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,121 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/go-errors/errors"
+)
+
+type sectionRename struct {
+	from, to string
+}
+
+type keyRename struct {
+	section, from, to string
+}
+
+// Sections renamed across CLI versions, applied to nested sections as well. Add an entry
+// here whenever a released config section is renamed.
+var sectionRenames []sectionRename
+
+// Keys renamed within the same section across CLI versions. Add an entry here whenever a
+// released config key is renamed.
+var keyRenames []keyRename
+
+type ConfigChange struct {
+	Line int
+	From string
+	To   string
+}
+
+var (
+	tableHeaderPattern = regexp.MustCompile(`^(\s*\[\[?\s*)([A-Za-z0-9_.\-]+)(\s*\]\]?.*)$`)
+	keyValuePattern    = regexp.MustCompile(`^(\s*)([A-Za-z0-9_\-]+)(\s*=.*)$`)
+)
+
+// MigrateConfig rewrites deprecated sections and keys in config.toml to the current
+// schema. Only matched lines are changed so that comments and formatting are kept.
+func MigrateConfig(data []byte) ([]byte, []ConfigChange, error) {
+	lines := strings.Split(string(data), "\n")
+	// 1. Rename sections and track the section of each line
+	var changes []ConfigChange
+	sections := make([]string, len(lines))
+	keys := map[string]bool{}
+	tables := map[string]int{}
+	multiline := make([]bool, len(lines))
+	current, inString := "", false
+	for i, line := range lines {
+		// Skips the body of multiline strings
+		multiline[i] = inString
+		if strings.Count(line, `"""`)%2 == 1 {
+			inString = !inString
+		}
+		if multiline[i] {
+			sections[i] = current
+			continue
+		}
+		if matches := tableHeaderPattern.FindStringSubmatch(line); len(matches) > 0 {
+			current = matches[2]
+			for _, r := range sectionRenames {
+				if current == r.from || strings.HasPrefix(current, r.from+".") {
+					renamed := r.to + strings.TrimPrefix(current, r.from)
+					changes = append(changes, ConfigChange{Line: i + 1, From: current, To: renamed})
+					lines[i] = matches[1] + renamed + matches[3]
+					current = renamed
+				}
+			}
+			if !strings.HasPrefix(strings.TrimSpace(line), "[[") {
+				tables[current]++
+			}
+		} else if matches := keyValuePattern.FindStringSubmatch(line); len(matches) > 0 {
+			keys[current+"."+matches[2]] = true
+		}
+		sections[i] = current
+	}
+	for _, c := range changes {
+		if tables[c.To] > 1 {
+			return nil, nil, errors.Errorf("Cannot migrate [%s] on line %d: [%s] is already defined.", c.From, c.Line, c.To)
+		}
+	}
+	// 2. Rename keys within their sections
+	for i, line := range lines {
+		matches := keyValuePattern.FindStringSubmatch(line)
+		if len(matches) == 0 || multiline[i] {
+			continue
+		}
+		for _, r := range keyRenames {
+			if sections[i] != r.section || matches[2] != r.from {
+				continue
+			}
+			from, to := r.section+"."+r.from, r.section+"."+r.to
+			if keys[to] {
+				return nil, nil, errors.Errorf("Cannot migrate %s on line %d: %s is already set.", from, i+1, to)
+			}
+			changes = append(changes, ConfigChange{Line: i + 1, From: from, To: to})
+			lines[i] = matches[1] + r.to + matches[3]
+		}
+	}
+	if len(changes) == 0 {
+		return data, nil, nil
+	}
+	return []byte(strings.Join(lines, "\n")), changes, nil
+}
+
+// Returns the deprecated fields among the undecoded keys of config.toml.
+func findDeprecatedFields(data []byte, undecoded []string) []string {
+	_, changes, err := MigrateConfig(data)
+	if err != nil {
+		return nil
+	}
+	var result []string
+	for _, key := range undecoded {
+		for _, c := range changes {
+			if key == c.From || strings.HasPrefix(key, c.From+".") {
+				result = append(result, key)
+				break
+			}
+		}
+	}
+	return result
+}
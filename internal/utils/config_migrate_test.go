@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateConfig(t *testing.T) {
+	// Setup renamed fields
+	sectionRenames = []sectionRename{{"edge_functions", "edge_runtime"}}
+	keyRenames = []keyRename{
+		{"db.pooler", "pool_size", "default_pool_size"},
+		{"edge_runtime", "inspect_port", "inspector_port"},
+		{"studio", "openai_key", "openai_api_key"},
+	}
+	t.Cleanup(func() {
+		sectionRenames = nil
+		keyRenames = nil
+	})
+
+	t.Run("renames deprecated sections and keys", func(t *testing.T) {
+		config := `[studio]
+# OpenAI key for SQL assistant
+openai_key = "env(OPENAI_API_KEY)"
+
+[edge_functions]
+enabled = true
+inspect_port = 8083
+
+[auth.email.template.invite]
+content = """
+openai_key = "kept"
+"""
+`
+		// Run test
+		migrated, changes, err := MigrateConfig([]byte(config))
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, `[studio]
+# OpenAI key for SQL assistant
+openai_api_key = "env(OPENAI_API_KEY)"
+
+[edge_runtime]
+enabled = true
+inspector_port = 8083
+
+[auth.email.template.invite]
+content = """
+openai_key = "kept"
+"""
+`, string(migrated))
+		assert.Equal(t, []ConfigChange{
+			{Line: 5, From: "edge_functions", To: "edge_runtime"},
+			{Line: 3, From: "studio.openai_key", To: "studio.openai_api_key"},
+			{Line: 7, From: "edge_runtime.inspect_port", To: "edge_runtime.inspector_port"},
+		}, changes)
+	})
+
+	t.Run("skips up to date config", func(t *testing.T) {
+		config := []byte("[edge_runtime]\ninspector_port = 8083\n")
+		// Run test
+		migrated, changes, err := MigrateConfig(config)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, changes)
+		assert.Equal(t, config, migrated)
+	})
+
+	t.Run("throws error on conflicting key", func(t *testing.T) {
+		config := "[db.pooler]\npool_size = 10\ndefault_pool_size = 20\n"
+		// Run test
+		_, _, err := MigrateConfig([]byte(config))
+		// Check error
+		assert.ErrorContains(t, err, "Cannot migrate db.pooler.pool_size on line 2: db.pooler.default_pool_size is already set.")
+	})
+
+	t.Run("throws error on conflicting section", func(t *testing.T) {
+		config := "[edge_runtime]\nenabled = true\n\n[edge_functions]\nenabled = false\n"
+		// Run test
+		_, _, err := MigrateConfig([]byte(config))
+		// Check error
+		assert.ErrorContains(t, err, "Cannot migrate [edge_functions] on line 4: [edge_runtime] is already defined.")
+	})
+}
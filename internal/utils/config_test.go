@@ -1,7 +1,9 @@
 package utils
 
 import (
+	"bytes"
 	_ "embed"
+	"maps"
 	"testing"
 	"text/template"
 
@@ -19,8 +21,15 @@ var (
 func TestConfigParsing(t *testing.T) {
 	// Reset global variable
 	copy := initConfigTemplate
+	saved := Config
+	saved.Auth.Email.Template = maps.Clone(Config.Auth.Email.Template)
+	saved.Auth.External = maps.Clone(Config.Auth.External)
 	teardown := func() {
 		initConfigTemplate = copy
+		// Decoding does not clear fields loaded by previous tests
+		Config = saved
+		Config.Auth.Email.Template = maps.Clone(saved.Auth.Email.Template)
+		Config.Auth.External = maps.Clone(saved.Auth.External)
 	}
 
 	t.Run("classic config file", func(t *testing.T) {
@@ -30,6 +39,20 @@ func TestConfigParsing(t *testing.T) {
 		assert.NoError(t, LoadConfigFS(fsys))
 	})
 
+	t.Run("config file with reassigned ports", func(t *testing.T) {
+		defer teardown()
+		fsys := afero.NewMemMapFs()
+		assert.NoError(t, WriteConfig(fsys, false))
+		assert.NoError(t, afero.WriteFile(fsys, PortsPath, []byte(`{
+			"api.port": {"configured": 54321, "selected": 54330},
+			"db.port": {"configured": 5432, "selected": 54331}
+		}`), 0644))
+		assert.NoError(t, LoadConfigFS(fsys))
+		assert.Equal(t, uint16(54330), Config.Api.Port)
+		// Configured port no longer matches, so config.toml takes precedence
+		assert.Equal(t, uint16(54322), Config.Db.Port)
+	})
+
 	t.Run("config file with environment variables", func(t *testing.T) {
 		defer teardown()
 		initConfigTemplate = testInitConfigTemplate
@@ -57,6 +80,431 @@ func TestConfigParsing(t *testing.T) {
 		// Run test
 		assert.Error(t, LoadConfigFS(fsys))
 	})
+
+	t.Run("config file with docker image overrides", func(t *testing.T) {
+		defer teardown()
+		defer func() {
+			Config.Docker = docker{}
+			Config.Db.Image = Pg15Image
+			Config.Storage.Image = StorageImage
+		}()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		assert.NoError(t, WriteConfig(fsys, false))
+		contents, err := afero.ReadFile(fsys, ConfigPath)
+		assert.NoError(t, err)
+		contents = bytes.Replace(contents, []byte(`registry = ""`), []byte(`registry = "ghcr.io"`), 1)
+		contents = bytes.Replace(contents, []byte(`# postgres = `), []byte(`postgres = `), 1)
+		contents = bytes.Replace(contents, []byte(`# storage = `), []byte(`storage = `), 1)
+		assert.NoError(t, afero.WriteFile(fsys, ConfigPath, contents, 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check error
+		assert.Equal(t, "ghcr.io", Config.Docker.Registry)
+		assert.Equal(t, "registry.example.com/supabase/postgres:15.1.1.61", Config.Db.Image)
+		assert.Equal(t, "registry.example.com/supabase/storage-api:v1.0.6", Config.Storage.Image)
+		assert.Equal(t, GotrueImage, Config.Auth.Image)
+	})
+
+	t.Run("throws error on image override without tag", func(t *testing.T) {
+		defer teardown()
+		defer func() {
+			Config.Docker = docker{}
+		}()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		assert.NoError(t, WriteConfig(fsys, false))
+		contents, err := afero.ReadFile(fsys, ConfigPath)
+		assert.NoError(t, err)
+		contents = bytes.Replace(contents, []byte(`# gotrue = "registry.example.com/supabase/gotrue:v2.151.0"`), []byte(`gotrue = "localhost:5000/gotrue"`), 1)
+		assert.NoError(t, afero.WriteFile(fsys, ConfigPath, contents, 0644))
+		// Run test
+		err = LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for docker.images.gotrue. Must include an image tag")
+	})
+
+	t.Run("config file with version pins", func(t *testing.T) {
+		defer teardown()
+		defer func() {
+			Config.Auth.Version = ""
+			Config.Auth.Image = GotrueImage
+			Config.EdgeRuntime.Version = ""
+			Config.EdgeRuntime.Image = EdgeRuntimeImage
+		}()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		assert.NoError(t, WriteConfig(fsys, false))
+		contents, err := afero.ReadFile(fsys, ConfigPath)
+		assert.NoError(t, err)
+		contents = bytes.Replace(contents, []byte(`# version = "v2.151.0"`), []byte(`version = "v2.150.0"`), 1)
+		contents = bytes.Replace(contents, []byte(`# version = "v1.54.3"`), []byte(`version = "v1.53.0"`), 1)
+		assert.NoError(t, afero.WriteFile(fsys, ConfigPath, contents, 0644))
+		// Pins take precedence over linked versions
+		assert.NoError(t, afero.WriteFile(fsys, GotrueVersionPath, []byte("v2.149.0"), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check error
+		assert.Equal(t, "supabase/gotrue:v2.150.0", Config.Auth.Image)
+		assert.Equal(t, "supabase/edge-runtime:v1.53.0", Config.EdgeRuntime.Image)
+		assert.Equal(t, RealtimeImage, Config.Realtime.Image)
+	})
+
+	t.Run("config file with tools version pin", func(t *testing.T) {
+		defer teardown()
+		defer func() {
+			Config.Db.Tools.Version = ""
+			Config.Db.Tools.Image = Pg15Image
+		}()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		assert.NoError(t, WriteConfig(fsys, false))
+		contents, err := afero.ReadFile(fsys, ConfigPath)
+		assert.NoError(t, err)
+		contents = bytes.Replace(contents, []byte(`# version = "15.6.1.120"`), []byte(`version = "15.6.1.120"`), 1)
+		assert.NoError(t, afero.WriteFile(fsys, ConfigPath, contents, 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check error
+		assert.Equal(t, "supabase/postgres:15.6.1.120", Config.Db.Tools.Image)
+		assert.Equal(t, Pg15Image, Config.Db.Image)
+	})
+
+	t.Run("throws error on tools bin_dir with version", func(t *testing.T) {
+		defer teardown()
+		defer func() {
+			Config.Db.Tools.Version = ""
+			Config.Db.Tools.BinDir = ""
+		}()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		assert.NoError(t, WriteConfig(fsys, false))
+		contents, err := afero.ReadFile(fsys, ConfigPath)
+		assert.NoError(t, err)
+		contents = bytes.Replace(contents, []byte(`# version = "15.6.1.120"`), []byte(`version = "15.6.1.120"`), 1)
+		contents = bytes.Replace(contents, []byte(`# bin_dir = "/usr/lib/postgresql/16/bin"`), []byte(`bin_dir = "/usr/lib/postgresql/16/bin"`), 1)
+		assert.NoError(t, afero.WriteFile(fsys, ConfigPath, contents, 0644))
+		// Run test
+		err = LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for db.tools: bin_dir and version cannot both be set.")
+	})
+
+	t.Run("throws error on managed tools with bin_dir", func(t *testing.T) {
+		defer teardown()
+		defer func() {
+			Config.Db.Tools.BinDir = ""
+			Config.Db.Tools.Managed = false
+		}()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		assert.NoError(t, WriteConfig(fsys, false))
+		contents, err := afero.ReadFile(fsys, ConfigPath)
+		assert.NoError(t, err)
+		contents = bytes.Replace(contents, []byte(`# bin_dir = "/usr/lib/postgresql/16/bin"`), []byte(`bin_dir = "/usr/lib/postgresql/16/bin"`), 1)
+		contents = bytes.Replace(contents, []byte(`# managed = true`), []byte(`managed = true`), 1)
+		assert.NoError(t, afero.WriteFile(fsys, ConfigPath, contents, 0644))
+		// Run test
+		err = LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for db.tools: managed cannot be set with bin_dir or version.")
+	})
+
+	t.Run("config file with db settings", func(t *testing.T) {
+		defer teardown()
+		defer func() {
+			Config.Db.Settings = settings{}
+		}()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		assert.NoError(t, WriteConfig(fsys, false))
+		contents, err := afero.ReadFile(fsys, ConfigPath)
+		assert.NoError(t, err)
+		contents = bytes.Replace(contents, []byte(`# shared_preload_libraries = ["pg_partman_bgw"]`), []byte(`shared_preload_libraries = ["pg_partman_bgw"]`), 1)
+		contents = bytes.Replace(contents, []byte(`# init_scripts = ["./init/extensions.sql"]`), []byte(`init_scripts = ["./init/extensions.sql"]`), 1)
+		contents = bytes.Replace(contents, []byte(`# "pg_partman_bgw.interval" = "3600"`), []byte(`"pg_partman_bgw.interval" = "3600"`), 1)
+		assert.NoError(t, afero.WriteFile(fsys, ConfigPath, contents, 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check config
+		assert.Equal(t, []string{"pg_partman_bgw"}, Config.Db.Settings.SharedPreloadLibraries)
+		assert.Equal(t, []string{"supabase/init/extensions.sql"}, Config.Db.Settings.InitScripts)
+		assert.Equal(t, []string{"-c", "pg_partman_bgw.interval=3600"}, Config.Db.Settings.ParameterArgs())
+	})
+
+	t.Run("throws error on invalid db settings", func(t *testing.T) {
+		// Setup invalid settings
+		for _, s := range []settings{
+			{SharedPreloadLibraries: []string{"pg_cron; rm -rf /"}},
+			{Parameters: map[string]string{"shared_preload_libraries": "pg_cron"}},
+			{Parameters: map[string]string{"work mem": "64MB"}},
+		} {
+			// Run test
+			err := s.validate(15)
+			// Check error
+			assert.ErrorContains(t, err, "Invalid config for db.settings")
+		}
+		// Run test
+		err := (&settings{SharedPreloadLibraries: []string{"pg_cron"}}).validate(14)
+		// Check error
+		assert.ErrorContains(t, err, "requires db.major_version 15 or above")
+	})
+
+	t.Run("throws error on zero realtime users", func(t *testing.T) {
+		defer teardown()
+		defer func() {
+			Config.Realtime.MaxConcurrentUsers = 200
+		}()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		assert.NoError(t, WriteConfig(fsys, false))
+		contents, err := afero.ReadFile(fsys, ConfigPath)
+		assert.NoError(t, err)
+		contents = bytes.Replace(contents, []byte(`max_concurrent_users = 200`), []byte(`max_concurrent_users = 0`), 1)
+		assert.NoError(t, afero.WriteFile(fsys, ConfigPath, contents, 0644))
+		// Run test
+		err = LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for realtime.max_concurrent_users. Must be greater than 0.")
+	})
+
+	t.Run("config file with functions routing", func(t *testing.T) {
+		defer teardown()
+		defer func() {
+			Config.EdgeRuntime.PathPrefixes = nil
+			Config.EdgeRuntime.Hosts = nil
+		}()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		assert.NoError(t, WriteConfig(fsys, false))
+		contents, err := afero.ReadFile(fsys, ConfigPath)
+		assert.NoError(t, err)
+		contents = bytes.Replace(contents, []byte(`# path_prefixes = ["/api/functions/"]`), []byte(`path_prefixes = ["/api/functions"]`), 1)
+		contents = bytes.Replace(contents, []byte(`# hosts = ["*.functions.localhost"]`), []byte(`hosts = ["*.functions.localhost"]`), 1)
+		assert.NoError(t, afero.WriteFile(fsys, ConfigPath, contents, 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check error
+		assert.Equal(t, []string{"/api/functions/"}, Config.EdgeRuntime.PathPrefixes)
+		assert.Equal(t, []string{"*.functions.localhost"}, Config.EdgeRuntime.Hosts)
+	})
+
+	t.Run("throws error on invalid functions host", func(t *testing.T) {
+		defer teardown()
+		defer func() {
+			Config.EdgeRuntime.Hosts = nil
+		}()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		assert.NoError(t, WriteConfig(fsys, false))
+		contents, err := afero.ReadFile(fsys, ConfigPath)
+		assert.NoError(t, err)
+		contents = bytes.Replace(contents, []byte(`# hosts = ["*.functions.localhost"]`), []byte(`hosts = ["http://functions.localhost:54321"]`), 1)
+		assert.NoError(t, afero.WriteFile(fsys, ConfigPath, contents, 0644))
+		// Run test
+		err = LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for edge_runtime.hosts: http://functions.localhost:54321.")
+	})
+
+	t.Run("throws error on relative path prefix", func(t *testing.T) {
+		defer teardown()
+		defer func() {
+			Config.EdgeRuntime.PathPrefixes = nil
+		}()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		assert.NoError(t, WriteConfig(fsys, false))
+		contents, err := afero.ReadFile(fsys, ConfigPath)
+		assert.NoError(t, err)
+		contents = bytes.Replace(contents, []byte(`# path_prefixes = ["/api/functions/"]`), []byte(`path_prefixes = ["api"]`), 1)
+		assert.NoError(t, afero.WriteFile(fsys, ConfigPath, contents, 0644))
+		// Run test
+		err = LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for edge_runtime.path_prefixes: api.")
+	})
+
+	t.Run("throws error on invalid version pin", func(t *testing.T) {
+		defer teardown()
+		defer func() {
+			Config.EdgeRuntime.Version = ""
+		}()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		assert.NoError(t, WriteConfig(fsys, false))
+		contents, err := afero.ReadFile(fsys, ConfigPath)
+		assert.NoError(t, err)
+		contents = bytes.Replace(contents, []byte(`# version = "v1.54.3"`), []byte(`version = "supabase/edge-runtime:v1.53.0"`), 1)
+		assert.NoError(t, afero.WriteFile(fsys, ConfigPath, contents, 0644))
+		// Run test
+		err = LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for edge_runtime.version. Must be an image tag, eg. v1.54.3")
+	})
+
+	t.Run("throws error on unknown hook event", func(t *testing.T) {
+		defer teardown()
+		defer func() {
+			Config.Hooks = nil
+		}()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		assert.NoError(t, WriteConfig(fsys, false))
+		contents, err := afero.ReadFile(fsys, ConfigPath)
+		assert.NoError(t, err)
+		contents = append(contents, []byte(`
+[hooks.deploy]
+url = "https://example.com/webhook"
+on = ["functions.delete"]
+`)...)
+		assert.NoError(t, afero.WriteFile(fsys, ConfigPath, contents, 0644))
+		// Run test
+		err = LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for hooks.deploy.on. Must be one of:")
+	})
+
+	t.Run("config file with function hooks", func(t *testing.T) {
+		defer teardown()
+		defer func() {
+			Config.Functions = nil
+			Config.FunctionHooks = FunctionHooks{}
+		}()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		assert.NoError(t, WriteConfig(fsys, false))
+		contents, err := afero.ReadFile(fsys, ConfigPath)
+		assert.NoError(t, err)
+		contents = append(contents, []byte(`
+[function_hooks]
+pre_bundle = "npm run build:shared"
+post_deploy = "echo deployed"
+
+[functions.hello]
+pre_bundle = "npm run build:hello"
+`)...)
+		assert.NoError(t, afero.WriteFile(fsys, ConfigPath, contents, 0644))
+		// Run test
+		err = LoadConfigFS(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "npm run build:shared", Config.FunctionHooks.PreBundle)
+		fc := GetFunctionConfig("hello", "", nil, fsys)
+		assert.Equal(t, "npm run build:hello", fc.PreBundle)
+		assert.Equal(t, "echo deployed", fc.PostDeploy)
+	})
+
+	t.Run("throws error on unsupported seed file", func(t *testing.T) {
+		defer teardown()
+		defer func() {
+			Config.Seed.Tables = nil
+		}()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		assert.NoError(t, WriteConfig(fsys, false))
+		contents, err := afero.ReadFile(fsys, ConfigPath)
+		assert.NoError(t, err)
+		contents = append(contents, []byte(`
+[[seed.tables]]
+table = "public.cities"
+file = "seeds/cities.xlsx"
+`)...)
+		assert.NoError(t, afero.WriteFile(fsys, ConfigPath, contents, 0644))
+		// Run test
+		err = LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for seed.tables[0].file. Must be a .csv or .json file.")
+	})
+
+	t.Run("throws error on invalid production ref", func(t *testing.T) {
+		defer teardown()
+		defer func() {
+			Config.ProductionRefs = nil
+		}()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		assert.NoError(t, WriteConfig(fsys, false))
+		contents, err := afero.ReadFile(fsys, ConfigPath)
+		assert.NoError(t, err)
+		// Top level keys must precede all tables
+		contents = append([]byte(`production_refs = ["invalid"]
+`), contents...)
+		assert.NoError(t, afero.WriteFile(fsys, ConfigPath, contents, 0644))
+		// Run test
+		err = LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for production_refs. invalid must be a valid project ref.")
+	})
+
+	t.Run("merges deprecated production refs", func(t *testing.T) {
+		defer teardown()
+		defer func() {
+			Config.ProductionRefs = nil
+			Config.Safety.ProtectedRefs = nil
+		}()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		assert.NoError(t, WriteConfig(fsys, false))
+		contents, err := afero.ReadFile(fsys, ConfigPath)
+		assert.NoError(t, err)
+		// Top level keys must precede all tables
+		contents = append([]byte(`production_refs = ["abcdefghijklmnopqrst", "tsrqponmlkjihgfedcba"]
+`), contents...)
+		contents = append(contents, []byte(`
+[safety]
+protected_refs = ["abcdefghijklmnopqrst"]
+`)...)
+		assert.NoError(t, afero.WriteFile(fsys, ConfigPath, contents, 0644))
+		// Run test
+		err = LoadConfigFS(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"abcdefghijklmnopqrst", "tsrqponmlkjihgfedcba"}, Config.Safety.ProtectedRefs)
+	})
+
+	t.Run("throws error on invalid protected ref", func(t *testing.T) {
+		defer teardown()
+		defer func() {
+			Config.Safety.ProtectedRefs = nil
+		}()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		assert.NoError(t, WriteConfig(fsys, false))
+		contents, err := afero.ReadFile(fsys, ConfigPath)
+		assert.NoError(t, err)
+		contents = append(contents, []byte(`
+[safety]
+protected_refs = ["invalid"]
+`)...)
+		assert.NoError(t, afero.WriteFile(fsys, ConfigPath, contents, 0644))
+		// Run test
+		err = LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for safety.protected_refs. invalid must be a valid project ref.")
+	})
+}
+
+func TestResourcesConfigParsing(t *testing.T) {
+	t.Run("parses memory and cpu limits", func(t *testing.T) {
+		var testConfig config
+		_, err := toml.Decode(`
+		[db.resources]
+		memory = "2GB"
+		cpus = 1.5`, &testConfig)
+		if assert.NoError(t, err) {
+			limits := testConfig.Db.Resources.ToContainerResources()
+			assert.Equal(t, int64(2*1024*1024*1024), limits.Memory)
+			assert.Equal(t, int64(1500000000), limits.NanoCPUs)
+		}
+	})
+
+	t.Run("defaults to unlimited", func(t *testing.T) {
+		var testConfig config
+		limits := testConfig.Auth.Resources.ToContainerResources()
+		assert.Zero(t, limits.Memory)
+		assert.Zero(t, limits.NanoCPUs)
+	})
 }
 
 func TestFileSizeLimitConfigParsing(t *testing.T) {
@@ -47,11 +47,7 @@ func GetPoolerConfig(projectRef string) *pgconn.Config {
 		fmt.Fprintln(logger, "Pooler URL is not configured")
 		return nil
 	}
-	// Remove password from pooler connection string because the placeholder text
-	// [YOUR-PASSWORD] messes up pgconn.ParseConfig. The password must be percent
-	// escaped so we cannot simply call strings.Replace with actual password.
-	poolerUrl := strings.ReplaceAll(Config.Db.Pooler.ConnectionString, "[YOUR-PASSWORD]", "")
-	poolerConfig, err := pgconn.ParseConfig(poolerUrl)
+	poolerUrl, poolerConfig, err := parsePoolerUrl()
 	if err != nil {
 		fmt.Fprintln(logger, "Failed to parse pooler URL:", poolerUrl)
 		return nil
@@ -79,10 +75,49 @@ func GetPoolerConfig(projectRef string) *pgconn.Config {
 	}
 	fmt.Fprintln(logger, "Using connection pooler:", poolerUrl)
 	// Supavisor transaction mode does not support prepared statement
-	poolerConfig.Port = 5432
+	poolerConfig.Port = PoolerSessionPort
 	return poolerConfig
 }
 
+func parsePoolerUrl() (string, *pgconn.Config, error) {
+	// Remove password from pooler connection string because the placeholder text
+	// [YOUR-PASSWORD] messes up pgconn.ParseConfig. The password must be percent
+	// escaped so we cannot simply call strings.Replace with actual password.
+	poolerUrl := strings.ReplaceAll(Config.Db.Pooler.ConnectionString, "[YOUR-PASSWORD]", "")
+	poolerConfig, err := pgconn.ParseConfig(poolerUrl)
+	return poolerUrl, poolerConfig, err
+}
+
+// Supavisor serves session mode and transaction mode on separate ports.
+const (
+	PoolerSessionPort     uint16 = 5432
+	PoolerTransactionPort uint16 = 6543
+)
+
+// Transaction mode reuses server connections across clients, so session level features
+// like prepared statements and LISTEN break in confusing ways. The mode is taken from the
+// pooler config saved by supabase link, or the local pooler config.
+func IsTransactionPooler(config pgconn.Config) bool {
+	if len(Config.Db.Pooler.ConnectionString) > 0 {
+		if _, linked, err := parsePoolerUrl(); err == nil && linked.Host == config.Host && linked.Port == config.Port {
+			return Config.Db.Pooler.PoolMode == TransactionMode
+		}
+	}
+	pooler := Config.Db.Pooler
+	return pooler.Enabled && pooler.PoolMode == TransactionMode &&
+		config.Host == Config.Hostname && config.Port == pooler.Port
+}
+
+// Returns a config that connects to the same database bypassing transaction mode pooling.
+func GetSessionConfig(config pgconn.Config) pgconn.Config {
+	if isSupabaseDomain(config.Host) {
+		config.Port = PoolerSessionPort
+	} else {
+		config.Port = Config.Db.Port
+	}
+	return config
+}
+
 func isSupabaseDomain(host string) bool {
 	switch GetSupabaseAPIHost() {
 	case "https://api.supabase.green":
@@ -146,8 +181,21 @@ func ConnectByConfigStream(ctx context.Context, config pgconn.Config, w io.Write
 		if DNSResolver.Value == DNS_OVER_HTTPS {
 			cc.LookupFunc = FallbackLookupIP
 		}
+		cc.LookupFunc = withNetworkPreference(cc.LookupFunc)
 	})
-	return ConnectByUrl(ctx, ToPostgresURL(config), opts...)
+	conn, err := ConnectByUrl(ctx, ToPostgresURL(config), opts...)
+	if err == nil || !isNetworkError(err) {
+		return conn, err
+	}
+	// Direct connections may be IPv6 only, while the pooler supports IPv4
+	if fallback := getFallbackConfig(config); fallback != nil {
+		fmt.Fprintln(w, "Retrying connection via "+Bold(fallback.Host)+"...")
+		if conn, fbErr := ConnectByUrl(ctx, ToPostgresURL(*fallback), opts...); fbErr == nil {
+			return conn, nil
+		}
+	}
+	suggestNetworkFix(config, err)
+	return nil, err
 }
 
 func ConnectByConfig(ctx context.Context, config pgconn.Config, options ...func(*pgx.ConnConfig)) (*pgx.Conn, error) {
@@ -164,6 +164,47 @@ func TestPoolerConfig(t *testing.T) {
 	})
 }
 
+func TestTransactionPooler(t *testing.T) {
+	t.Run("detects linked transaction pooler", func(t *testing.T) {
+		Config.Db.Pooler.ConnectionString = PG15_POOLER_URL
+		Config.Db.Pooler.PoolMode = TransactionMode
+		defer func() { Config.Db.Pooler.ConnectionString = "" }()
+		config := pgconn.Config{Host: "fly-0-sin.pooler.supabase.com", Port: PoolerTransactionPort}
+		assert.True(t, IsTransactionPooler(config))
+		assert.Equal(t, PoolerSessionPort, GetSessionConfig(config).Port)
+	})
+
+	t.Run("ignores linked session pooler", func(t *testing.T) {
+		Config.Db.Pooler.ConnectionString = PG15_POOLER_URL
+		Config.Db.Pooler.PoolMode = SessionMode
+		defer func() { Config.Db.Pooler.ConnectionString = "" }()
+		config := pgconn.Config{Host: "fly-0-sin.pooler.supabase.com", Port: PoolerTransactionPort}
+		assert.False(t, IsTransactionPooler(config))
+	})
+
+	t.Run("ignores unknown pooler", func(t *testing.T) {
+		config := pgconn.Config{Host: "aws-0-us-east-1.pooler.supabase.com", Port: PoolerTransactionPort}
+		assert.False(t, IsTransactionPooler(config))
+	})
+
+	t.Run("detects local transaction pooler", func(t *testing.T) {
+		Config.Hostname = "127.0.0.1"
+		Config.Db.Port = 54322
+		Config.Db.Pooler.Enabled = true
+		Config.Db.Pooler.Port = 54329
+		Config.Db.Pooler.PoolMode = TransactionMode
+		defer func() { Config.Db.Pooler.Enabled = false }()
+		config := pgconn.Config{Host: "127.0.0.1", Port: 54329}
+		assert.True(t, IsTransactionPooler(config))
+		assert.Equal(t, uint16(54322), GetSessionConfig(config).Port)
+	})
+
+	t.Run("ignores session mode", func(t *testing.T) {
+		config := pgconn.Config{Host: "aws-0-us-east-1.pooler.supabase.com", Port: PoolerSessionPort}
+		assert.False(t, IsTransactionPooler(config))
+	})
+}
+
 func TestPostgresURL(t *testing.T) {
 	url := ToPostgresURL(pgconn.Config{
 		Host:     "2406:da18:4fd:9b0d:80ec:9812:3e65:450b",
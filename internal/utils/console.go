@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/go-errors/errors"
+	"github.com/spf13/viper"
 	"golang.org/x/term"
 )
 
@@ -32,6 +33,11 @@ func NewConsole() *Console {
 	}
 }
 
+// IsInteractive reports whether the user may be prompted, which is disabled by --non-interactive.
+func IsInteractive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && !viper.GetBool("non-interactive")
+}
+
 // Prevent interactive terminals from hanging more than 10 minutes
 const ttyTimeout = time.Minute * 10
 
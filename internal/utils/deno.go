@@ -49,6 +49,8 @@ func GetDenoPath() (string, error) {
 	return denoPath, nil
 }
 
+// InstallOrUpgradeDeno downloads deno to GetDenoPath without verifying its checksum. It is
+// kept as a fallback for platforms without a checksum in the managed tools cache.
 func InstallOrUpgradeDeno(ctx context.Context, fsys afero.Fs) error {
 	denoPath, err := GetDenoPath()
 	if err != nil {
@@ -293,9 +295,28 @@ func GetFunctionConfig(slug, importMapPath string, noVerifyJWT *bool, fsys afero
 		fc.VerifyJWT = Ptr(true)
 	}
 	fc.ImportMap = getImportMapPath(importMapPath, fc.ImportMap, fsys)
+	fc.FunctionHooks = mergeFunctionHooks(Config.FunctionHooks, fc.FunctionHooks)
 	return fc
 }
 
+// Hooks declared for a function take precedence over those shared by all functions.
+func mergeFunctionHooks(shared, override FunctionHooks) FunctionHooks {
+	result := shared
+	if len(override.PreBundle) > 0 {
+		result.PreBundle = override.PreBundle
+	}
+	if len(override.PostBundle) > 0 {
+		result.PostBundle = override.PostBundle
+	}
+	if len(override.PreDeploy) > 0 {
+		result.PreDeploy = override.PreDeploy
+	}
+	if len(override.PostDeploy) > 0 {
+		result.PostDeploy = override.PostDeploy
+	}
+	return result
+}
+
 // Path returned is either absolute or relative to CWD.
 func getImportMapPath(flagImportMap, slugImportMap string, fsys afero.Fs) string {
 	// Precedence order: CLI flags > config.toml > fallback value
@@ -340,9 +361,3 @@ func BindImportMap(importMapPath string, fsys afero.Fs) ([]string, string, error
 	}
 	return binds, dockerImportMapPath, nil
 }
-
-func ToDockerPath(absHostPath string) string {
-	prefix := filepath.VolumeName(absHostPath)
-	dockerPath := filepath.ToSlash(absHostPath)
-	return strings.TrimPrefix(dockerPath, prefix)
-}
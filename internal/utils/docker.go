@@ -32,6 +32,7 @@ import (
 	"github.com/docker/docker/pkg/jsonmessage"
 	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
 	"github.com/spf13/viper"
 )
 
@@ -43,7 +44,12 @@ func NewDocker() *client.Client {
 	if err != nil {
 		log.Fatalln("Failed to create Docker client:", err)
 	}
-	if err := cli.Initialize(&dockerFlags.ClientOptions{}); err != nil {
+	opts := dockerFlags.ClientOptions{}
+	// Fallback to podman socket when docker is not installed
+	if host := getPodmanHost(); len(host) > 0 {
+		opts.Hosts = []string{host}
+	}
+	if err := cli.Initialize(&opts); err != nil {
 		log.Fatalln("Failed to initialize Docker client:", err)
 	}
 	return cli.Client().(*client.Client)
@@ -51,7 +57,14 @@ func NewDocker() *client.Client {
 
 const (
 	CliProjectLabel     = "com.supabase.cli.project"
+	CliWorkdirLabel     = "com.supabase.cli.workdir"
 	composeProjectLabel = "com.docker.compose.project"
+	// Snapshots are not labelled with project so that stop --no-backup keeps them
+	CliSnapshotLabel        = "com.supabase.cli.snapshot"
+	CliSnapshotProjectLabel = "com.supabase.cli.snapshot.project"
+	// Records the database that a snapshot was taken from, checked before restoring
+	CliSnapshotImageLabel        = "com.supabase.cli.snapshot.image"
+	CliSnapshotMajorVersionLabel = "com.supabase.cli.snapshot.major_version"
 )
 
 func DockerNetworkCreateIfNotExists(ctx context.Context, mode container.NetworkMode, labels map[string]string) error {
@@ -149,28 +162,37 @@ func CliProjectFilter() filters.Args {
 }
 
 var (
-	// Only supports one registry per command invocation
-	registryAuth string
-	registryOnce sync.Once
+	// Caches encoded credentials per registry host
+	registryAuth = map[string]string{}
+	registryLock sync.Mutex
 )
 
 func GetRegistryAuth() string {
-	registryOnce.Do(func() {
-		config := dockerConfig.LoadDefaultConfigFile(os.Stderr)
-		// Ref: https://docs.docker.com/engine/api/sdk/examples/#pull-an-image-with-authentication
-		auth, err := config.GetAuthConfig(GetRegistry())
-		if err != nil {
-			fmt.Fprintln(os.Stderr, "Failed to load registry credentials:", err)
-			return
-		}
-		encoded, err := json.Marshal(auth)
-		if err != nil {
-			fmt.Fprintln(os.Stderr, "Failed to serialise auth config:", err)
-			return
-		}
-		registryAuth = base64.URLEncoding.EncodeToString(encoded)
-	})
-	return registryAuth
+	return getRegistryAuth(GetRegistry())
+}
+
+func getRegistryAuth(registry string) string {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	if auth, ok := registryAuth[registry]; ok {
+		return auth
+	}
+	// Cache failures so we only print the warning once
+	registryAuth[registry] = ""
+	config := dockerConfig.LoadDefaultConfigFile(os.Stderr)
+	// Ref: https://docs.docker.com/engine/api/sdk/examples/#pull-an-image-with-authentication
+	auth, err := config.GetAuthConfig(registry)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to load registry credentials:", err)
+		return ""
+	}
+	encoded, err := json.Marshal(auth)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to serialise auth config:", err)
+		return ""
+	}
+	registryAuth[registry] = base64.URLEncoding.EncodeToString(encoded)
+	return registryAuth[registry]
 }
 
 // Defaults to Supabase public ECR for faster image pull
@@ -178,6 +200,9 @@ const defaultRegistry = "public.ecr.aws"
 
 func GetRegistry() string {
 	registry := viper.GetString("INTERNAL_IMAGE_REGISTRY")
+	if len(registry) == 0 {
+		registry = Config.Docker.Registry
+	}
 	if len(registry) == 0 {
 		return defaultRegistry
 	}
@@ -185,19 +210,78 @@ func GetRegistry() string {
 }
 
 func GetRegistryImageUrl(imageName string) string {
+	// Images overridden with a fully qualified name are pulled as is
+	if host := getImageRegistry(imageName); len(host) > 0 {
+		return imageName
+	}
 	registry := GetRegistry()
 	if registry == "docker.io" {
 		return imageName
 	}
-	// Configure mirror registry
-	parts := strings.Split(imageName, "/")
-	imageName = parts[len(parts)-1]
-	return registry + "/supabase/" + imageName
+	// Default images are mirrored under the supabase namespace, ie. ghcr.io/supabase/postgrest
+	if isDefaultImage(imageName) {
+		parts := strings.Split(imageName, "/")
+		imageName = parts[len(parts)-1]
+		return registry + "/supabase/" + imageName
+	}
+	// Overridden images keep their repository path on the configured registry
+	return registry + "/" + imageName
+}
+
+// Reports whether the repository of imageName, ignoring its tag, is one that the CLI pulls
+// by default. Tags may differ because linked projects pin their own service versions.
+func isDefaultImage(imageName string) bool {
+	repo := getImageRepository(imageName)
+	if strings.HasPrefix(repo, "supabase/") {
+		return true
+	}
+	for _, images := range [][]string{ServiceImages, JobImages} {
+		for _, image := range images {
+			if getImageRepository(image) == repo {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func getImageRepository(imageName string) string {
+	if i := strings.IndexByte(imageName, '@'); i >= 0 {
+		imageName = imageName[:i]
+	}
+	if i := strings.LastIndexByte(imageName, ':'); i > strings.LastIndexByte(imageName, '/') {
+		imageName = imageName[:i]
+	}
+	return imageName
+}
+
+// Returns the registry host of a fully qualified image name, following the same
+// heuristics as docker: the first path component must look like a hostname.
+func getImageRegistry(imageName string) string {
+	parts := strings.SplitN(imageName, "/", 2)
+	if len(parts) < 2 {
+		return ""
+	}
+	if host := parts[0]; strings.ContainsAny(host, ".:") || host == "localhost" {
+		return strings.ToLower(host)
+	}
+	return ""
 }
 
 func DockerImagePull(ctx context.Context, imageTag string, w io.Writer) error {
+	registry := getImageRegistry(imageTag)
+	if len(registry) == 0 {
+		registry = GetRegistry()
+	}
+	span := StartSpan("docker", "pull "+imageTag)
+	err := dockerImagePull(ctx, imageTag, registry, w)
+	span.Finish(err)
+	return err
+}
+
+func dockerImagePull(ctx context.Context, imageTag, registry string, w io.Writer) error {
 	out, err := Docker.ImagePull(ctx, imageTag, image.PullOptions{
-		RegistryAuth: GetRegistryAuth(),
+		RegistryAuth: getRegistryAuth(registry),
 	})
 	if err != nil {
 		return errors.Errorf("failed to pull docker image: %w", err)
@@ -213,16 +297,17 @@ func DockerImagePull(ctx context.Context, imageTag string, w io.Writer) error {
 var timeUnit = time.Second
 
 func DockerImagePullWithRetry(ctx context.Context, image string, retries int) error {
-	err := DockerImagePull(ctx, image, os.Stderr)
+	w := GetStatusWriter()
+	err := DockerImagePull(ctx, image, w)
 	for i := 0; i < retries; i++ {
 		if err == nil || errors.Is(ctx.Err(), context.Canceled) {
 			break
 		}
-		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(w, err)
 		period := time.Duration(2<<(i+1)) * timeUnit
-		fmt.Fprintf(os.Stderr, "Retrying after %v: %s\n", period, image)
+		fmt.Fprintf(w, "Retrying after %v: %s\n", period, image)
 		time.Sleep(period)
-		err = DockerImagePull(ctx, image, os.Stderr)
+		err = DockerImagePull(ctx, image, w)
 	}
 	return err
 }
@@ -234,10 +319,23 @@ func DockerPullImageIfNotCached(ctx context.Context, imageName string) error {
 	} else if !client.IsErrNotFound(err) {
 		return errors.Errorf("failed to inspect docker image: %w", err)
 	}
+	// Fail fast instead of waiting for registry timeouts
+	if viper.GetBool("OFFLINE") {
+		CmdSuggestion = fmt.Sprintf("Run %s on a connected machine and %s to load the images here.", Aqua("supabase images export > images.tar"), Aqua("supabase images import < images.tar"))
+		return errors.Errorf("%w %s", ErrNotCached, imageUrl)
+	}
 	return DockerImagePullWithRetry(ctx, imageUrl, 2)
 }
 
-var suggestDockerInstall = "Docker Desktop is a prerequisite for local development. Follow the official docs to install: https://docs.docker.com/desktop"
+// Converts configured limits to docker resource constraints, where zero means unlimited.
+func (r resources) ToContainerResources() container.Resources {
+	return container.Resources{
+		Memory:   int64(r.Memory),
+		NanoCPUs: int64(r.Cpus * 1e9),
+	}
+}
+
+var suggestDockerInstall = "Docker Desktop is a prerequisite for local development. Follow the official docs to install: https://docs.docker.com/desktop\nPodman is also supported by setting DOCKER_HOST to its socket, eg. unix://$XDG_RUNTIME_DIR/podman/podman.sock"
 
 func DockerStart(ctx context.Context, config container.Config, hostConfig container.HostConfig, networkingConfig network.NetworkingConfig, containerName string) (string, error) {
 	// Pull container image
@@ -250,12 +348,17 @@ func DockerStart(ctx context.Context, config container.Config, hostConfig contai
 	// Setup default config
 	config.Image = GetRegistryImageUrl(config.Image)
 	if config.Labels == nil {
-		config.Labels = make(map[string]string, 2)
+		config.Labels = make(map[string]string, 3)
 	}
 	config.Labels[CliProjectLabel] = Config.ProjectId
 	config.Labels[composeProjectLabel] = Config.ProjectId
-	// Configure container network
-	hostConfig.ExtraHosts = append(hostConfig.ExtraHosts, extraHosts...)
+	if cwd, err := os.Getwd(); err == nil {
+		config.Labels[CliWorkdirLabel] = cwd
+	}
+	// Configure container network, podman resolves host.containers.internal by default
+	if !IsPodman() {
+		hostConfig.ExtraHosts = append(hostConfig.ExtraHosts, extraHosts...)
+	}
 	if networkId := viper.GetString("network-id"); len(networkId) > 0 {
 		hostConfig.NetworkMode = container.NetworkMode(networkId)
 	} else if len(hostConfig.NetworkMode) == 0 {
@@ -266,11 +369,19 @@ func DockerStart(ctx context.Context, config container.Config, hostConfig contai
 	}
 	// Configure container volumes
 	var binds, sources []string
-	for _, bind := range hostConfig.Binds {
+	var copies []copyMount
+	if GetMountStrategy() == MountStrategyCopy {
+		hostConfig.Binds, copies = splitCopyMounts(hostConfig.Binds)
+	}
+	for i, bind := range hostConfig.Binds {
 		spec, err := loader.ParseVolume(bind)
 		if err != nil {
 			return "", errors.Errorf("failed to parse docker volume: %w", err)
 		}
+		if spec.Type == string(mount.TypeBind) && IsPodman() {
+			bind = podmanBind(bind, spec)
+			hostConfig.Binds[i] = bind
+		}
 		if spec.Type != string(mount.TypeVolume) {
 			binds = append(binds, bind)
 		} else if len(spec.Source) > 0 {
@@ -292,12 +403,22 @@ func DockerStart(ctx context.Context, config container.Config, hostConfig contai
 		}
 	}
 	// Create container from image
+	span := StartSpan("docker", "start "+config.Image)
+	span.SetAttr("container.name", containerName)
 	resp, err := Docker.ContainerCreate(ctx, &config, &hostConfig, &networkingConfig, nil, containerName)
 	if err != nil {
-		return "", errors.Errorf("failed to create docker container: %w", err)
+		err = errors.Errorf("failed to create docker container: %w", err)
+		span.Finish(err)
+		return "", err
+	}
+	if err := dockerCopyMounts(ctx, resp.ID, copies, afero.NewOsFs()); err != nil {
+		span.Finish(err)
+		DockerRemove(resp.ID)
+		return "", err
 	}
 	// Run container in background
 	err = Docker.ContainerStart(ctx, resp.ID, container.StartOptions{})
+	span.Finish(err)
 	if err != nil {
 		if hostPort := parsePortBindError(err); len(hostPort) > 0 {
 			CmdSuggestion = suggestDockerStop(ctx, hostPort)
@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/cli/cli/compose/loader"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+)
+
+type copyMount struct {
+	Source string
+	Target string
+}
+
+// Replaces read-only bind mounts with copies. Writable binds are kept so that containers
+// can still write back to the host.
+func splitCopyMounts(binds []string) ([]string, []copyMount) {
+	var kept []string
+	var copies []copyMount
+	for _, bind := range binds {
+		spec, err := loader.ParseVolume(bind)
+		if err != nil || spec.Type != string(mount.TypeBind) || !spec.ReadOnly {
+			kept = append(kept, bind)
+			continue
+		}
+		copies = append(copies, copyMount{Source: spec.Source, Target: spec.Target})
+	}
+	return kept, copies
+}
+
+// Copies host files into a created container at the target paths of the bind mounts
+// they replace.
+func dockerCopyMounts(ctx context.Context, containerId string, mounts []copyMount, fsys afero.Fs) error {
+	for _, m := range mounts {
+		pr, pw := io.Pipe()
+		go func(m copyMount) {
+			tw := tar.NewWriter(pw)
+			err := writeArchive(tw, m.Source, m.Target, fsys)
+			if err == nil {
+				err = tw.Close()
+			}
+			pw.CloseWithError(err)
+		}(m)
+		err := Docker.CopyToContainer(ctx, containerId, "/", pr, types.CopyToContainerOptions{})
+		// Unblocks the writer if copying fails midway
+		pr.Close()
+		if err != nil {
+			return errors.Errorf("failed to copy %s to container: %w", m.Source, err)
+		}
+	}
+	return nil
+}
+
+// Writes the source file or directory to a tar archive rooted at the target path. Symlinks
+// are followed so that linked files outside the source are copied as well.
+func writeArchive(tw *tar.Writer, source, target string, fsys afero.Fs) error {
+	// Creates parent directories of the target path
+	var parents []string
+	for dir := path.Dir(target); dir != "/" && dir != "."; dir = path.Dir(dir) {
+		parents = append([]string{dir}, parents...)
+	}
+	for _, dir := range parents {
+		if err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeDir,
+			Name:     strings.TrimPrefix(dir, "/") + "/",
+			Mode:     0755,
+		}); err != nil {
+			return errors.Errorf("failed to write archive: %w", err)
+		}
+	}
+	return writeArchiveEntry(tw, source, target, map[string]bool{}, fsys)
+}
+
+func writeArchiveEntry(tw *tar.Writer, source, target string, visited map[string]bool, fsys afero.Fs) error {
+	info, err := fsys.Stat(source)
+	if err != nil {
+		return errors.Errorf("failed to stat %s: %w", source, err)
+	}
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return errors.Errorf("failed to create archive header: %w", err)
+	}
+	header.Name = strings.TrimPrefix(target, "/")
+	if !info.IsDir() {
+		if err := tw.WriteHeader(header); err != nil {
+			return errors.Errorf("failed to write archive: %w", err)
+		}
+		f, err := fsys.Open(source)
+		if err != nil {
+			return errors.Errorf("failed to open %s: %w", source, err)
+		}
+		defer f.Close()
+		if _, err := io.Copy(tw, f); err != nil {
+			return errors.Errorf("failed to write archive: %w", err)
+		}
+		return nil
+	}
+	// Guards against symlink cycles
+	real := source
+	if resolved, err := filepath.EvalSymlinks(source); err == nil {
+		real = resolved
+	}
+	if visited[real] {
+		return nil
+	}
+	visited[real] = true
+	header.Name += "/"
+	if err := tw.WriteHeader(header); err != nil {
+		return errors.Errorf("failed to write archive: %w", err)
+	}
+	entries, err := afero.ReadDir(fsys, source)
+	if err != nil {
+		return errors.Errorf("failed to read %s: %w", source, err)
+	}
+	for _, e := range entries {
+		if err := writeArchiveEntry(tw, filepath.Join(source, e.Name()), path.Join(target, e.Name()), visited, fsys); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+)
+
+func TestSplitCopyMounts(t *testing.T) {
+	binds := []string{
+		"deno-cache:/root/.cache/deno:rw",
+		"/home/me/functions:/home/me/functions:ro",
+		"/home/me/output:/root/eszips:rw",
+	}
+	// Run test
+	kept, copies := splitCopyMounts(binds)
+	// Check output
+	assert.Equal(t, []string{"deno-cache:/root/.cache/deno:rw", "/home/me/output:/root/eszips:rw"}, kept)
+	assert.Equal(t, []copyMount{{Source: "/home/me/functions", Target: "/home/me/functions"}}, copies)
+}
+
+func TestWriteArchive(t *testing.T) {
+	// Setup in-memory fs
+	fsys := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fsys, "/src/hello/index.ts", []byte("Deno.serve()"), 0644))
+	// Run test
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	require.NoError(t, writeArchive(tw, "/src", "/app/functions", fsys))
+	require.NoError(t, tw.Close())
+	// Check output
+	var names []string
+	var contents string
+	tr := tar.NewReader(&buf)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, header.Name)
+		if header.Typeflag == tar.TypeReg {
+			data, err := io.ReadAll(tr)
+			require.NoError(t, err)
+			contents = string(data)
+		}
+	}
+	assert.Equal(t, []string{"app/", "app/functions/", "app/functions/hello/", "app/functions/hello/index.ts"}, names)
+	assert.Equal(t, "Deno.serve()", contents)
+}
+
+func TestStartWithCopyStrategy(t *testing.T) {
+	viper.Set("INTERNAL_IMAGE_REGISTRY", "docker.io")
+	viper.Set("MOUNT_STRATEGY", MountStrategyCopy)
+	t.Cleanup(func() { viper.Set("MOUNT_STRATEGY", "") })
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "index.ts"), []byte("Deno.serve()"), 0644))
+
+	t.Run("copies read-only binds into container", func(t *testing.T) {
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(Docker))
+		defer gock.OffAll()
+		gock.New(Docker.DaemonHost()).
+			Get("/v" + Docker.ClientVersion() + "/images/" + imageId + "/json").
+			Reply(http.StatusOK).
+			JSON(struct{}{})
+		gock.New(Docker.DaemonHost()).
+			Post("/v" + Docker.ClientVersion() + "/networks/create").
+			Reply(http.StatusCreated).
+			JSON(struct{}{})
+		gock.New(Docker.DaemonHost()).
+			Post("/v" + Docker.ClientVersion() + "/containers/create").
+			Reply(http.StatusOK).
+			JSON(map[string]string{"Id": containerId})
+		gock.New(Docker.DaemonHost()).
+			Put("/v"+Docker.ClientVersion()+"/containers/"+containerId+"/archive").
+			MatchParam("path", "/").
+			Reply(http.StatusOK)
+		gock.New(Docker.DaemonHost()).
+			Post("/v" + Docker.ClientVersion() + "/containers/" + containerId + "/start").
+			Reply(http.StatusAccepted)
+		// Run test
+		config := container.Config{Image: imageId}
+		hostConfig := container.HostConfig{Binds: []string{dir + ":/app:ro"}}
+		id, err := DockerStart(context.Background(), config, hostConfig, network.NetworkingConfig{}, "")
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, containerId, id)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on copy failure", func(t *testing.T) {
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(Docker))
+		defer gock.OffAll()
+		gock.New(Docker.DaemonHost()).
+			Get("/v" + Docker.ClientVersion() + "/images/" + imageId + "/json").
+			Reply(http.StatusOK).
+			JSON(struct{}{})
+		gock.New(Docker.DaemonHost()).
+			Post("/v" + Docker.ClientVersion() + "/networks/create").
+			Reply(http.StatusCreated).
+			JSON(struct{}{})
+		gock.New(Docker.DaemonHost()).
+			Post("/v" + Docker.ClientVersion() + "/containers/create").
+			Reply(http.StatusOK).
+			JSON(map[string]string{"Id": containerId})
+		gock.New(Docker.DaemonHost()).
+			Put("/v" + Docker.ClientVersion() + "/containers/" + containerId + "/archive").
+			Reply(http.StatusServiceUnavailable)
+		gock.New(Docker.DaemonHost()).
+			Delete("/v" + Docker.ClientVersion() + "/containers/" + containerId).
+			Reply(http.StatusOK)
+		// Run test
+		config := container.Config{Image: imageId}
+		hostConfig := container.HostConfig{Binds: []string{dir + ":/app:ro"}}
+		_, err := DockerStart(context.Background(), config, hostConfig, network.NetworkingConfig{}, "")
+		// Check error
+		assert.ErrorContains(t, err, "failed to copy")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
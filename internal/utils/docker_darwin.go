@@ -2,10 +2,29 @@
 
 package utils
 
-import "github.com/docker/docker/api/types/container"
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/docker/docker/api/types/container"
+)
 
 var extraHosts []string
 
 func isUserDefined(mode container.NetworkMode) bool {
 	return mode.IsUserDefined()
 }
+
+const defaultDockerSocket = "/var/run/docker.sock"
+
+func podmanSockets() []string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+	// Podman machine forwards its API socket to the host
+	return []string{
+		filepath.Join(home, ".local", "share", "containers", "podman", "machine", "podman.sock"),
+		filepath.Join(home, ".local", "share", "containers", "podman", "machine", "qemu", "podman.sock"),
+	}
+}
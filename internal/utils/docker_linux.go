@@ -2,7 +2,12 @@
 
 package utils
 
-import "github.com/docker/docker/api/types/container"
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/docker/docker/api/types/container"
+)
 
 // Allows containers to resolve host network: https://stackoverflow.com/a/62431165
 var extraHosts = []string{"host.docker.internal:host-gateway"}
@@ -10,3 +15,14 @@ var extraHosts = []string{"host.docker.internal:host-gateway"}
 func isUserDefined(mode container.NetworkMode) bool {
 	return mode.IsUserDefined()
 }
+
+const defaultDockerSocket = "/var/run/docker.sock"
+
+func podmanSockets() []string {
+	var result []string
+	// Rootless podman listens on user runtime dir
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); len(runtimeDir) > 0 {
+		result = append(result, filepath.Join(runtimeDir, "podman", "podman.sock"))
+	}
+	return append(result, "/run/podman/podman.sock")
+}
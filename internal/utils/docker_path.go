@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	MountStrategyBind = "bind"
+	MountStrategyCopy = "copy"
+)
+
+var (
+	// WSL distros are exposed to Windows as \\wsl$\<distro> or \\wsl.localhost\<distro>
+	wslPathPattern = regexp.MustCompile(`(?i)^//wsl(\$|\.localhost)/[^/]+(/.*)?$`)
+	drivePattern   = regexp.MustCompile(`^[A-Za-z]:`)
+)
+
+// Copy strategy avoids bind mounts that Docker Desktop cannot resolve, at the cost of
+// changes on host not being reflected in running containers.
+func GetMountStrategy() string {
+	if viper.GetString("MOUNT_STRATEGY") == MountStrategyCopy {
+		return MountStrategyCopy
+	}
+	return MountStrategyBind
+}
+
+// ToDockerPath translates an absolute host path to the path mounted in linux containers.
+func ToDockerPath(absHostPath string) string {
+	return toDockerPath(absHostPath, runtime.GOOS == "windows")
+}
+
+func toDockerPath(absHostPath string, windows bool) string {
+	if !windows {
+		return filepath.ToSlash(absHostPath)
+	}
+	p := strings.ReplaceAll(absHostPath, `\`, "/")
+	// Strips extended length prefix, ie. \\?\C:\ or \\?\UNC\server\share
+	if strings.HasPrefix(p, "//?/") {
+		p = strings.TrimPrefix(p, "//?/")
+		if strings.HasPrefix(strings.ToUpper(p), "UNC/") {
+			p = "//" + p[len("UNC/"):]
+		}
+	}
+	if matches := wslPathPattern.FindStringSubmatch(p); len(matches) > 0 {
+		// Files in WSL keep their linux paths
+		return path.Clean("/" + matches[2])
+	}
+	if strings.HasPrefix(p, "//") {
+		// Keeps server and share names of UNC paths to avoid conflicts
+		return path.Clean(p[1:])
+	}
+	// Drive letters are dropped for backwards compatibility with bundled entrypoints
+	p = drivePattern.ReplaceAllString(p, "")
+	return path.Clean("/" + p)
+}
+
+// ResolveBindSource returns the host path to bind mount, following symlinks so that
+// Docker Desktop shares the target directory instead of the link itself.
+func ResolveBindSource(absHostPath string) string {
+	if resolved, err := filepath.EvalSymlinks(absHostPath); err == nil {
+		return resolved
+	}
+	return absHostPath
+}
@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToDockerPath(t *testing.T) {
+	cases := map[string]string{
+		`C:\Users\me\project\supabase\functions`:    "/Users/me/project/supabase/functions",
+		`d:\project`:                                "/project",
+		`\\?\C:\Users\me\project`:                   "/Users/me/project",
+		`\\wsl$\Ubuntu\home\me\project`:             "/home/me/project",
+		`\\wsl.localhost\Ubuntu-22.04\home\me\proj`: "/home/me/proj",
+		`\\wsl$\Ubuntu`:                             "/",
+		`\\fileserver\share\project`:                "/fileserver/share/project",
+		`\\?\UNC\fileserver\share\project`:          "/fileserver/share/project",
+		`C:\Users\me\project\..\other\functions\`:   "/Users/me/other/functions",
+	}
+	for input, expected := range cases {
+		assert.Equal(t, expected, toDockerPath(input, true), input)
+	}
+	assert.Equal(t, "/home/me/project", toDockerPath("/home/me/project", false))
+}
+
+func TestResolveBindSource(t *testing.T) {
+	t.Run("follows symlinked directory", func(t *testing.T) {
+		dir := t.TempDir()
+		target := filepath.Join(dir, "functions")
+		require.NoError(t, os.Mkdir(target, 0755))
+		link := filepath.Join(dir, "link")
+		require.NoError(t, os.Symlink(target, link))
+		expected, err := filepath.EvalSymlinks(target)
+		require.NoError(t, err)
+		// Run test
+		assert.Equal(t, expected, ResolveBindSource(link))
+	})
+
+	t.Run("falls back to missing path", func(t *testing.T) {
+		assert.Equal(t, "/missing/functions", ResolveBindSource("/missing/functions"))
+	})
+}
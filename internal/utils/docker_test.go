@@ -58,6 +58,22 @@ func TestPullImage(t *testing.T) {
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
 
+	t.Run("throws error if image is missing in offline mode", func(t *testing.T) {
+		viper.Set("OFFLINE", true)
+		t.Cleanup(func() { viper.Set("OFFLINE", false) })
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(Docker))
+		defer gock.OffAll()
+		gock.New(Docker.DaemonHost()).
+			Get("/v" + Docker.ClientVersion() + "/images/" + imageId + "/json").
+			Reply(http.StatusNotFound)
+		// Run test
+		err := DockerPullImageIfNotCached(context.Background(), imageId)
+		// Validate api
+		assert.ErrorIs(t, err, ErrNotCached)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
 	t.Run("throws error if docker is unavailable", func(t *testing.T) {
 		// Setup mock docker
 		require.NoError(t, apitest.MockDocker(Docker))
@@ -105,6 +121,61 @@ func TestPullImage(t *testing.T) {
 	})
 }
 
+func TestRegistryImageUrl(t *testing.T) {
+	t.Run("rewrites image to mirror registry", func(t *testing.T) {
+		viper.Set("INTERNAL_IMAGE_REGISTRY", "ghcr.io")
+		t.Cleanup(func() { viper.Set("INTERNAL_IMAGE_REGISTRY", "docker.io") })
+		// Run test
+		url := GetRegistryImageUrl("supabase/postgres:15.1.1.61")
+		// Check output
+		assert.Equal(t, "ghcr.io/supabase/postgres:15.1.1.61", url)
+	})
+
+	t.Run("uses registry from config", func(t *testing.T) {
+		viper.Set("INTERNAL_IMAGE_REGISTRY", "")
+		Config.Docker.Registry = "mirror.example.com"
+		t.Cleanup(func() {
+			viper.Set("INTERNAL_IMAGE_REGISTRY", "docker.io")
+			Config.Docker.Registry = ""
+		})
+		// Run test
+		url := GetRegistryImageUrl("supabase/gotrue:v2.151.0")
+		// Check output
+		assert.Equal(t, "mirror.example.com/supabase/gotrue:v2.151.0", url)
+	})
+
+	t.Run("rewrites default image to supabase namespace", func(t *testing.T) {
+		viper.Set("INTERNAL_IMAGE_REGISTRY", "ghcr.io")
+		t.Cleanup(func() { viper.Set("INTERNAL_IMAGE_REGISTRY", "docker.io") })
+		// Run test
+		url := GetRegistryImageUrl("postgrest/postgrest:v12.2.0")
+		// Check output
+		assert.Equal(t, "ghcr.io/supabase/postgrest:v12.2.0", url)
+	})
+
+	t.Run("preserves repository path of override image", func(t *testing.T) {
+		viper.Set("INTERNAL_IMAGE_REGISTRY", "")
+		Config.Docker.Registry = "mirror.example.com"
+		t.Cleanup(func() {
+			viper.Set("INTERNAL_IMAGE_REGISTRY", "docker.io")
+			Config.Docker.Registry = ""
+		})
+		// Run test
+		url := GetRegistryImageUrl("myorg/postgres:15")
+		// Check output
+		assert.Equal(t, "mirror.example.com/myorg/postgres:15", url)
+	})
+
+	t.Run("preserves fully qualified image", func(t *testing.T) {
+		viper.Set("INTERNAL_IMAGE_REGISTRY", "ghcr.io")
+		t.Cleanup(func() { viper.Set("INTERNAL_IMAGE_REGISTRY", "docker.io") })
+		// Run test
+		url := GetRegistryImageUrl("localhost:5000/custom/postgres:15")
+		// Check output
+		assert.Equal(t, "localhost:5000/custom/postgres:15", url)
+	})
+}
+
 func TestRunOnce(t *testing.T) {
 	viper.Set("INTERNAL_IMAGE_REGISTRY", "docker.io")
 
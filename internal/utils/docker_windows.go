@@ -13,3 +13,10 @@ func isUserDefined(mode container.NetworkMode) bool {
 	// Host network requires explicit check on windows: https://github.com/supabase/cli/pull/952
 	return mode.IsUserDefined() && mode.UserDefined() != network.NetworkHost
 }
+
+// Podman machine on windows uses named pipes which must be configured via DOCKER_HOST
+const defaultDockerSocket = ""
+
+func podmanSockets() []string {
+	return nil
+}
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/go-errors/errors"
+)
+
+// Stable error codes that wrappers can branch on instead of matching error messages.
+// The letter after the prefix denotes the failure class: A for auth, D for docker,
+// L for local project state, and F for functions.
+const (
+	CodeMissingToken   = "SUPA-A101"
+	CodeDbNotRunning   = "SUPA-D201"
+	CodeConfigNotFound = "SUPA-L301"
+	CodeMigrationDrift = "SUPA-L302"
+	CodeNotLinked      = "SUPA-L303"
+	CodeSchemaDrift    = "SUPA-L304"
+	CodeBundleFailed   = "SUPA-F401"
+	CodeDeployFailed   = "SUPA-F402"
+)
+
+var errorHints = map[string]string{
+	CodeMissingToken:   fmt.Sprintf("Run %s or set the SUPABASE_ACCESS_TOKEN environment variable.", Aqua("supabase login")),
+	CodeDbNotRunning:   fmt.Sprintf("Run %s to start the local development stack.", Aqua("supabase start")),
+	CodeConfigNotFound: fmt.Sprintf("Run %s to create a new project, or pass --workdir to an existing one.", Aqua("supabase init")),
+	CodeMigrationDrift: fmt.Sprintf("Run %s to reconcile the migration history table with your local files.", Aqua("supabase migration repair")),
+	CodeNotLinked:      fmt.Sprintf("Run %s or pass --project-ref to select a project.", Aqua("supabase link")),
+	CodeSchemaDrift:    fmt.Sprintf("Run %s to capture changes made outside of migrations in a new migration.", Aqua("supabase db pull")),
+	CodeBundleFailed:   "Check the bundler output above for syntax or import errors, and rerun with --verbose for details.",
+	CodeDeployFailed:   "Check that the project is active and that your access token has permission to deploy functions.",
+}
+
+// Known sentinel errors are classified without wrapping, so identity checks on them keep working.
+var sentinelCodes = []struct {
+	err  error
+	code string
+}{
+	{ErrMissingToken, CodeMissingToken},
+	{ErrNotRunning, CodeDbNotRunning},
+	{ErrNotLinked, CodeNotLinked},
+}
+
+type CodedError struct {
+	Code string
+	err  error
+}
+
+func (e *CodedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *CodedError) Unwrap() error {
+	return e.err
+}
+
+// WithCode attaches a stable error code to err, returning nil if err is nil.
+func WithCode(code string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &CodedError{Code: code, err: err}
+}
+
+// GetErrorCode returns the catalog code and remediation hint for err, or empty strings if unknown.
+func GetErrorCode(err error) (string, string) {
+	var coded *CodedError
+	if errors.As(err, &coded) {
+		return coded.Code, errorHints[coded.Code]
+	}
+	for _, s := range sentinelCodes {
+		if errors.Is(err, s.err) {
+			return s.code, errorHints[s.code]
+		}
+	}
+	return "", ""
+}
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/go-errors/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetErrorCode(t *testing.T) {
+	t.Run("returns code of wrapped error", func(t *testing.T) {
+		err := errors.Errorf("failed to deploy: %w", WithCode(CodeDeployFailed, errors.New("bad gateway")))
+		// Run test
+		code, hint := GetErrorCode(err)
+		// Check error
+		assert.Equal(t, CodeDeployFailed, code)
+		assert.NotEmpty(t, hint)
+		assert.ErrorContains(t, err, "failed to deploy: bad gateway")
+	})
+
+	t.Run("classifies sentinel errors", func(t *testing.T) {
+		code, hint := GetErrorCode(errors.New(ErrNotRunning))
+		// Check error
+		assert.Equal(t, CodeDbNotRunning, code)
+		assert.Contains(t, hint, "supabase start")
+	})
+
+	t.Run("preserves identity of wrapped error", func(t *testing.T) {
+		err := WithCode(CodeNotLinked, ErrNotLinked)
+		// Check error
+		assert.ErrorIs(t, err, ErrNotLinked)
+	})
+
+	t.Run("returns empty for unknown errors", func(t *testing.T) {
+		code, hint := GetErrorCode(errors.New("unknown"))
+		// Check error
+		assert.Empty(t, code)
+		assert.Empty(t, hint)
+	})
+
+	t.Run("ignores nil error", func(t *testing.T) {
+		assert.NoError(t, WithCode(CodeBundleFailed, nil))
+	})
+}
@@ -1,11 +1,13 @@
 package flags
 
 import (
+	"context"
 	"crypto/rand"
 	"fmt"
 	"math/big"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/go-errors/errors"
 	"github.com/jackc/pgconn"
@@ -29,7 +31,11 @@ const (
 
 var DbConfig pgconn.Config
 
-func ParseDatabaseConfig(flagSet *pflag.FlagSet, fsys afero.Fs) error {
+// Commands that print or persist credentials need the long-lived database password, since
+// temporary login roles expire shortly after the command exits.
+var RequirePassword bool
+
+func ParseDatabaseConfig(ctx context.Context, flagSet *pflag.FlagSet, fsys afero.Fs) error {
 	// Changed flags take precedence over default values
 	var connType connection
 	if flag := flagSet.Lookup("db-url"); flag != nil && flag.Changed {
@@ -71,11 +77,18 @@ func ParseDatabaseConfig(flagSet *pflag.FlagSet, fsys afero.Fs) error {
 		if err := utils.LoadConfigFS(fsys); err != nil {
 			return err
 		}
-		projectRef, err := LoadProjectRef(fsys)
-		if err != nil {
+		// Resolves branch mapping and prompts for a project if not linked
+		if err := ParseProjectRef(ctx, fsys); err != nil {
 			return err
 		}
-		DbConfig = NewDbConfigWithPassword(projectRef)
+		if RequirePassword {
+			DbConfig = NewDbConfigWithPassword(ProjectRef)
+		} else if config, err := NewDbConfigWithLoginRole(ctx, ProjectRef, fsys); err == nil {
+			DbConfig = config
+		} else {
+			fmt.Fprintln(utils.GetDebugLogger(), err)
+			DbConfig = NewDbConfigWithPassword(ProjectRef)
+		}
 	case proxy:
 		token, err := utils.LoadAccessTokenFS(fsys)
 		if err != nil {
@@ -95,11 +108,56 @@ func ParseDatabaseConfig(flagSet *pflag.FlagSet, fsys afero.Fs) error {
 }
 
 func NewDbConfigWithPassword(projectRef string) pgconn.Config {
+	return NewDbConfigFromPassword(projectRef, getPassword(projectRef))
+}
+
+// NewDbConfigFromPassword is like NewDbConfigWithPassword, but never reads the password from
+// the environment or prompts for it on stdin.
+func NewDbConfigFromPassword(projectRef, password string) pgconn.Config {
 	config := getDbConfig(projectRef)
-	config.Password = getPassword(projectRef)
+	config.Password = password
 	return config
 }
 
+var errPasswordFound = errors.New("database password is configured")
+
+// Mints a short-lived login role using the access token when no database password is
+// configured, so that CI environments need not store the long-lived password.
+func NewDbConfigWithLoginRole(ctx context.Context, projectRef string, fsys afero.Fs) (pgconn.Config, error) {
+	if len(viper.GetString("DB_PASSWORD")) > 0 {
+		return pgconn.Config{}, errors.New(errPasswordFound)
+	}
+	if _, err := credentials.Get(projectRef); err == nil {
+		return pgconn.Config{}, errors.New(errPasswordFound)
+	}
+	if _, err := utils.LoadAccessTokenFS(fsys); err != nil {
+		return pgconn.Config{}, err
+	}
+	resp, err := utils.GetSupabase().V1CreateLoginRoleWithResponse(ctx, projectRef, api.CreateRoleBody{})
+	if err != nil {
+		return pgconn.Config{}, errors.Errorf("failed to create login role: %w", err)
+	}
+	if resp.JSON201 == nil {
+		return pgconn.Config{}, errors.New("Unexpected error creating login role: " + string(resp.Body))
+	}
+	config := getDbConfig(projectRef)
+	// Pooler usernames are suffixed with project ref, ie. postgres.<ref>
+	if _, ref, found := strings.Cut(config.User, "."); found {
+		config.User = resp.JSON201.Role + "." + ref
+	} else {
+		config.User = resp.JSON201.Role
+	}
+	config.Password = resp.JSON201.Password
+	// Assume postgres role so that new objects are not owned by the temporary role
+	if config.RuntimeParams == nil {
+		config.RuntimeParams = make(map[string]string)
+	}
+	config.RuntimeParams["role"] = "postgres"
+	ttl := time.Duration(resp.JSON201.TtlSeconds) * time.Second
+	fmt.Fprintf(os.Stderr, "Using temporary login role %s valid for %s.\n", utils.Aqua(resp.JSON201.Role), ttl)
+	return config, nil
+}
+
 func getPassword(projectRef string) string {
 	if password := viper.GetString("DB_PASSWORD"); len(password) > 0 {
 		return password
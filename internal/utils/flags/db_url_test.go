@@ -0,0 +1,109 @@
+package flags
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/testing/fstest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"github.com/zalando/go-keyring"
+)
+
+func TestLoginRole(t *testing.T) {
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	project := apitest.RandomProjectRef()
+	keyring.MockInit()
+
+	t.Run("creates temporary login role", func(t *testing.T) {
+		utils.Config.Db.Pooler.ConnectionString = ""
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/cli/login-role").
+			Reply(http.StatusCreated).
+			JSON(api.CreateRoleResponse{
+				Role:       "cli_login_postgres",
+				Password:   "temporary",
+				TtlSeconds: 300,
+			})
+		// Run test
+		config, err := NewDbConfigWithLoginRole(context.Background(), project, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, utils.GetSupabaseDbHost(project), config.Host)
+		assert.Equal(t, "cli_login_postgres", config.User)
+		assert.Equal(t, "temporary", config.Password)
+		assert.Equal(t, "postgres", config.RuntimeParams["role"])
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("skips login role when password is stored", func(t *testing.T) {
+		viper.Set("DB_PASSWORD", "password")
+		t.Cleanup(func() { viper.Set("DB_PASSWORD", "") })
+		// Run test
+		_, err := NewDbConfigWithLoginRole(context.Background(), project, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorIs(t, err, errPasswordFound)
+	})
+
+	t.Run("throws error on unsupported platform", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/cli/login-role").
+			Reply(http.StatusNotFound)
+		// Run test
+		_, err := NewDbConfigWithLoginRole(context.Background(), project, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Unexpected error creating login role:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestRequirePassword(t *testing.T) {
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	project := apitest.RandomProjectRef()
+	keyring.MockInit()
+
+	t.Run("skips login role for linked project", func(t *testing.T) {
+		RequirePassword = true
+		t.Cleanup(func() {
+			RequirePassword = false
+			ProjectRef = ""
+		})
+		defer fstest.MockStdin(t, "password")()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, afero.WriteFile(fsys, utils.ProjectRefPath, []byte(project), 0644))
+		flagSet := pflag.NewFlagSet("url", pflag.ContinueOnError)
+		flagSet.Bool("linked", false, "")
+		require.NoError(t, flagSet.Set("linked", "true"))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/cli/login-role").
+			Reply(http.StatusCreated).
+			JSON(api.CreateRoleResponse{Role: "cli_login_postgres", Password: "temporary"})
+		// Run test
+		err := ParseDatabaseConfig(context.Background(), flagSet, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "postgres", DbConfig.User)
+		assert.Equal(t, "password", DbConfig.Password)
+		assert.True(t, gock.IsPending())
+	})
+}
@@ -6,11 +6,12 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/BurntSushi/toml"
 	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
 	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/gen/keys"
 	"github.com/supabase/cli/internal/utils"
-	"golang.org/x/term"
 )
 
 var ProjectRef string
@@ -23,12 +24,16 @@ func ParseProjectRef(ctx context.Context, fsys afero.Fs) error {
 	if len(ProjectRef) > 0 {
 		return utils.AssertProjectRefIsValid(ProjectRef)
 	}
+	// Followed by git branch mapping in config
+	if ref, err := LoadRemoteRef(fsys); err != nil || len(ref) > 0 {
+		return err
+	}
 	// Followed by linked ref file
 	if _, err := LoadProjectRef(fsys); !errors.Is(err, utils.ErrNotLinked) {
 		return err
 	}
 	// Prompt as the last resort
-	if term.IsTerminal(int(os.Stdin.Fd())) {
+	if utils.IsInteractive() {
 		return PromptProjectRef(ctx, "Select a project:")
 	}
 	return errors.New(utils.ErrNotLinked)
@@ -42,13 +47,19 @@ func PromptProjectRef(ctx context.Context, title string) error {
 	if resp.JSON200 == nil {
 		return errors.New("Unexpected error retrieving projects: " + string(resp.Body))
 	}
+	orgs := listOrgNames(ctx)
 	items := make([]utils.PromptItem, len(*resp.JSON200))
 	for i, project := range *resp.JSON200 {
+		org := project.OrganizationId
+		if name, ok := orgs[org]; ok {
+			org = name
+		}
 		items[i] = utils.PromptItem{
 			Summary: project.Id,
-			Details: fmt.Sprintf("name: %s, org: %s, region: %s", project.Name, project.OrganizationId, project.Region),
+			Details: fmt.Sprintf("name: %s, org: %s, region: %s", project.Name, org, project.Region),
 		}
 	}
+	// Type / to fuzzy search by ref, name, org, or region
 	choice, err := utils.PromptChoice(ctx, title, items)
 	if err != nil {
 		return err
@@ -58,6 +69,50 @@ func PromptProjectRef(ctx context.Context, title string) error {
 	return nil
 }
 
+// Resolves organization names for display, falling back to ids on error.
+func listOrgNames(ctx context.Context) map[string]string {
+	result := map[string]string{}
+	resp, err := utils.GetSupabase().V1ListAllOrganizationsWithResponse(ctx)
+	if err != nil || resp.JSON200 == nil {
+		logger := utils.GetDebugLogger()
+		fmt.Fprintln(logger, "failed to list organizations:", err)
+		return result
+	}
+	for _, org := range *resp.JSON200 {
+		result[org.Id] = org.Name
+	}
+	return result
+}
+
+// Looks up the project ref mapped to the current git branch under [remotes] in config.
+// Decodes only the [remotes] table, so that commands which never load config.toml
+// are not blocked by unrelated config errors.
+func LoadRemoteRef(fsys afero.Fs) (string, error) {
+	var config struct {
+		Remotes map[string]string `toml:"remotes"`
+	}
+	if _, err := toml.DecodeFS(afero.NewIOFS(fsys), utils.ConfigPath, &config); errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "Ignoring git branch mapping in config:", err)
+		return "", nil
+	}
+	if len(config.Remotes) == 0 {
+		return "", nil
+	}
+	branch := keys.GetGitBranchOrDefault("", fsys)
+	ref, ok := config.Remotes[branch]
+	if !ok {
+		return "", nil
+	}
+	if !utils.ProjectRefPattern.MatchString(ref) {
+		return "", errors.Errorf("Invalid config for remotes.%s. Must be a valid project ref.", branch)
+	}
+	fmt.Fprintf(os.Stderr, "Using project %s mapped to git branch %s\n", utils.Aqua(ref), utils.Aqua(branch))
+	ProjectRef = ref
+	return ref, nil
+}
+
 func LoadProjectRef(fsys afero.Fs) (string, error) {
 	projectRefBytes, err := afero.ReadFile(fsys, utils.ProjectRefPath)
 	if errors.Is(err, os.ErrNotExist) {
@@ -42,6 +42,57 @@ func TestProjectRef(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("loads from git branch mapping", func(t *testing.T) {
+		ProjectRef = ""
+		t.Setenv("GITHUB_HEAD_REF", "develop")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Setup branch mapping
+		project := apitest.RandomProjectRef()
+		f, err := fsys.OpenFile(utils.ConfigPath, os.O_APPEND|os.O_WRONLY, 0644)
+		require.NoError(t, err)
+		_, err = f.WriteString("\n[remotes]\ndevelop = \"" + project + "\"\n")
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		// Run test
+		err = ParseProjectRef(context.Background(), fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, project, ProjectRef)
+	})
+
+	t.Run("throws error on invalid mapping", func(t *testing.T) {
+		ProjectRef = ""
+		t.Setenv("GITHUB_HEAD_REF", "main")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		f, err := fsys.OpenFile(utils.ConfigPath, os.O_APPEND|os.O_WRONLY, 0644)
+		require.NoError(t, err)
+		_, err = f.WriteString("\n[remotes]\nmain = \"abcd1234\"\n")
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		// Run test
+		err = ParseProjectRef(context.Background(), fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for remotes.main. Must be a valid project ref.")
+	})
+
+	t.Run("ignores malformed config", func(t *testing.T) {
+		ProjectRef = ""
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte("malformed"), 0644))
+		project := apitest.RandomProjectRef()
+		require.NoError(t, afero.WriteFile(fsys, utils.ProjectRefPath, []byte(project), 0644))
+		// Run test
+		err := ParseProjectRef(context.Background(), fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, project, ProjectRef)
+	})
+
 	t.Run("throws error on read failure", func(t *testing.T) {
 		ProjectRef = ""
 		// Setup in-memory fs
@@ -79,6 +130,13 @@ func TestProjectPrompt(t *testing.T) {
 				Name:           "My Project",
 				OrganizationId: "test-org",
 			}})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/organizations").
+			Reply(http.StatusOK).
+			JSON([]api.OrganizationResponseV1{{
+				Id:   "test-org",
+				Name: "My Org",
+			}})
 		// Run test
 		err := PromptProjectRef(context.Background(), "")
 		// Check error
@@ -0,0 +1,142 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+)
+
+// Held by commands that write linked project files, ie. project ref and service versions.
+const ProjectLock = "project"
+
+var (
+	// Intermediate outputs are namespaced by process id, so that concurrent commands
+	// in the same project do not overwrite each other's files.
+	RunTempDir = filepath.Join(TempDir, "run", strconv.Itoa(os.Getpid()))
+
+	ErrLocked = errors.New("Another supabase command is running in this project.")
+
+	errWouldBlock = errors.New("lock is held by another file handle")
+)
+
+// AcquireLock takes an exclusive advisory lock on a file in the temp directory, failing
+// with ErrLocked if another process holds it. The lock is released by the OS when the
+// holder exits, so locks are never left behind by crashed commands. The caller must
+// invoke the returned release func when done.
+func AcquireLock(name string, fsys afero.Fs) (func(), error) {
+	if err := MkdirIfNotExistFS(fsys, TempDir); err != nil {
+		return nil, err
+	}
+	lockPath := filepath.Join(TempDir, name+".lock")
+	f, err := fsys.OpenFile(lockPath, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, errors.Errorf("failed to open lock file: %w", err)
+	}
+	unlock, err := lockFile(f, fsys)
+	if errors.Is(err, errWouldBlock) {
+		owner, _ := io.ReadAll(f)
+		f.Close()
+		CmdSuggestion = "Wait for the other command to finish before trying again."
+		if pid, err := strconv.Atoi(strings.TrimSpace(string(owner))); err == nil && pid > 0 {
+			return nil, errors.Errorf("%w Lock is held by process %d.", ErrLocked, pid)
+		}
+		return nil, errors.New(ErrLocked)
+	} else if err != nil {
+		f.Close()
+		return nil, errors.Errorf("failed to lock file: %w", err)
+	}
+	release := func() {
+		if err := unlock(); err != nil {
+			fmt.Fprintln(GetDebugLogger(), "Failed to release lock:", err)
+		}
+		if err := f.Close(); err != nil {
+			fmt.Fprintln(GetDebugLogger(), err)
+		}
+	}
+	// Owner is informational only, so the lock file is never removed while others may open it
+	if err := f.Truncate(0); err != nil {
+		release()
+		return nil, errors.Errorf("failed to write lock file: %w", err)
+	}
+	if _, err := f.WriteAt([]byte(strconv.Itoa(os.Getpid())), 0); err != nil {
+		release()
+		return nil, errors.Errorf("failed to write lock file: %w", err)
+	}
+	return release, nil
+}
+
+type fdFile interface {
+	Fd() uintptr
+}
+
+var (
+	// Files on non-OS filesystems, such as in tests, are only locked within this process
+	memLocks   = map[memLockKey]struct{}{}
+	memLocksMu sync.Mutex
+)
+
+type memLockKey struct {
+	fsys afero.Fs
+	path string
+}
+
+func lockFile(f afero.File, fsys afero.Fs) (func() error, error) {
+	if osFile, ok := f.(fdFile); ok {
+		if err := lockFd(osFile.Fd()); err != nil {
+			return nil, err
+		}
+		return func() error { return unlockFd(osFile.Fd()) }, nil
+	}
+	key := memLockKey{fsys: fsys, path: f.Name()}
+	memLocksMu.Lock()
+	defer memLocksMu.Unlock()
+	if _, ok := memLocks[key]; ok {
+		return nil, errWouldBlock
+	}
+	memLocks[key] = struct{}{}
+	return func() error {
+		memLocksMu.Lock()
+		defer memLocksMu.Unlock()
+		delete(memLocks, key)
+		return nil
+	}, nil
+}
+
+// RemoveRunTempDir deletes the temp outputs of this process, along with those left
+// behind by processes that were killed before they could clean up.
+func RemoveRunTempDir(fsys afero.Fs) error {
+	if err := fsys.RemoveAll(RunTempDir); err != nil {
+		return errors.Errorf("failed to remove temp dir: %w", err)
+	}
+	runDir := filepath.Dir(RunTempDir)
+	entries, err := afero.ReadDir(fsys, runDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return errors.Errorf("failed to read temp dir: %w", err)
+	}
+	remaining := len(entries)
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil || isProcessRunning(pid) {
+			continue
+		}
+		if err := fsys.RemoveAll(filepath.Join(runDir, e.Name())); err != nil {
+			return errors.Errorf("failed to remove stale temp dir: %w", err)
+		}
+		remaining--
+	}
+	if remaining == 0 {
+		if err := fsys.Remove(runDir); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return errors.Errorf("failed to remove temp dir: %w", err)
+		}
+	}
+	return nil
+}
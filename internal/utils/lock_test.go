@@ -0,0 +1,132 @@
+package utils
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcquireLock(t *testing.T) {
+	lockPath := filepath.Join(TempDir, "test.lock")
+
+	t.Run("acquires and releases lock", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		release, err := AcquireLock("test", fsys)
+		// Check error
+		assert.NoError(t, err)
+		owner, err := afero.ReadFile(fsys, lockPath)
+		assert.NoError(t, err)
+		assert.Equal(t, strconv.Itoa(os.Getpid()), string(owner))
+		release()
+		release, err = AcquireLock("test", fsys)
+		assert.NoError(t, err)
+		release()
+	})
+
+	t.Run("ignores lock file left by exited process", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, lockPath, []byte(strconv.Itoa(math.MaxInt32)), 0644))
+		// Run test
+		release, err := AcquireLock("test", fsys)
+		// Check error
+		assert.NoError(t, err)
+		defer release()
+		owner, err := afero.ReadFile(fsys, lockPath)
+		assert.NoError(t, err)
+		assert.Equal(t, strconv.Itoa(os.Getpid()), string(owner))
+	})
+
+	t.Run("throws error when held by another handle", func(t *testing.T) {
+		defer func() { CmdSuggestion = "" }()
+		// Setup os fs
+		fsys := afero.NewOsFs()
+		cwd, err := os.Getwd()
+		require.NoError(t, err)
+		require.NoError(t, os.Chdir(t.TempDir()))
+		defer func() { require.NoError(t, os.Chdir(cwd)) }()
+		release, err := AcquireLock("test", fsys)
+		require.NoError(t, err)
+		defer release()
+		// Run test
+		_, err = AcquireLock("test", fsys)
+		// Check error
+		assert.ErrorIs(t, err, ErrLocked)
+		assert.ErrorContains(t, err, strconv.Itoa(os.Getpid()))
+		assert.NotEmpty(t, CmdSuggestion)
+	})
+
+	t.Run("throws error when held in memory", func(t *testing.T) {
+		defer func() { CmdSuggestion = "" }()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		release, err := AcquireLock("test", fsys)
+		require.NoError(t, err)
+		defer release()
+		// Run test
+		_, err = AcquireLock("test", fsys)
+		// Check error
+		assert.ErrorIs(t, err, ErrLocked)
+	})
+
+	t.Run("throws error on permission denied", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewReadOnlyFs(afero.NewMemMapFs())
+		// Run test
+		_, err := AcquireLock("test", fsys)
+		// Check error
+		assert.ErrorIs(t, err, os.ErrPermission)
+	})
+}
+
+func TestRemoveRunTempDir(t *testing.T) {
+	runDir := filepath.Dir(RunTempDir)
+
+	t.Run("removes own and stale outputs", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		stale := filepath.Join(runDir, strconv.Itoa(math.MaxInt32))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(RunTempDir, "output.eszip"), []byte{}, 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(stale, "output.eszip"), []byte{}, 0644))
+		// Run test
+		err := RemoveRunTempDir(fsys)
+		// Check error
+		assert.NoError(t, err)
+		exists, err := afero.Exists(fsys, runDir)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("keeps outputs of running process", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		running := filepath.Join(runDir, strconv.Itoa(os.Getppid()))
+		require.NoError(t, fsys.MkdirAll(running, 0755))
+		require.NoError(t, fsys.MkdirAll(RunTempDir, 0755))
+		// Run test
+		err := RemoveRunTempDir(fsys)
+		// Check error
+		assert.NoError(t, err)
+		exists, err := afero.DirExists(fsys, running)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		exists, err = afero.DirExists(fsys, RunTempDir)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("ignores missing dir", func(t *testing.T) {
+		// Run test
+		err := RemoveRunTempDir(afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+	})
+}
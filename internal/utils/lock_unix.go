@@ -0,0 +1,29 @@
+//go:build !windows
+
+package utils
+
+import (
+	"errors"
+	"syscall"
+)
+
+func isProcessRunning(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	// Signal 0 only checks that the process exists
+	err := syscall.Kill(pid, syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+func lockFd(fd uintptr) error {
+	err := syscall.Flock(int(fd), syscall.LOCK_EX|syscall.LOCK_NB)
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		return errWouldBlock
+	}
+	return err
+}
+
+func unlockFd(fd uintptr) error {
+	return syscall.Flock(int(fd), syscall.LOCK_UN)
+}
@@ -13,3 +13,19 @@ func GetDebugLogger() io.Writer {
 	}
 	return io.Discard
 }
+
+// GetStatusWriter returns the writer for progress messages, which are silenced by --quiet.
+func GetStatusWriter() io.Writer {
+	if viper.GetBool("QUIET") {
+		return io.Discard
+	}
+	return os.Stderr
+}
+
+// GetVerboseLogger returns the writer for detailed progress, enabled by --verbose or --debug.
+func GetVerboseLogger() io.Writer {
+	if viper.GetBool("VERBOSE") || viper.GetBool("DEBUG") {
+		return os.Stderr
+	}
+	return io.Discard
+}
@@ -105,6 +105,8 @@ var (
 	UUIDPattern        = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
 	ProjectHostPattern = regexp.MustCompile(`^(db\.)([a-z]{20})\.supabase\.(co|red)$`)
 	MigrateFilePattern = regexp.MustCompile(`^([0-9]+)_(.*)\.sql$`)
+	DownFilePattern    = regexp.MustCompile(`^([0-9]+)_(.*)\.down\.sql$`)
+	DataFilePattern    = regexp.MustCompile(`^([0-9]+)_(.*)\.ts$`)
 	BranchNamePattern  = regexp.MustCompile(`[[:word:]-]+`)
 	FuncSlugPattern    = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
 	ImageNamePattern   = regexp.MustCompile(`\/(.*):`)
@@ -199,12 +201,17 @@ var (
 	PoolerVersionPath     = filepath.Join(TempDir, "pooler-version")
 	RealtimeVersionPath   = filepath.Join(TempDir, "realtime-version")
 	CliVersionPath        = filepath.Join(TempDir, "cli-latest")
+	WorkspaceIdPath       = filepath.Join(TempDir, "workspace-id")
+	PortsPath             = filepath.Join(TempDir, "ports.json")
+	EnvKeyPath            = filepath.Join(TempDir, "env-key")
+	ThirdPartyJwksPath    = filepath.Join(TempDir, "third-party-jwks.json")
 	CurrBranchPath        = filepath.Join(SupabaseDirPath, ".branches", "_current_branch")
 	SchemasDir            = filepath.Join(SupabaseDirPath, "schemas")
 	MigrationsDir         = filepath.Join(SupabaseDirPath, "migrations")
 	FunctionsDir          = filepath.Join(SupabaseDirPath, "functions")
 	FallbackImportMapPath = filepath.Join(FunctionsDir, "import_map.json")
 	FallbackEnvFilePath   = filepath.Join(FunctionsDir, ".env")
+	EncryptedEnvPath      = filepath.Join(SupabaseDirPath, ".env.encrypted")
 	DbTestsDir            = filepath.Join(SupabaseDirPath, "tests")
 	SeedDataPath          = filepath.Join(SupabaseDirPath, "seed.sql")
 	CustomRolesPath       = filepath.Join(SupabaseDirPath, "roles.sql")
@@ -213,8 +220,17 @@ var (
 	ErrInvalidRef  = errors.New("Invalid project ref format. Must be like `abcdefghijklmnopqrst`.")
 	ErrInvalidSlug = errors.New("Invalid Function name. Must start with at least one letter, and only include alphanumeric characters, underscores, and hyphens. (^[A-Za-z][A-Za-z0-9_-]*$)")
 	ErrNotRunning  = errors.Errorf("%s is not running.", Aqua("supabase start"))
+	ErrNotCached   = errors.New("Cannot pull docker image in offline mode:")
 )
 
+// Matches both SQL and data migration file names, returning the version and name.
+func MatchMigrationFile(filename string) []string {
+	if matches := MigrateFilePattern.FindStringSubmatch(filename); len(matches) > 0 {
+		return matches
+	}
+	return DataFilePattern.FindStringSubmatch(filename)
+}
+
 func GetCurrentTimestamp() string {
 	// Magic number: https://stackoverflow.com/q/45160822.
 	return time.Now().UTC().Format("20060102150405")
@@ -282,7 +298,20 @@ func ChangeWorkDir(fsys afero.Fs) error {
 		}
 	}
 	workdir := viper.GetString("WORKDIR")
-	if len(workdir) == 0 {
+	workspace := viper.GetString("WORKSPACE")
+	if len(workspace) == 0 {
+		// --app is kept as an alias of --workspace
+		workspace = viper.GetString("APP")
+	}
+	if len(workspace) > 0 {
+		if len(workdir) > 0 {
+			return errors.New("--workdir and --workspace cannot be used together.")
+		}
+		var err error
+		if workdir, err = ResolveWorkspace(workspace, fsys); err != nil {
+			return err
+		}
+	} else if len(workdir) == 0 {
 		workdir = getProjectRoot(CurrentDirAbs, fsys)
 	}
 	if err := os.Chdir(workdir); err != nil {
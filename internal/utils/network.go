@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+)
+
+const (
+	NETWORK_IPV4 = "ipv4"
+	NETWORK_IPV6 = "ipv6"
+)
+
+var (
+	// Empty value lets the resolver decide which IP family to use.
+	NetworkPreference = EnumFlag{
+		Allowed: []string{NETWORK_IPV4, NETWORK_IPV6},
+	}
+
+	ErrIPv6Unreachable = errors.New("IPv6 is not supported on your current network")
+
+	// Dialing udp sends no packets, but fails immediately if the host has no route to IPv6.
+	hasIPv6Route = func() bool {
+		conn, err := net.Dial("udp6", "[2001:4860:4860::8888]:53")
+		if err != nil {
+			return false
+		}
+		conn.Close()
+		return true
+	}
+)
+
+// Filters resolved addresses by the preferred IP family, and probes for IPv6
+// connectivity when a host only resolves to IPv6 addresses.
+func withNetworkPreference(lookup pgconn.LookupFunc) pgconn.LookupFunc {
+	return func(ctx context.Context, host string) ([]string, error) {
+		addrs, err := lookup(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		var ipv4, ipv6 []string
+		for _, a := range addrs {
+			if ip := net.ParseIP(a); ip != nil && ip.To4() == nil {
+				ipv6 = append(ipv6, a)
+			} else {
+				ipv4 = append(ipv4, a)
+			}
+		}
+		switch NetworkPreference.Value {
+		case NETWORK_IPV4:
+			if len(ipv4) == 0 {
+				return nil, errors.Errorf("%w: %s only resolves to IPv6 addresses %v", ErrIPv6Unreachable, host, addrs)
+			}
+			return ipv4, nil
+		case NETWORK_IPV6:
+			if len(ipv6) == 0 {
+				return nil, errors.Errorf("failed to locate IPv6 address for %s; resolves to %v", host, addrs)
+			}
+			return ipv6, nil
+		}
+		if len(ipv4) == 0 && !hasIPv6Route() {
+			return nil, errors.Errorf("%w: %s only resolves to IPv6 addresses %v", ErrIPv6Unreachable, host, addrs)
+		}
+		return addrs, nil
+	}
+}
+
+// Returns the alternate connection to the same project, ie. pooler for direct
+// connection and vice versa, or nil if none is available.
+func getFallbackConfig(config pgconn.Config) *pgconn.Config {
+	if ref, found := strings.CutPrefix(config.Host, "db."); found {
+		ref, _, _ = strings.Cut(ref, ".")
+		if GetSupabaseDbHost(ref) != config.Host {
+			return nil
+		}
+		pooler := GetPoolerConfig(ref)
+		if pooler != nil {
+			pooler.Password = config.Password
+			pooler.ConnectTimeout = config.ConnectTimeout
+		}
+		return pooler
+	}
+	if !isSupabaseDomain(config.Host) {
+		return nil
+	}
+	// Supavisor usernames are suffixed with project ref, ie. postgres.<ref>
+	user, ref, found := strings.Cut(config.User, ".")
+	if !found {
+		return nil
+	}
+	direct := *config.Copy()
+	direct.Host = GetSupabaseDbHost(ref)
+	direct.Port = 5432
+	direct.User = user
+	return &direct
+}
+
+// Only retry on errors that are likely caused by the network path to the host.
+func isNetworkError(err error) bool {
+	if errors.Is(err, ErrIPv6Unreachable) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func suggestNetworkFix(config pgconn.Config, err error) {
+	if errors.Is(err, ErrIPv6Unreachable) {
+		CmdSuggestion = fmt.Sprintf("Connect via the IPv4 compatible pooler by running %s to fetch its URL, or enable IPv6 on your network.", Aqua("supabase link"))
+	} else if isNetworkError(err) {
+		CmdSuggestion = fmt.Sprintf("Make sure %s is reachable from your network, or try %s to prefer a different IP family.", Bold(config.Host), Aqua("--network ipv4"))
+	}
+}
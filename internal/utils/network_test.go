@@ -0,0 +1,119 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockLookup(addrs ...string) pgconn.LookupFunc {
+	return func(ctx context.Context, host string) ([]string, error) {
+		return addrs, nil
+	}
+}
+
+func TestNetworkPreference(t *testing.T) {
+	dualStack := mockLookup("::1", "127.0.0.1")
+	ipv6Only := mockLookup("::1")
+
+	t.Run("filters ipv4 addresses", func(t *testing.T) {
+		NetworkPreference.Value = NETWORK_IPV4
+		defer func() { NetworkPreference.Value = "" }()
+		// Run test
+		addrs, err := withNetworkPreference(dualStack)(context.Background(), "localhost")
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"127.0.0.1"}, addrs)
+	})
+
+	t.Run("filters ipv6 addresses", func(t *testing.T) {
+		NetworkPreference.Value = NETWORK_IPV6
+		defer func() { NetworkPreference.Value = "" }()
+		// Run test
+		addrs, err := withNetworkPreference(dualStack)(context.Background(), "localhost")
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"::1"}, addrs)
+	})
+
+	t.Run("throws error on ipv6 only host", func(t *testing.T) {
+		NetworkPreference.Value = NETWORK_IPV4
+		defer func() { NetworkPreference.Value = "" }()
+		// Run test
+		_, err := withNetworkPreference(ipv6Only)(context.Background(), "localhost")
+		// Check error
+		assert.ErrorIs(t, err, ErrIPv6Unreachable)
+	})
+
+	t.Run("probes ipv6 route by default", func(t *testing.T) {
+		probe := hasIPv6Route
+		hasIPv6Route = func() bool { return false }
+		defer func() { hasIPv6Route = probe }()
+		// Run test
+		_, err := withNetworkPreference(ipv6Only)(context.Background(), "localhost")
+		// Check error
+		assert.ErrorIs(t, err, ErrIPv6Unreachable)
+	})
+}
+
+func TestFallbackConfig(t *testing.T) {
+	t.Run("falls back from direct to pooler", func(t *testing.T) {
+		Config.Db.Pooler.ConnectionString = PG15_POOLER_URL
+		config := pgconn.Config{
+			Host:     GetSupabaseDbHost("zupyfdrjfhbeevcogohz"),
+			Port:     5432,
+			User:     "postgres",
+			Password: "password",
+			Database: "postgres",
+		}
+		// Run test
+		fallback := getFallbackConfig(config)
+		// Check result
+		require.NotNil(t, fallback)
+		assert.Equal(t, "fly-0-sin.pooler.supabase.com", fallback.Host)
+		assert.Equal(t, "postgres.zupyfdrjfhbeevcogohz", fallback.User)
+		assert.Equal(t, config.Password, fallback.Password)
+	})
+
+	t.Run("falls back from pooler to direct", func(t *testing.T) {
+		config := pgconn.Config{
+			Host:     "fly-0-sin.pooler.supabase.com",
+			Port:     PoolerSessionPort,
+			User:     "postgres.zupyfdrjfhbeevcogohz",
+			Password: "password",
+			Database: "postgres",
+		}
+		// Run test
+		fallback := getFallbackConfig(config)
+		// Check result
+		require.NotNil(t, fallback)
+		assert.Equal(t, GetSupabaseDbHost("zupyfdrjfhbeevcogohz"), fallback.Host)
+		assert.Equal(t, "postgres", fallback.User)
+	})
+
+	t.Run("ignores self-hosted database", func(t *testing.T) {
+		assert.Nil(t, getFallbackConfig(pgconn.Config{Host: "db.example.com"}))
+	})
+}
+
+func TestConnectIPv6Unreachable(t *testing.T) {
+	t.Run("suggests pooler on ipv6 only host", func(t *testing.T) {
+		Config.Db.Pooler.ConnectionString = ""
+		DNSResolver.Value = DNS_GO_NATIVE
+		probe := hasIPv6Route
+		hasIPv6Route = func() bool { return false }
+		defer func() { hasIPv6Route = probe }()
+		CmdSuggestion = ""
+		// Run test
+		_, err := ConnectByConfig(context.Background(), dbConfig, func(cc *pgx.ConnConfig) {
+			cc.LookupFunc = mockLookup("::1")
+		})
+		// Check error
+		assert.ErrorIs(t, err, ErrIPv6Unreachable)
+		assert.Contains(t, CmdSuggestion, "supabase link")
+	})
+}
@@ -12,9 +12,12 @@ import (
 )
 
 const (
+	OutputCsv    = "csv"
 	OutputEnv    = "env"
 	OutputJson   = "json"
 	OutputPretty = "pretty"
+	OutputRaw    = "raw"
+	OutputTable  = "table"
 	OutputToml   = "toml"
 	OutputYaml   = "yaml"
 
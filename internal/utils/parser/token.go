@@ -3,6 +3,7 @@ package parser
 import (
 	"bufio"
 	"io"
+	"regexp"
 	"strings"
 	"unicode/utf8"
 
@@ -116,3 +117,17 @@ func SplitAndTrim(sql io.Reader) (stats []string, err error) {
 		return strings.TrimRight(token, ";")
 	}, strings.TrimSpace)
 }
+
+// Separates the revert script from the rest of a migration file. The marker is namespaced so
+// that existing migrations with a plain `-- down:` comment are never truncated.
+var downSectionPattern = regexp.MustCompile(`(?m)^--[ \t]*supabase:down[ \t]*\r?$`)
+
+// CutDownSection splits a migration file into the up and down scripts, which are
+// separated by a `-- supabase:down` comment on its own line.
+func CutDownSection(sql []byte) (up, down []byte, found bool) {
+	loc := downSectionPattern.FindIndex(sql)
+	if loc == nil {
+		return sql, nil, false
+	}
+	return sql[:loc[0]], sql[loc[1]:], true
+}
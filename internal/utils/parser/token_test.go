@@ -50,3 +50,35 @@ func TestSplitAndTrim(t *testing.T) {
 	assert.ErrorContains(t, err, "After statement 1: \tBEGIN;")
 	assert.ElementsMatch(t, []string{"BEGIN"}, stats)
 }
+
+func TestCutDownSection(t *testing.T) {
+	t.Run("splits down section", func(t *testing.T) {
+		sql := "create table t();\n-- supabase:down\ndrop table t;\n"
+		// Run test
+		up, down, found := CutDownSection([]byte(sql))
+		// Check error
+		assert.True(t, found)
+		assert.Equal(t, "create table t();\n", string(up))
+		assert.Equal(t, "\ndrop table t;\n", string(down))
+	})
+
+	t.Run("ignores inline comment", func(t *testing.T) {
+		sql := "create table t(); -- supabase:down drop table t;\n"
+		// Run test
+		up, down, found := CutDownSection([]byte(sql))
+		// Check error
+		assert.False(t, found)
+		assert.Equal(t, sql, string(up))
+		assert.Empty(t, down)
+	})
+
+	t.Run("ignores plain down comment", func(t *testing.T) {
+		sql := "create table t();\n-- down:\ndrop table t;\n"
+		// Run test
+		up, down, found := CutDownSection([]byte(sql))
+		// Check error
+		assert.False(t, found)
+		assert.Equal(t, sql, string(up))
+		assert.Empty(t, down)
+	})
+}
@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	composeTypes "github.com/docker/cli/cli/compose/types"
+	dockerConfig "github.com/docker/cli/cli/config"
+	"github.com/docker/docker/client"
+)
+
+// Returns the podman socket to connect to when docker is not configured on the host.
+func getPodmanHost() string {
+	// Respect user defined docker host or context
+	if len(os.Getenv(client.EnvOverrideHost)) > 0 || len(os.Getenv("DOCKER_CONTEXT")) > 0 {
+		return ""
+	}
+	if config := dockerConfig.LoadDefaultConfigFile(io.Discard); len(config.CurrentContext) > 0 && config.CurrentContext != "default" {
+		return ""
+	}
+	if len(defaultDockerSocket) == 0 {
+		return ""
+	}
+	if _, err := os.Stat(defaultDockerSocket); err == nil {
+		return ""
+	}
+	for _, sock := range podmanSockets() {
+		if _, err := os.Stat(sock); err == nil {
+			return "unix://" + sock
+		}
+	}
+	return ""
+}
+
+var (
+	isPodman   bool
+	podmanOnce sync.Once
+)
+
+// Podman exposes a docker compatible API, either on its own socket or symlinked
+// to the default docker socket by the podman-docker package.
+func IsPodman() bool {
+	podmanOnce.Do(func() {
+		parsed, err := url.Parse(Docker.DaemonHost())
+		if err != nil || parsed.Scheme != "unix" {
+			return
+		}
+		sock := parsed.Path
+		if resolved, err := filepath.EvalSymlinks(sock); err == nil {
+			sock = resolved
+		}
+		isPodman = strings.Contains(sock, "podman")
+	})
+	return isPodman
+}
+
+// Relabels host directories so they are accessible by rootless podman containers on SELinux.
+func podmanBind(bind string, spec composeTypes.ServiceVolumeConfig) string {
+	// Compose loader drops selinux options, so we check the raw bind spec instead
+	if opts := bind[strings.LastIndex(bind, ":")+1:]; bind != spec.Source+":"+spec.Target {
+		for _, o := range strings.Split(opts, ",") {
+			if o == "z" || o == "Z" {
+				return bind
+			}
+		}
+	}
+	opts := []string{"z"}
+	if spec.ReadOnly {
+		opts = append(opts, "ro")
+	}
+	if spec.Bind != nil && len(spec.Bind.Propagation) > 0 {
+		opts = append(opts, spec.Bind.Propagation)
+	}
+	return spec.Source + ":" + spec.Target + ":" + strings.Join(opts, ",")
+}
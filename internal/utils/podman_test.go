@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/docker/cli/cli/compose/loader"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPodmanBind(t *testing.T) {
+	t.Run("relabels bind mount", func(t *testing.T) {
+		bind := "/tmp/functions:/home/deno/functions"
+		spec, err := loader.ParseVolume(bind)
+		require.NoError(t, err)
+		// Check output
+		assert.Equal(t, "/tmp/functions:/home/deno/functions:z", podmanBind(bind, spec))
+	})
+
+	t.Run("preserves read only option", func(t *testing.T) {
+		bind := "/tmp/functions:/home/deno/functions:ro"
+		spec, err := loader.ParseVolume(bind)
+		require.NoError(t, err)
+		// Check output
+		assert.Equal(t, "/tmp/functions:/home/deno/functions:z,ro", podmanBind(bind, spec))
+	})
+
+	t.Run("preserves private label", func(t *testing.T) {
+		bind := "/tmp/functions:/home/deno/functions:Z"
+		spec, err := loader.ParseVolume(bind)
+		require.NoError(t, err)
+		// Check output
+		assert.Equal(t, "/tmp/functions:/home/deno/functions:Z", podmanBind(bind, spec))
+	})
+}
@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/charmbracelet/bubbles/progress"
+	"golang.org/x/term"
+)
+
+// ProgressReader renders a progress bar to the status writer as the underlying reader is consumed.
+type ProgressReader struct {
+	io.Reader
+	label   string
+	total   int64
+	read    int64
+	percent int
+	bar     progress.Model
+	w       io.Writer
+}
+
+func NewProgressReader(r io.Reader, label string, total int64) *ProgressReader {
+	var w io.Writer = io.Discard
+	// Redrawing the bar only makes sense on an interactive terminal
+	if status := GetStatusWriter(); status == os.Stderr && term.IsTerminal(int(os.Stderr.Fd())) {
+		w = status
+	}
+	return &ProgressReader{
+		Reader:  r,
+		label:   label,
+		total:   total,
+		percent: -1,
+		bar:     progress.New(progress.WithGradient("#1c1c1c", "#34b27b"), progress.WithWidth(40)),
+		w:       w,
+	}
+}
+
+func (p *ProgressReader) Read(b []byte) (int, error) {
+	n, err := p.Reader.Read(b)
+	p.read += int64(n)
+	if p.total > 0 {
+		// Only redraw when the percentage changes to avoid flooding the terminal
+		if percent := int(p.read * 100 / p.total); percent != p.percent {
+			p.percent = percent
+			fmt.Fprintf(p.w, "\r%s %s", p.label, p.bar.ViewAs(float64(p.read)/float64(p.total)))
+			if p.read >= p.total {
+				fmt.Fprintln(p.w)
+			}
+		}
+	}
+	return n, err
+}
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressReader(t *testing.T) {
+	t.Run("renders progress until complete", func(t *testing.T) {
+		var out bytes.Buffer
+		r := NewProgressReader(strings.NewReader("hello"), "Uploading", 5)
+		r.w = &out
+		// Run test
+		data, err := io.ReadAll(r)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", string(data))
+		assert.Contains(t, out.String(), "\rUploading ")
+		assert.Contains(t, out.String(), "100%\n")
+	})
+
+	t.Run("skips rendering on unknown size", func(t *testing.T) {
+		var out bytes.Buffer
+		r := NewProgressReader(strings.NewReader("hello"), "Uploading", 0)
+		r.w = &out
+		// Run test
+		data, err := io.ReadAll(r)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", string(data))
+		assert.Empty(t, out.String())
+	})
+}
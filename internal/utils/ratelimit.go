@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const maxRateLimitRetries = 5
+
+// Wraps the default transport to limit concurrent management API requests and
+// transparently retry requests rejected with 429 Too Many Requests.
+type rateLimitTransport struct {
+	once    sync.Once
+	tokens  chan struct{}
+	mu      sync.Mutex
+	resetAt time.Time
+}
+
+var apiTransport = &rateLimitTransport{}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.once.Do(func() {
+		if limit := viper.GetInt("max-api-concurrency"); limit > 0 {
+			t.tokens = make(chan struct{}, limit)
+		}
+	})
+	if t.tokens != nil {
+		select {
+		case t.tokens <- struct{}{}:
+			defer func() { <-t.tokens }()
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+	for i := 0; ; i++ {
+		if err := t.waitForReset(req); err != nil {
+			return nil, err
+		}
+		resp, err := http.DefaultTransport.RoundTrip(req)
+		if err != nil {
+			return resp, err
+		}
+		wait := t.updateLimit(resp)
+		if resp.StatusCode != http.StatusTooManyRequests || i >= maxRateLimitRetries {
+			return resp, nil
+		}
+		// Only retry if the request body can be replayed
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, nil
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return resp, nil
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+		resp.Body.Close()
+		if wait <= 0 {
+			wait = time.Duration(1<<i) * timeUnit
+			t.mu.Lock()
+			t.resetAt = time.Now().Add(wait)
+			t.mu.Unlock()
+		}
+		fmt.Fprintf(os.Stderr, "Rate limited by API, retrying after %v: %s %s\n", wait.Round(time.Second), req.Method, req.URL.Path)
+	}
+}
+
+// Blocks all queued requests until the current rate limit window resets.
+func (t *rateLimitTransport) waitForReset(req *http.Request) error {
+	t.mu.Lock()
+	wait := time.Until(t.resetAt)
+	t.mu.Unlock()
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
+
+// Records the reset window from rate limit headers, returning the time to wait if
+// no more requests are allowed in the current window.
+func (t *rateLimitTransport) updateLimit(resp *http.Response) time.Duration {
+	wait := parseRetryAfter(resp.Header)
+	if wait <= 0 && (resp.StatusCode == http.StatusTooManyRequests || resp.Header.Get("X-RateLimit-Remaining") == "0") {
+		wait = parseRateLimitReset(resp.Header)
+	}
+	if wait <= 0 {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if resetAt := time.Now().Add(wait); resetAt.After(t.resetAt) {
+		t.resetAt = resetAt
+	}
+	return wait
+}
+
+// Parses Retry-After header in either delay seconds or http date format.
+func parseRetryAfter(header http.Header) time.Duration {
+	value := header.Get("Retry-After")
+	if len(value) == 0 {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+	return 0
+}
+
+// Parses X-RateLimit-Reset header, which may be a unix timestamp or seconds until reset.
+func parseRateLimitReset(header http.Header) time.Duration {
+	value, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil || value <= 0 {
+		return 0
+	}
+	// Timestamps are much larger than any reasonable window in seconds
+	if value > 1e9 {
+		return time.Until(time.Unix(value, 0))
+	}
+	return time.Duration(value) * time.Second
+}
@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+)
+
+func TestRateLimitTransport(t *testing.T) {
+	timeUnit = time.Duration(0)
+	t.Cleanup(func() { timeUnit = time.Second })
+
+	t.Run("retries on too many requests", func(t *testing.T) {
+		client := http.Client{Transport: &rateLimitTransport{}}
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(DefaultApiHost).
+			Get("/v1/projects").
+			Times(2).
+			Reply(http.StatusTooManyRequests)
+		gock.New(DefaultApiHost).
+			Get("/v1/projects").
+			Reply(http.StatusOK).
+			JSON([]string{})
+		// Run test
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, DefaultApiHost+"/v1/projects", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		client := http.Client{Transport: &rateLimitTransport{}}
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(DefaultApiHost).
+			Get("/v1/projects").
+			Times(maxRateLimitRetries + 1).
+			Reply(http.StatusTooManyRequests)
+		// Run test
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, DefaultApiHost+"/v1/projects", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestParseRateLimitHeaders(t *testing.T) {
+	t.Run("parses retry after seconds", func(t *testing.T) {
+		header := http.Header{"Retry-After": []string{"3"}}
+		assert.Equal(t, 3*time.Second, parseRetryAfter(header))
+	})
+
+	t.Run("parses reset seconds", func(t *testing.T) {
+		header := http.Header{"X-Ratelimit-Reset": []string{"60"}}
+		assert.Equal(t, time.Minute, parseRateLimitReset(header))
+	})
+
+	t.Run("parses reset timestamp", func(t *testing.T) {
+		reset := time.Now().Add(time.Hour).Unix()
+		header := http.Header{"X-Ratelimit-Reset": []string{strconv.FormatInt(reset, 10)}}
+		assert.InDelta(t, time.Hour.Seconds(), parseRateLimitReset(header).Seconds(), 2)
+	})
+
+	t.Run("ignores invalid headers", func(t *testing.T) {
+		header := http.Header{"Retry-After": []string{"soon"}}
+		assert.Zero(t, parseRetryAfter(header))
+		assert.Zero(t, parseRateLimitReset(header))
+	})
+}
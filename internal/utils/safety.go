@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+)
+
+var ErrProtectedRef = errors.New("Project ref does not match the protected project.")
+
+// ConfirmProtectedRef asks the user to type the project ref before running a destructive
+// command against a project listed under [safety] protected_refs. Passing force skips the
+// prompt, ie. with --i-know-what-im-doing.
+func ConfirmProtectedRef(ctx context.Context, projectRef, action string, force bool, fsys afero.Fs) error {
+	if protected, err := IsProtectedRef(projectRef, fsys); err != nil || !protected {
+		return err
+	}
+	if force {
+		fmt.Fprintln(os.Stderr, Yellow("WARNING:"), "Skipped confirmation to "+action+" on protected project "+Aqua(projectRef)+".")
+		return nil
+	}
+	fmt.Fprintln(os.Stderr, Red("WARNING:"), "You are about to "+action+" on protected project "+Aqua(projectRef)+".")
+	input, err := NewConsole().PromptText(ctx, "Type the project ref to confirm: ")
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(input) != projectRef {
+		CmdSuggestion = fmt.Sprintf("Pass %s to skip confirmation in non-interactive environments.", Aqua("--i-know-what-im-doing"))
+		return errors.New(ErrProtectedRef)
+	}
+	return nil
+}
+
+// IsProtectedRef checks if the project ref is listed under [safety] protected_refs.
+func IsProtectedRef(projectRef string, fsys afero.Fs) (bool, error) {
+	// Commands against remote projects may run outside of a local project directory
+	if exists, err := afero.Exists(fsys, ConfigPath); err != nil {
+		return false, errors.Errorf("failed to check config file: %w", err)
+	} else if exists {
+		if err := LoadConfigFS(fsys); err != nil {
+			return false, err
+		}
+	}
+	return SliceContains(Config.Safety.ProtectedRefs, projectRef), nil
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/fstest"
+)
+
+func TestConfirmProtectedRef(t *testing.T) {
+	const projectRef = "abcdefghijklmnopqrst"
+
+	Config.Safety.ProtectedRefs = []string{projectRef}
+	t.Cleanup(func() { Config.Safety.ProtectedRefs = nil })
+
+	t.Run("confirms matching project ref", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		defer fstest.MockStdin(t, projectRef)()
+		// Run test
+		err := ConfirmProtectedRef(context.Background(), projectRef, "delete functions", false, fsys)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("skips confirmation with force", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := ConfirmProtectedRef(context.Background(), projectRef, "delete functions", true, fsys)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("skips unprotected project", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := ConfirmProtectedRef(context.Background(), "tsrqponmlkjihgfedcba", "delete functions", false, fsys)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on mismatched project ref", func(t *testing.T) {
+		defer func() { CmdSuggestion = "" }()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		defer fstest.MockStdin(t, "y")()
+		// Run test
+		err := ConfirmProtectedRef(context.Background(), projectRef, "delete functions", false, fsys)
+		// Check error
+		assert.ErrorIs(t, err, ErrProtectedRef)
+		assert.Contains(t, CmdSuggestion, "--i-know-what-im-doing")
+	})
+}
@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+)
+
+// Directory where pnpm stores the packages linked from each node_modules
+const pnpmStoreDir = "node_modules/.pnpm"
+
+// BindSymlinks returns the bind mounts for symlinks under the functions directory that
+// point outside of it, such as `_shared -> ../../packages/shared` in a monorepo. Each
+// target is mounted where the link resolves inside the container, so relative links
+// work as they do on host. Copied sources already follow symlinks, so nothing is bound
+// with the copy mount strategy.
+func BindSymlinks(hostFuncDir string) ([]string, error) {
+	if GetMountStrategy() == MountStrategyCopy {
+		return nil, nil
+	}
+	mounts := map[string]string{}
+	if err := bindSymlinks(ResolveBindSource(hostFuncDir), ToDockerPath(hostFuncDir), ToDockerPath(hostFuncDir), mounts); err != nil {
+		return nil, err
+	}
+	dockerPaths := make([]string, 0, len(mounts))
+	for dockerPath := range mounts {
+		dockerPaths = append(dockerPaths, dockerPath)
+	}
+	sort.Strings(dockerPaths)
+	binds := make([]string, len(dockerPaths))
+	for i, dockerPath := range dockerPaths {
+		binds[i] = mounts[dockerPath] + ":" + dockerPath + ":ro"
+	}
+	return binds, nil
+}
+
+func bindSymlinks(hostDir, dockerDir, dockerFuncDir string, mounts map[string]string) error {
+	logger := GetDebugLogger()
+	return filepath.WalkDir(hostDir, func(hostPath string, d fs.DirEntry, err error) error {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return errors.Errorf("failed to walk %s: %w", hostPath, err)
+		}
+		if d.Type()&fs.ModeSymlink == 0 {
+			return nil
+		}
+		link, err := os.Readlink(hostPath)
+		if err != nil {
+			return errors.Errorf("failed to read symlink: %w", err)
+		}
+		resolved, err := filepath.EvalSymlinks(hostPath)
+		if err != nil {
+			// Dangling links fail the same way in container
+			fmt.Fprintln(logger, err)
+			return nil
+		}
+		rel, err := filepath.Rel(hostDir, hostPath)
+		if err != nil {
+			return errors.Errorf("failed to resolve relative path: %w", err)
+		}
+		dockerPath := ToDockerPath(link)
+		if !filepath.IsAbs(link) {
+			dockerLink := path.Join(dockerDir, filepath.ToSlash(rel))
+			dockerPath = path.Join(path.Dir(dockerLink), filepath.ToSlash(link))
+		}
+		// Targets inside mounted directories are already visible in container
+		if isSubPath(dockerPath, dockerFuncDir) || isSubPath(dockerPath, dockerDir) {
+			return nil
+		}
+		// Packages linked by pnpm depend on each other through the same store
+		hostStore, dockerStore := pnpmStore(resolved), pnpmStore(dockerPath)
+		if len(hostStore) > 0 && len(dockerStore) > 0 {
+			mounts[dockerStore] = hostStore
+			return nil
+		}
+		if _, ok := mounts[dockerPath]; ok {
+			return nil
+		}
+		mounts[dockerPath] = resolved
+		if info, err := os.Stat(resolved); err == nil && info.IsDir() {
+			// Shared packages may link to their own dependencies
+			return bindSymlinks(resolved, dockerPath, dockerFuncDir, mounts)
+		}
+		return nil
+	})
+}
+
+func isSubPath(p, dir string) bool {
+	return p == dir || strings.HasPrefix(p, dir+"/")
+}
+
+func pnpmStore(p string) string {
+	slashed := filepath.ToSlash(p)
+	if i := strings.Index(slashed, "/"+pnpmStoreDir+"/"); i >= 0 {
+		return p[:i+len(pnpmStoreDir)+1]
+	}
+	return ""
+}
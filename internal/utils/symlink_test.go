@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindSymlinks(t *testing.T) {
+	t.Run("binds shared directory outside functions", func(t *testing.T) {
+		root, err := filepath.EvalSymlinks(t.TempDir())
+		require.NoError(t, err)
+		funcDir := filepath.Join(root, "supabase", "functions")
+		shared := filepath.Join(root, "packages", "shared")
+		require.NoError(t, os.MkdirAll(funcDir, 0755))
+		require.NoError(t, os.MkdirAll(shared, 0755))
+		require.NoError(t, os.Symlink("../../packages/shared", filepath.Join(funcDir, "_shared")))
+		// Run test
+		binds, err := BindSymlinks(funcDir)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{shared + ":" + shared + ":ro"}, binds)
+	})
+
+	t.Run("binds pnpm store once", func(t *testing.T) {
+		root, err := filepath.EvalSymlinks(t.TempDir())
+		require.NoError(t, err)
+		funcDir := filepath.Join(root, "supabase", "functions")
+		modules := filepath.Join(funcDir, "hello", "node_modules")
+		store := filepath.Join(root, "node_modules", ".pnpm")
+		require.NoError(t, os.MkdirAll(modules, 0755))
+		for _, pkg := range []string{"a@1.0.0/node_modules/a", "b@2.0.0/node_modules/b"} {
+			require.NoError(t, os.MkdirAll(filepath.Join(store, pkg), 0755))
+		}
+		require.NoError(t, os.Symlink("../../../../node_modules/.pnpm/a@1.0.0/node_modules/a", filepath.Join(modules, "a")))
+		require.NoError(t, os.Symlink("../../../../node_modules/.pnpm/b@2.0.0/node_modules/b", filepath.Join(modules, "b")))
+		// Run test
+		binds, err := BindSymlinks(funcDir)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{store + ":" + store + ":ro"}, binds)
+	})
+
+	t.Run("follows nested symlinks", func(t *testing.T) {
+		root, err := filepath.EvalSymlinks(t.TempDir())
+		require.NoError(t, err)
+		funcDir := filepath.Join(root, "supabase", "functions")
+		shared := filepath.Join(root, "packages", "shared")
+		utils := filepath.Join(root, "packages", "utils")
+		require.NoError(t, os.MkdirAll(funcDir, 0755))
+		require.NoError(t, os.MkdirAll(shared, 0755))
+		require.NoError(t, os.MkdirAll(utils, 0755))
+		require.NoError(t, os.Symlink("../../packages/shared", filepath.Join(funcDir, "_shared")))
+		require.NoError(t, os.Symlink("../utils", filepath.Join(shared, "utils")))
+		require.NoError(t, os.Symlink("../../packages/shared", filepath.Join(utils, "shared")))
+		// Run test
+		binds, err := BindSymlinks(funcDir)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			shared + ":" + shared + ":ro",
+			utils + ":" + utils + ":ro",
+		}, binds)
+	})
+
+	t.Run("skips links within functions", func(t *testing.T) {
+		root, err := filepath.EvalSymlinks(t.TempDir())
+		require.NoError(t, err)
+		funcDir := filepath.Join(root, "functions")
+		require.NoError(t, os.MkdirAll(filepath.Join(funcDir, "hello"), 0755))
+		require.NoError(t, os.Symlink("hello", filepath.Join(funcDir, "alias")))
+		require.NoError(t, os.Symlink("missing", filepath.Join(funcDir, "dangling")))
+		// Run test
+		binds, err := BindSymlinks(funcDir)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, binds)
+	})
+
+	t.Run("ignores missing functions directory", func(t *testing.T) {
+		// Run test
+		binds, err := BindSymlinks(filepath.Join(t.TempDir(), "missing"))
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, binds)
+	})
+
+	t.Run("skips bind with copy strategy", func(t *testing.T) {
+		viper.Set("MOUNT_STRATEGY", MountStrategyCopy)
+		t.Cleanup(func() { viper.Set("MOUNT_STRATEGY", "") })
+		// Run test
+		binds, err := BindSymlinks(t.TempDir())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, binds)
+	})
+}
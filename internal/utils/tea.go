@@ -12,12 +12,14 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/muesli/reflow/wrap"
+	"github.com/spf13/viper"
 	"golang.org/x/term"
 )
 
 func NewProgram(model tea.Model, opts ...tea.ProgramOption) Program {
 	var p Program
-	if term.IsTerminal(int(os.Stdin.Fd())) {
+	// Quiet mode skips the spinner and discards status messages
+	if term.IsTerminal(int(os.Stdin.Fd())) && !viper.GetBool("QUIET") {
 		p = tea.NewProgram(model, opts...)
 	} else {
 		p = newFakeProgram(model)
@@ -57,10 +59,10 @@ func (p *fakeProgram) Start() error {
 func (p *fakeProgram) Send(msg tea.Msg) {
 	switch msg := msg.(type) {
 	case StatusMsg:
-		fmt.Println(msg)
+		fmt.Fprintln(GetStatusWriter(), msg)
 	case PsqlMsg:
 		if msg != nil {
-			fmt.Println(*msg)
+			fmt.Fprintln(GetStatusWriter(), *msg)
 		}
 	}
 
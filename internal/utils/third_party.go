@@ -0,0 +1,156 @@
+package utils
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/pkg/fetcher"
+)
+
+const firebaseJwksUrl = "https://www.googleapis.com/service_accounts/v1/jwk/securetoken@system.gserviceaccount.com"
+
+func (t thirdParty) validate() error {
+	enabled := 0
+	if t.Firebase.Enabled {
+		enabled++
+		if len(t.Firebase.ProjectID) == 0 {
+			return errors.New("Missing required field in config: auth.third_party.firebase.project_id")
+		}
+	}
+	if t.Auth0.Enabled {
+		enabled++
+		if len(t.Auth0.Tenant) == 0 {
+			return errors.New("Missing required field in config: auth.third_party.auth0.tenant")
+		}
+	}
+	if t.Cognito.Enabled {
+		enabled++
+		if len(t.Cognito.UserPoolID) == 0 {
+			return errors.New("Missing required field in config: auth.third_party.aws_cognito.user_pool_id")
+		}
+		if len(t.Cognito.UserPoolRegion) == 0 {
+			return errors.New("Missing required field in config: auth.third_party.aws_cognito.user_pool_region")
+		}
+	}
+	if enabled > 1 {
+		return errors.New("Invalid config: only one third_party provider can be enabled under [auth.third_party]")
+	}
+	return nil
+}
+
+// IssuerURL returns the OIDC issuer of the enabled third-party auth provider, or an
+// empty string if none is enabled.
+func (t thirdParty) IssuerURL() string {
+	if t.Firebase.Enabled {
+		return "https://securetoken.google.com/" + t.Firebase.ProjectID
+	}
+	if t.Auth0.Enabled {
+		if len(t.Auth0.TenantRegion) > 0 {
+			return fmt.Sprintf("https://%s.%s.auth0.com", t.Auth0.Tenant, t.Auth0.TenantRegion)
+		}
+		return fmt.Sprintf("https://%s.auth0.com", t.Auth0.Tenant)
+	}
+	if t.Cognito.Enabled {
+		return fmt.Sprintf("https://cognito-idp.%s.amazonaws.com/%s", t.Cognito.UserPoolRegion, t.Cognito.UserPoolID)
+	}
+	return ""
+}
+
+// JwksURL returns the url of the public keys used to verify JWTs issued by the enabled
+// third-party auth provider.
+func (t thirdParty) JwksURL() string {
+	if t.Firebase.Enabled {
+		// Firebase does not serve its keys from the issuer's well-known path
+		return firebaseJwksUrl
+	}
+	if issuer := t.IssuerURL(); len(issuer) > 0 {
+		return issuer + "/.well-known/jwks.json"
+	}
+	return ""
+}
+
+type jwks struct {
+	Keys []json.RawMessage `json:"keys"`
+}
+
+// Public keys of a third-party auth provider saved across restarts of the local stack.
+type cachedJwks struct {
+	Url       string    `json:"url"`
+	FetchedAt time.Time `json:"fetched_at"`
+	jwks
+}
+
+// Providers rotate their signing keys every few hours at most.
+const jwksCacheTTL = time.Hour
+
+// ResolveJWKS returns the JWT secret of local services. When a third-party auth provider is
+// enabled, its public keys are combined with the local JWT secret so that local services
+// accept tokens signed by either. Otherwise, the local JWT secret is returned as is.
+func ResolveJWKS(ctx context.Context, fsys afero.Fs) (string, error) {
+	jwksUrl := Config.Auth.ThirdParty.JwksURL()
+	if len(jwksUrl) == 0 {
+		return Config.Auth.JwtSecret, nil
+	}
+	result, err := loadProviderJwks(ctx, jwksUrl, fsys)
+	if err != nil {
+		return "", err
+	}
+	secret, err := json.Marshal(map[string]string{
+		"kty": "oct",
+		"k":   base64.RawURLEncoding.EncodeToString([]byte(Config.Auth.JwtSecret)),
+	})
+	if err != nil {
+		return "", errors.Errorf("failed to encode jwt secret: %w", err)
+	}
+	result.Keys = append(result.Keys, secret)
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", errors.Errorf("failed to encode jwks: %w", err)
+	}
+	return string(data), nil
+}
+
+// Reuses recently fetched keys, falling back to stale keys if the provider is unreachable.
+func loadProviderJwks(ctx context.Context, jwksUrl string, fsys afero.Fs) (jwks, error) {
+	var cached cachedJwks
+	if data, err := afero.ReadFile(fsys, ThirdPartyJwksPath); err == nil {
+		if err := json.Unmarshal(data, &cached); err != nil || cached.Url != jwksUrl {
+			cached = cachedJwks{}
+		}
+	}
+	if len(cached.Url) > 0 && time.Since(cached.FetchedAt) < jwksCacheTTL {
+		return cached.jwks, nil
+	}
+	result, err := fetchJwks(ctx, jwksUrl)
+	if err != nil {
+		if len(cached.Url) == 0 {
+			return result, err
+		}
+		fmt.Fprintln(os.Stderr, Yellow("WARNING:"), "Using cached third-party auth keys:", err)
+		return cached.jwks, nil
+	}
+	cached = cachedJwks{Url: jwksUrl, FetchedAt: time.Now().UTC(), jwks: result}
+	if data, err := json.Marshal(cached); err == nil {
+		if err := WriteFile(ThirdPartyJwksPath, data, fsys); err != nil {
+			fmt.Fprintln(GetDebugLogger(), err)
+		}
+	}
+	return result, nil
+}
+
+func fetchJwks(ctx context.Context, jwksUrl string) (jwks, error) {
+	resp, err := fetcher.NewFetcher(jwksUrl, fetcher.WithExpectedStatus(http.StatusOK)).
+		Send(ctx, http.MethodGet, "", nil)
+	if err != nil {
+		return jwks{}, errors.Errorf("failed to fetch third-party auth jwks: %w", err)
+	}
+	defer resp.Body.Close()
+	return fetcher.ParseJSON[jwks](resp.Body)
+}
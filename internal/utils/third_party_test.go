@@ -0,0 +1,131 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThirdPartyIssuer(t *testing.T) {
+	t.Run("resolves firebase issuer", func(t *testing.T) {
+		tpa := thirdParty{Firebase: tpaFirebase{Enabled: true, ProjectID: "my-project"}}
+		assert.NoError(t, tpa.validate())
+		assert.Equal(t, "https://securetoken.google.com/my-project", tpa.IssuerURL())
+		assert.Equal(t, firebaseJwksUrl, tpa.JwksURL())
+	})
+
+	t.Run("resolves auth0 issuer with region", func(t *testing.T) {
+		tpa := thirdParty{Auth0: tpaAuth0{Enabled: true, Tenant: "acme", TenantRegion: "eu"}}
+		assert.NoError(t, tpa.validate())
+		assert.Equal(t, "https://acme.eu.auth0.com", tpa.IssuerURL())
+		assert.Equal(t, "https://acme.eu.auth0.com/.well-known/jwks.json", tpa.JwksURL())
+	})
+
+	t.Run("resolves cognito issuer", func(t *testing.T) {
+		tpa := thirdParty{Cognito: tpaCognito{Enabled: true, UserPoolID: "pool", UserPoolRegion: "us-east-1"}}
+		assert.NoError(t, tpa.validate())
+		assert.Equal(t, "https://cognito-idp.us-east-1.amazonaws.com/pool", tpa.IssuerURL())
+	})
+
+	t.Run("ignores disabled providers", func(t *testing.T) {
+		tpa := thirdParty{Firebase: tpaFirebase{ProjectID: "my-project"}}
+		assert.NoError(t, tpa.validate())
+		assert.Empty(t, tpa.IssuerURL())
+		assert.Empty(t, tpa.JwksURL())
+	})
+
+	t.Run("throws error on missing field", func(t *testing.T) {
+		tpa := thirdParty{Cognito: tpaCognito{Enabled: true, UserPoolID: "pool"}}
+		assert.ErrorContains(t, tpa.validate(), "auth.third_party.aws_cognito.user_pool_region")
+	})
+
+	t.Run("throws error on multiple providers", func(t *testing.T) {
+		tpa := thirdParty{
+			Firebase: tpaFirebase{Enabled: true, ProjectID: "my-project"},
+			Auth0:    tpaAuth0{Enabled: true, Tenant: "acme"},
+		}
+		assert.ErrorContains(t, tpa.validate(), "only one third_party provider can be enabled")
+	})
+}
+
+func TestResolveJWKS(t *testing.T) {
+	teardown := func() {
+		Config.Auth.JwtSecret = ""
+		Config.Auth.ThirdParty = thirdParty{}
+	}
+
+	t.Run("uses plain jwt secret without provider", func(t *testing.T) {
+		defer teardown()
+		Config.Auth.JwtSecret = "secret"
+		// Run test
+		jwks, err := ResolveJWKS(context.Background(), afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "secret", jwks)
+	})
+
+	t.Run("merges third-party keys", func(t *testing.T) {
+		defer teardown()
+		Config.Auth.JwtSecret = "secret"
+		Config.Auth.ThirdParty.Auth0 = tpaAuth0{Enabled: true, Tenant: "acme"}
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock server
+		defer gock.OffAll()
+		gock.New("https://acme.auth0.com").
+			Get("/.well-known/jwks.json").
+			Reply(http.StatusOK).
+			JSON(map[string]any{"keys": []map[string]string{{"kty": "RSA", "kid": "abc"}}})
+		// Run test
+		keys, err := ResolveJWKS(context.Background(), fsys)
+		// Check error
+		assert.NoError(t, err)
+		var result jwks
+		require.NoError(t, json.Unmarshal([]byte(keys), &result))
+		assert.Len(t, result.Keys, 2)
+		assert.JSONEq(t, `{"kty":"RSA","kid":"abc"}`, string(result.Keys[0]))
+		assert.JSONEq(t, `{"kty":"oct","k":"c2VjcmV0"}`, string(result.Keys[1]))
+		assert.Empty(t, gock.Pending())
+		// Reuses cached keys on next start
+		cached, err := ResolveJWKS(context.Background(), fsys)
+		assert.NoError(t, err)
+		assert.Equal(t, keys, cached)
+	})
+
+	t.Run("falls back to stale keys", func(t *testing.T) {
+		defer teardown()
+		Config.Auth.ThirdParty.Firebase = tpaFirebase{Enabled: true, ProjectID: "my-project"}
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		stale := []byte(`{"url":"` + firebaseJwksUrl + `","fetched_at":"2024-01-01T00:00:00Z","keys":[{"kid":"old"}]}`)
+		require.NoError(t, afero.WriteFile(fsys, ThirdPartyJwksPath, stale, 0644))
+		// Setup mock server
+		defer gock.OffAll()
+		gock.New(firebaseJwksUrl).
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		keys, err := ResolveJWKS(context.Background(), fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Contains(t, keys, `{"kid":"old"}`)
+	})
+
+	t.Run("throws error on unavailable provider", func(t *testing.T) {
+		defer teardown()
+		Config.Auth.ThirdParty.Firebase = tpaFirebase{Enabled: true, ProjectID: "my-project"}
+		// Setup mock server
+		defer gock.OffAll()
+		gock.New(firebaseJwksUrl).
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		_, err := ResolveJWKS(context.Background(), afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "failed to fetch third-party auth jwks: Error status 503")
+	})
+}
@@ -0,0 +1,231 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+)
+
+const (
+	TraceFormatChrome = "chrome"
+	TraceFormatOtel   = "otel"
+)
+
+var TraceFormat = EnumFlag{
+	Allowed: []string{TraceFormatChrome, TraceFormatOtel},
+	Value:   TraceFormatChrome,
+}
+
+type Span struct {
+	Name     string
+	Category string
+	Start    time.Time
+	End      time.Time
+	Attrs    map[string]string
+	Err      error
+}
+
+type tracer struct {
+	mu    sync.Mutex
+	spans []*Span
+	epoch time.Time
+}
+
+// Spans are only recorded after EnableTrace is called, ie. when --trace flag is set.
+var globalTracer *tracer
+
+func EnableTrace() {
+	globalTracer = &tracer{epoch: time.Now()}
+	http.DefaultTransport = &tracingTransport{base: http.DefaultTransport}
+}
+
+// Starts a new span for an internal step. Returns nil when tracing is disabled,
+// which is safe to call Finish on.
+func StartSpan(category, name string) *Span {
+	if globalTracer == nil {
+		return nil
+	}
+	return &Span{
+		Name:     name,
+		Category: category,
+		Start:    time.Now(),
+		Attrs:    map[string]string{},
+	}
+}
+
+func (s *Span) SetAttr(key, value string) {
+	if s != nil {
+		s.Attrs[key] = value
+	}
+}
+
+func (s *Span) Finish(err error) {
+	if s == nil || globalTracer == nil {
+		return
+	}
+	s.End = time.Now()
+	s.Err = err
+	globalTracer.mu.Lock()
+	defer globalTracer.mu.Unlock()
+	globalTracer.spans = append(globalTracer.spans, s)
+}
+
+type tracingTransport struct {
+	base http.RoundTripper
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	span := StartSpan("http", req.Method+" "+req.URL.Path)
+	span.SetAttr("http.host", req.URL.Host)
+	resp, err := t.base.RoundTrip(req)
+	if resp != nil {
+		span.SetAttr("http.status_code", strconv.Itoa(resp.StatusCode))
+	}
+	span.Finish(err)
+	return resp, err
+}
+
+// Returns the underlying transport if it has been wrapped for tracing.
+func unwrapTransport(rt http.RoundTripper) http.RoundTripper {
+	if t, ok := rt.(*tracingTransport); ok {
+		return t.base
+	}
+	return rt
+}
+
+func WriteTrace(path, format string, fsys afero.Fs) error {
+	if globalTracer == nil {
+		return nil
+	}
+	globalTracer.mu.Lock()
+	defer globalTracer.mu.Unlock()
+	var value any
+	switch format {
+	case TraceFormatOtel:
+		value = globalTracer.toOtel()
+	default:
+		value = globalTracer.toChrome()
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(value); err != nil {
+		return errors.Errorf("failed to encode trace: %w", err)
+	}
+	if err := WriteFile(path, buf.Bytes(), fsys); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Written trace to "+Bold(path))
+	return nil
+}
+
+type chromeEvent struct {
+	Name     string            `json:"name"`
+	Category string            `json:"cat"`
+	Phase    string            `json:"ph"`
+	Ts       int64             `json:"ts"`
+	Dur      int64             `json:"dur"`
+	Pid      int               `json:"pid"`
+	Tid      int               `json:"tid"`
+	Args     map[string]string `json:"args,omitempty"`
+}
+
+// Encodes spans as complete events of the Chrome trace event format, viewable in
+// chrome://tracing or https://ui.perfetto.dev.
+func (t *tracer) toChrome() map[string]any {
+	events := make([]chromeEvent, len(t.spans))
+	for i, s := range t.spans {
+		events[i] = chromeEvent{
+			Name:     s.Name,
+			Category: s.Category,
+			Phase:    "X",
+			Ts:       s.Start.Sub(t.epoch).Microseconds(),
+			Dur:      s.End.Sub(s.Start).Microseconds(),
+			Pid:      os.Getpid(),
+			Tid:      1,
+			Args:     s.attrs(),
+		}
+	}
+	return map[string]any{"traceEvents": events, "displayTimeUnit": "ms"}
+}
+
+type otelAttribute struct {
+	Key   string            `json:"key"`
+	Value map[string]string `json:"value"`
+}
+
+type otelSpan struct {
+	TraceId           string          `json:"traceId"`
+	SpanId            string          `json:"spanId"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otelAttribute `json:"attributes,omitempty"`
+	Status            map[string]any  `json:"status"`
+}
+
+// Encodes spans as an OTLP JSON export request, which can be sent to any
+// OpenTelemetry collector.
+func (t *tracer) toOtel() map[string]any {
+	traceId := randomHex(16)
+	spans := make([]otelSpan, len(t.spans))
+	for i, s := range t.spans {
+		attrs := []otelAttribute{{Key: "category", Value: map[string]string{"stringValue": s.Category}}}
+		for k, v := range s.Attrs {
+			attrs = append(attrs, otelAttribute{Key: k, Value: map[string]string{"stringValue": v}})
+		}
+		// Status codes: 1 is ok, 2 is error
+		status := map[string]any{"code": 1}
+		if s.Err != nil {
+			status = map[string]any{"code": 2, "message": s.Err.Error()}
+		}
+		spans[i] = otelSpan{
+			TraceId:           traceId,
+			SpanId:            randomHex(8),
+			Name:              s.Name,
+			Kind:              1,
+			StartTimeUnixNano: strconv.FormatInt(s.Start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.End.UnixNano(), 10),
+			Attributes:        attrs,
+			Status:            status,
+		}
+	}
+	return map[string]any{"resourceSpans": []map[string]any{{
+		"resource": map[string]any{"attributes": []otelAttribute{
+			{Key: "service.name", Value: map[string]string{"stringValue": "supabase-cli"}},
+			{Key: "service.version", Value: map[string]string{"stringValue": Version}},
+		}},
+		"scopeSpans": []map[string]any{{
+			"scope": map[string]string{"name": "github.com/supabase/cli"},
+			"spans": spans,
+		}},
+	}}}
+}
+
+func (s *Span) attrs() map[string]string {
+	if s.Err == nil {
+		return s.Attrs
+	}
+	result := map[string]string{"error": s.Err.Error()}
+	for k, v := range s.Attrs {
+		result[k] = v
+	}
+	return result
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteTrace(t *testing.T) {
+	t.Run("skips span when disabled", func(t *testing.T) {
+		span := StartSpan("docker", "pull")
+		span.SetAttr("key", "value")
+		span.Finish(nil)
+		// Check error
+		assert.Nil(t, span)
+	})
+
+	globalTracer = &tracer{}
+	t.Cleanup(func() { globalTracer = nil })
+	span := StartSpan("functions", "bundle hello")
+	span.SetAttr("size", "42")
+	span.Finish(errors.New("bundle failed"))
+
+	t.Run("writes chrome trace", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := WriteTrace("trace.json", TraceFormatChrome, fsys)
+		// Check error
+		assert.NoError(t, err)
+		contents, err := afero.ReadFile(fsys, "trace.json")
+		require.NoError(t, err)
+		var trace struct {
+			TraceEvents []chromeEvent `json:"traceEvents"`
+		}
+		require.NoError(t, json.Unmarshal(contents, &trace))
+		require.Len(t, trace.TraceEvents, 1)
+		assert.Equal(t, "bundle hello", trace.TraceEvents[0].Name)
+		assert.Equal(t, "X", trace.TraceEvents[0].Phase)
+		assert.Equal(t, map[string]string{"size": "42", "error": "bundle failed"}, trace.TraceEvents[0].Args)
+	})
+
+	t.Run("writes otel trace", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := WriteTrace("trace.json", TraceFormatOtel, fsys)
+		// Check error
+		assert.NoError(t, err)
+		contents, err := afero.ReadFile(fsys, "trace.json")
+		require.NoError(t, err)
+		assert.Contains(t, string(contents), `"name": "bundle hello"`)
+		assert.Contains(t, string(contents), `"message": "bundle failed"`)
+	})
+}
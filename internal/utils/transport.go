@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/go-errors/errors"
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultApiMaxRetries = 3
+	defaultApiBackoff    = 500 * time.Millisecond
+)
+
+// Returns the management API request timeout from SUPABASE_API_TIMEOUT, eg. 30s.
+// Zero means no timeout.
+func GetApiTimeout() time.Duration {
+	return viper.GetDuration("API_TIMEOUT")
+}
+
+// Creates an exponential backoff policy for retrying management API requests,
+// configurable via SUPABASE_API_MAX_RETRIES and SUPABASE_API_RETRY_BACKOFF.
+func NewApiBackoff(ctx context.Context) backoff.BackOffContext {
+	maxRetries := uint64(defaultApiMaxRetries)
+	if viper.IsSet("API_MAX_RETRIES") {
+		maxRetries = uint64(viper.GetUint("API_MAX_RETRIES"))
+	}
+	b := backoff.NewExponentialBackOff()
+	if interval := viper.GetDuration("API_RETRY_BACKOFF"); interval > 0 {
+		b.InitialInterval = interval
+	} else {
+		b.InitialInterval = defaultApiBackoff
+	}
+	b.Reset()
+	return backoff.WithContext(backoff.WithMaxRetries(b, maxRetries), ctx)
+}
+
+// Applies proxy and custom CA settings to the default transport. Proxies are read from
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY. Additional root certificates, such as those of a
+// TLS intercepting corporate proxy, are loaded from the PEM file at SUPABASE_CA_CERT.
+func configureTransport(t *http.Transport) error {
+	t.Proxy = http.ProxyFromEnvironment
+	caPath := viper.GetString("CA_CERT")
+	if len(caPath) == 0 {
+		return nil
+	}
+	pem, err := os.ReadFile(caPath)
+	if err != nil {
+		return errors.Errorf("failed to read CA bundle: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return errors.Errorf("failed to parse CA bundle: no certificates found in %s", caPath)
+	}
+	if t.TLSClientConfig == nil {
+		t.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	t.TLSClientConfig.RootCAs = pool
+	return nil
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/go-errors/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApiBackoff(t *testing.T) {
+	t.Run("retries up to configured limit", func(t *testing.T) {
+		viper.Set("API_MAX_RETRIES", 1)
+		viper.Set("API_RETRY_BACKOFF", time.Millisecond)
+		t.Cleanup(func() {
+			viper.Set("API_MAX_RETRIES", nil)
+			viper.Set("API_RETRY_BACKOFF", nil)
+		})
+		attempts := 0
+		// Run test
+		err := backoff.Retry(func() error {
+			attempts++
+			return errors.New("network error")
+		}, NewApiBackoff(context.Background()))
+		// Check error
+		assert.ErrorContains(t, err, "network error")
+		assert.Equal(t, 2, attempts)
+	})
+}
+
+func TestConfigureTransport(t *testing.T) {
+	t.Run("skips CA bundle when unset", func(t *testing.T) {
+		transport := &http.Transport{}
+		// Run test
+		err := configureTransport(transport)
+		// Check error
+		assert.NoError(t, err)
+		assert.Nil(t, transport.TLSClientConfig)
+		assert.NotNil(t, transport.Proxy)
+	})
+
+	t.Run("throws error on missing CA bundle", func(t *testing.T) {
+		viper.Set("CA_CERT", filepath.Join(t.TempDir(), "missing.pem"))
+		t.Cleanup(func() { viper.Set("CA_CERT", "") })
+		// Run test
+		err := configureTransport(&http.Transport{})
+		// Check error
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("throws error on invalid CA bundle", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "ca.pem")
+		require.NoError(t, os.WriteFile(path, []byte("malformed"), 0644))
+		viper.Set("CA_CERT", path)
+		t.Cleanup(func() { viper.Set("CA_CERT", "") })
+		// Run test
+		err := configureTransport(&http.Transport{})
+		// Check error
+		assert.ErrorContains(t, err, "no certificates found in "+path)
+	})
+}
@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+)
+
+// WorkspacesManifestPath is the monorepo manifest listing Supabase project directories.
+const WorkspacesManifestPath = "supabase.workspaces.toml"
+
+type WorkspacesManifest struct {
+	// Maps workspace names to project directories relative to the manifest
+	Workspaces map[string]string `toml:"workspaces"`
+}
+
+// Returns the directory of the nearest workspaces manifest, searching upward from absPath.
+func findWorkspacesRoot(absPath string, fsys afero.Fs) (string, error) {
+	for cwd := absPath; ; cwd = filepath.Dir(cwd) {
+		path := filepath.Join(cwd, WorkspacesManifestPath)
+		if exists, err := afero.Exists(fsys, path); exists {
+			return cwd, nil
+		} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", errors.Errorf("failed to find workspaces manifest: %w", err)
+		}
+		if isRootDirectory(cwd) {
+			break
+		}
+	}
+	return "", errors.Errorf("cannot find %s in %s or any parent directory", WorkspacesManifestPath, Bold(absPath))
+}
+
+func LoadWorkspacesManifest(root string, fsys afero.Fs) (WorkspacesManifest, error) {
+	var manifest WorkspacesManifest
+	path := filepath.Join(root, WorkspacesManifestPath)
+	data, err := afero.ReadFile(fsys, path)
+	if err != nil {
+		return manifest, errors.Errorf("failed to read workspaces manifest: %w", err)
+	}
+	if _, err := toml.Decode(string(data), &manifest); err != nil {
+		return manifest, errors.Errorf("failed to parse workspaces manifest: %w", err)
+	}
+	for name, dir := range manifest.Workspaces {
+		if !isLocalPath(dir) {
+			return manifest, errors.Errorf("Invalid path for workspace %s: %s must be a relative path inside %s", name, Bold(dir), Bold(root))
+		}
+	}
+	return manifest, nil
+}
+
+// Reports whether path is relative and does not escape its parent directory.
+func isLocalPath(path string) bool {
+	if len(path) == 0 || filepath.IsAbs(path) || filepath.VolumeName(path) != "" {
+		return false
+	}
+	cleaned := filepath.Clean(path)
+	return cleaned != ".." && !strings.HasPrefix(cleaned, ".."+string(filepath.Separator))
+}
+
+// ResolveWorkspace returns the absolute project directory of a workspace, referenced either by
+// its name or its path in the manifest.
+func ResolveWorkspace(workspace string, fsys afero.Fs) (string, error) {
+	root, err := findWorkspacesRoot(CurrentDirAbs, fsys)
+	if err != nil {
+		return "", err
+	}
+	manifest, err := LoadWorkspacesManifest(root, fsys)
+	if err != nil {
+		return "", err
+	}
+	names := make([]string, 0, len(manifest.Workspaces))
+	for name, dir := range manifest.Workspaces {
+		if name == workspace || filepath.Clean(dir) == filepath.Clean(workspace) {
+			return filepath.Join(root, dir), nil
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return "", errors.Errorf("Unknown workspace %s. Must be one of: %v", Bold(workspace), names)
+}
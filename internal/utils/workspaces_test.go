@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveWorkspace(t *testing.T) {
+	root := filepath.Join(string(filepath.Separator), "repo")
+	manifest := []byte(`[workspaces]
+api = "apps/api"
+web = "apps/web"
+`)
+	cwd := CurrentDirAbs
+	t.Cleanup(func() { CurrentDirAbs = cwd })
+
+	t.Run("resolves workspace by name", func(t *testing.T) {
+		CurrentDirAbs = filepath.Join(root, "apps", "web", "src")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(root, WorkspacesManifestPath), manifest, 0644))
+		// Run test
+		workdir, err := ResolveWorkspace("api", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(root, "apps", "api"), workdir)
+	})
+
+	t.Run("resolves workspace by path", func(t *testing.T) {
+		CurrentDirAbs = root
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(root, WorkspacesManifestPath), manifest, 0644))
+		// Run test
+		workdir, err := ResolveWorkspace("apps/web/", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(root, "apps", "web"), workdir)
+	})
+
+	t.Run("throws error on unknown workspace", func(t *testing.T) {
+		CurrentDirAbs = root
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(root, WorkspacesManifestPath), manifest, 0644))
+		// Run test
+		_, err := ResolveWorkspace("docs", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Must be one of: [api web]")
+	})
+
+	t.Run("throws error on path outside root", func(t *testing.T) {
+		CurrentDirAbs = root
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(root, WorkspacesManifestPath), []byte(`[workspaces]
+api = "../other/api"
+`), 0644))
+		// Run test
+		_, err := ResolveWorkspace("api", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid path for workspace api:")
+	})
+
+	t.Run("throws error on missing manifest", func(t *testing.T) {
+		CurrentDirAbs = root
+		// Run test
+		_, err := ResolveWorkspace("api", afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "cannot find "+WorkspacesManifestPath)
+	})
+}
@@ -0,0 +1,28 @@
+package create
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const CREATE_VAULT_SECRET = "SELECT vault.create_secret($1, $2, $3)::text"
+
+func Run(ctx context.Context, name, secret, description string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	var id string
+	if err := conn.QueryRow(ctx, CREATE_VAULT_SECRET, secret, name, description).Scan(&id); err != nil {
+		return errors.Errorf("failed to create vault secret: %w", err)
+	}
+	fmt.Println("Created vault secret " + utils.Aqua(name) + ": " + id)
+	return nil
+}
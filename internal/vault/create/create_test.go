@@ -0,0 +1,46 @@
+package create
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/pgtest"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestCreateVaultSecret(t *testing.T) {
+	t.Run("creates vault secret", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(CREATE_VAULT_SECRET, "sk_test", "stripe_key", "").
+			Reply("SELECT 1", []interface{}{"8a3fbc05-2ad7-4e0a-9f4e-3c0e3c6cbd2b"})
+		// Run test
+		err := Run(context.Background(), "stripe_key", "sk_test", "", dbConfig, afero.NewMemMapFs(), conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on duplicate name", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(CREATE_VAULT_SECRET, "sk_test", "stripe_key", "").
+			ReplyError(pgerrcode.UniqueViolation, `duplicate key value violates unique constraint "secrets_name_idx"`)
+		// Run test
+		err := Run(context.Background(), "stripe_key", "sk_test", "", dbConfig, afero.NewMemMapFs(), conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "failed to create vault secret:")
+	})
+}
@@ -0,0 +1,35 @@
+package delete
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const DELETE_VAULT_SECRETS = "DELETE FROM vault.secrets WHERE name = ANY($1)"
+
+func Run(ctx context.Context, names []string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	msg := fmt.Sprintf("Do you want to delete these vault secrets?\n • %s\n\n", strings.Join(names, "\n • "))
+	if shouldDelete, err := utils.NewConsole().PromptYesNo(ctx, msg, true); err != nil {
+		return err
+	} else if !shouldDelete {
+		return errors.New(context.Canceled)
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	tag, err := conn.Exec(ctx, DELETE_VAULT_SECRETS, names)
+	if err != nil {
+		return errors.Errorf("failed to delete vault secrets: %w", err)
+	}
+	fmt.Printf("Deleted %d vault secret(s).\n", tag.RowsAffected())
+	return nil
+}
@@ -0,0 +1,56 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/pgxv5"
+)
+
+const LIST_VAULT_SECRETS = `SELECT id::text AS id, coalesce(name, '') AS name, coalesce(description, '') AS description, updated_at::text AS updated_at
+FROM vault.secrets ORDER BY name`
+
+type Result struct {
+	Id          string
+	Name        string
+	Description string
+	Updated_at  string
+}
+
+func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	secrets, err := ListVaultSecrets(ctx, conn)
+	if err != nil {
+		return err
+	}
+	table := "|ID|NAME|DESCRIPTION|UPDATED AT (UTC)|\n|-|-|-|-|\n"
+	for _, s := range secrets {
+		table += fmt.Sprintf(
+			"|`%s`|`%s`|%s|`%s`|\n",
+			s.Id,
+			strings.ReplaceAll(s.Name, "|", "\\|"),
+			strings.ReplaceAll(s.Description, "|", "\\|"),
+			s.Updated_at,
+		)
+	}
+	return list.RenderTable(table)
+}
+
+func ListVaultSecrets(ctx context.Context, conn *pgx.Conn) ([]Result, error) {
+	rows, err := conn.Query(ctx, LIST_VAULT_SECRETS)
+	if err != nil {
+		return nil, errors.Errorf("failed to list vault secrets: %w", err)
+	}
+	return pgxv5.CollectRows[Result](rows)
+}
@@ -0,0 +1,51 @@
+package list
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/pgtest"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestListVaultSecrets(t *testing.T) {
+	t.Run("lists vault secrets", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(LIST_VAULT_SECRETS).
+			Reply("SELECT 1", Result{
+				Id:          "8a3fbc05-2ad7-4e0a-9f4e-3c0e3c6cbd2b",
+				Name:        "stripe_key",
+				Description: "Stripe API key",
+				Updated_at:  "2024-05-01 00:00:00+00",
+			})
+		// Run test
+		err := Run(context.Background(), dbConfig, afero.NewMemMapFs(), conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on missing extension", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(LIST_VAULT_SECRETS).
+			ReplyError(pgerrcode.UndefinedTable, `relation "vault.secrets" does not exist`)
+		// Run test
+		err := Run(context.Background(), dbConfig, afero.NewMemMapFs(), conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, `ERROR: relation "vault.secrets" does not exist (SQLSTATE 42P01)`)
+	})
+}
@@ -0,0 +1,35 @@
+package update
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Null arguments are left unchanged by vault.update_secret
+const UPDATE_VAULT_SECRET = "SELECT vault.update_secret(id, $2, NULL, $3) FROM vault.secrets WHERE name = $1"
+
+func Run(ctx context.Context, name string, secret, description *string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if secret == nil && description == nil {
+		return errors.New("Nothing to update. Specify a new secret value or --description.")
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	tag, err := conn.Exec(ctx, UPDATE_VAULT_SECRET, name, secret, description)
+	if err != nil {
+		return errors.Errorf("failed to update vault secret: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.Errorf("Vault secret not found: %s", name)
+	}
+	fmt.Println("Updated vault secret " + utils.Aqua(name) + ".")
+	return nil
+}
@@ -0,0 +1,19 @@
+package update
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateVaultSecret(t *testing.T) {
+	t.Run("throws error on nothing to update", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), "stripe_key", nil, nil, pgconn.Config{}, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Nothing to update.")
+	})
+}
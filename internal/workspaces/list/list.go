@@ -0,0 +1,77 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+)
+
+type Workspace struct {
+	ProjectId string
+	Workdir   string
+	Running   int
+	Total     int
+}
+
+func Run(ctx context.Context) error {
+	containers, err := utils.Docker.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", utils.CliProjectLabel)),
+	})
+	if err != nil {
+		return errors.Errorf("failed to list containers: %w", err)
+	}
+	workspaces := GroupByProject(containers)
+	if len(workspaces) == 0 {
+		fmt.Println("No local workspaces found.")
+		return nil
+	}
+	table := `|PROJECT ID|WORKDIR|CONTAINERS|STATUS|
+|-|-|-|-|
+`
+	for _, w := range workspaces {
+		status := "running"
+		if w.Running == 0 {
+			status = "stopped"
+		} else if w.Running < w.Total {
+			status = "degraded"
+		}
+		table += fmt.Sprintf("|`%s`|`%s`|`%d/%d`|`%s`|\n", w.ProjectId, w.Workdir, w.Running, w.Total, status)
+	}
+	return list.RenderTable(table)
+}
+
+func GroupByProject(containers []types.Container) []Workspace {
+	byProject := map[string]*Workspace{}
+	var projectIds []string
+	for _, c := range containers {
+		projectId := c.Labels[utils.CliProjectLabel]
+		w, ok := byProject[projectId]
+		if !ok {
+			w = &Workspace{ProjectId: projectId, Workdir: "-"}
+			byProject[projectId] = w
+			projectIds = append(projectIds, projectId)
+		}
+		// Containers started by older CLI versions are not labelled with workdir
+		if workdir, ok := c.Labels[utils.CliWorkdirLabel]; ok {
+			w.Workdir = workdir
+		}
+		if c.State == "running" {
+			w.Running++
+		}
+		w.Total++
+	}
+	sort.Strings(projectIds)
+	result := make([]Workspace, len(projectIds))
+	for i, id := range projectIds {
+		result[i] = *byProject[id]
+	}
+	return result
+}
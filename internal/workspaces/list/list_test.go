@@ -0,0 +1,69 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestGroupByProject(t *testing.T) {
+	containers := []types.Container{{
+		State:  "running",
+		Labels: map[string]string{utils.CliProjectLabel: "web", utils.CliWorkdirLabel: "/src/web"},
+	}, {
+		State:  "exited",
+		Labels: map[string]string{utils.CliProjectLabel: "web", utils.CliWorkdirLabel: "/src/web"},
+	}, {
+		State:  "running",
+		Labels: map[string]string{utils.CliProjectLabel: "api"},
+	}}
+	// Run test
+	workspaces := GroupByProject(containers)
+	// Check output
+	assert.Equal(t, []Workspace{
+		{ProjectId: "api", Workdir: "-", Running: 1, Total: 1},
+		{ProjectId: "web", Workdir: "/src/web", Running: 1, Total: 2},
+	}, workspaces)
+}
+
+func TestListWorkspaces(t *testing.T) {
+	t.Run("lists running workspaces", func(t *testing.T) {
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/json").
+			Reply(http.StatusOK).
+			JSON([]types.Container{{
+				State:  "running",
+				Labels: map[string]string{utils.CliProjectLabel: "web"},
+			}})
+		// Run test
+		err := Run(context.Background())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on docker failure", func(t *testing.T) {
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/json").
+			ReplyError(errors.New("network error"))
+		// Run test
+		err := Run(context.Background())
+		// Check error
+		assert.ErrorContains(t, err, "network error")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -14,6 +14,36 @@ const (
 	Oauth2Scopes = "oauth2.Scopes"
 )
 
+// Defines values for AddonType.
+const (
+	AuthMfaPhone    AddonType = "auth_mfa_phone"
+	AuthMfaWebAuthn AddonType = "auth_mfa_web_authn"
+	ComputeInstance AddonType = "compute_instance"
+	CustomDomain    AddonType = "custom_domain"
+	Ipv4            AddonType = "ipv4"
+	LogDrain        AddonType = "log_drain"
+	Pitr            AddonType = "pitr"
+)
+
+// Defines values for AddonVariantPriceInterval.
+const (
+	Hourly  AddonVariantPriceInterval = "hourly"
+	Monthly AddonVariantPriceInterval = "monthly"
+)
+
+// Defines values for AddonVariantPriceType.
+const (
+	Fixed AddonVariantPriceType = "fixed"
+	Usage AddonVariantPriceType = "usage"
+)
+
+// Defines values for ApiKeyResponseType.
+const (
+	ApiKeyResponseTypeLegacy      ApiKeyResponseType = "legacy"
+	ApiKeyResponseTypePublishable ApiKeyResponseType = "publishable"
+	ApiKeyResponseTypeSecret      ApiKeyResponseType = "secret"
+)
+
 // Defines values for BillingPlanId.
 const (
 	BillingPlanIdEnterprise BillingPlanId = "enterprise"
@@ -47,6 +77,12 @@ const (
 	RUNNINGMIGRATIONS BranchResponseStatus = "RUNNING_MIGRATIONS"
 )
 
+// Defines values for CreateApiKeyBodyType.
+const (
+	CreateApiKeyBodyTypePublishable CreateApiKeyBodyType = "publishable"
+	CreateApiKeyBodyTypeSecret      CreateApiKeyBodyType = "secret"
+)
+
 // Defines values for CreateProviderBodyType.
 const (
 	Saml CreateProviderBodyType = "saml"
@@ -99,6 +135,12 @@ const (
 	Xlarge    DesiredInstanceSize = "xlarge"
 )
 
+// Defines values for DiskAttributesType.
+const (
+	Gp3 DiskAttributesType = "gp3"
+	Io2 DiskAttributesType = "io2"
+)
+
 // Defines values for FunctionResponseStatus.
 const (
 	FunctionResponseStatusACTIVE    FunctionResponseStatus = "ACTIVE"
@@ -350,10 +392,46 @@ type ActivateVanitySubdomainResponse struct {
 	CustomDomain string `json:"custom_domain"`
 }
 
+// AddonType defines model for AddonType.
+type AddonType string
+
+// AddonVariant defines model for AddonVariant.
+type AddonVariant struct {
+	Id    string            `json:"id"`
+	Name  string            `json:"name"`
+	Price AddonVariantPrice `json:"price"`
+}
+
+// AddonVariantPrice defines model for AddonVariantPrice.
+type AddonVariantPrice struct {
+	Amount      float32                   `json:"amount"`
+	Description string                    `json:"description"`
+	Interval    AddonVariantPriceInterval `json:"interval"`
+	Type        AddonVariantPriceType     `json:"type"`
+}
+
+// AddonVariantPriceInterval defines model for AddonVariantPrice.Interval.
+type AddonVariantPriceInterval string
+
+// AddonVariantPriceType defines model for AddonVariantPrice.Type.
+type AddonVariantPriceType string
+
 // ApiKeyResponse defines model for ApiKeyResponse.
 type ApiKeyResponse struct {
-	ApiKey string `json:"api_key"`
-	Name   string `json:"name"`
+	ApiKey      string              `json:"api_key"`
+	Description *string             `json:"description"`
+	Id          *string             `json:"id"`
+	Name        string              `json:"name"`
+	Type        *ApiKeyResponseType `json:"type"`
+}
+
+// ApiKeyResponseType defines model for ApiKeyResponse.Type.
+type ApiKeyResponseType string
+
+// ApplyProjectAddonBody defines model for ApplyProjectAddonBody.
+type ApplyProjectAddonBody struct {
+	AddonType    AddonType `json:"addon_type"`
+	AddonVariant string    `json:"addon_variant"`
 }
 
 // AttributeMapping defines model for AttributeMapping.
@@ -551,6 +629,13 @@ type AuthHealthResponse struct {
 	Version     string `json:"version"`
 }
 
+// AvailableAddon defines model for AvailableAddon.
+type AvailableAddon struct {
+	Name     string         `json:"name"`
+	Type     AddonType      `json:"type"`
+	Variants []AddonVariant `json:"variants"`
+}
+
 // BillingPlanId defines model for BillingPlanId.
 type BillingPlanId string
 
@@ -599,6 +684,15 @@ type BranchResponse struct {
 // BranchResponseStatus defines model for BranchResponse.Status.
 type BranchResponseStatus string
 
+// CreateApiKeyBody defines model for CreateApiKeyBody.
+type CreateApiKeyBody struct {
+	Description *string              `json:"description"`
+	Type        CreateApiKeyBodyType `json:"type"`
+}
+
+// CreateApiKeyBodyType defines model for CreateApiKeyBody.Type.
+type CreateApiKeyBodyType string
+
 // CreateBranchBody defines model for CreateBranchBody.
 type CreateBranchBody struct {
 	BranchName string  `json:"branch_name"`
@@ -634,6 +728,18 @@ type CreateProviderResponse struct {
 	UpdatedAt *string         `json:"updated_at,omitempty"`
 }
 
+// CreateRoleBody defines model for CreateRoleBody.
+type CreateRoleBody struct {
+	ReadOnly bool `json:"read_only"`
+}
+
+// CreateRoleResponse defines model for CreateRoleResponse.
+type CreateRoleResponse struct {
+	Password   string `json:"password"`
+	Role       string `json:"role"`
+	TtlSeconds int64  `json:"ttl_seconds"`
+}
+
 // CreateSecretBody defines model for CreateSecretBody.
 type CreateSecretBody struct {
 	// Name Secret name must not start with the SUPABASE_ prefix.
@@ -641,6 +747,13 @@ type CreateSecretBody struct {
 	Value string `json:"value"`
 }
 
+// CreateThirdPartyAuthBody defines model for CreateThirdPartyAuthBody.
+type CreateThirdPartyAuthBody struct {
+	CustomJwks    *map[string]interface{} `json:"custom_jwks,omitempty"`
+	JwksUrl       *string                 `json:"jwks_url,omitempty"`
+	OidcIssuerUrl *string                 `json:"oidc_issuer_url,omitempty"`
+}
+
 // DatabaseUpgradeStatus defines model for DatabaseUpgradeStatus.
 type DatabaseUpgradeStatus struct {
 	Error         *DatabaseUpgradeStatusError    `json:"error,omitempty"`
@@ -676,6 +789,28 @@ type DeleteProviderResponse struct {
 // DesiredInstanceSize defines model for DesiredInstanceSize.
 type DesiredInstanceSize string
 
+// DiskAttributes defines model for DiskAttributes.
+type DiskAttributes struct {
+	Iops            int                `json:"iops"`
+	SizeGb          int                `json:"size_gb"`
+	ThroughputMibps *int               `json:"throughput_mibps,omitempty"`
+	Type            DiskAttributesType `json:"type"`
+}
+
+// DiskAttributesType defines model for DiskAttributes.Type.
+type DiskAttributesType string
+
+// DiskRequestBody defines model for DiskRequestBody.
+type DiskRequestBody struct {
+	Attributes DiskAttributes `json:"attributes"`
+}
+
+// DiskResponse defines model for DiskResponse.
+type DiskResponse struct {
+	Attributes     DiskAttributes `json:"attributes"`
+	LastModifiedAt *string        `json:"last_modified_at,omitempty"`
+}
+
 // Domain defines model for Domain.
 type Domain struct {
 	CreatedAt *string `json:"created_at,omitempty"`
@@ -729,6 +864,12 @@ type GetProviderResponse struct {
 	UpdatedAt *string         `json:"updated_at,omitempty"`
 }
 
+// ListProjectAddonsResponse defines model for ListProjectAddonsResponse.
+type ListProjectAddonsResponse struct {
+	AvailableAddons []AvailableAddon `json:"available_addons"`
+	SelectedAddons  []SelectedAddon  `json:"selected_addons"`
+}
+
 // ListProvidersResponse defines model for ListProvidersResponse.
 type ListProvidersResponse struct {
 	Items []Provider `json:"items"`
@@ -866,6 +1007,12 @@ type ReadOnlyStatusResponse struct {
 	OverrideEnabled     bool   `json:"override_enabled"`
 }
 
+// RealtimeConfigResponse defines model for RealtimeConfigResponse.
+type RealtimeConfigResponse struct {
+	MaxConcurrentUsers int  `json:"max_concurrent_users"`
+	PrivateOnly        bool `json:"private_only"`
+}
+
 // RealtimeHealthResponse defines model for RealtimeHealthResponse.
 type RealtimeHealthResponse struct {
 	ConnectedCluster float32 `json:"connected_cluster"`
@@ -898,6 +1045,12 @@ type SecretResponse struct {
 	Value string `json:"value"`
 }
 
+// SelectedAddon defines model for SelectedAddon.
+type SelectedAddon struct {
+	Type    AddonType    `json:"type"`
+	Variant AddonVariant `json:"variant"`
+}
+
 // SetUpReadReplicaBody defines model for SetUpReadReplicaBody.
 type SetUpReadReplicaBody struct {
 	// ReadReplicaRegion Region you want your read replica to reside in
@@ -1014,6 +1167,19 @@ type SupavisorConfigResponseDatabaseType string
 // SupavisorConfigResponsePoolMode defines model for SupavisorConfigResponse.PoolMode.
 type SupavisorConfigResponsePoolMode string
 
+// ThirdPartyAuth defines model for ThirdPartyAuth.
+type ThirdPartyAuth struct {
+	CustomJwks    *map[string]interface{} `json:"custom_jwks"`
+	Id            string                  `json:"id"`
+	InsertedAt    string                  `json:"inserted_at"`
+	JwksUrl       *string                 `json:"jwks_url"`
+	OidcIssuerUrl *string                 `json:"oidc_issuer_url"`
+	ResolvedAt    *string                 `json:"resolved_at"`
+	ResolvedJwks  *map[string]interface{} `json:"resolved_jwks"`
+	Type          string                  `json:"type"`
+	UpdatedAt     string                  `json:"updated_at"`
+}
+
 // TypescriptResponse defines model for TypescriptResponse.
 type TypescriptResponse struct {
 	Types string `json:"types"`
@@ -1255,6 +1421,12 @@ type UpdateProviderResponse struct {
 	UpdatedAt *string         `json:"updated_at,omitempty"`
 }
 
+// UpdateRealtimeConfigBody defines model for UpdateRealtimeConfigBody.
+type UpdateRealtimeConfigBody struct {
+	MaxConcurrentUsers *int  `json:"max_concurrent_users,omitempty"`
+	PrivateOnly        *bool `json:"private_only,omitempty"`
+}
+
 // UpdateSupavisorConfigBody defines model for UpdateSupavisorConfigBody.
 type UpdateSupavisorConfigBody struct {
 	DefaultPoolSize *int `json:"default_pool_size"`
@@ -1507,6 +1679,12 @@ type V1AuthorizeUserParamsResponseType string
 // V1AuthorizeUserParamsCodeChallengeMethod defines parameters for V1AuthorizeUser.
 type V1AuthorizeUserParamsCodeChallengeMethod string
 
+// V1GetProjectApiKeysParams defines parameters for V1GetProjectApiKeys.
+type V1GetProjectApiKeysParams struct {
+	// Reveal Include the full value of secret keys
+	Reveal *bool `form:"reveal,omitempty" json:"reveal,omitempty"`
+}
+
 // CreateFunctionParams defines parameters for CreateFunction.
 type CreateFunctionParams struct {
 	Slug           *string `form:"slug,omitempty" json:"slug,omitempty"`
@@ -1564,9 +1742,18 @@ type V1CreateAnOrganizationJSONRequestBody = CreateOrganizationBodyV1
 // V1CreateAProjectJSONRequestBody defines body for V1CreateAProject for application/json ContentType.
 type V1CreateAProjectJSONRequestBody = V1CreateProjectBody
 
+// V1CreateProjectApiKeyJSONRequestBody defines body for V1CreateProjectApiKey for application/json ContentType.
+type V1CreateProjectApiKeyJSONRequestBody = CreateApiKeyBody
+
+// V1ApplyProjectAddonJSONRequestBody defines body for V1ApplyProjectAddon for application/json ContentType.
+type V1ApplyProjectAddonJSONRequestBody = ApplyProjectAddonBody
+
 // V1CreateABranchJSONRequestBody defines body for V1CreateABranch for application/json ContentType.
 type V1CreateABranchJSONRequestBody = CreateBranchBody
 
+// V1CreateLoginRoleJSONRequestBody defines body for V1CreateLoginRole for application/json ContentType.
+type V1CreateLoginRoleJSONRequestBody = CreateRoleBody
+
 // V1UpdateAuthServiceConfigJSONRequestBody defines body for V1UpdateAuthServiceConfig for application/json ContentType.
 type V1UpdateAuthServiceConfigJSONRequestBody = UpdateAuthConfigBody
 
@@ -1576,12 +1763,21 @@ type V1CreateASsoProviderJSONRequestBody = CreateProviderBody
 // V1UpdateASsoProviderJSONRequestBody defines body for V1UpdateASsoProvider for application/json ContentType.
 type V1UpdateASsoProviderJSONRequestBody = UpdateProviderBody
 
+// V1CreateProjectTpaIntegrationJSONRequestBody defines body for V1CreateProjectTpaIntegration for application/json ContentType.
+type V1CreateProjectTpaIntegrationJSONRequestBody = CreateThirdPartyAuthBody
+
 // V1UpdateSupavisorConfigJSONRequestBody defines body for V1UpdateSupavisorConfig for application/json ContentType.
 type V1UpdateSupavisorConfigJSONRequestBody = UpdateSupavisorConfigBody
 
 // V1UpdatePostgresConfigJSONRequestBody defines body for V1UpdatePostgresConfig for application/json ContentType.
 type V1UpdatePostgresConfigJSONRequestBody = UpdatePostgresConfigBody
 
+// V1ModifyProjectDiskConfigJSONRequestBody defines body for V1ModifyProjectDiskConfig for application/json ContentType.
+type V1ModifyProjectDiskConfigJSONRequestBody = DiskRequestBody
+
+// V1UpdateRealtimeConfigJSONRequestBody defines body for V1UpdateRealtimeConfig for application/json ContentType.
+type V1UpdateRealtimeConfigJSONRequestBody = UpdateRealtimeConfigBody
+
 // V1UpdateHostnameConfigJSONRequestBody defines body for V1UpdateHostnameConfig for application/json ContentType.
 type V1UpdateHostnameConfigJSONRequestBody = UpdateCustomHostnameBody
 
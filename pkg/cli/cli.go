@@ -0,0 +1,144 @@
+// Package cli exposes a stable facade over the Supabase CLI's commands so that
+// infrastructure tools can embed them without shelling out.
+//
+// Management API calls authenticate with SUPABASE_ACCESS_TOKEN or the token saved by
+// supabase login. All paths are resolved relative to the current working directory,
+// which should be the project root containing the supabase directory.
+package cli
+
+import (
+	"context"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/push"
+	"github.com/supabase/cli/internal/functions/deploy"
+	"github.com/supabase/cli/internal/link"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/credentials"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+type DeployFunctionsOptions struct {
+	// Project ref of the Supabase project to deploy to.
+	ProjectRef string
+	// Function slugs to deploy. All functions under supabase/functions are deployed if empty.
+	Slugs []string
+	// Overrides verify_jwt in config.toml when set.
+	NoVerifyJWT *bool
+	// Path to an import map shared by all functions.
+	ImportMapPath string
+	// Minimum severity of import audit findings that fails the deploy. Defaults to errors, in
+	// which case an unreachable advisory database is only reported as a warning.
+	FailOn string
+	// Filesystem to read project files from. Defaults to the OS filesystem.
+	Fsys afero.Fs
+}
+
+// DeployFunctions bundles and deploys edge functions to a remote project.
+func DeployFunctions(ctx context.Context, opts DeployFunctionsOptions) error {
+	if err := utils.AssertProjectRefIsValid(opts.ProjectRef); err != nil {
+		return err
+	}
+	return deploy.Run(ctx, opts.Slugs, opts.ProjectRef, opts.NoVerifyJWT, opts.ImportMapPath, opts.FailOn, getFs(opts.Fsys))
+}
+
+type PushMigrationsOptions struct {
+	// Project ref of the Supabase project to push to. Ignored if DbUrl is set.
+	ProjectRef string
+	// Database password of the project. Defaults to SUPABASE_DB_PASSWORD, then the password
+	// saved by LinkProject.
+	Password string
+	// Connection string of a database to push to instead of a Supabase project.
+	DbUrl string
+	// Prints the pending migrations without applying them.
+	DryRun bool
+	// Pushes migrations even if the remote history contains versions not found locally.
+	IgnoreVersionMismatch bool
+	// Creates custom roles from supabase/roles.sql.
+	IncludeRoles bool
+	// Seeds data from supabase/seed.sql.
+	IncludeSeed bool
+	// Order of push steps, ie. roles, migrations and seed.
+	Order []string
+	// Applies all steps in a single transaction.
+	Atomic bool
+	// Filesystem to read project files from. Defaults to the OS filesystem.
+	Fsys afero.Fs
+}
+
+// PushMigrations applies local migrations that are pending on the remote database.
+func PushMigrations(ctx context.Context, opts PushMigrationsOptions) error {
+	fsys := getFs(opts.Fsys)
+	var config pgconn.Config
+	if len(opts.DbUrl) > 0 {
+		parsed, err := pgconn.ParseConfig(opts.DbUrl)
+		if err != nil {
+			return errors.Errorf("failed to parse connection string: %w", err)
+		}
+		config = *parsed
+	} else {
+		if err := utils.AssertProjectRefIsValid(opts.ProjectRef); err != nil {
+			return err
+		}
+		if err := utils.LoadConfigFS(fsys); err != nil {
+			return err
+		}
+		password := getPassword(opts.ProjectRef, opts.Password)
+		if len(password) == 0 {
+			return errors.New("Missing database password: set Password or SUPABASE_DB_PASSWORD.")
+		}
+		config = flags.NewDbConfigFromPassword(opts.ProjectRef, password)
+	}
+	return push.Run(ctx, opts.DryRun, opts.IgnoreVersionMismatch, opts.IncludeRoles, opts.IncludeSeed, opts.Order, opts.Atomic, config, fsys)
+}
+
+type LinkProjectOptions struct {
+	// Project ref of the Supabase project to link.
+	ProjectRef string
+	// Database password of the project. Defaults to SUPABASE_DB_PASSWORD, then the password
+	// saved by a previous link. The database connection is not checked if neither is set.
+	Password string
+	// Skips reading service configs and versions from the management API.
+	SkipServices bool
+	// Filesystem to write project files to. Defaults to the OS filesystem.
+	Fsys afero.Fs
+}
+
+// LinkProject links the local project to a remote one, so that subsequent commands
+// can infer the project ref.
+func LinkProject(ctx context.Context, opts LinkProjectOptions) error {
+	if err := utils.AssertProjectRefIsValid(opts.ProjectRef); err != nil {
+		return err
+	}
+	fsys := getFs(opts.Fsys)
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	password := getPassword(opts.ProjectRef, opts.Password)
+	return link.RunWithPassword(ctx, opts.ProjectRef, password, opts.SkipServices, fsys)
+}
+
+func getFs(fsys afero.Fs) afero.Fs {
+	if fsys == nil {
+		return afero.NewOsFs()
+	}
+	return fsys
+}
+
+// Never prompts for password on stdin, which is unavailable to library callers, nor reads
+// global viper state, which would leak one call's password into the next.
+func getPassword(projectRef, password string) string {
+	if len(password) > 0 {
+		return password
+	}
+	if password := os.Getenv("SUPABASE_DB_PASSWORD"); len(password) > 0 {
+		return password
+	}
+	if password, err := credentials.Get(projectRef); err == nil {
+		return password
+	}
+	return ""
+}
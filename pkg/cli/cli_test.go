@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/credentials"
+	"github.com/zalando/go-keyring"
+)
+
+func TestFacadeValidation(t *testing.T) {
+	t.Run("throws error on invalid project ref", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := DeployFunctions(context.Background(), DeployFunctionsOptions{ProjectRef: "invalid", Fsys: fsys})
+		// Check error
+		assert.ErrorIs(t, err, utils.ErrInvalidRef)
+		err = LinkProject(context.Background(), LinkProjectOptions{ProjectRef: "invalid", Fsys: fsys})
+		assert.ErrorIs(t, err, utils.ErrInvalidRef)
+	})
+
+	t.Run("throws error on malformed db url", func(t *testing.T) {
+		// Run test
+		err := PushMigrations(context.Background(), PushMigrationsOptions{DbUrl: "postgres://:invalid", Fsys: afero.NewMemMapFs()})
+		// Check error
+		assert.ErrorContains(t, err, "failed to parse connection string:")
+	})
+}
+
+func TestGetPassword(t *testing.T) {
+	const ref = "abcdefghijklmnopqrst"
+
+	t.Run("prefers explicit password", func(t *testing.T) {
+		t.Setenv("SUPABASE_DB_PASSWORD", "env")
+		// Run test
+		assert.Equal(t, "explicit", getPassword(ref, "explicit"))
+	})
+
+	t.Run("reads password from env", func(t *testing.T) {
+		t.Setenv("SUPABASE_DB_PASSWORD", "env")
+		// Run test
+		assert.Equal(t, "env", getPassword(ref, ""))
+	})
+
+	t.Run("reads password from credentials", func(t *testing.T) {
+		t.Setenv("SUPABASE_DB_PASSWORD", "")
+		keyring.MockInit()
+		require.NoError(t, credentials.Set(ref, "saved"))
+		// Run test
+		assert.Equal(t, "saved", getPassword(ref, ""))
+	})
+
+	t.Run("returns empty without prompting", func(t *testing.T) {
+		t.Setenv("SUPABASE_DB_PASSWORD", "")
+		keyring.MockInit()
+		// Run test
+		assert.Empty(t, getPassword(ref, ""))
+	})
+}
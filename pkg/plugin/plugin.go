@@ -0,0 +1,72 @@
+// Package plugin exposes a small set of CLI helpers for building supabase-<name>
+// plugins, so that custom commands share the same auth, config and output conventions.
+package plugin
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/api"
+)
+
+// Supported output formats for EncodeOutput.
+const (
+	OutputEnv  = utils.OutputEnv
+	OutputJson = utils.OutputJson
+	OutputToml = utils.OutputToml
+	OutputYaml = utils.OutputYaml
+)
+
+// Init reads SUPABASE_* environment variables and changes to the project root,
+// mirroring what the CLI does before running any command.
+func Init() error {
+	viper.SetEnvPrefix("SUPABASE")
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
+	viper.AutomaticEnv()
+	return utils.ChangeWorkDir(afero.NewOsFs())
+}
+
+// CliVersion returns the version of the CLI that invoked this plugin.
+func CliVersion() string {
+	return os.Getenv("SUPABASE_CLI_VERSION")
+}
+
+// AccessToken returns the token saved by supabase login or SUPABASE_ACCESS_TOKEN.
+func AccessToken() (string, error) {
+	return utils.LoadAccessTokenFS(afero.NewOsFs())
+}
+
+// ProjectRef returns the project ref from SUPABASE_PROJECT_ID or the linked project.
+func ProjectRef() (string, error) {
+	if ref := viper.GetString("PROJECT_ID"); len(ref) > 0 {
+		return ref, utils.AssertProjectRefIsValid(ref)
+	}
+	return flags.LoadProjectRef(afero.NewOsFs())
+}
+
+// ProjectId loads supabase/config.toml and returns the local project id.
+func ProjectId() (string, error) {
+	if err := utils.LoadConfigFS(afero.NewOsFs()); err != nil {
+		return "", err
+	}
+	return utils.Config.ProjectId, nil
+}
+
+// ManagementClient returns an authenticated Management API client that honours the
+// same timeout, retry and proxy settings as the CLI.
+func ManagementClient() (*api.ClientWithResponses, error) {
+	if _, err := AccessToken(); err != nil {
+		return nil, err
+	}
+	return utils.GetSupabase(), nil
+}
+
+// EncodeOutput writes value to w in one of the CLI's machine readable formats.
+func EncodeOutput(format string, w io.Writer, value any) error {
+	return utils.EncodeOutput(format, w, value)
+}